@@ -1,16 +1,30 @@
 package cli
 
 const (
-	SocketPathFlagName             = "socketPath"
-	ConfigFlagName                 = "config"
-	TrustDomainFlagName            = "trustDomain"
-	TrustDomainAFlagName           = "trustDomainA"
-	TrustDomainBFlagName           = "trustDomainB"
-	TrustDomainDescriptionFlagName = "trustDomainDescription"
-	ConsentStatusFlagName          = "status"
-	ConsentStatusAFlagName         = "statusA"
-	ConsentStatusBFlagName         = "statusB"
-	TTLFlagName                    = "ttl"
-	RelationshipIDFlagName         = "relationshipID"
-	JoinTokenFlagName              = "joinToken"
+	SocketPathFlagName              = "socketPath"
+	ConfigFlagName                  = "config"
+	TrustDomainFlagName             = "trustDomain"
+	TrustDomainAFlagName            = "trustDomainA"
+	TrustDomainBFlagName            = "trustDomainB"
+	TrustDomainDescriptionFlagName  = "trustDomainDescription"
+	BundleVerifierFlagName          = "bundleVerifier"
+	ConsentStatusFlagName           = "status"
+	ConsentStatusAFlagName          = "statusA"
+	ConsentStatusBFlagName          = "statusB"
+	TTLFlagName                     = "ttl"
+	RelationshipIDFlagName          = "relationshipID"
+	JoinTokenFlagName               = "joinToken"
+	JoinTokenIDFlagName             = "joinTokenID"
+	UsedFlagName                    = "used"
+	ExpiredFlagName                 = "expired"
+	ShallowFlagName                 = "shallow"
+	VerboseFlagName                 = "verbose"
+	SinceFlagName                   = "since"
+	UntilFlagName                   = "until"
+	ActorFlagName                   = "actor"
+	VerifyFlagName                  = "verify"
+	QuarantinedBundleIDFlagName     = "bundleID"
+	MaxUsesFlagName                 = "maxUses"
+	HarvesterKeyFingerprintFlagName = "harvesterKeyFingerprint"
+	SourceCIDRFlagName              = "sourceCIDR"
 )
@@ -0,0 +1,262 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/cmd/common/cli"
+	"github.com/HewlettPackard/galadriel/cmd/harvester/util"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/api/admin"
+	"github.com/spf13/cobra"
+)
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Args:  cobra.ExactArgs(0),
+	Short: "Manage the federated bundles received by this Harvester",
+	Long: `
+The 'bundle' command allows you to manage the bundles of the federated trust domains
+that this Harvester receives from the Galadriel Server and sets in the SPIRE Server.
+`,
+}
+
+var resetBundleCmd = &cobra.Command{
+	Use:   "reset",
+	Args:  cobra.ExactArgs(0),
+	Short: "Approve a reset of the bundle of a federated trust domain",
+	Long: `
+The 'reset' command approves the next bundle received for a federated trust domain, even if
+it keeps no X.509 authority of the bundle currently set in the SPIRE Server and is not signed
+by a key chaining to it.
+
+It is only needed when the Harvester enforces the bundle continuity policy, e.g. after all the
+authorities of the SPIRE Server of the federated trust domain were replaced. The approval is
+consumed by the first bundle accepted thanks to it, and is lost if the Harvester restarts.
+`,
+	Example: "bundle reset --trustDomain <trustDomain>",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		trustDomainName, err := cmd.Flags().GetString(cli.TrustDomainFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get trust domain flag: %v", err)
+		}
+
+		client, err := util.NewUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		_, err = client.ApproveBundleReset(ctx, trustDomainName)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Reset of the bundle of Trust Domain %q approved\n", trustDomainName)
+
+		return nil
+	},
+}
+
+var quarantineBundleCmd = &cobra.Command{
+	Use:   "quarantine",
+	Args:  cobra.ExactArgs(0),
+	Short: "Manage the federated bundles rejected by this Harvester",
+	Long: `
+The 'quarantine' command allows you to manage the federated bundles that this Harvester rejected,
+because no BundleVerifier could verify them or they do not continue the bundle trusted by the
+SPIRE Server. The rejected bundles are kept in the data directory of the Harvester, with the
+reason of their rejection, until they are accepted or discarded.
+`,
+}
+
+var listQuarantinedBundlesCmd = &cobra.Command{
+	Use:     "list",
+	Args:    cobra.ExactArgs(0),
+	Short:   "List the quarantined bundles",
+	Example: "bundle quarantine list --trustDomain <trustDomain>",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		trustDomainName, err := cmd.Flags().GetString(cli.TrustDomainFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get trust domain flag: %v", err)
+		}
+
+		client, err := util.NewUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		bundles, err := client.ListQuarantinedBundles(ctx, trustDomainName)
+		if err != nil {
+			return err
+		}
+
+		if len(bundles) == 0 {
+			fmt.Println("No quarantined bundles found")
+			return nil
+		}
+
+		fmt.Println()
+		for _, b := range bundles {
+			fmt.Printf("%s\n", quarantinedBundleConsoleString(b))
+		}
+		fmt.Println()
+
+		return nil
+	},
+}
+
+var showQuarantinedBundleCmd = &cobra.Command{
+	Use:     "show",
+	Args:    cobra.ExactArgs(0),
+	Short:   "Show a quarantined bundle",
+	Example: "bundle quarantine show --bundleID <bundleID>",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, bundleID, err := quarantinedBundleCommandArgs(cmd)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		bundle, err := client.GetQuarantinedBundle(ctx, bundleID)
+		if err != nil {
+			return err
+		}
+
+		signed := "no"
+		if bundle.Signature != nil {
+			signed = "yes"
+		}
+
+		fmt.Printf("%s\n  Signed: %s\n  Trust Bundle: %s\n", quarantinedBundleConsoleString(bundle), signed, bundle.TrustBundle)
+
+		return nil
+	},
+}
+
+var acceptQuarantinedBundleCmd = &cobra.Command{
+	Use:   "accept",
+	Args:  cobra.ExactArgs(0),
+	Short: "Accept a quarantined bundle",
+	Long: `
+The 'accept' command sets a quarantined bundle in the SPIRE Server without verifying it, and removes
+it from the quarantine. The bundle must still be the current bundle of its trust domain in the
+Galadriel Server.
+
+Please exercise caution when accepting bundles, as the workloads of the SPIRE Server will trust
+the authorities of the bundle for the federated trust domain. Ensure that you verify the
+authenticity of the bundle before accepting it.
+`,
+	Example: "bundle quarantine accept --bundleID <bundleID>",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, bundleID, err := quarantinedBundleCommandArgs(cmd)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		bundle, err := client.AcceptQuarantinedBundle(ctx, bundleID)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Quarantined bundle of Trust Domain %q accepted\n", bundle.TrustDomainName)
+
+		return nil
+	},
+}
+
+var discardQuarantinedBundleCmd = &cobra.Command{
+	Use:     "discard",
+	Args:    cobra.ExactArgs(0),
+	Short:   "Discard a quarantined bundle",
+	Example: "bundle quarantine discard --bundleID <bundleID>",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, bundleID, err := quarantinedBundleCommandArgs(cmd)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		err = client.DiscardQuarantinedBundle(ctx, bundleID)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Quarantined bundle %q discarded\n", bundleID)
+
+		return nil
+	},
+}
+
+// quarantinedBundleCommandArgs returns the client and the quarantined bundle ID of a command managing a quarantined bundle.
+func quarantinedBundleCommandArgs(cmd *cobra.Command) (util.HarvesterAPIClient, admin.QuarantinedBundleID, error) {
+	socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot get socket path flag: %v", err)
+	}
+
+	bundleID, err := cmd.Flags().GetString(cli.QuarantinedBundleIDFlagName)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot get bundle ID flag: %v", err)
+	}
+
+	client, err := util.NewUDSClient(socketPath, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return client, bundleID, nil
+}
+
+// quarantinedBundleConsoleString formats a quarantined bundle and the reason of its rejection.
+func quarantinedBundleConsoleString(b *admin.QuarantinedBundle) string {
+	return fmt.Sprintf("Quarantined Bundle:\n  ID: %s\n  Trust Domain: %s\n  Quarantined At: %s\n  Reason: %s",
+		b.Id, b.TrustDomainName, b.QuarantinedAt.Format(time.RFC3339), b.Reason)
+}
+
+func init() {
+	RootCmd.AddCommand(bundleCmd)
+	bundleCmd.AddCommand(resetBundleCmd)
+	bundleCmd.AddCommand(quarantineBundleCmd)
+	quarantineBundleCmd.AddCommand(listQuarantinedBundlesCmd)
+	quarantineBundleCmd.AddCommand(showQuarantinedBundleCmd)
+	quarantineBundleCmd.AddCommand(acceptQuarantinedBundleCmd)
+	quarantineBundleCmd.AddCommand(discardQuarantinedBundleCmd)
+
+	listQuarantinedBundlesCmd.Flags().StringP(cli.TrustDomainFlagName, "t", "", "The federated trust domain name to filter the quarantined bundles by.")
+
+	for _, c := range []*cobra.Command{showQuarantinedBundleCmd, acceptQuarantinedBundleCmd, discardQuarantinedBundleCmd} {
+		c.Flags().StringP(cli.QuarantinedBundleIDFlagName, "i", "", "The ID of the quarantined bundle.")
+		if err := c.MarkFlagRequired(cli.QuarantinedBundleIDFlagName); err != nil {
+			fmt.Printf("cannot mark bundleID flag as required: %v", err)
+		}
+	}
+
+	resetBundleCmd.Flags().StringP(cli.TrustDomainFlagName, "t", "", "The federated trust domain name.")
+	err := resetBundleCmd.MarkFlagRequired(cli.TrustDomainFlagName)
+	if err != nil {
+		fmt.Printf("cannot mark trustDomain flag as required: %v", err)
+	}
+}
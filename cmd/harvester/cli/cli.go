@@ -1,12 +1,28 @@
 package cli
 
 import (
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/sirupsen/logrus"
 )
 
 const (
 	defaultSocketPath = "/tmp/galadriel-harvester/api.sock"
 	defaultConfigPath = "conf/harvester/harvester.conf"
+
+	defaultMetricsAddress = "0.0.0.0"
+	defaultMetricsPort    = 8089
+
+	defaultHealthChecksAddress = "0.0.0.0"
+	defaultHealthChecksPort    = 8090
+
+	defaultTracingExporter    = telemetry.TracingExporterOTLP
+	defaultTracingSampleRatio = 1.0
+
+	defaultFederatedBundlesWatchTimeout = time.Minute
+	// maxFederatedBundlesWatchTimeout is the longest wait accepted by the Galadriel Server
+	maxFederatedBundlesWatchTimeout = 5 * time.Minute
 )
 
 type harvesterCLI struct {
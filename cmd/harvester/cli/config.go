@@ -4,13 +4,19 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"net/url"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/common/health"
 	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/common/util"
 	"github.com/HewlettPackard/galadriel/pkg/harvester"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/bundlemanager"
 	"github.com/HewlettPackard/galadriel/pkg/harvester/catalog"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/spireclient"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
@@ -19,6 +25,9 @@ import (
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
 
+// instanceIDRegex restricts the instance ID to characters that are safe in file names, as it is part of the JWT token file name.
+var instanceIDRegex = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
 type Config struct {
 	Harvester *harvesterConfig `hcl:"harvester,block"`
 	Providers *providersBlock  `hcl:"providers,block"`
@@ -31,9 +40,62 @@ type harvesterConfig struct {
 	GaladrielServerAddress       string `hcl:"galadriel_server_address"`
 	ServerTrustBundlePath        string `hcl:"server_trust_bundle_path"`
 	FederatedBundlesPollInterval string `hcl:"federated_bundles_poll_interval,optional"`
+	FederatedBundlesWatchTimeout string `hcl:"federated_bundles_watch_timeout,optional"`
 	SpireBundlePollInterval      string `hcl:"spire_bundle_poll_interval,optional"`
 	LogLevel                     string `hcl:"log_level,optional"`
 	DataDir                      string `hcl:"data_dir"`
+	ValidateServerJWT            bool   `hcl:"validate_server_jwt,optional"`
+	ClientCertificateAuth        bool   `hcl:"client_certificate_auth,optional"`
+	InstanceID                   string `hcl:"instance_id,optional"`
+	BundleContinuity             bool   `hcl:"bundle_continuity,optional"`
+	QuarantineMaxBundles         int    `hcl:"quarantine_max_bundles,optional"`
+
+	FederationRelationships *federationRelationshipsConfig `hcl:"federation_relationships,block"`
+	FederatesWith           *federatesWithConfig           `hcl:"federates_with,block"`
+	Metrics                 *metricsConfig                 `hcl:"metrics,block"`
+	Tracing                 *tracingConfig                 `hcl:"tracing,block"`
+	HealthChecks            *healthChecksConfig            `hcl:"health_checks,block"`
+}
+
+// metricsConfig holds the configuration of the listener serving the metrics.
+type metricsConfig struct {
+	ListenAddress string `hcl:"listen_address,optional"`
+	ListenPort    int    `hcl:"listen_port,optional"`
+}
+
+// healthChecksConfig holds the configuration of the listener serving the health checks.
+type healthChecksConfig struct {
+	ListenAddress string `hcl:"listen_address,optional"`
+	ListenPort    int    `hcl:"listen_port,optional"`
+}
+
+// tracingConfig holds the configuration of the export of the spans.
+type tracingConfig struct {
+	Exporter    string   `hcl:"exporter,optional"`
+	Endpoint    string   `hcl:"endpoint,optional"`
+	Insecure    bool     `hcl:"insecure,optional"`
+	FilePath    string   `hcl:"file_path,optional"`
+	SampleRatio *float64 `hcl:"sample_ratio,optional"`
+}
+
+// federationRelationshipsConfig holds the configuration of the federation relationships managed in the SPIRE Server.
+type federationRelationshipsConfig struct {
+	BundleEndpointURL     string `hcl:"bundle_endpoint_url"`
+	BundleEndpointProfile string `hcl:"bundle_endpoint_profile,optional"`
+	EndpointSPIFFEID      string `hcl:"endpoint_spiffe_id,optional"`
+}
+
+// federatesWithConfig holds the configuration of the registration entries whose federated trust domains are managed.
+type federatesWithConfig struct {
+	SyncInterval string               `hcl:"sync_interval,optional"`
+	Policies     []*entryPolicyConfig `hcl:"entry_policy,block"`
+}
+
+// entryPolicyConfig selects registration entries by SPIFFE ID prefix and selectors, in the "type:value" form.
+type entryPolicyConfig struct {
+	SPIFFEIDPrefix string   `hcl:"spiffe_id_prefix,optional"`
+	Selectors      []string `hcl:"selectors,optional"`
+	TrustDomains   []string `hcl:"trust_domains,optional"`
 }
 
 // providersBlock holds the Providers HCL block body.
@@ -90,6 +152,18 @@ func NewHarvesterConfig(c *Config) (*harvester.Config, error) {
 		hc.FederatedBundlesPollInterval = federatedBundlesPollInterval
 	}
 
+	hc.FederatedBundlesWatchTimeout = defaultFederatedBundlesWatchTimeout
+	if c.Harvester.FederatedBundlesWatchTimeout != "" {
+		federatedBundlesWatchTimeout, err := time.ParseDuration(c.Harvester.FederatedBundlesWatchTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse federated bundles watch timeout: %v", err)
+		}
+		if federatedBundlesWatchTimeout < 0 || federatedBundlesWatchTimeout > maxFederatedBundlesWatchTimeout {
+			return nil, fmt.Errorf("federated bundles watch timeout must be between 0s and %s", maxFederatedBundlesWatchTimeout)
+		}
+		hc.FederatedBundlesWatchTimeout = federatedBundlesWatchTimeout
+	}
+
 	if c.Harvester.SpireBundlePollInterval != "" {
 		spireBundlePollInterval, err := time.ParseDuration(c.Harvester.SpireBundlePollInterval)
 		if err != nil {
@@ -114,6 +188,54 @@ func NewHarvesterConfig(c *Config) (*harvester.Config, error) {
 
 	hc.DataDir = c.Harvester.DataDir
 	hc.ServerTrustBundlePath = c.Harvester.ServerTrustBundlePath
+	hc.ValidateServerJWT = c.Harvester.ValidateServerJWT
+	hc.ClientCertificateAuth = c.Harvester.ClientCertificateAuth
+
+	if c.Harvester.InstanceID != "" && !instanceIDRegex.MatchString(c.Harvester.InstanceID) {
+		return nil, fmt.Errorf("invalid instance ID %q: only letters, digits, '.', '_' and '-' are allowed", c.Harvester.InstanceID)
+	}
+	hc.InstanceID = c.Harvester.InstanceID
+	hc.BundleContinuity = c.Harvester.BundleContinuity
+
+	if c.Harvester.QuarantineMaxBundles < 0 {
+		return nil, errors.New("quarantine max bundles must not be negative")
+	}
+	hc.QuarantineMaxBundles = c.Harvester.QuarantineMaxBundles
+
+	if c.Harvester.FederationRelationships != nil {
+		hc.FederationRelationships, err = newFederationRelationshipsConfig(c.Harvester.FederationRelationships)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse federation relationships configuration: %w", err)
+		}
+	}
+
+	if c.Harvester.FederatesWith != nil {
+		hc.FederatesWith, err = newFederatesWithConfig(c.Harvester.FederatesWith)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse federates with configuration: %w", err)
+		}
+	}
+
+	if c.Harvester.Metrics != nil {
+		hc.Metrics, err = newMetricsConfig(c.Harvester.Metrics)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse metrics configuration: %w", err)
+		}
+	}
+
+	if c.Harvester.Tracing != nil {
+		hc.Tracing, err = newTracingConfig(c.Harvester.Tracing)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse tracing configuration: %w", err)
+		}
+	}
+
+	if c.Harvester.HealthChecks != nil {
+		hc.HealthChecks, err = newHealthChecksConfig(c.Harvester.HealthChecks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse health checks configuration: %w", err)
+		}
+	}
 
 	hc.ProvidersConfig, err = catalog.ProvidersConfigsFromHCLBody(c.Providers.Body)
 	if err != nil {
@@ -123,6 +245,104 @@ func NewHarvesterConfig(c *Config) (*harvester.Config, error) {
 	return hc, nil
 }
 
+func newFederationRelationshipsConfig(c *federationRelationshipsConfig) (*bundlemanager.FederationRelationshipsConfig, error) {
+	bundleEndpointURL, err := url.Parse(c.BundleEndpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bundle endpoint URL: %v", err)
+	}
+	if bundleEndpointURL.Scheme != "https" {
+		return nil, fmt.Errorf("bundle endpoint URL must use the https scheme: %q", c.BundleEndpointURL)
+	}
+
+	fc := &bundlemanager.FederationRelationshipsConfig{
+		BundleEndpointURL:     bundleEndpointURL,
+		BundleEndpointProfile: spireclient.BundleEndpointProfile(c.BundleEndpointProfile),
+	}
+
+	if c.EndpointSPIFFEID != "" {
+		fc.EndpointSPIFFEID, err = spiffeid.FromString(c.EndpointSPIFFEID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse endpoint SPIFFE ID: %v", err)
+		}
+	}
+
+	return fc, nil
+}
+
+func newFederatesWithConfig(c *federatesWithConfig) (*bundlemanager.FederatesWithConfig, error) {
+	fc := &bundlemanager.FederatesWithConfig{}
+
+	if c.SyncInterval != "" {
+		syncInterval, err := time.ParseDuration(c.SyncInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse sync interval: %v", err)
+		}
+		fc.SyncInterval = syncInterval
+	}
+
+	for _, p := range c.Policies {
+		policy := &bundlemanager.EntryPolicy{
+			SPIFFEIDPrefix: p.SPIFFEIDPrefix,
+		}
+
+		for _, s := range p.Selectors {
+			selectorType, value, ok := strings.Cut(s, ":")
+			if !ok || selectorType == "" || value == "" {
+				return nil, fmt.Errorf("selector %q must be in the type:value form", s)
+			}
+			policy.Selectors = append(policy.Selectors, spireclient.Selector{Type: selectorType, Value: value})
+		}
+
+		for _, td := range p.TrustDomains {
+			trustDomain, err := spiffeid.TrustDomainFromString(td)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse trust domain %q: %v", td, err)
+			}
+			policy.TrustDomains = append(policy.TrustDomains, trustDomain)
+		}
+
+		fc.Policies = append(fc.Policies, policy)
+	}
+
+	return fc, nil
+}
+
+func newMetricsConfig(c *metricsConfig) (*telemetry.MetricsConfig, error) {
+	addrPort := fmt.Sprintf("%s:%d", c.ListenAddress, c.ListenPort)
+	tcpAddr, err := net.ResolveTCPAddr(constants.TCPProtocol, addrPort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve TCP address %s: %w", addrPort, err)
+	}
+
+	return &telemetry.MetricsConfig{Address: tcpAddr}, nil
+}
+
+func newHealthChecksConfig(c *healthChecksConfig) (*health.Config, error) {
+	addrPort := fmt.Sprintf("%s:%d", c.ListenAddress, c.ListenPort)
+	tcpAddr, err := net.ResolveTCPAddr(constants.TCPProtocol, addrPort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve TCP address %s: %w", addrPort, err)
+	}
+
+	return &health.Config{Address: tcpAddr}, nil
+}
+
+func newTracingConfig(c *tracingConfig) (*telemetry.TracingConfig, error) {
+	tc := &telemetry.TracingConfig{
+		Exporter:    telemetry.TracingExporter(c.Exporter),
+		Endpoint:    c.Endpoint,
+		Insecure:    c.Insecure,
+		FilePath:    c.FilePath,
+		SampleRatio: *c.SampleRatio,
+	}
+
+	if err := tc.Validate(); err != nil {
+		return nil, err
+	}
+
+	return tc, nil
+}
+
 func newConfig(configBytes []byte) (*Config, error) {
 	var config Config
 
@@ -152,4 +372,39 @@ func setDefaults(config *harvesterConfig) {
 	if config.LogLevel == "" {
 		config.LogLevel = constants.DefaultLogLevel
 	}
+
+	if config.FederationRelationships != nil && config.FederationRelationships.BundleEndpointProfile == "" {
+		config.FederationRelationships.BundleEndpointProfile = string(spireclient.HTTPSWebProfile)
+	}
+
+	if config.Metrics != nil {
+		if config.Metrics.ListenAddress == "" {
+			config.Metrics.ListenAddress = defaultMetricsAddress
+		}
+
+		if config.Metrics.ListenPort == 0 {
+			config.Metrics.ListenPort = defaultMetricsPort
+		}
+	}
+
+	if config.Tracing != nil {
+		if config.Tracing.Exporter == "" {
+			config.Tracing.Exporter = string(defaultTracingExporter)
+		}
+
+		if config.Tracing.SampleRatio == nil {
+			sampleRatio := defaultTracingSampleRatio
+			config.Tracing.SampleRatio = &sampleRatio
+		}
+	}
+
+	if config.HealthChecks != nil {
+		if config.HealthChecks.ListenAddress == "" {
+			config.HealthChecks.ListenAddress = defaultHealthChecksAddress
+		}
+
+		if config.HealthChecks.ListenPort == 0 {
+			config.HealthChecks.ListenPort = defaultHealthChecksPort
+		}
+	}
 }
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/spireclient"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,6 +23,7 @@ var hclConfig = `harvester {
     spire_bundle_poll_interval = "1h"
     log_level = "DEBUG"
 	data_dir = "/test"
+    bundle_continuity = true
 }
 `
 
@@ -50,6 +54,7 @@ func TestNew(t *testing.T) {
 					SpireBundlePollInterval:      "1h",
 					LogLevel:                     "DEBUG",
 					DataDir:                      "/test",
+					BundleContinuity:             true,
 				},
 			},
 		},
@@ -79,6 +84,32 @@ spire_bundle_poll_interval = "30m"
 				},
 			},
 		},
+		{
+			name: "federation_relationships",
+			config: bytes.NewBuffer([]byte(`harvester {
+trust_domain = "example.org"
+galadriel_server_address = "localhost:5000"
+server_trust_bundle_path = "./root_ca.crt"
+data_dir = "./data"
+federation_relationships {
+  bundle_endpoint_url = "https://galadriel.example.org:8443/bundle"
+}
+}`)),
+			expected: &Config{
+				Harvester: &harvesterConfig{
+					TrustDomain:            "example.org",
+					HarvesterSocketPath:    defaultSocketPath,
+					GaladrielServerAddress: "localhost:5000",
+					ServerTrustBundlePath:  "./root_ca.crt",
+					DataDir:                "./data",
+					LogLevel:               constants.DefaultLogLevel,
+					FederationRelationships: &federationRelationshipsConfig{
+						BundleEndpointURL:     "https://galadriel.example.org:8443/bundle",
+						BundleEndpointProfile: "https_web",
+					},
+				},
+			},
+		},
 		{
 			name:   "empty_config_file",
 			config: bytes.NewBufferString(``),
@@ -116,3 +147,99 @@ spire_bundle_poll_interval = "30m"
 		})
 	}
 }
+
+func TestNewFederationRelationshipsConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *federationRelationshipsConfig
+		err    string
+	}{
+		{
+			name: "https_web",
+			config: &federationRelationshipsConfig{
+				BundleEndpointURL:     "https://galadriel.example.org:8443/bundle",
+				BundleEndpointProfile: "https_web",
+			},
+		},
+		{
+			name: "https_spiffe",
+			config: &federationRelationshipsConfig{
+				BundleEndpointURL:     "https://galadriel.example.org:8443/bundle",
+				BundleEndpointProfile: "https_spiffe",
+				EndpointSPIFFEID:      "spiffe://galadriel.org/bundle-endpoint",
+			},
+		},
+		{
+			name: "err_url_scheme",
+			config: &federationRelationshipsConfig{
+				BundleEndpointURL:     "http://galadriel.example.org:8443/bundle",
+				BundleEndpointProfile: "https_web",
+			},
+			err: "bundle endpoint URL must use the https scheme",
+		},
+		{
+			name: "err_endpoint_spiffe_id",
+			config: &federationRelationshipsConfig{
+				BundleEndpointURL:     "https://galadriel.example.org:8443/bundle",
+				BundleEndpointProfile: "https_spiffe",
+				EndpointSPIFFEID:      "not-a-spiffe-id",
+			},
+			err: "failed to parse endpoint SPIFFE ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fc, err := newFederationRelationshipsConfig(tt.config)
+			if tt.err != "" {
+				assert.Nil(t, fc)
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.config.BundleEndpointURL, fc.BundleEndpointURL.String())
+			assert.Equal(t, tt.config.BundleEndpointProfile, string(fc.BundleEndpointProfile))
+			if tt.config.EndpointSPIFFEID != "" {
+				assert.Equal(t, tt.config.EndpointSPIFFEID, fc.EndpointSPIFFEID.String())
+			}
+		})
+	}
+}
+
+func TestNewFederatesWithConfig(t *testing.T) {
+	config, err := ParseConfig(bytes.NewBufferString(`harvester {
+trust_domain = "example.org"
+galadriel_server_address = "localhost:5000"
+server_trust_bundle_path = "./root_ca.crt"
+data_dir = "./data"
+federates_with {
+  sync_interval = "30s"
+  entry_policy {
+    spiffe_id_prefix = "spiffe://example.org/payments/"
+  }
+  entry_policy {
+    selectors = ["k8s:ns:billing", "k8s:sa:billing"]
+    trust_domains = ["partner.org"]
+  }
+}
+}`))
+	assert.NoError(t, err)
+
+	fc, err := newFederatesWithConfig(config.Harvester.FederatesWith)
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, fc.SyncInterval)
+	assert.Len(t, fc.Policies, 2)
+	assert.Equal(t, "spiffe://example.org/payments/", fc.Policies[0].SPIFFEIDPrefix)
+	assert.Equal(t, []spireclient.Selector{{Type: "k8s", Value: "ns:billing"}, {Type: "k8s", Value: "sa:billing"}}, fc.Policies[1].Selectors)
+	assert.Equal(t, []spiffeid.TrustDomain{spiffeid.RequireTrustDomainFromString("partner.org")}, fc.Policies[1].TrustDomains)
+
+	_, err = newFederatesWithConfig(&federatesWithConfig{Policies: []*entryPolicyConfig{{Selectors: []string{"k8s"}}}})
+	assert.EqualError(t, err, `selector "k8s" must be in the type:value form`)
+
+	_, err = newFederatesWithConfig(&federatesWithConfig{Policies: []*entryPolicyConfig{{TrustDomains: []string{"Invalid TD"}}}})
+	assert.ErrorContains(t, err, `failed to parse trust domain "Invalid TD"`)
+
+	_, err = newFederatesWithConfig(&federatesWithConfig{SyncInterval: "soon"})
+	assert.ErrorContains(t, err, "failed to parse sync interval")
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/HewlettPackard/galadriel/cmd/common/cli"
+	httputil "github.com/HewlettPackard/galadriel/cmd/common/http"
+	"github.com/HewlettPackard/galadriel/pkg/common/health"
+	"github.com/spf13/cobra"
+)
+
+var healthcheckCmd = &cobra.Command{
+	Use:   "healthcheck",
+	Args:  cobra.ExactArgs(0),
+	Short: "Checks the health of the Harvester",
+	Long: `
+The 'healthcheck' command checks that the Harvester is ready, calling its readiness endpoint
+through the API socket. The Harvester is ready when the SPIRE Server and the Galadriel Server are
+reachable, its JWT access token has not expired and the federated bundles were synced recently.
+
+With the --shallow flag, it only checks that the Harvester is alive, calling its liveness endpoint.
+It exits with a non-zero status if the Harvester is not healthy, so that it can be used by supervisors.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		shallow, err := cmd.Flags().GetBool(cli.ShallowFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get shallow flag: %v", err)
+		}
+
+		verbose, err := cmd.Flags().GetBool(cli.VerboseFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get verbose flag: %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), cli.CommandTimeout)
+		defer cancel()
+
+		return runHealthcheck(ctx, socketPath, shallow, verbose, cmd.OutOrStdout())
+	},
+}
+
+func runHealthcheck(ctx context.Context, socketPath string, shallow, verbose bool, out io.Writer) error {
+	path := health.ReadyPath
+	if shallow {
+		path = health.LivePath
+	}
+
+	report, err := health.Probe(ctx, httputil.NewUDSHTTPClient(socketPath), strings.TrimSuffix(cli.LocalhostURL, "/"), path)
+	if err != nil {
+		return fmt.Errorf("failed to check the health of the Harvester: %w", err)
+	}
+
+	if report.Status != health.StatusOK {
+		return fmt.Errorf("harvester is unhealthy: %s", report)
+	}
+
+	if verbose {
+		fmt.Fprintf(out, "Harvester is healthy: %s\n", report)
+		return nil
+	}
+
+	fmt.Fprintln(out, "Harvester is healthy.")
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(healthcheckCmd)
+
+	healthcheckCmd.Flags().Bool(cli.ShallowFlagName, false, "Only check that the Harvester is alive")
+	healthcheckCmd.Flags().Bool(cli.VerboseFlagName, false, "Print the status of each readiness check")
+}
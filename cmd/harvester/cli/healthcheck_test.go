@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/health"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunHealthcheck(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "api.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	checker := health.NewChecker()
+	server := echo.New()
+	checker.RegisterHandlers(server)
+	go func() {
+		_ = server.Server.Serve(listener)
+	}()
+	defer server.Close()
+
+	var out bytes.Buffer
+	err = runHealthcheck(context.Background(), socketPath, false, false, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "Harvester is healthy.\n", out.String())
+
+	checker.AddCheck("spire_server", func(context.Context) error {
+		return errors.New("failed to reach SPIRE Server")
+	})
+
+	out.Reset()
+	err = runHealthcheck(context.Background(), socketPath, false, false, &out)
+	assert.EqualError(t, err, "harvester is unhealthy: unavailable\n  spire_server: failed to reach SPIRE Server")
+	assert.Empty(t, out.String())
+
+	// the liveness does not depend on the readiness checks
+	err = runHealthcheck(context.Background(), socketPath, true, true, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "Harvester is healthy: ok\n", out.String())
+
+	err = runHealthcheck(context.Background(), filepath.Join(t.TempDir(), "missing.sock"), false, false, &out)
+	assert.ErrorContains(t, err, "failed to check the health of the Harvester")
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/HewlettPackard/galadriel/cmd/common/cli"
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/util/fileutil"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/galadrielclient"
+	"github.com/spf13/cobra"
+)
+
+var keyCmd = &cobra.Command{
+	Use:   "key",
+	Short: "Manage the Harvester key",
+}
+
+var keyFingerprintCmd = &cobra.Command{
+	Use:   "fingerprint",
+	Args:  cobra.ExactArgs(0),
+	Short: "Prints the fingerprint of the Harvester key",
+	Long: `
+The 'fingerprint' command prints the fingerprint of the key the Harvester uses to prove its identity
+when it onboards to the Galadriel Server. The key is kept in the data dir of the Harvester, it is
+generated if it does not exist yet, so that the fingerprint can be known before the first run.
+
+Set the fingerprint with --harvesterKeyFingerprint when generating a join token with the Galadriel Server,
+to bind the token to this Harvester.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configPath, err := cmd.Flags().GetString(cli.ConfigFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get flag config: %w", err)
+		}
+
+		configFile, err := os.Open(configPath)
+		if err != nil {
+			return fmt.Errorf("unable to open configuration file: %w", err)
+		}
+		defer configFile.Close()
+
+		c, err := ParseConfig(configFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse config file: %w", err)
+		}
+
+		hc, err := NewHarvesterConfig(c)
+		if err != nil {
+			return fmt.Errorf("failed to build harvester configuration: %w", err)
+		}
+
+		return printKeyFingerprint(hc.DataDir, hc.InstanceID, cmd.OutOrStdout())
+	},
+}
+
+func printKeyFingerprint(dataDir, instanceID string, out io.Writer) error {
+	if err := fileutil.CreateDirIfNotExist(dataDir); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	key, err := galadrielclient.LoadOrCreateHarvesterKey(dataDir, instanceID)
+	if err != nil {
+		return err
+	}
+
+	fingerprint, err := cryptoutil.PublicKeyFingerprint(key.Public())
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, fingerprint)
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(keyCmd)
+	keyCmd.AddCommand(keyFingerprintCmd)
+
+	keyFingerprintCmd.Flags().StringP(cli.ConfigFlagName, "c", defaultConfigPath, "Path to the Galadriel Harvester config file")
+}
@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/galadrielclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintKeyFingerprint(t *testing.T) {
+	dataDir := filepath.Join(t.TempDir(), "data")
+
+	var out bytes.Buffer
+	err := printKeyFingerprint(dataDir, "instance-1", &out)
+	require.NoError(t, err)
+
+	key, err := galadrielclient.LoadOrCreateHarvesterKey(dataDir, "instance-1")
+	require.NoError(t, err)
+	fingerprint, err := cryptoutil.PublicKeyFingerprint(key.Public())
+	require.NoError(t, err)
+	assert.Equal(t, fingerprint, strings.TrimSpace(out.String()))
+
+	// the fingerprint does not change once the key is generated
+	out.Reset()
+	err = printKeyFingerprint(dataDir, "instance-1", &out)
+	require.NoError(t, err)
+	assert.Equal(t, fingerprint+"\n", out.String())
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/cmd/common/cli"
 	"github.com/HewlettPackard/galadriel/cmd/harvester/util"
 	"github.com/HewlettPackard/galadriel/pkg/common/api"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/api/admin"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 )
@@ -128,6 +132,86 @@ before denying it.
 	},
 }
 
+var consentsRelationshipCmd = &cobra.Command{
+	Use:   "consents",
+	Args:  cobra.ExactArgs(0),
+	Short: "Show the signed consents to a relationship",
+	Long: `
+The 'consents' command shows the consents of the trust domains of a relationship, as signed by their
+Harvesters and stored by the Galadriel Server.
+
+Each consent statement states the relationship, the trust domain, its consent status and the time it was
+signed. The signature of each consent is verified with the bundle verifiers of this Harvester, so that
+the consent of the other trust domain can be audited as proof that it approved or denied the relationship.
+`,
+	Example: "relationship consents --relationshipID <relationshipID>",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		idStr, err := cmd.Flags().GetString(cli.RelationshipIDFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get relationship ID flag: %v", err)
+		}
+		relID, err := uuid.Parse(idStr)
+		if err != nil {
+			return fmt.Errorf("cannot parse relationship ID: %v", err)
+		}
+
+		client, err := util.NewUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		consents, err := client.GetRelationshipConsents(ctx, relID)
+		if err != nil {
+			return err
+		}
+
+		if len(consents) == 0 {
+			fmt.Println("No consents found")
+			return nil
+		}
+
+		fmt.Println()
+		for _, c := range consents {
+			fmt.Printf("%s\n", consentConsoleString(c))
+		}
+		fmt.Println()
+
+		return nil
+	},
+}
+
+// consentConsoleString formats the consent statement and the result of the verification of its signature.
+func consentConsoleString(c *admin.VerifiedRelationshipConsent) string {
+	verified := "yes"
+	if !c.Verified {
+		verified = "no"
+		if c.VerificationError != nil {
+			verified = fmt.Sprintf("no (%s)", *c.VerificationError)
+		}
+	}
+
+	data, err := encoding.DecodeFromBase64(c.Consent.ConsentStatement)
+	if err != nil {
+		return fmt.Sprintf("Consent:\n  Trust Domain ID: %s\n  Statement: cannot decode statement: %v\n  Verified: %s", c.Consent.TrustDomainId, err, verified)
+	}
+
+	statement, err := entity.ParseConsentStatement(data)
+	if err != nil {
+		return fmt.Sprintf("Consent:\n  Trust Domain ID: %s\n  Statement: %v\n  Verified: %s", c.Consent.TrustDomainId, err, verified)
+	}
+
+	return fmt.Sprintf("Consent:\n  Trust Domain: %s\n  Consent Status: %s\n  Signed At: %s\n  Verified: %s",
+		statement.TrustDomain, statement.ConsentStatus, statement.SignedAt.Format(time.RFC3339), verified)
+}
+
 func modifyRelationship(cmd *cobra.Command, args []string, action api.ConsentStatus) error {
 	socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
 	if err != nil {
@@ -172,6 +256,7 @@ func init() {
 	relationshipCmd.AddCommand(listRelationshipCmd)
 	relationshipCmd.AddCommand(approveRelationshipCmd)
 	relationshipCmd.AddCommand(denyRelationshipCmd)
+	relationshipCmd.AddCommand(consentsRelationshipCmd)
 
 	approveRelationshipCmd.Flags().StringP(cli.RelationshipIDFlagName, "r", "", "Relationship ID to approve")
 	err := approveRelationshipCmd.MarkFlagRequired(cli.RelationshipIDFlagName)
@@ -185,6 +270,12 @@ func init() {
 		fmt.Printf("cannot mark relationshipID flag as required: %v", err)
 	}
 
+	consentsRelationshipCmd.Flags().StringP(cli.RelationshipIDFlagName, "r", "", "Relationship ID to show the consents of")
+	err = consentsRelationshipCmd.MarkFlagRequired(cli.RelationshipIDFlagName)
+	if err != nil {
+		fmt.Printf("cannot mark relationshipID flag as required: %v", err)
+	}
+
 	listRelationshipCmd.Flags().StringP(cli.ConsentStatusFlagName, "s", "", fmt.Sprintf("Consent status to filter relationships by. Valid values: %s", strings.Join(cli.ValidConsentStatusValues, ", ")))
 	listRelationshipCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
 		status, err := cmd.Flags().GetString(cli.ConsentStatusFlagName)
@@ -3,9 +3,12 @@ package cli
 import (
 	"os"
 	"testing"
+	"time"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -48,3 +51,433 @@ providers {
 	assert.Equal(t, "/test.api", config.HarvesterSocketPath.String())
 	assert.Equal(t, "abc123", config.JoinToken)
 }
+
+func TestLoadConfigInstanceID(t *testing.T) {
+	tests := []struct {
+		name       string
+		instanceID string
+		err        string
+	}{
+		{
+			name:       "ok",
+			instanceID: "harvester-0.spire",
+		},
+		{
+			name:       "invalid",
+			instanceID: "../harvester",
+			err:        `invalid instance ID "../harvester"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempFile, err := os.CreateTemp("", "harvester.conf")
+			assert.NoError(t, err)
+			defer os.Remove(tempFile.Name())
+
+			_, err = tempFile.WriteString(`
+harvester {
+    trust_domain = "example.org"
+    galadriel_server_address = "localhost:5000"
+    server_trust_bundle_path = "./root_ca.crt"
+    data_dir = "/test"
+    instance_id = "` + tt.instanceID + `"
+}
+
+providers {
+    BundleSigner "noop" {}
+    BundleVerifier "noop" {}
+}
+`)
+			assert.NoError(t, err)
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("socketPath", "", "")
+			cmd.Flags().String("config", tempFile.Name(), "")
+			cmd.Flags().String("joinToken", "", "")
+
+			config, err := LoadConfig(cmd)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.instanceID, config.InstanceID)
+		})
+	}
+}
+
+func TestLoadConfigFederatedBundlesWatchTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		watchTimeout string
+		expected     time.Duration
+		err          string
+	}{
+		{
+			name:     "default",
+			expected: defaultFederatedBundlesWatchTimeout,
+		},
+		{
+			name:         "disabled",
+			watchTimeout: "0s",
+			expected:     0,
+		},
+		{
+			name:         "ok",
+			watchTimeout: "3m",
+			expected:     3 * time.Minute,
+		},
+		{
+			name:         "too_long",
+			watchTimeout: "10m",
+			err:          "federated bundles watch timeout must be between 0s and 5m0s",
+		},
+		{
+			name:         "invalid",
+			watchTimeout: "soon",
+			err:          "failed to parse federated bundles watch timeout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempFile, err := os.CreateTemp("", "harvester.conf")
+			assert.NoError(t, err)
+			defer os.Remove(tempFile.Name())
+
+			watchTimeout := ""
+			if tt.watchTimeout != "" {
+				watchTimeout = `federated_bundles_watch_timeout = "` + tt.watchTimeout + `"`
+			}
+
+			_, err = tempFile.WriteString(`
+harvester {
+    trust_domain = "example.org"
+    galadriel_server_address = "localhost:5000"
+    server_trust_bundle_path = "./root_ca.crt"
+    data_dir = "/test"
+    ` + watchTimeout + `
+}
+
+providers {
+    BundleSigner "noop" {}
+    BundleVerifier "noop" {}
+}
+`)
+			assert.NoError(t, err)
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("socketPath", "", "")
+			cmd.Flags().String("config", tempFile.Name(), "")
+			cmd.Flags().String("joinToken", "", "")
+
+			config, err := LoadConfig(cmd)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config.FederatedBundlesWatchTimeout)
+		})
+	}
+}
+
+func TestLoadConfigQuarantineMaxBundles(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxBundles string
+		expected   int
+		err        string
+	}{
+		{
+			name:     "default",
+			expected: 0,
+		},
+		{
+			name:       "ok",
+			maxBundles: "3",
+			expected:   3,
+		},
+		{
+			name:       "negative",
+			maxBundles: "-1",
+			err:        "quarantine max bundles must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempFile, err := os.CreateTemp("", "harvester.conf")
+			assert.NoError(t, err)
+			defer os.Remove(tempFile.Name())
+
+			maxBundles := ""
+			if tt.maxBundles != "" {
+				maxBundles = `quarantine_max_bundles = ` + tt.maxBundles
+			}
+
+			_, err = tempFile.WriteString(`
+harvester {
+    trust_domain = "example.org"
+    galadriel_server_address = "localhost:5000"
+    server_trust_bundle_path = "./root_ca.crt"
+    data_dir = "/test"
+    ` + maxBundles + `
+}
+
+providers {
+    BundleSigner "noop" {}
+    BundleVerifier "noop" {}
+}
+`)
+			assert.NoError(t, err)
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("socketPath", "", "")
+			cmd.Flags().String("config", tempFile.Name(), "")
+			cmd.Flags().String("joinToken", "", "")
+
+			config, err := LoadConfig(cmd)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config.QuarantineMaxBundles)
+		})
+	}
+}
+
+func TestLoadConfigMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		metrics  string
+		expected string
+		err      string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:     "defaults",
+			metrics:  `metrics {}`,
+			expected: "0.0.0.0:8089",
+		},
+		{
+			name: "ok",
+			metrics: `metrics {
+        listen_address = "127.0.0.1"
+        listen_port = 9100
+    }`,
+			expected: "127.0.0.1:9100",
+		},
+		{
+			name: "invalid",
+			metrics: `metrics {
+        listen_port = -1
+    }`,
+			err: "failed to parse metrics configuration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempFile, err := os.CreateTemp("", "harvester.conf")
+			assert.NoError(t, err)
+			defer os.Remove(tempFile.Name())
+
+			_, err = tempFile.WriteString(`
+harvester {
+    trust_domain = "example.org"
+    galadriel_server_address = "localhost:5000"
+    server_trust_bundle_path = "./root_ca.crt"
+    data_dir = "/test"
+    ` + tt.metrics + `
+}
+
+providers {
+    BundleSigner "noop" {}
+    BundleVerifier "noop" {}
+}
+`)
+			assert.NoError(t, err)
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("socketPath", "", "")
+			cmd.Flags().String("config", tempFile.Name(), "")
+			cmd.Flags().String("joinToken", "", "")
+
+			config, err := LoadConfig(cmd)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.expected == "" {
+				assert.Nil(t, config.Metrics)
+				return
+			}
+			require.NotNil(t, config.Metrics)
+			assert.Equal(t, tt.expected, config.Metrics.Address.String())
+		})
+	}
+}
+
+func TestLoadConfigHealthChecks(t *testing.T) {
+	tests := []struct {
+		name     string
+		checks   string
+		expected string
+		err      string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:     "defaults",
+			checks:   `health_checks {}`,
+			expected: "0.0.0.0:8090",
+		},
+		{
+			name: "ok",
+			checks: `health_checks {
+        listen_address = "127.0.0.1"
+        listen_port = 9100
+    }`,
+			expected: "127.0.0.1:9100",
+		},
+		{
+			name: "invalid",
+			checks: `health_checks {
+        listen_port = -1
+    }`,
+			err: "failed to parse health checks configuration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempFile, err := os.CreateTemp("", "harvester.conf")
+			assert.NoError(t, err)
+			defer os.Remove(tempFile.Name())
+
+			_, err = tempFile.WriteString(`
+harvester {
+    trust_domain = "example.org"
+    galadriel_server_address = "localhost:5000"
+    server_trust_bundle_path = "./root_ca.crt"
+    data_dir = "/test"
+    ` + tt.checks + `
+}
+
+providers {
+    BundleSigner "noop" {}
+    BundleVerifier "noop" {}
+}
+`)
+			assert.NoError(t, err)
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("socketPath", "", "")
+			cmd.Flags().String("config", tempFile.Name(), "")
+			cmd.Flags().String("joinToken", "", "")
+
+			config, err := LoadConfig(cmd)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.expected == "" {
+				assert.Nil(t, config.HealthChecks)
+				return
+			}
+			require.NotNil(t, config.HealthChecks)
+			assert.Equal(t, tt.expected, config.HealthChecks.Address.String())
+		})
+	}
+}
+
+func TestLoadConfigTracing(t *testing.T) {
+	tests := []struct {
+		name     string
+		tracing  string
+		expected *telemetry.TracingConfig
+		err      string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:    "defaults",
+			tracing: `tracing {}`,
+			expected: &telemetry.TracingConfig{
+				Exporter:    telemetry.TracingExporterOTLP,
+				SampleRatio: 1,
+			},
+		},
+		{
+			name: "file",
+			tracing: `tracing {
+        exporter = "file"
+        file_path = "/tmp/harvester-spans.json"
+        sample_ratio = 0
+    }`,
+			expected: &telemetry.TracingConfig{
+				Exporter: telemetry.TracingExporterFile,
+				FilePath: "/tmp/harvester-spans.json",
+			},
+		},
+		{
+			name: "invalid",
+			tracing: `tracing {
+        exporter = "zipkin"
+    }`,
+			err: "failed to parse tracing configuration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempFile, err := os.CreateTemp("", "harvester.conf")
+			assert.NoError(t, err)
+			defer os.Remove(tempFile.Name())
+
+			_, err = tempFile.WriteString(`
+harvester {
+    trust_domain = "example.org"
+    galadriel_server_address = "localhost:5000"
+    server_trust_bundle_path = "./root_ca.crt"
+    data_dir = "/test"
+    ` + tt.tracing + `
+}
+
+providers {
+    BundleSigner "noop" {}
+    BundleVerifier "noop" {}
+}
+`)
+			assert.NoError(t, err)
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("socketPath", "", "")
+			cmd.Flags().String("config", tempFile.Name(), "")
+			cmd.Flags().String("joinToken", "", "")
+
+			config, err := LoadConfig(cmd)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config.Tracing)
+		})
+	}
+}
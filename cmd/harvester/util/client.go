@@ -17,12 +17,21 @@ import (
 const (
 	errFailedRequest          = "failed to send request: %v"
 	errUnmarshalRelationships = "failed to unmarshal relationships: %v"
+	errUnmarshalConsents      = "failed to unmarshal relationship consents: %v"
+	errUnmarshalResetApproval = "failed to unmarshal bundle reset approval: %v"
+	errUnmarshalQuarantined   = "failed to unmarshal quarantined bundles: %v"
 )
 
 // HarvesterAPIClient represents an API client for the Harvester.
 type HarvesterAPIClient interface {
 	GetRelationships(context.Context, api.ConsentStatus) ([]*entity.Relationship, error)
 	UpdateRelationship(context.Context, uuid.UUID, api.ConsentStatus) (*entity.Relationship, error)
+	GetRelationshipConsents(context.Context, uuid.UUID) ([]*admin.VerifiedRelationshipConsent, error)
+	ApproveBundleReset(context.Context, api.TrustDomainName) (*admin.BundleResetApproval, error)
+	ListQuarantinedBundles(context.Context, api.TrustDomainName) ([]*admin.QuarantinedBundle, error)
+	GetQuarantinedBundle(context.Context, admin.QuarantinedBundleID) (*admin.QuarantinedBundle, error)
+	AcceptQuarantinedBundle(context.Context, admin.QuarantinedBundleID) (*admin.QuarantinedBundle, error)
+	DiscardQuarantinedBundle(context.Context, admin.QuarantinedBundleID) error
 }
 
 type harvesterAPIClient struct {
@@ -105,3 +114,117 @@ func (h harvesterAPIClient) UpdateRelationship(ctx context.Context, relationship
 
 	return rel, nil
 }
+
+func (h harvesterAPIClient) GetRelationshipConsents(ctx context.Context, relationshipID uuid.UUID) ([]*admin.VerifiedRelationshipConsent, error) {
+	res, err := h.client.GetRelationshipConsents(ctx, relationshipID)
+	if err != nil {
+		return nil, fmt.Errorf(errFailedRequest, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var consents []*admin.VerifiedRelationshipConsent
+	if err := json.Unmarshal(body, &consents); err != nil {
+		return nil, fmt.Errorf(errUnmarshalConsents, err)
+	}
+
+	return consents, nil
+}
+
+func (h harvesterAPIClient) ApproveBundleReset(ctx context.Context, trustDomainName api.TrustDomainName) (*admin.BundleResetApproval, error) {
+	res, err := h.client.ApproveBundleReset(ctx, trustDomainName)
+	if err != nil {
+		return nil, fmt.Errorf(errFailedRequest, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var approval *admin.BundleResetApproval
+	if err := json.Unmarshal(body, &approval); err != nil {
+		return nil, fmt.Errorf(errUnmarshalResetApproval, err)
+	}
+
+	return approval, nil
+}
+
+func (h harvesterAPIClient) ListQuarantinedBundles(ctx context.Context, trustDomainName api.TrustDomainName) ([]*admin.QuarantinedBundle, error) {
+	params := &admin.ListQuarantinedBundlesParams{}
+	if trustDomainName != "" {
+		params.TrustDomainName = &trustDomainName
+	}
+
+	res, err := h.client.ListQuarantinedBundles(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf(errFailedRequest, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var bundles []*admin.QuarantinedBundle
+	if err := json.Unmarshal(body, &bundles); err != nil {
+		return nil, fmt.Errorf(errUnmarshalQuarantined, err)
+	}
+
+	return bundles, nil
+}
+
+func (h harvesterAPIClient) GetQuarantinedBundle(ctx context.Context, bundleID admin.QuarantinedBundleID) (*admin.QuarantinedBundle, error) {
+	res, err := h.client.GetQuarantinedBundle(ctx, bundleID)
+	if err != nil {
+		return nil, fmt.Errorf(errFailedRequest, err)
+	}
+	defer res.Body.Close()
+
+	return unmarshalQuarantinedBundle(res)
+}
+
+func (h harvesterAPIClient) AcceptQuarantinedBundle(ctx context.Context, bundleID admin.QuarantinedBundleID) (*admin.QuarantinedBundle, error) {
+	res, err := h.client.AcceptQuarantinedBundle(ctx, bundleID)
+	if err != nil {
+		return nil, fmt.Errorf(errFailedRequest, err)
+	}
+	defer res.Body.Close()
+
+	return unmarshalQuarantinedBundle(res)
+}
+
+func (h harvesterAPIClient) DiscardQuarantinedBundle(ctx context.Context, bundleID admin.QuarantinedBundleID) error {
+	res, err := h.client.DiscardQuarantinedBundle(ctx, bundleID)
+	if err != nil {
+		return fmt.Errorf(errFailedRequest, err)
+	}
+	defer res.Body.Close()
+
+	_, err = httputil.ReadResponse(res)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func unmarshalQuarantinedBundle(res *http.Response) (*admin.QuarantinedBundle, error) {
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var bundle *admin.QuarantinedBundle
+	if err := json.Unmarshal(body, &bundle); err != nil {
+		return nil, fmt.Errorf(errUnmarshalQuarantined, err)
+	}
+
+	return bundle, nil
+}
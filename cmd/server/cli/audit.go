@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/cmd/common/cli"
+	"github.com/HewlettPackard/galadriel/cmd/server/util"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/HewlettPackard/galadriel/pkg/server/audit"
+	"github.com/spf13/cobra"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Manage the audit log",
+}
+
+var listAuditEventsCmd = &cobra.Command{
+	Use:   "list",
+	Args:  cobra.ExactArgs(0),
+	Short: "Lists the events of the audit log",
+	Long: `
+The 'list' command lists the events of the audit log of the Galadriel Server, in the order they were recorded.
+
+The audit log records who created, updated or deleted a trust domain or a relationship, generated a join
+token, onboarded a Harvester, consented to a relationship, and replaced or rolled back a bundle. The actor
+of an event is 'admin' for the actions taken through the Admin API, and 'harvester:<trust domain>' for the
+actions taken by a Harvester.
+
+The --since and --until flags take either an RFC3339 timestamp or a duration before now, e.g. '24h'.
+
+Each event is chained to the previous one by its hash. With the --verify flag, the whole audit log is
+fetched and the chain is verified, detecting altered, inserted or deleted events.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		since, err := cmd.Flags().GetString(cli.SinceFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get since flag: %v", err)
+		}
+
+		until, err := cmd.Flags().GetString(cli.UntilFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get until flag: %v", err)
+		}
+
+		actor, err := cmd.Flags().GetString(cli.ActorFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get actor flag: %v", err)
+		}
+
+		verify, err := cmd.Flags().GetBool(cli.VerifyFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get verify flag: %v", err)
+		}
+
+		params, err := newListAuditEventsParams(since, until, actor, time.Now())
+		if err != nil {
+			return err
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		return runListAuditEvents(ctx, client, params, verify, cmd.OutOrStdout())
+	},
+}
+
+func runListAuditEvents(ctx context.Context, client util.GaladrielAPIClient, params *admin.ListAuditEventsParams, verify bool, out io.Writer) error {
+	if verify && (params.Since != nil || params.Until != nil || params.Actor != nil) {
+		return errors.New("the audit log can only be verified as a whole, the filters cannot be used with --verify")
+	}
+
+	events, err := client.ListAuditEvents(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	if verify {
+		if err := audit.VerifyChain(events); err != nil {
+			return fmt.Errorf("audit log verification failed: %w", err)
+		}
+		fmt.Fprintf(out, "Audit log verified: %d events\n", len(events))
+		return nil
+	}
+
+	if len(events) == 0 {
+		fmt.Fprintln(out, "No audit events found")
+		return nil
+	}
+
+	fmt.Fprintln(out)
+	for _, e := range events {
+		fmt.Fprintf(out, "%s\n", e.ConsoleString())
+	}
+	fmt.Fprintln(out)
+
+	return nil
+}
+
+func newListAuditEventsParams(since, until, actor string, now time.Time) (*admin.ListAuditEventsParams, error) {
+	params := &admin.ListAuditEventsParams{}
+
+	if since != "" {
+		t, err := parseTimeFlag(since, now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid since: %w", err)
+		}
+		params.Since = &t
+	}
+
+	if until != "" {
+		t, err := parseTimeFlag(until, now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid until: %w", err)
+		}
+		params.Until = &t
+	}
+
+	if actor != "" {
+		params.Actor = &actor
+	}
+
+	return params, nil
+}
+
+// parseTimeFlag parses an RFC3339 timestamp, or a duration that is subtracted from now.
+func parseTimeFlag(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither an RFC3339 timestamp nor a duration", value)
+	}
+	if d < 0 {
+		return time.Time{}, fmt.Errorf("duration %q must not be negative", value)
+	}
+
+	return now.Add(-d), nil
+}
+
+func init() {
+	RootCmd.AddCommand(auditCmd)
+
+	auditCmd.AddCommand(listAuditEventsCmd)
+
+	listAuditEventsCmd.Flags().String(cli.SinceFlagName, "", "List the events recorded at or after this time, an RFC3339 timestamp or a duration before now")
+	listAuditEventsCmd.Flags().String(cli.UntilFlagName, "", "List the events recorded before this time, an RFC3339 timestamp or a duration before now")
+	listAuditEventsCmd.Flags().String(cli.ActorFlagName, "", "List the events of this actor, 'admin' or 'harvester:<trust domain>'")
+	listAuditEventsCmd.Flags().Bool(cli.VerifyFlagName, false, "Verify the hash chain of the whole audit log")
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/cmd/server/util"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/HewlettPackard/galadriel/pkg/server/audit"
+	"github.com/HewlettPackard/galadriel/pkg/server/endpoints"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeFlag(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	ts, err := parseTimeFlag("2023-05-31T10:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 5, 31, 10, 0, 0, 0, time.UTC), ts)
+
+	ts, err = parseTimeFlag("24h", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-24*time.Hour), ts)
+
+	_, err = parseTimeFlag("-1h", now)
+	assert.EqualError(t, err, `duration "-1h" must not be negative`)
+
+	_, err = parseTimeFlag("yesterday", now)
+	assert.EqualError(t, err, `"yesterday" is neither an RFC3339 timestamp nor a duration`)
+}
+
+func TestNewListAuditEventsParams(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	params, err := newListAuditEventsParams("", "", "", now)
+	require.NoError(t, err)
+	assert.Equal(t, &admin.ListAuditEventsParams{}, params)
+
+	params, err = newListAuditEventsParams("1h", "2023-06-01T12:00:00Z", "admin", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-time.Hour), *params.Since)
+	assert.Equal(t, now, *params.Until)
+	assert.Equal(t, "admin", *params.Actor)
+
+	_, err = newListAuditEventsParams("", "tomorrow", "", now)
+	assert.ErrorContains(t, err, "invalid until")
+}
+
+func TestRunListAuditEvents(t *testing.T) {
+	ctx := context.Background()
+	ds := fakedatastore.NewFakeDB()
+	for _, actor := range []string{audit.AdminActor, audit.HarvesterActor("td1.org")} {
+		_, err := ds.AppendAuditEvent(ctx, &entity.AuditEvent{Actor: actor, Action: entity.AuditActionTrustDomainUpdate, Target: "td1.org"})
+		require.NoError(t, err)
+	}
+
+	socketPath := filepath.Join(t.TempDir(), "api.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	logger, _ := test.NewNullLogger()
+	server := echo.New()
+	admin.RegisterHandlers(server, endpoints.NewAdminAPIHandlers(logger, ds, nil, nil, nil))
+	go func() {
+		_ = server.Server.Serve(listener)
+	}()
+	defer server.Close()
+
+	client, err := util.NewGaladrielUDSClient(socketPath, nil)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	actor := audit.HarvesterActor("td1.org")
+	err = runListAuditEvents(ctx, client, &admin.ListAuditEventsParams{Actor: &actor}, false, &out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Actor: harvester:td1.org")
+	assert.NotContains(t, out.String(), "Actor: admin")
+
+	out.Reset()
+	err = runListAuditEvents(ctx, client, &admin.ListAuditEventsParams{}, true, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "Audit log verified: 2 events\n", out.String())
+
+	err = runListAuditEvents(ctx, client, &admin.ListAuditEventsParams{Actor: &actor}, true, &out)
+	assert.ErrorContains(t, err, "the filters cannot be used with --verify")
+
+	out.Reset()
+	other := "harvester:td2.org"
+	err = runListAuditEvents(ctx, client, &admin.ListAuditEventsParams{Actor: &other}, false, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "No audit events found\n", out.String())
+}
@@ -4,23 +4,35 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"os"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/common/health"
 	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/common/util"
 	"github.com/HewlettPackard/galadriel/pkg/server"
 	"github.com/HewlettPackard/galadriel/pkg/server/catalog"
+	"github.com/HewlettPackard/galadriel/pkg/server/endpoints"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
 
 const (
 	// TODO: These defaults should be moved close to where they are used (Server, Endpoints).
 	defaultPort    = 8085
 	defaultAddress = "0.0.0.0"
+
+	defaultBundleEndpointPort = 8443
+	defaultMetricsPort        = 8088
+	defaultHealthChecksPort   = 8087
+
+	defaultTracingExporter    = telemetry.TracingExporterOTLP
+	defaultTracingSampleRatio = 1.0
 )
 
 // Config holds the configuration for the Galadriel server.
@@ -34,6 +46,65 @@ type serverConfig struct {
 	ListenPort    int    `hcl:"listen_port,optional"`
 	SocketPath    string `hcl:"socket_path,optional"`
 	LogLevel      string `hcl:"log_level,optional"`
+
+	JWTKeyRotationInterval string `hcl:"jwt_key_rotation_interval,optional"`
+
+	BundleContinuity bool `hcl:"bundle_continuity,optional"`
+
+	OnboardSigners []string `hcl:"onboard_signers,optional"`
+
+	BundleEndpoint *bundleEndpointConfig `hcl:"bundle_endpoint,block"`
+
+	HighAvailability *highAvailabilityConfig `hcl:"high_availability,block"`
+
+	Metrics *metricsConfig `hcl:"metrics,block"`
+
+	Tracing *tracingConfig `hcl:"tracing,block"`
+
+	HealthChecks *healthChecksConfig `hcl:"health_checks,block"`
+
+	Audit *auditConfig `hcl:"audit,block"`
+}
+
+// metricsConfig holds the configuration of the listener serving the metrics.
+type metricsConfig struct {
+	ListenAddress string `hcl:"listen_address,optional"`
+	ListenPort    int    `hcl:"listen_port,optional"`
+}
+
+// healthChecksConfig holds the configuration of the listener serving the health checks.
+type healthChecksConfig struct {
+	ListenAddress string `hcl:"listen_address,optional"`
+	ListenPort    int    `hcl:"listen_port,optional"`
+}
+
+// auditConfig holds the configuration of the export of the audit events.
+type auditConfig struct {
+	FilePath string `hcl:"file_path,optional"`
+}
+
+// tracingConfig holds the configuration of the export of the spans.
+type tracingConfig struct {
+	Exporter    string   `hcl:"exporter,optional"`
+	Endpoint    string   `hcl:"endpoint,optional"`
+	Insecure    bool     `hcl:"insecure,optional"`
+	FilePath    string   `hcl:"file_path,optional"`
+	SampleRatio *float64 `hcl:"sample_ratio,optional"`
+}
+
+// highAvailabilityConfig holds the configuration of a Galadriel Server replica sharing its datastore with other replicas.
+type highAvailabilityConfig struct {
+	InstanceID string `hcl:"instance_id,optional"`
+}
+
+// bundleEndpointConfig holds the configuration of the SPIFFE Federation bundle endpoint.
+type bundleEndpointConfig struct {
+	ListenAddress string   `hcl:"listen_address,optional"`
+	ListenPort    int      `hcl:"listen_port,optional"`
+	Profile       string   `hcl:"profile,optional"`
+	SPIFFEID      string   `hcl:"spiffe_id,optional"`
+	DNSNames      []string `hcl:"dns_names,optional"`
+	RefreshHint   string   `hcl:"refresh_hint,optional"`
 }
 
 // providersBlock holds the Providers HCL block body.
@@ -84,6 +155,66 @@ func NewServerConfig(c *Config) (*server.Config, error) {
 	logger.SetLevel(logLevel)
 	sc.Logger = logger.WithField(telemetry.SubsystemName, telemetry.Server)
 
+	if c.Server.JWTKeyRotationInterval != "" {
+		jwtKeyRotationInterval, err := time.ParseDuration(c.Server.JWTKeyRotationInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JWT key rotation interval: %w", err)
+		}
+		sc.JWTKeyRotationInterval = jwtKeyRotationInterval
+	}
+
+	sc.BundleContinuity = c.Server.BundleContinuity
+
+	for _, signer := range c.Server.OnboardSigners {
+		id, err := spiffeid.FromString(signer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse onboard signer SPIFFE ID %s: %w", signer, err)
+		}
+		sc.OnboardSigners = append(sc.OnboardSigners, id)
+	}
+
+	if c.Server.BundleEndpoint != nil {
+		sc.BundleEndpoint, err = newBundleEndpointConfig(c.Server.BundleEndpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse bundle endpoint configuration: %w", err)
+		}
+	}
+
+	if c.Server.HighAvailability != nil {
+		sc.HighAvailability, err = newHighAvailabilityConfig(c.Server.HighAvailability)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse high availability configuration: %w", err)
+		}
+	}
+
+	if c.Server.Metrics != nil {
+		sc.Metrics, err = newMetricsConfig(c.Server.Metrics)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse metrics configuration: %w", err)
+		}
+	}
+
+	if c.Server.Tracing != nil {
+		sc.Tracing, err = newTracingConfig(c.Server.Tracing)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse tracing configuration: %w", err)
+		}
+	}
+
+	if c.Server.HealthChecks != nil {
+		sc.HealthChecks, err = newHealthChecksConfig(c.Server.HealthChecks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse health checks configuration: %w", err)
+		}
+	}
+
+	if c.Server.Audit != nil {
+		sc.Audit, err = newAuditConfig(c.Server.Audit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse audit configuration: %w", err)
+		}
+	}
+
 	sc.ProvidersConfig, err = catalog.ProvidersConfigsFromHCLBody(c.Providers.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse providers configuration: %w", err)
@@ -92,6 +223,93 @@ func NewServerConfig(c *Config) (*server.Config, error) {
 	return sc, nil
 }
 
+func newBundleEndpointConfig(c *bundleEndpointConfig) (*endpoints.BundleEndpointConfig, error) {
+	addrPort := fmt.Sprintf("%s:%d", c.ListenAddress, c.ListenPort)
+	tcpAddr, err := net.ResolveTCPAddr(constants.TCPProtocol, addrPort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve TCP address %s: %w", addrPort, err)
+	}
+
+	bc := &endpoints.BundleEndpointConfig{
+		Address:  tcpAddr,
+		Profile:  endpoints.BundleEndpointProfile(c.Profile),
+		DNSNames: c.DNSNames,
+	}
+
+	if c.SPIFFEID != "" {
+		bc.SPIFFEID, err = spiffeid.FromString(c.SPIFFEID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SPIFFE ID %s: %w", c.SPIFFEID, err)
+		}
+	}
+
+	if c.RefreshHint != "" {
+		bc.RefreshHint, err = time.ParseDuration(c.RefreshHint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse refresh hint: %w", err)
+		}
+	}
+
+	return bc, nil
+}
+
+func newMetricsConfig(c *metricsConfig) (*telemetry.MetricsConfig, error) {
+	addrPort := fmt.Sprintf("%s:%d", c.ListenAddress, c.ListenPort)
+	tcpAddr, err := net.ResolveTCPAddr(constants.TCPProtocol, addrPort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve TCP address %s: %w", addrPort, err)
+	}
+
+	return &telemetry.MetricsConfig{Address: tcpAddr}, nil
+}
+
+func newHealthChecksConfig(c *healthChecksConfig) (*health.Config, error) {
+	addrPort := fmt.Sprintf("%s:%d", c.ListenAddress, c.ListenPort)
+	tcpAddr, err := net.ResolveTCPAddr(constants.TCPProtocol, addrPort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve TCP address %s: %w", addrPort, err)
+	}
+
+	return &health.Config{Address: tcpAddr}, nil
+}
+
+func newTracingConfig(c *tracingConfig) (*telemetry.TracingConfig, error) {
+	tc := &telemetry.TracingConfig{
+		Exporter:    telemetry.TracingExporter(c.Exporter),
+		Endpoint:    c.Endpoint,
+		Insecure:    c.Insecure,
+		FilePath:    c.FilePath,
+		SampleRatio: *c.SampleRatio,
+	}
+
+	if err := tc.Validate(); err != nil {
+		return nil, err
+	}
+
+	return tc, nil
+}
+
+func newAuditConfig(c *auditConfig) (*server.AuditConfig, error) {
+	if c.FilePath == "" {
+		return nil, errors.New("file path is required")
+	}
+
+	return &server.AuditConfig{FilePath: c.FilePath}, nil
+}
+
+func newHighAvailabilityConfig(c *highAvailabilityConfig) (*server.HighAvailabilityConfig, error) {
+	instanceID := c.InstanceID
+	if instanceID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get hostname to use as instance ID: %w", err)
+		}
+		instanceID = hostname
+	}
+
+	return &server.HighAvailabilityConfig{InstanceID: instanceID}, nil
+}
+
 func newConfig(configBytes []byte) (*Config, error) {
 	var config Config
 
@@ -125,4 +343,49 @@ func (c *Config) setDefaults() {
 	if c.Server.LogLevel == "" {
 		c.Server.LogLevel = constants.DefaultLogLevel
 	}
+
+	if c.Server.BundleEndpoint != nil {
+		if c.Server.BundleEndpoint.ListenAddress == "" {
+			c.Server.BundleEndpoint.ListenAddress = defaultAddress
+		}
+
+		if c.Server.BundleEndpoint.ListenPort == 0 {
+			c.Server.BundleEndpoint.ListenPort = defaultBundleEndpointPort
+		}
+
+		if c.Server.BundleEndpoint.Profile == "" {
+			c.Server.BundleEndpoint.Profile = string(endpoints.BundleEndpointProfileHTTPSWeb)
+		}
+	}
+
+	if c.Server.Metrics != nil {
+		if c.Server.Metrics.ListenAddress == "" {
+			c.Server.Metrics.ListenAddress = defaultAddress
+		}
+
+		if c.Server.Metrics.ListenPort == 0 {
+			c.Server.Metrics.ListenPort = defaultMetricsPort
+		}
+	}
+
+	if c.Server.Tracing != nil {
+		if c.Server.Tracing.Exporter == "" {
+			c.Server.Tracing.Exporter = string(defaultTracingExporter)
+		}
+
+		if c.Server.Tracing.SampleRatio == nil {
+			sampleRatio := defaultTracingSampleRatio
+			c.Server.Tracing.SampleRatio = &sampleRatio
+		}
+	}
+
+	if c.Server.HealthChecks != nil {
+		if c.Server.HealthChecks.ListenAddress == "" {
+			c.Server.HealthChecks.ListenAddress = defaultAddress
+		}
+
+		if c.Server.HealthChecks.ListenPort == 0 {
+			c.Server.HealthChecks.ListenPort = defaultHealthChecksPort
+		}
+	}
 }
@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/server/endpoints"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
@@ -19,6 +23,9 @@ var hclConfigWithProviders = `server {
     listen_port = "2222"
     socket_path = "/tmp/api.sock"
 	log_level = "DEBUG"
+    jwt_key_rotation_interval = "24h"
+    bundle_continuity = true
+    onboard_signers = ["spiffe://example.org/galadriel-harvester"]
 }
 
 providers {
@@ -58,6 +65,10 @@ func TestNew(t *testing.T) {
 					ListenPort:    2222,
 					SocketPath:    "/tmp/api.sock",
 					LogLevel:      "DEBUG",
+
+					JWTKeyRotationInterval: "24h",
+					BundleContinuity:       true,
+					OnboardSigners:         []string{"spiffe://example.org/galadriel-harvester"},
 				},
 			},
 		},
@@ -72,6 +83,93 @@ func TestNew(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "bundle_endpoint_defaults",
+			config: bytes.NewBufferString(`server {
+    bundle_endpoint {}
+}`),
+			expected: &Config{
+				Server: &serverConfig{
+					ListenAddress: defaultAddress,
+					ListenPort:    defaultPort,
+					LogLevel:      constants.DefaultLogLevel,
+					BundleEndpoint: &bundleEndpointConfig{
+						ListenAddress: defaultAddress,
+						ListenPort:    defaultBundleEndpointPort,
+						Profile:       "https_web",
+					},
+				},
+			},
+		},
+		{
+			name: "high_availability",
+			config: bytes.NewBufferString(`server {
+    high_availability {
+        instance_id = "server-1"
+    }
+}`),
+			expected: &Config{
+				Server: &serverConfig{
+					ListenAddress: defaultAddress,
+					ListenPort:    defaultPort,
+					LogLevel:      constants.DefaultLogLevel,
+					HighAvailability: &highAvailabilityConfig{
+						InstanceID: "server-1",
+					},
+				},
+			},
+		},
+		{
+			name: "metrics_defaults",
+			config: bytes.NewBufferString(`server {
+    metrics {}
+}`),
+			expected: &Config{
+				Server: &serverConfig{
+					ListenAddress: defaultAddress,
+					ListenPort:    defaultPort,
+					LogLevel:      constants.DefaultLogLevel,
+					Metrics: &metricsConfig{
+						ListenAddress: defaultAddress,
+						ListenPort:    defaultMetricsPort,
+					},
+				},
+			},
+		},
+		{
+			name: "tracing_defaults",
+			config: bytes.NewBufferString(`server {
+    tracing {}
+}`),
+			expected: &Config{
+				Server: &serverConfig{
+					ListenAddress: defaultAddress,
+					ListenPort:    defaultPort,
+					LogLevel:      constants.DefaultLogLevel,
+					Tracing: &tracingConfig{
+						Exporter:    string(telemetry.TracingExporterOTLP),
+						SampleRatio: ptr(defaultTracingSampleRatio),
+					},
+				},
+			},
+		},
+		{
+			name: "health_checks_defaults",
+			config: bytes.NewBufferString(`server {
+    health_checks {}
+}`),
+			expected: &Config{
+				Server: &serverConfig{
+					ListenAddress: defaultAddress,
+					ListenPort:    defaultPort,
+					LogLevel:      constants.DefaultLogLevel,
+					HealthChecks: &healthChecksConfig{
+						ListenAddress: defaultAddress,
+						ListenPort:    defaultHealthChecksPort,
+					},
+				},
+			},
+		},
 		{
 			name:   "empty_config_file",
 			config: bytes.NewBufferString(``),
@@ -121,3 +219,91 @@ func TestParseHCLConfigWithProviders(t *testing.T) {
 
 	require.NotNil(t, config.Providers)
 }
+
+func TestNewBundleEndpointConfig(t *testing.T) {
+	c := &bundleEndpointConfig{
+		ListenAddress: "127.0.0.1",
+		ListenPort:    8443,
+		Profile:       "https_spiffe",
+		SPIFFEID:      "spiffe://galadriel.org/bundle-endpoint",
+		RefreshHint:   "10m",
+	}
+
+	bc, err := newBundleEndpointConfig(c)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:8443", bc.Address.String())
+	assert.Equal(t, endpoints.BundleEndpointProfileHTTPSSPIFFE, bc.Profile)
+	assert.Equal(t, "spiffe://galadriel.org/bundle-endpoint", bc.SPIFFEID.String())
+	assert.Equal(t, 10*time.Minute, bc.RefreshHint)
+
+	c.SPIFFEID = "not-a-spiffe-id"
+	_, err = newBundleEndpointConfig(c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse SPIFFE ID")
+
+	c.SPIFFEID = ""
+	c.RefreshHint = "ten minutes"
+	_, err = newBundleEndpointConfig(c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse refresh hint")
+}
+
+func TestNewMetricsConfig(t *testing.T) {
+	mc, err := newMetricsConfig(&metricsConfig{ListenAddress: "127.0.0.1", ListenPort: 9100})
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:9100", mc.Address.String())
+
+	_, err = newMetricsConfig(&metricsConfig{ListenAddress: "127.0.0.1", ListenPort: -1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to resolve TCP address")
+}
+
+func TestNewHealthChecksConfig(t *testing.T) {
+	hc, err := newHealthChecksConfig(&healthChecksConfig{ListenAddress: "127.0.0.1", ListenPort: 8087})
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:8087", hc.Address.String())
+
+	_, err = newHealthChecksConfig(&healthChecksConfig{ListenAddress: "127.0.0.1", ListenPort: -1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to resolve TCP address")
+}
+
+func TestNewTracingConfig(t *testing.T) {
+	tc, err := newTracingConfig(&tracingConfig{Exporter: "stdout", SampleRatio: ptr(0.5)})
+	require.NoError(t, err)
+	assert.Equal(t, &telemetry.TracingConfig{Exporter: telemetry.TracingExporterStdout, SampleRatio: 0.5}, tc)
+
+	_, err = newTracingConfig(&tracingConfig{Exporter: "file", SampleRatio: ptr(1.0)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file path is required by the file exporter")
+
+	_, err = newTracingConfig(&tracingConfig{Exporter: "otlp", SampleRatio: ptr(2.0)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sample ratio must be between 0 and 1")
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestNewAuditConfig(t *testing.T) {
+	ac, err := newAuditConfig(&auditConfig{FilePath: "/var/log/galadriel/audit.jsonl"})
+	require.NoError(t, err)
+	assert.Equal(t, "/var/log/galadriel/audit.jsonl", ac.FilePath)
+
+	_, err = newAuditConfig(&auditConfig{})
+	require.EqualError(t, err, "file path is required")
+}
+
+func TestNewHighAvailabilityConfig(t *testing.T) {
+	hc, err := newHighAvailabilityConfig(&highAvailabilityConfig{InstanceID: "server-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "server-1", hc.InstanceID)
+
+	// the instance ID defaults to the hostname
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	hc, err = newHighAvailabilityConfig(&highAvailabilityConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, hostname, hc.InstanceID)
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/HewlettPackard/galadriel/cmd/common/cli"
+	httputil "github.com/HewlettPackard/galadriel/cmd/common/http"
+	"github.com/HewlettPackard/galadriel/pkg/common/health"
+	"github.com/spf13/cobra"
+)
+
+var healthcheckCmd = &cobra.Command{
+	Use:   "healthcheck",
+	Args:  cobra.ExactArgs(0),
+	Short: "Checks the health of the Galadriel Server",
+	Long: `
+The 'healthcheck' command checks that the Galadriel Server is ready, calling its readiness endpoint
+through the API socket. The server is ready when its datastore is reachable, its TLS certificate is
+loaded and a JWT signing key is available.
+
+With the --shallow flag, it only checks that the server is alive, calling its liveness endpoint.
+It exits with a non-zero status if the server is not healthy, so that it can be used by supervisors.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		shallow, err := cmd.Flags().GetBool(cli.ShallowFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get shallow flag: %v", err)
+		}
+
+		verbose, err := cmd.Flags().GetBool(cli.VerboseFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get verbose flag: %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), cli.CommandTimeout)
+		defer cancel()
+
+		return runHealthcheck(ctx, socketPath, shallow, verbose, cmd.OutOrStdout())
+	},
+}
+
+func runHealthcheck(ctx context.Context, socketPath string, shallow, verbose bool, out io.Writer) error {
+	path := health.ReadyPath
+	if shallow {
+		path = health.LivePath
+	}
+
+	report, err := health.Probe(ctx, httputil.NewUDSHTTPClient(socketPath), strings.TrimSuffix(cli.LocalhostURL, "/"), path)
+	if err != nil {
+		return fmt.Errorf("failed to check the health of the Galadriel Server: %w", err)
+	}
+
+	if report.Status != health.StatusOK {
+		return fmt.Errorf("galadriel Server is unhealthy: %s", report)
+	}
+
+	if verbose {
+		fmt.Fprintf(out, "Galadriel Server is healthy: %s\n", report)
+		return nil
+	}
+
+	fmt.Fprintln(out, "Galadriel Server is healthy.")
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(healthcheckCmd)
+
+	healthcheckCmd.Flags().Bool(cli.ShallowFlagName, false, "Only check that the server is alive")
+	healthcheckCmd.Flags().Bool(cli.VerboseFlagName, false, "Print the status of each readiness check")
+}
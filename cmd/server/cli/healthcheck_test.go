@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/health"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunHealthcheck(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "api.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	checker := health.NewChecker()
+	server := echo.New()
+	checker.RegisterHandlers(server)
+	go func() {
+		_ = server.Server.Serve(listener)
+	}()
+	defer server.Close()
+
+	var out bytes.Buffer
+	err = runHealthcheck(context.Background(), socketPath, false, false, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "Galadriel Server is healthy.\n", out.String())
+
+	checker.AddCheck("datastore", func(context.Context) error {
+		return errors.New("failed to ping database")
+	})
+
+	out.Reset()
+	err = runHealthcheck(context.Background(), socketPath, false, false, &out)
+	assert.EqualError(t, err, "galadriel Server is unhealthy: unavailable\n  datastore: failed to ping database")
+	assert.Empty(t, out.String())
+
+	// the liveness does not depend on the readiness checks
+	err = runHealthcheck(context.Background(), socketPath, true, true, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "Galadriel Server is healthy: ok\n", out.String())
+
+	err = runHealthcheck(context.Background(), filepath.Join(t.TempDir(), "missing.sock"), false, false, &out)
+	assert.ErrorContains(t, err, "failed to check the health of the Galadriel Server")
+}
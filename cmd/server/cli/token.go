@@ -4,17 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/cmd/common/cli"
 
 	"github.com/HewlettPackard/galadriel/cmd/server/util"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
 	"github.com/HewlettPackard/galadriel/pkg/server/endpoints"
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 )
 
 var tokenCmd = &cobra.Command{
-	Use: "token",
+	Use:   "token",
+	Short: "Manage the join tokens",
 }
 
 var generateTokenCmd = &cobra.Command{
@@ -33,6 +38,11 @@ ensure compatibility and successful connection.
 
 Please exercise caution when handling and sharing join tokens, as they grant access to the 
 trust domain and should only be shared with authorized individuals or entities.
+
+By default, a join token can be used once. To onboard several Harvester instances with the same
+token, set the number of uses with --maxUses. The token can be bound to the Harvester holding a key,
+with the fingerprint printed by 'galadriel-harvester key fingerprint' set with --harvesterKeyFingerprint,
+and to the network the Harvester connects from with --sourceCIDR.
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
@@ -45,14 +55,51 @@ trust domain and should only be shared with authorized individuals or entities.
 			return fmt.Errorf("cannot get trust domain flag: %v", err)
 		}
 
-		ttlStr, err := cmd.Flags().GetString(cli.TTLFlagName)
+		params, err := newGetJoinTokenParams(cmd)
+		if err != nil {
+			return err
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		joinToken, err := client.GetJoinToken(ctx, trustDomain, params)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(joinToken.ConsoleString())
+		return nil
+	},
+}
+
+var listTokensCmd = &cobra.Command{
+	Use:   "list",
+	Args:  cobra.ExactArgs(0),
+	Short: "Lists the join tokens",
+	Long: `
+The 'list' command lists the join tokens, with their values masked, their trust domain and their expiry.
+
+The join tokens can be filtered by trust domain with the --trustDomain flag, and by state with the
+--used and --expired flags, e.g. '--used=false --expired=false' lists the tokens that can still be used
+to onboard a Harvester.
+
+The expired join tokens are deleted by the Galadriel Server a day after they expire.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
 		if err != nil {
-			return fmt.Errorf("cannot get TTL flag: %v", err)
+			return fmt.Errorf("cannot get socket path flag: %v", err)
 		}
 
-		ttl, err := strconv.ParseInt(ttlStr, 10, 32)
+		params, err := newListJoinTokensParams(cmd)
 		if err != nil {
-			return errors.New("invalid TTL")
+			return err
 		}
 
 		client, err := util.NewGaladrielUDSClient(socketPath, nil)
@@ -63,20 +110,224 @@ trust domain and should only be shared with authorized individuals or entities.
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
-		joinToken, err := client.GetJoinToken(ctx, trustDomain, int32(ttl))
+		joinTokens, err := client.ListJoinTokens(ctx, params)
 		if err != nil {
 			return err
 		}
 
-		fmt.Print(joinToken.ConsoleString())
+		out := cmd.OutOrStdout()
+		if len(joinTokens) == 0 {
+			fmt.Fprintln(out, "No join tokens found")
+			return nil
+		}
+
+		fmt.Fprintln(out)
+		for _, jt := range joinTokens {
+			printJoinToken(out, jt)
+		}
+		fmt.Fprintln(out)
+
+		return nil
+	},
+}
+
+var showTokenCmd = &cobra.Command{
+	Use:   "show",
+	Args:  cobra.ExactArgs(0),
+	Short: "Shows a join token",
+	Long: `
+The 'show' command shows a join token, with its value masked, its trust domain and its expiry.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		joinTokenID, err := getJoinTokenIDAndParse(cmd)
+		if err != nil {
+			return err
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		joinToken, err := client.GetJoinTokenByID(ctx, joinTokenID)
+		if err != nil {
+			return err
+		}
+
+		printJoinToken(cmd.OutOrStdout(), joinToken)
 		return nil
 	},
 }
 
+var revokeTokenCmd = &cobra.Command{
+	Use:   "revoke",
+	Args:  cobra.ExactArgs(0),
+	Short: "Revokes a join token",
+	Long: `
+The 'revoke' command deletes a join token, so that it can no longer be used to onboard a Harvester.
+
+Revoke a join token as soon as it is suspected to be disclosed. A Harvester already onboarded
+with the join token is not affected.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		joinTokenID, err := getJoinTokenIDAndParse(cmd)
+		if err != nil {
+			return err
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		if err := client.RevokeJoinToken(ctx, joinTokenID); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Join token %q revoked\n", joinTokenID.String())
+		return nil
+	},
+}
+
+// newGetJoinTokenParams returns the parameters of the join token to generate, only setting the
+// optional constraints that were provided.
+func newGetJoinTokenParams(cmd *cobra.Command) (*admin.GetJoinTokenParams, error) {
+	ttlStr, err := cmd.Flags().GetString(cli.TTLFlagName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get TTL flag: %v", err)
+	}
+
+	ttl, err := strconv.ParseInt(ttlStr, 10, 32)
+	if err != nil {
+		return nil, errors.New("invalid TTL")
+	}
+
+	params := &admin.GetJoinTokenParams{Ttl: int32(ttl)}
+
+	if cmd.Flags().Changed(cli.MaxUsesFlagName) {
+		maxUses, err := cmd.Flags().GetInt32(cli.MaxUsesFlagName)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get max uses flag: %v", err)
+		}
+		params.MaxUses = &maxUses
+	}
+
+	fingerprint, err := cmd.Flags().GetString(cli.HarvesterKeyFingerprintFlagName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get harvester key fingerprint flag: %v", err)
+	}
+	if fingerprint != "" {
+		params.HarvesterKeyFingerprint = &fingerprint
+	}
+
+	sourceCIDR, err := cmd.Flags().GetString(cli.SourceCIDRFlagName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get source CIDR flag: %v", err)
+	}
+	if sourceCIDR != "" {
+		params.SourceCIDR = &sourceCIDR
+	}
+
+	return params, nil
+}
+
+// newListJoinTokensParams returns the filters of the join token list, only setting the flags that were provided.
+func newListJoinTokensParams(cmd *cobra.Command) (*admin.ListJoinTokensParams, error) {
+	params := &admin.ListJoinTokensParams{}
+
+	trustDomain, err := cmd.Flags().GetString(cli.TrustDomainFlagName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get trust domain flag: %v", err)
+	}
+	if trustDomain != "" {
+		params.TrustDomainName = &trustDomain
+	}
+
+	if cmd.Flags().Changed(cli.UsedFlagName) {
+		used, err := cmd.Flags().GetBool(cli.UsedFlagName)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get used flag: %v", err)
+		}
+		params.Used = &used
+	}
+
+	if cmd.Flags().Changed(cli.ExpiredFlagName) {
+		expired, err := cmd.Flags().GetBool(cli.ExpiredFlagName)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get expired flag: %v", err)
+		}
+		params.Expired = &expired
+	}
+
+	return params, nil
+}
+
+func getJoinTokenIDAndParse(cmd *cobra.Command) (uuid.UUID, error) {
+	idStr, err := cmd.Flags().GetString(cli.JoinTokenIDFlagName)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("cannot get join token ID flag: %v", err)
+	}
+
+	joinTokenID, err := uuid.Parse(idStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("cannot parse join token ID: %v", err)
+	}
+
+	return joinTokenID, nil
+}
+
+func printJoinToken(out io.Writer, jt *admin.JoinTokenDetails) {
+	fmt.Fprintf(out, `JoinToken:
+  ID: %s
+  Token: %s
+  TrustDomain: %s
+  Used: %t
+  Uses: %d/%d
+  Expired: %t
+  ExpiresAt: %s
+  CreatedAt: %s
+`,
+		jt.Id,
+		jt.MaskedToken,
+		jt.TrustDomainName,
+		jt.Used,
+		jt.UseCount,
+		jt.MaxUses,
+		jt.Expired,
+		jt.ExpiresAt.UTC().Format(time.RFC3339),
+		jt.CreatedAt.UTC().Format(time.RFC3339))
+
+	if jt.HarvesterKeyFingerprint != nil {
+		fmt.Fprintf(out, "  HarvesterKeyFingerprint: %s\n", *jt.HarvesterKeyFingerprint)
+	}
+	if jt.SourceCidr != nil {
+		fmt.Fprintf(out, "  SourceCIDR: %s\n", *jt.SourceCidr)
+	}
+}
+
 func init() {
 	RootCmd.AddCommand(tokenCmd)
 
 	tokenCmd.AddCommand(generateTokenCmd)
+	tokenCmd.AddCommand(listTokensCmd)
+	tokenCmd.AddCommand(showTokenCmd)
+	tokenCmd.AddCommand(revokeTokenCmd)
 
 	generateTokenCmd.Flags().StringP(cli.TrustDomainFlagName, "t", "", "The trust domain to which the join token will be bound")
 	err := generateTokenCmd.MarkFlagRequired(cli.TrustDomainFlagName)
@@ -84,4 +335,23 @@ func init() {
 		fmt.Printf("Error marking trustDomain flag as required: %v\n", err)
 	}
 	generateTokenCmd.Flags().StringP(cli.TTLFlagName, "", fmt.Sprintf("%d", endpoints.DefaultTokenTTL), "Token TTL in seconds")
+	generateTokenCmd.Flags().Int32(cli.MaxUsesFlagName, 1, "Number of times the token can be used to onboard a Harvester")
+	generateTokenCmd.Flags().String(cli.HarvesterKeyFingerprintFlagName, "", "Fingerprint of the key of the only Harvester that can use the token")
+	generateTokenCmd.Flags().String(cli.SourceCIDRFlagName, "", "Network from which the token can be used, in CIDR notation")
+
+	listTokensCmd.Flags().StringP(cli.TrustDomainFlagName, "t", "", "List the join tokens of this trust domain")
+	listTokensCmd.Flags().Bool(cli.UsedFlagName, false, "List the join tokens that were used, or with --used=false, that were not used")
+	listTokensCmd.Flags().Bool(cli.ExpiredFlagName, false, "List the join tokens that expired, or with --expired=false, that did not expire")
+
+	showTokenCmd.Flags().StringP(cli.JoinTokenIDFlagName, "i", "", "The ID of the join token to be shown")
+	err = showTokenCmd.MarkFlagRequired(cli.JoinTokenIDFlagName)
+	if err != nil {
+		fmt.Printf(errMarkFlagAsRequired, cli.JoinTokenIDFlagName, err)
+	}
+
+	revokeTokenCmd.Flags().StringP(cli.JoinTokenIDFlagName, "i", "", "The ID of the join token to be revoked")
+	err = revokeTokenCmd.MarkFlagRequired(cli.JoinTokenIDFlagName)
+	if err != nil {
+		fmt.Printf(errMarkFlagAsRequired, cli.JoinTokenIDFlagName, err)
+	}
 }
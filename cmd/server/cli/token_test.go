@@ -3,8 +3,10 @@ package cli
 import (
 	"testing"
 
+	"github.com/HewlettPackard/galadriel/cmd/common/cli"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTokenCmd(t *testing.T) {
@@ -15,3 +17,55 @@ func TestTokenCmd(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestNewGetJoinTokenParams(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String(cli.TTLFlagName, "600", "")
+		cmd.Flags().Int32(cli.MaxUsesFlagName, 1, "")
+		cmd.Flags().String(cli.HarvesterKeyFingerprintFlagName, "", "")
+		cmd.Flags().String(cli.SourceCIDRFlagName, "", "")
+		require.NoError(t, cmd.Flags().Parse(args))
+		return cmd
+	}
+
+	params, err := newGetJoinTokenParams(newCmd())
+	require.NoError(t, err)
+	assert.Equal(t, int32(600), params.Ttl)
+	assert.Nil(t, params.MaxUses)
+	assert.Nil(t, params.HarvesterKeyFingerprint)
+	assert.Nil(t, params.SourceCIDR)
+
+	params, err = newGetJoinTokenParams(newCmd("--ttl", "60", "--maxUses", "5", "--harvesterKeyFingerprint", "abcd", "--sourceCIDR", "10.0.1.0/24"))
+	require.NoError(t, err)
+	assert.Equal(t, int32(60), params.Ttl)
+	assert.Equal(t, int32(5), *params.MaxUses)
+	assert.Equal(t, "abcd", *params.HarvesterKeyFingerprint)
+	assert.Equal(t, "10.0.1.0/24", *params.SourceCIDR)
+
+	_, err = newGetJoinTokenParams(newCmd("--ttl", "forever"))
+	require.EqualError(t, err, "invalid TTL")
+}
+
+func TestNewListJoinTokensParams(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().StringP(cli.TrustDomainFlagName, "t", "", "")
+		cmd.Flags().Bool(cli.UsedFlagName, false, "")
+		cmd.Flags().Bool(cli.ExpiredFlagName, false, "")
+		require.NoError(t, cmd.Flags().Parse(args))
+		return cmd
+	}
+
+	params, err := newListJoinTokensParams(newCmd())
+	require.NoError(t, err)
+	assert.Nil(t, params.TrustDomainName)
+	assert.Nil(t, params.Used)
+	assert.Nil(t, params.Expired)
+
+	params, err = newListJoinTokensParams(newCmd("-t", "test.com", "--used=false", "--expired"))
+	require.NoError(t, err)
+	assert.Equal(t, "test.com", *params.TrustDomainName)
+	assert.False(t, *params.Used)
+	assert.True(t, *params.Expired)
+}
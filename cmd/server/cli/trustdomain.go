@@ -53,6 +53,11 @@ The 'create' command allows you to create a new trust domain in the Galadriel Se
 			return fmt.Errorf("cannot get trust domain flag: %v", err)
 		}
 
+		bundleVerifier, err := cmd.Flags().GetString(cli.BundleVerifierFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get bundle verifier flag: %v", err)
+		}
+
 		client, err := util.NewGaladrielUDSClient(socketPath, nil)
 		if err != nil {
 			return err
@@ -61,7 +66,7 @@ The 'create' command allows you to create a new trust domain in the Galadriel Se
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
-		trustDomainRes, err := client.CreateTrustDomain(ctx, trustDomain)
+		trustDomainRes, err := client.CreateTrustDomain(ctx, trustDomain, bundleVerifier)
 		if err != nil {
 			return err
 		}
@@ -174,6 +179,16 @@ in the Galadriel Server.`,
 			return fmt.Errorf("cannot get description flag: %v", err)
 		}
 
+		// the bundle verifier of the trust domain is only changed when the flag is set
+		var bundleVerifier *string
+		if cmd.Flags().Changed(cli.BundleVerifierFlagName) {
+			verifier, err := cmd.Flags().GetString(cli.BundleVerifierFlagName)
+			if err != nil {
+				return fmt.Errorf("cannot get bundle verifier flag: %v", err)
+			}
+			bundleVerifier = &verifier
+		}
+
 		client, err := util.NewGaladrielUDSClient(socketPath, nil)
 		if err != nil {
 			return err
@@ -182,7 +197,7 @@ in the Galadriel Server.`,
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
-		_, err = client.UpdateTrustDomainByName(ctx, trustDomainName, description)
+		_, err = client.UpdateTrustDomainByName(ctx, trustDomainName, description, bundleVerifier)
 		if err != nil {
 			return err
 		}
@@ -193,18 +208,61 @@ in the Galadriel Server.`,
 	},
 }
 
+var resetBundleTrustDomainCmd = &cobra.Command{
+	Use:   "reset-bundle",
+	Args:  cobra.ExactArgs(0),
+	Short: "Approve a reset of the bundle of a trust domain",
+	Long: `The 'reset-bundle' command approves the next bundle uploaded for a trust domain, even if
+it keeps no X.509 authority of the current bundle and is not signed by a key chaining to it.
+
+It is only needed when the server enforces the bundle continuity policy, e.g. after all the
+authorities of the SPIRE Server of the trust domain were replaced. The approval is consumed
+by the first bundle accepted thanks to it.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		socketPath, err := cmd.Flags().GetString(cli.SocketPathFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get socket path flag: %v", err)
+		}
+
+		trustDomainName, err := cmd.Flags().GetString(cli.TrustDomainFlagName)
+		if err != nil {
+			return fmt.Errorf("cannot get trust domain flag: %v", err)
+		}
+
+		client, err := util.NewGaladrielUDSClient(socketPath, nil)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		_, err = client.ApproveBundleReset(ctx, trustDomainName)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Reset of the bundle of Trust Domain %q approved\n", trustDomainName)
+
+		return nil
+	},
+}
+
 func init() {
 	RootCmd.AddCommand(trustDomainCmd)
 	trustDomainCmd.AddCommand(createTrustDomainCmd)
 	trustDomainCmd.AddCommand(listTrustDomainCmd)
 	trustDomainCmd.AddCommand(deleteTrustDomainCmd)
 	trustDomainCmd.AddCommand(updateTrustDomainCmd)
+	trustDomainCmd.AddCommand(resetBundleTrustDomainCmd)
 
 	createTrustDomainCmd.Flags().StringP(cli.TrustDomainFlagName, "t", "", "The trust domain name.")
 	err := createTrustDomainCmd.MarkFlagRequired(cli.TrustDomainFlagName)
 	if err != nil {
 		fmt.Printf(errMarkFlagAsRequired, cli.TrustDomainFlagName, err)
 	}
+	createTrustDomainCmd.Flags().String(cli.BundleVerifierFlagName, "", "The name of the BundleVerifier that must accept the trust domain bundles. If not set, any configured BundleVerifier can accept them.")
 
 	deleteTrustDomainCmd.Flags().StringP(cli.TrustDomainFlagName, "t", "", "The trust domain name.")
 	err = deleteTrustDomainCmd.MarkFlagRequired(cli.TrustDomainFlagName)
@@ -223,4 +281,11 @@ func init() {
 	if err != nil {
 		fmt.Printf(errMarkFlagAsRequired, cli.TrustDomainDescriptionFlagName, err)
 	}
+	updateTrustDomainCmd.Flags().String(cli.BundleVerifierFlagName, "", "The name of the BundleVerifier that must accept the trust domain bundles. If not set, the BundleVerifier of the trust domain is kept. If empty, any configured BundleVerifier can accept them.")
+
+	resetBundleTrustDomainCmd.Flags().StringP(cli.TrustDomainFlagName, "t", "", "The trust domain whose bundle can be reset.")
+	err = resetBundleTrustDomainCmd.MarkFlagRequired(cli.TrustDomainFlagName)
+	if err != nil {
+		fmt.Printf(errMarkFlagAsRequired, cli.TrustDomainFlagName, err)
+	}
 }
@@ -18,20 +18,28 @@ const (
 	errUnmarshalRelationships = "failed to unmarshal relationships: %v"
 	errUnmarshalTrustDomains  = "failed to unmarshal trust domain: %v"
 	errUnmarshalJoinToken     = "failed to unmarshal join token: %v"
+	errUnmarshalJoinTokens    = "failed to unmarshal join tokens: %v"
+	errUnmarshalAuditEvents   = "failed to unmarshal audit events: %v"
+	errUnmarshalResetApproval = "failed to unmarshal bundle reset approval: %v"
 )
 
 // GaladrielAPIClient represents an API client for the Galadriel Server API.
 type GaladrielAPIClient interface {
-	CreateTrustDomain(context.Context, api.TrustDomainName) (*entity.TrustDomain, error)
+	CreateTrustDomain(context.Context, api.TrustDomainName, string) (*entity.TrustDomain, error)
 	GetTrustDomainByName(context.Context, api.TrustDomainName) (*entity.TrustDomain, error)
 	ListTrustDomains(context.Context) ([]*entity.TrustDomain, error)
 	DeleteTrustDomainByName(context.Context, api.TrustDomainName) error
-	UpdateTrustDomainByName(context.Context, api.TrustDomainName, string) (*entity.TrustDomain, error)
+	UpdateTrustDomainByName(context.Context, api.TrustDomainName, string, *string) (*entity.TrustDomain, error)
 	CreateRelationship(context.Context, *entity.Relationship) (*entity.Relationship, error)
 	GetRelationships(context.Context, api.ConsentStatus, api.TrustDomainName) ([]*entity.Relationship, error)
 	PatchRelationshipByID(context.Context, api.UUID, api.ConsentStatus, api.ConsentStatus) (*entity.Relationship, error)
 	DeleteRelationshipByID(ctx context.Context, relID api.UUID) error
-	GetJoinToken(context.Context, api.TrustDomainName, int32) (*entity.JoinToken, error)
+	GetJoinToken(context.Context, api.TrustDomainName, *admin.GetJoinTokenParams) (*entity.JoinToken, error)
+	ListJoinTokens(context.Context, *admin.ListJoinTokensParams) ([]*admin.JoinTokenDetails, error)
+	GetJoinTokenByID(context.Context, api.UUID) (*admin.JoinTokenDetails, error)
+	RevokeJoinToken(context.Context, api.UUID) error
+	ListAuditEvents(context.Context, *admin.ListAuditEventsParams) ([]*entity.AuditEvent, error)
+	ApproveBundleReset(context.Context, api.TrustDomainName) (*admin.BundleResetApproval, error)
 }
 
 type galadrielAdminClient struct {
@@ -121,8 +129,10 @@ func (g *galadrielAdminClient) DeleteTrustDomainByName(ctx context.Context, trus
 	return nil
 }
 
-func (g *galadrielAdminClient) UpdateTrustDomainByName(ctx context.Context, trustDomainName api.TrustDomainName, description string) (*entity.TrustDomain, error) {
-	payload := api.TrustDomain{Name: trustDomainName, Description: &description}
+// UpdateTrustDomainByName updates the description of the trust domain. The bundle verifier is only updated when it is
+// not nil, an empty bundle verifier removes the one required by the trust domain.
+func (g *galadrielAdminClient) UpdateTrustDomainByName(ctx context.Context, trustDomainName api.TrustDomainName, description string, bundleVerifier *string) (*entity.TrustDomain, error) {
+	payload := api.TrustDomain{Name: trustDomainName, Description: &description, BundleVerifier: bundleVerifier}
 
 	res, err := g.client.PutTrustDomainByName(ctx, trustDomainName, payload)
 	if err != nil {
@@ -143,8 +153,11 @@ func (g *galadrielAdminClient) UpdateTrustDomainByName(ctx context.Context, trus
 	return trustDomain, nil
 }
 
-func (g *galadrielAdminClient) CreateTrustDomain(ctx context.Context, trustDomainName api.TrustDomainName) (*entity.TrustDomain, error) {
+func (g *galadrielAdminClient) CreateTrustDomain(ctx context.Context, trustDomainName api.TrustDomainName, bundleVerifier string) (*entity.TrustDomain, error) {
 	payload := admin.PutTrustDomainJSONRequestBody{Name: trustDomainName}
+	if bundleVerifier != "" {
+		payload.BundleVerifier = &bundleVerifier
+	}
 
 	res, err := g.client.PutTrustDomain(ctx, payload)
 	if err != nil {
@@ -254,8 +267,7 @@ func (g *galadrielAdminClient) GetRelationships(ctx context.Context, status api.
 	return rels, nil
 }
 
-func (g *galadrielAdminClient) GetJoinToken(ctx context.Context, trustDomainName api.TrustDomainName, ttl int32) (*entity.JoinToken, error) {
-	params := &admin.GetJoinTokenParams{Ttl: ttl}
+func (g *galadrielAdminClient) GetJoinToken(ctx context.Context, trustDomainName api.TrustDomainName, params *admin.GetJoinTokenParams) (*entity.JoinToken, error) {
 	res, err := g.client.GetJoinToken(ctx, trustDomainName, params)
 	if err != nil {
 		return nil, fmt.Errorf(errorRequestFailed, err)
@@ -275,6 +287,110 @@ func (g *galadrielAdminClient) GetJoinToken(ctx context.Context, trustDomainName
 	return joinToken, nil
 }
 
+func (g *galadrielAdminClient) ListJoinTokens(ctx context.Context, params *admin.ListJoinTokensParams) ([]*admin.JoinTokenDetails, error) {
+	res, err := g.client.ListJoinTokens(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var joinTokens []*admin.JoinTokenDetails
+	if err := json.Unmarshal(body, &joinTokens); err != nil {
+		return nil, fmt.Errorf(errUnmarshalJoinTokens, err)
+	}
+
+	return joinTokens, nil
+}
+
+func (g *galadrielAdminClient) GetJoinTokenByID(ctx context.Context, joinTokenID api.UUID) (*admin.JoinTokenDetails, error) {
+	res, err := g.client.GetJoinTokenByID(ctx, joinTokenID)
+	if err != nil {
+		return nil, fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var joinToken *admin.JoinTokenDetails
+	if err := json.Unmarshal(body, &joinToken); err != nil {
+		return nil, fmt.Errorf(errUnmarshalJoinToken, err)
+	}
+
+	return joinToken, nil
+}
+
+func (g *galadrielAdminClient) RevokeJoinToken(ctx context.Context, joinTokenID api.UUID) error {
+	res, err := g.client.RevokeJoinToken(ctx, joinTokenID)
+	if err != nil {
+		return fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	_, err = httputil.ReadResponse(res)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (g *galadrielAdminClient) ListAuditEvents(ctx context.Context, params *admin.ListAuditEventsParams) ([]*entity.AuditEvent, error) {
+	res, err := g.client.ListAuditEvents(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var auditEvents []*admin.AuditEvent
+	if err := json.Unmarshal(body, &auditEvents); err != nil {
+		return nil, fmt.Errorf(errUnmarshalAuditEvents, err)
+	}
+
+	events := make([]*entity.AuditEvent, 0, len(auditEvents))
+	for _, e := range auditEvents {
+		event, err := e.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (g *galadrielAdminClient) ApproveBundleReset(ctx context.Context, trustDomainName api.TrustDomainName) (*admin.BundleResetApproval, error) {
+	res, err := g.client.ApproveBundleReset(ctx, trustDomainName)
+	if err != nil {
+		return nil, fmt.Errorf(errorRequestFailed, err)
+	}
+	defer res.Body.Close()
+
+	body, err := httputil.ReadResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var approval *admin.BundleResetApproval
+	if err := json.Unmarshal(body, &approval); err != nil {
+		return nil, fmt.Errorf(errUnmarshalResetApproval, err)
+	}
+
+	return approval, nil
+}
+
 func unmarshalJSONToTrustDomain(body []byte) (*entity.TrustDomain, error) {
 	var trustDomain *entity.TrustDomain
 	if err := json.Unmarshal(body, &trustDomain); err != nil {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
 	"github.com/google/uuid"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
@@ -19,27 +20,34 @@ func (td TrustDomain) ToEntity() (*entity.TrustDomain, error) {
 		description = *td.Description
 	}
 
+	bundleVerifier := ""
+	if td.BundleVerifier != nil {
+		bundleVerifier = *td.BundleVerifier
+	}
+
 	id := uuid.NullUUID{
 		UUID:  td.Id,
 		Valid: true,
 	}
 
 	return &entity.TrustDomain{
-		ID:          id,
-		Name:        tdName,
-		Description: description,
-		CreatedAt:   td.CreatedAt,
-		UpdatedAt:   td.UpdatedAt,
+		ID:             id,
+		Name:           tdName,
+		Description:    description,
+		BundleVerifier: bundleVerifier,
+		CreatedAt:      td.CreatedAt,
+		UpdatedAt:      td.UpdatedAt,
 	}, nil
 }
 
 func TrustDomainFromEntity(entity *entity.TrustDomain) *TrustDomain {
 	return &TrustDomain{
-		Id:          entity.ID.UUID,
-		Name:        entity.Name.String(),
-		Description: &entity.Description,
-		UpdatedAt:   entity.UpdatedAt,
-		CreatedAt:   entity.CreatedAt,
+		Id:             entity.ID.UUID,
+		Name:           entity.Name.String(),
+		Description:    &entity.Description,
+		BundleVerifier: &entity.BundleVerifier,
+		UpdatedAt:      entity.UpdatedAt,
+		CreatedAt:      entity.CreatedAt,
 	}
 }
 
@@ -103,6 +111,72 @@ func MapRelationships(relationships ...*entity.Relationship) []*Relationship {
 	return cRelationships
 }
 
+func (c RelationshipConsent) ToEntity() (*entity.RelationshipConsent, error) {
+	statement, err := encoding.DecodeFromBase64(c.ConsentStatement)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode consent statement: %w", err)
+	}
+
+	var sig []byte
+	if c.Signature != nil {
+		sig, err = encoding.DecodeFromBase64(*c.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode signature: %w", err)
+		}
+	}
+
+	var certChain []byte
+	if c.SigningCertificateChain != nil {
+		certChain, err = encoding.DecodeFromBase64(*c.SigningCertificateChain)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode signing certificate chain: %w", err)
+		}
+	}
+
+	return &entity.RelationshipConsent{
+		RelationshipID:          c.RelationshipId,
+		TrustDomainID:           c.TrustDomainId,
+		ConsentStatement:        statement,
+		Signature:               sig,
+		SigningCertificateChain: certChain,
+		CreatedAt:               c.CreatedAt,
+		UpdatedAt:               c.UpdatedAt,
+	}, nil
+}
+
+func RelationshipConsentFromEntity(entity *entity.RelationshipConsent) *RelationshipConsent {
+	consent := &RelationshipConsent{
+		RelationshipId:   entity.RelationshipID,
+		TrustDomainId:    entity.TrustDomainID,
+		ConsentStatement: encoding.EncodeToBase64(entity.ConsentStatement),
+		CreatedAt:        entity.CreatedAt,
+		UpdatedAt:        entity.UpdatedAt,
+	}
+
+	if len(entity.Signature) > 0 {
+		sig := encoding.EncodeToBase64(entity.Signature)
+		consent.Signature = &sig
+	}
+
+	if len(entity.SigningCertificateChain) > 0 {
+		certChain := encoding.EncodeToBase64(entity.SigningCertificateChain)
+		consent.SigningCertificateChain = &certChain
+	}
+
+	return consent
+}
+
+// MapRelationshipConsents transforms a slice of RelationshipConsent entities to a slice of API RelationshipConsent representations.
+func MapRelationshipConsents(consents ...*entity.RelationshipConsent) []*RelationshipConsent {
+	cConsents := make([]*RelationshipConsent, len(consents))
+
+	for i, c := range consents {
+		cConsents[i] = RelationshipConsentFromEntity(c)
+	}
+
+	return cConsents
+}
+
 // MapTrustDomains transforms a slice of TrustDomain entities to a slice of API TrustDomain representation.
 func MapTrustDomains(trustDomains ...*entity.TrustDomain) []*TrustDomain {
 	cTrustDomains := make([]*TrustDomain, len(trustDomains))
@@ -180,3 +180,33 @@ func TestMapRelationships(t *testing.T) {
 		assert.Equal(t, ConsentStatus(relationships[i].TrustDomainBConsent), cRelation.TrustDomainBConsent)
 	}
 }
+
+func TestRelationshipConsentFromEntityAndBack(t *testing.T) {
+	eConsent := &entity.RelationshipConsent{
+		RelationshipID:          uuid.New(),
+		TrustDomainID:           uuid.New(),
+		ConsentStatement:        []byte(`{"consent_status":"approved"}`),
+		Signature:               []byte("signature"),
+		SigningCertificateChain: []byte("chain"),
+		CreatedAt:               time.Now(),
+		UpdatedAt:               time.Now(),
+	}
+
+	c := RelationshipConsentFromEntity(eConsent)
+	assert.Equal(t, eConsent.RelationshipID, c.RelationshipId)
+	assert.Equal(t, eConsent.TrustDomainID, c.TrustDomainId)
+	require.NotNil(t, c.Signature)
+	require.NotNil(t, c.SigningCertificateChain)
+
+	back, err := c.ToEntity()
+	require.NoError(t, err)
+	assert.Equal(t, eConsent, back)
+
+	unsigned := RelationshipConsentFromEntity(&entity.RelationshipConsent{ConsentStatement: []byte("{}")})
+	assert.Nil(t, unsigned.Signature)
+	assert.Nil(t, unsigned.SigningCertificateChain)
+
+	c.ConsentStatement = "not base64!"
+	_, err = c.ToEntity()
+	assert.ErrorContains(t, err, "cannot decode consent statement")
+}
@@ -36,6 +36,9 @@ type BundleDigest = string
 // CertificateChain X.509 certificate chain in PEM format
 type CertificateChain = string
 
+// ConsentStatement base64 encoded JSON statement of the consent of a trust domain to a relationship, as it was signed by its Harvester
+type ConsentStatement = string
+
 // ConsentStatus defines model for ConsentStatus.
 type ConsentStatus string
 
@@ -73,6 +76,22 @@ type Relationship struct {
 	UpdatedAt           time.Time        `json:"updated_at"`
 }
 
+// RelationshipConsent defines model for RelationshipConsent.
+type RelationshipConsent struct {
+	// ConsentStatement base64 encoded JSON statement of the consent of a trust domain to a relationship, as it was signed by its Harvester
+	ConsentStatement ConsentStatement `json:"consent_statement"`
+	CreatedAt        time.Time        `json:"created_at"`
+	RelationshipId   UUID             `json:"relationship_id"`
+
+	// Signature base64 encoded signature of the bundle
+	Signature *Signature `json:"signature,omitempty"`
+
+	// SigningCertificateChain X.509 certificate chain in PEM format
+	SigningCertificateChain *CertificateChain `json:"signing_certificate_chain,omitempty"`
+	TrustDomainId           UUID              `json:"trust_domain_id"`
+	UpdatedAt               time.Time         `json:"updated_at"`
+}
+
 // SPIFFEID defines model for SPIFFEID.
 type SPIFFEID = string
 
@@ -84,6 +103,7 @@ type TrustBundle = string
 
 // TrustDomain defines model for TrustDomain.
 type TrustDomain struct {
+	BundleVerifier    *string         `json:"bundle_verifier,omitempty"`
 	CreatedAt         time.Time       `json:"created_at"`
 	Description       *string         `json:"description,omitempty"`
 	HarvesterSpiffeId *SPIFFEID       `json:"harvester_spiffe_id,omitempty"`
@@ -104,62 +124,67 @@ type UUID = openapi_types.UUID
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9RZ6XLbOrJ+FRXv/JgZ2RE3LXTVqSlwkURKXCRSGw9zXVzATRRIcZWY8rvfopST2Ikz",
-	"OZO6y1z/MdhoNL4GGl83oE+Ym56yFEFUFtjTJ6xwQ3iyb02QRUKep3nXtj0vKqMU2YmWpxnMywgW2JNv",
-	"JwV8wLJXos6eB7v/fpqf7BJ7wiJUjmjsATvZl+hUnbCnIcM8YKcI3b8IHH/AymsG76owgDn28oCdYFHY",
-	"wc0SvNinLOn6Qc+BdlVGfpX0YIet94faw9f5ijKPUHCfcAlRUIbYE/lqks/9Ly8PWA7PVZRDD3v6/Y77",
-	"67wfv+inTgzdssPEVshLIB8FsCg7YB4s3DzKuoXBnjDHLuCI7kHUWfJ6+hw8ksNRz7up91K/V4aw59xM",
-	"YA+vnPJxejjyxjb08MkEjhkC0iMCdymXtL0RZfuQHkESjsdjZjLxPcdlyDHuE0PoMmOCcGgS+86zB4zr",
-	"9sOPXLuEXGhH6Hu0+w9DnOm5X/V6bqfYi1BPE+Te59V8jfOx+2OFmaj0OGFtiFORA4Zwk1pIFkV+1nIc",
-	"CNQANCILAlE06mk9FKbcsXYbfnWQFqkphrWrgJWwZFegmcXCQWYPM0BsBAuBi6zZuyFu7qXS3K2zw36d",
-	"LHdKKLP0njfEVuYPV5kXGzkGFyVJOxku84eLatxlFlKStJnzgi2zwc0muMjr9ywuDbEJAiGSAT7j9PNM",
-	"Fx2KXwmshcBqAwAtsnwDOo0FSEUWrLgzzinAaHyCKw/Oht5OZlkL+8rRia87lzaQXIvLveqfSAuRiyk/",
-	"F4/bMK0Hy8WS9RPcmQdi4PVD+jg5tStW6Y8n9PBa5KdjMJSkgR+PgYuIaLUx+PnZQsmUoUbXsqqF2jeD",
-	"vlbL2sJrl+phFUyrUcVyrdjX5dGmH2uDXbAQBwd+vDsG21ZcR6OoPlhok9NLrar7crNqz67Rtknpepo+",
-	"Nuloudy2Y2buaojGm2k9Xw1UfZ3oqRQ7bH3ZN0fiEC8ttEciowiwkSrf7duUhvyrUzGuPRfowdDUbJHh",
-	"9+YwKpC8Q/1YtZUZB6WJsJzQgsjPRcZCjTJxd318d4JCjEI9iKjTmqHYc41O+zzMp3UUUodUC+eSegGB",
-	"zAIwiw8tG8qA7vbOsxDfCOygWQldNLEx0NhA2c7XMgv8icAagAer+UBm8Zs2H6x2LKtPlikPy3nQ2NPN",
-	"yULhQtmIh90FIf86ioF/s6DLwowHu4A1xKZZkHDh67zkqAS1gnVmFCae7owWKGxwPFsoPEYzpsFZsCqm",
-	"AKgcWAnA0Bf5Tg/M44ByKrXUqKQ/H9Y+MUxmi8Fx2DaBFs83hlyPDpSFjJCZXoySNElBkiCuhiNE6IdK",
-	"jYmK0CMF1sZ04omqWATLzAkJU1wuU35xURYL1UXNLrZQdGTkqZwvRvSkURXHNtXBxkjTONmA6Yhnh3OC",
-	"H29oaeepfU+uHVMcbsXxUp9TsTLhlIK0UEV7ALfnZzjN9w4ZVgmveWbseuLFkNcBHQ0ELx+vW9c08v5Z",
-	"HnjgsIv5CvGjPlusWy+xEGdvKXJVXeV9M8X7B3ZykCca0KdgPoui9XFFiOtgrrDShHTIQIasWLa7xJ1N",
-	"crZQD5etYSHSo/BTmUhbRRlPyfNQJwpip862sZiXjnpChnMdCtL6XIHffrPQjVQEhX+HaH7KQoLBccA/",
-	"fWEh4ZSS64NPX6nNuywkxMLqc9TdWWjunpja44j4YAA4bfCr3AJSjsGdfQzT7mQKD0jZOH6RyWx66eLK",
-	"Ql2UsUA2eDKpvNn26uy2R3s3xU29s8hxQBe/xcGyK8AHgaABnuM6Fkq5IBBYIBNOM+J4WTLFlX7ZbKNi",
-	"Mhen/Yqr3ZJRh65kO3ObIvileBz42mGTqnKgko2FnFlmcLR/kiR72JabwWoQXsok1MR2IS4LnDgL1TYR",
-	"7TTvKwtBO9QEtfckjQ6vjXZmxmJmIflyrCj/MkYq0EDajoqWuw7q/bEOL0w7zrWJsMN9TecPsdrIG6Ga",
-	"eQLKzmeD94KlxkW6heRNERHTQKPFZQBl3vOz5XWw0uxsc1yofKwFx0MVomubG+p2SDHAplmq2HMwN/h2",
-	"u2R8Cxkb6ZJA/gQknyYZp44NfWeGnFuX1+pQ+UBKWDEamzOWSWfBMs1wUeTwarIgyi25C/u1hZgZqRIL",
-	"Plozsq+roqsxot7sxqfDYeonM5kDjQCArcTyTGj44MBv17jW8QoLVjwIhJmFZDC5MYxwZ6OpDG4M1MxX",
-	"N22VZQ/CVI55kJt0xohyUoz3HjvqJ+oF6aGpWUhm7xbEZnWQWRtMOWOamcy6ajJ6EaKQOMj7Zt8u4oio",
-	"v81IXLMBFuoyElhx8xTA5WZybSmxXFzwuSYKgubMRgP6ytBjUYuOl4A6SrulMV2EC41IKDJjZHF/slAt",
-	"1fRsvarsnXo1EtOH6LKdT6jjmeEhs58cxbaY5OvpeniqtZ3Hlam+C8XrdjFTzGJSBKKFZvhMW8ZGPtF3",
-	"575TOUJViqPSd3j2uIjjbJnU27Fun8cOQRErMAyhVKmLXb+YRTRFgenKQub8xOTJUt35rUtOJvZUdaI5",
-	"Z6tJHOzM4hDo+2Si9f3EkaXpwKMuW+CTJrkW1ED1hwkTWCjzgmg3zaGrFJHM5vyZiI8JBMJswJT0dj7j",
-	"QSlPGGWTNqpDjjZmWi4M1hTbVG7McZrrFiI8FT8t5wMlX9ZTCkfXOJDpaBWO+4r6T1nndX2ZwdO7JVeK",
-	"CohKvbTL6lYIQ9SVt79jdpblaQ097AHzIIpujQwirxv38R1DvF1+U/aSOEk84sQjhb/G4XV6b6tc4j1z",
-	"MIElXMMi6wD+GxXzAiqj8trzbvi8/91KXtoZb8HAqxQ6MzdSI0nctCKhRGIhovXQ5cSReMz2W05iPsCr",
-	"1Ho7MVIj8SLHMq4YB0rlj40YNZFzmpamflOu7RkdrGdM0sk76hfj9KIYAinH8lDmxau/+qD7yeLSrCVd",
-	"hovFlFwZtN9kMpR8aqSpx9FV2j7b3qoomqH7ekXipny7HDTOjB6wzC5LmHdl/X/+bj+24NHEHxnLenz+",
-	"2P+HZX14T/bXb4V/+8df3gtrKY2QkR4hertelG9Phv6IfhyOifEjPRyRjw7lu4+ky4wofzSyfXv0GnhV",
-	"Rd5b5NQ3uPFHxn70P36avDx+adN/ok2QL+8C1+wAKtXJgfn3lx8jhD106+vuZVEJT0WvTHvFMcp6DvTT",
-	"HPaK0s7LCAWd3E2TBLrl7QKXw6JKyl4Byw/Yq8B/N+w7CHrUwjsA366Ssgvghx+iKd7AyWFZ5ejDm9OG",
-	"vz5s7825hond2S3CKPtXD3sO7RJ6z3b5I/ox8MkThT/huPktET2W0enPsFHkdbb/kkMfe8L+Y/D18WHw",
-	"+eVhsNmIfKdZ5lVRPnvpyY7Qs/3s3un1Z6PfsvD3Zn55fmSf4M+GGt0Q/jZC6dS/teL893jh/KoXzq96",
-	"UWXe/3BkfEPhN7J4FY9vILy3qe8t0Q9j6Ifb8l6q0DVxOhVE/q3nRRb5PnwaDF5bGjRpfkxS23uOPIjK",
-	"yI9g/vN8Rk/eOSd6FCC7rHL403em4g/Nf/LEZM9G5p6yTb8/KoPBdc2b3lpXSpliktbcKVdzv5ZMnpAO",
-	"O8L48s2ZsbeXruZuiG9nSWluFfywIxrNEAilFa6ysWlUY3My92Fj76XkpmPgF5UPSMVwCZk/EhKSQue0",
-	"rh0Dv8pxd4va/PYeV99C7v669r2/9w3o3XQ+O9eLUE/SVeW9Z6pPVpcln+2qDNM86rjNwp5+/2Rh8JJF",
-	"OSye7dLCniyMGE3oITGiaMrCHizsCK/PkXfrAZ5hurg7bgtm5I6CenWR2NHKE0b8Va8Uv77pZ5WTRO7z",
-	"EV5vY+TpsRGaw7y71bUxzoHVQfzc5sHK5VcBEC6EZq4bX6B4s1DPpMzi6lDb+U7R5nY2U/zTUJgOiLTZ",
-	"D5HIK6fYiJyBcvXHHORqfekKLoUfMtupgRMs5xO3IEO+Jbr7Mvby8CP/JsT3/vnB1uZd2wAHuz3OyJ3P",
-	"ULtydjmtvb0PcIX9Vf9yXo8jN0dnfYME8goJKa18lp8tnVKUY2m6nS3gXC0XxrA6J+xgYUwUkhrui2If",
-	"GMvVWg7bDPCuLNObwSFx6/R6nA9Pwc2/jw8WlkM/h0X4HEbo7iF+A1rAcwWRC5/vKfTWM771vD6aN3Hp",
-	"ERb28sMAvHPev2HSfHMeXk9yPxN3D3tlaJe9HGY57LjsRgXwXmLv/1RR/aoY+2vv77/fa0P7sf3Y+/vf",
-	"/v5uiRXaeQ2LEubPdzr8E3npC5v+S7XAL6atFDmpnXcXrWfnC7n81MZnHvo/S3s3Z3+c/d7LUd/6/gbt",
-	"7Rh8uAfJBzc9/WJGuu3F/6s7wMsDVkC3yqPyqncbfD+wX4O2SxGdxIF2DvPpHzC7u+HD/Yewztq996v1",
-	"sCwz7KUzHiE/xZ5QlSQPWJpBZGcR9oRhN5fC4t7z8l8BAAD//7tN79hhGwAA",
+	"H4sIAAAAAAAC/9RZ55LiyJZ+FYK9P+4dqho5jCpi4oYsSCADEghp1Fshk3JIKUoWNNHvviFoU1VdPW1i",
+	"d3a3/7TIPHnynMwvv2Pqz6GXZ6ccAliVw4c/h6UXgcy5flKnmCuKvOi/Hd+PqziHTqoW+QkUVQzK4UPg",
+	"pCW4G56eDfX6fND/H+RF5lTDh2EMqykxvBtmzjnO6mz4MCHJu2EWw9svFEHuhtXlBG6iIATF8MPdMANl",
+	"6YRXTeDsZKe0n6cGLnDqKg7qdAB62wafxO6+7FdWRQzD24ZrAMMqGj5gzzb5OP/hw92wAE91XAB/+PDH",
+	"ze4v+77/LJ+7CfCq3ia6hn4K2DgEZdUb5oPSK+JTfzDDh6HrlGBKDADsNfkDbUndY5PpwL+KD/JgUEVg",
+	"4F5VDO+eORUgxGTqzxzgI/M5mJEoIKYo4uEe5vhT3AkAMQUYmM1m5Hwe+K5HYjMkQCfAI2co6hLY8CvP",
+	"7oZMfx9B7DkVYCInhl9be3g3QciB90Vu4PWCgxgOVE4afDzN53be9/9obiHIA4bb6gIvMJTOXUdtKAkC",
+	"u+gYhgqVkGoFmgoFQW/4ZsLxzLHxWnZjiqvcEqLGk6kNt6Y3VLtIOFOizQWF7jgbUmdJdYwJYh3EyjK2",
+	"J/OwTdeGHEk0cWB1oZNY8yKxQisl1FlO834MkVjzrOi3MRvKad4uWc6R6PCqkzpL27c0rnWhDUMulihk",
+	"wWhPC01wcXbD0TakNjuKIgSabaleYkXlAk1tmCeEkSm9DVCmMt0dsZ8vTh0YyUc3uRgeoUOpEdYHJcgw",
+	"G2Irnl0Kx32UN+P1ak0HKeIuQyH0RxFxnGfdhpZHszkxuZRFdgwnojgOkhnlQTTe7HR2+WTDlCfx6aWq",
+	"G64JrHCkNpK68ru1Ym5Cvp7WNNMJI02a7kaJOjbClTA22ZlxDPedsI2ncWPacFcQa7VuRlK76Z48vevS",
+	"yvNVbWYR8Xq972bk0lMhgbR8s9yMFW2barmYuHRzPrRH1EzWNjxAgZQ50Ip14I0cXIXBxa1Jz1lyxHhi",
+	"qY5AsgdrEpdQMuAoURx5wQBxzq3nBCewS4G0YSvPPWOEGBngEhhpYYxnWxKnnxqYHYqo4Js4ws1cjZai",
+	"cqZCiaaoRWJ2dCRRRH93vg3ZlqPH7Ybr0UQnlEqH8n65lWgqmHO0TrHUZjmWaOQqzYYbg6a1+TpnQbUM",
+	"W4ffZTaMVvJOMI0zhMFlmlDBVYMmcQuWMkJaF9p2hYFVoLGiq6D4BjQnvbSQ3NA7SqbD45MNo2O8IFuE",
+	"pjYlT1EKQ204StdWhaGF1nGMu7VSqXg6Wk6aAJ2ki9X4OOnaUE2WO11qpiZuQz0i+bNeYRbGiSJAlGgK",
+	"Uc2slQStUS2WQaPzc19QhDJcn9wItYT1OmdXZ3m1UjzYGokN4yMp8VKxmhLzVpFdx1LGOz3Pk3RH8VOW",
+	"nixRdrYjRMNXRr7UuJYw2QuztbbEE3nOyCVmw5rwKcRZPgG+OLhYVKes6luJ5wtnXdqGRDzm/GK27TxL",
+	"L0ZP0tinTCNha8hOR3S57fzUhoyzx7FNfZEOLY+MTHpuSnOV0nhquYjj7XGDCttwKdPiHHOxUAK0UHVG",
+	"6i3mBV0q5nmv2xDzcSSrUnEvyzMee5poaIkaymKfCEXlKhnU3cuEE7dPNfX77za8kgons28QzXdZiNMZ",
+	"hgqyzyzEZTm2NQPigu/eZCEu4TYfUXdjoaWXkY3PoImpU4BvkYvUUZiUUDf20S2nH5NZCpP04+cxic7P",
+	"Pa5s2KOMpiSdxdLaX+wvrrE/OgaPWFqvkWEoTXhtB01vKDYMOZViGaZnoZwJQ46mJNRtpwwriZaw0c67",
+	"fVzOlwI/qpnGq0hl4omOu3RwlF0Lx3GgmrtckUIFa23oLk46QwSZKDqTrtqNN+PoXKWRKnQrYV0i6BNX",
+	"71PByYuRvOJUs0Hxgy+qRHRp1SdyJpxsKJ2PNR6cZ1ChVCrvpmXHXMbN4dhEZ7KbFeqcM5BA1VgzUVpp",
+	"x9ULn4Onpyed9cO1ysSaDaVdGaN8qBLCOgQS6wen9WW8UZ3T7rhS2EQNj2YdwUtX6Mp+gpOUQ9B4eWBA",
+	"obPdfk0GNtR34jkFbEaJAYGRbpPommFFjNdUl9qsA0pMaSGeWQuazBfhOj8hgsAg9XyFVnvMiEaNDckF",
+	"pqArNt6SUqApgqeSgtYas8w0+SBdSAzVchTlyIm04Fo2NNn9FlF7XqGpDUuF3MKGEjW/Mgx3YyNeoq4M",
+	"1C43V2mFpk2OlxKWKiziRApSWs4OPj0dpcoZapGl2lCibxqEdmNKtEPxjM6fLHJbtydiFcEINaVDe+hW",
+	"SYw2ryMS0+4oG/YRidowy5wC69380uFCtTojS1XgONVdTMfEhSRmghofzyF+FI21zq+ilYqmOHYiJeGQ",
+	"2bARG2Kx3dSOoVz01AoAPO+Xc/z4RLKAPMyPQlfOiy2/nWSNavhMlWtGJFz2q4VslfMyFGy4QBbqOtGL",
+	"uWY8jdza5epKmFaBy9LHVZKc1mmzn2nO08xFcXRDTSIg1srKGJWLmMBxit/Y0FpmZJGuFSPoPGw+d3jF",
+	"jZeMo6RJaFilGWqHdK6OgtSVRH7s4+c9FWAWtuWUUAkmKRna8OSHscEXwJPLWKIL9glNjimguMWYrIj9",
+	"csFSlTQn5V3eKi423Vl5tdJpS+hyqbVmeaHZEPUVJFsvx3KxbngcgZcklIh4E81GsvKXrPM8vzyB7M2U",
+	"K4clgJVWORXIAPx+gihqijwoP4l/ShC9m5r+pzOoirqsBn6e9ZlZlQ+cQQFSp1dXRvHpbuCUg7gatE45",
+	"KOMQAn/gXgZxVQ6WTtGAsgKFDV+kcOAiXizjHPmLtHGhnDsHOnCMTazEQmdlHCEbJiYzCCp3HL7WN6ic",
+	"CJWZyJl5QS5md8QkzMTkjM9M3YzXjIh4cN/5PHl0MTRyDCJWYhGxWK52cREKcRubGFl72L72ebLzFzzi",
+	"H6ReJvKW9MXFrdRihFKA8snCJqnFk5HPCFMh6fM5qZJYs5JYfifpwlRiqanE0o4Akd+/c/b1tQgBsC8t",
+	"/hg6p1ORN8Af3g19AOPrxwlAv1/3/g1FrFO9KjkwBEPvEfQeR55jwO/lXlYY6FvqQAoqsAXlqTfw/1Ah",
+	"xcEqri4D/2qf//dWUaKhvzQGXMTIXXg9MoRdJ6By3KNiO/F6NBxPhz0jku/ARex8Q+ihepYSCZF1E1fY",
+	"Y9vDzM34ytKuwo2zIMLtgkz78T7sCkl+lnUOkxJpIrHCJdi804J0dW63oiaB1YrHNjoRtCcJiAE+VZXj",
+	"9CLuHx1/U5btxHt+IklbvTwOAiGnd8OTU1Wg6N/3f/7h3HfUvYXck7Z9//h+9G/bfvfW2D9fD/7r3/94",
+	"C9ZiHkM9PwL48rzwwJlPgilxP5mhs3tiMsXuXTzw7jGPnOLBdOoEzvS54XUd+y8tx1/ZjdyTzn3w/s/5",
+	"h/vP38QPfKPYhzcNV50QyHXmguJrFtQjMIDXuZ7j4gpkZU9t5TE+DVwQ5AXoSbGoYhj2416epsCrrtxY",
+	"gLJOq0EJqnfDZ8B/E/a9CVrcgZsBgVOnVQ/gu29aU74wpwBVXcB3L14b8vyxvbXn9hk5/+xjL4BTAf/R",
+	"qb5FPzoyf8CRBwSxXhPRfRVnP8JGsd/r/kcBguHD8D/GXxo/449dn/FuJ7C95DXuPN7izqPz+DEmfW/1",
+	"Sxb+Ws0v7w+dDHxvqd4vYa8r5F78tRb3v8cL91e9cH/Vi/rk/w8j4xWFX8niGR5fmPDWpb51RN/E0Dev",
+	"5a1Q8fxBMV+u76eC6HXVY/k8LfvB67/Jf7j7Wx7n88TuJzDW531OVRffRZb2WfDjqhiGj8/af4/epz7h",
+	"X57O677ia5j/uOV/O65fn/DXlt+9AZdvP4W3AKupAs9zAvvSpfIUBwF4GI+fbzhu8+KY5o7/GPsA9qcK",
+	"iu8nYMT8Dexoz0HwlzXHZ7j8RT/aWUytA+5YwWhahePLlrX8rSZXEk6mnWXIF+uwFS0WFU0D1T//ZqzE",
+	"P/TFxQTZL9LK2suIaaCtqnN9MXGR9F2r6LvMOkStcxDTq4yOnBU2xGTdQyX2iIpQjNxs27g6cpGSvuWy",
+	"ezPZv3LkrRX/tb+3CxhcZT4617e0r7XWGz3tP+0+rXt06irKi7gnDXv48Mef9hCcT3EBykensocP9hCd",
+	"zokJOsUJ3B7e2cMjuDzG/nWG8nXLQ7xZV5JTbxo2m7NITzc+N2UvWi0HzVX+VLtp7D0eweW6RuKPLdea",
+	"y74F1CUIQ21M4eM3S208dhNS3BlVrW0bcDhrlcoTJtGIMlGNwC27wjkt5CCbcPwYzdvDBAqsnCV67I7l",
+	"SzBjANNoa4/zcMQ8OW5DueF6OfdKLGI7tG+uDT/cfcu/Ofq1f0G4d1jP0SnT6Y4LzAhI3KgW52zrHwIK",
+	"kelf9a9gtST2Cvik7SCHXQAq5nVAs4u1WwlSIvL7xQoslWqlT+qnlB6v9LmM4ZNDWR5Cfb3ZSlF3olhP",
+	"kojd2Ey9Jr8cl5MsvPr3/s4eFiAoQBk9RjG8eYhcDS3BUw2gBx5vOd91Znadef40r8OVj9rDD98E4C1I",
+	"/2Q0ugHysQHF7bm/YAk/Lo9vbfd3RJ8Xj+j5JvqLDkTkVIMCnApwbVH0/AFuheThh0rHZyXHPwe//XGr",
+	"gJz77v3gt3/99mYhEX1qZDzeOPQH4stnCv6pjPcXk7McurlT9O2ER/czI31Xx0fy+l9L7q7O/lxge+37",
+	"C2uvb+fdDSTvvDz7xTB2vYv/V5Vun0oBry7i6qL1F3x75V9A28eV67sHTgEK/pOZfQfk7van9l7bbfaL",
+	"9qiqTsMPvfIYBvnwAdZpejfMTwA6p3j4MBxeXYrK28yH/xoAatedfcMfAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
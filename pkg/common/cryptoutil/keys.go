@@ -7,6 +7,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -233,3 +234,37 @@ func verifyECPrivateKey(cert *x509.Certificate, privateKey crypto.PrivateKey) er
 
 	return nil
 }
+
+// ErrInvalidSignature is returned when a signature does not match the payload and the public key.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// PublicKeyFingerprint returns the hex encoded SHA-256 digest of the DER encoded SubjectPublicKeyInfo of the public key.
+func PublicKeyFingerprint(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed marshaling public key: %w", err)
+	}
+
+	return hex.EncodeToString(CalculateDigest(der)), nil
+}
+
+// VerifySignature verifies the signature of the SHA-256 digest of the payload, made with the private key of
+// the given ECDSA or RSA PKCS #1 v1.5 public key.
+func VerifySignature(publicKey crypto.PublicKey, payload, signature []byte) error {
+	digest := CalculateDigest(payload)
+
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest, signature) {
+			return ErrInvalidSignature
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature); err != nil {
+			return ErrInvalidSignature
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+
+	return nil
+}
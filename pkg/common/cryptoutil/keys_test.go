@@ -1,7 +1,9 @@
 package cryptoutil
 
 import (
+	"crypto"
 	"crypto/ecdsa"
+	"crypto/rand"
 	"crypto/rsa"
 	"testing"
 
@@ -186,3 +188,47 @@ func TestVerifyCertificatePrivateKeyEC(t *testing.T) {
 	require.Error(t, err)
 	assert.Equal(t, "certificate public key does not match private key", err.Error())
 }
+
+func TestPublicKeyFingerprint(t *testing.T) {
+	key, err := GenerateSigner(ECP256)
+	require.NoError(t, err)
+
+	fingerprint, err := PublicKeyFingerprint(key.Public())
+	require.NoError(t, err)
+	assert.Len(t, fingerprint, 64)
+
+	again, err := PublicKeyFingerprint(key.Public())
+	require.NoError(t, err)
+	assert.Equal(t, fingerprint, again)
+
+	other, err := GenerateSigner(ECP256)
+	require.NoError(t, err)
+	otherFingerprint, err := PublicKeyFingerprint(other.Public())
+	require.NoError(t, err)
+	assert.NotEqual(t, fingerprint, otherFingerprint)
+
+	_, err = PublicKeyFingerprint("not a key")
+	require.Error(t, err)
+}
+
+func TestVerifySignature(t *testing.T) {
+	payload := []byte("payload")
+
+	for _, keyType := range []KeyType{ECP256, RSA2048} {
+		key, err := GenerateSigner(keyType)
+		require.NoError(t, err)
+
+		signature, err := key.Sign(rand.Reader, CalculateDigest(payload), crypto.SHA256)
+		require.NoError(t, err)
+
+		err = VerifySignature(key.Public(), payload, signature)
+		require.NoError(t, err)
+
+		err = VerifySignature(key.Public(), []byte("other payload"), signature)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	}
+
+	err := VerifySignature("not a key", payload, []byte("signature"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported public key type")
+}
@@ -15,12 +15,41 @@ const (
 	ConsentStatusPending  ConsentStatus = "pending"
 )
 
+// ChangeEventKind is the kind of change recorded in the change log.
+type ChangeEventKind string
+
+const (
+	ChangeEventKindBundle       ChangeEventKind = "bundle"
+	ChangeEventKindRelationship ChangeEventKind = "relationship"
+	ChangeEventKindTrustDomain  ChangeEventKind = "trust_domain"
+)
+
+// AuditAction is the action recorded by an event of the audit log.
+type AuditAction string
+
+const (
+	AuditActionBundleReplace      AuditAction = "bundle.replace"
+	AuditActionBundleReset        AuditAction = "bundle.reset"
+	AuditActionBundleResetApprove AuditAction = "bundle.reset_approve"
+	AuditActionBundleRollback     AuditAction = "bundle.rollback"
+	AuditActionHarvesterOnboard   AuditAction = "harvester.onboard"
+	AuditActionJoinTokenCreate    AuditAction = "join_token.create"
+	AuditActionJoinTokenRevoke    AuditAction = "join_token.revoke"
+	AuditActionRelationshipCreate AuditAction = "relationship.create"
+	AuditActionRelationshipUpdate AuditAction = "relationship.update"
+	AuditActionRelationshipDelete AuditAction = "relationship.delete"
+	AuditActionTrustDomainCreate  AuditAction = "trust_domain.create"
+	AuditActionTrustDomainUpdate  AuditAction = "trust_domain.update"
+	AuditActionTrustDomainDelete  AuditAction = "trust_domain.delete"
+)
+
 type TrustDomain struct {
-	ID          uuid.NullUUID
-	Name        spiffeid.TrustDomain
-	Description string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID             uuid.NullUUID
+	Name           spiffeid.TrustDomain
+	Description    string
+	BundleVerifier string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 type Relationship struct {
@@ -35,10 +64,63 @@ type Relationship struct {
 	UpdatedAt           time.Time
 }
 
+// RelationshipConsent is the consent of a trust domain to a relationship, signed by its Harvester.
+// The statement is kept as it was signed, so that the peers can verify the signature.
+type RelationshipConsent struct {
+	RelationshipID          uuid.UUID
+	TrustDomainID           uuid.UUID
+	ConsentStatement        []byte
+	Signature               []byte
+	SigningCertificateChain []byte
+	CreatedAt               time.Time
+	UpdatedAt               time.Time
+}
+
+// ConsentStatement is the statement signed by a Harvester to set the consent status of its trust domain in a relationship.
+type ConsentStatement struct {
+	RelationshipID uuid.UUID     `json:"relationship_id"`
+	TrustDomain    string        `json:"trust_domain"`
+	ConsentStatus  ConsentStatus `json:"consent_status"`
+	SignedAt       time.Time     `json:"signed_at"`
+}
+
+// OnboardStatement is the statement signed by a Harvester to onboard, either with its key and a join token
+// bound to the fingerprint of the key, or without a join token, with a key proving the control of the trust domain.
+type OnboardStatement struct {
+	TrustDomain string `json:"trust_domain"`
+	JoinToken   string `json:"join_token,omitempty"`
+	// Nonce is the nonce of the OnboardChallenge issued by the Galadriel Server, required to onboard without a join token.
+	Nonce    string    `json:"nonce,omitempty"`
+	SignedAt time.Time `json:"signed_at"`
+}
+
+// OnboardChallenge is a nonce issued to a Harvester to onboard by proving the control of its trust domain. The
+// Harvester includes the nonce in the onboard statement it signs, and it can only be used once, before it expires.
+type OnboardChallenge struct {
+	Nonce         string
+	TrustDomainID uuid.UUID
+	ExpiresAt     time.Time
+	CreatedAt     time.Time
+}
+
 type JoinToken struct {
-	ID              uuid.NullUUID
-	Token           string
-	Used            bool
+	ID uuid.NullUUID
+	// Token is the plaintext join token. It is only set when the token is generated, and it is never stored.
+	Token string
+	// TokenHash is the salted SHA-256 hash of the join token, which is stored instead of the token.
+	TokenHash []byte
+	TokenSalt []byte
+	// TokenHint is the last characters of the join token, to tell the tokens apart and to look them up.
+	TokenHint string
+	// Used is true when the join token was used as many times as allowed by MaxUses.
+	Used     bool
+	MaxUses  int32
+	UseCount int32
+	// HarvesterKeyFingerprint is optional, when set, only the Harvester holding the key with this
+	// hex encoded SHA-256 fingerprint can be onboarded with the join token.
+	HarvesterKeyFingerprint string
+	// SourceCIDR is optional, when set, the Harvester can only be onboarded with the join token from this network.
+	SourceCIDR      string
 	TrustDomainID   uuid.UUID
 	TrustDomainName spiffeid.TrustDomain
 	ExpiresAt       time.Time
@@ -55,6 +137,91 @@ type Bundle struct {
 	SigningCertificateChain []byte
 	TrustDomainID           uuid.UUID
 	TrustDomainName         spiffeid.TrustDomain
+	PinnedVersion           int64 // Version of the bundle history pinned by an admin, 0 if not pinned.
 	CreatedAt               time.Time
 	UpdatedAt               time.Time
 }
+
+// QuarantinedBundle is a federated bundle rejected by a Harvester, kept with the reason of the rejection until an
+// admin accepts or discards it.
+type QuarantinedBundle struct {
+	ID            string // Hex encoded SHA-256 digest of the trust domain name and the bundle data.
+	Bundle        *Bundle
+	Reason        string
+	QuarantinedAt time.Time
+}
+
+// BundleVersion is an entry of the append-only history of the bundles uploaded for a trust domain.
+type BundleVersion struct {
+	ID                      uuid.NullUUID
+	TrustDomainID           uuid.UUID
+	Version                 int64 // Monotonic version number, starting at 1 for each trust domain.
+	Data                    []byte
+	Digest                  []byte
+	Signature               []byte
+	SigningCertificateChain []byte
+	UploadedBy              string // ID of the Harvester instance that uploaded the bundle, if any.
+	CreatedAt               time.Time
+}
+
+// HarvesterLease grants a Harvester instance the exclusive right to upload the bundle of its trust domain
+// until the lease expires, allowing several Harvester instances to run for the same trust domain.
+type HarvesterLease struct {
+	TrustDomainID uuid.UUID
+	InstanceID    string
+	ExpiresAt     time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// BundleResetApproval is the approval of an admin to accept the next bundle uploaded for a trust domain
+// even if it does not continue the previous bundle, e.g. after all the authorities of its SPIRE Server were replaced.
+// The approval is consumed by the first bundle accepted thanks to it.
+type BundleResetApproval struct {
+	TrustDomainID uuid.UUID
+	CreatedAt     time.Time
+}
+
+// SigningKey is a signing key shared by the Galadriel Server replicas.
+// The private key is encrypted, the datastore never holds it in the clear.
+type SigningKey struct {
+	ID           string
+	EncryptedKey []byte
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ServerLease elects the Galadriel Server replica that runs a background task, e.g. the JWT key rotation,
+// until the lease expires.
+type ServerLease struct {
+	Name      string
+	HolderID  string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ChangeEvent is an entry of the change log of the bundles and relationships, ordered by its monotonic ID.
+// The bundle and trust domain changes concern a single trust domain, the relationship changes also concern the peer.
+type ChangeEvent struct {
+	ID                  int64
+	Kind                ChangeEventKind
+	TrustDomainID       uuid.UUID
+	TrustDomainName     spiffeid.TrustDomain
+	PeerTrustDomainID   uuid.NullUUID // Set for relationship changes only.
+	PeerTrustDomainName spiffeid.TrustDomain
+	CreatedAt           time.Time
+}
+
+// AuditEvent is an entry of the audit log, ordered by its monotonic ID. Each event holds the hash of the
+// previous one, so that altering or deleting an event breaks the hash chain.
+type AuditEvent struct {
+	ID        int64
+	Actor     string
+	Action    AuditAction
+	Target    string
+	Details   string
+	PrevHash  []byte
+	Hash      []byte
+	CreatedAt time.Time
+}
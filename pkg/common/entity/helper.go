@@ -1,9 +1,21 @@
 package entity
 
 import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/google/uuid"
 )
 
+// GenesisAuditHash is the previous hash of the first event of the audit log.
+var GenesisAuditHash = make([]byte, sha256.Size)
+
 // FilterRelationships filters a slice of Relationship entities based on a trust domain ID and consent status.
 // If the trust domain ID is nil, it filters based on the consent status only.
 // If the trust domain ID is not nil, it filters based on both the trust domain ID and the consent status.
@@ -38,3 +50,140 @@ func FilterRelationships(relationships []*Relationship, status ConsentStatus, tr
 	// Trim the slice to the actual length to free up unused capacity
 	return filtered[:]
 }
+
+// ComputeHash returns the SHA-256 hash chaining the event to the previous one. It covers the previous hash
+// and the content of the event, but not its ID, which is assigned when the event is stored.
+func (e *AuditEvent) ComputeHash() []byte {
+	content, _ := json.Marshal(struct {
+		Actor     string `json:"actor"`
+		Action    string `json:"action"`
+		Target    string `json:"target"`
+		Details   string `json:"details"`
+		CreatedAt string `json:"created_at"`
+	}{
+		Actor:     e.Actor,
+		Action:    string(e.Action),
+		Target:    e.Target,
+		Details:   e.Details,
+		CreatedAt: e.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+
+	h := sha256.New()
+	h.Write(e.PrevHash)
+	h.Write(content)
+	return h.Sum(nil)
+}
+
+// Marshal returns the bytes of the statement that are signed.
+func (s *ConsentStatement) Marshal() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// Marshal returns the bytes of the statement that are signed.
+func (s *OnboardStatement) Marshal() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// ParseOnboardStatement parses a signed onboard statement, rejecting the statements with unknown or missing fields.
+func ParseOnboardStatement(data []byte) (*OnboardStatement, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var s OnboardStatement
+	if err := decoder.Decode(&s); err != nil {
+		return nil, fmt.Errorf("failed to parse onboard statement: %w", err)
+	}
+
+	switch {
+	case s.TrustDomain == "":
+		return nil, errors.New("onboard statement has no trust domain")
+	case s.SignedAt.IsZero():
+		return nil, errors.New("onboard statement has no signing time")
+	}
+
+	return &s, nil
+}
+
+// ParseConsentStatement parses a signed consent statement, rejecting the statements with unknown or missing fields.
+func ParseConsentStatement(data []byte) (*ConsentStatement, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var s ConsentStatement
+	if err := decoder.Decode(&s); err != nil {
+		return nil, fmt.Errorf("failed to parse consent statement: %w", err)
+	}
+
+	switch {
+	case s.RelationshipID == uuid.Nil:
+		return nil, errors.New("consent statement has no relationship ID")
+	case s.TrustDomain == "":
+		return nil, errors.New("consent statement has no trust domain")
+	case s.ConsentStatus == "":
+		return nil, errors.New("consent statement has no consent status")
+	case s.SignedAt.IsZero():
+		return nil, errors.New("consent statement has no signing time")
+	}
+
+	return &s, nil
+}
+
+// joinTokenHintChars is the number of trailing characters of a join token kept as its hint.
+const joinTokenHintChars = 4
+
+// joinTokenSaltSize is the size in bytes of the random salt of a join token hash.
+const joinTokenSaltSize = 16
+
+// JoinTokenHint returns the last characters of the join token, or an empty string when the token is too short
+// to disclose any of its characters.
+func JoinTokenHint(token string) string {
+	if len(token) <= 2*joinTokenHintChars {
+		return ""
+	}
+
+	return token[len(token)-joinTokenHintChars:]
+}
+
+// HashJoinToken returns the SHA-256 hash of the salt and the join token.
+func HashJoinToken(token string, salt []byte) []byte {
+	h := sha256.New()
+	h.Write(salt)
+	h.Write([]byte(token))
+	return h.Sum(nil)
+}
+
+// HashToken replaces the plaintext token of the join token by its salted hash and its hint,
+// so that the join token can be stored.
+func (jt *JoinToken) HashToken() error {
+	salt := make([]byte, joinTokenSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to generate join token salt: %w", err)
+	}
+
+	jt.TokenSalt = salt
+	jt.TokenHash = HashJoinToken(jt.Token, salt)
+	jt.TokenHint = JoinTokenHint(jt.Token)
+	jt.Token = ""
+
+	return nil
+}
+
+// MatchesToken reports whether the plaintext token hashes to the hash of the join token, in constant time.
+func (jt *JoinToken) MatchesToken(token string) bool {
+	if len(jt.TokenHash) == 0 {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(jt.TokenHash, HashJoinToken(token, jt.TokenSalt)) == 1
+}
+
+// MaskedToken returns the hint of the join token with the rest of the token masked, so that it can be displayed
+// without disclosing a token that can still be used.
+func (jt *JoinToken) MaskedToken() string {
+	return "****" + jt.TokenHint
+}
+
+// IsExpired reports whether the join token is expired at the given time.
+func (jt *JoinToken) IsExpired(now time.Time) bool {
+	return !now.Before(jt.ExpiresAt)
+}
@@ -2,9 +2,11 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFilterRelationships(t *testing.T) {
@@ -38,3 +40,116 @@ func TestFilterRelationships(t *testing.T) {
 	assert.Equal(t, relationships[0].TrustDomainAConsent, filtered[0].TrustDomainAConsent)
 	assert.Equal(t, relationships[0].TrustDomainBConsent, filtered[0].TrustDomainBConsent)
 }
+
+func TestAuditEventComputeHash(t *testing.T) {
+	event := &AuditEvent{
+		Actor:     "admin",
+		Action:    AuditActionTrustDomainCreate,
+		Target:    "td1.org",
+		PrevHash:  GenesisAuditHash,
+		CreatedAt: time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	hash := event.ComputeHash()
+	assert.Len(t, hash, 32)
+
+	// the hash does not depend on the ID nor on the location of the creation time
+	event.ID = 42
+	event.CreatedAt = event.CreatedAt.In(time.FixedZone("UTC-3", -3*60*60))
+	assert.Equal(t, hash, event.ComputeHash())
+
+	changed := *event
+	changed.Target = "td2.org"
+	assert.NotEqual(t, hash, changed.ComputeHash())
+
+	changed = *event
+	changed.PrevHash = hash
+	assert.NotEqual(t, hash, changed.ComputeHash())
+}
+
+func TestParseConsentStatement(t *testing.T) {
+	statement := &ConsentStatement{
+		RelationshipID: uuid.New(),
+		TrustDomain:    "td1.org",
+		ConsentStatus:  ConsentStatusApproved,
+		SignedAt:       time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	data, err := statement.Marshal()
+	require.NoError(t, err)
+
+	parsed, err := ParseConsentStatement(data)
+	require.NoError(t, err)
+	assert.Equal(t, statement, parsed)
+
+	_, err = ParseConsentStatement([]byte(`{"relationship_id":"` + statement.RelationshipID.String() + `","trust_domain":"td1.org","consent_status":"approved","signed_at":"2023-06-01T12:00:00Z","extra":true}`))
+	assert.ErrorContains(t, err, "failed to parse consent statement")
+
+	_, err = ParseConsentStatement([]byte(`{"relationship_id":"` + statement.RelationshipID.String() + `","consent_status":"approved","signed_at":"2023-06-01T12:00:00Z"}`))
+	assert.EqualError(t, err, "consent statement has no trust domain")
+
+	_, err = ParseConsentStatement([]byte(`not json`))
+	assert.ErrorContains(t, err, "failed to parse consent statement")
+}
+
+func TestParseOnboardStatement(t *testing.T) {
+	statement := &OnboardStatement{
+		TrustDomain: "td1.org",
+		SignedAt:    time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	data, err := statement.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, `{"trust_domain":"td1.org","signed_at":"2023-06-01T12:00:00Z"}`, string(data))
+
+	parsed, err := ParseOnboardStatement(data)
+	require.NoError(t, err)
+	assert.Equal(t, statement, parsed)
+
+	_, err = ParseOnboardStatement([]byte(`{"trust_domain":"td1.org","signed_at":"2023-06-01T12:00:00Z","extra":true}`))
+	assert.ErrorContains(t, err, "failed to parse onboard statement")
+
+	_, err = ParseOnboardStatement([]byte(`{"signed_at":"2023-06-01T12:00:00Z"}`))
+	assert.EqualError(t, err, "onboard statement has no trust domain")
+
+	_, err = ParseOnboardStatement([]byte(`{"trust_domain":"td1.org"}`))
+	assert.EqualError(t, err, "onboard statement has no signing time")
+}
+
+func TestJoinTokenHashToken(t *testing.T) {
+	token := "6e3f9a2c-8b1d-4c7e-9f0a-5d2b8c1e2f4e"
+	jt := &JoinToken{Token: token}
+	require.NoError(t, jt.HashToken())
+
+	assert.Empty(t, jt.Token)
+	assert.Equal(t, "2f4e", jt.TokenHint)
+	assert.Equal(t, "****2f4e", jt.MaskedToken())
+	assert.Len(t, jt.TokenSalt, joinTokenSaltSize)
+	assert.Equal(t, HashJoinToken(token, jt.TokenSalt), jt.TokenHash)
+
+	assert.True(t, jt.MatchesToken(token))
+	assert.False(t, jt.MatchesToken("6e3f9a2c-8b1d-4c7e-9f0a-5d2b8c1e0000"))
+
+	// the same token is hashed with another salt
+	other := &JoinToken{Token: token}
+	require.NoError(t, other.HashToken())
+	assert.NotEqual(t, jt.TokenHash, other.TokenHash)
+	assert.True(t, other.MatchesToken(token))
+
+	// a join token without hash matches no token
+	assert.False(t, (&JoinToken{}).MatchesToken(""))
+}
+
+func TestJoinTokenHint(t *testing.T) {
+	assert.Equal(t, "2f4e", JoinTokenHint("6e3f9a2c-8b1d-4c7e-9f0a-5d2b8c1e2f4e"))
+
+	// short tokens are not disclosed
+	assert.Empty(t, JoinTokenHint("8c1e2f4e"))
+}
+
+func TestJoinTokenIsExpired(t *testing.T) {
+	now := time.Now()
+	jt := &JoinToken{ExpiresAt: now.Add(time.Minute)}
+	assert.False(t, jt.IsExpired(now))
+	assert.True(t, jt.IsExpired(now.Add(time.Minute)))
+}
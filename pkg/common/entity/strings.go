@@ -1,6 +1,9 @@
 package entity
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 const indent = "    "
 
@@ -9,11 +12,13 @@ func (td *TrustDomain) String() string {
 %sID: %s
 %sName: %s
 %sDescription: %s
+%sBundleVerifier: %s
 %sCreatedAt: %s
 %sUpdatedAt: %s`,
 		indent, td.ID.UUID,
 		indent, td.Name,
 		indent, td.Description,
+		indent, td.BundleVerifier,
 		indent, td.CreatedAt,
 		indent, td.UpdatedAt)
 }
@@ -22,10 +27,12 @@ func (td *TrustDomain) ConsoleString() string {
 	return fmt.Sprintf(`TrustDomain:
 %sID: %s
 %sName: %s
-%sDescription: %s`,
+%sDescription: %s
+%sBundleVerifier: %s`,
 		indent, td.ID.UUID,
 		indent, td.Name,
-		indent, td.Description)
+		indent, td.Description,
+		indent, td.BundleVerifier)
 }
 
 func (rel *Relationship) String() string {
@@ -67,7 +74,7 @@ func (rel *Relationship) ConsoleString() string {
 func (jt *JoinToken) String() string {
 	return fmt.Sprintf(`JoinToken:
 %sID: %s
-%sToken: %s
+%sTokenHint: %s
 %sUsed: %t
 %sTrustDomainID: %s
 %sTrustDomainName: %s
@@ -75,7 +82,7 @@ func (jt *JoinToken) String() string {
 %sCreatedAt: %s
 %sUpdatedAt: %s`,
 		indent, jt.ID.UUID,
-		indent, jt.Token,
+		indent, jt.TokenHint,
 		indent, jt.Used,
 		indent, jt.TrustDomainID,
 		indent, jt.TrustDomainName,
@@ -117,3 +124,19 @@ func (b *Bundle) ConsoleString() string {
 		indent, b.String(),
 		indent, b.TrustDomainName)
 }
+
+func (e *AuditEvent) ConsoleString() string {
+	return fmt.Sprintf(`AuditEvent:
+%sID: %d
+%sCreatedAt: %s
+%sActor: %s
+%sAction: %s
+%sTarget: %s
+%sDetails: %s`,
+		indent, e.ID,
+		indent, e.CreatedAt.UTC().Format(time.RFC3339Nano),
+		indent, e.Actor,
+		indent, e.Action,
+		indent, e.Target,
+		indent, e.Details)
+}
@@ -0,0 +1,204 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// LivePath is the path of the liveness endpoint.
+	LivePath = "/live"
+	// ReadyPath is the path of the readiness endpoint.
+	ReadyPath = "/ready"
+
+	// StatusOK and StatusUnavailable are the statuses of the process and of its readiness checks.
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+
+	// checkTimeout bounds the time the readiness checks take to complete.
+	checkTimeout = 5 * time.Second
+)
+
+// Check reports an error when the component it checks is not ready.
+type Check func(ctx context.Context) error
+
+// Config holds the configuration of the listener serving the health checks.
+type Config struct {
+	// Address is the address the health checks are served on.
+	Address *net.TCPAddr
+}
+
+// Report is the response of the health endpoints.
+type Report struct {
+	Status string `json:"status"`
+	// Checks maps the name of each readiness check to its status, or to the error it reported.
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Checker runs the readiness checks of the process.
+type Checker struct {
+	mu     sync.RWMutex
+	checks map[string]Check
+}
+
+// NewChecker creates a Checker without readiness checks.
+func NewChecker() *Checker {
+	return &Checker{checks: make(map[string]Check)}
+}
+
+// AddCheck adds a readiness check under the given name, replacing the check previously added under the same name.
+func (c *Checker) AddCheck(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = check
+}
+
+// Ready runs the readiness checks concurrently and reports their status. The process is ready when all the checks pass.
+func (c *Checker) Ready(ctx context.Context) (*Report, bool) {
+	c.mu.RLock()
+	checks := make(map[string]Check, len(c.checks))
+	for name, check := range c.checks {
+		checks[name] = check
+	}
+	c.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	report := &Report{Status: StatusOK, Checks: make(map[string]string, len(checks))}
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+
+			status := StatusOK
+			if err := check(ctx); err != nil {
+				status = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = status
+			if status != StatusOK {
+				report.Status = StatusUnavailable
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	return report, report.Status == StatusOK
+}
+
+// RegisterHandlers serves the liveness and the readiness endpoints on the given server.
+// The liveness endpoint reports that the process serves requests, the readiness endpoint responds
+// with 503 Service Unavailable unless all the readiness checks pass.
+func (c *Checker) RegisterHandlers(server *echo.Echo) {
+	server.GET(LivePath, func(echoCtx echo.Context) error {
+		return echoCtx.JSON(http.StatusOK, &Report{Status: StatusOK})
+	})
+
+	server.GET(ReadyPath, func(echoCtx echo.Context) error {
+		report, ready := c.Ready(echoCtx.Request().Context())
+		if !ready {
+			return echoCtx.JSON(http.StatusServiceUnavailable, report)
+		}
+		return echoCtx.JSON(http.StatusOK, report)
+	})
+}
+
+// Serve serves the health checks on the configured address until the context is done.
+func Serve(ctx context.Context, c *Config, checker *Checker, logger logrus.FieldLogger) error {
+	server := echo.New()
+	server.HideBanner = true
+	server.HidePort = true
+	checker.RegisterHandlers(server)
+
+	httpServer := &http.Server{
+		Addr:              c.Address.String(),
+		Handler:           server,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	log := logger.WithFields(logrus.Fields{
+		telemetry.Network: c.Address.Network(),
+		telemetry.Address: c.Address.String()})
+
+	errChan := make(chan error, 1)
+	go func() {
+		log.Info("Started health checks listener")
+		errChan <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errChan:
+		log.WithError(err).Error("Health checks listener stopped prematurely")
+		return err
+	case <-ctx.Done():
+		log.Info("Stopping health checks listener")
+		if err := httpServer.Close(); err != nil {
+			log.WithError(err).Error("Error closing health checks listener")
+		}
+		if err := <-errChan; err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		log.Info("Health checks listener stopped")
+		return nil
+	}
+}
+
+// Probe calls the health endpoint at the given path and returns its report. It returns an error
+// if the endpoint cannot be reached or does not respond with a report.
+func Probe(ctx context.Context, client *http.Client, baseURL, path string) (*Report, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	report := &Report{}
+	if err := json.Unmarshal(body, report); err != nil || report.Status == "" {
+		return nil, fmt.Errorf("unexpected response with status code %d", res.StatusCode)
+	}
+
+	return report, nil
+}
+
+// String returns the status of the report, followed by the status of each check in alphabetical order.
+func (r *Report) String() string {
+	s := r.Status
+	names := make([]string, 0, len(r.Checks))
+	for name := range r.Checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		s += fmt.Sprintf("\n  %s: %s", name, r.Checks[name])
+	}
+
+	return s
+}
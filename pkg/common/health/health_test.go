@@ -0,0 +1,116 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func passingCheck(context.Context) error {
+	return nil
+}
+
+func failingCheck(context.Context) error {
+	return errors.New("failed to ping database")
+}
+
+func TestReady(t *testing.T) {
+	checker := NewChecker()
+
+	report, ready := checker.Ready(context.Background())
+	assert.True(t, ready)
+	assert.Equal(t, &Report{Status: StatusOK, Checks: map[string]string{}}, report)
+
+	checker.AddCheck("jwt_key", passingCheck)
+	checker.AddCheck("datastore", failingCheck)
+
+	report, ready = checker.Ready(context.Background())
+	assert.False(t, ready)
+	assert.Equal(t, &Report{
+		Status: StatusUnavailable,
+		Checks: map[string]string{
+			"jwt_key":   StatusOK,
+			"datastore": "failed to ping database",
+		},
+	}, report)
+	assert.Equal(t, "unavailable\n  datastore: failed to ping database\n  jwt_key: ok", report.String())
+
+	// a check added under the same name replaces the previous one
+	checker.AddCheck("datastore", passingCheck)
+	_, ready = checker.Ready(context.Background())
+	assert.True(t, ready)
+}
+
+func TestHandlers(t *testing.T) {
+	checker := NewChecker()
+	checker.AddCheck("datastore", failingCheck)
+
+	server := echo.New()
+	checker.RegisterHandlers(server)
+
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, LivePath, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ReadyPath, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.JSONEq(t, `{"status":"unavailable","checks":{"datastore":"failed to ping database"}}`, rec.Body.String())
+
+	checker.AddCheck("datastore", passingCheck)
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ReadyPath, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok","checks":{"datastore":"ok"}}`, rec.Body.String())
+}
+
+func TestServeAndProbe(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().(*net.TCPAddr)
+	require.NoError(t, listener.Close())
+
+	checker := NewChecker()
+	checker.AddCheck("datastore", failingCheck)
+
+	logger, _ := test.NewNullLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Serve(ctx, &Config{Address: addr}, checker, logger)
+	}()
+
+	baseURL := "http://" + addr.String()
+	var report *Report
+	require.Eventually(t, func() bool {
+		report, err = Probe(context.Background(), http.DefaultClient, baseURL, LivePath)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, StatusOK, report.Status)
+
+	report, err = Probe(context.Background(), http.DefaultClient, baseURL, ReadyPath)
+	require.NoError(t, err)
+	assert.Equal(t, StatusUnavailable, report.Status)
+	assert.Equal(t, "failed to ping database", report.Checks["datastore"])
+
+	_, err = Probe(context.Background(), http.DefaultClient, baseURL, "/unknown")
+	assert.EqualError(t, err, "unexpected response with status code 404")
+
+	cancel()
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "health checks listener did not stop")
+	}
+}
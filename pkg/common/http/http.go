@@ -3,10 +3,20 @@ package http
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"regexp"
+	"time"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // WriteResponse parses a struct into a json and writes in the response
@@ -55,6 +65,118 @@ func LogAndRespondWithError(logger logrus.FieldLogger, err error, errorMessage s
 	}
 }
 
+// MetricsMiddleware records the count and the latency of the requests served by the API, per operation.
+// The operation is the method and the route of the request, so that the path parameters do not
+// create a series per value.
+func MetricsMiddleware(api string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			telemetry.ObserveAPIRequest(api, c.Request().Method+" "+c.Path(), responseStatus(c, err), time.Since(start))
+			return err
+		}
+	}
+}
+
+// TracingMiddleware starts a server span for each request served by the API, named after the method and
+// the route of the request. The span continues the trace of the caller, propagated in the W3C trace-context headers,
+// and is set in the context of the request for the handlers to start their own spans.
+func TracingMiddleware(api string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+			ctx, span := telemetry.Tracer().Start(ctx, req.Method+" "+c.Path(),
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String(telemetry.APIAttribute, api),
+					semconv.HTTPRequestMethodKey.String(req.Method),
+					semconv.HTTPRoute(c.Path()),
+				))
+			defer span.End()
+
+			c.SetRequest(req.WithContext(ctx))
+			err := next(c)
+
+			code := responseStatus(c, err)
+			span.SetAttributes(semconv.HTTPResponseStatusCode(code))
+			if code >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(code))
+			}
+			if err != nil {
+				span.RecordError(err)
+			}
+
+			return err
+		}
+	}
+}
+
+// HTTPRequestDoer performs HTTP requests.
+type HTTPRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// TracingClient starts a client span for each request performed by the wrapped client, and propagates the trace
+// to the server in the W3C trace-context headers of the request.
+type TracingClient struct {
+	doer HTTPRequestDoer
+}
+
+// NewTracingClient returns the client wrapped to trace its requests.
+func NewTracingClient(doer HTTPRequestDoer) *TracingClient {
+	return &TracingClient{doer: doer}
+}
+
+// Do starts a span for the request, propagates it, and performs the request.
+func (c *TracingClient) Do(req *http.Request) (*http.Response, error) {
+	ctx, span := telemetry.Tracer().Start(req.Context(), req.Method+" "+req.URL.Path,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLFull(redactedURL(req.URL)),
+		))
+	defer span.End()
+
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+
+	return resp, nil
+}
+
+// redactedURL returns the scheme, host and path of the URL. The query, which can carry credentials such as
+// join tokens, the user info and the fragment are left out, so that they are never exported with the spans.
+func redactedURL(u *url.URL) string {
+	redacted := url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path, RawPath: u.RawPath}
+	return redacted.String()
+}
+
+// responseStatus returns the status code of the response to the request, including the one of the error
+// returned by the handler that echo is yet to write.
+func responseStatus(c echo.Context, err error) int {
+	var httpErr *echo.HTTPError
+	switch {
+	case errors.As(err, &httpErr):
+		return httpErr.Code
+	case err != nil:
+		return http.StatusInternalServerError
+	default:
+		return c.Response().Status
+	}
+}
+
 func sanitize(val string) string {
 	// Newlines and non-printable characters can be disruptive to logs
 	invalidCharsRegex := regexp.MustCompile(`[\x00-\x1F\x7F]`)
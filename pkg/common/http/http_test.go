@@ -4,10 +4,20 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type TestBody struct {
@@ -84,3 +94,135 @@ func TestFromBody(t *testing.T) {
 		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
 	})
 }
+
+func TestMetricsMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(MetricsMiddleware("test"))
+	e.GET("/items/:id", func(c echo.Context) error {
+		if c.Param("id") == "missing" {
+			return echo.NewHTTPError(http.StatusNotFound, "item not found")
+		}
+		return c.NoContent(http.StatusOK)
+	})
+
+	for _, id := range []string{"1", "2", "missing"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/"+id, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	telemetry.MetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, telemetry.MetricsPath, nil))
+
+	// the requests are counted per route, not per path
+	assert.Contains(t, rec.Body.String(), `galadriel_api_requests_total{api="test",code="200",operation="GET /items/:id"} 2`)
+	assert.Contains(t, rec.Body.String(), `galadriel_api_requests_total{api="test",code="404",operation="GET /items/:id"} 1`)
+}
+
+// setupTracing records the spans started by the test, and restores the global tracing on cleanup.
+func setupTracing(t *testing.T) *tracetest.SpanRecorder {
+	tracerProvider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetTextMapPropagator(propagator)
+	})
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return recorder
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	recorder := setupTracing(t)
+
+	var handlerSpan trace.SpanContext
+	e := echo.New()
+	e.Use(TracingMiddleware("test"))
+	e.GET("/items/:id", func(c echo.Context) error {
+		handlerSpan = trace.SpanContextFromContext(c.Request().Context())
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get item")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	req.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+
+	// the span continues the trace of the caller, and is the one of the handler
+	assert.Equal(t, "GET /items/:id", span.Name())
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", span.SpanContext().TraceID().String())
+	assert.Equal(t, "b7ad6b7169203331", span.Parent().SpanID().String())
+	assert.Equal(t, span.SpanContext(), handlerSpan)
+
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Contains(t, span.Attributes(), attribute.String(telemetry.APIAttribute, "test"))
+	assert.Contains(t, span.Attributes(), attribute.Int("http.response.status_code", http.StatusInternalServerError))
+}
+
+func TestTracingClient(t *testing.T) {
+	recorder := setupTracing(t)
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/trust-domain/example.org/bundles/sync", nil)
+	require.NoError(t, err)
+
+	resp, err := NewTracingClient(server.Client()).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+
+	assert.Equal(t, "POST /trust-domain/example.org/bundles/sync", span.Name())
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.Equal(t, codes.Error, span.Status().Code)
+
+	// the server receives the trace context of the client span
+	assert.Equal(t, "00-"+span.SpanContext().TraceID().String()+"-"+span.SpanContext().SpanID().String()+"-01", traceparent)
+	assert.Empty(t, req.Header.Get("traceparent"), "the request of the caller must not be modified")
+}
+
+func TestTracingClientOmitsQuery(t *testing.T) {
+	recorder := setupTracing(t)
+
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/onboard?joinToken=secret-token#fragment", nil)
+	require.NoError(t, err)
+	req.URL.User = url.UserPassword("user", "password")
+
+	resp, err := NewTracingClient(server.Client()).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	// the query still reaches the server
+	assert.Equal(t, "joinToken=secret-token", query)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+
+	assert.Equal(t, "POST /onboard", span.Name())
+	assert.Contains(t, span.Attributes(), attribute.String("url.full", server.URL+"/onboard"))
+	for _, attr := range span.Attributes() {
+		assert.NotContains(t, attr.Value.Emit(), "secret-token")
+		assert.NotContains(t, attr.Value.Emit(), "password")
+		assert.NotContains(t, attr.Value.Emit(), "?")
+	}
+}
@@ -16,20 +16,40 @@ type Validator interface {
 	ValidateToken(context.Context, string) (*jwt.RegisteredClaims, error)
 }
 
+// KeyStatus reports whether a signing key is still accepted for validating JWTs.
+type KeyStatus interface {
+	// IsRetired returns true if the tokens signed with the key with the given ID must no longer be accepted.
+	IsRetired(kid string) bool
+}
+
+// PublicKeyFunc returns the public key with the given key ID.
+type PublicKeyFunc func(ctx context.Context, kid string) (crypto.PublicKey, error)
+
 type ValidatorConfig struct {
 	// KeyManager is the key manager used to get the public key for validating the JWT.
 	KeyManager       keymanager.KeyManager
 	ExpectedAudience []string
+
+	// PublicKeyFunc is optional. When set, it is used instead of the KeyManager to get the public key
+	// for validating the JWT, e.g. for looking up the keys published in a JWKS.
+	PublicKeyFunc PublicKeyFunc
+
+	// KeyStatus is optional. When set, tokens signed with a retired key are rejected.
+	KeyStatus KeyStatus
 }
 
 type DefaultJWTValidator struct {
 	keyManager       keymanager.KeyManager
+	publicKeyFunc    PublicKeyFunc
+	keyStatus        KeyStatus
 	expectedAudience []string
 }
 
 func NewDefaultJWTValidator(c *ValidatorConfig) *DefaultJWTValidator {
 	return &DefaultJWTValidator{
 		keyManager:       c.KeyManager,
+		publicKeyFunc:    c.PublicKeyFunc,
+		keyStatus:        c.KeyStatus,
 		expectedAudience: c.ExpectedAudience,
 	}
 }
@@ -75,6 +95,18 @@ func (v *DefaultJWTValidator) getPublicKey(ctx context.Context, t *jwt.Token) (c
 		return nil, errors.New("missing kid header")
 	}
 
+	if v.keyStatus != nil && v.keyStatus.IsRetired(kid) {
+		return nil, fmt.Errorf("key %q is retired", kid)
+	}
+
+	if v.publicKeyFunc != nil {
+		publicKey, err := v.publicKeyFunc(ctx, kid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve public key for kid %q: %w", kid, err)
+		}
+		return publicKey, nil
+	}
+
 	key, err := v.keyManager.GetKey(ctx, kid)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve public key for kid %q: %w", kid, err)
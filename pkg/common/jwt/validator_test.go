@@ -3,6 +3,7 @@ package jwt
 import (
 	"context"
 	"crypto"
+	"errors"
 	"testing"
 	"time"
 
@@ -159,6 +160,78 @@ func TestValidateToken(t *testing.T) {
 	}
 }
 
+func TestValidateTokenRetiredKey(t *testing.T) {
+	ctx := context.Background()
+	issuer, validator := setup(t)
+
+	params := &JWTParams{
+		Issuer:   "test-issuer",
+		Subject:  testTrustDomain,
+		Audience: expAud,
+		TTL:      5 * time.Minute,
+	}
+	token, err := issuer.IssueJWT(ctx, params)
+	require.NoError(t, err)
+
+	status := &fakeKeyStatus{retired: map[string]bool{}}
+	validator.keyStatus = status
+
+	claims, err := validator.ValidateToken(ctx, token)
+	require.NoError(t, err)
+	require.NotNil(t, claims)
+
+	status.retired[testKid] = true
+
+	claims, err = validator.ValidateToken(ctx, token)
+	require.Error(t, err)
+	require.Nil(t, claims)
+	assert.Contains(t, err.Error(), `key "`+testKid+`" is retired`)
+}
+
+func TestValidateTokenWithPublicKeyFunc(t *testing.T) {
+	ctx := context.Background()
+	issuer, validator := setup(t)
+
+	params := &JWTParams{
+		Issuer:   "test-issuer",
+		Subject:  testTrustDomain,
+		Audience: expAud,
+		TTL:      5 * time.Minute,
+	}
+	token, err := issuer.IssueJWT(ctx, params)
+	require.NoError(t, err)
+
+	// the validator does not use the key manager when a public key func is set
+	validator.keyManager = keymanager.NewMemoryKeyManager(nil)
+	validator.publicKeyFunc = func(ctx context.Context, kid string) (crypto.PublicKey, error) {
+		if kid != testKid {
+			return nil, errors.New("unknown key")
+		}
+		return issuer.signer.Public(), nil
+	}
+
+	claims, err := validator.ValidateToken(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, testTrustDomain.String(), claims.Subject)
+
+	issuer.kid = "other-kid"
+	token, err = issuer.IssueJWT(ctx, params)
+	require.NoError(t, err)
+
+	claims, err = validator.ValidateToken(ctx, token)
+	require.Error(t, err)
+	require.Nil(t, claims)
+	assert.Contains(t, err.Error(), `failed to retrieve public key for kid "other-kid": unknown key`)
+}
+
+type fakeKeyStatus struct {
+	retired map[string]bool
+}
+
+func (f *fakeKeyStatus) IsRetired(kid string) bool {
+	return f.retired[kid]
+}
+
 func setup(t *testing.T) (*JWTCA, *DefaultJWTValidator) {
 	ctx := context.Background()
 	km := keymanager.NewMemoryKeyManager(nil)
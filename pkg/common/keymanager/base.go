@@ -114,6 +114,15 @@ func (b *base) GetKeys(ctx context.Context) ([]Key, error) {
 	return keys, nil
 }
 
+func (b *base) DeleteKey(ctx context.Context, id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.entries, id)
+
+	return nil
+}
+
 func (b *base) generateKeyEntry(keyID string, keyType cryptoutil.KeyType) (*KeyEntry, error) {
 	var err error
 	var privateKey crypto.Signer
@@ -102,3 +102,18 @@ func TestGetKeyFailsWithUnknownKeyID(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, key)
 }
+
+func TestDeleteKey(t *testing.T) {
+	b, ctx := setup()
+
+	_, err := b.GenerateKey(ctx, "foo", cryptoutil.RSA2048)
+	assert.NoError(t, err)
+
+	err = b.DeleteKey(ctx, "foo")
+	assert.NoError(t, err)
+	assert.NotContains(t, b.entries, "foo")
+
+	// deleting a key that does not exist is not an error
+	err = b.DeleteKey(ctx, "foo")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,222 @@
+package keymanager
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+)
+
+// KeyEncryptionKeySize is the size in bytes of the AES-256 key used to encrypt the keys stored in the datastore.
+const KeyEncryptionKeySize = 32
+
+// KeyStore persists the encrypted keys of the Datastore KeyManager.
+// It is implemented by the Galadriel Server datastore.
+type KeyStore interface {
+	CreateOrUpdateSigningKey(ctx context.Context, req *entity.SigningKey) (*entity.SigningKey, error)
+	FindSigningKeyByID(ctx context.Context, id string) (*entity.SigningKey, error)
+	ListSigningKeys(ctx context.Context) ([]*entity.SigningKey, error)
+	DeleteSigningKey(ctx context.Context, id string) error
+}
+
+// Datastore extends the base KeyManager to store keys in a datastore shared by several
+// Galadriel Server replicas. The keys are encrypted with AES-256-GCM using a key encryption key
+// that all the replicas share, and the key ID is authenticated along with the key.
+// Keys generated by other replicas are loaded from the datastore when they are first requested.
+type Datastore struct {
+	base
+
+	store KeyStore
+	aead  cipher.AEAD
+}
+
+// NewDatastoreKeyManager creates a new Datastore KeyManager that stores keys in the given KeyStore,
+// encrypted with the given key encryption key.
+func NewDatastoreKeyManager(generator Generator, store KeyStore, keyEncryptionKey []byte) (*Datastore, error) {
+	if store == nil {
+		return nil, errors.New("key store is required")
+	}
+	if len(keyEncryptionKey) != KeyEncryptionKeySize {
+		return nil, fmt.Errorf("key encryption key must be %d bytes long, got %d", KeyEncryptionKeySize, len(keyEncryptionKey))
+	}
+
+	block, err := aes.NewCipher(keyEncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AEAD: %w", err)
+	}
+
+	return &Datastore{
+		base:  *newBase(&Config{Generator: generator}),
+		store: store,
+		aead:  aead,
+	}, nil
+}
+
+// GenerateKey generates a new key and stores it encrypted in the datastore.
+func (d *Datastore) GenerateKey(ctx context.Context, keyID string, keyType cryptoutil.KeyType) (Key, error) {
+	if keyID == "" {
+		return nil, errors.New("key id is required")
+	}
+	if keyType == cryptoutil.KeyTypeUnset {
+		return nil, errors.New("key type is required")
+	}
+
+	entry, err := d.generateKeyEntry(keyID, keyType)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedKey, err := d.encrypt(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := d.store.CreateOrUpdateSigningKey(ctx, &entity.SigningKey{ID: keyID, EncryptedKey: encryptedKey}); err != nil {
+		return nil, fmt.Errorf("failed to store key: %w", err)
+	}
+
+	d.mu.Lock()
+	d.entries[keyID] = entry
+	d.mu.Unlock()
+
+	return entry, nil
+}
+
+// GetKey returns the key with the given ID, loading it from the datastore if it was generated by another replica.
+func (d *Datastore) GetKey(ctx context.Context, id string) (Key, error) {
+	d.mu.RLock()
+	entry, ok := d.entries[id]
+	d.mu.RUnlock()
+	if ok {
+		return entry, nil
+	}
+
+	signingKey, err := d.store.FindSigningKeyByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up key %q: %w", id, err)
+	}
+	if signingKey == nil {
+		return nil, fmt.Errorf("no such key %q", id)
+	}
+
+	return d.cacheKey(signingKey)
+}
+
+// GetKeys returns all the keys in the datastore, including the keys generated by other replicas.
+func (d *Datastore) GetKeys(ctx context.Context) ([]Key, error) {
+	signingKeys, err := d.store.ListSigningKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+
+	keys := make([]Key, 0, len(signingKeys))
+	for _, signingKey := range signingKeys {
+		d.mu.RLock()
+		entry, ok := d.entries[signingKey.ID]
+		d.mu.RUnlock()
+		if ok {
+			keys = append(keys, entry)
+			continue
+		}
+
+		key, err := d.cacheKey(signingKey)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	// forget the keys deleted by other replicas
+	d.mu.Lock()
+	for id := range d.entries {
+		if !containsKey(keys, id) {
+			delete(d.entries, id)
+		}
+	}
+	d.mu.Unlock()
+
+	return keys, nil
+}
+
+// DeleteKey deletes the key from the datastore.
+func (d *Datastore) DeleteKey(ctx context.Context, id string) error {
+	if err := d.store.DeleteSigningKey(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete key %q: %w", id, err)
+	}
+
+	return d.base.DeleteKey(ctx, id)
+}
+
+// cacheKey decrypts the signing key and keeps it in memory.
+func (d *Datastore) cacheKey(signingKey *entity.SigningKey) (Key, error) {
+	entry, err := d.decrypt(signingKey)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	d.entries[entry.id] = entry
+	d.mu.Unlock()
+
+	return entry, nil
+}
+
+func (d *Datastore) encrypt(entry *KeyEntry) ([]byte, error) {
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(entry.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal private key: %w", err)
+	}
+
+	nonce := make([]byte, d.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	// the nonce is prepended to the ciphertext
+	return d.aead.Seal(nonce, nonce, keyBytes, []byte(entry.id)), nil
+}
+
+func (d *Datastore) decrypt(signingKey *entity.SigningKey) (*KeyEntry, error) {
+	nonceSize := d.aead.NonceSize()
+	if len(signingKey.EncryptedKey) < nonceSize {
+		return nil, fmt.Errorf("encrypted key %q is too short", signingKey.ID)
+	}
+
+	nonce, ciphertext := signingKey.EncryptedKey[:nonceSize], signingKey.EncryptedKey[nonceSize:]
+	keyBytes, err := d.aead.Open(nil, nonce, ciphertext, []byte(signingKey.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt key %q: %w", signingKey.ID, err)
+	}
+
+	signer, err := parsePKCS8Signer(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key %q: %w", signingKey.ID, err)
+	}
+
+	return &KeyEntry{
+		PrivateKey: signer,
+		PublicKey:  signer.Public(),
+		id:         signingKey.ID,
+	}, nil
+}
+
+func containsKey(keys []Key, id string) bool {
+	for _, key := range keys {
+		if key.ID() == id {
+			return true
+		}
+	}
+
+	return false
+}
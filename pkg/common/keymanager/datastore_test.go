@@ -0,0 +1,135 @@
+package keymanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatastoreKeyManager(t *testing.T) {
+	ctx := context.Background()
+	store := fakedatastore.NewFakeDB()
+	kek := make([]byte, KeyEncryptionKeySize)
+	kek[0] = 1
+
+	km1, err := NewDatastoreKeyManager(nil, store, kek)
+	require.NoError(t, err)
+
+	key1, err := km1.GenerateKey(ctx, "foo", cryptoutil.RSA2048)
+	require.NoError(t, err)
+	key2, err := km1.GenerateKey(ctx, "bar", cryptoutil.RSA2048)
+	require.NoError(t, err)
+
+	// the keys are stored encrypted
+	stored, err := store.FindSigningKeyByID(ctx, "foo")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.NotEmpty(t, stored.EncryptedKey)
+
+	// another replica sharing the datastore and the key encryption key gets the same keys
+	km2, err := NewDatastoreKeyManager(nil, store, kek)
+	require.NoError(t, err)
+
+	key, err := km2.GetKey(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, key1.ID(), key.ID())
+	assert.Equal(t, key1.Signer().Public(), key.Signer().Public())
+
+	keys, err := km2.GetKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	ids := []string{keys[0].ID(), keys[1].ID()}
+	assert.ElementsMatch(t, []string{"foo", "bar"}, ids)
+	for _, k := range keys {
+		if k.ID() == "bar" {
+			assert.Equal(t, key2.Signer().Public(), k.Signer().Public())
+		}
+	}
+
+	_, err = km2.GetKey(ctx, "baz")
+	assert.EqualError(t, err, `no such key "baz"`)
+}
+
+func TestDatastoreKeyManagerDeleteKey(t *testing.T) {
+	ctx := context.Background()
+	store := fakedatastore.NewFakeDB()
+	kek := make([]byte, KeyEncryptionKeySize)
+	kek[0] = 1
+
+	km1, err := NewDatastoreKeyManager(nil, store, kek)
+	require.NoError(t, err)
+	km2, err := NewDatastoreKeyManager(nil, store, kek)
+	require.NoError(t, err)
+
+	_, err = km1.GenerateKey(ctx, "foo", cryptoutil.RSA2048)
+	require.NoError(t, err)
+	_, err = km1.GenerateKey(ctx, "bar", cryptoutil.RSA2048)
+	require.NoError(t, err)
+
+	keys, err := km2.GetKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+
+	require.NoError(t, km1.DeleteKey(ctx, "foo"))
+
+	stored, err := store.FindSigningKeyByID(ctx, "foo")
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+
+	// the other replica forgets the deleted key
+	keys, err = km2.GetKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, "bar", keys[0].ID())
+}
+
+func TestDatastoreKeyManagerWrongKeyEncryptionKey(t *testing.T) {
+	ctx := context.Background()
+	store := fakedatastore.NewFakeDB()
+
+	km1, err := NewDatastoreKeyManager(nil, store, make([]byte, KeyEncryptionKeySize))
+	require.NoError(t, err)
+	_, err = km1.GenerateKey(ctx, "foo", cryptoutil.RSA2048)
+	require.NoError(t, err)
+
+	otherKEK := make([]byte, KeyEncryptionKeySize)
+	otherKEK[0] = 1
+	km2, err := NewDatastoreKeyManager(nil, store, otherKEK)
+	require.NoError(t, err)
+
+	_, err = km2.GetKey(ctx, "foo")
+	assert.ErrorContains(t, err, `failed to decrypt key "foo"`)
+}
+
+func TestDatastoreKeyManagerTamperedKeyID(t *testing.T) {
+	ctx := context.Background()
+	store := fakedatastore.NewFakeDB()
+	kek := make([]byte, KeyEncryptionKeySize)
+
+	km, err := NewDatastoreKeyManager(nil, store, kek)
+	require.NoError(t, err)
+	_, err = km.GenerateKey(ctx, "foo", cryptoutil.RSA2048)
+	require.NoError(t, err)
+
+	// an encrypted key copied under another ID does not decrypt
+	stored, err := store.FindSigningKeyByID(ctx, "foo")
+	require.NoError(t, err)
+	stored.ID = "bar"
+	_, err = store.CreateOrUpdateSigningKey(ctx, stored)
+	require.NoError(t, err)
+
+	_, err = km.GetKey(ctx, "bar")
+	assert.ErrorContains(t, err, `failed to decrypt key "bar"`)
+}
+
+func TestNewDatastoreKeyManager(t *testing.T) {
+	_, err := NewDatastoreKeyManager(nil, nil, make([]byte, KeyEncryptionKeySize))
+	assert.EqualError(t, err, "key store is required")
+
+	_, err = NewDatastoreKeyManager(nil, fakedatastore.NewFakeDB(), make([]byte, 16))
+	assert.EqualError(t, err, "key encryption key must be 32 bytes long, got 16")
+}
@@ -58,6 +58,15 @@ func (d *Disk) GenerateKey(ctx context.Context, keyID string, keyType cryptoutil
 	return key, nil
 }
 
+// DeleteKey deletes the key and removes it from disk.
+func (d *Disk) DeleteKey(ctx context.Context, id string) error {
+	if err := d.base.DeleteKey(ctx, id); err != nil {
+		return err
+	}
+
+	return d.saveKeysToDisk()
+}
+
 func (d *Disk) loadKeysFromDisk() error {
 	data, err := os.ReadFile(d.keysFilePath)
 	if err != nil {
@@ -126,7 +135,12 @@ func convertToSigner(keyBytes []byte) (crypto.Signer, error) {
 		return nil, errors.New("failed to decode PEM block containing private key")
 	}
 
-	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	return parsePKCS8Signer(block.Bytes)
+}
+
+// parsePKCS8Signer parses a PKCS#8 DER encoded RSA or EC private key.
+func parsePKCS8Signer(der []byte) (crypto.Signer, error) {
+	key, err := x509.ParsePKCS8PrivateKey(der)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse private key: %w", err)
 	}
@@ -21,6 +21,9 @@ type KeyManager interface {
 
 	// GetKeys returns all keys managed by the Memory.
 	GetKeys(ctx context.Context) ([]Key, error)
+
+	// DeleteKey deletes the key with the given ID. Deleting a key that does not exist is not an error.
+	DeleteKey(ctx context.Context, id string) error
 }
 
 // Key is an interface for an opaque key that can be used for signing.
@@ -0,0 +1,224 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// MetricsPath is the path the metrics are served on.
+	MetricsPath = "/metrics"
+
+	metricsNamespace = "galadriel"
+
+	// OutcomeSuccess and OutcomeFailure label the outcome of an operation.
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// MetricsConfig holds the configuration of the metrics listener.
+type MetricsConfig struct {
+	// Address is the address the metrics are served on.
+	Address *net.TCPAddr
+}
+
+var (
+	registry = prometheus.NewRegistry()
+
+	apiRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "api_requests_total",
+		Help:      "Number of API requests served, per API, operation and status code.",
+	}, []string{"api", "operation", "code"})
+
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "api_request_duration_seconds",
+		Help:      "Latency of the API requests, per API and operation.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"api", "operation"})
+
+	datastoreQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "datastore_query_duration_seconds",
+		Help:      "Latency of the datastore queries, per query and outcome.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"query", "outcome"})
+
+	bundleSyncDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "bundle_sync_duration_seconds",
+		Help:      "Duration of the federated bundles syncs with the Galadriel Server, per mode and outcome.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"mode", "outcome"})
+
+	bundleVerificationFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "bundle_verification_failures_total",
+		Help:      "Number of bundles that no verifier could verify, per trust domain.",
+	}, []string{"trust_domain"})
+
+	jwtRenewals = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "jwt_renewals_total",
+		Help:      "Number of JWT access token renewals, per outcome.",
+	}, []string{"outcome"})
+
+	federatedBundles = newFederatedBundleAgeCollector()
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		apiRequests,
+		apiRequestDuration,
+		datastoreQueryDuration,
+		bundleSyncDuration,
+		bundleVerificationFailures,
+		jwtRenewals,
+		federatedBundles,
+	)
+}
+
+// ObserveAPIRequest records an API request served with the given status code.
+func ObserveAPIRequest(api, operation string, code int, duration time.Duration) {
+	apiRequests.WithLabelValues(api, operation, strconv.Itoa(code)).Inc()
+	apiRequestDuration.WithLabelValues(api, operation).Observe(duration.Seconds())
+}
+
+// ObserveDatastoreQuery records the latency of a datastore query.
+func ObserveDatastoreQuery(query string, err error, duration time.Duration) {
+	datastoreQueryDuration.WithLabelValues(query, outcome(err)).Observe(duration.Seconds())
+}
+
+// ObserveBundleSync records a sync of the federated bundles with the Galadriel Server.
+func ObserveBundleSync(mode string, err error, duration time.Duration) {
+	bundleSyncDuration.WithLabelValues(mode, outcome(err)).Observe(duration.Seconds())
+}
+
+// IncBundleVerificationFailures counts a bundle of the trust domain that no verifier could verify.
+func IncBundleVerificationFailures(trustDomain string) {
+	bundleVerificationFailures.WithLabelValues(trustDomain).Inc()
+}
+
+// IncJWTRenewals counts a JWT access token renewal.
+func IncJWTRenewals(err error) {
+	jwtRenewals.WithLabelValues(outcome(err)).Inc()
+}
+
+// SetFederatedBundleUpdated records the time the bundle of the federated trust domain was last updated,
+// its age is reported from then on.
+func SetFederatedBundleUpdated(trustDomain string, updatedAt time.Time) {
+	federatedBundles.set(trustDomain, updatedAt)
+}
+
+// DeleteFederatedBundle stops reporting the age of the bundle of the trust domain that is no longer federated.
+func DeleteFederatedBundle(trustDomain string) {
+	federatedBundles.delete(trustDomain)
+}
+
+// MetricsHandler returns the handler serving the metrics in the Prometheus exposition format.
+func MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ServeMetrics serves the metrics on the configured address until the context is done.
+func ServeMetrics(ctx context.Context, c *MetricsConfig, logger logrus.FieldLogger) error {
+	mux := http.NewServeMux()
+	mux.Handle(MetricsPath, MetricsHandler())
+
+	server := &http.Server{
+		Addr:              c.Address.String(),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	log := logger.WithFields(logrus.Fields{
+		Network: c.Address.Network(),
+		Address: c.Address.String()})
+
+	errChan := make(chan error, 1)
+	go func() {
+		log.Info("Started metrics listener")
+		errChan <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errChan:
+		log.WithError(err).Error("Metrics listener stopped prematurely")
+		return err
+	case <-ctx.Done():
+		log.Info("Stopping metrics listener")
+		if err := server.Close(); err != nil {
+			log.WithError(err).Error("Error closing metrics listener")
+		}
+		if err := <-errChan; err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		log.Info("Metrics listener stopped")
+		return nil
+	}
+}
+
+func outcome(err error) string {
+	if err != nil {
+		return OutcomeFailure
+	}
+	return OutcomeSuccess
+}
+
+// federatedBundleAgeCollector reports the age of the federated bundles when the metrics are collected.
+type federatedBundleAgeCollector struct {
+	desc *prometheus.Desc
+
+	mu        sync.Mutex
+	updatedAt map[string]time.Time
+}
+
+func newFederatedBundleAgeCollector() *federatedBundleAgeCollector {
+	return &federatedBundleAgeCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "federated_bundle_age_seconds"),
+			"Time since the bundle of the federated trust domain was last updated.",
+			[]string{"trust_domain"}, nil),
+		updatedAt: make(map[string]time.Time),
+	}
+}
+
+func (c *federatedBundleAgeCollector) set(trustDomain string, updatedAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.updatedAt[trustDomain] = updatedAt
+}
+
+func (c *federatedBundleAgeCollector) delete(trustDomain string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.updatedAt, trustDomain)
+}
+
+// Describe implements prometheus.Collector.
+func (c *federatedBundleAgeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector.
+func (c *federatedBundleAgeCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for trustDomain, updatedAt := range c.updatedAt {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, time.Since(updatedAt).Seconds(), trustDomain)
+	}
+}
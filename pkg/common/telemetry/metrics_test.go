@@ -0,0 +1,82 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrapeMetrics(t *testing.T) string {
+	recorder := httptest.NewRecorder()
+	MetricsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, MetricsPath, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	return recorder.Body.String()
+}
+
+func TestMetrics(t *testing.T) {
+	ObserveAPIRequest("admin", "GET /trust-domain/:trustDomainName", http.StatusOK, 10*time.Millisecond)
+	ObserveDatastoreQuery("FindTrustDomainByName", nil, time.Millisecond)
+	ObserveBundleSync("poll", errors.New("unavailable"), time.Second)
+	IncBundleVerificationFailures("example.org")
+	IncJWTRenewals(nil)
+
+	metrics := scrapeMetrics(t)
+	assert.Contains(t, metrics, `galadriel_api_requests_total{api="admin",code="200",operation="GET /trust-domain/:trustDomainName"} 1`)
+	assert.Contains(t, metrics, `galadriel_api_request_duration_seconds_count{api="admin",operation="GET /trust-domain/:trustDomainName"} 1`)
+	assert.Contains(t, metrics, `galadriel_datastore_query_duration_seconds_count{outcome="success",query="FindTrustDomainByName"} 1`)
+	assert.Contains(t, metrics, `galadriel_bundle_sync_duration_seconds_count{mode="poll",outcome="failure"} 1`)
+	assert.Contains(t, metrics, `galadriel_bundle_verification_failures_total{trust_domain="example.org"} 1`)
+	assert.Contains(t, metrics, `galadriel_jwt_renewals_total{outcome="success"} 1`)
+}
+
+func TestFederatedBundleAge(t *testing.T) {
+	SetFederatedBundleUpdated("federated.org", time.Now().Add(-time.Hour))
+	assert.Regexp(t, `galadriel_federated_bundle_age_seconds\{trust_domain="federated.org"\} 360\d`, scrapeMetrics(t))
+
+	DeleteFederatedBundle("federated.org")
+	assert.NotContains(t, scrapeMetrics(t), `trust_domain="federated.org"`)
+}
+
+func TestServeMetrics(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().(*net.TCPAddr)
+	require.NoError(t, listener.Close())
+
+	logger, _ := test.NewNullLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- ServeMetrics(ctx, &MetricsConfig{Address: addr}, logger)
+	}()
+
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		resp, err = http.Get("http://" + addr.String() + MetricsPath)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "go_goroutines")
+
+	cancel()
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "metrics listener did not stop")
+	}
+}
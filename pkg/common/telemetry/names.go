@@ -25,29 +25,80 @@ const (
 )
 
 const (
+	// Action represents the action recorded by an audit event.
+	Action = "action"
+
+	// Actor represents the actor of an audit event.
+	Actor = "actor"
+
 	// Address represents a network address.
 	Address = "address"
 
+	// AuditRecorder represents the subsystem recording the audit log.
+	AuditRecorder = "audit_recorder"
+
 	// BundleOpStatus represents a bundle operation status.
 	BundleOpStatus = "bundle_op_status"
 
 	Datastore = "datastore"
 
+	// BundleEndpoint represents the SPIFFE Federation bundle endpoint subsystem.
+	BundleEndpoint = "bundle_endpoint"
+
+	// ChangeLogPruner represents the subsystem that deletes the old changes from the change log.
+	ChangeLogPruner = "change_log_pruner"
+
 	// DiskX509CA represents a disk-based X509 CA.
 	DiskX509CA = "disk_x509_ca"
 
+	// EntryID tags the ID of a SPIRE registration entry.
+	EntryID = "entry_id"
+
 	// Endpoints represents functionality related to agent/server endpoints.
 	Endpoints = "endpoints"
 
+	// HealthChecks represents the subsystem serving the health checks.
+	HealthChecks = "health_checks"
+
+	// InstanceID tags the ID of a Galadriel Server replica or Harvester instance.
+	InstanceID = "instance_id"
+
+	// JoinTokenPurger represents the subsystem that deletes the expired join tokens.
+	JoinTokenPurger = "join_token_purger"
+
+	// JWTKeyManager represents the JWT signing keys manager subsystem.
+	JWTKeyManager = "jwt_key_manager"
+
+	// KeyID tags the ID of a key.
+	KeyID = "key_id"
+
 	// FederatedBundlesSynchronizer represents the Federated Bundles Synchronizer subsystem.
 	FederatedBundlesSynchronizer = "federated_bundles_synchronizer"
 
+	// FederatesWithSynchronizer represents the subsystem that updates the federated trust domains of registration entries.
+	FederatesWithSynchronizer = "federates_with_synchronizer"
+
 	// GaladrielServer represents the Galadriel server subsystem.
 	GaladrielServer = "galadriel_server"
 
+	// LeaseKeeper represents the subsystem that keeps the upload lease of the Harvester instance.
+	LeaseKeeper = "lease_keeper"
+
+	// LeaderElector represents the subsystem that elects the Galadriel Server replica running the background work.
+	LeaderElector = "leader_elector"
+
+	// Metrics represents the subsystem serving the metrics.
+	Metrics = "metrics"
+
 	// Network represents a network name ("tcp", "udp").
 	Network = "network"
 
+	// Profile tags the SPIFFE bundle endpoint profile.
+	Profile = "profile"
+
+	// SPIFFEID tags a SPIFFE ID.
+	SPIFFEID = "spiffe_id"
+
 	// SpireBundleSynchronizer represents the SPIRE Bundle Synchronizer subsystem.
 	SpireBundleSynchronizer = "spire_bundle_synchronizer"
 
@@ -0,0 +1,174 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingExporter is the exporter of the spans.
+type TracingExporter string
+
+const (
+	// TracingExporterOTLP exports the spans to an OpenTelemetry collector using OTLP over HTTP.
+	TracingExporterOTLP TracingExporter = "otlp"
+	// TracingExporterStdout writes the spans to the standard output, as JSON.
+	TracingExporterStdout TracingExporter = "stdout"
+	// TracingExporterFile appends the spans to a file, as JSON, for offline use.
+	TracingExporterFile TracingExporter = "file"
+
+	tracerName = "github.com/HewlettPackard/galadriel"
+
+	// tracingShutdownTimeout bounds the flush of the pending spans on shutdown.
+	tracingShutdownTimeout = 5 * time.Second
+
+	// APIAttribute is the attribute of the spans naming the API serving the request.
+	APIAttribute = "galadriel.api"
+	// QueryAttribute is the attribute of the spans naming the datastore query.
+	QueryAttribute = "galadriel.db.query"
+	// TrustDomainAttribute is the attribute of the span events naming a trust domain.
+	TrustDomainAttribute = "galadriel.trust_domain"
+)
+
+// TracingConfig holds the configuration of the tracing.
+type TracingConfig struct {
+	// Exporter is the exporter of the spans.
+	Exporter TracingExporter
+
+	// Endpoint is the host and port of the OpenTelemetry collector, used by the otlp exporter.
+	// When empty, the OTEL_EXPORTER_OTLP_* environment variables apply.
+	Endpoint string
+	// Insecure disables TLS towards the OpenTelemetry collector.
+	Insecure bool
+
+	// FilePath is the file the spans are appended to, used by the file exporter.
+	FilePath string
+
+	// SampleRatio is the ratio of the traces started by this process that are sampled, between 0 and 1.
+	// The traces started by a caller follow the caller sampling decision.
+	SampleRatio float64
+}
+
+// Validate checks the tracing configuration.
+func (c *TracingConfig) Validate() error {
+	switch c.Exporter {
+	case TracingExporterOTLP, TracingExporterStdout:
+	case TracingExporterFile:
+		if c.FilePath == "" {
+			return errors.New("file path is required by the file exporter")
+		}
+	default:
+		return fmt.Errorf("unknown tracing exporter %q", c.Exporter)
+	}
+
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return errors.New("sample ratio must be between 0 and 1")
+	}
+
+	return nil
+}
+
+// InitTracing installs the tracer provider exporting the spans of the service, and the W3C trace-context propagator.
+// The returned function flushes the pending spans and releases the exporter.
+func InitTracing(ctx context.Context, c *TracingConfig, serviceName string) (func(context.Context) error, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	exporter, closeOutput, err := newSpanExporter(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s span exporter: %w", c.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	shutdown := func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closeOutput != nil {
+			err = errors.Join(err, closeOutput.Close())
+		}
+		return err
+	}
+
+	return shutdown, nil
+}
+
+// ShutdownTracing flushes the pending spans with the shutdown function returned by InitTracing.
+// It is meant to be deferred, once the context of the service is canceled.
+func ShutdownTracing(shutdown func(context.Context) error, logger logrus.FieldLogger) {
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+
+	if err := shutdown(ctx); err != nil {
+		logger.WithError(err).Warn("Failed to flush the spans")
+	}
+}
+
+// Tracer returns the tracer of Galadriel. It does not record spans unless the tracing is initialized.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// RecordError marks the span as failed with the error, if any.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+func newSpanExporter(ctx context.Context, c *TracingConfig) (sdktrace.SpanExporter, io.Closer, error) {
+	switch c.Exporter {
+	case TracingExporterOTLP:
+		var opts []otlptracehttp.Option
+		if c.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(c.Endpoint))
+		}
+		if c.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err := otlptracehttp.New(ctx, opts...)
+		return exporter, nil, err
+	case TracingExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		return exporter, nil, err
+	default:
+		f, err := os.OpenFile(c.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, nil, err
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+		return exporter, f, nil
+	}
+}
@@ -0,0 +1,105 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestTracingConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config TracingConfig
+		err    string
+	}{
+		{
+			name:   "otlp",
+			config: TracingConfig{Exporter: TracingExporterOTLP, Endpoint: "collector:4318", SampleRatio: 1},
+		},
+		{
+			name:   "stdout",
+			config: TracingConfig{Exporter: TracingExporterStdout},
+		},
+		{
+			name:   "file",
+			config: TracingConfig{Exporter: TracingExporterFile, FilePath: "spans.json", SampleRatio: 0.1},
+		},
+		{
+			name:   "file_without_path",
+			config: TracingConfig{Exporter: TracingExporterFile},
+			err:    "file path is required by the file exporter",
+		},
+		{
+			name:   "unknown_exporter",
+			config: TracingConfig{Exporter: "zipkin"},
+			err:    `unknown tracing exporter "zipkin"`,
+		},
+		{
+			name:   "invalid_sample_ratio",
+			config: TracingConfig{Exporter: TracingExporterStdout, SampleRatio: 1.5},
+			err:    "sample ratio must be between 0 and 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestInitTracingFileExporter(t *testing.T) {
+	tracerProvider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetTextMapPropagator(propagator)
+	})
+
+	path := filepath.Join(t.TempDir(), "spans.json")
+	shutdown, err := InitTracing(context.Background(), &TracingConfig{
+		Exporter:    TracingExporterFile,
+		FilePath:    path,
+		SampleRatio: 1,
+	}, "galadriel-test")
+	require.NoError(t, err)
+
+	ctx, span := Tracer().Start(context.Background(), "SyncBundles")
+	RecordError(span, errors.New("server unavailable"))
+	span.End()
+
+	// the trace context of the span is propagated in the W3C headers
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	assert.Contains(t, carrier.Get("traceparent"), span.SpanContext().TraceID().String())
+
+	require.NoError(t, shutdown(context.Background()))
+
+	spans, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(spans), `"Name":"SyncBundles"`)
+	assert.Contains(t, string(spans), `"Value":"galadriel-test"`)
+	assert.Contains(t, string(spans), "server unavailable")
+}
+
+func TestInitTracingInvalidConfig(t *testing.T) {
+	_, err := InitTracing(context.Background(), &TracingConfig{Exporter: TracingExporterFile}, "galadriel-test")
+	assert.EqualError(t, err, "file path is required by the file exporter")
+
+	_, err = InitTracing(context.Background(), &TracingConfig{
+		Exporter: TracingExporterFile,
+		FilePath: filepath.Join(t.TempDir(), "missing", "spans.json"),
+	}, "galadriel-test")
+	assert.ErrorContains(t, err, "failed to create file span exporter")
+}
@@ -149,6 +149,15 @@ func (ca *X509CA) IssueX509Certificate(ctx context.Context, params *x509ca.X509C
 	return chain, nil
 }
 
+// X509Authorities returns the CA certificate, the chains issued by the CA include the certificates
+// needed to chain it to the upstream trust bundle.
+func (ca *X509CA) X509Authorities() []*x509.Certificate {
+	if ca.certificate == nil {
+		return nil
+	}
+	return []*x509.Certificate{ca.certificate}
+}
+
 func (ca *X509CA) buildCertificateChain(leafCert *x509.Certificate) ([]*x509.Certificate, error) {
 	chain := []*x509.Certificate{leafCert}
 
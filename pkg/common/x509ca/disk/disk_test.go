@@ -197,6 +197,8 @@ func runIssueX509CertificateTest(t *testing.T, config Config, expectedChainLengt
 	require.Equal(t, expectedExtKeyUsage, leaf.ExtKeyUsage)
 	require.NotNil(t, leaf.SerialNumber)
 
+	require.Equal(t, []*x509.Certificate{ca.certificate}, ca.X509Authorities())
+
 	x509CertPool := x509.NewCertPool()
 	x509CertPool.AddCert(ca.certificate)
 	opts := x509.VerifyOptions{
@@ -13,6 +13,10 @@ import (
 type X509CA interface {
 	// IssueX509Certificate issues an X509 certificate and returns the leaf certificate and the certificate chain.
 	IssueX509Certificate(context.Context, *X509CertificateParams) ([]*x509.Certificate, error)
+
+	// X509Authorities returns the CA certificates the issued certificates chain to, e.g. to verify
+	// the client certificates issued to the Harvesters.
+	X509Authorities() []*x509.Certificate
 }
 
 // X509CertificateParams holds the parameters for issuing an X509 certificate.
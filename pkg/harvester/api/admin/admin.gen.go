@@ -15,6 +15,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	externalRef0 "github.com/HewlettPackard/galadriel/pkg/common/api"
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
@@ -26,14 +27,63 @@ const (
 	Harvester_authScopes = "harvester_auth.Scopes"
 )
 
+// BundleResetApproval defines model for BundleResetApproval.
+type BundleResetApproval struct {
+	CreatedAt       time.Time                    `json:"created_at"`
+	TrustDomainName externalRef0.TrustDomainName `json:"trust_domain_name"`
+}
+
 // PatchRelationshipRequest defines model for PatchRelationshipRequest.
 type PatchRelationshipRequest struct {
 	ConsentStatus externalRef0.ConsentStatus `json:"consent_status"`
 }
 
+// QuarantinedBundle defines model for QuarantinedBundle.
+type QuarantinedBundle struct {
+	// Digest base64 encoded SHA-256 digest of the bundle
+	Digest *externalRef0.BundleDigest `json:"digest,omitempty"`
+
+	// Id Hex encoded SHA-256 digest of the trust domain name and the bundle data
+	Id            QuarantinedBundleID `json:"id"`
+	QuarantinedAt time.Time           `json:"quarantined_at"`
+
+	// Reason Reason why the bundle was rejected
+	Reason string `json:"reason"`
+
+	// Signature base64 encoded signature of the bundle
+	Signature *externalRef0.Signature `json:"signature,omitempty"`
+
+	// SigningCertificateChain X.509 certificate chain in PEM format
+	SigningCertificateChain *externalRef0.CertificateChain `json:"signing_certificate_chain,omitempty"`
+
+	// TrustBundle SPIFFE Trust bundle in JSON format
+	TrustBundle     externalRef0.TrustBundle     `json:"trust_bundle"`
+	TrustDomainName externalRef0.TrustDomainName `json:"trust_domain_name"`
+}
+
+// QuarantinedBundleID Hex encoded SHA-256 digest of the trust domain name and the bundle data
+type QuarantinedBundleID = string
+
+// VerifiedRelationshipConsent defines model for VerifiedRelationshipConsent.
+type VerifiedRelationshipConsent struct {
+	Consent externalRef0.RelationshipConsent `json:"consent"`
+
+	// VerificationError Reason why the signature of the consent could not be verified
+	VerificationError *string `json:"verification_error,omitempty"`
+
+	// Verified True when one of the bundle verifiers of the Harvester verified the signature of the consent
+	Verified bool `json:"verified"`
+}
+
 // Default defines model for Default.
 type Default = externalRef0.ApiError
 
+// ListQuarantinedBundlesParams defines parameters for ListQuarantinedBundles.
+type ListQuarantinedBundlesParams struct {
+	// TrustDomainName Name of the federated Trust Domain to list the quarantined bundles of
+	TrustDomainName *externalRef0.TrustDomainName `form:"trustDomainName,omitempty" json:"trustDomainName,omitempty"`
+}
+
 // GetRelationshipsParams defines parameters for GetRelationships.
 type GetRelationshipsParams struct {
 	ConsentStatus *externalRef0.ConsentStatus `form:"consentStatus,omitempty" json:"consentStatus,omitempty"`
@@ -115,6 +165,21 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// ApproveBundleReset request
+	ApproveBundleReset(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListQuarantinedBundles request
+	ListQuarantinedBundles(ctx context.Context, params *ListQuarantinedBundlesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DiscardQuarantinedBundle request
+	DiscardQuarantinedBundle(ctx context.Context, bundleID QuarantinedBundleID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetQuarantinedBundle request
+	GetQuarantinedBundle(ctx context.Context, bundleID QuarantinedBundleID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AcceptQuarantinedBundle request
+	AcceptQuarantinedBundle(ctx context.Context, bundleID QuarantinedBundleID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetRelationships request
 	GetRelationships(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -122,6 +187,69 @@ type ClientInterface interface {
 	PatchRelationshipWithBody(ctx context.Context, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PatchRelationship(ctx context.Context, relationshipID externalRef0.UUID, body PatchRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRelationshipConsents request
+	GetRelationshipConsents(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) ApproveBundleReset(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApproveBundleResetRequest(c.Server, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListQuarantinedBundles(ctx context.Context, params *ListQuarantinedBundlesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListQuarantinedBundlesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DiscardQuarantinedBundle(ctx context.Context, bundleID QuarantinedBundleID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDiscardQuarantinedBundleRequest(c.Server, bundleID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetQuarantinedBundle(ctx context.Context, bundleID QuarantinedBundleID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetQuarantinedBundleRequest(c.Server, bundleID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AcceptQuarantinedBundle(ctx context.Context, bundleID QuarantinedBundleID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAcceptQuarantinedBundleRequest(c.Server, bundleID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
 func (c *Client) GetRelationships(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -160,16 +288,62 @@ func (c *Client) PatchRelationship(ctx context.Context, relationshipID externalR
 	return c.Client.Do(req)
 }
 
-// NewGetRelationshipsRequest generates requests for GetRelationships
-func NewGetRelationshipsRequest(server string, params *GetRelationshipsParams) (*http.Request, error) {
+func (c *Client) GetRelationshipConsents(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRelationshipConsentsRequest(c.Server, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewApproveBundleResetRequest generates requests for ApproveBundleReset
+func NewApproveBundleResetRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
 	var err error
 
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/relationships")
+	operationPath := fmt.Sprintf("/bundles/%s/reset", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListQuarantinedBundlesRequest generates requests for ListQuarantinedBundles
+func NewListQuarantinedBundlesRequest(server string, params *ListQuarantinedBundlesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quarantined-bundles")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -182,9 +356,9 @@ func NewGetRelationshipsRequest(server string, params *GetRelationshipsParams) (
 	if params != nil {
 		queryValues := queryURL.Query()
 
-		if params.ConsentStatus != nil {
+		if params.TrustDomainName != nil {
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "consentStatus", runtime.ParamLocationQuery, *params.ConsentStatus); err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "trustDomainName", runtime.ParamLocationQuery, *params.TrustDomainName); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -209,24 +383,13 @@ func NewGetRelationshipsRequest(server string, params *GetRelationshipsParams) (
 	return req, nil
 }
 
-// NewPatchRelationshipRequest calls the generic PatchRelationship builder with application/json body
-func NewPatchRelationshipRequest(server string, relationshipID externalRef0.UUID, body PatchRelationshipJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPatchRelationshipRequestWithBody(server, relationshipID, "application/json", bodyReader)
-}
-
-// NewPatchRelationshipRequestWithBody generates requests for PatchRelationship with any type of body
-func NewPatchRelationshipRequestWithBody(server string, relationshipID externalRef0.UUID, contentType string, body io.Reader) (*http.Request, error) {
+// NewDiscardQuarantinedBundleRequest generates requests for DiscardQuarantinedBundle
+func NewDiscardQuarantinedBundleRequest(server string, bundleID QuarantinedBundleID) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, relationshipID)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "bundleID", runtime.ParamLocationPath, bundleID)
 	if err != nil {
 		return nil, err
 	}
@@ -236,7 +399,7 @@ func NewPatchRelationshipRequestWithBody(server string, relationshipID externalR
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/relationships/%s", pathParam0)
+	operationPath := fmt.Sprintf("/quarantined-bundles/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -246,144 +409,715 @@ func NewPatchRelationshipRequestWithBody(server string, relationshipID externalR
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
-
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	return nil
-}
+// NewGetQuarantinedBundleRequest generates requests for GetQuarantinedBundle
+func NewGetQuarantinedBundleRequest(server string, bundleID QuarantinedBundleID) (*http.Request, error) {
+	var err error
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+	var pathParam0 string
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "bundleID", runtime.ParamLocationPath, bundleID)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-}
-
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GetRelationships request
-	GetRelationshipsWithResponse(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*GetRelationshipsResponse, error)
-
-	// PatchRelationship request with any body
-	PatchRelationshipWithBodyWithResponse(ctx context.Context, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error)
 
-	PatchRelationshipWithResponse(ctx context.Context, relationshipID externalRef0.UUID, body PatchRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error)
-}
-
-type GetRelationshipsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]externalRef0.Relationship
-	JSONDefault  *externalRef0.ApiError
-}
+	operationPath := fmt.Sprintf("/quarantined-bundles/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// Status returns HTTPResponse.Status
-func (r GetRelationshipsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetRelationshipsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type PatchRelationshipResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *externalRef0.Relationship
-	JSONDefault  *externalRef0.ApiError
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r PatchRelationshipResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+// NewAcceptQuarantinedBundleRequest generates requests for AcceptQuarantinedBundle
+func NewAcceptQuarantinedBundleRequest(server string, bundleID QuarantinedBundleID) (*http.Request, error) {
+	var err error
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PatchRelationshipResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
-}
+	var pathParam0 string
 
-// GetRelationshipsWithResponse request returning *GetRelationshipsResponse
-func (c *ClientWithResponses) GetRelationshipsWithResponse(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*GetRelationshipsResponse, error) {
-	rsp, err := c.GetRelationships(ctx, params, reqEditors...)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "bundleID", runtime.ParamLocationPath, bundleID)
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetRelationshipsResponse(rsp)
-}
 
-// PatchRelationshipWithBodyWithResponse request with arbitrary body returning *PatchRelationshipResponse
-func (c *ClientWithResponses) PatchRelationshipWithBodyWithResponse(ctx context.Context, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error) {
-	rsp, err := c.PatchRelationshipWithBody(ctx, relationshipID, contentType, body, reqEditors...)
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-	return ParsePatchRelationshipResponse(rsp)
-}
 
-func (c *ClientWithResponses) PatchRelationshipWithResponse(ctx context.Context, relationshipID externalRef0.UUID, body PatchRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error) {
-	rsp, err := c.PatchRelationship(ctx, relationshipID, body, reqEditors...)
+	operationPath := fmt.Sprintf("/quarantined-bundles/%s/accept", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	return ParsePatchRelationshipResponse(rsp)
-}
 
-// ParseGetRelationshipsResponse parses an HTTP response from a GetRelationshipsWithResponse call
-func ParseGetRelationshipsResponse(rsp *http.Response) (*GetRelationshipsResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRelationshipsRequest generates requests for GetRelationships
+func NewGetRelationshipsRequest(server string, params *GetRelationshipsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/relationships")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ConsentStatus != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "consentStatus", runtime.ParamLocationQuery, *params.ConsentStatus); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPatchRelationshipRequest calls the generic PatchRelationship builder with application/json body
+func NewPatchRelationshipRequest(server string, relationshipID externalRef0.UUID, body PatchRelationshipJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchRelationshipRequestWithBody(server, relationshipID, "application/json", bodyReader)
+}
+
+// NewPatchRelationshipRequestWithBody generates requests for PatchRelationship with any type of body
+func NewPatchRelationshipRequestWithBody(server string, relationshipID externalRef0.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/relationships/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetRelationshipConsentsRequest generates requests for GetRelationshipConsents
+func NewGetRelationshipConsentsRequest(server string, relationshipID externalRef0.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/relationships/%s/consents", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// ApproveBundleReset request
+	ApproveBundleResetWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*ApproveBundleResetResponse, error)
+
+	// ListQuarantinedBundles request
+	ListQuarantinedBundlesWithResponse(ctx context.Context, params *ListQuarantinedBundlesParams, reqEditors ...RequestEditorFn) (*ListQuarantinedBundlesResponse, error)
+
+	// DiscardQuarantinedBundle request
+	DiscardQuarantinedBundleWithResponse(ctx context.Context, bundleID QuarantinedBundleID, reqEditors ...RequestEditorFn) (*DiscardQuarantinedBundleResponse, error)
+
+	// GetQuarantinedBundle request
+	GetQuarantinedBundleWithResponse(ctx context.Context, bundleID QuarantinedBundleID, reqEditors ...RequestEditorFn) (*GetQuarantinedBundleResponse, error)
+
+	// AcceptQuarantinedBundle request
+	AcceptQuarantinedBundleWithResponse(ctx context.Context, bundleID QuarantinedBundleID, reqEditors ...RequestEditorFn) (*AcceptQuarantinedBundleResponse, error)
+
+	// GetRelationships request
+	GetRelationshipsWithResponse(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*GetRelationshipsResponse, error)
+
+	// PatchRelationship request with any body
+	PatchRelationshipWithBodyWithResponse(ctx context.Context, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error)
+
+	PatchRelationshipWithResponse(ctx context.Context, relationshipID externalRef0.UUID, body PatchRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error)
+
+	// GetRelationshipConsents request
+	GetRelationshipConsentsWithResponse(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipConsentsResponse, error)
+}
+
+type ApproveBundleResetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BundleResetApproval
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r ApproveBundleResetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ApproveBundleResetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListQuarantinedBundlesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]QuarantinedBundle
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r ListQuarantinedBundlesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListQuarantinedBundlesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DiscardQuarantinedBundleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.DeleteResponse
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r DiscardQuarantinedBundleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DiscardQuarantinedBundleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetQuarantinedBundleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QuarantinedBundle
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetQuarantinedBundleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetQuarantinedBundleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AcceptQuarantinedBundleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QuarantinedBundle
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r AcceptQuarantinedBundleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AcceptQuarantinedBundleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRelationshipsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]externalRef0.Relationship
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRelationshipsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRelationshipsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchRelationshipResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.Relationship
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchRelationshipResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchRelationshipResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRelationshipConsentsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]VerifiedRelationshipConsent
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRelationshipConsentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRelationshipConsentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ApproveBundleResetWithResponse request returning *ApproveBundleResetResponse
+func (c *ClientWithResponses) ApproveBundleResetWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*ApproveBundleResetResponse, error) {
+	rsp, err := c.ApproveBundleReset(ctx, trustDomainName, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseApproveBundleResetResponse(rsp)
+}
+
+// ListQuarantinedBundlesWithResponse request returning *ListQuarantinedBundlesResponse
+func (c *ClientWithResponses) ListQuarantinedBundlesWithResponse(ctx context.Context, params *ListQuarantinedBundlesParams, reqEditors ...RequestEditorFn) (*ListQuarantinedBundlesResponse, error) {
+	rsp, err := c.ListQuarantinedBundles(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListQuarantinedBundlesResponse(rsp)
+}
+
+// DiscardQuarantinedBundleWithResponse request returning *DiscardQuarantinedBundleResponse
+func (c *ClientWithResponses) DiscardQuarantinedBundleWithResponse(ctx context.Context, bundleID QuarantinedBundleID, reqEditors ...RequestEditorFn) (*DiscardQuarantinedBundleResponse, error) {
+	rsp, err := c.DiscardQuarantinedBundle(ctx, bundleID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDiscardQuarantinedBundleResponse(rsp)
+}
+
+// GetQuarantinedBundleWithResponse request returning *GetQuarantinedBundleResponse
+func (c *ClientWithResponses) GetQuarantinedBundleWithResponse(ctx context.Context, bundleID QuarantinedBundleID, reqEditors ...RequestEditorFn) (*GetQuarantinedBundleResponse, error) {
+	rsp, err := c.GetQuarantinedBundle(ctx, bundleID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetQuarantinedBundleResponse(rsp)
+}
+
+// AcceptQuarantinedBundleWithResponse request returning *AcceptQuarantinedBundleResponse
+func (c *ClientWithResponses) AcceptQuarantinedBundleWithResponse(ctx context.Context, bundleID QuarantinedBundleID, reqEditors ...RequestEditorFn) (*AcceptQuarantinedBundleResponse, error) {
+	rsp, err := c.AcceptQuarantinedBundle(ctx, bundleID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAcceptQuarantinedBundleResponse(rsp)
+}
+
+// GetRelationshipsWithResponse request returning *GetRelationshipsResponse
+func (c *ClientWithResponses) GetRelationshipsWithResponse(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*GetRelationshipsResponse, error) {
+	rsp, err := c.GetRelationships(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRelationshipsResponse(rsp)
+}
+
+// PatchRelationshipWithBodyWithResponse request with arbitrary body returning *PatchRelationshipResponse
+func (c *ClientWithResponses) PatchRelationshipWithBodyWithResponse(ctx context.Context, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error) {
+	rsp, err := c.PatchRelationshipWithBody(ctx, relationshipID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchRelationshipResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchRelationshipWithResponse(ctx context.Context, relationshipID externalRef0.UUID, body PatchRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error) {
+	rsp, err := c.PatchRelationship(ctx, relationshipID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchRelationshipResponse(rsp)
+}
+
+// GetRelationshipConsentsWithResponse request returning *GetRelationshipConsentsResponse
+func (c *ClientWithResponses) GetRelationshipConsentsWithResponse(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipConsentsResponse, error) {
+	rsp, err := c.GetRelationshipConsents(ctx, relationshipID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRelationshipConsentsResponse(rsp)
+}
+
+// ParseApproveBundleResetResponse parses an HTTP response from a ApproveBundleResetWithResponse call
+func ParseApproveBundleResetResponse(rsp *http.Response) (*ApproveBundleResetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ApproveBundleResetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BundleResetApproval
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListQuarantinedBundlesResponse parses an HTTP response from a ListQuarantinedBundlesWithResponse call
+func ParseListQuarantinedBundlesResponse(rsp *http.Response) (*ListQuarantinedBundlesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListQuarantinedBundlesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []QuarantinedBundle
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDiscardQuarantinedBundleResponse parses an HTTP response from a DiscardQuarantinedBundleWithResponse call
+func ParseDiscardQuarantinedBundleResponse(rsp *http.Response) (*DiscardQuarantinedBundleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DiscardQuarantinedBundleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.DeleteResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetQuarantinedBundleResponse parses an HTTP response from a GetQuarantinedBundleWithResponse call
+func ParseGetQuarantinedBundleResponse(rsp *http.Response) (*GetQuarantinedBundleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetQuarantinedBundleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QuarantinedBundle
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAcceptQuarantinedBundleResponse parses an HTTP response from a AcceptQuarantinedBundleWithResponse call
+func ParseAcceptQuarantinedBundleResponse(rsp *http.Response) (*AcceptQuarantinedBundleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AcceptQuarantinedBundleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QuarantinedBundle
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRelationshipsResponse parses an HTTP response from a GetRelationshipsWithResponse call
+func ParseGetRelationshipsResponse(rsp *http.Response) (*GetRelationshipsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
@@ -446,14 +1180,65 @@ func ParsePatchRelationshipResponse(rsp *http.Response) (*PatchRelationshipRespo
 	return response, nil
 }
 
+// ParseGetRelationshipConsentsResponse parses an HTTP response from a GetRelationshipConsentsWithResponse call
+func ParseGetRelationshipConsentsResponse(rsp *http.Response) (*GetRelationshipConsentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRelationshipConsentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []VerifiedRelationshipConsent
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Approve the next bundle of a federated Trust Domain even if it does not continue the bundle trusted by the SPIRE Server
+	// (POST /bundles/{trustDomainName}/reset)
+	ApproveBundleReset(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
+	// List the federated bundles rejected by the Harvester, with the reason of their rejection
+	// (GET /quarantined-bundles)
+	ListQuarantinedBundles(ctx echo.Context, params ListQuarantinedBundlesParams) error
+	// Discard a quarantined bundle, without setting it in the SPIRE Server
+	// (DELETE /quarantined-bundles/{bundleID})
+	DiscardQuarantinedBundle(ctx echo.Context, bundleID QuarantinedBundleID) error
+	// Get a quarantined bundle
+	// (GET /quarantined-bundles/{bundleID})
+	GetQuarantinedBundle(ctx echo.Context, bundleID QuarantinedBundleID) error
+	// Accept a quarantined bundle, setting it in the SPIRE Server without verifying it
+	// (POST /quarantined-bundles/{bundleID}/accept)
+	AcceptQuarantinedBundle(ctx echo.Context, bundleID QuarantinedBundleID) error
 	// List the relationships.
 	// (GET /relationships)
 	GetRelationships(ctx echo.Context, params GetRelationshipsParams) error
 	// Accept/Denies relationship requests
 	// (PATCH /relationships/{relationshipID})
 	PatchRelationship(ctx echo.Context, relationshipID externalRef0.UUID) error
+	// Get the signed consents of the trust domains of a relationship, verified with the bundle verifiers of the Harvester
+	// (GET /relationships/{relationshipID}/consents)
+	GetRelationshipConsents(ctx echo.Context, relationshipID externalRef0.UUID) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -461,6 +1246,98 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// ApproveBundleReset converts echo context to params.
+func (w *ServerInterfaceWrapper) ApproveBundleReset(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	ctx.Set(Harvester_authScopes, []string{})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ApproveBundleReset(ctx, trustDomainName)
+	return err
+}
+
+// ListQuarantinedBundles converts echo context to params.
+func (w *ServerInterfaceWrapper) ListQuarantinedBundles(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(Harvester_authScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListQuarantinedBundlesParams
+	// ------------- Optional query parameter "trustDomainName" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "trustDomainName", ctx.QueryParams(), &params.TrustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListQuarantinedBundles(ctx, params)
+	return err
+}
+
+// DiscardQuarantinedBundle converts echo context to params.
+func (w *ServerInterfaceWrapper) DiscardQuarantinedBundle(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "bundleID" -------------
+	var bundleID QuarantinedBundleID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bundleID", runtime.ParamLocationPath, ctx.Param("bundleID"), &bundleID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bundleID: %s", err))
+	}
+
+	ctx.Set(Harvester_authScopes, []string{})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DiscardQuarantinedBundle(ctx, bundleID)
+	return err
+}
+
+// GetQuarantinedBundle converts echo context to params.
+func (w *ServerInterfaceWrapper) GetQuarantinedBundle(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "bundleID" -------------
+	var bundleID QuarantinedBundleID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bundleID", runtime.ParamLocationPath, ctx.Param("bundleID"), &bundleID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bundleID: %s", err))
+	}
+
+	ctx.Set(Harvester_authScopes, []string{})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetQuarantinedBundle(ctx, bundleID)
+	return err
+}
+
+// AcceptQuarantinedBundle converts echo context to params.
+func (w *ServerInterfaceWrapper) AcceptQuarantinedBundle(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "bundleID" -------------
+	var bundleID QuarantinedBundleID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "bundleID", runtime.ParamLocationPath, ctx.Param("bundleID"), &bundleID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bundleID: %s", err))
+	}
+
+	ctx.Set(Harvester_authScopes, []string{})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AcceptQuarantinedBundle(ctx, bundleID)
+	return err
+}
+
 // GetRelationships converts echo context to params.
 func (w *ServerInterfaceWrapper) GetRelationships(ctx echo.Context) error {
 	var err error
@@ -499,6 +1376,24 @@ func (w *ServerInterfaceWrapper) PatchRelationship(ctx echo.Context) error {
 	return err
 }
 
+// GetRelationshipConsents converts echo context to params.
+func (w *ServerInterfaceWrapper) GetRelationshipConsents(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "relationshipID" -------------
+	var relationshipID externalRef0.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, ctx.Param("relationshipID"), &relationshipID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter relationshipID: %s", err))
+	}
+
+	ctx.Set(Harvester_authScopes, []string{})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetRelationshipConsents(ctx, relationshipID)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -527,33 +1422,98 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.POST(baseURL+"/bundles/:trustDomainName/reset", wrapper.ApproveBundleReset)
+	router.GET(baseURL+"/quarantined-bundles", wrapper.ListQuarantinedBundles)
+	router.DELETE(baseURL+"/quarantined-bundles/:bundleID", wrapper.DiscardQuarantinedBundle)
+	router.GET(baseURL+"/quarantined-bundles/:bundleID", wrapper.GetQuarantinedBundle)
+	router.POST(baseURL+"/quarantined-bundles/:bundleID/accept", wrapper.AcceptQuarantinedBundle)
 	router.GET(baseURL+"/relationships", wrapper.GetRelationships)
 	router.PATCH(baseURL+"/relationships/:relationshipID", wrapper.PatchRelationship)
+	router.GET(baseURL+"/relationships/:relationshipID/consents", wrapper.GetRelationshipConsents)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8xWW2/bOBP9KwK/PupmO3ETvfmr09ZAtxs07csGXoOWRhK7EsWQo7hGoP++GMoXyXaa",
-	"y3aBfRLFy8yZOYeceWBxVapKgkTDogemwahKGrA/U0h5XSAN40oiSDvkShUi5igqGXw3laQ5E+dQchq9",
-	"0ZCyiP0v2NsN2lUTTJS40rrSrGkalyVgYi0U2WERswvO5Hrm7CHQrs1ZMr07TiCSRNBJXlzrSoFGQZBT",
-	"XhhwmepMEfQE6JtWuuTIIiYkjs+Yy0r+Q5R1yaLzy0uXlUK2f4MwdBmuFbRbIQPNGpeVYAzPrCX4wUtV",
-	"0PrEWQKvUaR14YCNYLvN3fszqIXMWoefQGaYs2jYcbJZp2g13NVCQ8Ki2xb33u98t79afocYCdM7ypPE",
-	"G+RY21hBUgS3xJGu7iFhlGYp7ECBTMjP/Mixy645xvkXKCyrJhfqC9zVYPDFqbZ4FmYH6Gdy6KM/Dr9n",
-	"61T4XcAvRaqBIyQLjn1Ch+Fw4IUDbxR+DS+iURiF4R9dLhOO4KEo4YDOwYmkiuSpDHz7NpvSTtS1wUVS",
-	"lVzIBV9sQn9h/o7MvNq/5CU8dfQrHZnaE59p+6GV5a+JYvnaKJavjaJWyb+sjAOhC7qcHT32IJwi9VSK",
-	"HtXQo7SculCH6eglwNrxWzt+XJVPP3FnFyduhWWrZ3iU8ovzdHzmnb8dvPXOzsdDbzlKY28YX45H6XjM",
-	"Uz7uOqtrG3DH1WjsMsURQVMp+fM29C65l84fLhpvNz57xngwbN6wY77oLsu02tZBHltptPpiHwTm9ZJY",
-	"0wWLWI6oTBQEmZ2mPAUfYVUA4jWP/+I6CTJe8EQLKNhREfywXXI+cn0PBkE7v3HJMyhBoq2ORkEs0k39",
-	"9ZnLChGDNNBBNFE8zsEZ+mEPVRQEq9XK53bVr3QWbI6a4NPs3dXnmytv6Id+jqVFhgItO6cwERDP+V2B",
-	"pNHIOroHbdooBn7oDwZko1IguRLEsR/6I2ZZyu0DHOjO421nMrBppVfaLswS8g74pbeRTGheAoI2LLp9",
-	"YIJc3tWg18zdZiDuvSnuM9uTw3o0d/vt0DAMX9QKCYTyySLYq2HNTntca74+1Sfd1HEMxlDDsctUK6Rd",
-	"r3bK3S6QYNvU2eYK4loLXNtE5lt6F7ymS3U7pwyYuiy5XrOIfRIGHczB6TFHEkSeEResz9ScPPRpDh66",
-	"v7NpQ3AV9R7HzB+1JMfU9zMzmzpVegSQua1CSHh7gfRhsO6DjLqG5yqmrTytUGy/9P8qWf+ydvnRpuyE",
-	"LLrbnLZlcrByluBsislRjM0/VPfzRf1fEvEkjkFhMKWm2PSE4mwoND8TtHWn77cC7D/5RRXzIq8M+mbF",
-	"swy0L6qAKxHcjxih2Fg91O3EubmevX9/5djq67Tldy/V3mzjHp/uBSHM5gYoDfSY2RW6F3zr5T0km4w7",
-	"PdEsAVcA0sFV1UNi9lD66Wjmzd8BAAD//0kOZEY+DgAA",
+	"H4sIAAAAAAAC/+x6WZOqytL2XyH4zl3bLYNjR+wLEFRUcMKJ7fo6CigGhYKGQtQV/d/fAIfW1tVrOHvv",
+	"s07E6ZvGoiors/KppzKT+koagR8GCCIck89fyQjGYYBimP8QoAUSD2ePRoAwRPkjCEPPNQB2A1RcxQHK",
+	"2mLDgT7Inv4VQYt8Jv9f8V1u8fA2LnKhK0ZREJFvb28F0oSxEblhJod8JvMXBDeQiHcVsl7HsZno8/BM",
+	"CdN0s5HAG0RBCCPsZipbwIthgQwvmjLVTZj9t4LIB5h8Jl2EKyWyQPpg6/qJTz6X6/UC6bvo8IumqAKJ",
+	"dyE8dIU2jMi3AunDOAZ2LglugR962XuO0CFIsGslHgFzC07dCu/zxThykX2YsAeRjR3ymbmY5Pg+szaC",
+	"r4kbQZN8/vOg9/u8X879A30FDZzpxCfI9KDg2jDOXXO9pDqIYaVEQJRJMolxm3tkyhXCzLsTgUVgBxJ6",
+	"LoIsXBhlUaVyxawCaFK1GqzWaViq0JTBGgwwKyywYKkCGVitVuu1mmXqRp2pUhZdhka9StN6iSFvLDtp",
+	"OoIxxFwYRsEGeD/rxggCDM0XgK9dwFAM/UjRjyylUrVnlnqmKO1y9U2A4SN2fXhPLRwlMX4xAx+46AUB",
+	"H34Pwmo2QMj7K1n3j067FVi41PyeExuZjVa2o2DDAS66deT8qUzVCeO9H2FkHQkXEQNRJo6mXrrwMfvj",
+	"xZakEA1xpEpNqcGpYt66RLIkCa19o8HZfZtLJZ6zJUndNDdlsdlYb4xUGC463UCTnI2hcEOxxw+5tLUS",
+	"FzK/aHH0RFwibisPwKxMafMO1majcDEfeb2Z4sh8aS6o0l4WFjtZkFJ5xW0VL8jaKFlYbPvqoW2JFC9I",
+	"24IIZN7OZXJbeXRPYk+VUtsWXZmjWo3xa2ss6awwFPkl4oYTjitJvJByWY8uF0g8N2y8Ug2FU1OLbuCF",
+	"PilNa61wDx+Utb7azYySiuSN1Jv3LZ9ZIqbbFNrSeuoEm2Kv2+Mtj9LbtmSbD05pXfP3Q155qNZK5V0c",
+	"+Wu73OkUrVWVMxDtDieq0H5dIq9ZZys7nGzEjaXZD4ONPOia+15/MbSbSSXhG3vpYSxXJg+rQXFmd6Xi",
+	"QqjO1vZ0L43cirtZLNEkKvUGyeZBTof7V0Pd7z1smINxVSu5vd50X623jQEqUWlz0x4W++ORNw46K53f",
+	"bOfpml6seks0R1JdEWHaSSzjAbADZO30pG6AtlgqlrUBkOrCXCu7MZJn6GHVB0qrATs1sVcriZLQlupL",
+	"lCo1Y/ZAzXworpAztl3WH9VZ/nWD/HnkRM2N67CLYOC0O/0tZ8s8x7VWiz3vyFwp8525REIq8sV0KGZo",
+	"4lfcgLeVaXsk85xVE3mVE7hhuyjzVN5bsIcznh/XeoEAcdtOQXPiL5HTVSbSYrZFyNpVVpyVSxjLYkvg",
+	"ZjavSmnaZWDXGgsdvU+zQ7gJ1Vijgpm65xTeXr8ukbN2W/WU4rlh3OS4foMbipw67kazsa2ti6ye9PGA",
+	"9R7a5Y1Fl71Wt7gu71N7sGpPVHlTWbBLpDr15lbFjMaInQ6k+k4F0eNF0l/RCT12FbhRmzVT6kux3Qt1",
+	"h9akXi8Qulul2+0bKJ2tlshd1+WmHHUrpVraV3Sg9YsTNQhW3oRrVgS+3KaF6qTUmZn9B1Pe6JpUnkrV",
+	"3rjNrpRaQ4mZJUpKJkeB9itsRnOdcRJPGJjayjClrSqP7JJbFM2oOtobmho9vMpFk1vMVkKChMoDH4/2",
+	"prdEDTBlmWGyk+dpk3pY8LWFXBtw4ybXbrnuaD2kpZHdVvhOjdEZW4a8hPczz2jVIj7uL7ZTdYkYk6V8",
+	"7HWmilJtMq/lMR3Ts35rupIirPd9pOq7stgZvSbcH38sUU4qoiLcIZrvspCoNhqc5Z9ZSPQDZrSwSjt2",
+	"cpeFxJU4PKLuwEJtw69vzAa9WqgcbKbUTt5zjLziDuyjaiBrUwSOkdX1uU3mg22GqyXKUMZzsiowXmK2",
+	"pjt9Nl2DWZPSxpnERoMbSx/14PkhJ9i2OOCERiNjoaBh2yLPybSeVhqC3NGk4Xg7mbpxrS01H5LGxsD1",
+	"ftnoAL0NWFroSeuiNVhMgr5s95l0ifRWqDZKlt/pgPIeT4rDorPFnjOQ9l2pF1P0q5hMPQkE0YPSFQeL",
+	"Dc3Ozc6g5OzSwWu9KoVLJG/XCWttq6jPDbhgX4n3jV1xM19vnG19X40GNXFGWYOxsFj1U3kiJi1TROHr",
+	"qyqYdm/QcMdLJE9il27ag5LUs6EsmFbY2xWHAxBO1t2+sBrY60XioN0+UvvTMlvnQIln43kDRqqwn/bq",
+	"1hKpk87Wg4LPdawSU9c3K3U805yGscG7ZJFYXMfjJbeqtfh60LJ7QUhJUoNKal0aT5mZ87BZonqL6dNd",
+	"wR3VZWvcl4xBXRqns6q/WDQtryU3uFTkOKCs5JaYCvZCmI6oQcYrPDcUOFtsLZHM1XKGEQ9s1JS5nIHS",
+	"9jDv3ef5hdiUVwIXaaWwLsleXJ2bfOXB62/R2NEGSyTzBwlSOlzIPOCaDbUZavVRkoalroMceiHP0/m+",
+	"u3LpzccTqZFOuCXKTiRu2GgHHOxNars9K+HulmoPJFEc6K1KsbSrl6rSwF1vbXbdmfXUZtfpDmiPZcK6",
+	"LM39Jdp0NqXWaJiAWX+nepoF0XbarrHr17oA6/PaWtrHtWjUHJX9zWBmNnAwnjnSbtptKVpci21piVpU",
+	"a9BbqVFtPHt90BNdTLBUwZYu8OvuahX2vM20OgavVZ1m6SFXdmAn6XdnD3HLLbEs1xwukdb265HX68+s",
+	"vcHUaqDZ1912A/S9lT3T4oU9nnu1wYPl6XKnWTTZ7ZSzGI0ZiX27b5W9ur1EoWm7s2YEDSV2ZT4SXunV",
+	"2oOc2CrWcWnabgkclmt1ZRKkfZ2pTLQAd1Vek/aBnGrVIBovEW32Kb/XLipRb9NkKbRb2XLJHTrVB6X/",
+	"KetcBn8h9O+FfY0AxRDhMQYY+se05tPYuTPuK0R86n6KnY2DmOwnIPLIjzhEfgQOCEBE0MuzpNhxwwIB",
+	"YsLFRApiInZtBE1C3xEujok2iDYwxjBaoqsQDu46O222dcyWt9GREoA5b4HZ0O270l7zxZIyWzBKg6KV",
+	"vcj21CGtrCS8WCn+YkftFvs1IzMLRvGb/kJduL1GhzLQdG8262udoR0wK7l9t0NpgpjobAdJbuoumHpi",
+	"MNPEbNb3ZqtJmXM56+MYbX6ns5qnNaRYQkqoMWVPa9YdsyFVpFUWz8lYFhZYFpoTWZUqssBVZIEHEqL+",
+	"+M7aJ3lgD1GWdf2Z5ZVRsIEmmaWGyM0fQojMbNyXO4IE6EEMR8ds8TdKCkWEXbwjzFw/85/NCAcAG87o",
+	"Angj+Jocs8OfWp7cSS/x2UufJUXXLr3V+krWPa2HCYgAwi6C5iFR/El1zXMG/JmaV9nyW4F0ze+NuNFL",
+	"ErKBr+/Nf206GkFwLKhcs9EobydSZ3eRs+dcEsFsDaF5T1rGMwAn0XeT2vG543GUi+yXi3TzxTjlpZ+i",
+	"4GMee86v9bNPv5taH93/d6Tmbr5Id/LzKyXPTrhx8w8BVxJuvdeG2+8UYa7OjkwrAiDz0tUmwODqdKhb",
+	"tYpJ1eharWRUzUq5DhgLAkAZ5TIwKboMWN0qWbTO6JReYxjDpMtmxaDLOmVRFKBq9/BySRv/geLMJRfS",
+	"d9T7/nadTA7788rJ4OVIQD/JYjdifnn+XwLvByn6X2OF/qtW6L9qRRKafzMy7m3zCzxeqXDPqfeW6JsY",
+	"+qZb7rHD5YZqvLvvF8/hc6T6g+4/9H8r/COb8zLW/QmM/TZH1NGfP675P47rjyt8q3nhDly+vRXuAXZ8",
+	"6Y5PE6Kz4z75jgBaFW3OAs16qGC7uBsJmjkaK1hm695emyk7bT7qaALdWcxo9fy7oa3MeZb5lKlpy8Pa",
+	"VKEWMzodqGKW6exkdZL21YmvzZ0UzDte3keltn3BZhTVoGVhTXdQx9H90UZXqZ28yupBk7uZyGXAcWPv",
+	"eCA1myKR9zmdwi46JIJ3Cu5fl+QqxS8gwU4Qudn2XZLPf35dknAbuhGMXwBeks9Lkq7USmW6wpbYJVlY",
+	"kmu4e3HN/A1nqppBGdV9XK8YFXsz3Hb4ytAUK8JunCjWJu8fJrrnGi9ruMvHyM11KqaLdlaf2q+oBjdc",
+	"SMdngRsawtDmxC090EapJbKCFvdfGZmn+uXBzNLjfQTClmL5ZbFZpIN0XkaSoPgr1dWLys6qNmBjM+4Z",
+	"osFSixDoG063e+2aETOOsKezyh/5VviWfTX61j7LngLBACq3APt1i5lZdXaGW1t/ZM4tjlL4X7UvEsYr",
+	"14jQ63iCRGYH6U6QWLzQ6ulYkled5rTVhe0+7qrl5NXji121pjBseR7Hc1vtDUeysw85wZDl0qS48IxN",
+	"sFu3y76d2/elsCQjaEUwdl4cFx0spHJF4yytQgZ8QYmvwyh/U83fXO7KvBmb9JJ8+yYAL47LKyLJ5Twd",
+	"5DwZgf/9/LFUuzNHzldXglkL1MpWpfRYrtLVx1K5wjzqrGU8Mka9wlqVCrBA5XKyJHHN66nYSoEMAcYw",
+	"ynbK//+TeqyDR+vL19rb4/m59APPNPP2r3vrMoWRa7nQ/MvOz++R+r2J3grkJtfj8Kn7BZ4+PX+amt3w",
+	"4lEDwggSzyRQgAkdEpujgfeMP7+7mUqNEkikDkREgD7w7kliFJ/az2Wl82Sf6veuiR4EHgToW2k8eaHh",
+	"7RGSDXKRFRzXHgMjX/tD7Ei2XOwkenYMRR75TDoYh/FzsWjnzRnGi22YehDjATDWIDKLNvCAGbnQI2/u",
+	"C7ROry4slQEC9qFCl10kiENonP33RBZIzzXgsVR01IgLgeFAgnmirrR6LhbTNH0C+dunILKLx6FxsSc1",
+	"RGUsPjJP1JOD/Vwz7GIPfkOnTJFHoh9ClD2x+UQbGMUHK+gn6ommMxlBCBEI3Wx/PlFPLJnvMCcHcfHg",
+	"47j4FV8TxlsxgjHMVzgM7t0AmGVguUBJ5hIXJVl1Kgw819gRbkxABHQPmgUCEBY0YZQFCKcB2AGYWEMY",
+	"xgQKiMN36NMZt7uG4BLl2h0Km1nzeCCNRGIMow2MCnlC68b5BnivfwJiDXeHj9kusrOqqYsLWbd47YYh",
+	"NIkEeTCOCYAIYPouIk41wry6GkP8lH21g8dm4GVDM5wm/rsalhu9n+DAMGCI870A0Do+zJhBIyONHCeS",
+	"mcMin+bi0kLujwj4EMMozk72DyudueO0Hu+reIgeDg4js51BPuduJQsnAH7wKHm553CUwMIPXq25LX58",
+	"KVxf5mEo6i+7yHPvNsedOz3jxDBgHGeXY87re9jJ53tF92Y5q108XUDKRMfQSDLU5YvvnPZXHnGRz39+",
+	"yeyNE98H0e7dgbk7ENye/Z9X6u/7h4AbiAjXyqr0ZgAPUD1uGHi5iT6BecaiwM7wcbzwEpNfMt2LF6Wk",
+	"x+Nuzqy3D5v3Gnw9N8Y3daX43wdgBnbPjXHe5UKho13ZyXHC6GsCo91nIP1PgdLF0I9/umpLvp0PKhBF",
+	"YPd7YbV38slH9n2v7p6wdj5WCkTqYidvO5Qrj653o+MYN0A/A8biV/1Yvnw7nCIexPAWmoIbGyAybxf4",
+	"O+CUhBM0b3F3nxdP6vwyId4t3f+tpPjhe9RvhbGj4whwxwEHLAUJJmKIcXYOuzhLdX+I3Ar3OawF8f9A",
+	"8oPc9DvhpAXxXYz8IpcUD/HWtwNU9f1Y9bOTKsau52UZUp6aJFEE0eXZ7eL4+kg7wvQ98D5A9WmJJJwF",
+	"gxH083DRigL/A7SIABkwg/w9rN8JCXNL/gfq/0JQH1z3De77nPPO1JjnvLtDx29uhssS7bfjuxbEo6uO",
+	"NxC6F4MZV991fhQLH7/M/yPx16V1/yWh15Xnni4cfO2pO24ufr38eYyfwuwWxq3nby5n/Dh7RNej7vDG",
+	"tRq/zB6H7xsHoOQ3R/jA3P1lTPHN6yl3YHHZjThcHslSGB0Sx68YNza+/Y0cdw3q34/eigJEbp4yXKza",
+	"0YXxvwXo4pF6fpjRGqf+vze6/24a/KyK/VuzYhYEnmrF0DxViON7l1XiQ0Xl+qbjueB8zlG/W6T+DKC5",
+	"+tlhfMDQde3YCwzgOUGMn+IU2DaMntygCEK3uGGz7zcnqR+hxxFX3/fOFboj2q5a3wq3o692mRsfQRxG",
+	"MFup/M1hYY6zNA+ZfdZ8xWo6xCmEiMBpcKVJ/K7K9XK8Fb4dQcefl38uZJ6ilrcvb/83AFQ5ymsAOAAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
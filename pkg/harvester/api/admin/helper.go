@@ -0,0 +1,45 @@
+package admin
+
+import (
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
+)
+
+// QuarantinedBundleFromEntity transforms a QuarantinedBundle entity to its API representation.
+func QuarantinedBundleFromEntity(qb *entity.QuarantinedBundle) *QuarantinedBundle {
+	bundle := &QuarantinedBundle{
+		Id:              qb.ID,
+		TrustDomainName: qb.Bundle.TrustDomainName.String(),
+		TrustBundle:     string(qb.Bundle.Data),
+		Reason:          qb.Reason,
+		QuarantinedAt:   qb.QuarantinedAt,
+	}
+
+	if len(qb.Bundle.Digest) > 0 {
+		digest := encoding.EncodeToBase64(qb.Bundle.Digest)
+		bundle.Digest = &digest
+	}
+
+	if len(qb.Bundle.Signature) > 0 {
+		sig := encoding.EncodeToBase64(qb.Bundle.Signature)
+		bundle.Signature = &sig
+	}
+
+	if len(qb.Bundle.SigningCertificateChain) > 0 {
+		certChain := encoding.EncodeToBase64(qb.Bundle.SigningCertificateChain)
+		bundle.SigningCertificateChain = &certChain
+	}
+
+	return bundle
+}
+
+// MapQuarantinedBundles transforms a slice of QuarantinedBundle entities to a slice of their API representations.
+func MapQuarantinedBundles(bundles ...*entity.QuarantinedBundle) []*QuarantinedBundle {
+	cBundles := make([]*QuarantinedBundle, len(bundles))
+
+	for i, qb := range bundles {
+		cBundles[i] = QuarantinedBundleFromEntity(qb)
+	}
+
+	return cBundles
+}
@@ -0,0 +1,40 @@
+package admin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuarantinedBundleFromEntity(t *testing.T) {
+	qb := &entity.QuarantinedBundle{
+		ID: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		Bundle: &entity.Bundle{
+			TrustDomainName: spiffeid.RequireTrustDomainFromString("example.org"),
+			Data:            []byte(`{"keys":[]}`),
+			Digest:          []byte("digest"),
+			Signature:       []byte("signature"),
+		},
+		Reason:        "verification failed",
+		QuarantinedAt: time.Now(),
+	}
+
+	bundles := MapQuarantinedBundles(qb)
+	require.Len(t, bundles, 1)
+
+	bundle := bundles[0]
+	assert.Equal(t, qb.ID, bundle.Id)
+	assert.Equal(t, "example.org", bundle.TrustDomainName)
+	assert.Equal(t, `{"keys":[]}`, bundle.TrustBundle)
+	assert.Equal(t, qb.Reason, bundle.Reason)
+	assert.Equal(t, qb.QuarantinedAt, bundle.QuarantinedAt)
+	require.NotNil(t, bundle.Digest)
+	assert.Equal(t, "ZGlnZXN0", *bundle.Digest)
+	require.NotNil(t, bundle.Signature)
+	assert.Equal(t, "c2lnbmF0dXJl", *bundle.Signature)
+	assert.Nil(t, bundle.SigningCertificateChain)
+}
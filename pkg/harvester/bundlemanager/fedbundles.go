@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"context"
 	"crypto/x509"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
@@ -16,28 +19,80 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 )
 
+const (
+	// modes of the federated bundles syncs in the metrics
+	bundleSyncModePoll  = "poll"
+	bundleSyncModeWatch = "watch"
+
+	// maxMissedSyncs is the number of sync intervals without a successful sync after which the synchronizer is not ready
+	maxMissedSyncs = 3
+)
+
+var (
+	// ErrQuarantineDisabled is returned when the quarantined bundles are managed without a quarantine configured.
+	ErrQuarantineDisabled = errors.New("bundle quarantine is not enabled")
+	// ErrStaleQuarantinedBundle is returned when a quarantined bundle is accepted after the Galadriel Server
+	// replaced it with another bundle of the same trust domain.
+	ErrStaleQuarantinedBundle = errors.New("quarantined bundle is no longer the current bundle of its trust domain")
+)
+
 // FederatedBundlesSynchronizer is responsible for periodically synchronizing the federated bundles
 // in the SPIRE Server with those fetched from the Galadriel Server. The synchronization process consists of the following steps:
 // 1. Fetch the federated bundles from the Galadriel Server.
 // 2. Verify the integrity of these bundles using the provided bundle verifiers.
 // 3. Update the SPIRE Server with the new bundles.
-// 4. If any relationships no longer exist, remove the corresponding bundles from the SPIRE Server.
+// 4. If configured, create, update and delete the federation relationships in the SPIRE Server accordingly.
+// 5. If any relationships no longer exist, remove the corresponding bundles from the SPIRE Server.
 //
 // The removal of bundles is done in DISSOCIATE mode, which dissociates the registration entries
 // from the non-existent federated trust domains. It also maintains a last-known state of federated
 // bundles fetched from the Galadriel Server to optimize synchronizations.
+//
+// When a watch timeout is configured, the synchronizer also keeps a long-poll BundleSync request open,
+// that the Galadriel Server answers as soon as the federated bundles change, so that changes reach the
+// SPIRE Server without waiting for the next poll. The watch resumes from the cursor in the change log of the
+// Galadriel Server returned by the previous one, so that only the changes made since then are transferred.
+// The periodic synchronization remains as a fallback, and always compares the full state of the SPIRE Server.
+//
+// When the bundle continuity policy is enabled, a federated bundle that keeps no X.509 authority of the bundle of the
+// same trust domain trusted by the SPIRE Server, and is not signed by a key chaining to it, is skipped, unless an admin
+// approved a reset of the bundle of that trust domain.
+//
+// When a quarantine is configured, the skipped bundles are kept in it with the reason of their rejection, until an
+// admin accepts or discards them.
 type FederatedBundlesSynchronizer struct {
 	spireClient     spireclient.Client
 	galadrielClient galadrielclient.Client
 	bundleVerifiers []integrity.Verifier
 	syncInterval    time.Duration
+	watchTimeout    time.Duration
 	logger          logrus.FieldLogger
 
+	// federationRelationships is nil when the federation relationships are not managed by the Harvester
+	federationRelationships *FederationRelationshipsConfig
+
+	bundleContinuity bool
+
+	// quarantine is nil when the rejected bundles are not quarantined
+	quarantine *Quarantine
+
+	// mu serializes the periodic synchronization and the watch
+	mu sync.Mutex
 	// last state of Federated Bundles fetched from Galadriel Server
 	lastFederatedBundleDigests map[spiffeid.TrustDomain][]byte
+	// position in the change log of the Galadriel Server that the last state is up to date with, zero if unknown
+	cursor int64
+	// time of the approvals of a reset of the bundles of the federated trust domains, consumed by the first
+	// bundle accepted thanks to them
+	resetApprovals map[spiffeid.TrustDomain]time.Time
+
+	// lastSync is the time of the last successful sync in Unix nanoseconds, or the creation time before the first one
+	lastSync atomic.Int64
 }
 
 // FederatedBundlesSynchronizerConfig holds the configuration for FederatedBundlesSynchronizer.
@@ -47,30 +102,100 @@ type FederatedBundlesSynchronizerConfig struct {
 	BundleVerifiers []integrity.Verifier
 	SyncInterval    time.Duration
 	Logger          logrus.FieldLogger
+
+	// WatchTimeout is optional, when set, the synchronizer waits up to this long for the Galadriel Server
+	// to push changes of the federated bundles. Zero disables the watch.
+	WatchTimeout time.Duration
+
+	// FederationRelationships is optional, when set, the federation relationships in the SPIRE Server are managed.
+	FederationRelationships *FederationRelationshipsConfig
+
+	// BundleContinuity skips the federated bundles that do not continue the bundles trusted by the SPIRE Server,
+	// unless an admin approved a reset.
+	BundleContinuity bool
+
+	// Quarantine is optional, when set, the rejected bundles are kept in it.
+	Quarantine *Quarantine
 }
 
 func NewFederatedBundlesSynchronizer(config *FederatedBundlesSynchronizerConfig) *FederatedBundlesSynchronizer {
-	return &FederatedBundlesSynchronizer{
+	s := &FederatedBundlesSynchronizer{
 		spireClient:     config.SpireClient,
 		galadrielClient: config.GaladrielClient,
 		bundleVerifiers: config.BundleVerifiers,
 		syncInterval:    config.SyncInterval,
+		watchTimeout:    config.WatchTimeout,
 		logger:          config.Logger,
+
+		federationRelationships: config.FederationRelationships,
+		bundleContinuity:        config.BundleContinuity,
+		quarantine:              config.Quarantine,
+		resetApprovals:          make(map[spiffeid.TrustDomain]time.Time),
 	}
+	s.lastSync.Store(time.Now().UnixNano())
+
+	return s
+}
+
+// ApproveBundleReset approves the next federated bundle of the trust domain even if it does not continue the bundle
+// trusted by the SPIRE Server. The bundle of the trust domain is set again on the next sync, in case it was skipped.
+// It returns the time of the approval.
+func (s *FederatedBundlesSynchronizer) ApproveBundleReset(td spiffeid.TrustDomain) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.resetApprovals[td] = now
+
+	// the last state is copied, as the watch may be reading it
+	digests := make(map[spiffeid.TrustDomain][]byte, len(s.lastFederatedBundleDigests))
+	for d, digest := range s.lastFederatedBundleDigests {
+		if d != td {
+			digests[d] = digest
+		}
+	}
+	s.lastFederatedBundleDigests = digests
+
+	return now
+}
+
+// CheckSync reports an error if the federated bundles have not been synced successfully for several sync intervals.
+func (s *FederatedBundlesSynchronizer) CheckSync(context.Context) error {
+	lastSync := time.Unix(0, s.lastSync.Load())
+	if time.Since(lastSync) > maxMissedSyncs*s.syncInterval {
+		return fmt.Errorf("federated bundles not synced with Galadriel Server since %s", lastSync.UTC().Format(time.RFC3339))
+	}
+
+	return nil
 }
 
 // StartSyncing starts the synchronization process.
 func (s *FederatedBundlesSynchronizer) StartSyncing(ctx context.Context) error {
 	s.logger.Info("Federated Bundles Synchronizer started")
 
+	var wg sync.WaitGroup
+	if s.watchTimeout > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.watch(ctx)
+		}()
+	}
+	defer wg.Wait()
+
 	ticker := time.NewTicker(s.syncInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			if err := s.synchronizeFederatedBundles(ctx); err != nil {
+			start := time.Now()
+			err := s.synchronizeFederatedBundles(ctx)
+			telemetry.ObserveBundleSync(bundleSyncModePoll, err, time.Since(start))
+			if err != nil {
 				s.logger.Errorf("Failed to sync federated bundles with Galadriel Server: %v", err)
+			} else {
+				s.lastSync.Store(time.Now().UnixNano())
 			}
 		case <-ctx.Done():
 			s.logger.Info("Federated Bundles Synchronizer stopped")
@@ -79,9 +204,132 @@ func (s *FederatedBundlesSynchronizer) StartSyncing(ctx context.Context) error {
 	}
 }
 
-func (s *FederatedBundlesSynchronizer) synchronizeFederatedBundles(ctx context.Context) error {
+// watch keeps a long-poll BundleSync request open until the context is done, and applies the changes
+// pushed by the Galadriel Server. After an error, or when the Galadriel Server answers without waiting,
+// as the servers that do not support the long-poll do, it backs off for the sync interval.
+func (s *FederatedBundlesSynchronizer) watch(ctx context.Context) {
+	for {
+		start := time.Now()
+		changed, err := s.watchFederatedBundles(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err == nil {
+			s.lastSync.Store(time.Now().UnixNano())
+		}
+
+		backoff := false
+		switch {
+		case err != nil:
+			s.logger.Errorf("Failed to watch federated bundles in Galadriel Server: %v", err)
+			backoff = true
+		case !changed && time.Since(start) < s.watchTimeout/2:
+			s.logger.Debug("Galadriel Server did not wait for changes of the federated bundles")
+			backoff = true
+		}
+
+		if !backoff {
+			continue
+		}
+
+		select {
+		case <-time.After(s.syncInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// watchFederatedBundles waits for the federated bundles in the Galadriel Server to change and applies them
+// to the SPIRE Server. It returns false if they did not change before the watch timeout elapsed.
+func (s *FederatedBundlesSynchronizer) watchFederatedBundles(ctx context.Context) (changed bool, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WatchFederatedBundles")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	s.mu.Lock()
+	lastDigests := s.lastFederatedBundleDigests
+	cursor := s.cursor
+	s.mu.Unlock()
+
+	galadrielCallCtx, galadrielCallCancel := context.WithTimeout(ctx, s.watchTimeout+galadrielCallTimeout)
+	defer galadrielCallCancel()
+
+	result, err := s.galadrielClient.WatchBundles(galadrielCallCtx, lastDigests, cursor, s.watchTimeout)
+	if err != nil {
+		telemetry.ObserveBundleSync(bundleSyncModeWatch, err, 0)
+		return false, err
+	}
+
+	// the wait for changes is not part of the sync duration
+	start := time.Now()
+	defer func() {
+		telemetry.ObserveBundleSync(bundleSyncModeWatch, err, time.Since(start))
+	}()
+
+	digests := result.Digests
+	if result.Incremental {
+		digests = mergeFederatedBundleDigests(lastDigests, result)
+	}
+
+	if len(result.Bundles) == 0 && areMapsEqual(lastDigests, digests) {
+		s.mu.Lock()
+		s.cursor = result.Cursor
+		s.mu.Unlock()
+		return false, nil
+	}
+
+	s.logger.Debug("Federated bundles changed in Galadriel Server")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	spireCallCtx, spireCallCancel := context.WithTimeout(ctx, spireCallTimeout)
+	defer spireCallCancel()
+
+	fedBundlesInSPIRE, err := s.fetchSPIREFederatedBundles(spireCallCtx)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch federated bundles from SPIRE Server: %w", err)
+	}
+
+	s.applyFederatedBundles(spireCallCtx, fedBundlesInSPIRE, result.Bundles, digests)
+	s.cursor = result.Cursor
+
+	return true, nil
+}
+
+// mergeFederatedBundleDigests returns a copy of the last state of the federated bundles, with the changes
+// of the given incremental result applied.
+func mergeFederatedBundleDigests(lastDigests map[spiffeid.TrustDomain][]byte, result *galadrielclient.BundleSyncResult) map[spiffeid.TrustDomain][]byte {
+	digests := make(map[spiffeid.TrustDomain][]byte, len(lastDigests))
+	for td, digest := range lastDigests {
+		digests[td] = digest
+	}
+	for td, digest := range result.Digests {
+		digests[td] = digest
+	}
+	for _, td := range result.Removed {
+		delete(digests, td)
+	}
+
+	return digests
+}
+
+func (s *FederatedBundlesSynchronizer) synchronizeFederatedBundles(ctx context.Context) (err error) {
 	s.logger.Debug("Synchronize federated bundles with Galadriel Server")
 
+	ctx, span := telemetry.Tracer().Start(ctx, "SynchronizeFederatedBundles")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	spireCallCtx, spireCallCancel := context.WithTimeout(ctx, spireCallTimeout)
 	if spireCallCancel == nil {
 		return fmt.Errorf("failed to create context for SPIRE call")
@@ -110,10 +358,41 @@ func (s *FederatedBundlesSynchronizer) synchronizeFederatedBundles(ctx context.C
 		return nil
 	}
 
+	s.applyFederatedBundles(spireCallCtx, fedBundlesInSPIRE, bundles, digests)
+
+	return nil
+}
+
+// applyFederatedBundles sets the given bundles in the SPIRE Server, reconciles the federation relationships,
+// and deletes the bundles in the SPIRE Server of the trust domains that are no longer federated.
+// It must be called holding the lock.
+func (s *FederatedBundlesSynchronizer) applyFederatedBundles(spireCallCtx context.Context, fedBundlesInSPIRE, bundles []*entity.Bundle, digests map[spiffeid.TrustDomain][]byte) {
+	spireCallCtx, span := telemetry.Tracer().Start(spireCallCtx, "ApplyFederatedBundles")
+	defer span.End()
+
+	// the bundles in the SPIRE Server are the last trusted bundles of the federated trust domains
+	trustedBundles := make(map[spiffeid.TrustDomain]*entity.Bundle, len(fedBundlesInSPIRE))
+	for _, b := range fedBundlesInSPIRE {
+		trustedBundles[b.TrustDomainName] = b
+	}
+
 	bundlesToSet := make([]*spiffebundle.Bundle, 0)
 	for _, b := range bundles {
-		if err := s.validateBundleIntegrity(b); err != nil {
+		if err := s.validateBundleIntegrity(b, trustedBundles[b.TrustDomainName]); err != nil {
+			telemetry.IncBundleVerificationFailures(b.TrustDomainName.String())
+			span.AddEvent("bundle verification failed", trace.WithAttributes(
+				attribute.String(telemetry.TrustDomainAttribute, b.TrustDomainName.String())))
 			s.logger.Errorf("Failed to verify bundle for trust domain %q: %v", b.TrustDomainName, err)
+			s.quarantineBundle(b, fmt.Sprintf("verification failed: %v", err))
+			continue // skip the bundle
+		}
+
+		if err := s.checkBundleContinuity(b, trustedBundles[b.TrustDomainName]); err != nil {
+			telemetry.IncBundleVerificationFailures(b.TrustDomainName.String())
+			span.AddEvent("bundle continuity check failed", trace.WithAttributes(
+				attribute.String(telemetry.TrustDomainAttribute, b.TrustDomainName.String())))
+			s.logger.Errorf("Failed to check continuity of bundle for trust domain %q: %v", b.TrustDomainName, err)
+			s.quarantineBundle(b, fmt.Sprintf("continuity check failed: %v", err))
 			continue // skip the bundle
 		}
 
@@ -133,11 +412,13 @@ func (s *FederatedBundlesSynchronizer) synchronizeFederatedBundles(ctx context.C
 		s.logFederatedBundleSetStatuses(setStatuses)
 	}
 
+	s.reconcileFederationRelationships(spireCallCtx, digests)
+
 	bundlesToDelete := s.findTrustDomainsToDelete(fedBundlesInSPIRE, digests)
 	if len(bundlesToDelete) == 0 {
 		// No updates to be made, update the last state and return
 		s.lastFederatedBundleDigests = digests
-		return nil
+		return
 	}
 
 	deleteStatuses, err := s.spireClient.DeleteFederatedBundles(spireCallCtx, bundlesToDelete)
@@ -149,8 +430,6 @@ func (s *FederatedBundlesSynchronizer) synchronizeFederatedBundles(ctx context.C
 
 	// update the last state of federated bundles
 	s.lastFederatedBundleDigests = digests
-
-	return nil
 }
 
 // findTrustDomainsToDelete returns a slice of trust domains to delete based on the provided bundles and digests map.
@@ -165,9 +444,10 @@ func (s *FederatedBundlesSynchronizer) findTrustDomainsToDelete(bundles []*entit
 	return trustDomainsToDelete
 }
 
-// validateBundleIntegrity verifies the bundle using the given verifiers.
+// validateBundleIntegrity verifies the bundle using the given verifiers, passing them the bundle of the same
+// trust domain currently trusted by the SPIRE Server, which is nil if there is none.
 // If one of the verifiers can verify the bundle, it returns nil.
-func (s *FederatedBundlesSynchronizer) validateBundleIntegrity(bundle *entity.Bundle) error {
+func (s *FederatedBundlesSynchronizer) validateBundleIntegrity(bundle, trustedBundle *entity.Bundle) error {
 	var certChain []*x509.Certificate
 	if len(bundle.SigningCertificateChain) > 0 {
 		var err error
@@ -177,8 +457,17 @@ func (s *FederatedBundlesSynchronizer) validateBundleIntegrity(bundle *entity.Bu
 		}
 	}
 
+	var trusted *spiffebundle.Bundle
+	if trustedBundle != nil {
+		var err error
+		trusted, err = models.ConvertEntityBundleToSPIFFEBundle(trustedBundle)
+		if err != nil {
+			return fmt.Errorf("failed to parse trusted bundle: %w", err)
+		}
+	}
+
 	for _, verifier := range s.bundleVerifiers {
-		err := verifier.Verify(bundle.Data, bundle.Signature, certChain)
+		err := integrity.VerifyForTrustDomain(verifier, bundle.TrustDomainName, trusted, bundle.Data, bundle.Signature, certChain)
 		if err == nil {
 			return nil
 		}
@@ -188,6 +477,135 @@ func (s *FederatedBundlesSynchronizer) validateBundleIntegrity(bundle *entity.Bu
 	return fmt.Errorf("no verifier could verify the bundle")
 }
 
+// checkBundleContinuity checks, when the bundle continuity policy is enabled, that the bundle continues the bundle
+// of the same trust domain currently trusted by the SPIRE Server, which is nil if there is none. A bundle that does
+// not is accepted if an admin approved a reset of the bundle of the trust domain, consuming the approval.
+// It must be called holding the lock.
+func (s *FederatedBundlesSynchronizer) checkBundleContinuity(bundle, trustedBundle *entity.Bundle) error {
+	if !s.bundleContinuity || trustedBundle == nil {
+		return nil
+	}
+
+	previous, err := models.ConvertEntityBundleToSPIFFEBundle(trustedBundle)
+	if err != nil {
+		return fmt.Errorf("failed to parse trusted bundle: %w", err)
+	}
+
+	var certChain []*x509.Certificate
+	if len(bundle.SigningCertificateChain) > 0 {
+		certChain, err = x509.ParseCertificates(bundle.SigningCertificateChain)
+		if err != nil {
+			return fmt.Errorf("failed to parse signing certificate chain: %w", err)
+		}
+	}
+
+	err = integrity.CheckContinuity(previous, bundle.Data, bundle.Signature, certChain, time.Now())
+	if err == nil {
+		return nil
+	}
+
+	approvedAt, ok := s.resetApprovals[bundle.TrustDomainName]
+	if !ok {
+		return err
+	}
+
+	delete(s.resetApprovals, bundle.TrustDomainName)
+	s.logger.Warnf("Accepting bundle for trust domain %q that does not continue the trusted bundle, as approved by an admin at %s", bundle.TrustDomainName, approvedAt.UTC().Format(time.RFC3339))
+
+	return nil
+}
+
+// quarantineBundle keeps the rejected bundle in the quarantine, if configured.
+func (s *FederatedBundlesSynchronizer) quarantineBundle(bundle *entity.Bundle, reason string) {
+	if s.quarantine == nil {
+		return
+	}
+
+	qb, err := s.quarantine.Add(bundle, reason)
+	if err != nil {
+		s.logger.Errorf("Failed to quarantine bundle for trust domain %q: %v", bundle.TrustDomainName, err)
+		return
+	}
+
+	s.logger.WithField(telemetry.TrustDomain, bundle.TrustDomainName).Warnf("Bundle quarantined with ID %s", qb.ID)
+}
+
+// ListQuarantinedBundles returns the quarantined bundles, of all trust domains if the given one is zero.
+func (s *FederatedBundlesSynchronizer) ListQuarantinedBundles(td spiffeid.TrustDomain) ([]*entity.QuarantinedBundle, error) {
+	if s.quarantine == nil {
+		return nil, ErrQuarantineDisabled
+	}
+
+	return s.quarantine.List(td)
+}
+
+// GetQuarantinedBundle returns the quarantined bundle with the given ID, or nil if there is none.
+func (s *FederatedBundlesSynchronizer) GetQuarantinedBundle(id string) (*entity.QuarantinedBundle, error) {
+	if s.quarantine == nil {
+		return nil, ErrQuarantineDisabled
+	}
+
+	return s.quarantine.Get(id)
+}
+
+// AcceptQuarantinedBundle sets the quarantined bundle with the given ID in the SPIRE Server without verifying it,
+// and removes it from the quarantine. The bundle must still be the current bundle of its trust domain in the
+// Galadriel Server. It returns nil if there is no such bundle.
+func (s *FederatedBundlesSynchronizer) AcceptQuarantinedBundle(ctx context.Context, id string) (*entity.QuarantinedBundle, error) {
+	if s.quarantine == nil {
+		return nil, ErrQuarantineDisabled
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	qb, err := s.quarantine.Get(id)
+	if err != nil || qb == nil {
+		return nil, err
+	}
+
+	td := qb.Bundle.TrustDomainName
+	if digest, ok := s.lastFederatedBundleDigests[td]; !ok || !bytes.Equal(digest, qb.Bundle.Digest) {
+		return nil, ErrStaleQuarantinedBundle
+	}
+
+	spireBundle, err := models.ConvertEntityBundleToSPIFFEBundle(qb.Bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert bundle for trust domain %q: %w", td, err)
+	}
+
+	spireCallCtx, spireCallCancel := context.WithTimeout(ctx, spireCallTimeout)
+	defer spireCallCancel()
+
+	setStatuses, err := s.spireClient.SetFederatedBundles(spireCallCtx, []*spiffebundle.Bundle{spireBundle})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set federated bundle in SPIRE Server: %w", err)
+	}
+	s.logFederatedBundleSetStatuses(setStatuses)
+	for _, status := range setStatuses {
+		if status.Status.Code != codes.OK {
+			return nil, fmt.Errorf("failed to set federated bundle in SPIRE Server: %s", status.Status.Message)
+		}
+	}
+
+	if _, err := s.quarantine.Remove(id); err != nil {
+		return nil, err
+	}
+	s.logger.WithField(telemetry.TrustDomain, td).Warnf("Quarantined bundle %s accepted by an admin", id)
+
+	return qb, nil
+}
+
+// DiscardQuarantinedBundle removes the quarantined bundle with the given ID from the quarantine, without setting it
+// in the SPIRE Server. It reports whether the bundle was quarantined.
+func (s *FederatedBundlesSynchronizer) DiscardQuarantinedBundle(id string) (bool, error) {
+	if s.quarantine == nil {
+		return false, ErrQuarantineDisabled
+	}
+
+	return s.quarantine.Remove(id)
+}
+
 func (s *FederatedBundlesSynchronizer) fetchSPIREFederatedBundles(ctx context.Context) ([]*entity.Bundle, error) {
 	bundles, err := s.spireClient.GetFederatedBundles(ctx)
 	if err != nil {
@@ -214,6 +632,7 @@ func (s *FederatedBundlesSynchronizer) logFederatedBundleSetStatuses(federatedBu
 				telemetry.BundleOpStatus: status.Status.Message,
 			}).Error("Failed setting federated bundle", status.Bundle.TrustDomain(), status.Status)
 		} else {
+			telemetry.SetFederatedBundleUpdated(status.Bundle.TrustDomain().String(), time.Now())
 			s.logger.WithField(telemetry.TrustDomain, status.Bundle.TrustDomain()).Info("Federated bundle set")
 		}
 	}
@@ -227,6 +646,7 @@ func (s *FederatedBundlesSynchronizer) logFederatedBundleDeleteStatuses(deleteSt
 				telemetry.BundleOpStatus: status.Status.Message,
 			}).Error("Failed deleting federated bundle", status.TrustDomain, status.Status)
 		} else {
+			telemetry.DeleteFederatedBundle(status.TrustDomain)
 			s.logger.WithField(telemetry.TrustDomain, status.TrustDomain).Info("Federated bundle deleted")
 		}
 	}
@@ -1,3 +1,350 @@
 package bundlemanager
 
-// TODO: add tests
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/galadrielclient"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/spireclient"
+	"github.com/HewlettPackard/galadriel/test/certtest"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakespireserver"
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWatchClient struct {
+	galadrielclient.Client // Embedded interface, all methods will panic unless overridden
+
+	bundles     []*entity.Bundle
+	digests     map[spiffeid.TrustDomain][]byte
+	removed     []spiffeid.TrustDomain
+	cursor      int64
+	incremental bool
+
+	lastState  map[spiffeid.TrustDomain][]byte
+	lastCursor int64
+	lastWait   time.Duration
+}
+
+func (f *fakeWatchClient) WatchBundles(ctx context.Context, state map[spiffeid.TrustDomain][]byte, cursor int64, wait time.Duration) (*galadrielclient.BundleSyncResult, error) {
+	f.lastState = state
+	f.lastCursor = cursor
+	f.lastWait = wait
+
+	return &galadrielclient.BundleSyncResult{
+		Bundles:     f.bundles,
+		Digests:     f.digests,
+		Removed:     f.removed,
+		Cursor:      f.cursor,
+		Incremental: f.incremental,
+	}, nil
+}
+
+func TestWatchFederatedBundles(t *testing.T) {
+	server := fakespireserver.New(t)
+	server.WithFederatedBundles(&types.Bundle{TrustDomain: otherTD.String()})
+
+	spireClient, err := spireclient.NewSpireClient(context.Background(), server.Addr)
+	require.NoError(t, err)
+
+	caCert, _ := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+	bundleData, err := spiffebundle.FromX509Authorities(approvedTD, []*x509.Certificate{caCert}).Marshal()
+	require.NoError(t, err)
+
+	galadrielClient := &fakeWatchClient{
+		bundles: []*entity.Bundle{{TrustDomainName: approvedTD, Data: bundleData}},
+		digests: map[spiffeid.TrustDomain][]byte{approvedTD: []byte("digest")},
+	}
+
+	logger, _ := test.NewNullLogger()
+	synchronizer := NewFederatedBundlesSynchronizer(&FederatedBundlesSynchronizerConfig{
+		SpireClient:     spireClient,
+		GaladrielClient: galadrielClient,
+		BundleVerifiers: []integrity.Verifier{integrity.NewNoOpVerifier()},
+		SyncInterval:    time.Minute,
+		WatchTimeout:    time.Minute,
+		Logger:          logger,
+	})
+
+	// the bundle of the approved trust domain is pushed, the bundle of the trust domain no longer federated is deleted
+	changed, err := synchronizer.watchFederatedBundles(context.Background())
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, time.Minute, galadrielClient.lastWait)
+	assert.Empty(t, galadrielClient.lastState)
+
+	bundles := server.FederatedBundles()
+	require.Len(t, bundles, 1)
+	assert.Equal(t, approvedTD.String(), bundles[0].TrustDomain)
+	assert.Equal(t, galadrielClient.digests, synchronizer.lastFederatedBundleDigests)
+
+	// the wait elapses without changes
+	galadrielClient.bundles = nil
+	changed, err = synchronizer.watchFederatedBundles(context.Background())
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, galadrielClient.digests, galadrielClient.lastState)
+
+	// the relationship is removed, the bundle is deleted
+	galadrielClient.digests = map[spiffeid.TrustDomain][]byte{}
+	changed, err = synchronizer.watchFederatedBundles(context.Background())
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Empty(t, server.FederatedBundles())
+}
+
+func TestWatchFederatedBundlesIncremental(t *testing.T) {
+	server := fakespireserver.New(t)
+
+	spireClient, err := spireclient.NewSpireClient(context.Background(), server.Addr)
+	require.NoError(t, err)
+
+	caCert, _ := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+	bundleData, err := spiffebundle.FromX509Authorities(approvedTD, []*x509.Certificate{caCert}).Marshal()
+	require.NoError(t, err)
+
+	// the first watch is a full one, that returns the cursor to resume from
+	galadrielClient := &fakeWatchClient{
+		bundles: []*entity.Bundle{{TrustDomainName: approvedTD, Data: bundleData}},
+		digests: map[spiffeid.TrustDomain][]byte{approvedTD: []byte("digest"), otherTD: []byte("other-digest")},
+		cursor:  5,
+	}
+
+	logger, _ := test.NewNullLogger()
+	synchronizer := NewFederatedBundlesSynchronizer(&FederatedBundlesSynchronizerConfig{
+		SpireClient:     spireClient,
+		GaladrielClient: galadrielClient,
+		BundleVerifiers: []integrity.Verifier{integrity.NewNoOpVerifier()},
+		SyncInterval:    time.Minute,
+		WatchTimeout:    time.Minute,
+		Logger:          logger,
+	})
+
+	changed, err := synchronizer.watchFederatedBundles(context.Background())
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Zero(t, galadrielClient.lastCursor)
+	assert.Equal(t, int64(5), synchronizer.cursor)
+
+	// no changes since the cursor
+	galadrielClient.bundles = nil
+	galadrielClient.digests = map[spiffeid.TrustDomain][]byte{}
+	galadrielClient.cursor = 6
+	galadrielClient.incremental = true
+	changed, err = synchronizer.watchFederatedBundles(context.Background())
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, int64(5), galadrielClient.lastCursor)
+	assert.Equal(t, int64(6), synchronizer.cursor)
+
+	// the changes since the cursor are merged into the last state
+	galadrielClient.bundles = []*entity.Bundle{{TrustDomainName: approvedTD, Data: bundleData}}
+	galadrielClient.digests = map[spiffeid.TrustDomain][]byte{approvedTD: []byte("rotated-digest")}
+	galadrielClient.removed = []spiffeid.TrustDomain{otherTD}
+	galadrielClient.cursor = 9
+	changed, err = synchronizer.watchFederatedBundles(context.Background())
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, int64(6), galadrielClient.lastCursor)
+	assert.Equal(t, int64(9), synchronizer.cursor)
+	assert.Equal(t, map[spiffeid.TrustDomain][]byte{approvedTD: []byte("rotated-digest")}, synchronizer.lastFederatedBundleDigests)
+
+	bundles := server.FederatedBundles()
+	require.Len(t, bundles, 1)
+	assert.Equal(t, approvedTD.String(), bundles[0].TrustDomain)
+}
+
+func TestCheckSync(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	s := NewFederatedBundlesSynchronizer(&FederatedBundlesSynchronizerConfig{
+		SyncInterval: time.Minute,
+		Logger:       logger,
+	})
+
+	// the synchronizer is given time for the first sync
+	require.NoError(t, s.CheckSync(context.Background()))
+
+	lastSync := time.Now().Add(-maxMissedSyncs*time.Minute - time.Second)
+	s.lastSync.Store(lastSync.UnixNano())
+	assert.EqualError(t, s.CheckSync(context.Background()), "federated bundles not synced with Galadriel Server since "+lastSync.UTC().Format(time.RFC3339))
+}
+
+func TestValidateBundleIntegrityWithTrustedBundle(t *testing.T) {
+	clk := clock.New()
+	caCert, caKey := certtest.CreateTestSelfSignedCACertificate(t, clk)
+	trustedData, err := spiffebundle.FromX509Authorities(approvedTD, []*x509.Certificate{caCert}).Marshal()
+	require.NoError(t, err)
+	trustedBundle := &entity.Bundle{TrustDomainName: approvedTD, Data: trustedData}
+
+	svidKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	svidID, err := url.Parse("spiffe://" + approvedTD.String() + "/harvester")
+	require.NoError(t, err)
+	template, err := cryptoutil.CreateX509Template(clk, svidKey.Public(), pkix.Name{}, []*url.URL{svidID}, nil, time.Hour)
+	require.NoError(t, err)
+	svidCert, err := cryptoutil.SignX509(template, caCert, caKey)
+	require.NoError(t, err)
+
+	newData := []byte("new bundle")
+	signature, err := svidKey.Sign(rand.Reader, cryptoutil.CalculateDigest(newData), crypto.SHA256)
+	require.NoError(t, err)
+	bundle := &entity.Bundle{TrustDomainName: approvedTD, Data: newData, Signature: signature, SigningCertificateChain: svidCert.Raw}
+
+	verifier := integrity.NewX509SVIDVerifier()
+	require.NoError(t, verifier.Configure(&integrity.X509SVIDVerifierConfig{}))
+
+	logger, _ := test.NewNullLogger()
+	synchronizer := NewFederatedBundlesSynchronizer(&FederatedBundlesSynchronizerConfig{
+		BundleVerifiers: []integrity.Verifier{verifier},
+		SyncInterval:    time.Minute,
+		Logger:          logger,
+	})
+
+	require.NoError(t, synchronizer.validateBundleIntegrity(bundle, trustedBundle))
+
+	// without a bundle of the trust domain in the SPIRE Server, the signer cannot be trusted
+	assert.EqualError(t, synchronizer.validateBundleIntegrity(bundle, nil), "no verifier could verify the bundle")
+}
+
+func TestCheckBundleContinuity(t *testing.T) {
+	clk := clock.New()
+	oldCA, _ := certtest.CreateTestSelfSignedCACertificate(t, clk)
+	newCA, _ := certtest.CreateTestSelfSignedCACertificate(t, clk)
+
+	trustedData, err := spiffebundle.FromX509Authorities(approvedTD, []*x509.Certificate{oldCA}).Marshal()
+	require.NoError(t, err)
+	trustedBundle := &entity.Bundle{TrustDomainName: approvedTD, Data: trustedData}
+
+	rotatedData, err := spiffebundle.FromX509Authorities(approvedTD, []*x509.Certificate{oldCA, newCA}).Marshal()
+	require.NoError(t, err)
+	rotated := &entity.Bundle{TrustDomainName: approvedTD, Data: rotatedData}
+
+	replacedData, err := spiffebundle.FromX509Authorities(approvedTD, []*x509.Certificate{newCA}).Marshal()
+	require.NoError(t, err)
+	replaced := &entity.Bundle{TrustDomainName: approvedTD, Data: replacedData}
+
+	logger, _ := test.NewNullLogger()
+	newSynchronizer := func(bundleContinuity bool) *FederatedBundlesSynchronizer {
+		return NewFederatedBundlesSynchronizer(&FederatedBundlesSynchronizerConfig{
+			SyncInterval:     time.Minute,
+			Logger:           logger,
+			BundleContinuity: bundleContinuity,
+		})
+	}
+
+	// without the policy, any bundle is accepted
+	assert.NoError(t, newSynchronizer(false).checkBundleContinuity(replaced, trustedBundle))
+
+	s := newSynchronizer(true)
+	assert.NoError(t, s.checkBundleContinuity(replaced, nil))
+	assert.NoError(t, s.checkBundleContinuity(rotated, trustedBundle))
+	assert.ErrorIs(t, s.checkBundleContinuity(replaced, trustedBundle), integrity.ErrBundleDiscontinuity)
+
+	// the approval of a reset is consumed by the first bundle accepted thanks to it
+	s.lastFederatedBundleDigests = map[spiffeid.TrustDomain][]byte{approvedTD: []byte("digest"), otherTD: []byte("other-digest")}
+	s.ApproveBundleReset(approvedTD)
+	assert.Equal(t, map[spiffeid.TrustDomain][]byte{otherTD: []byte("other-digest")}, s.lastFederatedBundleDigests)
+
+	assert.NoError(t, s.checkBundleContinuity(rotated, trustedBundle))
+	assert.Contains(t, s.resetApprovals, approvedTD)
+	assert.NoError(t, s.checkBundleContinuity(replaced, trustedBundle))
+	assert.NotContains(t, s.resetApprovals, approvedTD)
+	assert.ErrorIs(t, s.checkBundleContinuity(replaced, trustedBundle), integrity.ErrBundleDiscontinuity)
+}
+
+func TestQuarantineRejectedBundles(t *testing.T) {
+	server := fakespireserver.New(t)
+
+	spireClient, err := spireclient.NewSpireClient(context.Background(), server.Addr)
+	require.NoError(t, err)
+
+	caCert, _ := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+	bundleData, err := spiffebundle.FromX509Authorities(approvedTD, []*x509.Certificate{caCert}).Marshal()
+	require.NoError(t, err)
+
+	// the bundle is not signed, and there is no bundle of the trust domain in the SPIRE Server to verify it with
+	galadrielClient := &fakeWatchClient{
+		bundles: []*entity.Bundle{{TrustDomainName: approvedTD, Data: bundleData, Digest: []byte("digest")}},
+		digests: map[spiffeid.TrustDomain][]byte{approvedTD: []byte("digest")},
+	}
+
+	verifier := integrity.NewX509SVIDVerifier()
+	require.NoError(t, verifier.Configure(&integrity.X509SVIDVerifierConfig{}))
+
+	quarantine, err := NewQuarantine(t.TempDir(), defaultQuarantineMaxBundles)
+	require.NoError(t, err)
+
+	logger, _ := test.NewNullLogger()
+	synchronizer := NewFederatedBundlesSynchronizer(&FederatedBundlesSynchronizerConfig{
+		SpireClient:     spireClient,
+		GaladrielClient: galadrielClient,
+		BundleVerifiers: []integrity.Verifier{verifier},
+		SyncInterval:    time.Minute,
+		WatchTimeout:    time.Minute,
+		Logger:          logger,
+		Quarantine:      quarantine,
+	})
+
+	// the rejected bundle is quarantined once, even if it is received again
+	for i := 0; i < 2; i++ {
+		synchronizer.lastFederatedBundleDigests = nil
+		_, err = synchronizer.watchFederatedBundles(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Empty(t, server.FederatedBundles())
+
+	quarantined, err := synchronizer.ListQuarantinedBundles(spiffeid.TrustDomain{})
+	require.NoError(t, err)
+	require.Len(t, quarantined, 1)
+	assert.Equal(t, approvedTD, quarantined[0].Bundle.TrustDomainName)
+	assert.Equal(t, bundleData, quarantined[0].Bundle.Data)
+	assert.Equal(t, "verification failed: no verifier could verify the bundle", quarantined[0].Reason)
+
+	id := quarantined[0].ID
+	qb, err := synchronizer.GetQuarantinedBundle(id)
+	require.NoError(t, err)
+	assert.Equal(t, quarantined[0], qb)
+
+	// the bundle cannot be accepted once the Galadriel Server has another bundle of the trust domain
+	synchronizer.lastFederatedBundleDigests = map[spiffeid.TrustDomain][]byte{approvedTD: []byte("rotated-digest")}
+	_, err = synchronizer.AcceptQuarantinedBundle(context.Background(), id)
+	assert.ErrorIs(t, err, ErrStaleQuarantinedBundle)
+
+	synchronizer.lastFederatedBundleDigests = map[spiffeid.TrustDomain][]byte{approvedTD: []byte("digest")}
+	qb, err = synchronizer.AcceptQuarantinedBundle(context.Background(), id)
+	require.NoError(t, err)
+	require.NotNil(t, qb)
+
+	bundles := server.FederatedBundles()
+	require.Len(t, bundles, 1)
+	assert.Equal(t, approvedTD.String(), bundles[0].TrustDomain)
+
+	qb, err = synchronizer.AcceptQuarantinedBundle(context.Background(), id)
+	require.NoError(t, err)
+	assert.Nil(t, qb)
+
+	removed, err := synchronizer.DiscardQuarantinedBundle(id)
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	// without a quarantine, the quarantined bundles cannot be managed
+	synchronizer = NewFederatedBundlesSynchronizer(&FederatedBundlesSynchronizerConfig{SyncInterval: time.Minute, Logger: logger})
+	_, err = synchronizer.ListQuarantinedBundles(spiffeid.TrustDomain{})
+	assert.ErrorIs(t, err, ErrQuarantineDisabled)
+}
@@ -0,0 +1,378 @@
+package bundlemanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/galadrielclient"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/spireclient"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc/codes"
+)
+
+// EntryPolicy selects the SPIRE registration entries whose federated trust domains are managed by the Harvester.
+// An entry matches the policy when its SPIFFE ID starts with the SPIFFEIDPrefix and it has all the Selectors.
+type EntryPolicy struct {
+	SPIFFEIDPrefix string
+	Selectors      []spireclient.Selector
+	// TrustDomains restricts the federated trust domains added to the matched entries.
+	// When empty, all the trust domains the Harvester federates with are added.
+	TrustDomains []spiffeid.TrustDomain
+}
+
+// FederatesWithConfig holds the configuration of the FederatesWithSynchronizer.
+type FederatesWithConfig struct {
+	SyncInterval time.Duration
+	Policies     []*EntryPolicy
+}
+
+func (c *FederatesWithConfig) validate() error {
+	if len(c.Policies) == 0 {
+		return errors.New("at least one entry policy is required")
+	}
+
+	for i, p := range c.Policies {
+		if p.SPIFFEIDPrefix == "" && len(p.Selectors) == 0 {
+			return fmt.Errorf("entry policy %d must define a SPIFFE ID prefix or selectors", i)
+		}
+	}
+
+	return nil
+}
+
+// matches returns true if the entry matches the policy.
+func (p *EntryPolicy) matches(entry *spireclient.Entry) bool {
+	if p.SPIFFEIDPrefix != "" && !strings.HasPrefix(entry.SPIFFEID.String(), p.SPIFFEIDPrefix) {
+		return false
+	}
+
+	for _, s := range p.Selectors {
+		if !hasSelector(entry.Selectors, s) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FederatesWithSynchronizer periodically updates the federates_with field of the SPIRE registration entries
+// matched by the configured policies, so that they federate with the trust domains of the approved relationships.
+// The synchronization process consists of the following steps:
+// 1. Fetch the relationships of the Harvester trust domain from the Galadriel Server.
+// 2. Fetch the registration entries and the federated bundles from the SPIRE Server.
+// 3. Add the trust domains of the approved relationships to the matched entries, provided SPIRE has their bundle.
+// 4. Remove from the matched entries the trust domains it added whose relationships are no longer approved.
+// 5. Log a report of the reconciliation.
+//
+// Federated trust domains added to the entries by other means are left untouched. The trust domains added by the
+// synchronizer are tracked per entry, and persisted when a state file is configured, so that they can still be
+// removed after a restart of the Harvester.
+type FederatesWithSynchronizer struct {
+	spireClient     spireclient.Client
+	galadrielClient galadrielclient.Client
+	trustDomain     spiffeid.TrustDomain
+	policies        []*EntryPolicy
+	syncInterval    time.Duration
+	logger          logrus.FieldLogger
+
+	// trust domains added to the entries by the synchronizer, used to remove them once their relationship is gone
+	state *federatesWithState
+}
+
+// FederatesWithSynchronizerConfig holds the configuration for FederatesWithSynchronizer.
+type FederatesWithSynchronizerConfig struct {
+	SpireClient     spireclient.Client
+	GaladrielClient galadrielclient.Client
+	TrustDomain     spiffeid.TrustDomain
+	Policies        []*EntryPolicy
+	SyncInterval    time.Duration
+	Logger          logrus.FieldLogger
+
+	// StatePath is optional, when set, the trust domains added to the entries by the synchronizer are persisted
+	// in this file. Otherwise, they are only known until the Harvester restarts.
+	StatePath string
+}
+
+func NewFederatesWithSynchronizer(config *FederatesWithSynchronizerConfig) (*FederatesWithSynchronizer, error) {
+	state, err := loadFederatesWithState(config.StatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FederatesWithSynchronizer{
+		spireClient:     config.SpireClient,
+		galadrielClient: config.GaladrielClient,
+		trustDomain:     config.TrustDomain,
+		policies:        config.Policies,
+		syncInterval:    config.SyncInterval,
+		logger:          config.Logger,
+		state:           state,
+	}, nil
+}
+
+// entryChange describes the federated trust domains added to and removed from a registration entry.
+type entryChange struct {
+	entry   *spireclient.Entry
+	added   []spiffeid.TrustDomain
+	removed []spiffeid.TrustDomain
+}
+
+// reconciliationReport summarizes a synchronization cycle.
+type reconciliationReport struct {
+	federatedTrustDomains []spiffeid.TrustDomain
+	matched               int
+	updated               []*entryChange
+	failed                []*entryChange
+}
+
+// StartSyncing starts the synchronization process.
+func (s *FederatesWithSynchronizer) StartSyncing(ctx context.Context) error {
+	s.logger.Info("Federates With Synchronizer started")
+
+	ticker := time.NewTicker(s.syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			report, err := s.synchronizeEntries(ctx)
+			if err != nil {
+				s.logger.Errorf("Failed to update the federated trust domains of registration entries: %v", err)
+				continue
+			}
+			s.logReport(report)
+		case <-ctx.Done():
+			s.logger.Info("Federates With Synchronizer stopped")
+			return nil
+		}
+	}
+}
+
+func (s *FederatesWithSynchronizer) synchronizeEntries(ctx context.Context) (*reconciliationReport, error) {
+	s.logger.Debug("Synchronize federated trust domains of registration entries")
+
+	galadrielCallCtx, galadrielCallCancel := context.WithTimeout(ctx, galadrielCallTimeout)
+	defer galadrielCallCancel()
+
+	relationships, err := s.galadrielClient.GetRelationships(galadrielCallCtx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relationships from Galadriel Server: %w", err)
+	}
+
+	spireCallCtx, spireCallCancel := context.WithTimeout(ctx, spireCallTimeout)
+	defer spireCallCancel()
+
+	bundles, err := s.spireClient.GetFederatedBundles(spireCallCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch federated bundles from SPIRE Server: %w", err)
+	}
+
+	// SPIRE rejects entries that federate with a trust domain it has no bundle for
+	bundleTrustDomains := make(map[spiffeid.TrustDomain]struct{}, len(bundles))
+	for _, b := range bundles {
+		bundleTrustDomains[b.TrustDomain()] = struct{}{}
+	}
+
+	federated := make(map[spiffeid.TrustDomain]struct{})
+	for _, r := range relationships {
+		peer := r.TrustDomainAName
+		if peer == s.trustDomain {
+			peer = r.TrustDomainBName
+		}
+
+		_, hasBundle := bundleTrustDomains[peer]
+		if r.TrustDomainAConsent == entity.ConsentStatusApproved && r.TrustDomainBConsent == entity.ConsentStatusApproved && hasBundle {
+			federated[peer] = struct{}{}
+		}
+	}
+
+	entries, err := s.spireClient.ListEntries(spireCallCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registration entries from SPIRE Server: %w", err)
+	}
+
+	// the entries deleted from the SPIRE Server are forgotten
+	entryIDs := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		entryIDs[entry.ID] = struct{}{}
+	}
+	s.state.retainEntries(entryIDs)
+
+	report := &reconciliationReport{federatedTrustDomains: sortedTrustDomains(federated)}
+	changes := make([]*entryChange, 0)
+	for _, entry := range entries {
+		desired, ok := s.desiredTrustDomains(entry, federated)
+		if !ok {
+			continue
+		}
+		report.matched++
+
+		if change := s.entryChangeFor(entry, desired); change != nil {
+			changes = append(changes, change)
+		}
+	}
+
+	if len(changes) == 0 {
+		s.saveState()
+		return report, nil
+	}
+
+	entriesToUpdate := make([]*spireclient.Entry, 0, len(changes))
+	for _, c := range changes {
+		entriesToUpdate = append(entriesToUpdate, c.entry)
+	}
+
+	statuses, err := s.spireClient.UpdateEntriesFederatesWith(spireCallCtx, entriesToUpdate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update registration entries in SPIRE Server: %w", err)
+	}
+
+	// statuses are returned in the same order as the entries in the request
+	for i, st := range statuses {
+		if i >= len(changes) {
+			break
+		}
+		if st.Status.Code != codes.OK {
+			s.logger.WithFields(logrus.Fields{
+				telemetry.EntryID:        changes[i].entry.ID,
+				telemetry.BundleOpStatus: st.Status.Message,
+			}).Error("Failed updating federated trust domains of registration entry")
+			report.failed = append(report.failed, changes[i])
+			continue
+		}
+		report.updated = append(report.updated, changes[i])
+		s.state.add(changes[i].entry.ID, changes[i].added...)
+		s.state.remove(changes[i].entry.ID, changes[i].removed...)
+	}
+	s.saveState()
+
+	return report, nil
+}
+
+// saveState persists the trust domains added to the entries. A failure is logged, the state is kept in memory
+// and saved again on the next synchronization.
+func (s *FederatesWithSynchronizer) saveState() {
+	if err := s.state.save(); err != nil {
+		s.logger.Errorf("Failed to save the federated trust domains added to registration entries: %v", err)
+	}
+}
+
+// desiredTrustDomains returns the federated trust domains the matching policies add to the entry,
+// and false if no policy matches the entry.
+func (s *FederatesWithSynchronizer) desiredTrustDomains(entry *spireclient.Entry, federated map[spiffeid.TrustDomain]struct{}) (map[spiffeid.TrustDomain]struct{}, bool) {
+	var matched bool
+	desired := make(map[spiffeid.TrustDomain]struct{})
+	for _, p := range s.policies {
+		if !p.matches(entry) {
+			continue
+		}
+		matched = true
+
+		if len(p.TrustDomains) == 0 {
+			for td := range federated {
+				desired[td] = struct{}{}
+			}
+			continue
+		}
+
+		for _, td := range p.TrustDomains {
+			if _, ok := federated[td]; ok {
+				desired[td] = struct{}{}
+			}
+		}
+	}
+
+	return desired, matched
+}
+
+// entryChangeFor returns the change to apply to the entry to federate with the desired trust domains,
+// or nil if the entry is up-to-date. Only the trust domains added to the entry by the synchronizer are removed from it.
+func (s *FederatesWithSynchronizer) entryChangeFor(entry *spireclient.Entry, desired map[spiffeid.TrustDomain]struct{}) *entryChange {
+	change := &entryChange{}
+	federatesWith := make([]spiffeid.TrustDomain, 0, len(entry.FederatesWith))
+
+	current := make(map[spiffeid.TrustDomain]struct{}, len(entry.FederatesWith))
+	for _, td := range entry.FederatesWith {
+		current[td] = struct{}{}
+
+		_, isDesired := desired[td]
+		if s.state.isManaged(entry.ID, td) && !isDesired {
+			change.removed = append(change.removed, td)
+			continue
+		}
+		federatesWith = append(federatesWith, td)
+	}
+
+	for _, td := range sortedTrustDomains(desired) {
+		if _, ok := current[td]; !ok {
+			change.added = append(change.added, td)
+			federatesWith = append(federatesWith, td)
+		}
+	}
+
+	if len(change.added) == 0 && len(change.removed) == 0 {
+		return nil
+	}
+
+	updated := *entry
+	updated.FederatesWith = federatesWith
+	change.entry = &updated
+
+	return change
+}
+
+func (s *FederatesWithSynchronizer) logReport(report *reconciliationReport) {
+	for _, c := range report.updated {
+		s.logger.WithFields(logrus.Fields{
+			telemetry.EntryID:  c.entry.ID,
+			telemetry.SPIFFEID: c.entry.SPIFFEID.String(),
+			telemetry.Add:      trustDomainNames(c.added),
+			telemetry.Remove:   trustDomainNames(c.removed),
+		}).Info("Updated federated trust domains of registration entry")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		telemetry.Federation: trustDomainNames(report.federatedTrustDomains),
+		"matched":            report.matched,
+		"updated":            len(report.updated),
+		"failed":             len(report.failed),
+	}).Info("Registration entries reconciled")
+}
+
+func hasSelector(selectors []spireclient.Selector, selector spireclient.Selector) bool {
+	for _, s := range selectors {
+		if s == selector {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortedTrustDomains(set map[spiffeid.TrustDomain]struct{}) []spiffeid.TrustDomain {
+	out := make([]spiffeid.TrustDomain, 0, len(set))
+	for td := range set {
+		out = append(out, td)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].String() < out[j].String()
+	})
+
+	return out
+}
+
+func trustDomainNames(trustDomains []spiffeid.TrustDomain) []string {
+	out := make([]string, 0, len(trustDomains))
+	for _, td := range trustDomains {
+		out = append(out, td.String())
+	}
+
+	return out
+}
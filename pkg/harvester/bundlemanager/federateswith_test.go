@@ -0,0 +1,232 @@
+package bundlemanager
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/galadrielclient"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/spireclient"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakespireserver"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	localTD    = spiffeid.RequireTrustDomainFromString("example.org")
+	approvedTD = spiffeid.RequireTrustDomainFromString("approved.org")
+	pendingTD  = spiffeid.RequireTrustDomainFromString("pending.org")
+	otherTD    = spiffeid.RequireTrustDomainFromString("other.org")
+)
+
+type fakeGaladrielClient struct {
+	galadrielclient.Client // Embedded interface, all methods will panic unless overridden
+
+	relationships []*entity.Relationship
+}
+
+func (f *fakeGaladrielClient) GetRelationships(ctx context.Context, status entity.ConsentStatus) ([]*entity.Relationship, error) {
+	return f.relationships, nil
+}
+
+func TestFederatesWithSynchronizer(t *testing.T) {
+	server := fakespireserver.New(t)
+	server.WithFederatedBundles(
+		&types.Bundle{TrustDomain: approvedTD.String()},
+		&types.Bundle{TrustDomain: pendingTD.String()},
+		&types.Bundle{TrustDomain: otherTD.String()},
+	)
+	server.WithEntries(
+		// matched by SPIFFE ID prefix, federates with a trust domain whose relationship is pending,
+		// added by the operator
+		&types.Entry{
+			Id:            "entry-1",
+			SpiffeId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/payments/api"},
+			FederatesWith: []string{pendingTD.String(), otherTD.String()},
+		},
+		// matched by selectors, already up-to-date with a trust domain added by the operator
+		&types.Entry{
+			Id:            "entry-2",
+			SpiffeId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/billing"},
+			Selectors:     []*types.Selector{{Type: "k8s", Value: "ns:billing"}, {Type: "k8s", Value: "sa:billing"}},
+			FederatesWith: []string{approvedTD.String()},
+		},
+		// not matched, only one of the selectors
+		&types.Entry{
+			Id:            "entry-3",
+			SpiffeId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/other"},
+			Selectors:     []*types.Selector{{Type: "k8s", Value: "ns:billing"}},
+			FederatesWith: []string{pendingTD.String()},
+		},
+	)
+
+	spireClient, err := spireclient.NewSpireClient(context.Background(), server.Addr)
+	require.NoError(t, err)
+
+	galadrielClient := &fakeGaladrielClient{
+		relationships: []*entity.Relationship{
+			{
+				TrustDomainAName:    localTD,
+				TrustDomainBName:    approvedTD,
+				TrustDomainAConsent: entity.ConsentStatusApproved,
+				TrustDomainBConsent: entity.ConsentStatusApproved,
+			},
+			{
+				TrustDomainAName:    pendingTD,
+				TrustDomainBName:    localTD,
+				TrustDomainAConsent: entity.ConsentStatusPending,
+				TrustDomainBConsent: entity.ConsentStatusApproved,
+			},
+		},
+	}
+
+	logger, _ := test.NewNullLogger()
+	synchronizer, err := NewFederatesWithSynchronizer(&FederatesWithSynchronizerConfig{
+		SpireClient:     spireClient,
+		GaladrielClient: galadrielClient,
+		TrustDomain:     localTD,
+		Policies: []*EntryPolicy{
+			{SPIFFEIDPrefix: "spiffe://example.org/payments/"},
+			{Selectors: []spireclient.Selector{{Type: "k8s", Value: "ns:billing"}, {Type: "k8s", Value: "sa:billing"}}},
+		},
+		Logger: logger,
+	})
+	require.NoError(t, err)
+
+	report, err := synchronizer.synchronizeEntries(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []spiffeid.TrustDomain{approvedTD}, report.federatedTrustDomains)
+	assert.Equal(t, 2, report.matched)
+	assert.Empty(t, report.failed)
+	require.Len(t, report.updated, 1)
+	assert.Equal(t, "entry-1", report.updated[0].entry.ID)
+	assert.Equal(t, []spiffeid.TrustDomain{approvedTD}, report.updated[0].added)
+	assert.Empty(t, report.updated[0].removed)
+
+	entries := server.Entries()
+	// trust domains added by the operator are left untouched, even when their relationship is pending
+	assert.Equal(t, []string{pendingTD.String(), otherTD.String(), approvedTD.String()}, entries[0].FederatesWith)
+	assert.Equal(t, []string{approvedTD.String()}, entries[1].FederatesWith)
+	assert.Equal(t, []string{pendingTD.String()}, entries[2].FederatesWith)
+
+	// the relationship is removed, the trust domain is removed from the entries it was added to
+	galadrielClient.relationships = nil
+
+	report, err = synchronizer.synchronizeEntries(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, report.federatedTrustDomains)
+	require.Len(t, report.updated, 1)
+	assert.Equal(t, []spiffeid.TrustDomain{approvedTD}, report.updated[0].removed)
+
+	entries = server.Entries()
+	assert.Equal(t, []string{pendingTD.String(), otherTD.String()}, entries[0].FederatesWith)
+	assert.Equal(t, []string{approvedTD.String()}, entries[1].FederatesWith)
+}
+
+func TestFederatesWithSynchronizerRemovesAddedTrustDomainsAfterRestart(t *testing.T) {
+	server := fakespireserver.New(t)
+	server.WithFederatedBundles(&types.Bundle{TrustDomain: approvedTD.String()})
+	server.WithEntries(&types.Entry{
+		Id:       "entry-1",
+		SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/payments/api"},
+	})
+
+	spireClient, err := spireclient.NewSpireClient(context.Background(), server.Addr)
+	require.NoError(t, err)
+
+	galadrielClient := &fakeGaladrielClient{
+		relationships: []*entity.Relationship{
+			{
+				TrustDomainAName:    localTD,
+				TrustDomainBName:    approvedTD,
+				TrustDomainAConsent: entity.ConsentStatusApproved,
+				TrustDomainBConsent: entity.ConsentStatusApproved,
+			},
+		},
+	}
+
+	logger, _ := test.NewNullLogger()
+	config := &FederatesWithSynchronizerConfig{
+		SpireClient:     spireClient,
+		GaladrielClient: galadrielClient,
+		TrustDomain:     localTD,
+		Policies:        []*EntryPolicy{{SPIFFEIDPrefix: "spiffe://example.org/payments/"}},
+		Logger:          logger,
+		StatePath:       filepath.Join(t.TempDir(), "federates-with.json"),
+	}
+
+	synchronizer, err := NewFederatesWithSynchronizer(config)
+	require.NoError(t, err)
+	_, err = synchronizer.synchronizeEntries(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{approvedTD.String()}, server.Entries()[0].FederatesWith)
+
+	// the Harvester restarts after the relationship was deleted
+	galadrielClient.relationships = nil
+	synchronizer, err = NewFederatesWithSynchronizer(config)
+	require.NoError(t, err)
+
+	report, err := synchronizer.synchronizeEntries(context.Background())
+	require.NoError(t, err)
+	require.Len(t, report.updated, 1)
+	assert.Equal(t, []spiffeid.TrustDomain{approvedTD}, report.updated[0].removed)
+	assert.Empty(t, server.Entries()[0].FederatesWith)
+}
+
+func TestFederatesWithSynchronizerPolicyTrustDomains(t *testing.T) {
+	server := fakespireserver.New(t)
+	server.WithFederatedBundles(&types.Bundle{TrustDomain: approvedTD.String()}, &types.Bundle{TrustDomain: otherTD.String()})
+	server.WithEntries(&types.Entry{
+		Id:       "entry-1",
+		SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/payments/api"},
+	})
+
+	spireClient, err := spireclient.NewSpireClient(context.Background(), server.Addr)
+	require.NoError(t, err)
+
+	galadrielClient := &fakeGaladrielClient{
+		relationships: []*entity.Relationship{
+			{
+				TrustDomainAName:    localTD,
+				TrustDomainBName:    approvedTD,
+				TrustDomainAConsent: entity.ConsentStatusApproved,
+				TrustDomainBConsent: entity.ConsentStatusApproved,
+			},
+			{
+				TrustDomainAName:    localTD,
+				TrustDomainBName:    otherTD,
+				TrustDomainAConsent: entity.ConsentStatusApproved,
+				TrustDomainBConsent: entity.ConsentStatusApproved,
+			},
+		},
+	}
+
+	logger, _ := test.NewNullLogger()
+	synchronizer, err := NewFederatesWithSynchronizer(&FederatesWithSynchronizerConfig{
+		SpireClient:     spireClient,
+		GaladrielClient: galadrielClient,
+		TrustDomain:     localTD,
+		Policies: []*EntryPolicy{
+			{SPIFFEIDPrefix: "spiffe://example.org/payments/", TrustDomains: []spiffeid.TrustDomain{otherTD}},
+		},
+		Logger: logger,
+	})
+	require.NoError(t, err)
+
+	report, err := synchronizer.synchronizeEntries(context.Background())
+	require.NoError(t, err)
+	require.Len(t, report.updated, 1)
+
+	assert.Equal(t, []string{otherTD.String()}, server.Entries()[0].FederatesWith)
+}
+
+func TestFederatesWithConfigValidate(t *testing.T) {
+	assert.EqualError(t, (&FederatesWithConfig{}).validate(), "at least one entry policy is required")
+	assert.EqualError(t, (&FederatesWithConfig{Policies: []*EntryPolicy{{}}}).validate(), "entry policy 0 must define a SPIFFE ID prefix or selectors")
+	assert.NoError(t, (&FederatesWithConfig{Policies: []*EntryPolicy{{SPIFFEIDPrefix: "spiffe://example.org/"}}}).validate())
+}
@@ -0,0 +1,128 @@
+package bundlemanager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/diskutil"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+)
+
+// federatesWithState keeps, per registration entry, the federated trust domains added by the
+// FederatesWithSynchronizer, so that only those are removed once their relationship is gone.
+// When it has a path, the state is persisted in that file to survive the restarts of the Harvester.
+type federatesWithState struct {
+	path    string
+	entries map[string]map[spiffeid.TrustDomain]struct{}
+
+	// changed is set when the entries changed since they were last saved
+	changed bool
+}
+
+// loadFederatesWithState loads the state from the file at the given path, if it exists.
+// When the path is empty, the state is kept in memory only.
+func loadFederatesWithState(path string) (*federatesWithState, error) {
+	state := &federatesWithState{
+		path:    path,
+		entries: make(map[string]map[spiffeid.TrustDomain]struct{}),
+	}
+	if path == "" {
+		return state, nil
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return state, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read federates with state: %w", err)
+	}
+
+	var record map[string][]string
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal federates with state: %w", err)
+	}
+
+	for entryID, names := range record {
+		for _, name := range names {
+			td, err := spiffeid.TrustDomainFromString(name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trust domain %q in federates with state: %w", name, err)
+			}
+			state.add(entryID, td)
+		}
+	}
+	state.changed = false
+
+	return state, nil
+}
+
+// isManaged returns true if the trust domain was added to the entry by the synchronizer.
+func (s *federatesWithState) isManaged(entryID string, td spiffeid.TrustDomain) bool {
+	_, ok := s.entries[entryID][td]
+	return ok
+}
+
+func (s *federatesWithState) add(entryID string, trustDomains ...spiffeid.TrustDomain) {
+	if len(trustDomains) == 0 {
+		return
+	}
+
+	if s.entries[entryID] == nil {
+		s.entries[entryID] = make(map[spiffeid.TrustDomain]struct{})
+	}
+	for _, td := range trustDomains {
+		s.entries[entryID][td] = struct{}{}
+	}
+	s.changed = true
+}
+
+func (s *federatesWithState) remove(entryID string, trustDomains ...spiffeid.TrustDomain) {
+	if len(trustDomains) == 0 {
+		return
+	}
+
+	for _, td := range trustDomains {
+		delete(s.entries[entryID], td)
+	}
+	if len(s.entries[entryID]) == 0 {
+		delete(s.entries, entryID)
+	}
+	s.changed = true
+}
+
+// retainEntries forgets the entries that are not in the given set, e.g. because they were deleted from the SPIRE Server.
+func (s *federatesWithState) retainEntries(entryIDs map[string]struct{}) {
+	for entryID := range s.entries {
+		if _, ok := entryIDs[entryID]; !ok {
+			delete(s.entries, entryID)
+			s.changed = true
+		}
+	}
+}
+
+// save persists the state, when it has a path and it changed since it was last saved.
+func (s *federatesWithState) save() error {
+	if s.path == "" || !s.changed {
+		return nil
+	}
+
+	record := make(map[string][]string, len(s.entries))
+	for entryID, trustDomains := range s.entries {
+		record[entryID] = trustDomainNames(sortedTrustDomains(trustDomains))
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal federates with state: %w", err)
+	}
+
+	if err := diskutil.AtomicWritePrivateFile(s.path, data); err != nil {
+		return fmt.Errorf("failed to write federates with state: %w", err)
+	}
+	s.changed = false
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package bundlemanager
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/harvester/galadrielclient"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// defaultLeaseRenewInterval is well below the TTL of the leases granted by the Galadriel Server,
+	// so that a lease is renewed several times before it expires.
+	defaultLeaseRenewInterval = 10 * time.Second
+	leaseReleaseTimeout       = 5 * time.Second
+)
+
+// LeaseKeeper keeps the upload lease of the trust domain granted by the Galadriel Server.
+// When several Harvester instances serve the same trust domain, e.g. for a SPIRE Server running in HA mode,
+// only the instance holding the lease uploads the SPIRE bundle to the Galadriel Server.
+// The lease keeper periodically tries to acquire or renew the lease, and releases it when it stops,
+// so that another instance can take over without waiting for the lease to expire.
+type LeaseKeeper struct {
+	galadrielClient galadrielclient.Client
+	instanceID      string
+	renewInterval   time.Duration
+	logger          logrus.FieldLogger
+
+	mu        sync.RWMutex
+	expiresAt time.Time // expiration of the lease held by the instance, zero if the lease is not held
+}
+
+// LeaseKeeperConfig holds the configuration for LeaseKeeper.
+type LeaseKeeperConfig struct {
+	GaladrielClient galadrielclient.Client
+	InstanceID      string
+	RenewInterval   time.Duration
+	Logger          logrus.FieldLogger
+}
+
+// NewLeaseKeeper creates a new LeaseKeeper instance.
+func NewLeaseKeeper(config *LeaseKeeperConfig) *LeaseKeeper {
+	return &LeaseKeeper{
+		galadrielClient: config.GaladrielClient,
+		instanceID:      config.InstanceID,
+		renewInterval:   config.RenewInterval,
+		logger:          config.Logger,
+	}
+}
+
+// StartKeeping acquires the lease and keeps renewing it until the context is done, then releases it.
+func (k *LeaseKeeper) StartKeeping(ctx context.Context) error {
+	k.logger.Info("Lease Keeper started")
+
+	ticker := time.NewTicker(k.renewInterval)
+	defer ticker.Stop()
+
+	k.acquireLease(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			k.acquireLease(ctx)
+		case <-ctx.Done():
+			k.releaseLease()
+			k.logger.Info("Lease Keeper stopped")
+			return nil
+		}
+	}
+}
+
+// IsHeld returns true if the Harvester instance holds an unexpired lease.
+func (k *LeaseKeeper) IsHeld() bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return time.Now().Before(k.expiresAt)
+}
+
+func (k *LeaseKeeper) acquireLease(ctx context.Context) {
+	galadrielCallCtx, galadrielCallCancel := context.WithTimeout(ctx, galadrielCallTimeout)
+	defer galadrielCallCancel()
+
+	lease, err := k.galadrielClient.AcquireLease(galadrielCallCtx)
+	if err != nil {
+		// the lease held so far remains valid until it expires
+		k.logger.Errorf("Failed to acquire lease: %v", err)
+		return
+	}
+
+	wasHeld := k.IsHeld()
+	held := lease.InstanceID == k.instanceID
+
+	k.mu.Lock()
+	if held {
+		k.expiresAt = lease.ExpiresAt
+	} else {
+		k.expiresAt = time.Time{}
+	}
+	k.mu.Unlock()
+
+	switch {
+	case held && !wasHeld:
+		k.logger.Info("Acquired lease, this instance uploads the SPIRE bundle")
+	case !held && wasHeld:
+		k.logger.Warnf("Lost lease to instance %q", lease.InstanceID)
+	case !held:
+		k.logger.Debugf("Lease is held by instance %q", lease.InstanceID)
+	}
+}
+
+func (k *LeaseKeeper) releaseLease() {
+	if !k.IsHeld() {
+		return
+	}
+
+	k.mu.Lock()
+	k.expiresAt = time.Time{}
+	k.mu.Unlock()
+
+	// the context of the keeper is done, use a new one to release the lease
+	ctx, cancel := context.WithTimeout(context.Background(), leaseReleaseTimeout)
+	defer cancel()
+
+	if err := k.galadrielClient.ReleaseLease(ctx); err != nil {
+		k.logger.Errorf("Failed to release lease: %v", err)
+		return
+	}
+
+	k.logger.Info("Released lease")
+}
@@ -0,0 +1,121 @@
+package bundlemanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/galadrielclient"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLeaseClient struct {
+	galadrielclient.Client // Embedded interface, all methods will panic unless overridden
+
+	lease    *entity.HarvesterLease
+	err      error
+	released bool
+}
+
+func (f *fakeLeaseClient) AcquireLease(ctx context.Context) (*entity.HarvesterLease, error) {
+	return f.lease, f.err
+}
+
+func (f *fakeLeaseClient) ReleaseLease(ctx context.Context) error {
+	f.released = true
+	return nil
+}
+
+func TestLeaseKeeper(t *testing.T) {
+	client := &fakeLeaseClient{}
+	logger, _ := test.NewNullLogger()
+	keeper := NewLeaseKeeper(&LeaseKeeperConfig{
+		GaladrielClient: client,
+		InstanceID:      "harvester-1",
+		RenewInterval:   time.Second,
+		Logger:          logger,
+	})
+	assert.False(t, keeper.IsHeld())
+
+	// the lease is held by another instance
+	client.lease = &entity.HarvesterLease{InstanceID: "harvester-2", ExpiresAt: time.Now().Add(time.Minute)}
+	keeper.acquireLease(context.Background())
+	assert.False(t, keeper.IsHeld())
+
+	client.lease = &entity.HarvesterLease{InstanceID: "harvester-1", ExpiresAt: time.Now().Add(time.Minute)}
+	keeper.acquireLease(context.Background())
+	assert.True(t, keeper.IsHeld())
+
+	// the lease remains held until it expires if it cannot be renewed
+	client.err = errors.New("connection refused")
+	keeper.acquireLease(context.Background())
+	assert.True(t, keeper.IsHeld())
+
+	keeper.releaseLease()
+	assert.False(t, keeper.IsHeld())
+	assert.True(t, client.released)
+}
+
+func TestLeaseKeeperExpiredLease(t *testing.T) {
+	client := &fakeLeaseClient{lease: &entity.HarvesterLease{InstanceID: "harvester-1", ExpiresAt: time.Now().Add(-time.Second)}}
+	logger, _ := test.NewNullLogger()
+	keeper := NewLeaseKeeper(&LeaseKeeperConfig{
+		GaladrielClient: client,
+		InstanceID:      "harvester-1",
+		RenewInterval:   time.Second,
+		Logger:          logger,
+	})
+
+	keeper.acquireLease(context.Background())
+	assert.False(t, keeper.IsHeld())
+
+	// an expired lease is not released
+	keeper.releaseLease()
+	assert.False(t, client.released)
+}
+
+func TestLeaseKeeperStartKeeping(t *testing.T) {
+	client := &fakeLeaseClient{lease: &entity.HarvesterLease{InstanceID: "harvester-1", ExpiresAt: time.Now().Add(time.Minute)}}
+	logger, _ := test.NewNullLogger()
+	keeper := NewLeaseKeeper(&LeaseKeeperConfig{
+		GaladrielClient: client,
+		InstanceID:      "harvester-1",
+		RenewInterval:   time.Hour,
+		Logger:          logger,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- keeper.StartKeeping(ctx)
+	}()
+
+	// the lease is acquired as soon as the keeper starts
+	require.Eventually(t, keeper.IsHeld, time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-errCh)
+	assert.True(t, client.released)
+}
+
+func TestSpireBundleSynchronizerSkipsUploadWithoutLease(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	keeper := NewLeaseKeeper(&LeaseKeeperConfig{
+		GaladrielClient: &fakeLeaseClient{},
+		InstanceID:      "harvester-1",
+		Logger:          logger,
+	})
+
+	// neither the SPIRE Server nor the Galadriel Server are called while the lease is not held
+	synchronizer := NewSpireSynchronizer(&SpireSynchronizerConfig{
+		LeaseKeeper: keeper,
+		Logger:      logger,
+	})
+
+	err := synchronizer.syncSpireBundleToGaladriel(context.Background())
+	require.NoError(t, err)
+}
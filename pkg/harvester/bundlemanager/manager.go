@@ -3,19 +3,24 @@ package bundlemanager
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/common/util"
 	"github.com/HewlettPackard/galadriel/pkg/harvester/galadrielclient"
 	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
 	"github.com/HewlettPackard/galadriel/pkg/harvester/spireclient"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
 
 const (
 	defaultFederatedBundlesPollInterval = 2 * time.Minute
 	defaultSpireBundlesPollInterval     = 1 * time.Minute
+	defaultFederatesWithSyncInterval    = 1 * time.Minute
+	defaultQuarantineMaxBundles         = 10
 	spireCallTimeout                    = 10 * time.Second
 	galadrielCallTimeout                = 2 * time.Minute
 )
@@ -24,25 +29,65 @@ const (
 type BundleManager struct {
 	federatedBundlesSynchronizer *FederatedBundlesSynchronizer
 	spireBundleSynchronizer      *SpireBundleSynchronizer
+
+	// federatesWithSynchronizer is nil unless the federated trust domains of the registration entries are managed
+	federatesWithSynchronizer *FederatesWithSynchronizer
+
+	// leaseKeeper is nil unless the Harvester instance is configured with an instance ID
+	leaseKeeper *LeaseKeeper
 }
 
 // Config holds the configuration for BundleManager.
 type Config struct {
+	TrustDomain                  spiffeid.TrustDomain
 	SpireClient                  spireclient.Client
 	GaladrielClient              galadrielclient.Client
 	FederatedBundlesPollInterval time.Duration
 	SpireBundlePollInterval      time.Duration
 
+	// FederatedBundlesWatchTimeout is optional, when set, the Harvester waits up to this long for the Galadriel
+	// Server to push changes of the federated bundles, and the poll interval only applies as a fallback.
+	FederatedBundlesWatchTimeout time.Duration
+
 	// BundleSigner is used to sign the bundle before uploading it to Galadriel Server.
 	BundleSigner integrity.Signer
 	// BundleVerifiers are used to verify the bundle received from the SPIRE Server.
 	BundleVerifiers []integrity.Verifier
 
+	// FederationRelationships is optional, when set, the Harvester manages the federation relationships in the SPIRE Server.
+	FederationRelationships *FederationRelationshipsConfig
+
+	// FederatesWith is optional, when set, the Harvester updates the federated trust domains of the registration
+	// entries matched by its policies.
+	FederatesWith *FederatesWithConfig
+
+	// BundleContinuity is optional, when set, the federated bundles that keep no X.509 authority of the bundles
+	// trusted by the SPIRE Server, and are not signed by a key chaining to them, are skipped unless an admin
+	// approved a reset.
+	BundleContinuity bool
+
+	// FederatesWithStatePath is optional, when set, the federated trust domains the Harvester added to the
+	// registration entries are persisted in this file, so that they are removed even after a restart.
+	FederatesWithStatePath string
+
+	// QuarantineDir is optional, when set, the rejected federated bundles are kept in this directory until an admin
+	// accepts or discards them.
+	QuarantineDir string
+
+	// QuarantineMaxBundles is the number of rejected federated bundles kept per trust domain, the oldest ones
+	// are dropped first. It defaults to 10.
+	QuarantineMaxBundles int
+
+	// InstanceID is optional, when set, the Harvester instance coordinates with the other instances of
+	// the trust domain through a lease granted by the Galadriel Server, and only uploads the SPIRE bundle
+	// while it holds the lease.
+	InstanceID string
+
 	Logger logrus.FieldLogger
 }
 
 // NewBundleManager creates a new BundleManager instance.
-func NewBundleManager(c *Config) *BundleManager {
+func NewBundleManager(c *Config) (*BundleManager, error) {
 	if c.FederatedBundlesPollInterval == 0 {
 		c.FederatedBundlesPollInterval = defaultFederatedBundlesPollInterval
 	}
@@ -50,12 +95,51 @@ func NewBundleManager(c *Config) *BundleManager {
 		c.SpireBundlePollInterval = defaultSpireBundlesPollInterval
 	}
 
+	if c.FederationRelationships != nil {
+		if err := c.FederationRelationships.validate(); err != nil {
+			return nil, fmt.Errorf("invalid federation relationships configuration: %w", err)
+		}
+	}
+
+	if c.FederatesWith != nil {
+		if err := c.FederatesWith.validate(); err != nil {
+			return nil, fmt.Errorf("invalid federates with configuration: %w", err)
+		}
+		if c.FederatesWith.SyncInterval == 0 {
+			c.FederatesWith.SyncInterval = defaultFederatesWithSyncInterval
+		}
+	}
+
+	if c.QuarantineMaxBundles == 0 {
+		c.QuarantineMaxBundles = defaultQuarantineMaxBundles
+	}
+
+	var quarantine *Quarantine
+	if c.QuarantineDir != "" {
+		var err error
+		quarantine, err = NewQuarantine(c.QuarantineDir, c.QuarantineMaxBundles)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var leaseKeeper *LeaseKeeper
+	if c.InstanceID != "" {
+		leaseKeeper = NewLeaseKeeper(&LeaseKeeperConfig{
+			GaladrielClient: c.GaladrielClient,
+			InstanceID:      c.InstanceID,
+			RenewInterval:   defaultLeaseRenewInterval,
+			Logger:          c.Logger.WithField(telemetry.SubsystemName, telemetry.LeaseKeeper),
+		})
+	}
+
 	spireBundleSync := NewSpireSynchronizer(&SpireSynchronizerConfig{
 		GaladrielClient: c.GaladrielClient,
 		SpireClient:     c.SpireClient,
 		BundleSigner:    c.BundleSigner,
 		SyncInterval:    c.SpireBundlePollInterval,
 		Logger:          c.Logger.WithField(telemetry.SubsystemName, telemetry.SpireBundleSynchronizer),
+		LeaseKeeper:     leaseKeeper,
 	})
 
 	fedBundlesSync := NewFederatedBundlesSynchronizer(&FederatedBundlesSynchronizerConfig{
@@ -63,13 +147,37 @@ func NewBundleManager(c *Config) *BundleManager {
 		SpireClient:     c.SpireClient,
 		BundleVerifiers: c.BundleVerifiers,
 		SyncInterval:    c.FederatedBundlesPollInterval,
+		WatchTimeout:    c.FederatedBundlesWatchTimeout,
 		Logger:          c.Logger.WithField(telemetry.SubsystemName, telemetry.FederatedBundlesSynchronizer),
+
+		FederationRelationships: c.FederationRelationships,
+		BundleContinuity:        c.BundleContinuity,
+		Quarantine:              quarantine,
 	})
 
-	return &BundleManager{
+	bm := &BundleManager{
 		federatedBundlesSynchronizer: fedBundlesSync,
 		spireBundleSynchronizer:      spireBundleSync,
+		leaseKeeper:                  leaseKeeper,
 	}
+
+	if c.FederatesWith != nil {
+		var err error
+		bm.federatesWithSynchronizer, err = NewFederatesWithSynchronizer(&FederatesWithSynchronizerConfig{
+			SpireClient:     c.SpireClient,
+			GaladrielClient: c.GaladrielClient,
+			TrustDomain:     c.TrustDomain,
+			Policies:        c.FederatesWith.Policies,
+			SyncInterval:    c.FederatesWith.SyncInterval,
+			Logger:          c.Logger.WithField(telemetry.SubsystemName, telemetry.FederatesWithSynchronizer),
+			StatePath:       c.FederatesWithStatePath,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return bm, nil
 }
 
 // Run runs the bundle synchronization processes.
@@ -78,6 +186,12 @@ func (bm *BundleManager) Run(ctx context.Context) error {
 		bm.federatedBundlesSynchronizer.StartSyncing,
 		bm.spireBundleSynchronizer.StartSyncing,
 	}
+	if bm.federatesWithSynchronizer != nil {
+		tasks = append(tasks, bm.federatesWithSynchronizer.StartSyncing)
+	}
+	if bm.leaseKeeper != nil {
+		tasks = append(tasks, bm.leaseKeeper.StartKeeping)
+	}
 
 	err := util.RunTasks(ctx, tasks...)
 	if errors.Is(err, context.Canceled) {
@@ -86,3 +200,37 @@ func (bm *BundleManager) Run(ctx context.Context) error {
 
 	return err
 }
+
+// CheckFederatedBundlesSync reports an error if the federated bundles have not been synced successfully
+// with the Galadriel Server for several poll intervals.
+func (bm *BundleManager) CheckFederatedBundlesSync(ctx context.Context) error {
+	return bm.federatedBundlesSynchronizer.CheckSync(ctx)
+}
+
+// ApproveBundleReset approves the next federated bundle of the trust domain even if it does not continue the bundle
+// trusted by the SPIRE Server. It returns the time of the approval.
+func (bm *BundleManager) ApproveBundleReset(td spiffeid.TrustDomain) time.Time {
+	return bm.federatedBundlesSynchronizer.ApproveBundleReset(td)
+}
+
+// ListQuarantinedBundles returns the quarantined federated bundles, of all trust domains if the given one is zero.
+func (bm *BundleManager) ListQuarantinedBundles(td spiffeid.TrustDomain) ([]*entity.QuarantinedBundle, error) {
+	return bm.federatedBundlesSynchronizer.ListQuarantinedBundles(td)
+}
+
+// GetQuarantinedBundle returns the quarantined federated bundle with the given ID, or nil if there is none.
+func (bm *BundleManager) GetQuarantinedBundle(id string) (*entity.QuarantinedBundle, error) {
+	return bm.federatedBundlesSynchronizer.GetQuarantinedBundle(id)
+}
+
+// AcceptQuarantinedBundle sets the quarantined federated bundle with the given ID in the SPIRE Server without
+// verifying it. It returns nil if there is no such bundle.
+func (bm *BundleManager) AcceptQuarantinedBundle(ctx context.Context, id string) (*entity.QuarantinedBundle, error) {
+	return bm.federatedBundlesSynchronizer.AcceptQuarantinedBundle(ctx, id)
+}
+
+// DiscardQuarantinedBundle removes the quarantined federated bundle with the given ID. It reports whether the bundle
+// was quarantined.
+func (bm *BundleManager) DiscardQuarantinedBundle(id string) (bool, error) {
+	return bm.federatedBundlesSynchronizer.DiscardQuarantinedBundle(id)
+}
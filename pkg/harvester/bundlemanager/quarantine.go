@@ -0,0 +1,261 @@
+package bundlemanager
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/diskutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/util/fileutil"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+)
+
+const quarantineFileExt = ".json"
+
+// Quarantine keeps the federated bundles rejected by the Harvester on disk, one file per bundle, with the reason
+// of the rejection, so that an admin can inspect them and accept or discard them.
+// A bundle rejected again is kept once, with the reason of the last rejection. At most maxBundles bundles are kept
+// per trust domain, the oldest ones are dropped first.
+type Quarantine struct {
+	dir        string
+	maxBundles int
+
+	mu sync.Mutex
+}
+
+// quarantineRecord is the representation of a quarantined bundle on disk.
+type quarantineRecord struct {
+	TrustDomain             string    `json:"trust_domain"`
+	Data                    []byte    `json:"data"`
+	Digest                  []byte    `json:"digest,omitempty"`
+	Signature               []byte    `json:"signature,omitempty"`
+	SigningCertificateChain []byte    `json:"signing_certificate_chain,omitempty"`
+	Reason                  string    `json:"reason"`
+	QuarantinedAt           time.Time `json:"quarantined_at"`
+}
+
+// NewQuarantine creates a Quarantine that keeps up to maxBundles bundles per trust domain in the given directory,
+// creating it if needed.
+func NewQuarantine(dir string, maxBundles int) (*Quarantine, error) {
+	if maxBundles <= 0 {
+		return nil, errors.New("quarantine max bundles must be greater than zero")
+	}
+	if err := fileutil.CreateDirIfNotExist(dir); err != nil {
+		return nil, fmt.Errorf("failed to create quarantine directory: %w", err)
+	}
+
+	return &Quarantine{dir: dir, maxBundles: maxBundles}, nil
+}
+
+// Add quarantines the bundle with the reason of its rejection. If the bundle is already quarantined,
+// its reason is updated and the time it was first quarantined is kept. When the trust domain then has more
+// than maxBundles quarantined bundles, its oldest other bundles are dropped.
+func (q *Quarantine) Add(bundle *entity.Bundle, reason string) (*entity.QuarantinedBundle, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	id := quarantineID(bundle)
+	quarantinedAt := time.Now()
+
+	existing, err := q.read(id)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		quarantinedAt = existing.QuarantinedAt
+	}
+
+	record := &quarantineRecord{
+		TrustDomain:             bundle.TrustDomainName.String(),
+		Data:                    bundle.Data,
+		Digest:                  bundle.Digest,
+		Signature:               bundle.Signature,
+		SigningCertificateChain: bundle.SigningCertificateChain,
+		Reason:                  reason,
+		QuarantinedAt:           quarantinedAt,
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal quarantined bundle: %w", err)
+	}
+
+	if err := diskutil.AtomicWritePrivateFile(q.path(id), data); err != nil {
+		return nil, fmt.Errorf("failed to write quarantined bundle: %w", err)
+	}
+
+	if err := q.dropOldest(bundle.TrustDomainName, id); err != nil {
+		return nil, err
+	}
+
+	return record.toEntity(id)
+}
+
+// List returns the quarantined bundles, oldest first. When the trust domain is not zero,
+// only the bundles of that trust domain are returned.
+func (q *Quarantine) List(td spiffeid.TrustDomain) ([]*entity.QuarantinedBundle, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.list(td)
+}
+
+// Get returns the quarantined bundle with the given ID, or nil if there is none.
+func (q *Quarantine) Get(id string) (*entity.QuarantinedBundle, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.read(id)
+}
+
+// Remove removes the quarantined bundle with the given ID. It reports whether the bundle was quarantined.
+func (q *Quarantine) Remove(id string) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !isValidQuarantineID(id) {
+		return false, nil
+	}
+
+	err := os.Remove(q.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to remove quarantined bundle: %w", err)
+	}
+
+	return true, nil
+}
+
+// list returns the quarantined bundles of the trust domain, or of all trust domains if it is zero, oldest first.
+// It must be called holding the lock.
+func (q *Quarantine) list(td spiffeid.TrustDomain) ([]*entity.QuarantinedBundle, error) {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read quarantine directory: %w", err)
+	}
+
+	bundles := make([]*entity.QuarantinedBundle, 0)
+	for _, e := range entries {
+		id, ok := strings.CutSuffix(e.Name(), quarantineFileExt)
+		if e.IsDir() || !ok || !isValidQuarantineID(id) {
+			continue
+		}
+
+		qb, err := q.read(id)
+		if err != nil {
+			return nil, err
+		}
+		if qb == nil || (!td.IsZero() && qb.Bundle.TrustDomainName != td) {
+			continue
+		}
+
+		bundles = append(bundles, qb)
+	}
+
+	sort.SliceStable(bundles, func(i, j int) bool {
+		return bundles[i].QuarantinedAt.Before(bundles[j].QuarantinedAt)
+	})
+
+	return bundles, nil
+}
+
+// dropOldest removes the oldest quarantined bundles of the trust domain, other than the one with the given ID,
+// until it has at most maxBundles bundles. It must be called holding the lock.
+func (q *Quarantine) dropOldest(td spiffeid.TrustDomain, keepID string) error {
+	bundles, err := q.list(td)
+	if err != nil {
+		return err
+	}
+
+	excess := len(bundles) - q.maxBundles
+	for _, qb := range bundles {
+		if excess <= 0 {
+			break
+		}
+		if qb.ID == keepID {
+			continue
+		}
+
+		if err := os.Remove(q.path(qb.ID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove oldest quarantined bundle: %w", err)
+		}
+		excess--
+	}
+
+	return nil
+}
+
+// read reads the quarantined bundle with the given ID, returning nil if there is none.
+// It must be called holding the lock.
+func (q *Quarantine) read(id string) (*entity.QuarantinedBundle, error) {
+	if !isValidQuarantineID(id) {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(q.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read quarantined bundle: %w", err)
+	}
+
+	var record quarantineRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal quarantined bundle %q: %w", id, err)
+	}
+
+	return record.toEntity(id)
+}
+
+func (q *Quarantine) path(id string) string {
+	return filepath.Join(q.dir, id+quarantineFileExt)
+}
+
+func (r *quarantineRecord) toEntity(id string) (*entity.QuarantinedBundle, error) {
+	td, err := spiffeid.TrustDomainFromString(r.TrustDomain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse trust domain of quarantined bundle %q: %w", id, err)
+	}
+
+	return &entity.QuarantinedBundle{
+		ID: id,
+		Bundle: &entity.Bundle{
+			TrustDomainName:         td,
+			Data:                    r.Data,
+			Digest:                  r.Digest,
+			Signature:               r.Signature,
+			SigningCertificateChain: r.SigningCertificateChain,
+		},
+		Reason:        r.Reason,
+		QuarantinedAt: r.QuarantinedAt,
+	}, nil
+}
+
+// quarantineID identifies a bundle by its trust domain and its data, so that a bundle rejected on every sync
+// is quarantined once.
+func quarantineID(bundle *entity.Bundle) string {
+	data := append([]byte(bundle.TrustDomainName.String()+"\n"), bundle.Data...)
+	return hex.EncodeToString(cryptoutil.CalculateDigest(data))
+}
+
+// isValidQuarantineID reports whether the ID is a hex encoded SHA-256 digest, so that it cannot escape
+// the quarantine directory.
+func isValidQuarantineID(id string) bool {
+	if len(id) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
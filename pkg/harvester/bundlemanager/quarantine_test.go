@@ -0,0 +1,123 @@
+package bundlemanager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuarantine(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "quarantine")
+	q, err := NewQuarantine(dir, defaultQuarantineMaxBundles)
+	require.NoError(t, err)
+
+	bundleA := &entity.Bundle{TrustDomainName: approvedTD, Data: []byte("bundle-a"), Digest: []byte("digest-a"), Signature: []byte("signature")}
+	bundleB := &entity.Bundle{TrustDomainName: otherTD, Data: []byte("bundle-b")}
+
+	qbA, err := q.Add(bundleA, "verification failed")
+	require.NoError(t, err)
+	assert.Len(t, qbA.ID, 64)
+	assert.Equal(t, bundleA, qbA.Bundle)
+
+	qbB, err := q.Add(bundleB, "continuity check failed")
+	require.NoError(t, err)
+	assert.NotEqual(t, qbA.ID, qbB.ID)
+
+	// a bundle rejected again keeps the time it was first quarantined, with the last reason
+	again, err := q.Add(bundleA, "verification failed again")
+	require.NoError(t, err)
+	assert.Equal(t, qbA.ID, again.ID)
+	assert.True(t, qbA.QuarantinedAt.Equal(again.QuarantinedAt))
+	assert.Equal(t, "verification failed again", again.Reason)
+
+	all, err := q.List(spiffeid.TrustDomain{})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, qbA.ID, all[0].ID)
+	assert.Equal(t, qbB.ID, all[1].ID)
+
+	filtered, err := q.List(otherTD)
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, qbB.ID, filtered[0].ID)
+
+	// the quarantine is kept on disk
+	q, err = NewQuarantine(dir, defaultQuarantineMaxBundles)
+	require.NoError(t, err)
+	got, err := q.Get(qbA.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, bundleA, got.Bundle)
+	assert.Equal(t, "verification failed again", got.Reason)
+
+	// IDs that are not digests are never looked up on disk
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(dir), "outside.json"), []byte("{}"), 0600))
+	got, err = q.Get("../outside")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	removed, err := q.Remove("../outside")
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	removed, err = q.Remove(qbA.ID)
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = q.Remove(qbA.ID)
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	got, err = q.Get(qbA.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestQuarantineMaxBundles(t *testing.T) {
+	dir := t.TempDir()
+	q, err := NewQuarantine(dir, 2)
+	require.NoError(t, err)
+
+	first, err := q.Add(&entity.Bundle{TrustDomainName: approvedTD, Data: []byte("bundle-1")}, "verification failed")
+	require.NoError(t, err)
+	second, err := q.Add(&entity.Bundle{TrustDomainName: approvedTD, Data: []byte("bundle-2")}, "verification failed")
+	require.NoError(t, err)
+	other, err := q.Add(&entity.Bundle{TrustDomainName: otherTD, Data: []byte("bundle-other")}, "verification failed")
+	require.NoError(t, err)
+
+	// the oldest bundle of the trust domain is dropped, the bundles of other trust domains are kept
+	third, err := q.Add(&entity.Bundle{TrustDomainName: approvedTD, Data: []byte("bundle-3")}, "verification failed")
+	require.NoError(t, err)
+
+	bundles, err := q.List(approvedTD)
+	require.NoError(t, err)
+	require.Len(t, bundles, 2)
+	assert.Equal(t, second.ID, bundles[0].ID)
+	assert.Equal(t, third.ID, bundles[1].ID)
+
+	got, err := q.Get(first.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = q.Get(other.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+
+	// with a lower maximum, a bundle rejected again is kept even if it is the oldest of the trust domain
+	q, err = NewQuarantine(dir, 1)
+	require.NoError(t, err)
+	again, err := q.Add(&entity.Bundle{TrustDomainName: approvedTD, Data: []byte("bundle-2")}, "verification failed again")
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, again.ID)
+
+	bundles, err = q.List(approvedTD)
+	require.NoError(t, err)
+	require.Len(t, bundles, 1)
+	assert.Equal(t, second.ID, bundles[0].ID)
+
+	_, err = NewQuarantine(t.TempDir(), 0)
+	assert.EqualError(t, err, "quarantine max bundles must be greater than zero")
+}
@@ -0,0 +1,163 @@
+package bundlemanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/spireclient"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc/codes"
+)
+
+// FederationRelationshipsConfig holds the configuration used to manage the federation relationships in the SPIRE Server.
+// For each trust domain the Harvester federates with, a federation relationship pointing to the
+// Galadriel Server bundle endpoint is created in the SPIRE Server.
+type FederationRelationshipsConfig struct {
+	// BundleEndpointURL is the base URL of the Galadriel Server bundle endpoint.
+	// The trust domain name is appended to it to build the bundle endpoint URL of each relationship.
+	BundleEndpointURL *url.URL
+	// BundleEndpointProfile is the profile used by the SPIRE Server to authenticate the bundle endpoint.
+	BundleEndpointProfile spireclient.BundleEndpointProfile
+	// EndpointSPIFFEID is the SPIFFE ID of the bundle endpoint, required by the https_spiffe profile.
+	EndpointSPIFFEID spiffeid.ID
+}
+
+func (c *FederationRelationshipsConfig) validate() error {
+	if c.BundleEndpointURL == nil {
+		return errors.New("bundle endpoint URL is required")
+	}
+
+	switch c.BundleEndpointProfile {
+	case spireclient.HTTPSWebProfile:
+	case spireclient.HTTPSSPIFFEProfile:
+		if c.EndpointSPIFFEID.IsZero() {
+			return errors.New("endpoint SPIFFE ID is required by the https_spiffe profile")
+		}
+	default:
+		return fmt.Errorf("unknown bundle endpoint profile: %q", c.BundleEndpointProfile)
+	}
+
+	return nil
+}
+
+// reconcileFederationRelationships makes the federation relationships in the SPIRE Server match the given trust domains.
+// Missing relationships are created, and relationships managed by the Harvester with an outdated bundle endpoint
+// are updated. Relationships managed by the Harvester whose trust domain is no longer federated are deleted,
+// relationships configured by other means are left untouched.
+func (s *FederatedBundlesSynchronizer) reconcileFederationRelationships(ctx context.Context, trustDomains map[spiffeid.TrustDomain][]byte) {
+	if s.federationRelationships == nil {
+		return
+	}
+
+	relationships, err := s.spireClient.ListFederationRelationships(ctx)
+	if err != nil {
+		s.logger.Errorf("Failed to list federation relationships in SPIRE Server: %v", err)
+		return
+	}
+
+	existing := make(map[spiffeid.TrustDomain]*spireclient.FederationRelationship, len(relationships))
+	for _, r := range relationships {
+		existing[r.TrustDomain] = r
+	}
+
+	relationshipsToCreate := make([]*spireclient.FederationRelationship, 0)
+	relationshipsToUpdate := make([]*spireclient.FederationRelationship, 0)
+	for td := range trustDomains {
+		desired := s.federationRelationshipFor(td)
+
+		current, ok := existing[td]
+		switch {
+		case !ok:
+			relationshipsToCreate = append(relationshipsToCreate, desired)
+		case !s.isManagedFederationRelationship(current):
+			s.logger.WithField(telemetry.TrustDomain, td.String()).Debug("Skipping federation relationship not managed by the Harvester")
+		case !sameBundleEndpoint(current, desired):
+			relationshipsToUpdate = append(relationshipsToUpdate, desired)
+		}
+	}
+
+	relationshipsToDelete := make([]spiffeid.TrustDomain, 0)
+	for _, r := range relationships {
+		if _, ok := trustDomains[r.TrustDomain]; !ok && s.isManagedFederationRelationship(r) {
+			relationshipsToDelete = append(relationshipsToDelete, r.TrustDomain)
+		}
+	}
+
+	if len(relationshipsToCreate) > 0 {
+		statuses, err := s.spireClient.CreateFederationRelationships(ctx, relationshipsToCreate)
+		if err != nil {
+			s.logger.Errorf("Failed to create federation relationships in SPIRE Server: %v", err)
+		}
+		for i, st := range statuses {
+			// statuses are returned in the same order as the relationships in the request
+			s.logFederationRelationshipStatus(relationshipsToCreate[i].TrustDomain.String(), st.Status, "creating", "created")
+		}
+	}
+
+	if len(relationshipsToUpdate) > 0 {
+		statuses, err := s.spireClient.UpdateFederationRelationships(ctx, relationshipsToUpdate)
+		if err != nil {
+			s.logger.Errorf("Failed to update federation relationships in SPIRE Server: %v", err)
+		}
+		for i, st := range statuses {
+			// statuses are returned in the same order as the relationships in the request
+			s.logFederationRelationshipStatus(relationshipsToUpdate[i].TrustDomain.String(), st.Status, "updating", "updated")
+		}
+	}
+
+	if len(relationshipsToDelete) > 0 {
+		statuses, err := s.spireClient.DeleteFederationRelationships(ctx, relationshipsToDelete)
+		if err != nil {
+			s.logger.Errorf("Failed to delete federation relationships in SPIRE Server: %v", err)
+		}
+		for _, st := range statuses {
+			s.logFederationRelationshipStatus(st.TrustDomain, st.Status, "deleting", "deleted")
+		}
+	}
+}
+
+// federationRelationshipFor returns the federation relationship the Harvester manages for the given trust domain.
+func (s *FederatedBundlesSynchronizer) federationRelationshipFor(td spiffeid.TrustDomain) *spireclient.FederationRelationship {
+	return &spireclient.FederationRelationship{
+		TrustDomain:           td,
+		BundleEndpointURL:     s.federationRelationships.BundleEndpointURL.JoinPath(td.String()),
+		BundleEndpointProfile: s.federationRelationships.BundleEndpointProfile,
+		EndpointSPIFFEID:      s.federationRelationships.EndpointSPIFFEID,
+	}
+}
+
+// isManagedFederationRelationship returns true if the relationship points to the Galadriel Server bundle endpoint.
+func (s *FederatedBundlesSynchronizer) isManagedFederationRelationship(r *spireclient.FederationRelationship) bool {
+	if r.BundleEndpointURL == nil {
+		return false
+	}
+
+	base := strings.TrimSuffix(s.federationRelationships.BundleEndpointURL.String(), "/") + "/"
+	return strings.HasPrefix(r.BundleEndpointURL.String(), base)
+}
+
+func (s *FederatedBundlesSynchronizer) logFederationRelationshipStatus(trustDomain string, status *spireclient.Status, failedOp, succeededOp string) {
+	if status.Code != codes.OK {
+		s.logger.WithFields(logrus.Fields{
+			telemetry.TrustDomain:    trustDomain,
+			telemetry.BundleOpStatus: status.Message,
+		}).Errorf("Failed %s federation relationship", failedOp)
+	} else {
+		s.logger.WithField(telemetry.TrustDomain, trustDomain).Infof("Federation relationship %s", succeededOp)
+	}
+}
+
+func sameBundleEndpoint(a, b *spireclient.FederationRelationship) bool {
+	if a.BundleEndpointURL == nil || b.BundleEndpointURL == nil {
+		return a.BundleEndpointURL == b.BundleEndpointURL
+	}
+
+	return a.BundleEndpointURL.String() == b.BundleEndpointURL.String() &&
+		a.BundleEndpointProfile == b.BundleEndpointProfile &&
+		a.EndpointSPIFFEID == b.EndpointSPIFFEID
+}
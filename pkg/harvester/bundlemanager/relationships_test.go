@@ -0,0 +1,188 @@
+package bundlemanager
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/HewlettPackard/galadriel/pkg/harvester/spireclient"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakespireserver"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileFederationRelationships(t *testing.T) {
+	server := fakespireserver.New(t)
+	server.WithFederationRelationships(
+		// managed relationship whose trust domain is still federated, with an outdated profile
+		&types.FederationRelationship{
+			TrustDomain:           "outdated.org",
+			BundleEndpointUrl:     "https://galadriel.example.org/bundle/outdated.org",
+			BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{HttpsWeb: &types.HTTPSWebProfile{}},
+		},
+		// managed relationship that is up-to-date
+		&types.FederationRelationship{
+			TrustDomain:       "current.org",
+			BundleEndpointUrl: "https://galadriel.example.org/bundle/current.org",
+			BundleEndpointProfile: &types.FederationRelationship_HttpsSpiffe{HttpsSpiffe: &types.HTTPSSPIFFEProfile{
+				EndpointSpiffeId: "spiffe://galadriel.org/bundle-endpoint",
+			}},
+		},
+		// managed relationship whose trust domain is no longer federated
+		&types.FederationRelationship{
+			TrustDomain:           "removed.org",
+			BundleEndpointUrl:     "https://galadriel.example.org/bundle/removed.org",
+			BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{HttpsWeb: &types.HTTPSWebProfile{}},
+		},
+		// relationship not managed by the Harvester
+		&types.FederationRelationship{
+			TrustDomain:           "manual.org",
+			BundleEndpointUrl:     "https://manual.org/bundle",
+			BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{HttpsWeb: &types.HTTPSWebProfile{}},
+		},
+	)
+
+	spireClient, err := spireclient.NewSpireClient(context.Background(), server.Addr)
+	require.NoError(t, err)
+
+	baseURL, err := url.Parse("https://galadriel.example.org/bundle")
+	require.NoError(t, err)
+
+	logger, _ := test.NewNullLogger()
+	synchronizer := NewFederatedBundlesSynchronizer(&FederatedBundlesSynchronizerConfig{
+		SpireClient: spireClient,
+		Logger:      logger,
+		FederationRelationships: &FederationRelationshipsConfig{
+			BundleEndpointURL:     baseURL,
+			BundleEndpointProfile: spireclient.HTTPSSPIFFEProfile,
+			EndpointSPIFFEID:      spiffeid.RequireFromString("spiffe://galadriel.org/bundle-endpoint"),
+		},
+	})
+
+	digests := map[spiffeid.TrustDomain][]byte{
+		spiffeid.RequireTrustDomainFromString("outdated.org"): []byte("digest"),
+		spiffeid.RequireTrustDomainFromString("current.org"):  []byte("digest"),
+		spiffeid.RequireTrustDomainFromString("new.org"):      []byte("digest"),
+	}
+
+	synchronizer.reconcileFederationRelationships(context.Background(), digests)
+
+	relationships := server.FederationRelationships()
+	require.Len(t, relationships, 4)
+
+	assert.Equal(t, "current.org", relationships[0].TrustDomain)
+	assert.Equal(t, "spiffe://galadriel.org/bundle-endpoint", relationships[0].GetHttpsSpiffe().EndpointSpiffeId)
+
+	assert.Equal(t, "manual.org", relationships[1].TrustDomain)
+	assert.Equal(t, "https://manual.org/bundle", relationships[1].BundleEndpointUrl)
+
+	assert.Equal(t, "new.org", relationships[2].TrustDomain)
+	assert.Equal(t, "https://galadriel.example.org/bundle/new.org", relationships[2].BundleEndpointUrl)
+	assert.Equal(t, "spiffe://galadriel.org/bundle-endpoint", relationships[2].GetHttpsSpiffe().EndpointSpiffeId)
+
+	assert.Equal(t, "outdated.org", relationships[3].TrustDomain)
+	assert.Equal(t, "spiffe://galadriel.org/bundle-endpoint", relationships[3].GetHttpsSpiffe().EndpointSpiffeId)
+}
+
+func TestReconcileFederationRelationshipsKeepsOperatorRelationships(t *testing.T) {
+	server := fakespireserver.New(t)
+	server.WithFederationRelationships(
+		// relationship configured by the operator for a trust domain the Harvester federates with
+		&types.FederationRelationship{
+			TrustDomain:           "operator.org",
+			BundleEndpointUrl:     "https://operator.org/bundle",
+			BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{HttpsWeb: &types.HTTPSWebProfile{}},
+		},
+	)
+
+	spireClient, err := spireclient.NewSpireClient(context.Background(), server.Addr)
+	require.NoError(t, err)
+
+	baseURL, err := url.Parse("https://galadriel.example.org/bundle")
+	require.NoError(t, err)
+
+	logger, _ := test.NewNullLogger()
+	synchronizer := NewFederatedBundlesSynchronizer(&FederatedBundlesSynchronizerConfig{
+		SpireClient: spireClient,
+		Logger:      logger,
+		FederationRelationships: &FederationRelationshipsConfig{
+			BundleEndpointURL:     baseURL,
+			BundleEndpointProfile: spireclient.HTTPSSPIFFEProfile,
+			EndpointSPIFFEID:      spiffeid.RequireFromString("spiffe://galadriel.org/bundle-endpoint"),
+		},
+	})
+
+	synchronizer.reconcileFederationRelationships(context.Background(), map[spiffeid.TrustDomain][]byte{
+		spiffeid.RequireTrustDomainFromString("operator.org"): []byte("digest"),
+	})
+
+	relationships := server.FederationRelationships()
+	require.Len(t, relationships, 1)
+	assert.Equal(t, "operator.org", relationships[0].TrustDomain)
+	assert.Equal(t, "https://operator.org/bundle", relationships[0].BundleEndpointUrl)
+	assert.NotNil(t, relationships[0].GetHttpsWeb())
+}
+
+func TestReconcileFederationRelationshipsNotConfigured(t *testing.T) {
+	server := fakespireserver.New(t)
+
+	spireClient, err := spireclient.NewSpireClient(context.Background(), server.Addr)
+	require.NoError(t, err)
+
+	logger, _ := test.NewNullLogger()
+	synchronizer := NewFederatedBundlesSynchronizer(&FederatedBundlesSynchronizerConfig{
+		SpireClient: spireClient,
+		Logger:      logger,
+	})
+
+	synchronizer.reconcileFederationRelationships(context.Background(), map[spiffeid.TrustDomain][]byte{
+		spiffeid.RequireTrustDomainFromString("new.org"): []byte("digest"),
+	})
+
+	assert.Empty(t, server.FederationRelationships())
+}
+
+func TestFederationRelationshipsConfigValidate(t *testing.T) {
+	baseURL, err := url.Parse("https://galadriel.example.org/bundle")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		config *FederationRelationshipsConfig
+		err    string
+	}{
+		{
+			name:   "https_web",
+			config: &FederationRelationshipsConfig{BundleEndpointURL: baseURL, BundleEndpointProfile: spireclient.HTTPSWebProfile},
+		},
+		{
+			name:   "missing bundle endpoint URL",
+			config: &FederationRelationshipsConfig{BundleEndpointProfile: spireclient.HTTPSWebProfile},
+			err:    "bundle endpoint URL is required",
+		},
+		{
+			name:   "missing endpoint SPIFFE ID",
+			config: &FederationRelationshipsConfig{BundleEndpointURL: baseURL, BundleEndpointProfile: spireclient.HTTPSSPIFFEProfile},
+			err:    "endpoint SPIFFE ID is required by the https_spiffe profile",
+		},
+		{
+			name:   "unknown profile",
+			config: &FederationRelationshipsConfig{BundleEndpointURL: baseURL, BundleEndpointProfile: "other"},
+			err:    `unknown bundle endpoint profile: "other"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.validate()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -23,6 +23,9 @@ type SpireBundleSynchronizer struct {
 	syncInterval    time.Duration
 	logger          logrus.FieldLogger
 
+	// leaseKeeper is nil unless several Harvester instances serve the trust domain
+	leaseKeeper *LeaseKeeper
+
 	lastSpireBundle *spiffebundle.Bundle // last bundle fetched from the SPIRE Server and uploaded to the Galadriel Server
 }
 
@@ -33,6 +36,9 @@ type SpireSynchronizerConfig struct {
 	BundleSigner    integrity.Signer
 	SyncInterval    time.Duration
 	Logger          logrus.FieldLogger
+
+	// LeaseKeeper is optional, when set, the bundle is only uploaded while the Harvester instance holds the lease.
+	LeaseKeeper *LeaseKeeper
 }
 
 // NewSpireSynchronizer creates a new SpireBundleSynchronizer instance.
@@ -43,6 +49,7 @@ func NewSpireSynchronizer(config *SpireSynchronizerConfig) *SpireBundleSynchroni
 		bundleSigner:    config.BundleSigner,
 		syncInterval:    config.SyncInterval,
 		logger:          config.Logger,
+		leaseKeeper:     config.LeaseKeeper,
 	}
 }
 
@@ -71,6 +78,13 @@ func (s *SpireBundleSynchronizer) StartSyncing(ctx context.Context) error {
 // syncSPIREBundle fetches a new bundle from the SPIRE Server,
 // signs it if it's new, and then uploads it to the Galadriel Server.
 func (s *SpireBundleSynchronizer) syncSpireBundleToGaladriel(ctx context.Context) error {
+	if s.leaseKeeper != nil && !s.leaseKeeper.IsHeld() {
+		s.logger.Debug("Lease is held by another instance, skipping bundle upload")
+		// the bundle is uploaded again once this instance acquires the lease
+		s.lastSpireBundle = nil
+		return nil
+	}
+
 	s.logger.Debug("Checking SPIRE Server for a new bundle")
 
 	spireCallCtx, spireCallCancel := context.WithTimeout(ctx, spireCallTimeout)
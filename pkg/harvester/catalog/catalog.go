@@ -105,6 +105,30 @@ func loadBundleSigner(config *providerConfig, clk clock.Clock) (integrity.Signer
 		}
 
 		return verifier, nil
+	case "sigstore":
+		c, err := decodeSigstoreBundleSignerConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding sigstore bundle signer config: %w", err)
+		}
+
+		signer := integrity.NewSigstoreSigner()
+		if err := signer.Configure(c); err != nil {
+			return nil, fmt.Errorf("error configuring sigstore bundle signer: %w", err)
+		}
+
+		return signer, nil
+	case "x509svid":
+		c, err := decodeX509SVIDBundleSignerConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding x509svid bundle signer config: %w", err)
+		}
+
+		signer := integrity.NewX509SVIDSigner()
+		if err := signer.Configure(c); err != nil {
+			return nil, fmt.Errorf("error configuring x509svid bundle signer: %w", err)
+		}
+
+		return signer, nil
 	case "noop":
 		return integrity.NewNoOpSigner(), nil
 	}
@@ -121,6 +145,24 @@ func decodeDiskBundleSignerConfig(config *providerConfig) (*integrity.DiskSigner
 	return &dsConfig, nil
 }
 
+func decodeSigstoreBundleSignerConfig(config *providerConfig) (*integrity.SigstoreSignerConfig, error) {
+	var ssConfig integrity.SigstoreSignerConfig
+	if err := gohcl.DecodeBody(config.Options, nil, &ssConfig); err != nil {
+		return nil, err
+	}
+
+	return &ssConfig, nil
+}
+
+func decodeX509SVIDBundleSignerConfig(config *providerConfig) (*integrity.X509SVIDSignerConfig, error) {
+	var xsConfig integrity.X509SVIDSignerConfig
+	if err := gohcl.DecodeBody(config.Options, nil, &xsConfig); err != nil {
+		return nil, err
+	}
+
+	return &xsConfig, nil
+}
+
 func loadBundleVerifier(config *providerConfig, clk clock.Clock) (integrity.Verifier, error) {
 	switch config.Name {
 	case "disk":
@@ -135,6 +177,31 @@ func loadBundleVerifier(config *providerConfig, clk clock.Clock) (integrity.Veri
 			return nil, fmt.Errorf("error configuring disk bundle verifier: %w", err)
 		}
 
+		return verifier, nil
+	case "sigstore":
+		c, err := decodeSigstoreBundleVerifierConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding sigstore bundle verifier config: %w", err)
+		}
+
+		verifier := integrity.NewSigstoreVerifier()
+		if err := verifier.Configure(c); err != nil {
+			return nil, fmt.Errorf("error configuring sigstore bundle verifier: %w", err)
+		}
+
+		return verifier, nil
+	case "x509svid":
+		c, err := decodeX509SVIDBundleVerifierConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding x509svid bundle verifier config: %w", err)
+		}
+		c.Clock = clk
+
+		verifier := integrity.NewX509SVIDVerifier()
+		if err := verifier.Configure(c); err != nil {
+			return nil, fmt.Errorf("error configuring x509svid bundle verifier: %w", err)
+		}
+
 		return verifier, nil
 	case "noop":
 		return integrity.NewNoOpVerifier(), nil
@@ -151,3 +218,21 @@ func decodeDiskBundleVerifierConfig(config *providerConfig) (*integrity.DiskVeri
 
 	return &dsConfig, nil
 }
+
+func decodeSigstoreBundleVerifierConfig(config *providerConfig) (*integrity.SigstoreVerifierConfig, error) {
+	var svConfig integrity.SigstoreVerifierConfig
+	if err := gohcl.DecodeBody(config.Options, nil, &svConfig); err != nil {
+		return nil, err
+	}
+
+	return &svConfig, nil
+}
+
+func decodeX509SVIDBundleVerifierConfig(config *providerConfig) (*integrity.X509SVIDVerifierConfig, error) {
+	var xvConfig integrity.X509SVIDVerifierConfig
+	if err := gohcl.DecodeBody(config.Options, nil, &xvConfig); err != nil {
+		return nil, err
+	}
+
+	return &xvConfig, nil
+}
@@ -1,6 +1,11 @@
 package catalog
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"os"
 	"testing"
@@ -86,6 +91,56 @@ func TestLoadFromProvidersConfig(t *testing.T) {
 	require.True(t, ok)
 }
 
+func TestLoadSigstoreBundleSignerAndVerifier(t *testing.T) {
+	tempDir := t.TempDir()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	privateKeyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(tempDir+"/cosign.key", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyDER}), 0600))
+
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(tempDir+"/cosign.pub", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDER}), 0600))
+
+	signerBody, diagErr := hclsyntax.ParseConfig([]byte(fmt.Sprintf(`private_key_path = "%s"`, tempDir+"/cosign.key")), "", hcl.Pos{Line: 1, Column: 1})
+	require.False(t, diagErr.HasErrors())
+	signer, err := loadBundleSigner(&providerConfig{Name: "sigstore", Options: signerBody.Body}, clk)
+	require.NoError(t, err)
+	require.IsType(t, &integrity.SigstoreSigner{}, signer)
+
+	verifierBody, diagErr := hclsyntax.ParseConfig([]byte(fmt.Sprintf(`public_key_path = "%s"`, tempDir+"/cosign.pub")), "", hcl.Pos{Line: 1, Column: 1})
+	require.False(t, diagErr.HasErrors())
+	verifier, err := loadBundleVerifier(&providerConfig{Name: "sigstore", Options: verifierBody.Body}, clk)
+	require.NoError(t, err)
+	require.IsType(t, &integrity.SigstoreVerifier{}, verifier)
+
+	payload := []byte("bundle")
+	signature, _, err := signer.Sign(payload)
+	require.NoError(t, err)
+	require.NoError(t, verifier.Verify(payload, signature, nil))
+}
+
+func TestLoadX509SVIDBundleSignerAndVerifier(t *testing.T) {
+	signerBody, diagErr := hclsyntax.ParseConfig([]byte(`workload_api_socket_path = "/tmp/spire-agent/public/api.sock"`), "", hcl.Pos{Line: 1, Column: 1})
+	require.False(t, diagErr.HasErrors())
+	signer, err := loadBundleSigner(&providerConfig{Name: "x509svid", Options: signerBody.Body}, clk)
+	require.NoError(t, err)
+	require.IsType(t, &integrity.X509SVIDSigner{}, signer)
+
+	invalidBody, diagErr := hclsyntax.ParseConfig([]byte(`spiffe_id = "not-a-spiffe-id"`), "", hcl.Pos{Line: 1, Column: 1})
+	require.False(t, diagErr.HasErrors())
+	_, err = loadBundleSigner(&providerConfig{Name: "x509svid", Options: invalidBody.Body}, clk)
+	require.ErrorContains(t, err, "error configuring x509svid bundle signer: invalid SPIFFE ID")
+
+	verifierBody, diagErr := hclsyntax.ParseConfig([]byte(``), "", hcl.Pos{Line: 1, Column: 1})
+	require.False(t, diagErr.HasErrors())
+	verifier, err := loadBundleVerifier(&providerConfig{Name: "x509svid", Options: verifierBody.Body}, clk)
+	require.NoError(t, err)
+	require.IsType(t, &integrity.X509SVIDVerifier{}, verifier)
+}
+
 func setupTest(t *testing.T) (string, func()) {
 	tempDir := certtest.CreateTestCACertificates(t, clk)
 	cleanup := func() {
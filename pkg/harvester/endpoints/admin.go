@@ -1,27 +1,64 @@
 package endpoints
 
 import (
+	"context"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/api"
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	chttp "github.com/HewlettPackard/galadriel/pkg/common/http"
 	"github.com/HewlettPackard/galadriel/pkg/harvester/api/admin"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/bundlemanager"
 	"github.com/HewlettPackard/galadriel/pkg/harvester/galadrielclient"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
 
+// BundleResetApprover approves resets of the bundles of the federated trust domains.
+type BundleResetApprover interface {
+	// ApproveBundleReset approves the next bundle of the trust domain even if it does not continue the bundle
+	// trusted by the SPIRE Server, and returns the time of the approval.
+	ApproveBundleReset(td spiffeid.TrustDomain) time.Time
+}
+
+// BundleQuarantine manages the federated bundles rejected by the Harvester.
+type BundleQuarantine interface {
+	// ListQuarantinedBundles returns the quarantined bundles, of all trust domains if the given one is zero.
+	ListQuarantinedBundles(td spiffeid.TrustDomain) ([]*entity.QuarantinedBundle, error)
+	// GetQuarantinedBundle returns the quarantined bundle with the given ID, or nil if there is none.
+	GetQuarantinedBundle(id string) (*entity.QuarantinedBundle, error)
+	// AcceptQuarantinedBundle sets the quarantined bundle in the SPIRE Server without verifying it, and returns it,
+	// or nil if there is none.
+	AcceptQuarantinedBundle(ctx context.Context, id string) (*entity.QuarantinedBundle, error)
+	// DiscardQuarantinedBundle removes the quarantined bundle, and reports whether it was quarantined.
+	DiscardQuarantinedBundle(id string) (bool, error)
+}
+
 type AdminAPIHandlers struct {
-	client galadrielclient.Client
-	logger logrus.FieldLogger
+	client          galadrielclient.Client
+	logger          logrus.FieldLogger
+	bundleVerifiers []integrity.Verifier
+	resetApprover   BundleResetApprover
+	quarantine      BundleQuarantine
 }
 
-func NewAdminAPIHandlers(logger logrus.FieldLogger, client galadrielclient.Client) *AdminAPIHandlers {
+// NewAdminAPIHandlers creates a new AdminAPIHandlers.
+// The bundleVerifiers are used to verify the signatures of the consents to the relationships.
+// The resetApprover is optional, when not set, the resets of the federated bundles cannot be approved.
+// The quarantine is optional, when not set, the quarantined bundles cannot be managed.
+func NewAdminAPIHandlers(logger logrus.FieldLogger, client galadrielclient.Client, bundleVerifiers []integrity.Verifier, resetApprover BundleResetApprover, quarantine BundleQuarantine) *AdminAPIHandlers {
 	return &AdminAPIHandlers{
-		client: client,
-		logger: logger,
+		client:          client,
+		logger:          logger,
+		bundleVerifiers: bundleVerifiers,
+		resetApprover:   resetApprover,
+		quarantine:      quarantine,
 	}
 }
 
@@ -68,3 +105,210 @@ func (h AdminAPIHandlers) PatchRelationship(echoCtx echo.Context, relationshipID
 
 	return nil
 }
+
+// GetRelationshipConsents gets the signed consents of the trust domains of a relationship from the Galadriel Server,
+// and verifies their signatures with the bundle verifiers of the Harvester.
+func (h AdminAPIHandlers) GetRelationshipConsents(echoCtx echo.Context, relationshipID api.UUID) error {
+	ctx := echoCtx.Request().Context()
+
+	consents, err := h.client.GetRelationshipConsents(ctx, relationshipID)
+	if err != nil {
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	resp := make([]admin.VerifiedRelationshipConsent, 0, len(consents))
+	for _, consent := range consents {
+		verified := admin.VerifiedRelationshipConsent{
+			Consent:  *api.RelationshipConsentFromEntity(consent),
+			Verified: true,
+		}
+		if err := h.verifyConsent(consent); err != nil {
+			msg := err.Error()
+			verified.Verified = false
+			verified.VerificationError = &msg
+		}
+		resp = append(resp, verified)
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, resp)
+	if err != nil {
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// ApproveBundleReset approves the next bundle of a federated trust domain even if it does not continue the bundle
+// trusted by the SPIRE Server.
+func (h AdminAPIHandlers) ApproveBundleReset(echoCtx echo.Context, trustDomainName api.TrustDomainName) error {
+	td, err := spiffeid.TrustDomainFromString(trustDomainName)
+	if err != nil {
+		err = fmt.Errorf("malformed trust domain[%q]: %v", trustDomainName, err)
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	if h.resetApprover == nil {
+		err := errors.New("bundle resets cannot be approved")
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	resp := admin.BundleResetApproval{
+		TrustDomainName: td.String(),
+		CreatedAt:       h.resetApprover.ApproveBundleReset(td),
+	}
+	h.logger.Infof("Approved reset of the bundle of trust domain %q", td)
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, resp)
+	if err != nil {
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// ListQuarantinedBundles lists the federated bundles rejected by the Harvester, optionally of a single trust domain.
+func (h AdminAPIHandlers) ListQuarantinedBundles(echoCtx echo.Context, params admin.ListQuarantinedBundlesParams) error {
+	if err := h.checkQuarantine(); err != nil {
+		return err
+	}
+
+	var td spiffeid.TrustDomain
+	if params.TrustDomainName != nil {
+		var err error
+		td, err = spiffeid.TrustDomainFromString(*params.TrustDomainName)
+		if err != nil {
+			err = fmt.Errorf("malformed trust domain[%q]: %v", *params.TrustDomainName, err)
+			return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	bundles, err := h.quarantine.ListQuarantinedBundles(td)
+	if err != nil {
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, admin.MapQuarantinedBundles(bundles...))
+	if err != nil {
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetQuarantinedBundle gets a federated bundle rejected by the Harvester, with the reason of its rejection.
+func (h AdminAPIHandlers) GetQuarantinedBundle(echoCtx echo.Context, bundleID admin.QuarantinedBundleID) error {
+	if err := h.checkQuarantine(); err != nil {
+		return err
+	}
+
+	qb, err := h.quarantine.GetQuarantinedBundle(bundleID)
+	if err != nil {
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	if qb == nil {
+		err = fmt.Errorf("quarantined bundle %q does not exist", bundleID)
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusNotFound)
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, admin.QuarantinedBundleFromEntity(qb))
+	if err != nil {
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// AcceptQuarantinedBundle sets a quarantined bundle in the SPIRE Server without verifying it.
+func (h AdminAPIHandlers) AcceptQuarantinedBundle(echoCtx echo.Context, bundleID admin.QuarantinedBundleID) error {
+	ctx := echoCtx.Request().Context()
+
+	if err := h.checkQuarantine(); err != nil {
+		return err
+	}
+
+	qb, err := h.quarantine.AcceptQuarantinedBundle(ctx, bundleID)
+	if errors.Is(err, bundlemanager.ErrStaleQuarantinedBundle) {
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusConflict)
+	}
+	if err != nil {
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	if qb == nil {
+		err = fmt.Errorf("quarantined bundle %q does not exist", bundleID)
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusNotFound)
+	}
+	h.logger.Infof("Accepted quarantined bundle %q of trust domain %q", bundleID, qb.Bundle.TrustDomainName)
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, admin.QuarantinedBundleFromEntity(qb))
+	if err != nil {
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// DiscardQuarantinedBundle removes a quarantined bundle without setting it in the SPIRE Server.
+func (h AdminAPIHandlers) DiscardQuarantinedBundle(echoCtx echo.Context, bundleID admin.QuarantinedBundleID) error {
+	if err := h.checkQuarantine(); err != nil {
+		return err
+	}
+
+	removed, err := h.quarantine.DiscardQuarantinedBundle(bundleID)
+	if err != nil {
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	if !removed {
+		err = fmt.Errorf("quarantined bundle %q does not exist", bundleID)
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusNotFound)
+	}
+	h.logger.Infof("Discarded quarantined bundle %q", bundleID)
+
+	message := fmt.Sprintf("Quarantined bundle %q discarded", bundleID)
+	response := api.DeleteResponse{Code: http.StatusOK, Message: message}
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// checkQuarantine responds with an error if the quarantined bundles cannot be managed.
+func (h AdminAPIHandlers) checkQuarantine() error {
+	if h.quarantine == nil {
+		err := errors.New("bundle quarantine is not enabled")
+		return chttp.LogAndRespondWithError(h.logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// verifyConsent verifies the signature of the consent statement. The consent is verified if any of
+// the bundle verifiers can verify it.
+func (h AdminAPIHandlers) verifyConsent(consent *entity.RelationshipConsent) error {
+	if len(consent.Signature) == 0 {
+		return errors.New("consent is not signed")
+	}
+
+	var certChain []*x509.Certificate
+	if len(consent.SigningCertificateChain) > 0 {
+		var err error
+		certChain, err = x509.ParseCertificates(consent.SigningCertificateChain)
+		if err != nil {
+			return fmt.Errorf("failed to parse signing certificate chain: %w", err)
+		}
+	}
+
+	for _, verifier := range h.bundleVerifiers {
+		err := verifier.Verify(consent.ConsentStatement, consent.Signature, certChain)
+		if err == nil {
+			return nil
+		}
+		h.logger.Warnf("Consent to relationship %q failed verification using %T verifier: %v", consent.RelationshipID, verifier, err)
+	}
+
+	return errors.New("no verifier could verify the consent")
+}
@@ -1,2 +1,239 @@
-// TODO: add tests
 package endpoints
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/api/admin"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/bundlemanager"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/galadrielclient"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	galadrielclient.Client
+
+	consents []*entity.RelationshipConsent
+}
+
+func (c *fakeClient) GetRelationshipConsents(ctx context.Context, relationshipID uuid.UUID) ([]*entity.RelationshipConsent, error) {
+	return c.consents, nil
+}
+
+type fakeVerifier struct {
+	err error
+}
+
+func (v fakeVerifier) Verify(payload, signature []byte, certChain []*x509.Certificate) error {
+	return v.err
+}
+
+type fakeResetApprover struct {
+	approved []spiffeid.TrustDomain
+}
+
+func (a *fakeResetApprover) ApproveBundleReset(td spiffeid.TrustDomain) time.Time {
+	a.approved = append(a.approved, td)
+	return time.Unix(1700000000, 0)
+}
+
+type fakeQuarantine struct {
+	bundles   map[string]*entity.QuarantinedBundle
+	acceptErr error
+}
+
+func (q *fakeQuarantine) ListQuarantinedBundles(td spiffeid.TrustDomain) ([]*entity.QuarantinedBundle, error) {
+	var bundles []*entity.QuarantinedBundle
+	for _, qb := range q.bundles {
+		if td.IsZero() || qb.Bundle.TrustDomainName == td {
+			bundles = append(bundles, qb)
+		}
+	}
+	return bundles, nil
+}
+
+func (q *fakeQuarantine) GetQuarantinedBundle(id string) (*entity.QuarantinedBundle, error) {
+	return q.bundles[id], nil
+}
+
+func (q *fakeQuarantine) AcceptQuarantinedBundle(ctx context.Context, id string) (*entity.QuarantinedBundle, error) {
+	if q.acceptErr != nil {
+		return nil, q.acceptErr
+	}
+	qb := q.bundles[id]
+	delete(q.bundles, id)
+	return qb, nil
+}
+
+func (q *fakeQuarantine) DiscardQuarantinedBundle(id string) (bool, error) {
+	_, ok := q.bundles[id]
+	delete(q.bundles, id)
+	return ok, nil
+}
+
+func TestGetRelationshipConsents(t *testing.T) {
+	relationshipID := uuid.New()
+	signed := &entity.RelationshipConsent{RelationshipID: relationshipID, TrustDomainID: uuid.New(), ConsentStatement: []byte("statement"), Signature: []byte("signature")}
+	unsigned := &entity.RelationshipConsent{RelationshipID: relationshipID, TrustDomainID: uuid.New(), ConsentStatement: []byte("statement")}
+	client := &fakeClient{consents: []*entity.RelationshipConsent{signed, unsigned}}
+
+	getConsents := func(verifiers []integrity.Verifier) []admin.VerifiedRelationshipConsent {
+		logger, _ := test.NewNullLogger()
+		rec := httptest.NewRecorder()
+		echoCtx := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/relationships/"+relationshipID.String()+"/consents", nil), rec)
+
+		err := NewAdminAPIHandlers(logger, client, verifiers, nil, nil).GetRelationshipConsents(echoCtx, relationshipID)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var consents []admin.VerifiedRelationshipConsent
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &consents))
+		require.Len(t, consents, 2)
+		return consents
+	}
+
+	consents := getConsents([]integrity.Verifier{fakeVerifier{err: errors.New("invalid signature")}, fakeVerifier{}})
+	assert.Equal(t, signed.TrustDomainID, consents[0].Consent.TrustDomainId)
+	assert.True(t, consents[0].Verified)
+	assert.Nil(t, consents[0].VerificationError)
+	assert.False(t, consents[1].Verified)
+	require.NotNil(t, consents[1].VerificationError)
+	assert.Equal(t, "consent is not signed", *consents[1].VerificationError)
+
+	consents = getConsents([]integrity.Verifier{fakeVerifier{err: errors.New("invalid signature")}})
+	assert.False(t, consents[0].Verified)
+	require.NotNil(t, consents[0].VerificationError)
+	assert.Equal(t, "no verifier could verify the consent", *consents[0].VerificationError)
+}
+
+func TestApproveBundleReset(t *testing.T) {
+	approveReset := func(approver BundleResetApprover, trustDomainName string) (*httptest.ResponseRecorder, error) {
+		logger, _ := test.NewNullLogger()
+		rec := httptest.NewRecorder()
+		echoCtx := echo.New().NewContext(httptest.NewRequest(http.MethodPost, "/bundles/"+trustDomainName+"/reset", nil), rec)
+
+		err := NewAdminAPIHandlers(logger, &fakeClient{}, nil, approver, nil).ApproveBundleReset(echoCtx, trustDomainName)
+		return rec, err
+	}
+
+	approver := &fakeResetApprover{}
+	rec, err := approveReset(approver, "example.org")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []spiffeid.TrustDomain{spiffeid.RequireTrustDomainFromString("example.org")}, approver.approved)
+
+	var approval admin.BundleResetApproval
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &approval))
+	assert.Equal(t, "example.org", approval.TrustDomainName)
+	assert.True(t, approval.CreatedAt.Equal(time.Unix(1700000000, 0)))
+
+	_, err = approveReset(approver, "Invalid_Trust_Domain")
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+	assert.Len(t, approver.approved, 1)
+
+	_, err = approveReset(nil, "example.org")
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusInternalServerError, httpErr.Code)
+	assert.Equal(t, "bundle resets cannot be approved", httpErr.Message)
+}
+
+func TestQuarantinedBundles(t *testing.T) {
+	const bundleID = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	qb := &entity.QuarantinedBundle{
+		ID:            bundleID,
+		Bundle:        &entity.Bundle{TrustDomainName: spiffeid.RequireTrustDomainFromString("example.org"), Data: []byte("bundle")},
+		Reason:        "verification failed",
+		QuarantinedAt: time.Unix(1700000000, 0),
+	}
+	quarantine := &fakeQuarantine{bundles: map[string]*entity.QuarantinedBundle{bundleID: qb}}
+
+	newHandlers := func(quarantine BundleQuarantine) (*AdminAPIHandlers, *httptest.ResponseRecorder, echo.Context) {
+		logger, _ := test.NewNullLogger()
+		rec := httptest.NewRecorder()
+		echoCtx := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/quarantined-bundles", nil), rec)
+		return NewAdminAPIHandlers(logger, &fakeClient{}, nil, nil, quarantine), rec, echoCtx
+	}
+	requireHTTPError := func(t *testing.T, err error, code int) {
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, code, httpErr.Code)
+	}
+
+	t.Run("list", func(t *testing.T) {
+		h, rec, echoCtx := newHandlers(quarantine)
+		otherTD := "other.org"
+		require.NoError(t, h.ListQuarantinedBundles(echoCtx, admin.ListQuarantinedBundlesParams{}))
+
+		var bundles []admin.QuarantinedBundle
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &bundles))
+		require.Len(t, bundles, 1)
+		assert.Equal(t, bundleID, bundles[0].Id)
+		assert.Equal(t, "verification failed", bundles[0].Reason)
+
+		h, rec, echoCtx = newHandlers(quarantine)
+		require.NoError(t, h.ListQuarantinedBundles(echoCtx, admin.ListQuarantinedBundlesParams{TrustDomainName: &otherTD}))
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &bundles))
+		assert.Empty(t, bundles)
+
+		invalidTD := "Invalid_Trust_Domain"
+		h, _, echoCtx = newHandlers(quarantine)
+		requireHTTPError(t, h.ListQuarantinedBundles(echoCtx, admin.ListQuarantinedBundlesParams{TrustDomainName: &invalidTD}), http.StatusBadRequest)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		h, rec, echoCtx := newHandlers(quarantine)
+		require.NoError(t, h.GetQuarantinedBundle(echoCtx, bundleID))
+
+		var bundle admin.QuarantinedBundle
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &bundle))
+		assert.Equal(t, "example.org", bundle.TrustDomainName)
+		assert.Equal(t, "bundle", bundle.TrustBundle)
+
+		h, _, echoCtx = newHandlers(quarantine)
+		requireHTTPError(t, h.GetQuarantinedBundle(echoCtx, "unknown"), http.StatusNotFound)
+	})
+
+	t.Run("accept stale bundle", func(t *testing.T) {
+		h, _, echoCtx := newHandlers(&fakeQuarantine{bundles: quarantine.bundles, acceptErr: bundlemanager.ErrStaleQuarantinedBundle})
+		requireHTTPError(t, h.AcceptQuarantinedBundle(echoCtx, bundleID), http.StatusConflict)
+	})
+
+	t.Run("accept", func(t *testing.T) {
+		h, rec, echoCtx := newHandlers(&fakeQuarantine{bundles: map[string]*entity.QuarantinedBundle{bundleID: qb}})
+		require.NoError(t, h.AcceptQuarantinedBundle(echoCtx, bundleID))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		h, _, echoCtx = newHandlers(quarantine)
+		requireHTTPError(t, h.AcceptQuarantinedBundle(echoCtx, "unknown"), http.StatusNotFound)
+	})
+
+	t.Run("discard", func(t *testing.T) {
+		h, rec, echoCtx := newHandlers(quarantine)
+		require.NoError(t, h.DiscardQuarantinedBundle(echoCtx, bundleID))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, quarantine.bundles)
+
+		h, _, echoCtx = newHandlers(quarantine)
+		requireHTTPError(t, h.DiscardQuarantinedBundle(echoCtx, bundleID), http.StatusNotFound)
+	})
+
+	t.Run("quarantine not enabled", func(t *testing.T) {
+		h, _, echoCtx := newHandlers(nil)
+		requireHTTPError(t, h.ListQuarantinedBundles(echoCtx, admin.ListQuarantinedBundlesParams{}), http.StatusInternalServerError)
+	})
+}
@@ -6,14 +6,20 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/health"
+	chttp "github.com/HewlettPackard/galadriel/pkg/common/http"
 	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/common/util"
 	"github.com/HewlettPackard/galadriel/pkg/harvester/api/admin"
 	"github.com/HewlettPackard/galadriel/pkg/harvester/galadrielclient"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
 )
 
+// adminAPIName is the name of the admin API in the metrics.
+const adminAPIName = "admin"
+
 type Server interface {
 	// ListenAndServe starts all endpoint servers and blocks until the context
 	// is canceled or any of the endpoints fails to run.
@@ -21,9 +27,13 @@ type Server interface {
 }
 
 type Endpoints struct {
-	localAddress net.Addr
-	client       galadrielclient.Client
-	logger       logrus.FieldLogger
+	localAddress  net.Addr
+	client        galadrielclient.Client
+	logger        logrus.FieldLogger
+	healthChecker *health.Checker
+	verifiers     []integrity.Verifier
+	resetApprover BundleResetApprover
+	quarantine    BundleQuarantine
 }
 
 // Config represents the configuration of the Harvester Endpoints.
@@ -31,6 +41,14 @@ type Config struct {
 	LocalAddress net.Addr // UDS socket address the Harvester will listen on
 	Client       galadrielclient.Client
 	Logger       logrus.FieldLogger
+	// HealthChecker is optional, when set, the liveness and readiness endpoints are served on the UDS.
+	HealthChecker *health.Checker
+	// BundleVerifiers verify the signatures of the consents to the relationships.
+	BundleVerifiers []integrity.Verifier
+	// BundleResetApprover approves the resets of the bundles of the federated trust domains.
+	BundleResetApprover BundleResetApprover
+	// BundleQuarantine manages the federated bundles rejected by the Harvester.
+	BundleQuarantine BundleQuarantine
 }
 
 func New(cfg *Config) (*Endpoints, error) {
@@ -39,9 +57,13 @@ func New(cfg *Config) (*Endpoints, error) {
 	}
 
 	return &Endpoints{
-		localAddress: cfg.LocalAddress,
-		client:       cfg.Client,
-		logger:       cfg.Logger,
+		localAddress:  cfg.LocalAddress,
+		client:        cfg.Client,
+		logger:        cfg.Logger,
+		healthChecker: cfg.HealthChecker,
+		verifiers:     cfg.BundleVerifiers,
+		resetApprover: cfg.BundleResetApprover,
+		quarantine:    cfg.BundleQuarantine,
 	}, nil
 }
 
@@ -66,6 +88,7 @@ func (e *Endpoints) startUDSListener(ctx context.Context) error {
 	}
 	defer l.Close()
 
+	server.Use(chttp.TracingMiddleware(adminAPIName), chttp.MetricsMiddleware(adminAPIName))
 	e.addUDSHandlers(server)
 
 	log := e.logger.WithFields(logrus.Fields{
@@ -95,5 +118,8 @@ func (e *Endpoints) startUDSListener(ctx context.Context) error {
 }
 
 func (e *Endpoints) addUDSHandlers(server *echo.Echo) {
-	admin.RegisterHandlers(server, NewAdminAPIHandlers(e.logger, e.client))
+	admin.RegisterHandlers(server, NewAdminAPIHandlers(e.logger, e.client, e.verifiers, e.resetApprover, e.quarantine))
+	if e.healthChecker != nil {
+		e.healthChecker.RegisterHandlers(server)
+	}
 }
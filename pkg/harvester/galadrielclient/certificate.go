@@ -0,0 +1,239 @@
+package galadrielclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/diskutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/util"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+)
+
+const (
+	// clientCertificateFile is the name of the file in the data dir holding the client certificate chain of the Harvester.
+	clientCertificateFile = "client-certificate"
+
+	// clientCertificateRetryInterval is how long the client waits to retry a failed renewal of its client certificate.
+	clientCertificateRetryInterval = 30 * time.Second
+
+	// clientCertificateIdleConnTimeout is how long the connections authenticated with a client certificate are kept idle.
+	clientCertificateIdleConnTimeout = 90 * time.Second
+)
+
+// clientCertificatePath returns the path of the file holding the client certificate chain of the Harvester instance.
+// Like the key of the Harvester, the certificate of an instance with an ID is kept in a file of its own.
+func clientCertificatePath(dataDir, instanceID string) string {
+	fileName := clientCertificateFile
+	if instanceID != "" {
+		fileName = fmt.Sprintf("%s-%s", clientCertificateFile, instanceID)
+	}
+
+	return filepath.Join(dataDir, fileName)
+}
+
+// certificateTransport is the HTTP transport of a client authenticating with a client certificate.
+// A new transport presenting the certificate is created when the certificate is renewed,
+// so that no new request is sent over a connection authenticated with the previous certificate.
+type certificateTransport struct {
+	base *http.Transport
+
+	mu        sync.RWMutex
+	transport *http.Transport
+	cert      *tls.Certificate
+}
+
+func newCertificateTransport(base *http.Transport) *certificateTransport {
+	// the connections of the previous transports are closed once idle
+	base.IdleConnTimeout = clientCertificateIdleConnTimeout
+
+	return &certificateTransport{
+		base:      base,
+		transport: base.Clone(),
+	}
+}
+
+// RoundTrip sends the request with the transport presenting the current client certificate.
+func (t *certificateTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.RLock()
+	transport := t.transport
+	t.mu.RUnlock()
+
+	return transport.RoundTrip(req)
+}
+
+func (t *certificateTransport) setCertificate(cert *tls.Certificate) {
+	transport := t.base.Clone()
+	transport.TLSClientConfig.Certificates = []tls.Certificate{*cert}
+
+	t.mu.Lock()
+	previous := t.transport
+	t.transport = transport
+	t.cert = cert
+	t.mu.Unlock()
+
+	// the requests in flight complete over their connections
+	previous.CloseIdleConnections()
+}
+
+func (t *certificateTransport) getCertificate() *tls.Certificate {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.cert
+}
+
+// loadClientCertificate loads the client certificate stored in the data dir, unless it has expired or
+// it is not the certificate of the Harvester key.
+func (c *client) loadClientCertificate() error {
+	chainPEM, err := os.ReadFile(c.clientCertPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read client certificate: %w", err)
+	}
+
+	cert, err := c.buildClientCertificate(chainPEM)
+	if err != nil {
+		return err
+	}
+
+	if time.Now().After(cert.Leaf.NotAfter) {
+		c.logger.Infof("Stored client certificate expired at %s", cert.Leaf.NotAfter.Format(time.RFC3339))
+		return nil
+	}
+
+	c.certTransport.setCertificate(cert)
+
+	return nil
+}
+
+// renewClientCertificate requests a new client certificate for the Harvester key, authenticating
+// with the current client certificate, or with the JWT access token if the client has none yet.
+// The certificate is stored in the data dir, so that the Harvester can authenticate with it when it restarts.
+func (c *client) renewClientCertificate(ctx context.Context) error {
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: c.trustDomain.String()},
+	}, c.harvesterKey)
+	if err != nil {
+		return fmt.Errorf("failed to create certificate signing request: %w", err)
+	}
+
+	resp, err := c.client.IssueClientCertificate(ctx, c.trustDomain.String(), harvester.ClientCertificateRequest{
+		Csr: util.EncodeToString(csr),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get client certificate: %s", string(body))
+	}
+
+	certResponse := &harvester.ClientCertificateResponse{}
+	if err := json.Unmarshal(body, certResponse); err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	chainPEM := []byte(certResponse.CertificateChain)
+	cert, err := c.buildClientCertificate(chainPEM)
+	if err != nil {
+		return err
+	}
+
+	c.certTransport.setCertificate(cert)
+	c.logger.WithField("expiry", cert.Leaf.NotAfter).Info("Client certificate updated")
+
+	if err := diskutil.AtomicWritePrivateFile(c.clientCertPath, chainPEM); err != nil {
+		c.logger.Errorf("Failed to save client certificate to disk: %v", err)
+	}
+
+	return nil
+}
+
+// buildClientCertificate builds the TLS certificate presented by the client from the PEM certificate chain
+// issued by the Galadriel Server, checking that it is the certificate of the Harvester key.
+func (c *client) buildClientCertificate(chainPEM []byte) (*tls.Certificate, error) {
+	chain, err := cryptoutil.ParseCertificates(chainPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse client certificate: %w", err)
+	}
+	if len(chain) == 0 {
+		return nil, errors.New("empty client certificate chain")
+	}
+
+	leaf := chain[0]
+	publicKey, ok := leaf.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !publicKey.Equal(c.harvesterKey.Public()) {
+		return nil, errors.New("client certificate is not issued for the harvester key")
+	}
+
+	cert := &tls.Certificate{
+		PrivateKey: c.harvesterKey,
+		Leaf:       leaf,
+	}
+	for _, x509Cert := range chain {
+		cert.Certificate = append(cert.Certificate, x509Cert.Raw)
+	}
+
+	return cert, nil
+}
+
+// checkClientCertificate reports an error if the client holds no client certificate, or if it has expired.
+func (c *client) checkClientCertificate() error {
+	cert := c.certTransport.getCertificate()
+	if cert == nil {
+		return NotOnboardedErr
+	}
+
+	if time.Now().After(cert.Leaf.NotAfter) {
+		return fmt.Errorf("client certificate expired at %s", cert.Leaf.NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func (c *client) startClientCertificateRotation(ctx context.Context) {
+	c.logger.Info("Started client certificate rotator")
+
+	var renewErr error
+	for {
+		// the certificate is renewed when half of its lifetime has elapsed
+		wait := clientCertificateRetryInterval
+		if renewErr == nil {
+			leaf := c.certTransport.getCertificate().Leaf
+			wait = time.Until(leaf.NotBefore.Add(leaf.NotAfter.Sub(leaf.NotBefore) / 2))
+		}
+
+		select {
+		case <-time.After(wait):
+			c.logger.Debug("Requesting a new client certificate from Galadriel Server")
+			renewErr = c.renewClientCertificate(ctx)
+			if renewErr != nil {
+				c.logger.Errorf("Error getting new client certificate: %v", renewErr)
+			}
+		case <-ctx.Done():
+			c.logger.Info("Client certificate rotator stopped")
+			return
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package galadrielclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+	"github.com/HewlettPackard/galadriel/test/certtest"
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCertificateHarvesterClient struct {
+	harvester.ClientInterface
+
+	t          *testing.T
+	statusCode int
+	caCert     *x509.Certificate
+	caKey      crypto.PrivateKey
+	ttl        time.Duration
+	csr        *x509.CertificateRequest
+}
+
+func (c *fakeCertificateHarvesterClient) IssueClientCertificate(ctx context.Context, trustDomainName string, body harvester.IssueClientCertificateJSONRequestBody, reqEditors ...harvester.RequestEditorFn) (*http.Response, error) {
+	csr, err := body.ParseCSR()
+	require.NoError(c.t, err)
+	c.csr = csr
+
+	if c.statusCode != http.StatusOK {
+		return jsonResponse(c.statusCode, nil)
+	}
+
+	template, err := cryptoutil.CreateX509Template(clock.New(), csr.PublicKey, pkix.Name{CommonName: trustDomainName}, nil, nil, c.ttl)
+	require.NoError(c.t, err)
+	cert, err := cryptoutil.SignX509(template, c.caCert, c.caKey)
+	require.NoError(c.t, err)
+	chainPEM, err := cryptoutil.EncodeCertificates([]*x509.Certificate{cert, c.caCert})
+	require.NoError(c.t, err)
+
+	return jsonResponse(http.StatusOK, harvester.ClientCertificateResponse{
+		CertificateChain: string(chainPEM),
+		ExpiresAt:        cert.NotAfter,
+	})
+}
+
+func newCertificateTestClient(t *testing.T, fake *fakeCertificateHarvesterClient, dataDir string, key crypto.Signer) *client {
+	logger, _ := test.NewNullLogger()
+	store, err := newJwtStore(dataDir, tokenFile, logger)
+	require.NoError(t, err)
+
+	return &client{
+		client:         fake,
+		trustDomain:    spiffeid.RequireTrustDomainFromString("example.org"),
+		jwtStore:       store,
+		logger:         logger,
+		harvesterKey:   key,
+		certTransport:  newCertificateTransport(&http.Transport{TLSClientConfig: &tls.Config{}}),
+		clientCertPath: clientCertificatePath(dataDir, ""),
+	}
+}
+
+func TestRenewClientCertificate(t *testing.T) {
+	caCert, caKey := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+	key, err := LoadOrCreateHarvesterKey(t.TempDir(), "")
+	require.NoError(t, err)
+
+	t.Run("Renews and stores the client certificate of the harvester key", func(t *testing.T) {
+		dataDir := t.TempDir()
+		fake := &fakeCertificateHarvesterClient{t: t, statusCode: http.StatusOK, caCert: caCert, caKey: caKey, ttl: time.Hour}
+		c := newCertificateTestClient(t, fake, dataDir, key)
+
+		assert.ErrorIs(t, c.CheckToken(context.Background()), NotOnboardedErr)
+		assert.False(t, c.isClientOnboarded())
+
+		require.NoError(t, c.renewClientCertificate(context.Background()))
+		assert.Equal(t, key.Public(), fake.csr.PublicKey)
+		assert.Equal(t, "example.org", fake.csr.Subject.CommonName)
+
+		cert := c.certTransport.getCertificate()
+		require.NotNil(t, cert)
+		assert.Equal(t, key, cert.PrivateKey)
+		assert.Len(t, cert.Certificate, 2)
+		assert.Equal(t, []tls.Certificate{*cert}, c.certTransport.transport.TLSClientConfig.Certificates)
+		assert.NoError(t, c.CheckToken(context.Background()))
+		assert.True(t, c.isClientOnboarded())
+
+		info, err := os.Stat(filepath.Join(dataDir, "client-certificate"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+		// the stored certificate is loaded when the harvester restarts
+		restarted := newCertificateTestClient(t, fake, dataDir, key)
+		require.NoError(t, restarted.loadClientCertificate())
+		require.NotNil(t, restarted.certTransport.getCertificate())
+		assert.Equal(t, cert.Certificate, restarted.certTransport.getCertificate().Certificate)
+
+		// the stored certificate is not the certificate of another key
+		otherKey, err := LoadOrCreateHarvesterKey(t.TempDir(), "")
+		require.NoError(t, err)
+		other := newCertificateTestClient(t, fake, dataDir, otherKey)
+		assert.EqualError(t, other.loadClientCertificate(), "client certificate is not issued for the harvester key")
+		assert.Nil(t, other.certTransport.getCertificate())
+	})
+
+	t.Run("Ignores an expired stored client certificate", func(t *testing.T) {
+		dataDir := t.TempDir()
+		fake := &fakeCertificateHarvesterClient{t: t, statusCode: http.StatusOK, caCert: caCert, caKey: caKey, ttl: -time.Minute}
+		c := newCertificateTestClient(t, fake, dataDir, key)
+
+		require.NoError(t, c.renewClientCertificate(context.Background()))
+		assert.ErrorContains(t, c.CheckToken(context.Background()), "client certificate expired at")
+
+		restarted := newCertificateTestClient(t, fake, dataDir, key)
+		require.NoError(t, restarted.loadClientCertificate())
+		assert.Nil(t, restarted.certTransport.getCertificate())
+	})
+
+	t.Run("Fails if the server does not issue the certificate", func(t *testing.T) {
+		fake := &fakeCertificateHarvesterClient{t: t, statusCode: http.StatusUnauthorized}
+		c := newCertificateTestClient(t, fake, t.TempDir(), key)
+
+		assert.ErrorContains(t, c.renewClientCertificate(context.Background()), "failed to get client certificate")
+		assert.Nil(t, c.certTransport.getCertificate())
+	})
+}
@@ -2,6 +2,7 @@ package galadrielclient
 
 import (
 	"context"
+	"crypto"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
@@ -20,8 +21,13 @@ import (
 	"github.com/HewlettPackard/galadriel/pkg/common/constants"
 	"github.com/HewlettPackard/galadriel/pkg/common/diskutil"
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	chttp "github.com/HewlettPackard/galadriel/pkg/common/http"
+	"github.com/HewlettPackard/galadriel/pkg/common/jwt"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/common/util"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
 	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+	gojwt "github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
@@ -40,9 +46,31 @@ var (
 // Client represents a client to interact with the Galadriel Server API.
 type Client interface {
 	SyncBundles(context.Context, []*entity.Bundle) ([]*entity.Bundle, map[spiffeid.TrustDomain][]byte, error)
+	WatchBundles(context.Context, map[spiffeid.TrustDomain][]byte, int64, time.Duration) (*BundleSyncResult, error)
 	PostBundle(context.Context, *entity.Bundle) error
 	GetRelationships(context.Context, entity.ConsentStatus) ([]*entity.Relationship, error)
 	UpdateRelationship(context.Context, uuid.UUID, entity.ConsentStatus) (*entity.Relationship, error)
+	GetRelationshipConsents(context.Context, uuid.UUID) ([]*entity.RelationshipConsent, error)
+	AcquireLease(context.Context) (*entity.HarvesterLease, error)
+	ReleaseLease(context.Context) error
+
+	CheckConnection(context.Context) error
+	CheckToken(context.Context) error
+}
+
+// BundleSyncResult is the result of a bundle sync with the Galadriel Server.
+type BundleSyncResult struct {
+	// Bundles are the federated bundles that changed.
+	Bundles []*entity.Bundle
+	// Digests maps the federated trust domains to their bundle digests. In an incremental result,
+	// it only holds the trust domains whose bundle changed.
+	Digests map[spiffeid.TrustDomain][]byte
+	// Removed are the trust domains that are no longer federated, only set in an incremental result.
+	Removed []spiffeid.TrustDomain
+	// Cursor is the position in the change log of the Galadriel Server to resume from, zero if there is none.
+	Cursor int64
+	// Incremental is true when the result only holds the changes made since the cursor in the request.
+	Incremental bool
 }
 
 // Config is a struct that holds the configuration for the Galadriel Server client.
@@ -52,15 +80,48 @@ type Config struct {
 	TrustBundlePath        string
 	DataDir                string
 	JoinToken              string
-	Logger                 logrus.FieldLogger
+	// ValidateJWT enables the local validation of the JWT access tokens issued by the
+	// Galadriel Server, using the keys published in the server JWKS.
+	ValidateJWT bool
+	// InstanceID identifies the Harvester instance among the instances of the same trust domain.
+	// It is optional, when set, the JWT access token is stored in a file of its own, so that the
+	// instances can share the data dir.
+	InstanceID string
+	// ConsentSigner is optional, when set, the consents to the relationships are signed, so that
+	// the Galadriel Server and the peers can verify them. When the Harvester has neither a join token
+	// nor a stored JWT access token, it also signs the onboard statement proving the control of the
+	// trust domain, so that the Harvester can onboard without a join token.
+	ConsentSigner integrity.Signer
+	// HarvesterKey is optional, when set, the Harvester proves that it holds the key when it onboards,
+	// to use the join tokens bound to the key fingerprint.
+	HarvesterKey crypto.Signer
+	// ClientCertificateAuth enables the authentication with a client certificate for the HarvesterKey issued
+	// by the Galadriel Server, instead of a JWT access token. The JWT access token is only used to get the
+	// first certificate after onboarding, and is not kept in the data dir afterwards.
+	ClientCertificateAuth bool
+	Logger                logrus.FieldLogger
 }
 
 // client is a struct that implements the Client interface
 type client struct {
 	client      harvester.ClientInterface
 	trustDomain spiffeid.TrustDomain
+	instanceID  string
 	jwtStore    *jwtStore
 	logger      logrus.FieldLogger
+
+	// consentSigner is nil unless the consents are signed
+	consentSigner integrity.Signer
+
+	// harvesterKey is nil unless the Harvester proves that it holds its key when it onboards
+	harvesterKey crypto.Signer
+
+	// certTransport is nil unless the client authenticates with a client certificate
+	certTransport  *certificateTransport
+	clientCertPath string
+
+	// jwtValidator is nil unless the local validation of the JWT access tokens is enabled
+	jwtValidator jwt.Validator
 }
 
 // jwtStore is a struct that holds the JWT access token
@@ -88,21 +149,36 @@ func NewClient(ctx context.Context, cfg *Config) (Client, error) {
 		return nil, errors.New("data dir cannot be empty")
 	}
 
-	jwtProvider, err := newJwtStore(cfg.DataDir, tokenFile, cfg.Logger)
+	tokenFileName := tokenFile
+	if cfg.InstanceID != "" {
+		tokenFileName = fmt.Sprintf("%s-%s", tokenFile, cfg.InstanceID)
+	}
+
+	jwtProvider, err := newJwtStore(cfg.DataDir, tokenFileName, cfg.Logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create JWT provider: %w", err)
 	}
 
+	if cfg.ClientCertificateAuth && cfg.HarvesterKey == nil {
+		return nil, errors.New("harvester key is required to authenticate with a client certificate")
+	}
+
 	c, err := createTLSClient(cfg.TrustBundlePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create TLS client for server %s: %w", cfg.GaladrielServerAddress, err)
 	}
 
+	var certTransport *certificateTransport
+	if cfg.ClientCertificateAuth {
+		certTransport = newCertificateTransport(c.Transport.(*http.Transport))
+		c.Transport = certTransport
+	}
+
 	serverAddress := fmt.Sprintf("%s://%s", constants.HTTPSScheme, cfg.GaladrielServerAddress.String())
 
-	// Create harvester client
+	// Create harvester client, propagating the trace of the calls to the Galadriel Server
 	harvesterClient, err := harvester.NewClient(serverAddress,
-		harvester.WithHTTPClient(c),
+		harvester.WithHTTPClient(chttp.NewTracingClient(c)),
 		harvester.WithRequestEditorFn(createJWTTokenReqEditor(jwtProvider)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create harvester client: %w", err)
@@ -110,9 +186,29 @@ func NewClient(ctx context.Context, cfg *Config) (Client, error) {
 
 	client := &client{
 		trustDomain: cfg.TrustDomain,
+		instanceID:  cfg.InstanceID,
 		client:      harvesterClient,
 		logger:      cfg.Logger,
 		jwtStore:    jwtProvider,
+
+		consentSigner: cfg.ConsentSigner,
+		harvesterKey:  cfg.HarvesterKey,
+	}
+
+	if certTransport != nil {
+		client.certTransport = certTransport
+		client.clientCertPath = clientCertificatePath(cfg.DataDir, cfg.InstanceID)
+		if err := client.loadClientCertificate(); err != nil {
+			client.logger.Warnf("Ignoring stored client certificate: %v", err)
+		}
+	}
+
+	if cfg.ValidateJWT {
+		keySet := newJWKSKeySet(harvesterClient)
+		client.jwtValidator = jwt.NewDefaultJWTValidator(&jwt.ValidatorConfig{
+			PublicKeyFunc:    keySet.getPublicKey,
+			ExpectedAudience: []string{constants.GaladrielServerName},
+		})
 	}
 
 	// if the user provided a join token, try to onboard the Harvester to Galadriel Server
@@ -123,8 +219,26 @@ func NewClient(ctx context.Context, cfg *Config) (Client, error) {
 	}
 
 	if !client.isClientOnboarded() {
-		// this happens if the user did not provide a join token and the Harvester cannot find a stored jwt token
-		return nil, errors.New("harvester is not onboarded to Galadriel Server. A join token is required")
+		// this happens if the user did not provide a join token and the Harvester cannot find a stored jwt token or client certificate
+		if client.consentSigner == nil {
+			return nil, errors.New("harvester is not onboarded to Galadriel Server. A join token is required")
+		}
+
+		if err := client.onboardWithProof(ctx); err != nil {
+			return nil, fmt.Errorf("harvester is not onboarded to Galadriel Server and could not prove the control of its trust domain, a join token is required: %w", err)
+		}
+	}
+
+	if client.certTransport != nil {
+		client.logger.Debug("Requesting a new client certificate from Galadriel Server")
+		if err := client.renewClientCertificate(ctx); err != nil {
+			return nil, fmt.Errorf("could not get a client certificate: %v", err)
+		}
+		// a copy of the stored JWT token must not be enough to act on behalf of the trust domain
+		client.jwtStore.setToken("")
+		go client.startClientCertificateRotation(ctx)
+
+		return client, nil
 	}
 
 	client.logger.Debug("Requesting a new JWT token from Galadriel Server")
@@ -209,6 +323,12 @@ func (c *client) UpdateRelationship(ctx context.Context, relationshipID uuid.UUI
 		ConsentStatus: api.ConsentStatus(consentStatus),
 	}
 
+	if c.consentSigner != nil {
+		if err := c.signConsent(&request, relationshipID, consentStatus); err != nil {
+			return nil, err
+		}
+	}
+
 	resp, err := c.client.PatchRelationship(ctx, c.trustDomain.String(), relationshipID, request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update relationship: %v", err)
@@ -237,6 +357,82 @@ func (c *client) UpdateRelationship(ctx context.Context, relationshipID uuid.UUI
 	return ent, nil
 }
 
+// GetRelationshipConsents retrieves the signed consents of the trust domains of the relationship identified
+// by the given relationshipID. The consents are returned as stored by the Galadriel Server, their signatures
+// are not verified.
+// If the client is not onboarded, it returns NotOnboardedErr.
+func (c *client) GetRelationshipConsents(ctx context.Context, relationshipID uuid.UUID) ([]*entity.RelationshipConsent, error) {
+	if c.jwtStore == nil {
+		return nil, NotOnboardedErr
+	}
+
+	resp, err := c.client.GetRelationshipConsents(ctx, c.trustDomain.String(), relationshipID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relationship consents: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get relationship consents: %s", string(body))
+	}
+
+	var consents []api.RelationshipConsent
+	if err := json.Unmarshal(body, &consents); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %v", err)
+	}
+
+	ents := make([]*entity.RelationshipConsent, 0, len(consents))
+	for _, consent := range consents {
+		ent, err := consent.ToEntity()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert relationship consent to entity: %v", err)
+		}
+		ents = append(ents, ent)
+	}
+
+	return ents, nil
+}
+
+// signConsent signs the statement of the consent of the trust domain to the relationship, and adds it
+// to the request along with its signature.
+func (c *client) signConsent(request *harvester.PatchRelationshipRequest, relationshipID uuid.UUID, consentStatus entity.ConsentStatus) error {
+	statement := &entity.ConsentStatement{
+		RelationshipID: relationshipID,
+		TrustDomain:    c.trustDomain.String(),
+		ConsentStatus:  consentStatus,
+		SignedAt:       time.Now().UTC(),
+	}
+
+	data, err := statement.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal consent statement: %w", err)
+	}
+
+	sig, certChain, err := c.consentSigner.Sign(data)
+	if err != nil {
+		return fmt.Errorf("failed to sign consent statement: %w", err)
+	}
+
+	var chainBytes []byte
+	for _, cert := range certChain {
+		chainBytes = append(chainBytes, cert.Raw...)
+	}
+
+	encodedStatement := util.EncodeToString(data)
+	encodedSig := util.EncodeToString(sig)
+	encodedChain := util.EncodeToString(chainBytes)
+	request.ConsentStatement = &encodedStatement
+	request.Signature = &encodedSig
+	request.SigningCertificateChain = &encodedChain
+
+	return nil
+}
+
 // SyncBundles synchronizes the given bundles with the Galadriel Server. It returns the updated bundles and the
 // map of all federated trust domains with active relationships and their bundle digests.
 func (c *client) SyncBundles(ctx context.Context, bundles []*entity.Bundle) ([]*entity.Bundle, map[spiffeid.TrustDomain][]byte, error) {
@@ -253,31 +449,67 @@ func (c *client) SyncBundles(ctx context.Context, bundles []*entity.Bundle) ([]*
 		State: digests,
 	}
 
+	result, err := c.syncBundles(ctx, syncRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result.Bundles, result.Digests, nil
+}
+
+// WatchBundles waits until the federated bundles in the Galadriel Server differ from the given state, or until the
+// wait time elapses. When a cursor returned by a previous sync is given, the state is not sent, and the Galadriel
+// Server only returns the changes made since the cursor, unless it no longer has them in its change log, in which case
+// the result is a full one: the bundles of all federated trust domains and the map of all their bundle digests.
+func (c *client) WatchBundles(ctx context.Context, state map[spiffeid.TrustDomain][]byte, cursor int64, wait time.Duration) (*BundleSyncResult, error) {
+	if c.jwtStore == nil {
+		return nil, NotOnboardedErr
+	}
+
+	digests := make(map[string]string, len(state))
+	waitSeconds := int(wait.Seconds())
+	syncRequest := harvester.PostBundleSyncRequest{
+		State: digests,
+		Wait:  &waitSeconds,
+	}
+
+	if cursor > 0 {
+		syncRequest.Cursor = &cursor
+	} else {
+		for td, digest := range state {
+			digests[td.String()] = util.EncodeToString(digest)
+		}
+	}
+
+	return c.syncBundles(ctx, syncRequest)
+}
+
+func (c *client) syncBundles(ctx context.Context, syncRequest harvester.PostBundleSyncRequest) (*BundleSyncResult, error) {
 	resp, err := c.client.BundleSync(ctx, c.trustDomain.String(), syncRequest)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, nil, fmt.Errorf("failed to sync bundles: %s", string(body))
+		return nil, fmt.Errorf("failed to sync bundles: %s", string(body))
 	}
 
 	syncResult := &harvester.PostBundleSyncResponse{}
 	if err := json.Unmarshal(body, syncResult); err != nil {
-		return nil, nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
 
 	var updates []*entity.Bundle
 	for td, b := range syncResult.Updates {
 		bundle, err := createEntityBundle(td, &b)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
 		updates = append(updates, bundle)
@@ -287,16 +519,38 @@ func (c *client) SyncBundles(ctx context.Context, bundles []*entity.Bundle) ([]*
 	for td, digest := range syncResult.State {
 		trustDomain, err := spiffeid.TrustDomainFromString(td)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to parse trust domain: %w", err)
+			return nil, fmt.Errorf("failed to parse trust domain: %w", err)
 		}
 		d, err := util.DecodeString(digest)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to decode digest: %w", err)
+			return nil, fmt.Errorf("failed to decode digest: %w", err)
 		}
 		state[trustDomain] = d
 	}
 
-	return updates, state, nil
+	result := &BundleSyncResult{
+		Bundles: updates,
+		Digests: state,
+	}
+
+	// the servers that do not keep a change log return neither a cursor nor incremental results
+	if syncResult.Cursor != nil {
+		result.Cursor = *syncResult.Cursor
+	}
+	if syncResult.Incremental != nil && *syncResult.Incremental {
+		result.Incremental = true
+		if syncResult.Removed != nil {
+			for _, td := range *syncResult.Removed {
+				trustDomain, err := spiffeid.TrustDomainFromString(td)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse trust domain: %w", err)
+				}
+				result.Removed = append(result.Removed, trustDomain)
+			}
+		}
+	}
+
+	return result, nil
 }
 
 func (c *client) PostBundle(ctx context.Context, bundle *entity.Bundle) error {
@@ -313,6 +567,9 @@ func (c *client) PostBundle(ctx context.Context, bundle *entity.Bundle) error {
 		SigningCertificateChain: &certChain,
 		TrustDomain:             bundle.TrustDomainName.String(),
 	}
+	if c.instanceID != "" {
+		bundlePut.InstanceId = &c.instanceID
+	}
 
 	resp, err := c.client.BundlePut(ctx, bundle.TrustDomainName.String(), bundlePut)
 	if err != nil {
@@ -332,8 +589,115 @@ func (c *client) PostBundle(ctx context.Context, bundle *entity.Bundle) error {
 	return nil
 }
 
-// isClientOnboarded Check if the client has been onboarded by checking if there is a JWT token
+// AcquireLease acquires or renews the upload lease of the trust domain for the Harvester instance.
+// It returns the current lease, which is held by another instance if its instance ID differs from the client's.
+func (c *client) AcquireLease(ctx context.Context) (*entity.HarvesterLease, error) {
+	if c.jwtStore == nil {
+		return nil, NotOnboardedErr
+	}
+	if c.instanceID == "" {
+		return nil, errors.New("instance ID is required to acquire the lease")
+	}
+
+	resp, err := c.client.AcquireLease(ctx, c.trustDomain.String(), harvester.LeaseRequest{InstanceId: c.instanceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// the server responds with the lease of the holder instance when the lease is held by another instance
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusConflict {
+		return nil, fmt.Errorf("failed to acquire lease: %s", string(body))
+	}
+
+	lease := &harvester.Lease{}
+	if err := json.Unmarshal(body, lease); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return &entity.HarvesterLease{
+		InstanceID: lease.InstanceId,
+		ExpiresAt:  lease.ExpiresAt,
+	}, nil
+}
+
+// ReleaseLease releases the upload lease of the trust domain if it is held by the Harvester instance.
+func (c *client) ReleaseLease(ctx context.Context) error {
+	if c.jwtStore == nil {
+		return NotOnboardedErr
+	}
+	if c.instanceID == "" {
+		return errors.New("instance ID is required to release the lease")
+	}
+
+	resp, err := c.client.ReleaseLease(ctx, c.trustDomain.String(), &harvester.ReleaseLeaseParams{InstanceID: c.instanceID})
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %w", err)
+		}
+		return fmt.Errorf("failed to release lease: %s", string(body))
+	}
+
+	return nil
+}
+
+// CheckConnection reports an error if the Galadriel Server cannot be reached, fetching its JWKS
+// as it does not require authentication.
+func (c *client) CheckConnection(ctx context.Context) error {
+	resp, err := c.client.GetJWKS(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to reach Galadriel Server: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from Galadriel Server", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// CheckToken reports an error if the client holds no JWT access token, or if it has expired.
+// The token is not validated, as only the Galadriel Server holds the keys it was signed with.
+// When the client authenticates with a client certificate, the certificate is checked instead.
+func (c *client) CheckToken(context.Context) error {
+	if c.certTransport != nil {
+		return c.checkClientCertificate()
+	}
+
+	token := c.jwtStore.getToken()
+	if token == "" {
+		return NotOnboardedErr
+	}
+
+	claims := &gojwt.RegisteredClaims{}
+	if _, _, err := gojwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return fmt.Errorf("failed to parse JWT token: %w", err)
+	}
+
+	if claims.ExpiresAt != nil && time.Now().After(claims.ExpiresAt.Time) {
+		return fmt.Errorf("JWT token expired at %s", claims.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// isClientOnboarded Check if the client has been onboarded by checking if there is a JWT token or a client certificate
 func (c *client) isClientOnboarded() bool {
+	if c.certTransport != nil && c.certTransport.getCertificate() != nil {
+		return true
+	}
 	return c.jwtStore.getToken() != ""
 }
 
@@ -346,10 +710,98 @@ func (c *client) onboard(ctx context.Context, token string) error {
 	c.logger.Info("Onboarding Harvester")
 
 	params := harvester.OnboardParams{JoinToken: token}
+	if c.harvesterKey != nil {
+		if err := signOnboardParams(&params, c.harvesterKey, c.trustDomain.String(), time.Now()); err != nil {
+			return err
+		}
+	}
+
 	resp, err := c.client.Onboard(ctx, c.trustDomain.String(), &params)
 	if err != nil {
 		return fmt.Errorf("failed to send request: %w", err)
 	}
+
+	return c.handleOnboardResponse(ctx, resp)
+}
+
+// onboardWithProof onboards the client without a join token, proving the control of the trust domain
+// with an onboard statement signed with the consent signer, e.g. with an X509-SVID of the trust domain.
+// The Galadriel Server checks that the signing certificate chains to the bundle it stores for the trust domain,
+// and that the statement has the nonce of an onboard challenge it issued, so that the statement cannot be replayed.
+func (c *client) onboardWithProof(ctx context.Context) error {
+	c.logger.Info("Onboarding Harvester by proving the control of its trust domain")
+
+	nonce, err := c.getOnboardChallenge(ctx)
+	if err != nil {
+		return err
+	}
+
+	statement := &entity.OnboardStatement{
+		TrustDomain: c.trustDomain.String(),
+		Nonce:       nonce,
+		SignedAt:    time.Now().UTC(),
+	}
+
+	data, err := statement.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal onboard statement: %w", err)
+	}
+
+	sig, certChain, err := c.consentSigner.Sign(data)
+	if err != nil {
+		return fmt.Errorf("failed to sign onboard statement: %w", err)
+	}
+
+	var chainBytes []byte
+	for _, cert := range certChain {
+		chainBytes = append(chainBytes, cert.Raw...)
+	}
+
+	request := harvester.OnboardProofRequest{
+		OnboardStatement:        util.EncodeToString(data),
+		Signature:               util.EncodeToString(sig),
+		SigningCertificateChain: util.EncodeToString(chainBytes),
+	}
+
+	resp, err := c.client.OnboardWithProof(ctx, c.trustDomain.String(), request)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	return c.handleOnboardResponse(ctx, resp)
+}
+
+// getOnboardChallenge gets the nonce of a new onboard challenge from the Galadriel Server.
+func (c *client) getOnboardChallenge(ctx context.Context) (string, error) {
+	resp, err := c.client.CreateOnboardChallenge(ctx, c.trustDomain.String())
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get onboard challenge: %s", string(body))
+	}
+
+	challenge := &harvester.OnboardChallengeResponse{}
+	if err := json.Unmarshal(body, challenge); err != nil {
+		return "", fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	if challenge.Nonce == "" {
+		return "", errors.New("empty nonce in onboard challenge response")
+	}
+
+	return challenge.Nonce, nil
+}
+
+// handleOnboardResponse reads the JWT token in the onboard response, and caches it in the client jwtStore.
+func (c *client) handleOnboardResponse(ctx context.Context, resp *http.Response) error {
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
@@ -370,6 +822,9 @@ func (c *client) onboard(ctx context.Context, token string) error {
 	if jwtToken == "" {
 		return fmt.Errorf("empty JWT token in onboard response")
 	}
+	if err := c.validateJWTToken(ctx, jwtToken); err != nil {
+		return err
+	}
 	c.jwtStore.setToken(jwtToken)
 
 	c.logger.Info("Connected to Galadriel Server")
@@ -398,6 +853,9 @@ func (c *client) getNewJWTToken(ctx context.Context) error {
 	if jwtToken == "" {
 		return fmt.Errorf("JWT token could not be renewed")
 	}
+	if err := c.validateJWTToken(ctx, jwtToken); err != nil {
+		return err
+	}
 
 	c.logger.Info("JWT token updated")
 	c.jwtStore.setToken(jwtToken)
@@ -405,6 +863,26 @@ func (c *client) getNewJWTToken(ctx context.Context) error {
 	return nil
 }
 
+// validateJWTToken checks that the JWT token is signed by one of the keys in the Galadriel Server JWKS,
+// that it has not expired and that it was issued to the Harvester trust domain.
+// It is a no-op if the local validation of JWT tokens is not enabled.
+func (c *client) validateJWTToken(ctx context.Context, token string) error {
+	if c.jwtValidator == nil {
+		return nil
+	}
+
+	claims, err := c.jwtValidator.ValidateToken(ctx, token)
+	if err != nil {
+		return fmt.Errorf("invalid JWT token: %w", err)
+	}
+
+	if claims.Subject != c.trustDomain.String() {
+		return fmt.Errorf("invalid JWT token: subject %q does not match trust domain %q", claims.Subject, c.trustDomain)
+	}
+
+	return nil
+}
+
 func createTLSClient(trustBundlePath string) (*http.Client, error) {
 	caCert, err := os.ReadFile(trustBundlePath)
 	if err != nil {
@@ -441,15 +919,17 @@ func (p *jwtStore) createEmptyTokenFile() error {
 	return nil
 }
 
-// createJWTTokenReqEditor creates a request editor function that adds the JWT token to the request's Authorization header
+// createJWTTokenReqEditor creates a request editor function that adds the JWT token to the request's Authorization header.
+// No header is added when the client holds no token, e.g. when it authenticates with a client certificate.
 func createJWTTokenReqEditor(jp *jwtStore) harvester.RequestEditorFn {
 	return func(ctx context.Context, req *http.Request) error {
 		if req.URL.Path == onboardPath {
 			return nil
 		}
 
-		token := jp.getToken()
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		if token := jp.getToken(); token != "" {
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		}
 		req.Header.Set("Content-Type", constants.JSONContentType)
 		return nil
 	}
@@ -464,7 +944,9 @@ func (c *client) startJWTTokenRotation(ctx context.Context) {
 		select {
 		case <-ticker.C:
 			c.logger.Debug("Requesting a new JWT token from Galadriel Server")
-			if err := c.getNewJWTToken(ctx); err != nil {
+			err := c.getNewJWTToken(ctx)
+			telemetry.IncJWTRenewals(err)
+			if err != nil {
 				c.logger.Errorf("Error getting new JWT token: %v", err)
 			}
 		case <-ctx.Done():
@@ -1,2 +1,418 @@
-// TODO: add tests
 package galadrielclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/api"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/util"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+	gojwt "github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLeaseHarvesterClient struct {
+	harvester.ClientInterface
+
+	statusCode   int
+	lease        harvester.Lease
+	leaseRequest harvester.LeaseRequest
+	released     *harvester.ReleaseLeaseParams
+	bundlePut    harvester.PutBundleRequest
+}
+
+func (c *fakeLeaseHarvesterClient) AcquireLease(ctx context.Context, trustDomainName string, body harvester.AcquireLeaseJSONRequestBody, reqEditors ...harvester.RequestEditorFn) (*http.Response, error) {
+	c.leaseRequest = body
+	return jsonResponse(c.statusCode, c.lease)
+}
+
+func (c *fakeLeaseHarvesterClient) ReleaseLease(ctx context.Context, trustDomainName string, params *harvester.ReleaseLeaseParams, reqEditors ...harvester.RequestEditorFn) (*http.Response, error) {
+	c.released = params
+	return jsonResponse(c.statusCode, nil)
+}
+
+func (c *fakeLeaseHarvesterClient) BundlePut(ctx context.Context, trustDomainName string, body harvester.BundlePutJSONRequestBody, reqEditors ...harvester.RequestEditorFn) (*http.Response, error) {
+	c.bundlePut = body
+	return jsonResponse(c.statusCode, nil)
+}
+
+func (c *fakeLeaseHarvesterClient) GetJWKS(ctx context.Context, reqEditors ...harvester.RequestEditorFn) (*http.Response, error) {
+	return jsonResponse(c.statusCode, harvester.JWKS{})
+}
+
+func jsonResponse(statusCode int, v interface{}) (*http.Response, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}, nil
+}
+
+func newLeaseTestClient(t *testing.T, fake *fakeLeaseHarvesterClient, instanceID string) *client {
+	logger, _ := test.NewNullLogger()
+	store, err := newJwtStore(t.TempDir(), tokenFile, logger)
+	require.NoError(t, err)
+
+	return &client{
+		client:      fake,
+		trustDomain: spiffeid.RequireTrustDomainFromString("example.org"),
+		instanceID:  instanceID,
+		jwtStore:    store,
+		logger:      logger,
+	}
+}
+
+func TestAcquireLease(t *testing.T) {
+	expiresAt := time.Now().Add(time.Minute).UTC().Truncate(time.Second)
+
+	t.Run("lease acquired", func(t *testing.T) {
+		fake := &fakeLeaseHarvesterClient{statusCode: http.StatusOK, lease: harvester.Lease{InstanceId: "harvester-1", ExpiresAt: expiresAt}}
+		c := newLeaseTestClient(t, fake, "harvester-1")
+
+		lease, err := c.AcquireLease(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "harvester-1", fake.leaseRequest.InstanceId)
+		assert.Equal(t, "harvester-1", lease.InstanceID)
+		assert.Equal(t, expiresAt, lease.ExpiresAt)
+	})
+
+	t.Run("lease held by another instance", func(t *testing.T) {
+		fake := &fakeLeaseHarvesterClient{statusCode: http.StatusConflict, lease: harvester.Lease{InstanceId: "harvester-2", ExpiresAt: expiresAt}}
+		c := newLeaseTestClient(t, fake, "harvester-1")
+
+		lease, err := c.AcquireLease(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "harvester-2", lease.InstanceID)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		fake := &fakeLeaseHarvesterClient{statusCode: http.StatusInternalServerError}
+		c := newLeaseTestClient(t, fake, "harvester-1")
+
+		_, err := c.AcquireLease(context.Background())
+		assert.ErrorContains(t, err, "failed to acquire lease")
+	})
+
+	t.Run("instance ID not configured", func(t *testing.T) {
+		c := newLeaseTestClient(t, &fakeLeaseHarvesterClient{}, "")
+
+		_, err := c.AcquireLease(context.Background())
+		assert.EqualError(t, err, "instance ID is required to acquire the lease")
+	})
+}
+
+func TestReleaseLease(t *testing.T) {
+	fake := &fakeLeaseHarvesterClient{statusCode: http.StatusOK}
+	c := newLeaseTestClient(t, fake, "harvester-1")
+
+	err := c.ReleaseLease(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, fake.released)
+	assert.Equal(t, "harvester-1", fake.released.InstanceID)
+
+	fake.statusCode = http.StatusInternalServerError
+	err = c.ReleaseLease(context.Background())
+	assert.ErrorContains(t, err, "failed to release lease")
+}
+
+func TestCheckConnection(t *testing.T) {
+	fake := &fakeLeaseHarvesterClient{statusCode: http.StatusOK}
+	c := newLeaseTestClient(t, fake, "")
+	require.NoError(t, c.CheckConnection(context.Background()))
+
+	fake.statusCode = http.StatusServiceUnavailable
+	assert.EqualError(t, c.CheckConnection(context.Background()), "unexpected status code 503 from Galadriel Server")
+}
+
+func TestCheckToken(t *testing.T) {
+	newToken := func(expiresAt time.Time) string {
+		token, err := gojwt.NewWithClaims(gojwt.SigningMethodHS256, gojwt.RegisteredClaims{
+			Subject:   "example.org",
+			ExpiresAt: gojwt.NewNumericDate(expiresAt),
+		}).SignedString([]byte("key"))
+		require.NoError(t, err)
+		return token
+	}
+
+	c := newLeaseTestClient(t, &fakeLeaseHarvesterClient{}, "")
+	assert.ErrorIs(t, c.CheckToken(context.Background()), NotOnboardedErr)
+
+	c.jwtStore.setToken(newToken(time.Now().Add(time.Hour)))
+	require.NoError(t, c.CheckToken(context.Background()))
+
+	expiresAt := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	c.jwtStore.setToken(newToken(expiresAt))
+	assert.EqualError(t, c.CheckToken(context.Background()), "JWT token expired at "+expiresAt.Format(time.RFC3339))
+
+	c.jwtStore.setToken("not a token")
+	assert.ErrorContains(t, c.CheckToken(context.Background()), "failed to parse JWT token")
+}
+
+func TestPostBundleInstanceID(t *testing.T) {
+	bundle := &entity.Bundle{
+		TrustDomainName: spiffeid.RequireTrustDomainFromString("example.org"),
+		Data:            []byte("bundle"),
+	}
+
+	fake := &fakeLeaseHarvesterClient{statusCode: http.StatusOK}
+	c := newLeaseTestClient(t, fake, "")
+	require.NoError(t, c.PostBundle(context.Background(), bundle))
+	assert.Nil(t, fake.bundlePut.InstanceId)
+
+	c = newLeaseTestClient(t, fake, "harvester-1")
+	require.NoError(t, c.PostBundle(context.Background(), bundle))
+	require.NotNil(t, fake.bundlePut.InstanceId)
+	assert.Equal(t, "harvester-1", *fake.bundlePut.InstanceId)
+}
+
+type fakeSyncHarvesterClient struct {
+	harvester.ClientInterface
+
+	syncRequest  harvester.PostBundleSyncRequest
+	syncResponse harvester.PostBundleSyncResponse
+}
+
+func (c *fakeSyncHarvesterClient) BundleSync(ctx context.Context, trustDomainName string, body harvester.BundleSyncJSONRequestBody, reqEditors ...harvester.RequestEditorFn) (*http.Response, error) {
+	c.syncRequest = body
+	return jsonResponse(http.StatusOK, c.syncResponse)
+}
+
+func TestWatchBundles(t *testing.T) {
+	tdB := spiffeid.RequireTrustDomainFromString("b.org")
+	tdC := spiffeid.RequireTrustDomainFromString("c.org")
+
+	fake := &fakeSyncHarvesterClient{
+		syncResponse: harvester.PostBundleSyncResponse{
+			State: harvester.BundlesDigests{
+				tdB.String(): util.EncodeToString([]byte("digest-b")),
+				tdC.String(): util.EncodeToString([]byte("digest-c")),
+			},
+			Updates: harvester.BundlesUpdates{
+				tdC.String(): harvester.BundlesUpdatesItem{
+					TrustBundle: "bundle-c",
+					Digest:      util.EncodeToString([]byte("digest-c")),
+				},
+			},
+		},
+	}
+	logger, _ := test.NewNullLogger()
+	store, err := newJwtStore(t.TempDir(), tokenFile, logger)
+	require.NoError(t, err)
+	c := &client{
+		client:      fake,
+		trustDomain: spiffeid.RequireTrustDomainFromString("example.org"),
+		jwtStore:    store,
+		logger:      logger,
+	}
+
+	result, err := c.WatchBundles(context.Background(), map[spiffeid.TrustDomain][]byte{tdB: []byte("digest-b")}, 0, time.Minute)
+	require.NoError(t, err)
+
+	// the request carries the given state and the wait time
+	require.NotNil(t, fake.syncRequest.Wait)
+	assert.Equal(t, 60, *fake.syncRequest.Wait)
+	assert.Nil(t, fake.syncRequest.Cursor)
+	assert.Equal(t, harvester.BundlesDigests{tdB.String(): util.EncodeToString([]byte("digest-b"))}, fake.syncRequest.State)
+
+	require.Len(t, result.Bundles, 1)
+	assert.Equal(t, tdC, result.Bundles[0].TrustDomainName)
+	assert.Equal(t, map[spiffeid.TrustDomain][]byte{tdB: []byte("digest-b"), tdC: []byte("digest-c")}, result.Digests)
+	assert.False(t, result.Incremental)
+	assert.Zero(t, result.Cursor)
+
+	// with a cursor, the state is not sent, and the result holds only the changes since the cursor
+	cursor := int64(12)
+	incremental := true
+	removed := []string{tdB.String()}
+	fake.syncResponse = harvester.PostBundleSyncResponse{
+		State: harvester.BundlesDigests{
+			tdC.String(): util.EncodeToString([]byte("digest-c")),
+		},
+		Updates: harvester.BundlesUpdates{
+			tdC.String(): harvester.BundlesUpdatesItem{
+				TrustBundle: "bundle-c",
+				Digest:      util.EncodeToString([]byte("digest-c")),
+			},
+		},
+		Cursor:      &cursor,
+		Incremental: &incremental,
+		Removed:     &removed,
+	}
+
+	result, err = c.WatchBundles(context.Background(), map[spiffeid.TrustDomain][]byte{tdB: []byte("digest-b")}, 10, time.Minute)
+	require.NoError(t, err)
+
+	require.NotNil(t, fake.syncRequest.Cursor)
+	assert.Equal(t, int64(10), *fake.syncRequest.Cursor)
+	assert.Empty(t, fake.syncRequest.State)
+
+	assert.True(t, result.Incremental)
+	assert.Equal(t, int64(12), result.Cursor)
+	assert.Equal(t, []spiffeid.TrustDomain{tdB}, result.Removed)
+	assert.Equal(t, map[spiffeid.TrustDomain][]byte{tdC: []byte("digest-c")}, result.Digests)
+}
+
+type fakeConsentHarvesterClient struct {
+	harvester.ClientInterface
+
+	patchRequest harvester.PatchRelationshipRequest
+	consents     []*api.RelationshipConsent
+}
+
+func (c *fakeConsentHarvesterClient) PatchRelationship(ctx context.Context, trustDomainName string, relationshipID uuid.UUID, body harvester.PatchRelationshipJSONRequestBody, reqEditors ...harvester.RequestEditorFn) (*http.Response, error) {
+	c.patchRequest = body
+	tdName := trustDomainName
+	return jsonResponse(http.StatusOK, api.Relationship{Id: relationshipID, TrustDomainAName: &tdName, TrustDomainBName: &tdName})
+}
+
+func (c *fakeConsentHarvesterClient) GetRelationshipConsents(ctx context.Context, trustDomainName string, relationshipID uuid.UUID, reqEditors ...harvester.RequestEditorFn) (*http.Response, error) {
+	return jsonResponse(http.StatusOK, c.consents)
+}
+
+type fakeSigner struct{}
+
+func (fakeSigner) Sign(payload []byte) ([]byte, []*x509.Certificate, error) {
+	return append([]byte("signed:"), payload...), nil, nil
+}
+
+func TestUpdateRelationshipSignsConsent(t *testing.T) {
+	relationshipID := uuid.New()
+
+	fake := &fakeConsentHarvesterClient{}
+	c := newLeaseTestClient(t, &fakeLeaseHarvesterClient{}, "")
+	c.client = fake
+
+	_, err := c.UpdateRelationship(context.Background(), relationshipID, entity.ConsentStatusApproved)
+	require.NoError(t, err)
+	assert.Nil(t, fake.patchRequest.ConsentStatement)
+	assert.Nil(t, fake.patchRequest.Signature)
+
+	c.consentSigner = fakeSigner{}
+	_, err = c.UpdateRelationship(context.Background(), relationshipID, entity.ConsentStatusApproved)
+	require.NoError(t, err)
+	require.NotNil(t, fake.patchRequest.ConsentStatement)
+	require.NotNil(t, fake.patchRequest.Signature)
+
+	data, err := util.DecodeString(*fake.patchRequest.ConsentStatement)
+	require.NoError(t, err)
+	statement, err := entity.ParseConsentStatement(data)
+	require.NoError(t, err)
+	assert.Equal(t, relationshipID, statement.RelationshipID)
+	assert.Equal(t, "example.org", statement.TrustDomain)
+	assert.Equal(t, entity.ConsentStatusApproved, statement.ConsentStatus)
+	assert.WithinDuration(t, time.Now(), statement.SignedAt, time.Minute)
+
+	sig, err := util.DecodeString(*fake.patchRequest.Signature)
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte("signed:"), data...), sig)
+}
+
+type fakeOnboardHarvesterClient struct {
+	harvester.ClientInterface
+
+	statusCode          int
+	challengeStatusCode int
+	proofRequest        harvester.OnboardProofRequest
+}
+
+func (c *fakeOnboardHarvesterClient) CreateOnboardChallenge(ctx context.Context, trustDomainName string, reqEditors ...harvester.RequestEditorFn) (*http.Response, error) {
+	if c.challengeStatusCode != http.StatusOK {
+		return jsonResponse(c.challengeStatusCode, "trust domain not found")
+	}
+	return jsonResponse(c.challengeStatusCode, harvester.OnboardChallengeResponse{Nonce: "server-nonce", ExpiresAt: time.Now().Add(time.Minute)})
+}
+
+func (c *fakeOnboardHarvesterClient) OnboardWithProof(ctx context.Context, trustDomainName string, body harvester.OnboardWithProofJSONRequestBody, reqEditors ...harvester.RequestEditorFn) (*http.Response, error) {
+	c.proofRequest = body
+	if c.statusCode != http.StatusOK {
+		return jsonResponse(c.statusCode, "proof of trust domain control verification failed")
+	}
+	return jsonResponse(c.statusCode, harvester.OnboardHarvesterResponse{Token: "jwt-token", TrustDomainName: trustDomainName})
+}
+
+func TestOnboardWithProof(t *testing.T) {
+	fake := &fakeOnboardHarvesterClient{statusCode: http.StatusOK, challengeStatusCode: http.StatusOK}
+	c := newLeaseTestClient(t, &fakeLeaseHarvesterClient{}, "")
+	c.client = fake
+	c.consentSigner = fakeSigner{}
+
+	err := c.onboardWithProof(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "jwt-token", c.jwtStore.getToken())
+
+	data, err := util.DecodeString(fake.proofRequest.OnboardStatement)
+	require.NoError(t, err)
+	statement, err := entity.ParseOnboardStatement(data)
+	require.NoError(t, err)
+	assert.Equal(t, "example.org", statement.TrustDomain)
+	assert.Empty(t, statement.JoinToken)
+	assert.Equal(t, "server-nonce", statement.Nonce)
+	assert.WithinDuration(t, time.Now(), statement.SignedAt, time.Minute)
+
+	sig, err := util.DecodeString(fake.proofRequest.Signature)
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte("signed:"), data...), sig)
+
+	fake.statusCode = http.StatusForbidden
+	c = newLeaseTestClient(t, &fakeLeaseHarvesterClient{}, "")
+	c.client = fake
+	c.consentSigner = fakeSigner{}
+	err = c.onboardWithProof(context.Background())
+	assert.ErrorContains(t, err, "failed to onboard")
+	assert.Empty(t, c.jwtStore.getToken())
+
+	fake.challengeStatusCode = http.StatusBadRequest
+	err = c.onboardWithProof(context.Background())
+	assert.ErrorContains(t, err, "failed to get onboard challenge")
+	assert.Empty(t, c.jwtStore.getToken())
+}
+
+func TestGetRelationshipConsents(t *testing.T) {
+	consent := &entity.RelationshipConsent{
+		RelationshipID:   uuid.New(),
+		TrustDomainID:    uuid.New(),
+		ConsentStatement: []byte("statement"),
+		Signature:        []byte("signature"),
+	}
+
+	fake := &fakeConsentHarvesterClient{consents: api.MapRelationshipConsents(consent)}
+	c := newLeaseTestClient(t, &fakeLeaseHarvesterClient{}, "")
+	c.client = fake
+
+	consents, err := c.GetRelationshipConsents(context.Background(), consent.RelationshipID)
+	require.NoError(t, err)
+	require.Len(t, consents, 1)
+	assert.Equal(t, consent, consents[0])
+}
+
+func TestJWTTokenReqEditor(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store, err := newJwtStore(t.TempDir(), tokenFile, logger)
+	require.NoError(t, err)
+	editor := createJWTTokenReqEditor(store)
+
+	// the clients authenticating with a client certificate hold no token
+	req, err := http.NewRequest(http.MethodGet, "https://localhost/trust-domain/example.org/relationships", nil)
+	require.NoError(t, err)
+	require.NoError(t, editor(context.Background(), req))
+	assert.Empty(t, req.Header.Get("Authorization"))
+
+	store.setToken("token")
+	require.NoError(t, editor(context.Background(), req))
+	assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+}
@@ -0,0 +1,101 @@
+package galadrielclient
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+	"github.com/jmhodges/clock"
+)
+
+// jwksRefreshInterval is the minimum time between two JWKS fetches triggered by an unknown key ID.
+const jwksRefreshInterval = 30 * time.Second
+
+// jwksKeySet caches the public keys published in the Galadriel Server JWKS.
+// It is used to validate the JWT access tokens issued to the Harvester locally.
+type jwksKeySet struct {
+	client harvester.ClientInterface
+	clk    clock.Clock
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	lastRefresh time.Time
+}
+
+func newJWKSKeySet(client harvester.ClientInterface) *jwksKeySet {
+	return &jwksKeySet{
+		client: client,
+		clk:    clock.New(),
+		keys:   make(map[string]crypto.PublicKey),
+	}
+}
+
+// getPublicKey returns the public key with the given key ID. If the key is not cached,
+// the JWKS is fetched again from the Galadriel Server, at most once per jwksRefreshInterval.
+func (k *jwksKeySet) getPublicKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if key, ok := k.keys[kid]; ok {
+		return key, nil
+	}
+
+	if !k.lastRefresh.IsZero() && k.clk.Now().Before(k.lastRefresh.Add(jwksRefreshInterval)) {
+		return nil, fmt.Errorf("no such key %q", kid)
+	}
+
+	if err := k.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("no such key %q", kid)
+	}
+
+	return key, nil
+}
+
+// refresh fetches the JWKS from the Galadriel Server and replaces the cached keys.
+// The caller must hold the lock.
+func (k *jwksKeySet) refresh(ctx context.Context) error {
+	k.lastRefresh = k.clk.Now()
+
+	resp, err := k.client.GetJWKS(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get JWKS: %s", string(body))
+	}
+
+	jwks := &harvester.JWKS{}
+	if err := json.Unmarshal(body, jwks); err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		publicKey, err := jwk.PublicKey()
+		if err != nil {
+			return fmt.Errorf("failed to parse JWKS: %w", err)
+		}
+		keys[jwk.Kid] = publicKey
+	}
+	k.keys = keys
+
+	return nil
+}
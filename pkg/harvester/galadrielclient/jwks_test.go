@@ -0,0 +1,85 @@
+package galadrielclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+	"github.com/jmhodges/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeHarvesterClient struct {
+	harvester.ClientInterface
+
+	jwks       harvester.JWKS
+	statusCode int
+	calls      int
+}
+
+func (c *fakeHarvesterClient) GetJWKS(ctx context.Context, reqEditors ...harvester.RequestEditorFn) (*http.Response, error) {
+	c.calls++
+
+	body, err := json.Marshal(c.jwks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		StatusCode: c.statusCode,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}, nil
+}
+
+func TestJWKSKeySetGetPublicKey(t *testing.T) {
+	ctx := context.Background()
+
+	signer, err := cryptoutil.GenerateSigner(cryptoutil.RSA2048)
+	require.NoError(t, err)
+	jwk, err := harvester.JWKFromPublicKey("key-1", signer.Public())
+	require.NoError(t, err)
+
+	fakeClient := &fakeHarvesterClient{statusCode: http.StatusOK}
+	clk := clock.NewFake()
+	keySet := newJWKSKeySet(fakeClient)
+	keySet.clk = clk
+
+	// the key is not published yet
+	_, err = keySet.getPublicKey(ctx, "key-1")
+	require.EqualError(t, err, `no such key "key-1"`)
+	assert.Equal(t, 1, fakeClient.calls)
+
+	// the JWKS is not fetched again before the refresh interval
+	fakeClient.jwks = harvester.JWKS{Keys: []harvester.JWK{*jwk}}
+	_, err = keySet.getPublicKey(ctx, "key-1")
+	require.EqualError(t, err, `no such key "key-1"`)
+	assert.Equal(t, 1, fakeClient.calls)
+
+	clk.Add(jwksRefreshInterval)
+	publicKey, err := keySet.getPublicKey(ctx, "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, signer.Public(), publicKey)
+	assert.Equal(t, 2, fakeClient.calls)
+
+	// cached keys do not trigger a fetch
+	clk.Add(time.Hour)
+	_, err = keySet.getPublicKey(ctx, "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, fakeClient.calls)
+}
+
+func TestJWKSKeySetRefreshError(t *testing.T) {
+	fakeClient := &fakeHarvesterClient{statusCode: http.StatusInternalServerError}
+	keySet := newJWKSKeySet(fakeClient)
+
+	_, err := keySet.getPublicKey(context.Background(), "key-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get JWKS")
+}
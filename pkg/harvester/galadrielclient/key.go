@@ -0,0 +1,104 @@
+package galadrielclient
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/diskutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+)
+
+// harvesterKeyFile is the name of the file in the data dir holding the Harvester key.
+const harvesterKeyFile = "harvester-key"
+
+// HarvesterKeyPath returns the path of the file holding the key of the Harvester instance.
+// Like the JWT access token, the key of an instance with an ID is kept in a file of its own,
+// so that the instances can share the data dir.
+func HarvesterKeyPath(dataDir, instanceID string) string {
+	fileName := harvesterKeyFile
+	if instanceID != "" {
+		fileName = fmt.Sprintf("%s-%s", harvesterKeyFile, instanceID)
+	}
+
+	return filepath.Join(dataDir, fileName)
+}
+
+// LoadOrCreateHarvesterKey loads the key of the Harvester instance from the data dir,
+// generating and storing a new EC P-256 key the first time.
+// The key identifies the Harvester to the Galadriel Server, e.g. to use the join tokens bound to its fingerprint.
+func LoadOrCreateHarvesterKey(dataDir, instanceID string) (crypto.Signer, error) {
+	path := HarvesterKeyPath(dataDir, instanceID)
+
+	key, err := cryptoutil.LoadPrivateKey(path)
+	switch {
+	case err == nil:
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("harvester key %s cannot sign", path)
+		}
+		return signer, nil
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("failed to load harvester key: %w", err)
+	}
+
+	signer, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate harvester key: %w", err)
+	}
+
+	keyPEM, err := cryptoutil.EncodeECPrivateKey(signer.(*ecdsa.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := diskutil.AtomicWritePrivateFile(path, keyPEM); err != nil {
+		return nil, fmt.Errorf("failed to store harvester key: %w", err)
+	}
+
+	return signer, nil
+}
+
+// signOnboardParams sets the public key of the Harvester and its signature of the onboard statement
+// in the onboard parameters, so that the Galadriel Server can check that the Harvester holds the key
+// a join token is bound to.
+func signOnboardParams(params *harvester.OnboardParams, key crypto.Signer, trustDomain string, now time.Time) error {
+	signedAt := now.UTC().Truncate(time.Second)
+	statement := &entity.OnboardStatement{
+		TrustDomain: trustDomain,
+		JoinToken:   params.JoinToken,
+		SignedAt:    signedAt,
+	}
+
+	payload, err := statement.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal onboard statement: %w", err)
+	}
+
+	signature, err := key.Sign(rand.Reader, cryptoutil.CalculateDigest(payload), crypto.SHA256)
+	if err != nil {
+		return fmt.Errorf("failed to sign onboard statement: %w", err)
+	}
+
+	publicKey, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return fmt.Errorf("failed to marshal harvester public key: %w", err)
+	}
+
+	encodedKey := encoding.EncodeToBase64(publicKey)
+	encodedSignature := encoding.EncodeToBase64(signature)
+	params.HarvesterPublicKey = &encodedKey
+	params.SignedAt = &signedAt
+	params.Signature = &encodedSignature
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package galadrielclient
+
+import (
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOrCreateHarvesterKey(t *testing.T) {
+	dataDir := t.TempDir()
+
+	key, err := LoadOrCreateHarvesterKey(dataDir, "")
+	require.NoError(t, err)
+
+	info, err := os.Stat(filepath.Join(dataDir, "harvester-key"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// the stored key is loaded again
+	loaded, err := LoadOrCreateHarvesterKey(dataDir, "")
+	require.NoError(t, err)
+	assert.Equal(t, key.Public(), loaded.Public())
+
+	// each instance has a key of its own
+	instanceKey, err := LoadOrCreateHarvesterKey(dataDir, "instance-1")
+	require.NoError(t, err)
+	assert.NotEqual(t, key.Public(), instanceKey.Public())
+	assert.FileExists(t, filepath.Join(dataDir, "harvester-key-instance-1"))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "harvester-key"), []byte("not a key"), 0600))
+	_, err = LoadOrCreateHarvesterKey(dataDir, "")
+	assert.ErrorContains(t, err, "failed to load harvester key")
+}
+
+func TestSignOnboardParams(t *testing.T) {
+	key, err := LoadOrCreateHarvesterKey(t.TempDir(), "")
+	require.NoError(t, err)
+
+	now := time.Date(2023, 6, 1, 12, 0, 0, 500, time.UTC)
+	params := harvester.OnboardParams{JoinToken: "join-token"}
+	require.NoError(t, signOnboardParams(&params, key, "td1.org", now))
+
+	require.NotNil(t, params.SignedAt)
+	assert.Equal(t, now.Truncate(time.Second), *params.SignedAt)
+
+	der, err := encoding.DecodeFromBase64(*params.HarvesterPublicKey)
+	require.NoError(t, err)
+	publicKey, err := x509.ParsePKIXPublicKey(der)
+	require.NoError(t, err)
+	assert.Equal(t, key.Public(), publicKey)
+
+	statement := &entity.OnboardStatement{TrustDomain: "td1.org", JoinToken: "join-token", SignedAt: *params.SignedAt}
+	payload, err := statement.Marshal()
+	require.NoError(t, err)
+	signature, err := encoding.DecodeFromBase64(*params.Signature)
+	require.NoError(t, err)
+	assert.NoError(t, cryptoutil.VerifySignature(publicKey, payload, signature))
+}
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"path/filepath"
 	"time"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/health"
 	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/common/util"
 	"github.com/HewlettPackard/galadriel/pkg/common/util/fileutil"
@@ -19,6 +21,24 @@ import (
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
 
+const (
+	// serviceName names the service in the exported spans.
+	serviceName = "galadriel-harvester"
+
+	// names of the readiness checks
+	spireServerCheck          = "spire_server"
+	galadrielServerCheck      = "galadriel_server"
+	jwtTokenCheck             = "jwt_token"
+	federatedBundlesSyncCheck = "federated_bundles_sync"
+
+	// quarantineDirName is the directory of the data dir where the rejected federated bundles are kept.
+	quarantineDirName = "quarantine"
+
+	// federatesWithStateFileName is the file of the data dir where the federated trust domains added to the
+	// registration entries are kept.
+	federatesWithStateFileName = "federates-with.json"
+)
+
 // Harvester represents the Harvester agent.
 // It starts the bundle manager and the endpoints.
 type Harvester struct {
@@ -34,11 +54,33 @@ type Config struct {
 	JoinToken                    string
 	BundleUpdatesInterval        time.Duration
 	FederatedBundlesPollInterval time.Duration
+	FederatedBundlesWatchTimeout time.Duration // how long to wait for the Galadriel Server to push changes, zero disables it
 	SpireBundlePollInterval      time.Duration
 	ServerTrustBundlePath        string
+	ValidateServerJWT            bool // validate the JWT tokens issued by the Galadriel Server using its JWKS
+	ClientCertificateAuth        bool // authenticate with a client certificate issued by the Galadriel Server instead of a JWT token
 	DataDir                      string
 	Logger                       logrus.FieldLogger
 	ProvidersConfig              *catalog.ProvidersConfig
+	// FederationRelationships is optional, when set, the Harvester manages the federation relationships in the SPIRE Server
+	FederationRelationships *bundlemanager.FederationRelationshipsConfig
+	// FederatesWith is optional, when set, the Harvester updates the federated trust domains of registration entries
+	FederatesWith *bundlemanager.FederatesWithConfig
+	// BundleContinuity is optional, when set, the federated bundles that do not continue the bundles trusted by
+	// the SPIRE Server are skipped, unless an admin approved a reset through the admin API
+	BundleContinuity bool
+	// QuarantineMaxBundles is optional, the number of rejected federated bundles kept per trust domain
+	QuarantineMaxBundles int
+	// InstanceID is optional, when set, several Harvester instances can serve the trust domain,
+	// only the instance holding the lease granted by the Galadriel Server uploads the SPIRE bundle
+	InstanceID string
+	// Metrics is optional, when set, the metrics are served on the configured address
+	Metrics *telemetry.MetricsConfig
+	// Tracing is optional, when set, the spans are exported with the configured exporter
+	Tracing *telemetry.TracingConfig
+	// HealthChecks is optional, when set, the health checks are also served on the configured address,
+	// they are served on the UDS regardless
+	HealthChecks *health.Config
 }
 
 func New(cfg *Config) *Harvester {
@@ -56,9 +98,20 @@ func New(cfg *Config) *Harvester {
 // - Creates a SPIRE client using the provided SPIRE address.
 // - Creates, configures and run the Harvester endpoints.
 // - Creates and runs the BundleManager responsible for bundles synchronization.
+// - Serves the metrics and the health checks, if enabled. The readiness checks cover the SPIRE Server socket,
+// the connection to the Galadriel Server, the JWT access token and the last successful sync of the federated bundles.
+// - Exports the spans of the requests, if the tracing is enabled.
 func (h *Harvester) Run(ctx context.Context) error {
 	h.c.Logger.Info("Starting Harvester")
 
+	if h.c.Tracing != nil {
+		shutdownTracing, err := telemetry.InitTracing(ctx, h.c.Tracing, serviceName)
+		if err != nil {
+			return fmt.Errorf("failed to initialize tracing: %w", err)
+		}
+		defer telemetry.ShutdownTracing(shutdownTracing, h.c.Logger)
+	}
+
 	cat := catalog.New()
 	err := cat.LoadFromProvidersConfig(h.c.ProvidersConfig)
 	if err != nil {
@@ -70,12 +123,22 @@ func (h *Harvester) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to create data directory: %w", err)
 	}
 
+	harvesterKey, err := galadrielclient.LoadOrCreateHarvesterKey(h.c.DataDir, h.c.InstanceID)
+	if err != nil {
+		return err
+	}
+
 	galadrielClient, err := galadrielclient.NewClient(ctx, &galadrielclient.Config{
 		TrustDomain:            h.c.TrustDomain,
 		GaladrielServerAddress: h.c.GaladrielServerAddress,
 		TrustBundlePath:        h.c.ServerTrustBundlePath,
 		DataDir:                h.c.DataDir,
 		JoinToken:              h.c.JoinToken,
+		ValidateJWT:            h.c.ValidateServerJWT,
+		InstanceID:             h.c.InstanceID,
+		ConsentSigner:          cat.GetBundleSigner(),
+		HarvesterKey:           harvesterKey,
+		ClientCertificateAuth:  h.c.ClientCertificateAuth,
 		Logger:                 h.c.Logger.WithField(telemetry.SubsystemName, telemetry.Harvester),
 	})
 	if err != nil {
@@ -88,29 +151,67 @@ func (h *Harvester) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to create SPIRE client: %w", err)
 	}
 
-	ep, err := endpoints.New(&endpoints.Config{
-		LocalAddress: h.c.HarvesterSocketPath,
-		Client:       galadrielClient,
-		Logger:       h.c.Logger.WithField(telemetry.SubsystemName, telemetry.Endpoints),
+	healthChecker := health.NewChecker()
+	healthChecker.AddCheck(spireServerCheck, func(ctx context.Context) error {
+		if _, err := spireClient.GetBundle(ctx); err != nil {
+			return fmt.Errorf("failed to reach SPIRE Server: %w", err)
+		}
+		return nil
 	})
-	if err != nil {
-		return fmt.Errorf("failed to create Harvester endpoints: %w", err)
-	}
+	healthChecker.AddCheck(galadrielServerCheck, galadrielClient.CheckConnection)
+	healthChecker.AddCheck(jwtTokenCheck, galadrielClient.CheckToken)
 
-	bundleManager := bundlemanager.NewBundleManager(&bundlemanager.Config{
+	bundleManager, err := bundlemanager.NewBundleManager(&bundlemanager.Config{
+		TrustDomain:                  h.c.TrustDomain,
 		SpireClient:                  spireClient,
 		GaladrielClient:              galadrielClient,
 		FederatedBundlesPollInterval: h.c.FederatedBundlesPollInterval,
+		FederatedBundlesWatchTimeout: h.c.FederatedBundlesWatchTimeout,
 		SpireBundlePollInterval:      h.c.SpireBundlePollInterval,
 		BundleSigner:                 cat.GetBundleSigner(),
 		BundleVerifiers:              cat.GetBundleVerifiers(),
+		FederationRelationships:      h.c.FederationRelationships,
+		FederatesWith:                h.c.FederatesWith,
+		BundleContinuity:             h.c.BundleContinuity,
+		FederatesWithStatePath:       filepath.Join(h.c.DataDir, federatesWithStateFileName),
+		QuarantineDir:                filepath.Join(h.c.DataDir, quarantineDirName),
+		QuarantineMaxBundles:         h.c.QuarantineMaxBundles,
+		InstanceID:                   h.c.InstanceID,
 		Logger:                       h.c.Logger,
 	})
+	if err != nil {
+		return fmt.Errorf("failed to create bundle manager: %w", err)
+	}
+
+	ep, err := endpoints.New(&endpoints.Config{
+		LocalAddress:        h.c.HarvesterSocketPath,
+		Client:              galadrielClient,
+		Logger:              h.c.Logger.WithField(telemetry.SubsystemName, telemetry.Endpoints),
+		HealthChecker:       healthChecker,
+		BundleVerifiers:     cat.GetBundleVerifiers(),
+		BundleResetApprover: bundleManager,
+		BundleQuarantine:    bundleManager,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create Harvester endpoints: %w", err)
+	}
+
+	healthChecker.AddCheck(federatedBundlesSyncCheck, bundleManager.CheckFederatedBundlesSync)
 
 	tasks := []func(ctx context.Context) error{
 		ep.ListenAndServe,
 		bundleManager.Run,
 	}
+	if h.c.Metrics != nil {
+		tasks = append(tasks, func(ctx context.Context) error {
+			return telemetry.ServeMetrics(ctx, h.c.Metrics, h.c.Logger.WithField(telemetry.SubsystemName, telemetry.Metrics))
+		})
+	}
+	if h.c.HealthChecks != nil {
+		tasks = append(tasks, func(ctx context.Context) error {
+			return health.Serve(ctx, h.c.HealthChecks, healthChecker, h.c.Logger.WithField(telemetry.SubsystemName, telemetry.HealthChecks))
+		})
+	}
 
 	err = util.RunTasks(ctx, tasks...)
 	if errors.Is(err, context.Canceled) {
@@ -0,0 +1,76 @@
+package integrity
+
+import (
+	"bytes"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+)
+
+// ErrBundleDiscontinuity is returned when a bundle does not continue the previous bundle of its trust domain.
+var ErrBundleDiscontinuity = errors.New("bundle keeps no X.509 authority of the previous bundle and is not signed by a key chaining to it")
+
+// CheckContinuity checks that the bundle in the payload continues the previous accepted bundle of the same trust
+// domain, that is, it keeps at least one X.509 authority of the previous bundle, or its signature was made with the
+// key of a certificate that chains to one of those authorities at the given time.
+// A bundle always continues a nil previous bundle, or a previous bundle without X.509 authorities.
+func CheckContinuity(previous *spiffebundle.Bundle, payload, signature []byte, certChain []*x509.Certificate, now time.Time) error {
+	if previous == nil || len(previous.X509Authorities()) == 0 {
+		return nil
+	}
+
+	next, err := spiffebundle.Parse(previous.TrustDomain(), payload)
+	if err != nil {
+		return fmt.Errorf("failed to parse bundle: %w", err)
+	}
+
+	for _, authority := range next.X509Authorities() {
+		if containsCertificate(previous.X509Authorities(), authority) {
+			return nil
+		}
+	}
+
+	if len(certChain) > 0 && certChain[0] != nil && isSignedByPreviousAuthorities(previous, payload, signature, certChain, now) {
+		return nil
+	}
+
+	return ErrBundleDiscontinuity
+}
+
+func isSignedByPreviousAuthorities(previous *spiffebundle.Bundle, payload, signature []byte, certChain []*x509.Certificate, now time.Time) bool {
+	roots := x509.NewCertPool()
+	for _, authority := range previous.X509Authorities() {
+		roots.AddCert(authority)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certChain[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := certChain[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return false
+	}
+
+	// the certificates of the chain are public, so the signature must be made with the key of the signing certificate
+	return verifyWithPublicKey(certChain[0].PublicKey, payload, signature) == nil
+}
+
+func containsCertificate(certs []*x509.Certificate, cert *x509.Certificate) bool {
+	for _, c := range certs {
+		if bytes.Equal(c.Raw, cert.Raw) {
+			return true
+		}
+	}
+
+	return false
+}
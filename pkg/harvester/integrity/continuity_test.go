@@ -0,0 +1,105 @@
+package integrity
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"testing"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckContinuity(t *testing.T) {
+	oldCA, oldCAKey := createSVIDCA(t)
+	newCA, newCAKey := createSVIDCA(t)
+	otherCA, otherCAKey := createSVIDCA(t)
+
+	previous := spiffebundle.FromX509Authorities(svidTD, []*x509.Certificate{oldCA})
+	rotated := marshalTestBundle(t, oldCA, newCA)
+	replaced := marshalTestBundle(t, newCA)
+
+	signedBy := func(payload []byte, key crypto.Signer) []byte {
+		signature, err := key.Sign(rand.Reader, cryptoutil.CalculateDigest(payload), crypto.SHA256)
+		require.NoError(t, err)
+		return signature
+	}
+
+	oldSVID := createX509SVID(t, oldCA, oldCAKey, "spiffe://example.org/harvester")
+	newSVID := createX509SVID(t, newCA, newCAKey, "spiffe://example.org/harvester")
+	otherSVID := createX509SVID(t, otherCA, otherCAKey, "spiffe://example.org/harvester")
+
+	for _, tt := range []struct {
+		name        string
+		previous    *spiffebundle.Bundle
+		payload     []byte
+		signature   []byte
+		chain       []*x509.Certificate
+		expectedErr error
+	}{
+		{
+			name:    "no previous bundle",
+			payload: replaced,
+		},
+		{
+			name:     "previous bundle without X.509 authorities",
+			previous: spiffebundle.New(svidTD),
+			payload:  replaced,
+		},
+		{
+			name:     "bundle keeps an authority of the previous bundle",
+			previous: previous,
+			payload:  rotated,
+		},
+		{
+			name:      "bundle signed by a key chaining to the previous bundle",
+			previous:  previous,
+			payload:   replaced,
+			signature: signedBy(replaced, oldSVID.PrivateKey),
+			chain:     oldSVID.Certificates,
+		},
+		{
+			name:        "unrelated bundle",
+			previous:    previous,
+			payload:     replaced,
+			expectedErr: ErrBundleDiscontinuity,
+		},
+		{
+			name:        "unrelated bundle signed by a key chaining to itself",
+			previous:    previous,
+			payload:     replaced,
+			signature:   signedBy(replaced, newSVID.PrivateKey),
+			chain:       newSVID.Certificates,
+			expectedErr: ErrBundleDiscontinuity,
+		},
+		{
+			name:        "unrelated bundle with a chain of the previous bundle but signed by another key",
+			previous:    previous,
+			payload:     replaced,
+			signature:   signedBy(replaced, otherSVID.PrivateKey),
+			chain:       oldSVID.Certificates,
+			expectedErr: ErrBundleDiscontinuity,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckContinuity(tt.previous, tt.payload, tt.signature, tt.chain, clk.Now())
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	err := CheckContinuity(previous, []byte("not a bundle"), nil, nil, clk.Now())
+	assert.ErrorContains(t, err, "failed to parse bundle")
+}
+
+func marshalTestBundle(t *testing.T, authorities ...*x509.Certificate) []byte {
+	data, err := spiffebundle.FromX509Authorities(svidTD, authorities).Marshal()
+	require.NoError(t, err)
+
+	return data
+}
@@ -1,6 +1,11 @@
 package integrity
 
-import "crypto/x509"
+import (
+	"crypto/x509"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+)
 
 // Signer is an interface for signing payloads.
 type Signer interface {
@@ -14,3 +19,23 @@ type Verifier interface {
 	// Verify checks if the signature of the given payload matches the expected signature, using optionally a provided certificate chain for verification.
 	Verify(payload, signature []byte, certChain []*x509.Certificate) error
 }
+
+// TrustDomainVerifier is implemented by verifiers that need to know which trust domain signed a payload,
+// and the bundle of that trust domain trusted before the payload, to verify the signature.
+type TrustDomainVerifier interface {
+	Verifier
+
+	// VerifyTrustDomainSignature checks the signature of a payload signed by the given trust domain. The trusted bundle
+	// is the last bundle of the trust domain that was accepted, it is nil when there is none.
+	VerifyTrustDomainSignature(td spiffeid.TrustDomain, trustedBundle *spiffebundle.Bundle, payload, signature []byte, certChain []*x509.Certificate) error
+}
+
+// VerifyForTrustDomain verifies the signature of a payload signed by the given trust domain. The trust domain and its
+// trusted bundle are passed to the verifier when it is a TrustDomainVerifier, other verifiers ignore them.
+func VerifyForTrustDomain(verifier Verifier, td spiffeid.TrustDomain, trustedBundle *spiffebundle.Bundle, payload, signature []byte, certChain []*x509.Certificate) error {
+	if tdVerifier, ok := verifier.(TrustDomainVerifier); ok {
+		return tdVerifier.VerifyTrustDomainSignature(td, trustedBundle, payload, signature, certChain)
+	}
+
+	return verifier.Verify(payload, signature, certChain)
+}
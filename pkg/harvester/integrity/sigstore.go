@@ -0,0 +1,528 @@
+package integrity
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// SigstorePasswordEnvVar is the environment variable holding the password of an encrypted cosign private key.
+	// It is the same variable read by the cosign CLI.
+	SigstorePasswordEnvVar = "COSIGN_PASSWORD"
+
+	sigstoreKeyPEMType       = "ENCRYPTED SIGSTORE PRIVATE KEY"
+	cosignKeyPEMType         = "ENCRYPTED COSIGN PRIVATE KEY"
+	rekorEntriesPath         = "/api/v1/log/entries"
+	rekorHashedRekordKind    = "hashedrekord"
+	rekorHashedRekordVersion = "0.0.1"
+	rekorRequestTimeout      = 30 * time.Second
+)
+
+// SigstoreSigner implements the Signer interface producing cosign-compatible signature bundles, as written by
+// `cosign sign-blob --bundle`, using a key pair generated by `cosign generate-key-pair` or a plain PEM private key.
+// When a Rekor URL is configured, each signature is recorded in that transparency log and the returned bundle
+// carries the signed entry timestamp, otherwise the bundle holds only the signature.
+// The returned signing certificate chain is always empty, as signing is key-based.
+type SigstoreSigner struct {
+	privateKey   crypto.Signer
+	publicKeyPEM []byte
+
+	rekorURL   string
+	httpClient *http.Client
+}
+
+// SigstoreVerifier implements the Verifier interface for cosign-compatible signature bundles.
+// Signatures are checked against a public key on disk. When a Rekor public key is configured, bundles must
+// also carry a transparency log entry whose signed entry timestamp is verified offline against that key.
+type SigstoreVerifier struct {
+	publicKey      crypto.PublicKey
+	rekorPublicKey crypto.PublicKey
+	rekorLogID     string
+}
+
+// SigstoreSignerConfig is a configuration struct for creating a new SigstoreSigner
+type SigstoreSignerConfig struct {
+	PrivateKeyPath string `hcl:"private_key_path"`
+	RekorURL       string `hcl:"rekor_url,optional"`
+}
+
+// SigstoreVerifierConfig is a configuration struct for creating a new SigstoreVerifier
+type SigstoreVerifierConfig struct {
+	PublicKeyPath      string `hcl:"public_key_path"`
+	RekorPublicKeyPath string `hcl:"rekor_public_key_path,optional"`
+}
+
+// sigstoreBundle is the JSON document written by `cosign sign-blob --bundle`.
+type sigstoreBundle struct {
+	Base64Signature string       `json:"base64Signature"`
+	Cert            string       `json:"cert,omitempty"`
+	RekorBundle     *rekorBundle `json:"rekorBundle,omitempty"`
+}
+
+type rekorBundle struct {
+	SignedEntryTimestamp []byte       `json:"SignedEntryTimestamp"`
+	Payload              rekorPayload `json:"Payload"`
+}
+
+// rekorPayload is the part of a log entry covered by the signed entry timestamp.
+// Its fields are declared in lexicographic order, so that its JSON encoding is canonical.
+type rekorPayload struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+}
+
+type rekorLogEntry struct {
+	rekorPayload
+	Verification struct {
+		SignedEntryTimestamp []byte `json:"signedEntryTimestamp"`
+	} `json:"verification"`
+}
+
+type hashedRekord struct {
+	APIVersion string           `json:"apiVersion"`
+	Kind       string           `json:"kind"`
+	Spec       hashedRekordSpec `json:"spec"`
+}
+
+type hashedRekordSpec struct {
+	Data struct {
+		Hash struct {
+			Algorithm string `json:"algorithm"`
+			Value     string `json:"value"`
+		} `json:"hash"`
+	} `json:"data"`
+	Signature struct {
+		Content   string `json:"content"`
+		PublicKey struct {
+			Content string `json:"content"`
+		} `json:"publicKey"`
+	} `json:"signature"`
+}
+
+// encryptedKey is the JSON document wrapped in an encrypted cosign private key PEM block.
+type encryptedKey struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce []byte `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// NewSigstoreSigner creates a new SigstoreSigner instance without any configuration.
+func NewSigstoreSigner() *SigstoreSigner {
+	return &SigstoreSigner{}
+}
+
+// Configure sets up the SigstoreSigner with the provided configuration.
+// An encrypted cosign private key is decrypted with the password found in the COSIGN_PASSWORD environment variable.
+func (s *SigstoreSigner) Configure(config *SigstoreSignerConfig) error {
+	if config == nil {
+		return errors.New(constants.ErrConfigRequired)
+	}
+
+	if config.PrivateKeyPath == "" {
+		return errors.New(constants.ErrPrivateKeyPathRequired)
+	}
+
+	privateKey, err := loadSigstorePrivateKey(config.PrivateKeyPath, os.Getenv(SigstorePasswordEnvVar))
+	if err != nil {
+		return fmt.Errorf("failed to load private key: %w", err)
+	}
+
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	if err != nil {
+		return fmt.Errorf("failed to marshal public key: %w", err)
+	}
+
+	s.privateKey = privateKey
+	s.publicKeyPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDER})
+	s.rekorURL = strings.TrimSuffix(config.RekorURL, "/")
+	s.httpClient = &http.Client{Timeout: rekorRequestTimeout}
+
+	return nil
+}
+
+// Sign signs the SHA-256 digest of the payload and returns the JSON signature bundle.
+// The returned certificate chain is nil.
+func (s *SigstoreSigner) Sign(payload []byte) ([]byte, []*x509.Certificate, error) {
+	if s.privateKey == nil {
+		return nil, nil, errors.New("sigstore signer is not configured")
+	}
+
+	signature, err := s.privateKey.Sign(rand.Reader, cryptoutil.CalculateDigest(payload), crypto.SHA256)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign payload: %w", err)
+	}
+
+	bundle := sigstoreBundle{
+		Base64Signature: base64.StdEncoding.EncodeToString(signature),
+	}
+
+	if s.rekorURL != "" {
+		bundle.RekorBundle, err = s.uploadToRekor(payload, signature)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to record signature in transparency log: %w", err)
+		}
+	}
+
+	bundleBytes, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal signature bundle: %w", err)
+	}
+
+	return bundleBytes, nil, nil
+}
+
+func (s *SigstoreSigner) uploadToRekor(payload, signature []byte) (*rekorBundle, error) {
+	record := newHashedRekord(payload, signature, s.publicKeyPEM)
+	body, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal log entry: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), rekorRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.rekorURL+rekorEntriesPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var entries map[string]rekorLogEntry
+	if err := json.Unmarshal(respBody, &entries); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal log entry: %w", err)
+	}
+
+	if len(entries) != 1 {
+		return nil, fmt.Errorf("expected one log entry, got %d", len(entries))
+	}
+
+	var entry rekorLogEntry
+	for _, e := range entries {
+		entry = e
+	}
+
+	if len(entry.Verification.SignedEntryTimestamp) == 0 {
+		return nil, errors.New("log entry has no signed entry timestamp")
+	}
+
+	return &rekorBundle{
+		SignedEntryTimestamp: entry.Verification.SignedEntryTimestamp,
+		Payload:              entry.rekorPayload,
+	}, nil
+}
+
+// NewSigstoreVerifier creates a new SigstoreVerifier instance without any configuration.
+func NewSigstoreVerifier() *SigstoreVerifier {
+	return &SigstoreVerifier{}
+}
+
+// Configure sets up the SigstoreVerifier with the provided configuration.
+func (v *SigstoreVerifier) Configure(config *SigstoreVerifierConfig) error {
+	if config == nil {
+		return errors.New(constants.ErrConfigRequired)
+	}
+
+	if config.PublicKeyPath == "" {
+		return errors.New(constants.ErrPublicKeyRequired)
+	}
+
+	publicKey, err := loadPublicKey(config.PublicKeyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load public key: %w", err)
+	}
+	v.publicKey = publicKey
+
+	if config.RekorPublicKeyPath == "" {
+		return nil
+	}
+
+	rekorPublicKey, err := loadPublicKey(config.RekorPublicKeyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load transparency log public key: %w", err)
+	}
+
+	logID, err := rekorLogID(rekorPublicKey)
+	if err != nil {
+		return err
+	}
+
+	v.rekorPublicKey = rekorPublicKey
+	v.rekorLogID = logID
+
+	return nil
+}
+
+// Verify checks that the signature bundle holds a valid signature of the payload made with the configured key.
+// When a transparency log public key is configured, the bundle must also carry a log entry for that signature,
+// signed by the transparency log. The certificate chain is ignored.
+func (v *SigstoreVerifier) Verify(payload, signature []byte, _ []*x509.Certificate) error {
+	if v.publicKey == nil {
+		return errors.New("sigstore verifier is not configured")
+	}
+
+	var bundle sigstoreBundle
+	if err := json.Unmarshal(signature, &bundle); err != nil {
+		return fmt.Errorf("failed to parse signature bundle: %w", err)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(bundle.Base64Signature)
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	if err := verifyWithPublicKey(v.publicKey, payload, sig); err != nil {
+		return err
+	}
+
+	if v.rekorPublicKey == nil {
+		return nil
+	}
+
+	if bundle.RekorBundle == nil {
+		return errors.New("signature bundle has no transparency log entry")
+	}
+
+	return v.verifyRekorBundle(bundle.RekorBundle, payload, bundle.Base64Signature)
+}
+
+func (v *SigstoreVerifier) verifyRekorBundle(rb *rekorBundle, payload []byte, base64Signature string) error {
+	if rb.Payload.LogID != v.rekorLogID {
+		return fmt.Errorf("transparency log entry was not issued by the configured log: log ID %q", rb.Payload.LogID)
+	}
+
+	canonicalPayload, err := json.Marshal(rb.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal transparency log entry: %w", err)
+	}
+
+	if err := verifyWithPublicKey(v.rekorPublicKey, canonicalPayload, rb.SignedEntryTimestamp); err != nil {
+		return fmt.Errorf("failed to verify signed entry timestamp: %w", err)
+	}
+
+	body, err := base64.StdEncoding.DecodeString(rb.Payload.Body)
+	if err != nil {
+		return fmt.Errorf("failed to decode transparency log entry body: %w", err)
+	}
+
+	var entry hashedRekord
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return fmt.Errorf("failed to parse transparency log entry body: %w", err)
+	}
+
+	if entry.Kind != rekorHashedRekordKind {
+		return fmt.Errorf("unsupported transparency log entry kind %q", entry.Kind)
+	}
+
+	if entry.Spec.Data.Hash.Algorithm != "sha256" || entry.Spec.Data.Hash.Value != hex.EncodeToString(cryptoutil.CalculateDigest(payload)) {
+		return errors.New("transparency log entry does not match the payload")
+	}
+
+	if entry.Spec.Signature.Content != base64Signature {
+		return errors.New("transparency log entry does not match the signature")
+	}
+
+	entryKeyPEM, err := base64.StdEncoding.DecodeString(entry.Spec.Signature.PublicKey.Content)
+	if err != nil {
+		return fmt.Errorf("failed to decode transparency log entry public key: %w", err)
+	}
+
+	entryKey, err := parsePublicKey(entryKeyPEM)
+	if err != nil {
+		return fmt.Errorf("failed to parse transparency log entry public key: %w", err)
+	}
+
+	if !publicKeysEqual(entryKey, v.publicKey) {
+		return errors.New("transparency log entry does not match the public key")
+	}
+
+	return nil
+}
+
+func newHashedRekord(payload, signature, publicKeyPEM []byte) hashedRekord {
+	entry := hashedRekord{
+		APIVersion: rekorHashedRekordVersion,
+		Kind:       rekorHashedRekordKind,
+	}
+	entry.Spec.Data.Hash.Algorithm = "sha256"
+	entry.Spec.Data.Hash.Value = hex.EncodeToString(cryptoutil.CalculateDigest(payload))
+	entry.Spec.Signature.Content = base64.StdEncoding.EncodeToString(signature)
+	entry.Spec.Signature.PublicKey.Content = base64.StdEncoding.EncodeToString(publicKeyPEM)
+
+	return entry
+}
+
+// rekorLogID returns the ID of a transparency log, the hex-encoded SHA-256 digest of its DER-encoded public key.
+func rekorLogID(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal transparency log public key: %w", err)
+	}
+
+	return hex.EncodeToString(cryptoutil.CalculateDigest(der)), nil
+}
+
+func verifyWithPublicKey(publicKey crypto.PublicKey, payload, signature []byte) error {
+	err := cryptoutil.VerifySignature(publicKey, payload, signature)
+	if errors.Is(err, cryptoutil.ErrInvalidSignature) {
+		return ErrInvalidSignature
+	}
+
+	return err
+}
+
+func publicKeysEqual(a, b crypto.PublicKey) bool {
+	key, ok := a.(interface{ Equal(crypto.PublicKey) bool })
+	return ok && key.Equal(b)
+}
+
+func loadPublicKey(path string) (crypto.PublicKey, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePublicKey(pemBytes)
+}
+
+func parsePublicKey(pemBytes []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.New("no PEM encoded public key found")
+	}
+
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// loadSigstorePrivateKey loads an encrypted cosign private key, or a PKCS#8, SEC 1 or PKCS#1 PEM private key.
+func loadSigstorePrivateKey(path, password string) (crypto.Signer, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	var key crypto.PrivateKey
+	switch block.Type {
+	case sigstoreKeyPEMType, cosignKeyPEMType:
+		der, err := decryptCosignKey(block.Bytes, []byte(password))
+		if err != nil {
+			return nil, err
+		}
+		key, err = x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			return nil, err
+		}
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch signer := key.(type) {
+	case *ecdsa.PrivateKey:
+		return signer, nil
+	case *rsa.PrivateKey:
+		return signer, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+// decryptCosignKey decrypts the payload of an encrypted cosign private key, which is protected
+// with a scrypt-derived key and NaCl secretbox, and returns the PKCS#8 DER-encoded private key.
+func decryptCosignKey(data, password []byte) ([]byte, error) {
+	var k encryptedKey
+	if err := json.Unmarshal(data, &k); err != nil {
+		return nil, fmt.Errorf("failed to parse encrypted private key: %w", err)
+	}
+
+	if k.KDF.Name != "scrypt" {
+		return nil, fmt.Errorf("unsupported key derivation function %q", k.KDF.Name)
+	}
+
+	if k.Cipher.Name != "nacl/secretbox" {
+		return nil, fmt.Errorf("unsupported cipher %q", k.Cipher.Name)
+	}
+
+	var nonce [24]byte
+	if len(k.Cipher.Nonce) != len(nonce) {
+		return nil, errors.New("invalid nonce length")
+	}
+	copy(nonce[:], k.Cipher.Nonce)
+
+	derived, err := scrypt.Key(password, k.KDF.Salt, k.KDF.Params.N, k.KDF.Params.R, k.KDF.Params.P, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive encryption key: %w", err)
+	}
+
+	var secretKey [32]byte
+	copy(secretKey[:], derived)
+
+	der, ok := secretbox.Open(nil, k.Ciphertext, &nonce, &secretKey)
+	if !ok {
+		return nil, errors.New("failed to decrypt private key: invalid password")
+	}
+
+	return der, nil
+}
@@ -0,0 +1,297 @@
+package integrity
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+func TestSigstoreSignAndVerify(t *testing.T) {
+	tempDir := t.TempDir()
+	key := writeSigstoreKeyPair(t, tempDir, "cosign", "")
+
+	signer := NewSigstoreSigner()
+	require.NoError(t, signer.Configure(&SigstoreSignerConfig{PrivateKeyPath: key.privateKeyPath}))
+
+	verifier := NewSigstoreVerifier()
+	require.NoError(t, verifier.Configure(&SigstoreVerifierConfig{PublicKeyPath: key.publicKeyPath}))
+
+	payload := []byte("test payload")
+	signature, chain, err := signer.Sign(payload)
+	require.NoError(t, err)
+	assert.Nil(t, chain)
+
+	var bundle sigstoreBundle
+	require.NoError(t, json.Unmarshal(signature, &bundle))
+	assert.NotEmpty(t, bundle.Base64Signature)
+	assert.Nil(t, bundle.RekorBundle)
+
+	err = verifier.Verify(payload, signature, nil)
+	require.NoError(t, err)
+
+	err = verifier.Verify([]byte("other payload"), signature, nil)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	other := writeSigstoreKeyPair(t, tempDir, "other", "")
+	otherVerifier := NewSigstoreVerifier()
+	require.NoError(t, otherVerifier.Configure(&SigstoreVerifierConfig{PublicKeyPath: other.publicKeyPath}))
+	err = otherVerifier.Verify(payload, signature, nil)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	err = verifier.Verify(payload, []byte("not a bundle"), nil)
+	assert.ErrorContains(t, err, "failed to parse signature bundle")
+}
+
+func TestSigstoreSignWithEncryptedKey(t *testing.T) {
+	tempDir := t.TempDir()
+	key := writeSigstoreKeyPair(t, tempDir, "cosign", "secret")
+
+	t.Setenv(SigstorePasswordEnvVar, "wrong")
+	signer := NewSigstoreSigner()
+	err := signer.Configure(&SigstoreSignerConfig{PrivateKeyPath: key.privateKeyPath})
+	assert.ErrorContains(t, err, "invalid password")
+
+	t.Setenv(SigstorePasswordEnvVar, "secret")
+	require.NoError(t, signer.Configure(&SigstoreSignerConfig{PrivateKeyPath: key.privateKeyPath}))
+
+	verifier := NewSigstoreVerifier()
+	require.NoError(t, verifier.Configure(&SigstoreVerifierConfig{PublicKeyPath: key.publicKeyPath}))
+
+	payload := []byte("test payload")
+	signature, _, err := signer.Sign(payload)
+	require.NoError(t, err)
+	require.NoError(t, verifier.Verify(payload, signature, nil))
+}
+
+func TestSigstoreSignAndVerifyWithTransparencyLog(t *testing.T) {
+	tempDir := t.TempDir()
+	key := writeSigstoreKeyPair(t, tempDir, "cosign", "")
+	rekor := newFakeRekor(t)
+	rekorKeyPath := writePublicKey(t, tempDir, "rekor.pub", rekor.key.Public())
+
+	signer := NewSigstoreSigner()
+	require.NoError(t, signer.Configure(&SigstoreSignerConfig{PrivateKeyPath: key.privateKeyPath, RekorURL: rekor.server.URL + "/"}))
+
+	verifier := NewSigstoreVerifier()
+	require.NoError(t, verifier.Configure(&SigstoreVerifierConfig{PublicKeyPath: key.publicKeyPath, RekorPublicKeyPath: rekorKeyPath}))
+
+	payload := []byte("test payload")
+	signature, _, err := signer.Sign(payload)
+	require.NoError(t, err)
+	require.NoError(t, verifier.Verify(payload, signature, nil))
+
+	var bundle sigstoreBundle
+	require.NoError(t, json.Unmarshal(signature, &bundle))
+	require.NotNil(t, bundle.RekorBundle)
+	assert.Equal(t, int64(1), bundle.RekorBundle.Payload.LogIndex)
+
+	t.Run("bundle without log entry", func(t *testing.T) {
+		unlogged := bundle
+		unlogged.RekorBundle = nil
+		err := verifier.Verify(payload, marshalBundle(t, unlogged), nil)
+		assert.EqualError(t, err, "signature bundle has no transparency log entry")
+	})
+
+	t.Run("tampered log entry", func(t *testing.T) {
+		tampered := bundle
+		rb := *bundle.RekorBundle
+		rb.Payload.IntegratedTime++
+		tampered.RekorBundle = &rb
+		err := verifier.Verify(payload, marshalBundle(t, tampered), nil)
+		assert.ErrorContains(t, err, "failed to verify signed entry timestamp")
+	})
+
+	t.Run("log entry for another signature", func(t *testing.T) {
+		otherSignature, _, err := signer.Sign([]byte("other payload"))
+		require.NoError(t, err)
+		var other sigstoreBundle
+		require.NoError(t, json.Unmarshal(otherSignature, &other))
+
+		mixed := bundle
+		mixed.RekorBundle = other.RekorBundle
+		err = verifier.Verify(payload, marshalBundle(t, mixed), nil)
+		assert.EqualError(t, err, "transparency log entry does not match the payload")
+	})
+
+	t.Run("log entry from another log", func(t *testing.T) {
+		otherRekor := newFakeRekor(t)
+		otherKeyPath := writePublicKey(t, tempDir, "other-rekor.pub", otherRekor.key.Public())
+		otherVerifier := NewSigstoreVerifier()
+		require.NoError(t, otherVerifier.Configure(&SigstoreVerifierConfig{PublicKeyPath: key.publicKeyPath, RekorPublicKeyPath: otherKeyPath}))
+
+		err := otherVerifier.Verify(payload, signature, nil)
+		assert.ErrorContains(t, err, "transparency log entry was not issued by the configured log")
+	})
+
+	t.Run("log unavailable", func(t *testing.T) {
+		unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}))
+		defer unavailable.Close()
+
+		signer := NewSigstoreSigner()
+		require.NoError(t, signer.Configure(&SigstoreSignerConfig{PrivateKeyPath: key.privateKeyPath, RekorURL: unavailable.URL}))
+		_, _, err := signer.Sign(payload)
+		assert.ErrorContains(t, err, "unexpected status code 503")
+	})
+}
+
+func TestSigstoreConfigure(t *testing.T) {
+	err := NewSigstoreSigner().Configure(nil)
+	assert.EqualError(t, err, constants.ErrConfigRequired)
+
+	err = NewSigstoreSigner().Configure(&SigstoreSignerConfig{})
+	assert.EqualError(t, err, constants.ErrPrivateKeyPathRequired)
+
+	err = NewSigstoreVerifier().Configure(nil)
+	assert.EqualError(t, err, constants.ErrConfigRequired)
+
+	err = NewSigstoreVerifier().Configure(&SigstoreVerifierConfig{})
+	assert.EqualError(t, err, constants.ErrPublicKeyRequired)
+
+	_, _, err = NewSigstoreSigner().Sign([]byte("payload"))
+	assert.EqualError(t, err, "sigstore signer is not configured")
+
+	err = NewSigstoreVerifier().Verify([]byte("payload"), []byte("{}"), nil)
+	assert.EqualError(t, err, "sigstore verifier is not configured")
+}
+
+type sigstoreKeyPair struct {
+	privateKeyPath string
+	publicKeyPath  string
+}
+
+// writeSigstoreKeyPair writes an ECDSA P-256 key pair. When a password is given, the private key
+// is encrypted the same way as `cosign generate-key-pair` does.
+func writeSigstoreKeyPair(t *testing.T, dir, name, password string) sigstoreKeyPair {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	block := &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	if password != "" {
+		block = &pem.Block{Type: sigstoreKeyPEMType, Bytes: encryptCosignKey(t, der, []byte(password))}
+	}
+
+	privateKeyPath := filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(privateKeyPath, pem.EncodeToMemory(block), 0600))
+
+	return sigstoreKeyPair{
+		privateKeyPath: privateKeyPath,
+		publicKeyPath:  writePublicKey(t, dir, name+".pub", key.Public()),
+	}
+}
+
+func writePublicKey(t *testing.T, dir, name string, publicKey crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+
+	return path
+}
+
+func encryptCosignKey(t *testing.T, der, password []byte) []byte {
+	var k encryptedKey
+	k.KDF.Name = "scrypt"
+	k.KDF.Params.N, k.KDF.Params.R, k.KDF.Params.P = 32768, 8, 1
+	k.KDF.Salt = make([]byte, 32)
+	_, err := rand.Read(k.KDF.Salt)
+	require.NoError(t, err)
+
+	var nonce [24]byte
+	_, err = rand.Read(nonce[:])
+	require.NoError(t, err)
+	k.Cipher.Name = "nacl/secretbox"
+	k.Cipher.Nonce = nonce[:]
+
+	derived, err := scrypt.Key(password, k.KDF.Salt, k.KDF.Params.N, k.KDF.Params.R, k.KDF.Params.P, 32)
+	require.NoError(t, err)
+	var secretKey [32]byte
+	copy(secretKey[:], derived)
+	k.Ciphertext = secretbox.Seal(nil, der, &nonce, &secretKey)
+
+	data, err := json.Marshal(k)
+	require.NoError(t, err)
+
+	return data
+}
+
+func marshalBundle(t *testing.T, bundle sigstoreBundle) []byte {
+	data, err := json.Marshal(bundle)
+	require.NoError(t, err)
+
+	return data
+}
+
+type fakeRekor struct {
+	key    *ecdsa.PrivateKey
+	server *httptest.Server
+	index  int64
+}
+
+// newFakeRekor starts a server accepting hashedrekord entries and returning them with a signed entry timestamp.
+func newFakeRekor(t *testing.T) *fakeRekor {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	logID, err := rekorLogID(key.Public())
+	require.NoError(t, err)
+
+	r := &fakeRekor{key: key}
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != rekorEntriesPath {
+			http.NotFound(w, req)
+			return
+		}
+
+		var record hashedRekord
+		if err := json.NewDecoder(req.Body).Decode(&record); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		body, _ := json.Marshal(record)
+
+		r.index++
+		var entry rekorLogEntry
+		entry.Body = base64.StdEncoding.EncodeToString(body)
+		entry.IntegratedTime = 1700000000 + r.index
+		entry.LogID = logID
+		entry.LogIndex = r.index
+
+		canonical, _ := json.Marshal(entry.rekorPayload)
+		set, err := ecdsa.SignASN1(rand.Reader, key, cryptoutil.CalculateDigest(canonical))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		entry.Verification.SignedEntryTimestamp = set
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]rekorLogEntry{"entry-uuid": entry})
+	}))
+	t.Cleanup(r.server.Close)
+
+	return r
+}
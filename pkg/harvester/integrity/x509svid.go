@@ -0,0 +1,185 @@
+package integrity
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/jmhodges/clock"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+)
+
+const workloadAPITimeout = 30 * time.Second
+
+// X509SVIDSigner implements the Signer interface using an X509-SVID fetched from the SPIFFE Workload API.
+// The SVID is fetched on each signing operation, so that the latest rotated SVID is always used, and the
+// returned certificate chain is the SVID certificate chain.
+type X509SVIDSigner struct {
+	clientOptions []workloadapi.ClientOption
+	spiffeID      spiffeid.ID
+}
+
+// X509SVIDVerifier implements the TrustDomainVerifier interface for signatures made with X509-SVIDs.
+// A signature is accepted only when the signing certificate chain is an X509-SVID of the trust domain that signed
+// the payload, and chains back to the bundle of that trust domain trusted before the payload.
+type X509SVIDVerifier struct {
+	// used for testing
+	clock clock.Clock
+}
+
+// X509SVIDSignerConfig is a configuration struct for creating a new X509SVIDSigner
+type X509SVIDSignerConfig struct {
+	// WorkloadAPISocketPath is the path to the Workload API socket. When empty, the
+	// SPIFFE_ENDPOINT_SOCKET environment variable is used.
+	WorkloadAPISocketPath string `hcl:"workload_api_socket_path,optional"`
+	// SPIFFEID selects the SVID to sign with when the Workload API returns several. When empty, the first one is used.
+	SPIFFEID string `hcl:"spiffe_id,optional"`
+}
+
+// X509SVIDVerifierConfig is a configuration struct for creating a new X509SVIDVerifier
+type X509SVIDVerifierConfig struct {
+	Clock clock.Clock
+}
+
+// NewX509SVIDSigner creates a new X509SVIDSigner instance without any configuration.
+func NewX509SVIDSigner() *X509SVIDSigner {
+	return &X509SVIDSigner{}
+}
+
+// Configure sets up the X509SVIDSigner with the provided configuration.
+func (s *X509SVIDSigner) Configure(config *X509SVIDSignerConfig) error {
+	if config == nil {
+		return errors.New(constants.ErrConfigRequired)
+	}
+
+	s.clientOptions = nil
+	if config.WorkloadAPISocketPath != "" {
+		s.clientOptions = append(s.clientOptions, workloadapi.WithAddr("unix://"+config.WorkloadAPISocketPath))
+	}
+
+	s.spiffeID = spiffeid.ID{}
+	if config.SPIFFEID != "" {
+		id, err := spiffeid.FromString(config.SPIFFEID)
+		if err != nil {
+			return fmt.Errorf("invalid SPIFFE ID: %w", err)
+		}
+		s.spiffeID = id
+	}
+
+	return nil
+}
+
+// Sign fetches the X509-SVID from the Workload API and signs the SHA-256 digest of the payload with its private key.
+// It returns the signature along with the SVID certificate chain.
+func (s *X509SVIDSigner) Sign(payload []byte) ([]byte, []*x509.Certificate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), workloadAPITimeout)
+	defer cancel()
+
+	svids, err := workloadapi.FetchX509SVIDs(ctx, s.clientOptions...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch X509-SVID from the Workload API: %w", err)
+	}
+
+	svid, err := s.pickSVID(svids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signature, err := svid.PrivateKey.Sign(rand.Reader, cryptoutil.CalculateDigest(payload), crypto.SHA256)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign payload: %w", err)
+	}
+
+	return signature, svid.Certificates, nil
+}
+
+func (s *X509SVIDSigner) pickSVID(svids []*x509svid.SVID) (*x509svid.SVID, error) {
+	if len(svids) == 0 {
+		return nil, errors.New("the Workload API returned no X509-SVID")
+	}
+
+	if s.spiffeID.IsZero() {
+		return svids[0], nil
+	}
+
+	for _, svid := range svids {
+		if svid.ID == s.spiffeID {
+			return svid, nil
+		}
+	}
+
+	return nil, fmt.Errorf("the Workload API returned no X509-SVID for %q", s.spiffeID)
+}
+
+// NewX509SVIDVerifier creates a new X509SVIDVerifier instance without any configuration.
+func NewX509SVIDVerifier() *X509SVIDVerifier {
+	return &X509SVIDVerifier{}
+}
+
+// Configure sets up the X509SVIDVerifier with the provided configuration.
+func (v *X509SVIDVerifier) Configure(config *X509SVIDVerifierConfig) error {
+	if config == nil {
+		return errors.New(constants.ErrConfigRequired)
+	}
+
+	if config.Clock == nil {
+		config.Clock = clock.New()
+	}
+	v.clock = config.Clock
+
+	return nil
+}
+
+// Verify always fails, as verifying an X509-SVID signature requires the trust domain that signed the payload
+// and its trusted bundle. See VerifyTrustDomainSignature.
+func (v *X509SVIDVerifier) Verify(_, _ []byte, _ []*x509.Certificate) error {
+	return errors.New("the signing trust domain and its trusted bundle are required to verify an X509-SVID signature")
+}
+
+// VerifyTrustDomainSignature checks that the signing certificate chain is an X509-SVID of the given trust domain
+// that chains back to its trusted bundle, and that the signature of the payload was made with the SVID key.
+func (v *X509SVIDVerifier) VerifyTrustDomainSignature(td spiffeid.TrustDomain, trustedBundle *spiffebundle.Bundle, payload, signature []byte, certChain []*x509.Certificate) error {
+	if len(certChain) == 0 || certChain[0] == nil {
+		return errors.New("signing certificate chain is missing")
+	}
+
+	if trustedBundle == nil {
+		return fmt.Errorf("there is no trusted bundle of trust domain %q", td)
+	}
+
+	if trustedBundle.TrustDomain() != td {
+		return fmt.Errorf("trusted bundle belongs to trust domain %q instead of %q", trustedBundle.TrustDomain(), td)
+	}
+
+	id, err := x509svid.IDFromCert(certChain[0])
+	if err != nil {
+		return fmt.Errorf("signing certificate is not an X509-SVID: %w", err)
+	}
+
+	if id.TrustDomain() != td {
+		return fmt.Errorf("signer %q is not a member of trust domain %q", id, td)
+	}
+
+	if _, _, err := x509svid.Verify(certChain, trustedBundle, x509svid.WithTime(v.now())); err != nil {
+		return fmt.Errorf("failed to verify signing X509-SVID: %w", err)
+	}
+
+	return verifyWithPublicKey(certChain[0].PublicKey, payload, signature)
+}
+
+func (v *X509SVIDVerifier) now() time.Time {
+	if v.clock == nil {
+		return time.Now()
+	}
+
+	return v.clock.Now()
+}
@@ -0,0 +1,206 @@
+package integrity
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakeworkloadapi"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	svidTD      = spiffeid.RequireTrustDomainFromString("example.org")
+	otherSVIDTD = spiffeid.RequireTrustDomainFromString("other.org")
+)
+
+func TestX509SVIDSignAndVerify(t *testing.T) {
+	ca, caKey := createSVIDCA(t)
+	svid := createX509SVID(t, ca, caKey, "spiffe://example.org/harvester")
+
+	workloadAPI := fakeworkloadapi.New(t)
+	workloadAPI.SetX509SVIDs(t, []*x509.Certificate{ca}, svid)
+
+	signer := NewX509SVIDSigner()
+	require.NoError(t, signer.Configure(&X509SVIDSignerConfig{WorkloadAPISocketPath: workloadAPI.SocketPath}))
+
+	verifier := NewX509SVIDVerifier()
+	require.NoError(t, verifier.Configure(&X509SVIDVerifierConfig{Clock: clk}))
+
+	payload := []byte("test payload")
+	signature, chain, err := signer.Sign(payload)
+	require.NoError(t, err)
+	require.Len(t, chain, 1)
+	assert.Equal(t, svid.Certificates[0].Raw, chain[0].Raw)
+
+	trustedBundle := spiffebundle.FromX509Authorities(svidTD, []*x509.Certificate{ca})
+	err = verifier.VerifyTrustDomainSignature(svidTD, trustedBundle, payload, signature, chain)
+	require.NoError(t, err)
+
+	err = VerifyForTrustDomain(verifier, svidTD, trustedBundle, payload, signature, chain)
+	require.NoError(t, err)
+
+	err = verifier.VerifyTrustDomainSignature(svidTD, trustedBundle, []byte("other payload"), signature, chain)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	err = verifier.Verify(payload, signature, chain)
+	assert.EqualError(t, err, "the signing trust domain and its trusted bundle are required to verify an X509-SVID signature")
+}
+
+func TestX509SVIDVerifyRejectsUntrustedSigners(t *testing.T) {
+	ca, caKey := createSVIDCA(t)
+	otherCA, _ := createSVIDCA(t)
+	payload := []byte("test payload")
+
+	verifier := NewX509SVIDVerifier()
+	require.NoError(t, verifier.Configure(&X509SVIDVerifierConfig{Clock: clk}))
+
+	sign := func(svid *x509svid.SVID) []byte {
+		signature, err := svid.PrivateKey.Sign(rand.Reader, cryptoutil.CalculateDigest(payload), crypto.SHA256)
+		require.NoError(t, err)
+		return signature
+	}
+
+	svid := createX509SVID(t, ca, caKey, "spiffe://example.org/harvester")
+	foreignSVID := createX509SVID(t, ca, caKey, "spiffe://other.org/harvester")
+
+	for _, tt := range []struct {
+		name          string
+		td            spiffeid.TrustDomain
+		trustedBundle *spiffebundle.Bundle
+		svid          *x509svid.SVID
+		chain         []*x509.Certificate
+		expectedErr   string
+	}{
+		{
+			name:          "missing chain",
+			td:            svidTD,
+			trustedBundle: spiffebundle.FromX509Authorities(svidTD, []*x509.Certificate{ca}),
+			svid:          svid,
+			chain:         []*x509.Certificate{},
+			expectedErr:   "signing certificate chain is missing",
+		},
+		{
+			name:        "no trusted bundle",
+			td:          svidTD,
+			svid:        svid,
+			expectedErr: `there is no trusted bundle of trust domain "example.org"`,
+		},
+		{
+			name:          "trusted bundle of another trust domain",
+			td:            svidTD,
+			trustedBundle: spiffebundle.FromX509Authorities(otherSVIDTD, []*x509.Certificate{ca}),
+			svid:          svid,
+			expectedErr:   `trusted bundle belongs to trust domain "other.org" instead of "example.org"`,
+		},
+		{
+			name:          "signer of another trust domain",
+			td:            svidTD,
+			trustedBundle: spiffebundle.FromX509Authorities(svidTD, []*x509.Certificate{ca}),
+			svid:          foreignSVID,
+			expectedErr:   `signer "spiffe://other.org/harvester" is not a member of trust domain "example.org"`,
+		},
+		{
+			name:          "signer not issued by the trusted bundle",
+			td:            svidTD,
+			trustedBundle: spiffebundle.FromX509Authorities(svidTD, []*x509.Certificate{otherCA}),
+			svid:          svid,
+			expectedErr:   "failed to verify signing X509-SVID",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := tt.chain
+			if chain == nil {
+				chain = tt.svid.Certificates
+			}
+
+			err := verifier.VerifyTrustDomainSignature(tt.td, tt.trustedBundle, payload, sign(tt.svid), chain)
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestX509SVIDSignerPicksSVID(t *testing.T) {
+	ca, caKey := createSVIDCA(t)
+	first := createX509SVID(t, ca, caKey, "spiffe://example.org/first")
+	second := createX509SVID(t, ca, caKey, "spiffe://example.org/second")
+
+	workloadAPI := fakeworkloadapi.New(t)
+	workloadAPI.SetX509SVIDs(t, []*x509.Certificate{ca}, first, second)
+
+	signer := NewX509SVIDSigner()
+	require.NoError(t, signer.Configure(&X509SVIDSignerConfig{WorkloadAPISocketPath: workloadAPI.SocketPath}))
+	_, chain, err := signer.Sign([]byte("payload"))
+	require.NoError(t, err)
+	assert.Equal(t, first.Certificates[0].Raw, chain[0].Raw)
+
+	require.NoError(t, signer.Configure(&X509SVIDSignerConfig{WorkloadAPISocketPath: workloadAPI.SocketPath, SPIFFEID: "spiffe://example.org/second"}))
+	_, chain, err = signer.Sign([]byte("payload"))
+	require.NoError(t, err)
+	assert.Equal(t, second.Certificates[0].Raw, chain[0].Raw)
+
+	require.NoError(t, signer.Configure(&X509SVIDSignerConfig{WorkloadAPISocketPath: workloadAPI.SocketPath, SPIFFEID: "spiffe://example.org/unknown"}))
+	_, _, err = signer.Sign([]byte("payload"))
+	assert.EqualError(t, err, `the Workload API returned no X509-SVID for "spiffe://example.org/unknown"`)
+}
+
+func TestX509SVIDConfigure(t *testing.T) {
+	err := NewX509SVIDSigner().Configure(nil)
+	assert.EqualError(t, err, constants.ErrConfigRequired)
+
+	err = NewX509SVIDSigner().Configure(&X509SVIDSignerConfig{SPIFFEID: "not-an-id"})
+	assert.ErrorContains(t, err, "invalid SPIFFE ID")
+
+	err = NewX509SVIDVerifier().Configure(nil)
+	assert.EqualError(t, err, constants.ErrConfigRequired)
+}
+
+func TestVerifyForTrustDomain(t *testing.T) {
+	err := VerifyForTrustDomain(NewNoOpVerifier(), svidTD, nil, []byte("payload"), nil, nil)
+	assert.NoError(t, err)
+
+	err = VerifyForTrustDomain(NewX509SVIDVerifier(), svidTD, nil, []byte("payload"), nil, nil)
+	assert.EqualError(t, err, "signing certificate chain is missing")
+}
+
+func createSVIDCA(t *testing.T) (*x509.Certificate, crypto.PrivateKey) {
+	template, err := cryptoutil.CreateRootCATemplate(clk, pkix.Name{CommonName: "svid-ca"}, time.Hour)
+	require.NoError(t, err)
+
+	ca, key, err := cryptoutil.SelfSignX509(template)
+	require.NoError(t, err)
+
+	return ca, key
+}
+
+func createX509SVID(t *testing.T, ca *x509.Certificate, caKey crypto.PrivateKey, id string) *x509svid.SVID {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	uri, err := url.Parse(id)
+	require.NoError(t, err)
+
+	template, err := cryptoutil.CreateX509Template(clk, key.Public(), pkix.Name{}, []*url.URL{uri}, nil, time.Hour)
+	require.NoError(t, err)
+
+	cert, err := cryptoutil.SignX509(template, ca, caKey)
+	require.NoError(t, err)
+
+	return &x509svid.SVID{
+		ID:           spiffeid.RequireFromString(id),
+		Certificates: []*x509.Certificate{cert},
+		PrivateKey:   key,
+	}
+}
@@ -4,30 +4,50 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strings"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	bundlev1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/bundle/v1"
+	entryv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/entry/v1"
+	trustdomainv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/trustdomain/v1"
+	apitypes "github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 const (
-	listFederatedBundlesPageSize = 100
-	defaultSocketPath            = "/tmp/spire-server/private/api.sock"
+	listFederatedBundlesPageSize        = 100
+	listFederationRelationshipsPageSize = 100
+	listEntriesPageSize                 = 500
+	defaultSocketPath                   = "/tmp/spire-server/private/api.sock"
 )
 
 // Client is an interface for interacting with a SPIRE Server, providing methods for trust bundle retrieval,
-// setting federation bundles, and deleting federation bundles.
+// setting federation bundles, deleting federation bundles, managing federation relationships and
+// updating the federated trust domains of registration entries.
 type Client interface {
 	GetBundle(context.Context) (*spiffebundle.Bundle, error)
 	GetFederatedBundles(context.Context) ([]*spiffebundle.Bundle, error)
 	SetFederatedBundles(context.Context, []*spiffebundle.Bundle) ([]*BatchSetFederatedBundleStatus, error)
 	DeleteFederatedBundles(context.Context, []spiffeid.TrustDomain) ([]*BatchDeleteFederatedBundleStatus, error)
+
+	ListFederationRelationships(context.Context) ([]*FederationRelationship, error)
+	CreateFederationRelationships(context.Context, []*FederationRelationship) ([]*BatchCreateFederationRelationshipStatus, error)
+	UpdateFederationRelationships(context.Context, []*FederationRelationship) ([]*BatchUpdateFederationRelationshipStatus, error)
+	DeleteFederationRelationships(context.Context, []spiffeid.TrustDomain) ([]*BatchDeleteFederationRelationshipStatus, error)
+
+	ListEntries(context.Context) ([]*Entry, error)
+	UpdateEntriesFederatesWith(context.Context, []*Entry) ([]*BatchUpdateEntryStatus, error)
 }
 
 type spireServerClient struct {
-	bundleClient bundlev1.BundleClient
+	bundleClient      bundlev1.BundleClient
+	trustDomainClient trustdomainv1.TrustDomainClient
+	entryClient       entryv1.EntryClient
 }
 
 func NewSpireClient(ctx context.Context, addr net.Addr) (Client, error) {
@@ -43,7 +63,9 @@ func NewSpireClient(ctx context.Context, addr net.Addr) (Client, error) {
 	}
 
 	return &spireServerClient{
-		bundleClient: bundlev1.NewBundleClient(clientConn),
+		bundleClient:      bundlev1.NewBundleClient(clientConn),
+		trustDomainClient: trustdomainv1.NewTrustDomainClient(clientConn),
+		entryClient:       entryv1.NewEntryClient(clientConn),
 	}, nil
 }
 
@@ -133,9 +155,167 @@ func (c *spireServerClient) SetFederatedBundles(ctx context.Context, bundles []*
 	return statuses, nil
 }
 
+// ListFederationRelationships lists all the federation relationships configured in the SPIRE Server.
+func (c *spireServerClient) ListFederationRelationships(ctx context.Context) ([]*FederationRelationship, error) {
+	var pageToken string
+	result := make([]*FederationRelationship, 0)
+
+	for {
+		res, err := c.trustDomainClient.ListFederationRelationships(ctx, &trustdomainv1.ListFederationRelationshipsRequest{
+			PageToken: pageToken,
+			PageSize:  int32(listFederationRelationshipsPageSize),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("client failed to list federation relationships: %v", err)
+		}
+
+		relationships, err := protoToFederationRelationships(res.FederationRelationships)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse spire server federation relationships response: %v", err)
+		}
+
+		result = append(result, relationships...)
+
+		if res.NextPageToken == "" {
+			break
+		}
+		pageToken = res.NextPageToken
+	}
+
+	return result, nil
+}
+
+// CreateFederationRelationships creates a set of federation relationships on the SPIRE Server.
+func (c *spireServerClient) CreateFederationRelationships(ctx context.Context, relationships []*FederationRelationship) ([]*BatchCreateFederationRelationshipStatus, error) {
+	protoRelationships, err := federationRelationshipsToProto(relationships)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.trustDomainClient.BatchCreateFederationRelationship(ctx, &trustdomainv1.BatchCreateFederationRelationshipRequest{
+		FederationRelationships: protoRelationships,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("client failed to create federation relationships: %v", err)
+	}
+
+	statuses, err := protoToBatchCreateFederationRelationshipResult(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spire server federation relationships response: %v", err)
+	}
+
+	return statuses, nil
+}
+
+// UpdateFederationRelationships updates the bundle endpoint of a set of federation relationships on the SPIRE Server.
+func (c *spireServerClient) UpdateFederationRelationships(ctx context.Context, relationships []*FederationRelationship) ([]*BatchUpdateFederationRelationshipStatus, error) {
+	protoRelationships, err := federationRelationshipsToProto(relationships)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.trustDomainClient.BatchUpdateFederationRelationship(ctx, &trustdomainv1.BatchUpdateFederationRelationshipRequest{
+		FederationRelationships: protoRelationships,
+		InputMask: &apitypes.FederationRelationshipMask{
+			BundleEndpointUrl:     true,
+			BundleEndpointProfile: true,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("client failed to update federation relationships: %v", err)
+	}
+
+	statuses, err := protoToBatchUpdateFederationRelationshipResult(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spire server federation relationships response: %v", err)
+	}
+
+	return statuses, nil
+}
+
+// DeleteFederationRelationships deletes the federation relationships with a set of trust domains on the SPIRE Server.
+func (c *spireServerClient) DeleteFederationRelationships(ctx context.Context, trustDomains []spiffeid.TrustDomain) ([]*BatchDeleteFederationRelationshipStatus, error) {
+	tdsToDel := make([]string, 0, len(trustDomains))
+	for _, td := range trustDomains {
+		tdsToDel = append(tdsToDel, td.String())
+	}
+
+	resp, err := c.trustDomainClient.BatchDeleteFederationRelationship(ctx, &trustdomainv1.BatchDeleteFederationRelationshipRequest{
+		TrustDomains: tdsToDel,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("client failed to delete federation relationships: %v", err)
+	}
+
+	statuses, err := protoToBatchDeleteFederationRelationshipResult(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spire server federation relationships response: %v", err)
+	}
+
+	return statuses, nil
+}
+
+// ListEntries lists all the registration entries in the SPIRE Server.
+func (c *spireServerClient) ListEntries(ctx context.Context) ([]*Entry, error) {
+	var pageToken string
+	result := make([]*Entry, 0)
+
+	for {
+		res, err := c.entryClient.ListEntries(ctx, &entryv1.ListEntriesRequest{
+			PageToken: pageToken,
+			PageSize:  int32(listEntriesPageSize),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("client failed to list entries: %v", err)
+		}
+
+		entries, err := protoToEntries(res.Entries)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse spire server entries response: %v", err)
+		}
+
+		result = append(result, entries...)
+
+		if res.NextPageToken == "" {
+			break
+		}
+		pageToken = res.NextPageToken
+	}
+
+	return result, nil
+}
+
+// UpdateEntriesFederatesWith updates the federated trust domains of a set of registration entries on the SPIRE Server.
+// Only the FederatesWith field of the entries is updated.
+func (c *spireServerClient) UpdateEntriesFederatesWith(ctx context.Context, entries []*Entry) ([]*BatchUpdateEntryStatus, error) {
+	protoEntries, err := entriesToProto(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.entryClient.BatchUpdateEntry(ctx, &entryv1.BatchUpdateEntryRequest{
+		Entries: protoEntries,
+		InputMask: &apitypes.EntryMask{
+			FederatesWith: true,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("client failed to update entries: %v", err)
+	}
+
+	statuses, err := protoToBatchUpdateEntryResult(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spire server entries response: %v", err)
+	}
+
+	return statuses, nil
+}
+
 func dialSocket(ctx context.Context, addr net.Addr) (*grpc.ClientConn, error) {
 	target := fmt.Sprintf("%s://%s", addr.Network(), addr.String())
-	clientConn, err := grpc.DialContext(ctx, target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	clientConn, err := grpc.DialContext(ctx, target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(tracingInterceptor))
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial API socket: %v", err)
 	}
@@ -143,6 +323,19 @@ func dialSocket(ctx context.Context, addr net.Addr) (*grpc.ClientConn, error) {
 	return clientConn, nil
 }
 
+// tracingInterceptor traces the calls to the SPIRE Server API as spans of the sync making them.
+func tracingInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, span := telemetry.Tracer().Start(ctx, strings.TrimPrefix(method, "/"),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.RPCSystemGRPC))
+	defer span.End()
+
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	telemetry.RecordError(span, err)
+
+	return err
+}
+
 func newLocalSpireServerWithClient(bundleClient bundlev1.BundleClient) Client {
 	return &spireServerClient{
 		bundleClient: bundleClient,
@@ -6,15 +6,20 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"fmt"
 	"math/big"
+	"net/url"
 	"testing"
 	"time"
 
+	"github.com/HewlettPackard/galadriel/test/fakes/fakespireserver"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	bundlev1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/bundle/v1"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 )
 
 type fakeBundleClient struct {
@@ -66,7 +71,211 @@ func TestGetBundle(t *testing.T) {
 	assert.Equal(t, publicKey, bundle.JWTAuthorities()["test-key-id"])
 }
 
-// TODO add tests for other methods
+func TestFederationRelationships(t *testing.T) {
+	server := fakespireserver.New(t)
+	server.WithFederationRelationships(&types.FederationRelationship{
+		TrustDomain:       "existing.org",
+		BundleEndpointUrl: "https://galadriel.example.org/bundle/existing.org",
+		BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{
+			HttpsWeb: &types.HTTPSWebProfile{},
+		},
+	})
+
+	client, err := NewSpireClient(context.Background(), server.Addr)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	td1 := spiffeid.RequireTrustDomainFromString("td1.org")
+	td2 := spiffeid.RequireTrustDomainFromString("td2.org")
+	existing := spiffeid.RequireTrustDomainFromString("existing.org")
+	endpointID := spiffeid.RequireFromString("spiffe://galadriel.org/bundle-endpoint")
+
+	t.Run("List", func(t *testing.T) {
+		relationships, err := client.ListFederationRelationships(ctx)
+		require.NoError(t, err)
+		require.Len(t, relationships, 1)
+		assert.Equal(t, existing, relationships[0].TrustDomain)
+		assert.Equal(t, "https://galadriel.example.org/bundle/existing.org", relationships[0].BundleEndpointURL.String())
+		assert.Equal(t, HTTPSWebProfile, relationships[0].BundleEndpointProfile)
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		statuses, err := client.CreateFederationRelationships(ctx, []*FederationRelationship{
+			{
+				TrustDomain:           td1,
+				BundleEndpointURL:     mustParseURL(t, "https://galadriel.example.org/bundle/td1.org"),
+				BundleEndpointProfile: HTTPSWebProfile,
+			},
+			{
+				TrustDomain:           td2,
+				BundleEndpointURL:     mustParseURL(t, "https://galadriel.example.org/bundle/td2.org"),
+				BundleEndpointProfile: HTTPSSPIFFEProfile,
+				EndpointSPIFFEID:      endpointID,
+			},
+			{
+				TrustDomain:           existing,
+				BundleEndpointURL:     mustParseURL(t, "https://galadriel.example.org/bundle/existing.org"),
+				BundleEndpointProfile: HTTPSWebProfile,
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, statuses, 3)
+		assert.Equal(t, codes.OK, statuses[0].Status.Code)
+		assert.Equal(t, td1, statuses[0].FederationRelationship.TrustDomain)
+		assert.Equal(t, codes.OK, statuses[1].Status.Code)
+		assert.Equal(t, endpointID, statuses[1].FederationRelationship.EndpointSPIFFEID)
+		assert.Equal(t, codes.AlreadyExists, statuses[2].Status.Code)
+
+		stored := server.FederationRelationships()
+		require.Len(t, stored, 3)
+		assert.Equal(t, "td2.org", stored[2].TrustDomain)
+		assert.Equal(t, endpointID.String(), stored[2].GetHttpsSpiffe().EndpointSpiffeId)
+	})
+
+	t.Run("Create fails with an invalid relationship", func(t *testing.T) {
+		_, err := client.CreateFederationRelationships(ctx, []*FederationRelationship{
+			{
+				TrustDomain:           td1,
+				BundleEndpointURL:     mustParseURL(t, "https://galadriel.example.org/bundle/td1.org"),
+				BundleEndpointProfile: HTTPSSPIFFEProfile,
+			},
+		})
+		require.EqualError(t, err, "endpoint SPIFFE ID is required by the https_spiffe profile")
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		statuses, err := client.UpdateFederationRelationships(ctx, []*FederationRelationship{
+			{
+				TrustDomain:           td1,
+				BundleEndpointURL:     mustParseURL(t, "https://new.example.org/bundle/td1.org"),
+				BundleEndpointProfile: HTTPSSPIFFEProfile,
+				EndpointSPIFFEID:      endpointID,
+			},
+			{
+				TrustDomain:           spiffeid.RequireTrustDomainFromString("unknown.org"),
+				BundleEndpointURL:     mustParseURL(t, "https://new.example.org/bundle/unknown.org"),
+				BundleEndpointProfile: HTTPSWebProfile,
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, statuses, 2)
+		assert.Equal(t, codes.OK, statuses[0].Status.Code)
+		assert.Equal(t, "https://new.example.org/bundle/td1.org", statuses[0].FederationRelationship.BundleEndpointURL.String())
+		assert.Equal(t, HTTPSSPIFFEProfile, statuses[0].FederationRelationship.BundleEndpointProfile)
+		assert.Equal(t, codes.NotFound, statuses[1].Status.Code)
+		assert.Nil(t, statuses[1].FederationRelationship)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		statuses, err := client.DeleteFederationRelationships(ctx, []spiffeid.TrustDomain{td1, spiffeid.RequireTrustDomainFromString("unknown.org")})
+		require.NoError(t, err)
+		require.Len(t, statuses, 2)
+		assert.Equal(t, "td1.org", statuses[0].TrustDomain)
+		assert.Equal(t, codes.OK, statuses[0].Status.Code)
+		assert.Equal(t, codes.NotFound, statuses[1].Status.Code)
+
+		relationships, err := client.ListFederationRelationships(ctx)
+		require.NoError(t, err)
+		require.Len(t, relationships, 2)
+		assert.Equal(t, existing, relationships[0].TrustDomain)
+		assert.Equal(t, td2, relationships[1].TrustDomain)
+	})
+}
+
+func TestListFederationRelationshipsPaging(t *testing.T) {
+	server := fakespireserver.New(t)
+
+	relationships := make([]*types.FederationRelationship, 0, listFederationRelationshipsPageSize+1)
+	for i := 0; i <= listFederationRelationshipsPageSize; i++ {
+		td := fmt.Sprintf("td%d.org", i)
+		relationships = append(relationships, &types.FederationRelationship{
+			TrustDomain:           td,
+			BundleEndpointUrl:     "https://galadriel.example.org/bundle/" + td,
+			BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{HttpsWeb: &types.HTTPSWebProfile{}},
+		})
+	}
+	server.WithFederationRelationships(relationships...)
+
+	client, err := NewSpireClient(context.Background(), server.Addr)
+	require.NoError(t, err)
+
+	listed, err := client.ListFederationRelationships(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, listed, listFederationRelationshipsPageSize+1)
+}
+
+func TestEntries(t *testing.T) {
+	server := fakespireserver.New(t)
+	server.WithFederatedBundles(&types.Bundle{TrustDomain: "td1.org"})
+	server.WithEntries(
+		&types.Entry{
+			Id:        "entry-1",
+			SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload/a"},
+			ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent"},
+			Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+		},
+		&types.Entry{
+			Id:            "entry-2",
+			SpiffeId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload/b"},
+			ParentId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent"},
+			FederatesWith: []string{"td1.org"},
+		},
+	)
+
+	client, err := NewSpireClient(context.Background(), server.Addr)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	td1 := spiffeid.RequireTrustDomainFromString("td1.org")
+
+	t.Run("List", func(t *testing.T) {
+		entries, err := client.ListEntries(ctx)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+
+		assert.Equal(t, "entry-1", entries[0].ID)
+		assert.Equal(t, "spiffe://example.org/workload/a", entries[0].SPIFFEID.String())
+		assert.Equal(t, "spiffe://example.org/agent", entries[0].ParentID.String())
+		assert.Equal(t, []Selector{{Type: "unix", Value: "uid:1000"}}, entries[0].Selectors)
+		assert.Empty(t, entries[0].FederatesWith)
+
+		assert.Equal(t, []spiffeid.TrustDomain{td1}, entries[1].FederatesWith)
+	})
+
+	t.Run("Update federates with", func(t *testing.T) {
+		entries, err := client.ListEntries(ctx)
+		require.NoError(t, err)
+
+		entries[0].FederatesWith = []spiffeid.TrustDomain{td1}
+		entries[1].FederatesWith = []spiffeid.TrustDomain{spiffeid.RequireTrustDomainFromString("nobundle.org")}
+		unknown := &Entry{ID: "unknown"}
+
+		statuses, err := client.UpdateEntriesFederatesWith(ctx, []*Entry{entries[0], entries[1], unknown})
+		require.NoError(t, err)
+		require.Len(t, statuses, 3)
+
+		assert.Equal(t, codes.OK, statuses[0].Status.Code)
+		assert.Equal(t, []spiffeid.TrustDomain{td1}, statuses[0].Entry.FederatesWith)
+		assert.Equal(t, codes.InvalidArgument, statuses[1].Status.Code)
+		assert.Nil(t, statuses[1].Entry)
+		assert.Equal(t, codes.NotFound, statuses[2].Status.Code)
+
+		stored := server.Entries()
+		assert.Equal(t, []string{"td1.org"}, stored[0].FederatesWith)
+		assert.Equal(t, []string{"td1.org"}, stored[1].FederatesWith)
+	})
+
+	t.Run("Update fails without entry ID", func(t *testing.T) {
+		_, err := client.UpdateEntriesFederatesWith(ctx, []*Entry{{}})
+		require.EqualError(t, err, "entry ID is required")
+	})
+}
+
+func mustParseURL(t *testing.T, s string) *url.URL {
+	u, err := url.Parse(s)
+	require.NoError(t, err)
+	return u
+}
 
 func generateCertDER(t *testing.T) []byte {
 	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
@@ -5,11 +5,14 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	bundlev1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/bundle/v1"
+	entryv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/entry/v1"
+	trustdomainv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/trustdomain/v1"
 	apitypes "github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	"google.golang.org/grpc/codes"
 )
@@ -228,3 +231,334 @@ func protoToSpiffeBundles(resp *bundlev1.ListFederatedBundlesResponse) ([]*spiff
 
 	return out, nil
 }
+
+func protoToFederationRelationship(in *apitypes.FederationRelationship) (*FederationRelationship, error) {
+	if in == nil {
+		return nil, errors.New("federation relationship is empty")
+	}
+
+	td, err := spiffeid.TrustDomainFromString(in.TrustDomain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trust domain: %w", err)
+	}
+
+	bundleEndpointURL, err := url.Parse(in.BundleEndpointUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle endpoint URL: %w", err)
+	}
+
+	out := &FederationRelationship{
+		TrustDomain:       td,
+		BundleEndpointURL: bundleEndpointURL,
+	}
+
+	switch profile := in.BundleEndpointProfile.(type) {
+	case *apitypes.FederationRelationship_HttpsWeb:
+		out.BundleEndpointProfile = HTTPSWebProfile
+	case *apitypes.FederationRelationship_HttpsSpiffe:
+		out.BundleEndpointProfile = HTTPSSPIFFEProfile
+		out.EndpointSPIFFEID, err = spiffeid.FromString(profile.HttpsSpiffe.GetEndpointSpiffeId())
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint SPIFFE ID: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported bundle endpoint profile type: %T", profile)
+	}
+
+	if in.TrustDomainBundle != nil {
+		out.TrustDomainBundle, err = protoToBundle(in.TrustDomainBundle)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+func federationRelationshipToProto(in *FederationRelationship) (*apitypes.FederationRelationship, error) {
+	if in == nil {
+		return nil, errors.New("federation relationship is empty")
+	}
+
+	if in.BundleEndpointURL == nil {
+		return nil, errors.New("bundle endpoint URL is required")
+	}
+
+	out := &apitypes.FederationRelationship{
+		TrustDomain:       in.TrustDomain.String(),
+		BundleEndpointUrl: in.BundleEndpointURL.String(),
+	}
+
+	switch in.BundleEndpointProfile {
+	case HTTPSWebProfile:
+		out.BundleEndpointProfile = &apitypes.FederationRelationship_HttpsWeb{
+			HttpsWeb: &apitypes.HTTPSWebProfile{},
+		}
+	case HTTPSSPIFFEProfile:
+		if in.EndpointSPIFFEID.IsZero() {
+			return nil, errors.New("endpoint SPIFFE ID is required by the https_spiffe profile")
+		}
+		out.BundleEndpointProfile = &apitypes.FederationRelationship_HttpsSpiffe{
+			HttpsSpiffe: &apitypes.HTTPSSPIFFEProfile{
+				EndpointSpiffeId: in.EndpointSPIFFEID.String(),
+			},
+		}
+	default:
+		return nil, fmt.Errorf("unknown bundle endpoint profile: %q", in.BundleEndpointProfile)
+	}
+
+	if in.TrustDomainBundle != nil {
+		var err error
+		out.TrustDomainBundle, err = bundleToProto(in.TrustDomainBundle)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+func federationRelationshipsToProto(in []*FederationRelationship) ([]*apitypes.FederationRelationship, error) {
+	out := make([]*apitypes.FederationRelationship, 0, len(in))
+	for _, fr := range in {
+		protoFR, err := federationRelationshipToProto(fr)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, protoFR)
+	}
+
+	return out, nil
+}
+
+func protoToFederationRelationships(in []*apitypes.FederationRelationship) ([]*FederationRelationship, error) {
+	out := make([]*FederationRelationship, 0, len(in))
+	for _, protoFR := range in {
+		fr, err := protoToFederationRelationship(protoFR)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, fr)
+	}
+
+	return out, nil
+}
+
+// protoToStatus converts the status of a batch operation result.
+func protoToStatus(in *apitypes.Status) (*Status, error) {
+	if in == nil {
+		return nil, errors.New("call returned no status")
+	}
+
+	return &Status{
+		Message: in.GetMessage(),
+		Code:    codes.Code(in.GetCode()),
+	}, nil
+}
+
+func protoToBatchCreateFederationRelationshipResult(in *trustdomainv1.BatchCreateFederationRelationshipResponse) ([]*BatchCreateFederationRelationshipStatus, error) {
+	var out []*BatchCreateFederationRelationshipStatus
+
+	for _, r := range in.GetResults() {
+		status, err := protoToStatus(r.Status)
+		if err != nil {
+			return nil, err
+		}
+
+		var fr *FederationRelationship
+		if r.FederationRelationship != nil {
+			fr, err = protoToFederationRelationship(r.FederationRelationship)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		out = append(out, &BatchCreateFederationRelationshipStatus{
+			FederationRelationship: fr,
+			Status:                 status,
+		})
+	}
+
+	return out, nil
+}
+
+func protoToBatchUpdateFederationRelationshipResult(in *trustdomainv1.BatchUpdateFederationRelationshipResponse) ([]*BatchUpdateFederationRelationshipStatus, error) {
+	var out []*BatchUpdateFederationRelationshipStatus
+
+	for _, r := range in.GetResults() {
+		status, err := protoToStatus(r.Status)
+		if err != nil {
+			return nil, err
+		}
+
+		var fr *FederationRelationship
+		if r.FederationRelationship != nil {
+			fr, err = protoToFederationRelationship(r.FederationRelationship)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		out = append(out, &BatchUpdateFederationRelationshipStatus{
+			FederationRelationship: fr,
+			Status:                 status,
+		})
+	}
+
+	return out, nil
+}
+
+func protoToBatchDeleteFederationRelationshipResult(in *trustdomainv1.BatchDeleteFederationRelationshipResponse) ([]*BatchDeleteFederationRelationshipStatus, error) {
+	var out []*BatchDeleteFederationRelationshipStatus
+
+	for _, r := range in.GetResults() {
+		status, err := protoToStatus(r.Status)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, &BatchDeleteFederationRelationshipStatus{
+			TrustDomain: r.TrustDomain,
+			Status:      status,
+		})
+	}
+
+	return out, nil
+}
+
+func protoToEntry(in *apitypes.Entry) (*Entry, error) {
+	if in == nil {
+		return nil, errors.New("entry is empty")
+	}
+
+	spiffeID, err := protoToSPIFFEID(in.SpiffeId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SPIFFE ID of entry %q: %v", in.Id, err)
+	}
+
+	parentID, err := protoToSPIFFEID(in.ParentId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse parent ID of entry %q: %v", in.Id, err)
+	}
+
+	selectors := make([]Selector, 0, len(in.Selectors))
+	for _, s := range in.Selectors {
+		selectors = append(selectors, Selector{Type: s.Type, Value: s.Value})
+	}
+
+	federatesWith := make([]spiffeid.TrustDomain, 0, len(in.FederatesWith))
+	for _, td := range in.FederatesWith {
+		trustDomain, err := spiffeid.TrustDomainFromString(td)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse federated trust domain of entry %q: %v", in.Id, err)
+		}
+		federatesWith = append(federatesWith, trustDomain)
+	}
+
+	return &Entry{
+		ID:            in.Id,
+		SPIFFEID:      spiffeID,
+		ParentID:      parentID,
+		Selectors:     selectors,
+		FederatesWith: federatesWith,
+	}, nil
+}
+
+func protoToEntries(in []*apitypes.Entry) ([]*Entry, error) {
+	out := make([]*Entry, 0, len(in))
+	for _, e := range in {
+		entry, err := protoToEntry(e)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, entry)
+	}
+
+	return out, nil
+}
+
+func entryToProto(in *Entry) (*apitypes.Entry, error) {
+	if in.ID == "" {
+		return nil, errors.New("entry ID is required")
+	}
+
+	out := &apitypes.Entry{
+		Id:            in.ID,
+		SpiffeId:      spiffeIDToProto(in.SPIFFEID),
+		ParentId:      spiffeIDToProto(in.ParentID),
+		FederatesWith: make([]string, 0, len(in.FederatesWith)),
+	}
+
+	for _, s := range in.Selectors {
+		out.Selectors = append(out.Selectors, &apitypes.Selector{Type: s.Type, Value: s.Value})
+	}
+
+	for _, td := range in.FederatesWith {
+		out.FederatesWith = append(out.FederatesWith, td.String())
+	}
+
+	return out, nil
+}
+
+func entriesToProto(in []*Entry) ([]*apitypes.Entry, error) {
+	out := make([]*apitypes.Entry, 0, len(in))
+	for _, e := range in {
+		entry, err := entryToProto(e)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, entry)
+	}
+
+	return out, nil
+}
+
+func protoToSPIFFEID(in *apitypes.SPIFFEID) (spiffeid.ID, error) {
+	if in == nil {
+		return spiffeid.ID{}, nil
+	}
+
+	td, err := spiffeid.TrustDomainFromString(in.TrustDomain)
+	if err != nil {
+		return spiffeid.ID{}, err
+	}
+
+	return spiffeid.FromPath(td, in.Path)
+}
+
+func spiffeIDToProto(in spiffeid.ID) *apitypes.SPIFFEID {
+	if in.IsZero() {
+		return nil
+	}
+
+	return &apitypes.SPIFFEID{
+		TrustDomain: in.TrustDomain().String(),
+		Path:        in.Path(),
+	}
+}
+
+func protoToBatchUpdateEntryResult(in *entryv1.BatchUpdateEntryResponse) ([]*BatchUpdateEntryStatus, error) {
+	var out []*BatchUpdateEntryStatus
+
+	for _, r := range in.GetResults() {
+		status, err := protoToStatus(r.Status)
+		if err != nil {
+			return nil, err
+		}
+
+		var entry *Entry
+		if r.Entry != nil {
+			entry, err = protoToEntry(r.Entry)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		out = append(out, &BatchUpdateEntryStatus{
+			Entry:  entry,
+			Status: status,
+		})
+	}
+
+	return out, nil
+}
@@ -1,7 +1,10 @@
 package spireclient
 
 import (
+	"net/url"
+
 	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"google.golang.org/grpc/codes"
 )
 
@@ -28,3 +31,59 @@ type BatchGetFederatedBundleStatus struct {
 type ListFederatedBundlesResponse struct {
 	Bundles []*spiffebundle.Bundle
 }
+
+// BundleEndpointProfile is the profile used to authenticate the bundle endpoint of a federation relationship.
+type BundleEndpointProfile string
+
+const (
+	// HTTPSWebProfile authenticates the bundle endpoint using Web PKI.
+	HTTPSWebProfile BundleEndpointProfile = "https_web"
+	// HTTPSSPIFFEProfile authenticates the bundle endpoint using its X509-SVID.
+	HTTPSSPIFFEProfile BundleEndpointProfile = "https_spiffe"
+)
+
+// FederationRelationship represents a SPIRE federation relationship with a foreign trust domain.
+type FederationRelationship struct {
+	TrustDomain           spiffeid.TrustDomain
+	BundleEndpointURL     *url.URL
+	BundleEndpointProfile BundleEndpointProfile
+	// EndpointSPIFFEID is the SPIFFE ID of the bundle endpoint, only used by the https_spiffe profile.
+	EndpointSPIFFEID spiffeid.ID
+	// TrustDomainBundle is optional, when set, it is used as the bundle of the trust domain.
+	TrustDomainBundle *spiffebundle.Bundle
+}
+
+type BatchCreateFederationRelationshipStatus struct {
+	FederationRelationship *FederationRelationship
+	Status                 *Status
+}
+
+type BatchUpdateFederationRelationshipStatus struct {
+	FederationRelationship *FederationRelationship
+	Status                 *Status
+}
+
+type BatchDeleteFederationRelationshipStatus struct {
+	TrustDomain string
+	Status      *Status
+}
+
+// Selector is a selector of a SPIRE registration entry, e.g. type "k8s" and value "ns:payments".
+type Selector struct {
+	Type  string
+	Value string
+}
+
+// Entry represents a SPIRE registration entry. Only the fields managed or matched by the Harvester are mapped.
+type Entry struct {
+	ID            string
+	SPIFFEID      spiffeid.ID
+	ParentID      spiffeid.ID
+	Selectors     []Selector
+	FederatesWith []spiffeid.TrustDomain
+}
+
+type BatchUpdateEntryStatus struct {
+	Entry  *Entry
+	Status *Status
+}
@@ -15,6 +15,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	externalRef0 "github.com/HewlettPackard/galadriel/pkg/common/api"
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
@@ -22,11 +23,97 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// AuditEvent defines model for AuditEvent.
+type AuditEvent struct {
+	Action string `json:"action"`
+
+	// Actor Actor of the action, admin for the admin API or harvester:<trust domain> for a Harvester
+	Actor     string    `json:"actor"`
+	CreatedAt time.Time `json:"created_at"`
+	Details   *string   `json:"details,omitempty"`
+
+	// Hash hex encoded SHA-256 hash of the previous hash and the content of the event
+	Hash string `json:"hash"`
+	Id   int64  `json:"id"`
+
+	// PrevHash hex encoded SHA-256 hash of the previous event of the audit log
+	PrevHash string `json:"prev_hash"`
+
+	// Target Trust Domain, relationship or bundle the action applies to
+	Target string `json:"target"`
+}
+
+// BundleDiff defines model for BundleDiff.
+type BundleDiff struct {
+	AddedJwtAuthorities    []string            `json:"added_jwt_authorities"`
+	AddedX509Authorities   []X509Authority     `json:"added_x509_authorities"`
+	BaseVersion            BundleVersionNumber `json:"base_version"`
+	RemovedJwtAuthorities  []string            `json:"removed_jwt_authorities"`
+	RemovedX509Authorities []X509Authority     `json:"removed_x509_authorities"`
+	Version                BundleVersionNumber `json:"version"`
+}
+
+// BundleResetApproval defines model for BundleResetApproval.
+type BundleResetApproval struct {
+	CreatedAt       time.Time                    `json:"created_at"`
+	TrustDomainName externalRef0.TrustDomainName `json:"trust_domain_name"`
+}
+
+// BundleVersion defines model for BundleVersion.
+type BundleVersion struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Digest base64 encoded SHA-256 digest of the bundle
+	Digest externalRef0.BundleDigest `json:"digest"`
+
+	// Pinned Whether this version is pinned and served to the federated Trust Domains
+	Pinned bool `json:"pinned"`
+
+	// Signature base64 encoded signature of the bundle
+	Signature *externalRef0.Signature `json:"signature,omitempty"`
+
+	// SigningCertificateChain X.509 certificate chain in PEM format
+	SigningCertificateChain *externalRef0.CertificateChain `json:"signing_certificate_chain,omitempty"`
+
+	// TrustBundle SPIFFE Trust bundle in JSON format
+	TrustBundle externalRef0.TrustBundle `json:"trust_bundle"`
+
+	// UploadedBy ID of the Harvester instance that uploaded the bundle, when the Harvesters run in HA mode
+	UploadedBy *string             `json:"uploaded_by,omitempty"`
+	Version    BundleVersionNumber `json:"version"`
+}
+
+// BundleVersionNumber defines model for BundleVersionNumber.
+type BundleVersionNumber = int64
+
 // DeleteResponse defines model for DeleteResponse.
 type DeleteResponse struct {
 	Schema *externalRef0.DeleteResponse `json:"schema,omitempty"`
 }
 
+// JoinTokenDetails defines model for JoinTokenDetails.
+type JoinTokenDetails struct {
+	CreatedAt time.Time `json:"created_at"`
+	Expired   bool      `json:"expired"`
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// HarvesterKeyFingerprint Fingerprint of the key of the only Harvester that can use the join token
+	HarvesterKeyFingerprint *string           `json:"harvester_key_fingerprint,omitempty"`
+	Id                      externalRef0.UUID `json:"id"`
+
+	// MaskedToken Join token with all but its last characters masked
+	MaskedToken string `json:"masked_token"`
+	MaxUses     int32  `json:"max_uses"`
+
+	// SourceCidr Network from which the join token can be used
+	SourceCidr      *string                      `json:"source_cidr,omitempty"`
+	TrustDomainName externalRef0.TrustDomainName `json:"trust_domain_name"`
+	UseCount        int32                        `json:"use_count"`
+
+	// Used Whether the join token was used as many times as allowed
+	Used bool `json:"used"`
+}
+
 // JoinTokenResponse defines model for JoinTokenResponse.
 type JoinTokenResponse struct {
 	Token externalRef0.JoinToken `json:"token"`
@@ -46,13 +133,47 @@ type PutRelationshipRequest struct {
 
 // PutTrustDomainRequest defines model for PutTrustDomainRequest.
 type PutTrustDomainRequest struct {
-	Description *string                      `json:"description,omitempty"`
-	Name        externalRef0.TrustDomainName `json:"name"`
+	BundleVerifier *string                      `json:"bundle_verifier,omitempty"`
+	Description    *string                      `json:"description,omitempty"`
+	Name           externalRef0.TrustDomainName `json:"name"`
+}
+
+// X509Authority defines model for X509Authority.
+type X509Authority struct {
+	NotAfter time.Time `json:"not_after"`
+
+	// Sha256 hex encoded SHA-256 fingerprint of the certificate
+	Sha256  string `json:"sha256"`
+	Subject string `json:"subject"`
 }
 
 // Default defines model for Default.
 type Default = externalRef0.ApiError
 
+// ListAuditEventsParams defines parameters for ListAuditEvents.
+type ListAuditEventsParams struct {
+	// Since Only list the events recorded at or after this time.
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only list the events recorded before this time.
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+
+	// Actor Only list the events of this actor.
+	Actor *string `form:"actor,omitempty" json:"actor,omitempty"`
+}
+
+// ListJoinTokensParams defines parameters for ListJoinTokens.
+type ListJoinTokensParams struct {
+	// TrustDomainName Only list the join tokens of this Trust Domain.
+	TrustDomainName *externalRef0.TrustDomainName `form:"trustDomainName,omitempty" json:"trustDomainName,omitempty"`
+
+	// Used Only list the join tokens that were used, or that were not used.
+	Used *bool `form:"used,omitempty" json:"used,omitempty"`
+
+	// Expired Only list the join tokens that expired, or that did not expire.
+	Expired *bool `form:"expired,omitempty" json:"expired,omitempty"`
+}
+
 // GetRelationshipsParams defines parameters for GetRelationships.
 type GetRelationshipsParams struct {
 	// ConsentStatus relationship status from a Trust Domain perspective.
@@ -68,10 +189,25 @@ type GetRelationshipsParams struct {
 	PageNumber *externalRef0.PageNumber `form:"pageNumber,omitempty" json:"pageNumber,omitempty"`
 }
 
+// GetBundleVersionDiffParams defines parameters for GetBundleVersionDiff.
+type GetBundleVersionDiffParams struct {
+	// Base Version to compare against. Defaults to the version preceding the requested one.
+	Base *BundleVersionNumber `form:"base,omitempty" json:"base,omitempty"`
+}
+
 // GetJoinTokenParams defines parameters for GetJoinToken.
 type GetJoinTokenParams struct {
 	// Ttl Time-to-Live (TTL) in seconds for the join token
 	Ttl int32 `form:"ttl" json:"ttl"`
+
+	// MaxUses Number of times the join token can be used to onboard a Harvester
+	MaxUses *int32 `form:"maxUses,omitempty" json:"maxUses,omitempty"`
+
+	// HarvesterKeyFingerprint Hex encoded SHA-256 fingerprint of the public key of the only Harvester that can use the join token
+	HarvesterKeyFingerprint *string `form:"harvesterKeyFingerprint,omitempty" json:"harvesterKeyFingerprint,omitempty"`
+
+	// SourceCIDR Network from which the join token can be used, in CIDR notation
+	SourceCIDR *string `form:"sourceCIDR,omitempty" json:"sourceCIDR,omitempty"`
 }
 
 // PutRelationshipJSONRequestBody defines body for PutRelationship for application/json ContentType.
@@ -159,6 +295,18 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// ListAuditEvents request
+	ListAuditEvents(ctx context.Context, params *ListAuditEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListJoinTokens request
+	ListJoinTokens(ctx context.Context, params *ListJoinTokensParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RevokeJoinToken request
+	RevokeJoinToken(ctx context.Context, joinTokenID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetJoinTokenByID request
+	GetJoinTokenByID(ctx context.Context, joinTokenID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetRelationships request
 	GetRelationships(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -178,6 +326,24 @@ type ClientInterface interface {
 
 	PatchRelationshipByID(ctx context.Context, relationshipID externalRef0.UUID, body PatchRelationshipByIDJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetRelationshipConsents request
+	GetRelationshipConsents(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ApproveBundleReset request
+	ApproveBundleReset(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListBundleVersions request
+	ListBundleVersions(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBundleVersion request
+	GetBundleVersion(ctx context.Context, trustDomainName externalRef0.TrustDomainName, version BundleVersionNumber, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBundleVersionDiff request
+	GetBundleVersionDiff(ctx context.Context, trustDomainName externalRef0.TrustDomainName, version BundleVersionNumber, params *GetBundleVersionDiffParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RollbackBundle request
+	RollbackBundle(ctx context.Context, trustDomainName externalRef0.TrustDomainName, version BundleVersionNumber, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetJoinToken request
 	GetJoinToken(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetJoinTokenParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -201,6 +367,54 @@ type ClientInterface interface {
 	PutTrustDomainByName(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainByNameJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
+func (c *Client) ListAuditEvents(ctx context.Context, params *ListAuditEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListAuditEventsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListJoinTokens(ctx context.Context, params *ListJoinTokensParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListJoinTokensRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RevokeJoinToken(ctx context.Context, joinTokenID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRevokeJoinTokenRequest(c.Server, joinTokenID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetJoinTokenByID(ctx context.Context, joinTokenID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetJoinTokenByIDRequest(c.Server, joinTokenID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetRelationships(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetRelationshipsRequest(c.Server, params)
 	if err != nil {
@@ -285,6 +499,78 @@ func (c *Client) PatchRelationshipByID(ctx context.Context, relationshipID exter
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetRelationshipConsents(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRelationshipConsentsRequest(c.Server, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ApproveBundleReset(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewApproveBundleResetRequest(c.Server, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListBundleVersions(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListBundleVersionsRequest(c.Server, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBundleVersion(ctx context.Context, trustDomainName externalRef0.TrustDomainName, version BundleVersionNumber, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBundleVersionRequest(c.Server, trustDomainName, version)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBundleVersionDiff(ctx context.Context, trustDomainName externalRef0.TrustDomainName, version BundleVersionNumber, params *GetBundleVersionDiffParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBundleVersionDiffRequest(c.Server, trustDomainName, version, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RollbackBundle(ctx context.Context, trustDomainName externalRef0.TrustDomainName, version BundleVersionNumber, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRollbackBundleRequest(c.Server, trustDomainName, version)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetJoinToken(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetJoinTokenParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetJoinTokenRequest(c.Server, trustDomainName, params)
 	if err != nil {
@@ -381,8 +667,8 @@ func (c *Client) PutTrustDomainByName(ctx context.Context, trustDomainName exter
 	return c.Client.Do(req)
 }
 
-// NewGetRelationshipsRequest generates requests for GetRelationships
-func NewGetRelationshipsRequest(server string, params *GetRelationshipsParams) (*http.Request, error) {
+// NewListAuditEventsRequest generates requests for ListAuditEvents
+func NewListAuditEventsRequest(server string, params *ListAuditEventsParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -390,7 +676,7 @@ func NewGetRelationshipsRequest(server string, params *GetRelationshipsParams) (
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/relationships")
+	operationPath := fmt.Sprintf("/audit-events")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -403,25 +689,9 @@ func NewGetRelationshipsRequest(server string, params *GetRelationshipsParams) (
 	if params != nil {
 		queryValues := queryURL.Query()
 
-		if params.ConsentStatus != nil {
-
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "consentStatus", runtime.ParamLocationQuery, *params.ConsentStatus); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
-		if params.TrustDomainName != nil {
+		if params.Since != nil {
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "trustDomainName", runtime.ParamLocationQuery, *params.TrustDomainName); err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -435,9 +705,9 @@ func NewGetRelationshipsRequest(server string, params *GetRelationshipsParams) (
 
 		}
 
-		if params.PageSize != nil {
+		if params.Until != nil {
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageSize", runtime.ParamLocationQuery, *params.PageSize); err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "until", runtime.ParamLocationQuery, *params.Until); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -451,9 +721,9 @@ func NewGetRelationshipsRequest(server string, params *GetRelationshipsParams) (
 
 		}
 
-		if params.PageNumber != nil {
+		if params.Actor != nil {
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageNumber", runtime.ParamLocationQuery, *params.PageNumber); err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "actor", runtime.ParamLocationQuery, *params.Actor); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -478,19 +748,8 @@ func NewGetRelationshipsRequest(server string, params *GetRelationshipsParams) (
 	return req, nil
 }
 
-// NewPutRelationshipRequest calls the generic PutRelationship builder with application/json body
-func NewPutRelationshipRequest(server string, body PutRelationshipJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPutRelationshipRequestWithBody(server, "application/json", bodyReader)
-}
-
-// NewPutRelationshipRequestWithBody generates requests for PutRelationship with any type of body
-func NewPutRelationshipRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewListJoinTokensRequest generates requests for ListJoinTokens
+func NewListJoinTokensRequest(server string, params *ListJoinTokensParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -498,7 +757,7 @@ func NewPutRelationshipRequestWithBody(server string, contentType string, body i
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/relationships")
+	operationPath := fmt.Sprintf("/join-tokens")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -508,23 +767,75 @@ func NewPutRelationshipRequestWithBody(server string, contentType string, body i
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.TrustDomainName != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "trustDomainName", runtime.ParamLocationQuery, *params.TrustDomainName); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Used != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "used", runtime.ParamLocationQuery, *params.Used); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Expired != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "expired", runtime.ParamLocationQuery, *params.Expired); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
-
 	return req, nil
 }
 
-// NewDeleteRelationshipByIDRequest generates requests for DeleteRelationshipByID
-func NewDeleteRelationshipByIDRequest(server string, relationshipID externalRef0.UUID) (*http.Request, error) {
+// NewRevokeJoinTokenRequest generates requests for RevokeJoinToken
+func NewRevokeJoinTokenRequest(server string, joinTokenID externalRef0.UUID) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, relationshipID)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "joinTokenID", runtime.ParamLocationPath, joinTokenID)
 	if err != nil {
 		return nil, err
 	}
@@ -534,7 +845,7 @@ func NewDeleteRelationshipByIDRequest(server string, relationshipID externalRef0
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/relationships/%s", pathParam0)
+	operationPath := fmt.Sprintf("/join-tokens/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -552,13 +863,13 @@ func NewDeleteRelationshipByIDRequest(server string, relationshipID externalRef0
 	return req, nil
 }
 
-// NewGetRelationshipByIDRequest generates requests for GetRelationshipByID
-func NewGetRelationshipByIDRequest(server string, relationshipID externalRef0.UUID) (*http.Request, error) {
+// NewGetJoinTokenByIDRequest generates requests for GetJoinTokenByID
+func NewGetJoinTokenByIDRequest(server string, joinTokenID externalRef0.UUID) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, relationshipID)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "joinTokenID", runtime.ParamLocationPath, joinTokenID)
 	if err != nil {
 		return nil, err
 	}
@@ -568,7 +879,7 @@ func NewGetRelationshipByIDRequest(server string, relationshipID externalRef0.UU
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/relationships/%s", pathParam0)
+	operationPath := fmt.Sprintf("/join-tokens/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -586,34 +897,124 @@ func NewGetRelationshipByIDRequest(server string, relationshipID externalRef0.UU
 	return req, nil
 }
 
-// NewPatchRelationshipByIDRequest calls the generic PatchRelationshipByID builder with application/json body
-func NewPatchRelationshipByIDRequest(server string, relationshipID externalRef0.UUID, body PatchRelationshipByIDJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+// NewGetRelationshipsRequest generates requests for GetRelationships
+func NewGetRelationshipsRequest(server string, params *GetRelationshipsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPatchRelationshipByIDRequestWithBody(server, relationshipID, "application/json", bodyReader)
-}
 
-// NewPatchRelationshipByIDRequestWithBody generates requests for PatchRelationshipByID with any type of body
-func NewPatchRelationshipByIDRequestWithBody(server string, relationshipID externalRef0.UUID, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
+	operationPath := fmt.Sprintf("/relationships")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	var pathParam0 string
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, relationshipID)
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ConsentStatus != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "consentStatus", runtime.ParamLocationQuery, *params.ConsentStatus); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TrustDomainName != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "trustDomainName", runtime.ParamLocationQuery, *params.TrustDomainName); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageSize", runtime.ParamLocationQuery, *params.PageSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageNumber != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageNumber", runtime.ParamLocationQuery, *params.PageNumber); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutRelationshipRequest calls the generic PutRelationship builder with application/json body
+func NewPutRelationshipRequest(server string, body PutRelationshipJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutRelationshipRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPutRelationshipRequestWithBody generates requests for PutRelationship with any type of body
+func NewPutRelationshipRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/relationships/%s", pathParam0)
+	operationPath := fmt.Sprintf("/relationships")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -623,7 +1024,7 @@ func NewPatchRelationshipByIDRequestWithBody(server string, relationshipID exter
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -633,13 +1034,13 @@ func NewPatchRelationshipByIDRequestWithBody(server string, relationshipID exter
 	return req, nil
 }
 
-// NewGetJoinTokenRequest generates requests for GetJoinToken
-func NewGetJoinTokenRequest(server string, trustDomainName externalRef0.TrustDomainName, params *GetJoinTokenParams) (*http.Request, error) {
+// NewDeleteRelationshipByIDRequest generates requests for DeleteRelationshipByID
+func NewDeleteRelationshipByIDRequest(server string, relationshipID externalRef0.UUID) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, relationshipID)
 	if err != nil {
 		return nil, err
 	}
@@ -649,7 +1050,7 @@ func NewGetJoinTokenRequest(server string, trustDomainName externalRef0.TrustDom
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/trust-domain/%s/join-token", pathParam0)
+	operationPath := fmt.Sprintf("/relationships/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -659,25 +1060,7 @@ func NewGetJoinTokenRequest(server string, trustDomainName externalRef0.TrustDom
 		return nil, err
 	}
 
-	if params != nil {
-		queryValues := queryURL.Query()
-
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ttl", runtime.ParamLocationQuery, params.Ttl); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
-				}
-			}
-		}
-
-		queryURL.RawQuery = queryValues.Encode()
-	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -685,16 +1068,23 @@ func NewGetJoinTokenRequest(server string, trustDomainName externalRef0.TrustDom
 	return req, nil
 }
 
-// NewListTrustDomainsRequest generates requests for ListTrustDomains
-func NewListTrustDomainsRequest(server string) (*http.Request, error) {
+// NewGetRelationshipByIDRequest generates requests for GetRelationshipByID
+func NewGetRelationshipByIDRequest(server string, relationshipID externalRef0.UUID) (*http.Request, error) {
 	var err error
 
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/trust-domains")
+	operationPath := fmt.Sprintf("/relationships/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -712,27 +1102,34 @@ func NewListTrustDomainsRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewPutTrustDomainRequest calls the generic PutTrustDomain builder with application/json body
-func NewPutTrustDomainRequest(server string, body PutTrustDomainJSONRequestBody) (*http.Request, error) {
+// NewPatchRelationshipByIDRequest calls the generic PatchRelationshipByID builder with application/json body
+func NewPatchRelationshipByIDRequest(server string, relationshipID externalRef0.UUID, body PatchRelationshipByIDJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPutTrustDomainRequestWithBody(server, "application/json", bodyReader)
+	return NewPatchRelationshipByIDRequestWithBody(server, relationshipID, "application/json", bodyReader)
 }
 
-// NewPutTrustDomainRequestWithBody generates requests for PutTrustDomain with any type of body
-func NewPutTrustDomainRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPatchRelationshipByIDRequestWithBody generates requests for PatchRelationshipByID with any type of body
+func NewPatchRelationshipByIDRequestWithBody(server string, relationshipID externalRef0.UUID, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/trust-domains")
+	operationPath := fmt.Sprintf("/relationships/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -742,7 +1139,7 @@ func NewPutTrustDomainRequestWithBody(server string, contentType string, body io
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -752,13 +1149,13 @@ func NewPutTrustDomainRequestWithBody(server string, contentType string, body io
 	return req, nil
 }
 
-// NewDeleteTrustDomainByNameRequest generates requests for DeleteTrustDomainByName
-func NewDeleteTrustDomainByNameRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
+// NewGetRelationshipConsentsRequest generates requests for GetRelationshipConsents
+func NewGetRelationshipConsentsRequest(server string, relationshipID externalRef0.UUID) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, relationshipID)
 	if err != nil {
 		return nil, err
 	}
@@ -768,7 +1165,7 @@ func NewDeleteTrustDomainByNameRequest(server string, trustDomainName externalRe
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/trust-domains/%s", pathParam0)
+	operationPath := fmt.Sprintf("/relationships/%s/consents", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -778,7 +1175,7 @@ func NewDeleteTrustDomainByNameRequest(server string, trustDomainName externalRe
 		return nil, err
 	}
 
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -786,8 +1183,8 @@ func NewDeleteTrustDomainByNameRequest(server string, trustDomainName externalRe
 	return req, nil
 }
 
-// NewGetTrustDomainByNameRequest generates requests for GetTrustDomainByName
-func NewGetTrustDomainByNameRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
+// NewApproveBundleResetRequest generates requests for ApproveBundleReset
+func NewApproveBundleResetRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -802,7 +1199,7 @@ func NewGetTrustDomainByNameRequest(server string, trustDomainName externalRef0.
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/trust-domains/%s", pathParam0)
+	operationPath := fmt.Sprintf("/trust-domain/%s/bundle-reset", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -812,7 +1209,7 @@ func NewGetTrustDomainByNameRequest(server string, trustDomainName externalRef0.
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -820,19 +1217,8 @@ func NewGetTrustDomainByNameRequest(server string, trustDomainName externalRef0.
 	return req, nil
 }
 
-// NewPutTrustDomainByNameRequest calls the generic PutTrustDomainByName builder with application/json body
-func NewPutTrustDomainByNameRequest(server string, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainByNameJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPutTrustDomainByNameRequestWithBody(server, trustDomainName, "application/json", bodyReader)
-}
-
-// NewPutTrustDomainByNameRequestWithBody generates requests for PutTrustDomainByName with any type of body
-func NewPutTrustDomainByNameRequestWithBody(server string, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader) (*http.Request, error) {
+// NewListBundleVersionsRequest generates requests for ListBundleVersions
+func NewListBundleVersionsRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -847,7 +1233,7 @@ func NewPutTrustDomainByNameRequestWithBody(server string, trustDomainName exter
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/trust-domains/%s", pathParam0)
+	operationPath := fmt.Sprintf("/trust-domain/%s/bundles", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -857,498 +1243,1581 @@ func NewPutTrustDomainByNameRequestWithBody(server string, trustDomainName exter
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
-
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewGetBundleVersionRequest generates requests for GetBundleVersion
+func NewGetBundleVersionRequest(server string, trustDomainName externalRef0.TrustDomainName, version BundleVersionNumber) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
 	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+	var pathParam1 string
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "version", runtime.ParamLocationPath, version)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-}
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GetRelationships request
-	GetRelationshipsWithResponse(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*GetRelationshipsResponse, error)
+	operationPath := fmt.Sprintf("/trust-domain/%s/bundles/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	// PutRelationship request with any body
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetBundleVersionDiffRequest generates requests for GetBundleVersionDiff
+func NewGetBundleVersionDiffRequest(server string, trustDomainName externalRef0.TrustDomainName, version BundleVersionNumber, params *GetBundleVersionDiffParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "version", runtime.ParamLocationPath, version)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/bundles/%s/diff", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Base != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "base", runtime.ParamLocationQuery, *params.Base); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRollbackBundleRequest generates requests for RollbackBundle
+func NewRollbackBundleRequest(server string, trustDomainName externalRef0.TrustDomainName, version BundleVersionNumber) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "version", runtime.ParamLocationPath, version)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/bundles/%s/rollback", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetJoinTokenRequest generates requests for GetJoinToken
+func NewGetJoinTokenRequest(server string, trustDomainName externalRef0.TrustDomainName, params *GetJoinTokenParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/join-token", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ttl", runtime.ParamLocationQuery, params.Ttl); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.MaxUses != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "maxUses", runtime.ParamLocationQuery, *params.MaxUses); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.HarvesterKeyFingerprint != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "harvesterKeyFingerprint", runtime.ParamLocationQuery, *params.HarvesterKeyFingerprint); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.SourceCIDR != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sourceCIDR", runtime.ParamLocationQuery, *params.SourceCIDR); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListTrustDomainsRequest generates requests for ListTrustDomains
+func NewListTrustDomainsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domains")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutTrustDomainRequest calls the generic PutTrustDomain builder with application/json body
+func NewPutTrustDomainRequest(server string, body PutTrustDomainJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutTrustDomainRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPutTrustDomainRequestWithBody generates requests for PutTrustDomain with any type of body
+func NewPutTrustDomainRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domains")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteTrustDomainByNameRequest generates requests for DeleteTrustDomainByName
+func NewDeleteTrustDomainByNameRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domains/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTrustDomainByNameRequest generates requests for GetTrustDomainByName
+func NewGetTrustDomainByNameRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domains/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutTrustDomainByNameRequest calls the generic PutTrustDomainByName builder with application/json body
+func NewPutTrustDomainByNameRequest(server string, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainByNameJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutTrustDomainByNameRequestWithBody(server, trustDomainName, "application/json", bodyReader)
+}
+
+// NewPutTrustDomainByNameRequestWithBody generates requests for PutTrustDomainByName with any type of body
+func NewPutTrustDomainByNameRequestWithBody(server string, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domains/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// ListAuditEvents request
+	ListAuditEventsWithResponse(ctx context.Context, params *ListAuditEventsParams, reqEditors ...RequestEditorFn) (*ListAuditEventsResponse, error)
+
+	// ListJoinTokens request
+	ListJoinTokensWithResponse(ctx context.Context, params *ListJoinTokensParams, reqEditors ...RequestEditorFn) (*ListJoinTokensResponse, error)
+
+	// RevokeJoinToken request
+	RevokeJoinTokenWithResponse(ctx context.Context, joinTokenID externalRef0.UUID, reqEditors ...RequestEditorFn) (*RevokeJoinTokenResponse, error)
+
+	// GetJoinTokenByID request
+	GetJoinTokenByIDWithResponse(ctx context.Context, joinTokenID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetJoinTokenByIDResponse, error)
+
+	// GetRelationships request
+	GetRelationshipsWithResponse(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*GetRelationshipsResponse, error)
+
+	// PutRelationship request with any body
 	PutRelationshipWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutRelationshipResponse, error)
 
-	PutRelationshipWithResponse(ctx context.Context, body PutRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*PutRelationshipResponse, error)
+	PutRelationshipWithResponse(ctx context.Context, body PutRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*PutRelationshipResponse, error)
+
+	// DeleteRelationshipByID request
+	DeleteRelationshipByIDWithResponse(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*DeleteRelationshipByIDResponse, error)
+
+	// GetRelationshipByID request
+	GetRelationshipByIDWithResponse(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipByIDResponse, error)
+
+	// PatchRelationshipByID request with any body
+	PatchRelationshipByIDWithBodyWithResponse(ctx context.Context, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRelationshipByIDResponse, error)
+
+	PatchRelationshipByIDWithResponse(ctx context.Context, relationshipID externalRef0.UUID, body PatchRelationshipByIDJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRelationshipByIDResponse, error)
+
+	// GetRelationshipConsents request
+	GetRelationshipConsentsWithResponse(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipConsentsResponse, error)
+
+	// ApproveBundleReset request
+	ApproveBundleResetWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*ApproveBundleResetResponse, error)
+
+	// ListBundleVersions request
+	ListBundleVersionsWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*ListBundleVersionsResponse, error)
+
+	// GetBundleVersion request
+	GetBundleVersionWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, version BundleVersionNumber, reqEditors ...RequestEditorFn) (*GetBundleVersionResponse, error)
+
+	// GetBundleVersionDiff request
+	GetBundleVersionDiffWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, version BundleVersionNumber, params *GetBundleVersionDiffParams, reqEditors ...RequestEditorFn) (*GetBundleVersionDiffResponse, error)
+
+	// RollbackBundle request
+	RollbackBundleWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, version BundleVersionNumber, reqEditors ...RequestEditorFn) (*RollbackBundleResponse, error)
+
+	// GetJoinToken request
+	GetJoinTokenWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetJoinTokenParams, reqEditors ...RequestEditorFn) (*GetJoinTokenResponse, error)
+
+	// ListTrustDomains request
+	ListTrustDomainsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTrustDomainsResponse, error)
+
+	// PutTrustDomain request with any body
+	PutTrustDomainWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTrustDomainResponse, error)
+
+	PutTrustDomainWithResponse(ctx context.Context, body PutTrustDomainJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTrustDomainResponse, error)
+
+	// DeleteTrustDomainByName request
+	DeleteTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*DeleteTrustDomainByNameResponse, error)
+
+	// GetTrustDomainByName request
+	GetTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetTrustDomainByNameResponse, error)
+
+	// PutTrustDomainByName request with any body
+	PutTrustDomainByNameWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTrustDomainByNameResponse, error)
+
+	PutTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainByNameJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTrustDomainByNameResponse, error)
+}
+
+type ListAuditEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]AuditEvent
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r ListAuditEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListAuditEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListJoinTokensResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]JoinTokenDetails
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r ListJoinTokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListJoinTokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RevokeJoinTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r RevokeJoinTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RevokeJoinTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetJoinTokenByIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *JoinTokenDetails
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetJoinTokenByIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetJoinTokenByIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRelationshipsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]externalRef0.Relationship
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRelationshipsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRelationshipsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutRelationshipResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.Relationship
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r PutRelationshipResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutRelationshipResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteRelationshipByIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteRelationshipByIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteRelationshipByIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRelationshipByIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.Relationship
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRelationshipByIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRelationshipByIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchRelationshipByIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.Relationship
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchRelationshipByIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchRelationshipByIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRelationshipConsentsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]externalRef0.RelationshipConsent
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRelationshipConsentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRelationshipConsentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ApproveBundleResetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BundleResetApproval
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r ApproveBundleResetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ApproveBundleResetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListBundleVersionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]BundleVersion
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r ListBundleVersionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListBundleVersionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
 
-	// DeleteRelationshipByID request
-	DeleteRelationshipByIDWithResponse(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*DeleteRelationshipByIDResponse, error)
+type GetBundleVersionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BundleVersion
+	JSONDefault  *externalRef0.ApiError
+}
 
-	// GetRelationshipByID request
-	GetRelationshipByIDWithResponse(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipByIDResponse, error)
+// Status returns HTTPResponse.Status
+func (r GetBundleVersionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
 
-	// PatchRelationshipByID request with any body
-	PatchRelationshipByIDWithBodyWithResponse(ctx context.Context, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRelationshipByIDResponse, error)
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBundleVersionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
 
-	PatchRelationshipByIDWithResponse(ctx context.Context, relationshipID externalRef0.UUID, body PatchRelationshipByIDJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRelationshipByIDResponse, error)
+type GetBundleVersionDiffResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BundleDiff
+	JSONDefault  *externalRef0.ApiError
+}
 
-	// GetJoinToken request
-	GetJoinTokenWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetJoinTokenParams, reqEditors ...RequestEditorFn) (*GetJoinTokenResponse, error)
+// Status returns HTTPResponse.Status
+func (r GetBundleVersionDiffResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
 
-	// ListTrustDomains request
-	ListTrustDomainsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTrustDomainsResponse, error)
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBundleVersionDiffResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
 
-	// PutTrustDomain request with any body
-	PutTrustDomainWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTrustDomainResponse, error)
+type RollbackBundleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BundleVersion
+	JSONDefault  *externalRef0.ApiError
+}
 
-	PutTrustDomainWithResponse(ctx context.Context, body PutTrustDomainJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTrustDomainResponse, error)
+// Status returns HTTPResponse.Status
+func (r RollbackBundleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
 
-	// DeleteTrustDomainByName request
-	DeleteTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*DeleteTrustDomainByNameResponse, error)
+// StatusCode returns HTTPResponse.StatusCode
+func (r RollbackBundleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
 
-	// GetTrustDomainByName request
-	GetTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetTrustDomainByNameResponse, error)
+type GetJoinTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *JoinTokenResponse
+	JSONDefault  *externalRef0.ApiError
+}
 
-	// PutTrustDomainByName request with any body
-	PutTrustDomainByNameWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTrustDomainByNameResponse, error)
+// Status returns HTTPResponse.Status
+func (r GetJoinTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
 
-	PutTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainByNameJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTrustDomainByNameResponse, error)
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetJoinTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListTrustDomainsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.TrustDomain
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r ListTrustDomainsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListTrustDomainsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutTrustDomainResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *externalRef0.TrustDomain
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r PutTrustDomainResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutTrustDomainResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteTrustDomainByNameResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteTrustDomainByNameResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteTrustDomainByNameResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTrustDomainByNameResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.TrustDomain
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTrustDomainByNameResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTrustDomainByNameResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutTrustDomainByNameResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.TrustDomain
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r PutTrustDomainByNameResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutTrustDomainByNameResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ListAuditEventsWithResponse request returning *ListAuditEventsResponse
+func (c *ClientWithResponses) ListAuditEventsWithResponse(ctx context.Context, params *ListAuditEventsParams, reqEditors ...RequestEditorFn) (*ListAuditEventsResponse, error) {
+	rsp, err := c.ListAuditEvents(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListAuditEventsResponse(rsp)
+}
+
+// ListJoinTokensWithResponse request returning *ListJoinTokensResponse
+func (c *ClientWithResponses) ListJoinTokensWithResponse(ctx context.Context, params *ListJoinTokensParams, reqEditors ...RequestEditorFn) (*ListJoinTokensResponse, error) {
+	rsp, err := c.ListJoinTokens(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListJoinTokensResponse(rsp)
+}
+
+// RevokeJoinTokenWithResponse request returning *RevokeJoinTokenResponse
+func (c *ClientWithResponses) RevokeJoinTokenWithResponse(ctx context.Context, joinTokenID externalRef0.UUID, reqEditors ...RequestEditorFn) (*RevokeJoinTokenResponse, error) {
+	rsp, err := c.RevokeJoinToken(ctx, joinTokenID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRevokeJoinTokenResponse(rsp)
+}
+
+// GetJoinTokenByIDWithResponse request returning *GetJoinTokenByIDResponse
+func (c *ClientWithResponses) GetJoinTokenByIDWithResponse(ctx context.Context, joinTokenID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetJoinTokenByIDResponse, error) {
+	rsp, err := c.GetJoinTokenByID(ctx, joinTokenID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetJoinTokenByIDResponse(rsp)
+}
+
+// GetRelationshipsWithResponse request returning *GetRelationshipsResponse
+func (c *ClientWithResponses) GetRelationshipsWithResponse(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*GetRelationshipsResponse, error) {
+	rsp, err := c.GetRelationships(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRelationshipsResponse(rsp)
+}
+
+// PutRelationshipWithBodyWithResponse request with arbitrary body returning *PutRelationshipResponse
+func (c *ClientWithResponses) PutRelationshipWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutRelationshipResponse, error) {
+	rsp, err := c.PutRelationshipWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutRelationshipResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutRelationshipWithResponse(ctx context.Context, body PutRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*PutRelationshipResponse, error) {
+	rsp, err := c.PutRelationship(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutRelationshipResponse(rsp)
 }
 
-type GetRelationshipsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]externalRef0.Relationship
-	JSONDefault  *externalRef0.ApiError
+// DeleteRelationshipByIDWithResponse request returning *DeleteRelationshipByIDResponse
+func (c *ClientWithResponses) DeleteRelationshipByIDWithResponse(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*DeleteRelationshipByIDResponse, error) {
+	rsp, err := c.DeleteRelationshipByID(ctx, relationshipID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteRelationshipByIDResponse(rsp)
 }
 
-// Status returns HTTPResponse.Status
-func (r GetRelationshipsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// GetRelationshipByIDWithResponse request returning *GetRelationshipByIDResponse
+func (c *ClientWithResponses) GetRelationshipByIDWithResponse(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipByIDResponse, error) {
+	rsp, err := c.GetRelationshipByID(ctx, relationshipID, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return ParseGetRelationshipByIDResponse(rsp)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetRelationshipsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// PatchRelationshipByIDWithBodyWithResponse request with arbitrary body returning *PatchRelationshipByIDResponse
+func (c *ClientWithResponses) PatchRelationshipByIDWithBodyWithResponse(ctx context.Context, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRelationshipByIDResponse, error) {
+	rsp, err := c.PatchRelationshipByIDWithBody(ctx, relationshipID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	return ParsePatchRelationshipByIDResponse(rsp)
 }
 
-type PutRelationshipResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *externalRef0.Relationship
-	JSONDefault  *externalRef0.ApiError
+func (c *ClientWithResponses) PatchRelationshipByIDWithResponse(ctx context.Context, relationshipID externalRef0.UUID, body PatchRelationshipByIDJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRelationshipByIDResponse, error) {
+	rsp, err := c.PatchRelationshipByID(ctx, relationshipID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchRelationshipByIDResponse(rsp)
 }
 
-// Status returns HTTPResponse.Status
-func (r PutRelationshipResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// GetRelationshipConsentsWithResponse request returning *GetRelationshipConsentsResponse
+func (c *ClientWithResponses) GetRelationshipConsentsWithResponse(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipConsentsResponse, error) {
+	rsp, err := c.GetRelationshipConsents(ctx, relationshipID, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return ParseGetRelationshipConsentsResponse(rsp)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PutRelationshipResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// ApproveBundleResetWithResponse request returning *ApproveBundleResetResponse
+func (c *ClientWithResponses) ApproveBundleResetWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*ApproveBundleResetResponse, error) {
+	rsp, err := c.ApproveBundleReset(ctx, trustDomainName, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	return ParseApproveBundleResetResponse(rsp)
 }
 
-type DeleteRelationshipByIDResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSONDefault  *externalRef0.ApiError
+// ListBundleVersionsWithResponse request returning *ListBundleVersionsResponse
+func (c *ClientWithResponses) ListBundleVersionsWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*ListBundleVersionsResponse, error) {
+	rsp, err := c.ListBundleVersions(ctx, trustDomainName, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListBundleVersionsResponse(rsp)
 }
 
-// Status returns HTTPResponse.Status
-func (r DeleteRelationshipByIDResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// GetBundleVersionWithResponse request returning *GetBundleVersionResponse
+func (c *ClientWithResponses) GetBundleVersionWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, version BundleVersionNumber, reqEditors ...RequestEditorFn) (*GetBundleVersionResponse, error) {
+	rsp, err := c.GetBundleVersion(ctx, trustDomainName, version, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return ParseGetBundleVersionResponse(rsp)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteRelationshipByIDResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// GetBundleVersionDiffWithResponse request returning *GetBundleVersionDiffResponse
+func (c *ClientWithResponses) GetBundleVersionDiffWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, version BundleVersionNumber, params *GetBundleVersionDiffParams, reqEditors ...RequestEditorFn) (*GetBundleVersionDiffResponse, error) {
+	rsp, err := c.GetBundleVersionDiff(ctx, trustDomainName, version, params, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	return ParseGetBundleVersionDiffResponse(rsp)
 }
 
-type GetRelationshipByIDResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *externalRef0.Relationship
-	JSONDefault  *externalRef0.ApiError
+// RollbackBundleWithResponse request returning *RollbackBundleResponse
+func (c *ClientWithResponses) RollbackBundleWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, version BundleVersionNumber, reqEditors ...RequestEditorFn) (*RollbackBundleResponse, error) {
+	rsp, err := c.RollbackBundle(ctx, trustDomainName, version, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRollbackBundleResponse(rsp)
 }
 
-// Status returns HTTPResponse.Status
-func (r GetRelationshipByIDResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// GetJoinTokenWithResponse request returning *GetJoinTokenResponse
+func (c *ClientWithResponses) GetJoinTokenWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetJoinTokenParams, reqEditors ...RequestEditorFn) (*GetJoinTokenResponse, error) {
+	rsp, err := c.GetJoinToken(ctx, trustDomainName, params, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return ParseGetJoinTokenResponse(rsp)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetRelationshipByIDResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// ListTrustDomainsWithResponse request returning *ListTrustDomainsResponse
+func (c *ClientWithResponses) ListTrustDomainsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTrustDomainsResponse, error) {
+	rsp, err := c.ListTrustDomains(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	return ParseListTrustDomainsResponse(rsp)
 }
 
-type PatchRelationshipByIDResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *externalRef0.Relationship
+// PutTrustDomainWithBodyWithResponse request with arbitrary body returning *PutTrustDomainResponse
+func (c *ClientWithResponses) PutTrustDomainWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTrustDomainResponse, error) {
+	rsp, err := c.PutTrustDomainWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutTrustDomainResponse(rsp)
 }
 
-// Status returns HTTPResponse.Status
-func (r PatchRelationshipByIDResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *ClientWithResponses) PutTrustDomainWithResponse(ctx context.Context, body PutTrustDomainJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTrustDomainResponse, error) {
+	rsp, err := c.PutTrustDomain(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return ParsePutTrustDomainResponse(rsp)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PatchRelationshipByIDResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// DeleteTrustDomainByNameWithResponse request returning *DeleteTrustDomainByNameResponse
+func (c *ClientWithResponses) DeleteTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*DeleteTrustDomainByNameResponse, error) {
+	rsp, err := c.DeleteTrustDomainByName(ctx, trustDomainName, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	return ParseDeleteTrustDomainByNameResponse(rsp)
 }
 
-type GetJoinTokenResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *JoinTokenResponse
-	JSONDefault  *externalRef0.ApiError
+// GetTrustDomainByNameWithResponse request returning *GetTrustDomainByNameResponse
+func (c *ClientWithResponses) GetTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetTrustDomainByNameResponse, error) {
+	rsp, err := c.GetTrustDomainByName(ctx, trustDomainName, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTrustDomainByNameResponse(rsp)
 }
 
-// Status returns HTTPResponse.Status
-func (r GetJoinTokenResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// PutTrustDomainByNameWithBodyWithResponse request with arbitrary body returning *PutTrustDomainByNameResponse
+func (c *ClientWithResponses) PutTrustDomainByNameWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTrustDomainByNameResponse, error) {
+	rsp, err := c.PutTrustDomainByNameWithBody(ctx, trustDomainName, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return ParsePutTrustDomainByNameResponse(rsp)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetJoinTokenResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *ClientWithResponses) PutTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainByNameJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTrustDomainByNameResponse, error) {
+	rsp, err := c.PutTrustDomainByName(ctx, trustDomainName, body, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	return ParsePutTrustDomainByNameResponse(rsp)
 }
 
-type ListTrustDomainsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *externalRef0.TrustDomain
-	JSONDefault  *externalRef0.ApiError
+// ParseListAuditEventsResponse parses an HTTP response from a ListAuditEventsWithResponse call
+func ParseListAuditEventsResponse(rsp *http.Response) (*ListAuditEventsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListAuditEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []AuditEvent
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r ListTrustDomainsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseListJoinTokensResponse parses an HTTP response from a ListJoinTokensWithResponse call
+func ParseListJoinTokensResponse(rsp *http.Response) (*ListJoinTokensResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+
+	response := &ListJoinTokensResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []JoinTokenDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r ListTrustDomainsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// ParseRevokeJoinTokenResponse parses an HTTP response from a RevokeJoinTokenWithResponse call
+func ParseRevokeJoinTokenResponse(rsp *http.Response) (*RevokeJoinTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RevokeJoinTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return 0
-}
 
-type PutTrustDomainResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON201      *externalRef0.TrustDomain
-	JSONDefault  *externalRef0.ApiError
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r PutTrustDomainResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseGetJoinTokenByIDResponse parses an HTTP response from a GetJoinTokenByIDWithResponse call
+func ParseGetJoinTokenByIDResponse(rsp *http.Response) (*GetJoinTokenByIDResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PutTrustDomainResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &GetJoinTokenByIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type DeleteTrustDomainByNameResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSONDefault  *externalRef0.ApiError
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest JoinTokenDetails
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// Status returns HTTPResponse.Status
-func (r DeleteTrustDomainByNameResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteTrustDomainByNameResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
 	}
-	return 0
-}
 
-type GetTrustDomainByNameResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *externalRef0.TrustDomain
-	JSONDefault  *externalRef0.ApiError
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetTrustDomainByNameResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseGetRelationshipsResponse parses an HTTP response from a GetRelationshipsWithResponse call
+func ParseGetRelationshipsResponse(rsp *http.Response) (*GetRelationshipsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetTrustDomainByNameResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &GetRelationshipsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type PutTrustDomainByNameResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *externalRef0.TrustDomain
-	JSONDefault  *externalRef0.ApiError
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []externalRef0.Relationship
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// Status returns HTTPResponse.Status
-func (r PutTrustDomainByNameResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PutTrustDomainByNameResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
 	}
-	return 0
+
+	return response, nil
 }
 
-// GetRelationshipsWithResponse request returning *GetRelationshipsResponse
-func (c *ClientWithResponses) GetRelationshipsWithResponse(ctx context.Context, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*GetRelationshipsResponse, error) {
-	rsp, err := c.GetRelationships(ctx, params, reqEditors...)
+// ParsePutRelationshipResponse parses an HTTP response from a PutRelationshipWithResponse call
+func ParsePutRelationshipResponse(rsp *http.Response) (*PutRelationshipResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetRelationshipsResponse(rsp)
-}
 
-// PutRelationshipWithBodyWithResponse request with arbitrary body returning *PutRelationshipResponse
-func (c *ClientWithResponses) PutRelationshipWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutRelationshipResponse, error) {
-	rsp, err := c.PutRelationshipWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &PutRelationshipResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParsePutRelationshipResponse(rsp)
-}
 
-func (c *ClientWithResponses) PutRelationshipWithResponse(ctx context.Context, body PutRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*PutRelationshipResponse, error) {
-	rsp, err := c.PutRelationship(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.Relationship
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return ParsePutRelationshipResponse(rsp)
+
+	return response, nil
 }
 
-// DeleteRelationshipByIDWithResponse request returning *DeleteRelationshipByIDResponse
-func (c *ClientWithResponses) DeleteRelationshipByIDWithResponse(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*DeleteRelationshipByIDResponse, error) {
-	rsp, err := c.DeleteRelationshipByID(ctx, relationshipID, reqEditors...)
+// ParseDeleteRelationshipByIDResponse parses an HTTP response from a DeleteRelationshipByIDWithResponse call
+func ParseDeleteRelationshipByIDResponse(rsp *http.Response) (*DeleteRelationshipByIDResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseDeleteRelationshipByIDResponse(rsp)
-}
 
-// GetRelationshipByIDWithResponse request returning *GetRelationshipByIDResponse
-func (c *ClientWithResponses) GetRelationshipByIDWithResponse(ctx context.Context, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipByIDResponse, error) {
-	rsp, err := c.GetRelationshipByID(ctx, relationshipID, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &DeleteRelationshipByIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetRelationshipByIDResponse(rsp)
-}
 
-// PatchRelationshipByIDWithBodyWithResponse request with arbitrary body returning *PatchRelationshipByIDResponse
-func (c *ClientWithResponses) PatchRelationshipByIDWithBodyWithResponse(ctx context.Context, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRelationshipByIDResponse, error) {
-	rsp, err := c.PatchRelationshipByIDWithBody(ctx, relationshipID, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return ParsePatchRelationshipByIDResponse(rsp)
+
+	return response, nil
 }
 
-func (c *ClientWithResponses) PatchRelationshipByIDWithResponse(ctx context.Context, relationshipID externalRef0.UUID, body PatchRelationshipByIDJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRelationshipByIDResponse, error) {
-	rsp, err := c.PatchRelationshipByID(ctx, relationshipID, body, reqEditors...)
+// ParseGetRelationshipByIDResponse parses an HTTP response from a GetRelationshipByIDWithResponse call
+func ParseGetRelationshipByIDResponse(rsp *http.Response) (*GetRelationshipByIDResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePatchRelationshipByIDResponse(rsp)
-}
 
-// GetJoinTokenWithResponse request returning *GetJoinTokenResponse
-func (c *ClientWithResponses) GetJoinTokenWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetJoinTokenParams, reqEditors ...RequestEditorFn) (*GetJoinTokenResponse, error) {
-	rsp, err := c.GetJoinToken(ctx, trustDomainName, params, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetRelationshipByIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetJoinTokenResponse(rsp)
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.Relationship
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-// ListTrustDomainsWithResponse request returning *ListTrustDomainsResponse
-func (c *ClientWithResponses) ListTrustDomainsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTrustDomainsResponse, error) {
-	rsp, err := c.ListTrustDomains(ctx, reqEditors...)
+// ParsePatchRelationshipByIDResponse parses an HTTP response from a PatchRelationshipByIDWithResponse call
+func ParsePatchRelationshipByIDResponse(rsp *http.Response) (*PatchRelationshipByIDResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseListTrustDomainsResponse(rsp)
-}
 
-// PutTrustDomainWithBodyWithResponse request with arbitrary body returning *PutTrustDomainResponse
-func (c *ClientWithResponses) PutTrustDomainWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTrustDomainResponse, error) {
-	rsp, err := c.PutTrustDomainWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &PatchRelationshipByIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.Relationship
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	}
-	return ParsePutTrustDomainResponse(rsp)
-}
 
-func (c *ClientWithResponses) PutTrustDomainWithResponse(ctx context.Context, body PutTrustDomainJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTrustDomainResponse, error) {
-	rsp, err := c.PutTrustDomain(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParsePutTrustDomainResponse(rsp)
+	return response, nil
 }
 
-// DeleteTrustDomainByNameWithResponse request returning *DeleteTrustDomainByNameResponse
-func (c *ClientWithResponses) DeleteTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*DeleteTrustDomainByNameResponse, error) {
-	rsp, err := c.DeleteTrustDomainByName(ctx, trustDomainName, reqEditors...)
+// ParseGetRelationshipConsentsResponse parses an HTTP response from a GetRelationshipConsentsWithResponse call
+func ParseGetRelationshipConsentsResponse(rsp *http.Response) (*GetRelationshipConsentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseDeleteTrustDomainByNameResponse(rsp)
-}
 
-// GetTrustDomainByNameWithResponse request returning *GetTrustDomainByNameResponse
-func (c *ClientWithResponses) GetTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetTrustDomainByNameResponse, error) {
-	rsp, err := c.GetTrustDomainByName(ctx, trustDomainName, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetRelationshipConsentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetTrustDomainByNameResponse(rsp)
-}
 
-// PutTrustDomainByNameWithBodyWithResponse request with arbitrary body returning *PutTrustDomainByNameResponse
-func (c *ClientWithResponses) PutTrustDomainByNameWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTrustDomainByNameResponse, error) {
-	rsp, err := c.PutTrustDomainByNameWithBody(ctx, trustDomainName, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParsePutTrustDomainByNameResponse(rsp)
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []externalRef0.RelationshipConsent
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
 
-func (c *ClientWithResponses) PutTrustDomainByNameWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body PutTrustDomainByNameJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTrustDomainByNameResponse, error) {
-	rsp, err := c.PutTrustDomainByName(ctx, trustDomainName, body, reqEditors...)
-	if err != nil {
-		return nil, err
 	}
-	return ParsePutTrustDomainByNameResponse(rsp)
+
+	return response, nil
 }
 
-// ParseGetRelationshipsResponse parses an HTTP response from a GetRelationshipsWithResponse call
-func ParseGetRelationshipsResponse(rsp *http.Response) (*GetRelationshipsResponse, error) {
+// ParseApproveBundleResetResponse parses an HTTP response from a ApproveBundleResetWithResponse call
+func ParseApproveBundleResetResponse(rsp *http.Response) (*ApproveBundleResetResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetRelationshipsResponse{
+	response := &ApproveBundleResetResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []externalRef0.Relationship
+		var dest BundleResetApproval
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1366,22 +2835,22 @@ func ParseGetRelationshipsResponse(rsp *http.Response) (*GetRelationshipsRespons
 	return response, nil
 }
 
-// ParsePutRelationshipResponse parses an HTTP response from a PutRelationshipWithResponse call
-func ParsePutRelationshipResponse(rsp *http.Response) (*PutRelationshipResponse, error) {
+// ParseListBundleVersionsResponse parses an HTTP response from a ListBundleVersionsWithResponse call
+func ParseListBundleVersionsResponse(rsp *http.Response) (*ListBundleVersionsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PutRelationshipResponse{
+	response := &ListBundleVersionsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest externalRef0.Relationship
+		var dest []BundleVersion
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1399,20 +2868,27 @@ func ParsePutRelationshipResponse(rsp *http.Response) (*PutRelationshipResponse,
 	return response, nil
 }
 
-// ParseDeleteRelationshipByIDResponse parses an HTTP response from a DeleteRelationshipByIDWithResponse call
-func ParseDeleteRelationshipByIDResponse(rsp *http.Response) (*DeleteRelationshipByIDResponse, error) {
+// ParseGetBundleVersionResponse parses an HTTP response from a GetBundleVersionWithResponse call
+func ParseGetBundleVersionResponse(rsp *http.Response) (*GetBundleVersionResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteRelationshipByIDResponse{
+	response := &GetBundleVersionResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BundleVersion
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest externalRef0.ApiError
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1425,22 +2901,22 @@ func ParseDeleteRelationshipByIDResponse(rsp *http.Response) (*DeleteRelationshi
 	return response, nil
 }
 
-// ParseGetRelationshipByIDResponse parses an HTTP response from a GetRelationshipByIDWithResponse call
-func ParseGetRelationshipByIDResponse(rsp *http.Response) (*GetRelationshipByIDResponse, error) {
+// ParseGetBundleVersionDiffResponse parses an HTTP response from a GetBundleVersionDiffWithResponse call
+func ParseGetBundleVersionDiffResponse(rsp *http.Response) (*GetBundleVersionDiffResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetRelationshipByIDResponse{
+	response := &GetBundleVersionDiffResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest externalRef0.Relationship
+		var dest BundleDiff
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1458,27 +2934,34 @@ func ParseGetRelationshipByIDResponse(rsp *http.Response) (*GetRelationshipByIDR
 	return response, nil
 }
 
-// ParsePatchRelationshipByIDResponse parses an HTTP response from a PatchRelationshipByIDWithResponse call
-func ParsePatchRelationshipByIDResponse(rsp *http.Response) (*PatchRelationshipByIDResponse, error) {
+// ParseRollbackBundleResponse parses an HTTP response from a RollbackBundleWithResponse call
+func ParseRollbackBundleResponse(rsp *http.Response) (*RollbackBundleResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PatchRelationshipByIDResponse{
+	response := &RollbackBundleResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest externalRef0.Relationship
+		var dest BundleVersion
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
 
 	return response, nil
@@ -1677,6 +3160,18 @@ func ParsePutTrustDomainByNameResponse(rsp *http.Response) (*PutTrustDomainByNam
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List the events of the audit log, in the order of the hash chain
+	// (GET /audit-events)
+	ListAuditEvents(ctx echo.Context, params ListAuditEventsParams) error
+	// List the join tokens, with their values masked
+	// (GET /join-tokens)
+	ListJoinTokens(ctx echo.Context, params ListJoinTokensParams) error
+	// Revokes a specific join token, so that it can no longer be used to onboard a Harvester
+	// (DELETE /join-tokens/{joinTokenID})
+	RevokeJoinToken(ctx echo.Context, joinTokenID externalRef0.UUID) error
+	// Get a specific join token, with its value masked
+	// (GET /join-tokens/{joinTokenID})
+	GetJoinTokenByID(ctx echo.Context, joinTokenID externalRef0.UUID) error
 	// Get the relationships based on the trust domain name and/or consent statuses.
 	// (GET /relationships)
 	GetRelationships(ctx echo.Context, params GetRelationshipsParams) error
@@ -1692,6 +3187,24 @@ type ServerInterface interface {
 	// Update a specific relationship
 	// (PATCH /relationships/{relationshipID})
 	PatchRelationshipByID(ctx echo.Context, relationshipID externalRef0.UUID) error
+	// Get the signed consents of the trust domains of a relationship
+	// (GET /relationships/{relationshipID}/consents)
+	GetRelationshipConsents(ctx echo.Context, relationshipID externalRef0.UUID) error
+	// Approve the next bundle uploaded for the Trust Domain even if it does not continue the current bundle
+	// (POST /trust-domain/{trustDomainName}/bundle-reset)
+	ApproveBundleReset(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
+	// List the bundle history of a specific Trust Domain, latest version first
+	// (GET /trust-domain/{trustDomainName}/bundles)
+	ListBundleVersions(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
+	// Get a specific version of the bundle history of a Trust Domain
+	// (GET /trust-domain/{trustDomainName}/bundles/{version})
+	GetBundleVersion(ctx echo.Context, trustDomainName externalRef0.TrustDomainName, version BundleVersionNumber) error
+	// Get the authorities added and removed by a bundle version compared to a base version
+	// (GET /trust-domain/{trustDomainName}/bundles/{version}/diff)
+	GetBundleVersionDiff(ctx echo.Context, trustDomainName externalRef0.TrustDomainName, version BundleVersionNumber, params GetBundleVersionDiffParams) error
+	// Pin a version of the bundle history, served to federated Trust Domains until a newer bundle is uploaded
+	// (POST /trust-domain/{trustDomainName}/bundles/{version}/rollback)
+	RollbackBundle(ctx echo.Context, trustDomainName externalRef0.TrustDomainName, version BundleVersionNumber) error
 	// Get a join token for a specific Trust Domain
 	// (GET /trust-domain/{trustDomainName}/join-token)
 	GetJoinToken(ctx echo.Context, trustDomainName externalRef0.TrustDomainName, params GetJoinTokenParams) error
@@ -1717,6 +3230,102 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// ListAuditEvents converts echo context to params.
+func (w *ServerInterfaceWrapper) ListAuditEvents(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAuditEventsParams
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", ctx.QueryParams(), &params.Since)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter since: %s", err))
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", ctx.QueryParams(), &params.Until)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter until: %s", err))
+	}
+
+	// ------------- Optional query parameter "actor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor", ctx.QueryParams(), &params.Actor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter actor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListAuditEvents(ctx, params)
+	return err
+}
+
+// ListJoinTokens converts echo context to params.
+func (w *ServerInterfaceWrapper) ListJoinTokens(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListJoinTokensParams
+	// ------------- Optional query parameter "trustDomainName" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "trustDomainName", ctx.QueryParams(), &params.TrustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// ------------- Optional query parameter "used" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "used", ctx.QueryParams(), &params.Used)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter used: %s", err))
+	}
+
+	// ------------- Optional query parameter "expired" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "expired", ctx.QueryParams(), &params.Expired)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter expired: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListJoinTokens(ctx, params)
+	return err
+}
+
+// RevokeJoinToken converts echo context to params.
+func (w *ServerInterfaceWrapper) RevokeJoinToken(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "joinTokenID" -------------
+	var joinTokenID externalRef0.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "joinTokenID", runtime.ParamLocationPath, ctx.Param("joinTokenID"), &joinTokenID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter joinTokenID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.RevokeJoinToken(ctx, joinTokenID)
+	return err
+}
+
+// GetJoinTokenByID converts echo context to params.
+func (w *ServerInterfaceWrapper) GetJoinTokenByID(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "joinTokenID" -------------
+	var joinTokenID externalRef0.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "joinTokenID", runtime.ParamLocationPath, ctx.Param("joinTokenID"), &joinTokenID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter joinTokenID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetJoinTokenByID(ctx, joinTokenID)
+	return err
+}
+
 // GetRelationships converts echo context to params.
 func (w *ServerInterfaceWrapper) GetRelationships(ctx echo.Context) error {
 	var err error
@@ -1813,6 +3422,135 @@ func (w *ServerInterfaceWrapper) PatchRelationshipByID(ctx echo.Context) error {
 	return err
 }
 
+// GetRelationshipConsents converts echo context to params.
+func (w *ServerInterfaceWrapper) GetRelationshipConsents(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "relationshipID" -------------
+	var relationshipID externalRef0.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, ctx.Param("relationshipID"), &relationshipID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter relationshipID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetRelationshipConsents(ctx, relationshipID)
+	return err
+}
+
+// ApproveBundleReset converts echo context to params.
+func (w *ServerInterfaceWrapper) ApproveBundleReset(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ApproveBundleReset(ctx, trustDomainName)
+	return err
+}
+
+// ListBundleVersions converts echo context to params.
+func (w *ServerInterfaceWrapper) ListBundleVersions(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListBundleVersions(ctx, trustDomainName)
+	return err
+}
+
+// GetBundleVersion converts echo context to params.
+func (w *ServerInterfaceWrapper) GetBundleVersion(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version BundleVersionNumber
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "version", runtime.ParamLocationPath, ctx.Param("version"), &version)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetBundleVersion(ctx, trustDomainName, version)
+	return err
+}
+
+// GetBundleVersionDiff converts echo context to params.
+func (w *ServerInterfaceWrapper) GetBundleVersionDiff(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version BundleVersionNumber
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "version", runtime.ParamLocationPath, ctx.Param("version"), &version)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBundleVersionDiffParams
+	// ------------- Optional query parameter "base" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "base", ctx.QueryParams(), &params.Base)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter base: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetBundleVersionDiff(ctx, trustDomainName, version, params)
+	return err
+}
+
+// RollbackBundle converts echo context to params.
+func (w *ServerInterfaceWrapper) RollbackBundle(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version BundleVersionNumber
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "version", runtime.ParamLocationPath, ctx.Param("version"), &version)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.RollbackBundle(ctx, trustDomainName, version)
+	return err
+}
+
 // GetJoinToken converts echo context to params.
 func (w *ServerInterfaceWrapper) GetJoinToken(ctx echo.Context) error {
 	var err error
@@ -1833,6 +3571,27 @@ func (w *ServerInterfaceWrapper) GetJoinToken(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ttl: %s", err))
 	}
 
+	// ------------- Optional query parameter "maxUses" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "maxUses", ctx.QueryParams(), &params.MaxUses)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter maxUses: %s", err))
+	}
+
+	// ------------- Optional query parameter "harvesterKeyFingerprint" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "harvesterKeyFingerprint", ctx.QueryParams(), &params.HarvesterKeyFingerprint)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter harvesterKeyFingerprint: %s", err))
+	}
+
+	// ------------- Optional query parameter "sourceCIDR" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sourceCIDR", ctx.QueryParams(), &params.SourceCIDR)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sourceCIDR: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.GetJoinToken(ctx, trustDomainName, params)
 	return err
@@ -1932,11 +3691,21 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.GET(baseURL+"/audit-events", wrapper.ListAuditEvents)
+	router.GET(baseURL+"/join-tokens", wrapper.ListJoinTokens)
+	router.DELETE(baseURL+"/join-tokens/:joinTokenID", wrapper.RevokeJoinToken)
+	router.GET(baseURL+"/join-tokens/:joinTokenID", wrapper.GetJoinTokenByID)
 	router.GET(baseURL+"/relationships", wrapper.GetRelationships)
 	router.PUT(baseURL+"/relationships", wrapper.PutRelationship)
 	router.DELETE(baseURL+"/relationships/:relationshipID", wrapper.DeleteRelationshipByID)
 	router.GET(baseURL+"/relationships/:relationshipID", wrapper.GetRelationshipByID)
 	router.PATCH(baseURL+"/relationships/:relationshipID", wrapper.PatchRelationshipByID)
+	router.GET(baseURL+"/relationships/:relationshipID/consents", wrapper.GetRelationshipConsents)
+	router.POST(baseURL+"/trust-domain/:trustDomainName/bundle-reset", wrapper.ApproveBundleReset)
+	router.GET(baseURL+"/trust-domain/:trustDomainName/bundles", wrapper.ListBundleVersions)
+	router.GET(baseURL+"/trust-domain/:trustDomainName/bundles/:version", wrapper.GetBundleVersion)
+	router.GET(baseURL+"/trust-domain/:trustDomainName/bundles/:version/diff", wrapper.GetBundleVersionDiff)
+	router.POST(baseURL+"/trust-domain/:trustDomainName/bundles/:version/rollback", wrapper.RollbackBundle)
 	router.GET(baseURL+"/trust-domain/:trustDomainName/join-token", wrapper.GetJoinToken)
 	router.GET(baseURL+"/trust-domains", wrapper.ListTrustDomains)
 	router.PUT(baseURL+"/trust-domains", wrapper.PutTrustDomain)
@@ -1949,45 +3718,114 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xZb1fiuBr/Kjm5+2J3D9ACyijn7AsUdZhRR4XZmXH0ckL7FKJtUpMURA/f/Z6kFdtS",
-	"FLmOO7OvKE2T5+/vlydP7rHDg5AzYEri5j0WIEPOJJg/bfBI5Cv96HCmgJlHEoY+dYiinFlXkjP9Tjoj",
-	"CIh++k2Ah5v4P9bjulY8Kq1WSPeE4ALPZrMSdkE6goZ6HdzEZgC1TjroUQX9VTJXLz2frpVwXapnEv9E",
-	"8BCEolplj/gSSjhMvdKqu6B/PS4ConATU6YaG7iEA3JLgyjAzc3t7RIOKIv/VW27hNU0hPhTGILAsxIO",
-	"QEoyNCvBLQlCX4+30ABIpKgX+QiMBQ+flR7lSSUoG8YCD4EN1Qg3aykhybi2VsBNRAW4uPk91vtR7uX8",
-	"ez64AkdpnXa1n5jqKqIiYyswbcF3HSPBx+Bi7WZGzUMIzNVyLhcEl3AbfFBwlnj+hQ5eLfg5EdraBXs+",
-	"cMp6/BpY1st1j2xteo2N8ua76rvyxmajVh7UPadcc7Ybda/RIB5ppB0eRdTNurveKOGQKAVC59p/v9vl",
-	"bVL2Lu+3ZuX588YKz9Xa7Ddc4L+54mu6UD0Y/ZQHH72TT5V4elGGnJAhHEfBAAxqspDrjQAxM4a4h6iC",
-	"QCLFkbymIRqAxwUgqYhQlA31e4f7PjgKqRFojEa+QhJUBaegUwgcrUKX3kGsQMIoNbu0VBuZUUeAigSr",
-	"ZPBqp+FaLFM5ozPwDUvJEQ13pp32GdxEINWL6cNgrC8NyPrP5nkWk7NSfoHBCxdYYIWcOgUCChMhUml/",
-	"rOcLJSKp+i4PCGV90mckgOes6ekpbTPjWH+uYZ9eZbDeKnkAFChWLGiJb1IC1nNNJpnT5GVWRrESSI2I",
-	"QgJCATpiBkrAFFVT9HWdHaOEX8N5S/2STpiXokYAUeD2icp6o2bXqmW7Wq7bPXurWbebtn2ettwlCsqK",
-	"mvClja8W2E7d5yz//LnTXsg40k8A82Ik55ZZW/7r4OZVrBisa8VgXSui0P3BmZFLb1MLpPIxo0JRUItc",
-	"tDSHloalCFDdk87+/l6nnbVchtTzoGlZ6ZWsCRfXPidun7qaIjwK4nmK2NgqwIkJwk7EXB8Wi4BYJRSz",
-	"1MB8hChDH7qfjlEirJTS9f4CX01Un0RqxAXVaL/Aze/3FxhuQypA9om6wM0LXG1sbWxWG/WN+gUuXeBr",
-	"mPapa0Zabu/csZ13d3K74TSG49PbDzuNU3ev0Z52o2NvbL4Po4FPnf41TM2co/3ryd7k2/uP/Lxzd2Xv",
-	"tk6/dZLnduvUaZ8OW3u31ZPzs4m3V2+fy083taMd+9PmyRdvIO8ECQ+OvWBzb9+q8snXTdZpHwdXPTqw",
-	"jqfeu13YHXcPnT2nbn8LyWDcGgwP3285sjZq31Vbf/11gWelZfZtVRft84Z/k7ZDeq1v5O76oPbF265/",
-	"UQe3wZn71WvZxzvr2ifa3SvqCHbT/cz2alOofuCRt9M+OByoztHVh/2/Dz7C+0/qY28zuvF3rI+9reNa",
-	"ffOrlF+HvcPTs6PRXdhqO0dHG5+tb74z5tPr95vB0Nh3WbrAAjwBctQfURZbaBtFpd4MmQP9uDQzI+/M",
-	"SDpZzWvlVi/wDC9LwJgFfsJt5G127tTp43f05/dW+dycKe4u0Z9//Fl4phgRMQapQPRjgliBqef88qLd",
-	"cU0i52zAidBHyv5gTi7PrpHw0D+2ESSl4bL9oIi187ZntDUwqMRJUnF4sCZHm1j8Uode7V3KPP7QHSKO",
-	"iWOcTPiAqlE00L4VPm7ikVKhbFrW0LzWfrLew8QHpU6Ic02Eaw2JT1xBwV+AJD54GEJdEGMQ6IgwMoRA",
-	"47B10kEyBId6SUtKHxR96kByBk/UaYXEGQGqVeyMSk3LmkwmFWJGK1wMrWSqtA47u3vH3b1yrWJXRiow",
-	"aimqTGgWFGq5AWVGlzL6FALTT3UjawxCxlZUK3alWjXACYGRkOoYV+xKHZsojQzfWSJVcps3QzBu1aRo",
-	"BjquVgAyhzlplhAkAAVC6v0458H0qig+JiJP8ACRZOOPsxuFILQzFR2D9qOma3wTgZg+AKf5cNhMysnS",
-	"is2fhTPtQi8irYYWFRNuSISiDg2JAqnLEoLSpizTUeUAu6qWBQelvJ7HuX4JZQiIM0IhGS51WfjQAFlV",
-	"j3nH5EkF9LLyKZlJ3+clUpMps9llKdsKrtn2i9rAxjvPScycLx/7gUQIMi3qEXcjxwEpvchHczzEdDHv",
-	"UxeJmxtiPTS0TWM5CgIipjGakqZWClJoQCS4iDMzpNKlgElPwlyLC5TAIQFVHA9FhhqEOAvRy1kJh1EB",
-	"mHOdGRxvWiDVDnenr9Z7X9L/mWU3SSUimP2foV894m8W4V2z2+fYAyVuRgNQEwCG1IRn6FA+EctZKcfV",
-	"1n36b6c9i49aPihYDPlDLzzbn3yOxTttDXudjrl8MQSg95BH/GeVwfkYr8oJcYm4jA3eJHaxryQi830+",
-	"E8Un8bbK5vlLev5XBqOm23WCGRLljAros6jV/5MH9AfQ+1MXHrOE5X+qPMrkxGdzAlsjLTQPm825nLTN",
-	"7nPF38y64pSV5xdsyyjh8XLtmdTJVKpJeVmQNYs16Hpps0JN2qMBlBUvH9IxoN97vcM/dFkqweHMlcjj",
-	"wiS6dgNSiYWFVbPyn9RyDvt6w7ZLmev0ei19PbfV2LDtp28FfyivLV7GvjW5PfrauD+V1unsSaW1VhnF",
-	"6beY08vPgYdUpm+uJP6BXk038d7On9pCRHw/U4GnC7OMQ5+qsdP6/7ASu+AasZB8q28VlrjydV8hEi3X",
-	"TSdyOh7Lw7GQyov8/HydnDJxZ5qQ6eoUzf4Biv7J6uXVYrW8XP7XBOAXJsJc1bxqSFdgw18ppK9P2rlo",
-	"zv51ibNYWq9I3XoV096O0yHbyPe5Q/wRl6oiJ2Q4BFGh3CIhtcZ1rEOVLJrPohbK3DfPt+MkezJvFwvd",
-	"VrZ/Q2XStksu5cyIPteRByn74CaOzJzznuz4JKpkDxqLupwVSE2VfQMeMRcpnuuwVx4FpEq+2eXsfwEA",
-	"AP//NrPyPYgrAAA=",
+	"H4sIAAAAAAAC/+x9aXfiuNL/V/Hhf188z4QEmy2Qc+aFwSwGzGrCMvQ/R7blBYzs2DJbn3z358hmscEk",
+	"JLe7p+eeO2+G2LJUqvpVqVRVUn9PyNbSthBE2E08fU840LUt5EL/Dw6qwDMx+SlbCEPk/wS2bRoywIaF",
+	"UnPXQuSZK+twCcivfzlQTTwl/l/q1G8qeOumWNuoOI7lJN7e3pIJBbqyY9ikn8RTwn9BsV2eOpFAWu2/",
+	"JV0fPydEKIpBvgRm17Fs6GCDkKwC04XJhB16REhXIPm/ajlLgBNPCQPhfDaRTCzBxlh6y8RTrlhMJpYG",
+	"Cv5iaDqZwFsbBk2hBp3EWzKxhK4LNL8nuAFL2yTvWUqCwMOG6pkU9GdwaJY8jedix0BaMGALIg3riad0",
+	"aJD9ezJbB756hgOVxNNfAd2ncb8d21vSHMqY0MR6ioErq4NgbucJkAO2h+eCHc/FL4q1BAZ6kB0IMEwc",
+	"xzzQmCSfBiKIyo8ljylLpbAOqaD7JAWUpYEo1XKCp/5fRMSWQ+nAWUEXQ+dp5tF0RvZHp4LR/SfQ/w5Q",
+	"9UPDRDJErN9XHHkB4coLwNHZpek0c08z9xlapAtPGfqJpqdhISkAw3tsLGPnrEAMDNON9igTkCL84mKA",
+	"PfcFPFHAth1rBZW4LnTg6pdc0+GGgohIWqEGdfY+nctTpOWBkbYDV4blucFDgBT/6V4ZD42gD4CYMQ0l",
+	"QnE2nbxUgkugkzFf/k1yfZIOTwFBKWVaWhyNGDgaxJdDiT4eOB8PScqBpm9wXN2wCXokDykmDGGN8q0S",
+	"dClsRXCCFebBcrTER8pmKIkDtJMH7TgSF+bJXpIRoMWpZsmnkDNU9bOqqShQeZmv8QvwsG45xuGFgeHS",
+	"vWRUE24pnjvwujESqcN321h+Bw+A44At+TsYbpOji1fHe8+kj3N0kT2OF9O/BFz4soKOu7c373UW8Ow5",
+	"aNz2llIASAcurdWv5MlhwJ/HlX+HIWfIPXR1xuqrkn1neskr4LsugveQr0E3Rq8JkfnshRVR/OYHiQXq",
+	"HVFklc7m8sojgApdKMDHIgOzeYaWM3IaKPkMUGE2D9Pw8fGxWCioiiQX04+0yuSgXHxkGCmbjpN7QGkf",
+	"uhCzvukG5md9ix+w2oS9AiYOnaF1+QWBJfwINb7pDCxnmzQ/R8xlhzdas+cTan8zHilHtH2sTntkkpXO",
+	"QAgqlxgd6RDrkDgthkvtFYoyXCpo7y/DLnRWUKGw5eNVhQp0yCSp8LLlnjAnWZYJASKDuoaGAPacD8U4",
+	"ODbcf2Ug7UUmfFWJ+w1fZB0YH5qQ8umDst/+iKi9kt0CpoBx5FPPNi1AbIS0veTbyeQenTbKQC4GSCZr",
+	"NcDU4fOQlieptQ5R9CuXcjxEGYiqs9QycIMvRP5TTGiEM0dYHZHyOT3ZjxYGfSbGAzttPOK8sQv5XXB9",
+	"/JCji1QIGJQPDMK+bkWg9uOFbek9+a9UqfFtqlzpi3yVL7NixX86QwLPc7VducxqHY1d8yVW43lxVV3l",
+	"KtXyYiWvud6k0bSmvL6S22yv0ir12HVtXpkIpUmNZYaVGWI3QheMcvR03MDTUd+ejPtma9TWhVJ2zIn8",
+	"TuAmW4Hj18Kc3bRNizyjBW6y6YjBsxlqm9a6zlWAUNL8PtmN0I/rsSXya02rGAJL18qD19qAlzJcr1Ka",
+	"IbY3ZNksX+LWLGnRZC2+xPbKr3S5zYprlSnjiTTMPhdq9g7etRfSfDuSsyISVnxr3FGX6RlKN6tcnV88",
+	"69Yq1Wq2SqpJS3WN15Q7PbsoLHe9UvvusZDNbV1nudByjUZKnT+yMmKM3lDk6q8zZFaLmfwWe6vKSp1q",
+	"d92V0G0qu1Zn0tOqXt4rlXf83UDID+/m3dRIa/KpCfc4WmjPO75v5I3VZIaGTrbV9VZ3wrq3e5XF3c7E",
+	"stIdPE6zRqv1vHss1uUuytLr6qreS3UGfXNgNeZSabUZrxfMZN6aoTHii+0KXDc8Vb4DmS5St5JXlEG9",
+	"kk3lpl3AF7nxNGe4SBihu3kHtGtl2ChUWoVshefqfHGG1u2CPLqjR0tYmSN9oBmZZb+YKb2u0HLs6E51",
+	"ZeiZidXV643OhtWEEsvW5pNdSRfYLJGdMkPculJKrXsVgqbSnO2WtPZzvS+UWLVQKYksx/bqKaFE+605",
+	"rTcqlQaFlsVBXNfWoDpczpDebA/5yWiDkLrNz1nV72EgVGocO9JKIr9eN9OwqQ64htRhMj24skV3Slsj",
+	"cce2S9ridYb0hVErrukS23OrLNsps70KKw6azmigTRepjOR1cDdj3tVzK5XJmbVmapHbrbXuvD4UhVV+",
+	"kpkhUS9WNyJOT9OVRgPSHT2PmMHE68wZjxkYbbgSqwWF7/Cu1rIlnZnyrZbFNTftZrMjo/VoPkPGoihU",
+	"BaeZzxbWnbYEpp3UULSsuTlkq3mulKsz3OMw2xgpnTtFWElTPvfMP7YG9cy8XSi33fQMeVmFpUH9FVad",
+	"sZTWPZPrKtO5rPAbUehrWSNVUZzH/k6eis7dq5BS2MloznmIy9+V3P5OMWeoDJ4z6Z63FcbrKn03KRUm",
+	"QqHLDqpsvWYY/UWP4ftavV1qFNJSWhNgice7kSnXCk7J7Uw2z+IMpZUMvcRm47ndfqymX3MDxmVGndrz",
+	"nHew1FkiUdrmKo3+q8f++ecM+Ual0uZiDM2HVqgilsusujxaocrSSvcnanabGcZaocq80tujLrBCdXlZ",
+	"XCllZj4RWVhd01thx6aFORtYH3EKyLM2x6YFcXF8JpSsDcHVDBGUlVhB5NKmp9Set9LoeQFGVXo6ID2W",
+	"y+yAP6ejVOqxnKZVuixXLhMrZJU1rVJiBUZa58uc0JjyvcFm+Gy4hTpfvfPKKxkXOzm5AaQ6yDBci1+k",
+	"1O5kaHUErZNez5BUs8VyVl02GiC3w8NUL6VvsKl3+V2Tb7k081rxnk0eWM5du1npTlZMZqw0ull9u+6+",
+	"Fh95e4aEzcLLqJtH1GG7rLXLu7vyNrUaL1b6prh7dLqFyohWuwNuMu+shWHFqykVZL++ipyitbplYzBD",
+	"wtA1mKrWzfItDQqcotqtbarXBfZw0exw8662mHg62u4csfOcyxRZkC1l3HEZOiK3e24V1RkSh42NCbkl",
+	"21Cz6aK0mouD0VQvyyu89SaeyjbMEm88TmulolXTWpZN83yZ9gpNBj+nR/rdaoaKtXSHaXJGvyiogw4v",
+	"d4v8YD16XE4mVdWsCWV2XWFZ0J4Ltcqa0ybcc5/uErtSYnscq1VqMySwBd/CVAJrVBVY3wKt6z2/dadU",
+	"mlSqwpxjnWnWLvKC6T6OlVL+zuxs0ECfdmdIKAU98OveRCgBtloWq/a02PfWdrapI52ZCOP1eNecG8zq",
+	"fEUqr4fsDJEVie2V6xYLW8PCdpfhcXND17t8pdKVavlUdlvMPvJdY7HRMovGqCVWm3qzy5iZtF0U+PFy",
+	"hlaNVbbW73lg1NmK5lSFaPNcL2QWr0UOFseFBb9zC06/2s8tV92RUsbWYKTz2+dmrT11C67Gz1CNrnVb",
+	"c9EpDEavd5InVTzM57EqcaVFcz63W+bq+XEAXh8lJsP02JwOG16nObpza0Y2k2GrvRma1pdFx2x1RupO",
+	"ThcKoNqRjHoZdMy5Npq6E20wNgvdO9WUhEY1pWQ2z6yanqb7lY7WUXNmUZshW9GMUdWBcts1hJLDvTLz",
+	"hQnZSi1VxNnneo1jsVAotofWuiOl88OphZtiacrvLGE9fbScwQwxSodetuqpttNaVTM02s41IWv09Me7",
+	"duddqxPeYdhwGedZloPA3gADDJf72Oq7m9jGoNOm3EPzgw+8jw+SPwEVDm+SXQOIhLSSFHApA1Nr4FLE",
+	"04cKJW0pA7snl3iGIi4c3Da209FGV2rmSkJtC4xLKhj1jI7B76bLSrY9mqTbZZpp7yqZlthj2nMeT+bt",
+	"5WRLbye7RVpIT9LtZXU5ESdGq9ygZfS8U6rFhZRmdDDKGh2jQU+5iidlGog31sYkXfTk9LOnVIs7pVal",
+	"lbFA2uhyvbSVMlNzWuZdHrXtaTpnTqtFXSnzeX5O/DkBC9wEC1x1KIh8XuDYvMCVAI/oPz/gvRfEWxFx",
+	"jf9KhGKrCkSG/8OGSCHffYvpiIMmxLC/zyV8cst6WzrjbIi3t5gNQcMykGgt4FmsPaOCQk7NZ+9zj8zj",
+	"fTaXT99LGVW+T8vFfEbN54EK8mGkep6hRLfBmXwyYQOMoYMST4n//xd9XwT36rfvhbf74+/sDb+Z9Nu/",
+	"4gRxJJw7xb5/8ab/gia4sYMt2/eYfXXw0v2xIx6zFC8LuH1RDaRBx3aMOJtQPb08WIAF3B5+WsjchnbF",
+	"/mZYBojy3CCKPbd8u0CAcjWQ/x4Wh0OeIy2XwF1A5QUfIBelsXEchVobWKeAaVKSh31LYwIXk52jA2R/",
+	"Bx70FDE6f/zxxx9pNRvLqSXYvHj7vOHxCya63c2kYxMOruU5MnyRDSUmr9SGeG05C0p1rCW11g1ZP+OX",
+	"z0YJEk5GqWXoB/qBeaBT6Wzip4TVkgnPhS+y5aEo5OibZu3T+07oKTJHsjCQD8g6sQRoSxHAuuQvYJrW",
+	"OpxyOqpEXIYjAo9kbCRwz8ejPMOzPKlgRN8+DIscjckX7fERzu/J5zjIxdSDz+MI6wINnsI0ZxkoHVLI",
+	"f0eU2I/7k5XbXRg2JUHVciBZ8x1sII08ly3ThDL2JedA1zMx5UL8EA7wxOaVCQkDYwcDAvYJ9zSdvEqN",
+	"GyHHgdhz0EMknU1/FFTqAizr/ZADUtryXB++evsg6qey69Es6IfxyMgCTxK20Q6kT3ZwkTQ/IydmgFgg",
+	"eDjMj6/xIqJO4KtGJdKL9OMj/uCg6XEDXeFNaICvsSYIqZLslKEaZ0HRhGK4i/i0e0gDwh+IEW+arKUO",
+	"tB3ou9t+RhxhA2+p8VeqMJKJH8Hxq8wMo+w3TKTc7mmcYWqvZZ9W/7Nuvjz+j1G2HzIL6auzkL7sh9jK",
+	"T0ZGnDMRwmOEhDihxrHoKoauiuUjhSqfxPfFJey44b9R/EH7H1R39JFyhkMGn8DYb5Nx3Mvzdsp/Oa7P",
+	"OXxJeTIGLtdVIQ6wgy5frVZ4Ljol1zZUFT6lUuEBU2TrQzKmL4ZC1jR/6fxwTcsWYrAzCIPg3WjWES7v",
+	"VGOAWn46zoCpepfHWmrb56ZKf9DGQqZo7qaj9nY67jemHNOYjBjx+Hd5OlfGJGyVo59rJp4+t+nJiFl3",
+	"xQoJU20FcbjuiMPldKyvwbhh+m1EetPhtHRblBmBWzAN1NClZX8lifRWmJNg/jA2jBTOWl/MNxDAPlUf",
+	"TI4kS/0oXky29PsscVb2Mks8/fV9FtoBzRJPswSTL2RzTD6TzcwSyVmCBAwMxX/DKuJUpuXHnVvMy3lt",
+	"1ds0SvmeUslz24HXVld+e9uTTEMmcQb/G6G6WFfWkzpJLuzmdJntTfj9b47tyVxPYysbpjvtr9VKhpu6",
+	"nde0UKI7ue5IldydA+xaW13mKtUUY63HOcRz7eVcNKRUe6s+lmF5NWjJFTlDT2wgrVhJa9ULspvWuR1D",
+	"0jaJt+S1+RWYy/mp2jPgZCCyE7Bb1NIjtZgZ4dpm2VfGKku3S1+dn8MN5obsoNfBEFXSW8g0LE8tcbWW",
+	"hHlh3qg+15qw3sFNMee9mqVUUyy005nc2HXHmtjq9QV9Z7OcLAjZYWpiyitru6jnlpo/v2/JWcKBqgNd",
+	"/UU3UDBD2ifUJQ4ukuFLsN3y3zz6b8Kq6T/GCjNLvF0FYLBI/wpP+ZfU2PwSbzwU0/wf6o+/2PupH6nc",
+	"faP++N8//vV+jC6woTesL0cT/CmP94vOmYUkCzgkUP3Vkpu/w7m7rAv7cGE7n/tldfnDobrcWn5xGfNl",
+	"8Q8LpUerQT9nDZCFX4CKofN12V/Q4+ogncvfVt2tXka1Qw5hbO9eAIgIvZ0/A5VLlv8cDj4sxj50cSQ1",
+	"GeLDJfLI5wZSrcM5FRCMHqhromZg3ZMIeh2TTBJj231KpTT/MUFiqg7XJsS4C+QFcJSUBkygOAY0L4xe",
+	"onZ4RQ1I/Z9DCQABLcj6kXMNrg3lgDWG5cfkTEOG+3DnnhzWBrIOqfQDHSHpKZVar9cPwH9LKtZT+0/d",
+	"VIsvV9qDyn36gX7Q8dInCxvYhHEEscczFvdUx4aI/Mr4Yx3r5RIMiYszvmmyIQK2QbTogX7IJHw90H3g",
+	"pfyq/Xu/kN9/sK/SJ+D0p8criadEy3Dx6RCK63fggCXE0HGJk3TGvw7JgpiGi0/nFlzKgbLlEMwBTEr7",
+	"fSkHpZcEwISLZA1NvHrQ2R4M01PCNZAME8lQqu4W8L8lP0fSPs77ITUewob5s6jx9c5wKf94wjUKDmcX",
+	"ThSctO901ub6cYhvyejZrzRNf+rc10118SeoXBbFXx4JG3iyDF2XnK06oi7QyeOxtLjBjtNIHc6vvflm",
+	"abkEznaP2Qv2hk6pJCkjKEclEDgeafKPuQT732QCA43AOzh/lfhG+k+RbM29n2l4X12OaYpPasspG3RC",
+	"RLja+Bow8NmqnLwxux0T4bydQN8LXEMnSMslKcsJPUIW9h9fVaYgA3Ui8zKz9UlK9lmrEx2KofhkBC+u",
+	"EXJKdr1Dyy9RnIts/G+gPiEuJ4N0Mtah4VArYHowlD8+KAuZAyUGqbhzjUl9nx9myHNvgWtiQgwvVagP",
+	"V9YCHvnxkQ6dStIjGXZf2mStOwk7REAi7I5gx4O36kywU7gGiV8inoA/LgWOzkho5knKtQIFMILyA2RR",
+	"pkU8vEMGnaQU9xuWs7OXsWJMxtu5GjyZOZJa/CdJ6YecdL7U11+mnzWIrwnfV1IDu4GK3qCh4XDo9VWt",
+	"BiO50w/XtXCvVJCVDco7QGRBo2zokGlgY3XVQsuRRMytALhIIb93+JQiQwVKY5Nsv2zYAEOXOAnRIr6/",
+	"YQFun5UnGIiCQNYpG2hXWWYf6g1upeNYoPAuAaRb970xg6afGvV4XOeXrLFhDP+N6yvR36CGJKRSFInR",
+	"K5QVOKaRYlIfngApKcs5lpwGShXI46DcURUlptv2YpT5rBBib2Shi0uWsv1h9vFKucXb29u5UX/7iVY6",
+	"KvFfJuGyH1M7sx7Uns2UBPEaQkThtXV5mvCKLC9sdep7+M8PXKpDHWu0HOj2NfsMLzGrdpSYf7J7FfAq",
+	"4l450elf17dbFs9/JOf/ycp45i7dLEyblNDFmM+4yrrfXKA/wby/V1/4trfyvxWOIpgY+nmOL8DiYzuc",
+	"2i/RN3vT5UP7/1ij8GnnbM+S38BH25/VOcj0IIGwf+YG538+ASD/6/vg69T3s93DWyrIId470A3QY1tx",
+	"12yMDmfqg+aERGwgj+Rhbcs05C1luBREQDJJWAycLtQBmFpAaLskNBCcLD9enXKYnuw5DkSH0okZ2j+O",
+	"3tgDkEKGQBYOnWgC/pEIP4q6r5o2cJI0cyBJ5UCF8pAJXZcCaH9b1OHYj89CUk9NDuLC/WNgkm8J971l",
+	"MAChQzWcU10HkGVoY//CAYAWbjAkcYujShdc/wFDF4J8pG+RLeJ+Xxejapebv6/p2uVm8GeuxXEXo/w6",
+	"7doLwxcmgpujLI+XRxwuFIvIgETzKYPshCnFggHy9qiHMbANKWEw289o3/sR/sglEO5/PJBust8RnvwO",
+	"0es9pnTDxZazDYz0cbmPmjITYOji40Uwvn359+CT+r7v7O09NyDKs98dRhexoT3h0SrCQ24tQm5UFvGE",
+	"n+5o+RrB8ffA/HwjeoT837WjWcWKIYL7sDB+FLBTyv7qu5vQ7d+T91+E/wSEXyXaP7K2tIEDKaARRxU/",
+	"UHtAuYd7rQ7YsR0oQ8V32fzIpL+R8+ORV8PMJGCZ+N2V04fdr982hEqJKf/GPd9b3t+xF/jJe8AcBLCX",
+	"lBJcHUB4S53g8mP01bFMUwLyIrynOEu97luUDg7Uf9X1vwvSDbDv+qmyd9ehZOhCvSuX6VF+jRUFKATX",
+	"8HgDrOEetwVf1IRTFcJ7q9XNBQe/H+xFYwnvsXXfMlaQ+h9RbP0vwbwLZQsp7nEvdZl4P09jYvNdKo8g",
+	"yeTpuBPwx+PJhXyWpt8/Ff1eojE4+X79DoDrFQxxs1qCzTA45R4zE+a9aTA0/cE568tZ1G+rsA2OSnz5",
+	"+oi4eR5LAJtwG7qpIjLvuBrofDaupvkt+W9d0+BX2JV5rk826YGViac6uBmCtLxS2fjuHQ+/psIjdP/L",
+	"r/XwQ7wNbkqP3b6+V94RtozvhzRCZia4DvgncTU0zq8OCpC7UCKB2xDnIgx9L3Uepv+nZc5jDuPH5lSY",
+	"XyWWIKGt/IjQn6KEgRyWx3VxXED5cpX/OP0dmmJpu1+Sb1/o0e8Tt/u70uC3yep6Fvw/RgD/YEN4Fjq6",
+	"VaQ3WMN/kkh/vNE+k+bbfxxwLjPmN5pu0ot/cimAQ/SMlmnJwNQtFz+4a6Bp0HkwrBSwjdQqQ07wHjq9",
+	"+KdoqMgJ7+NyvEdP5OmlJ8tGy7IMdx/z2p9o9d8EYdP9KNVgv0oeh9O57xZy7UkJt3djaOnHjBpy+yTL",
+	"Q/uQ0PlJkP0AIZcvZmOoR+LAp125G021Xe39eEY1rmcMljZ07uHKv7qAHKw5DOJndg0XE6at/NrF0NYm",
+	"+LdX3NAowSGbt29v/zcAbzzlU6xqAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -1,9 +1,12 @@
 package admin
 
 import (
+	"encoding/hex"
 	"fmt"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
 
@@ -35,9 +38,15 @@ func (td *PutTrustDomainRequest) ToEntity() (*entity.TrustDomain, error) {
 		description = *td.Description
 	}
 
+	bundleVerifier := ""
+	if td.BundleVerifier != nil {
+		bundleVerifier = *td.BundleVerifier
+	}
+
 	return &entity.TrustDomain{
-		Name:        tdName,
-		Description: description,
+		Name:           tdName,
+		Description:    description,
+		BundleVerifier: bundleVerifier,
 	}, nil
 }
 
@@ -59,3 +68,106 @@ func (r *PatchRelationshipByIDRequest) ToEntity() (*entity.Relationship, error)
 		TrustDomainBConsent: entity.ConsentStatus(consentStatusB),
 	}, nil
 }
+
+// BundleVersionFromEntity converts a bundle version entity into its API representation.
+func BundleVersionFromEntity(bv *entity.BundleVersion, pinned bool) BundleVersion {
+	resp := BundleVersion{
+		Version:     bv.Version,
+		TrustBundle: string(bv.Data),
+		Digest:      encoding.EncodeToBase64(bv.Digest),
+		Pinned:      pinned,
+		CreatedAt:   bv.CreatedAt,
+	}
+
+	if len(bv.Signature) > 0 {
+		signature := encoding.EncodeToBase64(bv.Signature)
+		resp.Signature = &signature
+	}
+
+	if len(bv.SigningCertificateChain) > 0 {
+		chain := encoding.EncodeToBase64(bv.SigningCertificateChain)
+		resp.SigningCertificateChain = &chain
+	}
+
+	if bv.UploadedBy != "" {
+		uploadedBy := bv.UploadedBy
+		resp.UploadedBy = &uploadedBy
+	}
+
+	return resp
+}
+
+// JoinTokenDetailsFromEntity converts a join token entity into its API representation, with the token value masked.
+func JoinTokenDetailsFromEntity(jt *entity.JoinToken, now time.Time) JoinTokenDetails {
+	resp := JoinTokenDetails{
+		Id:              jt.ID.UUID,
+		MaskedToken:     jt.MaskedToken(),
+		TrustDomainName: jt.TrustDomainName.String(),
+		Used:            jt.Used,
+		MaxUses:         jt.MaxUses,
+		UseCount:        jt.UseCount,
+		Expired:         jt.IsExpired(now),
+		ExpiresAt:       jt.ExpiresAt.UTC(),
+		CreatedAt:       jt.CreatedAt.UTC(),
+	}
+
+	if jt.HarvesterKeyFingerprint != "" {
+		fingerprint := jt.HarvesterKeyFingerprint
+		resp.HarvesterKeyFingerprint = &fingerprint
+	}
+
+	if jt.SourceCIDR != "" {
+		sourceCIDR := jt.SourceCIDR
+		resp.SourceCidr = &sourceCIDR
+	}
+
+	return resp
+}
+
+// AuditEventFromEntity converts an audit event entity into its API representation.
+func AuditEventFromEntity(e *entity.AuditEvent) AuditEvent {
+	resp := AuditEvent{
+		Id:        e.ID,
+		Actor:     e.Actor,
+		Action:    string(e.Action),
+		Target:    e.Target,
+		PrevHash:  hex.EncodeToString(e.PrevHash),
+		Hash:      hex.EncodeToString(e.Hash),
+		CreatedAt: e.CreatedAt.UTC(),
+	}
+
+	if e.Details != "" {
+		details := e.Details
+		resp.Details = &details
+	}
+
+	return resp
+}
+
+func (e *AuditEvent) ToEntity() (*entity.AuditEvent, error) {
+	prevHash, err := hex.DecodeString(e.PrevHash)
+	if err != nil {
+		return nil, fmt.Errorf("malformed previous hash of audit event %d: %v", e.Id, err)
+	}
+
+	hash, err := hex.DecodeString(e.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("malformed hash of audit event %d: %v", e.Id, err)
+	}
+
+	details := ""
+	if e.Details != nil {
+		details = *e.Details
+	}
+
+	return &entity.AuditEvent{
+		ID:        e.Id,
+		Actor:     e.Actor,
+		Action:    entity.AuditAction(e.Action),
+		Target:    e.Target,
+		Details:   details,
+		PrevHash:  prevHash,
+		Hash:      hash,
+		CreatedAt: e.CreatedAt,
+	}, nil
+}
@@ -2,8 +2,13 @@ package admin
 
 import (
 	"testing"
+	"time"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/google/uuid"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -57,3 +62,58 @@ func TestTrustDomainPutToEntity(t *testing.T) {
 		assert.Equal(t, *tdPut.Description, trustDomain.Description)
 	})
 }
+
+func TestAuditEventConversion(t *testing.T) {
+	event := &entity.AuditEvent{
+		ID:        7,
+		Actor:     "admin",
+		Action:    entity.AuditActionRelationshipUpdate,
+		Target:    "c8a6e0ac-1f5c-4a6a-a8e6-6a4e6c4e7a3b",
+		Details:   "consent_status_a: approved",
+		PrevHash:  entity.GenesisAuditHash,
+		CreatedAt: time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+	event.Hash = event.ComputeHash()
+
+	apiEvent := AuditEventFromEntity(event)
+	assert.Equal(t, "0000000000000000000000000000000000000000000000000000000000000000", apiEvent.PrevHash)
+	assert.Equal(t, "relationship.update", apiEvent.Action)
+	require.NotNil(t, apiEvent.Details)
+
+	converted, err := apiEvent.ToEntity()
+	require.NoError(t, err)
+	assert.Equal(t, event, converted)
+
+	apiEvent.Hash = "not hex"
+	_, err = apiEvent.ToEntity()
+	assert.ErrorContains(t, err, "malformed hash of audit event 7")
+}
+
+func TestJoinTokenDetailsFromEntity(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	jt := &entity.JoinToken{
+		ID:              uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		TokenHint:       "2f4e",
+		Used:            true,
+		MaxUses:         3,
+		UseCount:        3,
+		SourceCIDR:      "10.0.1.0/24",
+		TrustDomainName: spiffeid.RequireTrustDomainFromString(td1),
+		ExpiresAt:       now.Add(-time.Minute),
+		CreatedAt:       now.Add(-time.Hour),
+	}
+
+	details := JoinTokenDetailsFromEntity(jt, now)
+	assert.Equal(t, jt.ID.UUID, details.Id)
+	assert.Equal(t, "****2f4e", details.MaskedToken)
+	assert.Equal(t, td1, details.TrustDomainName)
+	assert.True(t, details.Used)
+	assert.Equal(t, int32(3), details.MaxUses)
+	assert.Equal(t, int32(3), details.UseCount)
+	assert.Nil(t, details.HarvesterKeyFingerprint)
+	require.NotNil(t, details.SourceCidr)
+	assert.Equal(t, "10.0.1.0/24", *details.SourceCidr)
+	assert.True(t, details.Expired)
+	assert.Equal(t, jt.ExpiresAt, details.ExpiresAt)
+	assert.Equal(t, jt.CreatedAt, details.CreatedAt)
+}
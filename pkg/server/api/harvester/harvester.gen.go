@@ -15,6 +15,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	externalRef0 "github.com/HewlettPackard/galadriel/pkg/common/api"
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
@@ -47,14 +48,87 @@ type BundlesUpdatesItem struct {
 	TrustBundle externalRef0.TrustBundle `json:"trust_bundle"`
 }
 
+// ClientCertificateRequest defines model for ClientCertificateRequest.
+type ClientCertificateRequest struct {
+	// Csr base64 encoded DER certificate signing request, signed with the private key of the Harvester
+	Csr string `json:"csr"`
+}
+
+// ClientCertificateResponse defines model for ClientCertificateResponse.
+type ClientCertificateResponse struct {
+	// CertificateChain X.509 certificate chain in PEM format
+	CertificateChain externalRef0.CertificateChain `json:"certificate_chain"`
+
+	// ExpiresAt Time the client certificate expires at
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 // GetJwtResponse defines model for GetJwtResponse.
 type GetJwtResponse struct {
 	Token externalRef0.JWT `json:"token"`
 }
 
+// GetRelationshipConsentResponse defines model for GetRelationshipConsentResponse.
+type GetRelationshipConsentResponse = []externalRef0.RelationshipConsent
+
 // GetRelationshipResponse defines model for GetRelationshipResponse.
 type GetRelationshipResponse = []externalRef0.Relationship
 
+// JWK defines model for JWK.
+type JWK struct {
+	Alg *string `json:"alg,omitempty"`
+
+	// Crv Curve of an EC key
+	Crv *string `json:"crv,omitempty"`
+
+	// E base64url encoded exponent of an RSA key
+	E *string `json:"e,omitempty"`
+
+	// Kid ID of the key, matching the kid header of the JWTs it signed
+	Kid string `json:"kid"`
+
+	// Kty Key type (RSA or EC)
+	Kty string `json:"kty"`
+
+	// N base64url encoded modulus of an RSA key
+	N   *string `json:"n,omitempty"`
+	Use *string `json:"use,omitempty"`
+
+	// X base64url encoded x coordinate of an EC key
+	X *string `json:"x,omitempty"`
+
+	// Y base64url encoded y coordinate of an EC key
+	Y *string `json:"y,omitempty"`
+}
+
+// JWKS defines model for JWKS.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// Lease defines model for Lease.
+type Lease struct {
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// InstanceId ID of the Harvester instance holding the lease
+	InstanceId string `json:"instance_id"`
+}
+
+// LeaseRequest defines model for LeaseRequest.
+type LeaseRequest struct {
+	// InstanceId ID of the Harvester instance
+	InstanceId string `json:"instance_id"`
+}
+
+// OnboardChallengeResponse defines model for OnboardChallengeResponse.
+type OnboardChallengeResponse struct {
+	// ExpiresAt time after which the nonce can no longer be used
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Nonce nonce to include in the signed onboard statement, it can only be used once
+	Nonce string `json:"nonce"`
+}
+
 // OnboardHarvesterResponse defines model for OnboardHarvesterResponse.
 type OnboardHarvesterResponse struct {
 	Token           externalRef0.JWT             `json:"token"`
@@ -62,20 +136,51 @@ type OnboardHarvesterResponse struct {
 	TrustDomainName externalRef0.TrustDomainName `json:"trustDomainName"`
 }
 
+// OnboardProofRequest defines model for OnboardProofRequest.
+type OnboardProofRequest struct {
+	// OnboardStatement base64 encoded JSON statement of the trust domain to onboard, as it was signed by the Harvester
+	OnboardStatement string `json:"onboard_statement"`
+
+	// Signature base64 encoded signature of the bundle
+	Signature externalRef0.Signature `json:"signature"`
+
+	// SigningCertificateChain X.509 certificate chain in PEM format
+	SigningCertificateChain externalRef0.CertificateChain `json:"signing_certificate_chain"`
+}
+
 // PatchRelationshipRequest defines model for PatchRelationshipRequest.
 type PatchRelationshipRequest struct {
-	ConsentStatus externalRef0.ConsentStatus `json:"consent_status"`
+	// ConsentStatement base64 encoded JSON statement of the consent of a trust domain to a relationship, as it was signed by its Harvester
+	ConsentStatement *externalRef0.ConsentStatement `json:"consent_statement,omitempty"`
+	ConsentStatus    externalRef0.ConsentStatus     `json:"consent_status"`
+
+	// Signature base64 encoded signature of the bundle
+	Signature *externalRef0.Signature `json:"signature,omitempty"`
+
+	// SigningCertificateChain X.509 certificate chain in PEM format
+	SigningCertificateChain *externalRef0.CertificateChain `json:"signing_certificate_chain,omitempty"`
 }
 
 // PostBundleSyncRequest defines model for PostBundleSyncRequest.
 type PostBundleSyncRequest struct {
-	State BundlesDigests `json:"state"`
+	// Cursor Cursor returned by the previous sync. When set, the state can be left empty and the response only contains the changes made since that sync.
+	Cursor *int64         `json:"cursor,omitempty"`
+	State  BundlesDigests `json:"state"`
+
+	// Wait Number of seconds to wait for a change before responding. When set, the response is only sent once the federated bundles differ from the given state, or when the wait time elapses.
+	Wait *int `json:"wait,omitempty"`
 }
 
 // PostBundleSyncResponse defines model for PostBundleSyncResponse.
 type PostBundleSyncResponse struct {
-	State   BundlesDigests `json:"state"`
-	Updates BundlesUpdates `json:"updates"`
+	// Cursor Cursor to send in the next sync to only receive the changes made after this one.
+	Cursor *int64 `json:"cursor,omitempty"`
+
+	// Incremental True when the response only contains the changes made since the given cursor. The state then only holds the digests of the federated bundles that changed, and removed lists the Trust Domains that are no longer federated. False when the response contains the full state.
+	Incremental *bool                           `json:"incremental,omitempty"`
+	Removed     *[]externalRef0.TrustDomainName `json:"removed,omitempty"`
+	State       BundlesDigests                  `json:"state"`
+	Updates     BundlesUpdates                  `json:"updates"`
 }
 
 // PutBundleRequest defines model for PutBundleRequest.
@@ -83,6 +188,9 @@ type PutBundleRequest struct {
 	// Digest base64 encoded SHA-256 digest of the bundle
 	Digest externalRef0.BundleDigest `json:"digest"`
 
+	// InstanceId ID of the Harvester instance uploading the bundle, required while another instance holds the lease
+	InstanceId *string `json:"instance_id,omitempty"`
+
 	// Signature base64 encoded signature of the bundle
 	Signature *externalRef0.Signature `json:"signature,omitempty"`
 
@@ -97,10 +205,25 @@ type PutBundleRequest struct {
 // Default defines model for Default.
 type Default = externalRef0.ApiError
 
+// ReleaseLeaseParams defines parameters for ReleaseLease.
+type ReleaseLeaseParams struct {
+	// InstanceID ID of the Harvester instance releasing the lease
+	InstanceID string `form:"instanceID" json:"instanceID"`
+}
+
 // OnboardParams defines parameters for Onboard.
 type OnboardParams struct {
 	// JoinToken Join token to be used for onboarding
 	JoinToken string `form:"joinToken" json:"joinToken"`
+
+	// HarvesterPublicKey Base64 encoded DER public key of the Harvester, required by the join tokens bound to a Harvester key fingerprint
+	HarvesterPublicKey *string `form:"harvesterPublicKey,omitempty" json:"harvesterPublicKey,omitempty"`
+
+	// SignedAt Time at which the Harvester signed the onboard statement
+	SignedAt *time.Time `form:"signedAt,omitempty" json:"signedAt,omitempty"`
+
+	// Signature Base64 encoded signature of the onboard statement made with the Harvester key
+	Signature *string `form:"signature,omitempty" json:"signature,omitempty"`
 }
 
 // GetRelationshipsParams defines parameters for GetRelationships.
@@ -120,6 +243,15 @@ type BundlePutJSONRequestBody = PutBundleRequest
 // BundleSyncJSONRequestBody defines body for BundleSync for application/json ContentType.
 type BundleSyncJSONRequestBody = PostBundleSyncRequest
 
+// IssueClientCertificateJSONRequestBody defines body for IssueClientCertificate for application/json ContentType.
+type IssueClientCertificateJSONRequestBody = ClientCertificateRequest
+
+// AcquireLeaseJSONRequestBody defines body for AcquireLease for application/json ContentType.
+type AcquireLeaseJSONRequestBody = LeaseRequest
+
+// OnboardWithProofJSONRequestBody defines body for OnboardWithProof for application/json ContentType.
+type OnboardWithProofJSONRequestBody = OnboardProofRequest
+
 // PatchRelationshipJSONRequestBody defines body for PatchRelationship for application/json ContentType.
 type PatchRelationshipJSONRequestBody = PatchRelationshipRequest
 
@@ -196,6 +328,9 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetJWKS request
+	GetJWKS(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// BundlePut request with any body
 	BundlePutWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -206,12 +341,33 @@ type ClientInterface interface {
 
 	BundleSync(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body BundleSyncJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// IssueClientCertificate request with any body
+	IssueClientCertificateWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	IssueClientCertificate(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body IssueClientCertificateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetNewJWTToken request
 	GetNewJWTToken(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ReleaseLease request
+	ReleaseLease(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *ReleaseLeaseParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AcquireLease request with any body
+	AcquireLeaseWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AcquireLease(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body AcquireLeaseJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Onboard request
 	Onboard(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *OnboardParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// OnboardWithProof request with any body
+	OnboardWithProofWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	OnboardWithProof(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body OnboardWithProofJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateOnboardChallenge request
+	CreateOnboardChallenge(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetRelationships request
 	GetRelationships(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -219,6 +375,21 @@ type ClientInterface interface {
 	PatchRelationshipWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PatchRelationship(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, body PatchRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRelationshipConsents request
+	GetRelationshipConsents(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetJWKS(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetJWKSRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
 func (c *Client) BundlePutWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -269,6 +440,30 @@ func (c *Client) BundleSync(ctx context.Context, trustDomainName externalRef0.Tr
 	return c.Client.Do(req)
 }
 
+func (c *Client) IssueClientCertificateWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewIssueClientCertificateRequestWithBody(c.Server, trustDomainName, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) IssueClientCertificate(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body IssueClientCertificateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewIssueClientCertificateRequest(c.Server, trustDomainName, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetNewJWTToken(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetNewJWTTokenRequest(c.Server, trustDomainName)
 	if err != nil {
@@ -281,6 +476,42 @@ func (c *Client) GetNewJWTToken(ctx context.Context, trustDomainName externalRef
 	return c.Client.Do(req)
 }
 
+func (c *Client) ReleaseLease(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *ReleaseLeaseParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReleaseLeaseRequest(c.Server, trustDomainName, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AcquireLeaseWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAcquireLeaseRequestWithBody(c.Server, trustDomainName, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AcquireLease(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body AcquireLeaseJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAcquireLeaseRequest(c.Server, trustDomainName, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) Onboard(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *OnboardParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewOnboardRequest(c.Server, trustDomainName, params)
 	if err != nil {
@@ -293,6 +524,42 @@ func (c *Client) Onboard(ctx context.Context, trustDomainName externalRef0.Trust
 	return c.Client.Do(req)
 }
 
+func (c *Client) OnboardWithProofWithBody(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewOnboardWithProofRequestWithBody(c.Server, trustDomainName, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) OnboardWithProof(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body OnboardWithProofJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewOnboardWithProofRequest(c.Server, trustDomainName, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateOnboardChallenge(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateOnboardChallengeRequest(c.Server, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetRelationships(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetRelationshipsRequest(c.Server, trustDomainName, params)
 	if err != nil {
@@ -329,6 +596,45 @@ func (c *Client) PatchRelationship(ctx context.Context, trustDomainName external
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetRelationshipConsents(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRelationshipConsentsRequest(c.Server, trustDomainName, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetJWKSRequest generates requests for GetJWKS
+func NewGetJWKSRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/.well-known/jwks.json")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewBundlePutRequest calls the generic BundlePut builder with application/json body
 func NewBundlePutRequest(server string, trustDomainName externalRef0.TrustDomainName, body BundlePutJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -423,6 +729,53 @@ func NewBundleSyncRequestWithBody(server string, trustDomainName externalRef0.Tr
 	return req, nil
 }
 
+// NewIssueClientCertificateRequest calls the generic IssueClientCertificate builder with application/json body
+func NewIssueClientCertificateRequest(server string, trustDomainName externalRef0.TrustDomainName, body IssueClientCertificateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewIssueClientCertificateRequestWithBody(server, trustDomainName, "application/json", bodyReader)
+}
+
+// NewIssueClientCertificateRequestWithBody generates requests for IssueClientCertificate with any type of body
+func NewIssueClientCertificateRequestWithBody(server string, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/client-certificate", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewGetNewJWTTokenRequest generates requests for GetNewJWTToken
 func NewGetNewJWTTokenRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
 	var err error
@@ -457,8 +810,8 @@ func NewGetNewJWTTokenRequest(server string, trustDomainName externalRef0.TrustD
 	return req, nil
 }
 
-// NewOnboardRequest generates requests for Onboard
-func NewOnboardRequest(server string, trustDomainName externalRef0.TrustDomainName, params *OnboardParams) (*http.Request, error) {
+// NewReleaseLeaseRequest generates requests for ReleaseLease
+func NewReleaseLeaseRequest(server string, trustDomainName externalRef0.TrustDomainName, params *ReleaseLeaseParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -473,7 +826,7 @@ func NewOnboardRequest(server string, trustDomainName externalRef0.TrustDomainNa
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/trust-domain/%s/onboard", pathParam0)
+	operationPath := fmt.Sprintf("/trust-domain/%s/lease", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -486,7 +839,7 @@ func NewOnboardRequest(server string, trustDomainName externalRef0.TrustDomainNa
 	if params != nil {
 		queryValues := queryURL.Query()
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "joinToken", runtime.ParamLocationQuery, params.JoinToken); err != nil {
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "instanceID", runtime.ParamLocationQuery, params.InstanceID); err != nil {
 			return nil, err
 		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 			return nil, err
@@ -501,7 +854,7 @@ func NewOnboardRequest(server string, trustDomainName externalRef0.TrustDomainNa
 		queryURL.RawQuery = queryValues.Encode()
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -509,8 +862,19 @@ func NewOnboardRequest(server string, trustDomainName externalRef0.TrustDomainNa
 	return req, nil
 }
 
-// NewGetRelationshipsRequest generates requests for GetRelationships
-func NewGetRelationshipsRequest(server string, trustDomainName externalRef0.TrustDomainName, params *GetRelationshipsParams) (*http.Request, error) {
+// NewAcquireLeaseRequest calls the generic AcquireLease builder with application/json body
+func NewAcquireLeaseRequest(server string, trustDomainName externalRef0.TrustDomainName, body AcquireLeaseJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAcquireLeaseRequestWithBody(server, trustDomainName, "application/json", bodyReader)
+}
+
+// NewAcquireLeaseRequestWithBody generates requests for AcquireLease with any type of body
+func NewAcquireLeaseRequestWithBody(server string, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -525,7 +889,7 @@ func NewGetRelationshipsRequest(server string, trustDomainName externalRef0.Trus
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/trust-domain/%s/relationships", pathParam0)
+	operationPath := fmt.Sprintf("/trust-domain/%s/lease", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -535,18 +899,235 @@ func NewGetRelationshipsRequest(server string, trustDomainName externalRef0.Trus
 		return nil, err
 	}
 
-	if params != nil {
-		queryValues := queryURL.Query()
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
 
-		if params.ConsentStatus != nil {
+	req.Header.Add("Content-Type", contentType)
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "consentStatus", runtime.ParamLocationQuery, *params.ConsentStatus); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
+	return req, nil
+}
+
+// NewOnboardRequest generates requests for Onboard
+func NewOnboardRequest(server string, trustDomainName externalRef0.TrustDomainName, params *OnboardParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/onboard", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "joinToken", runtime.ParamLocationQuery, params.JoinToken); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.HarvesterPublicKey != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "harvesterPublicKey", runtime.ParamLocationQuery, *params.HarvesterPublicKey); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.SignedAt != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "signedAt", runtime.ParamLocationQuery, *params.SignedAt); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Signature != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "signature", runtime.ParamLocationQuery, *params.Signature); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewOnboardWithProofRequest calls the generic OnboardWithProof builder with application/json body
+func NewOnboardWithProofRequest(server string, trustDomainName externalRef0.TrustDomainName, body OnboardWithProofJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewOnboardWithProofRequestWithBody(server, trustDomainName, "application/json", bodyReader)
+}
+
+// NewOnboardWithProofRequestWithBody generates requests for OnboardWithProof with any type of body
+func NewOnboardWithProofRequestWithBody(server string, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/onboard", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCreateOnboardChallengeRequest generates requests for CreateOnboardChallenge
+func NewCreateOnboardChallengeRequest(server string, trustDomainName externalRef0.TrustDomainName) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/onboard/challenge", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRelationshipsRequest generates requests for GetRelationships
+func NewGetRelationshipsRequest(server string, trustDomainName externalRef0.TrustDomainName, params *GetRelationshipsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/relationships", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ConsentStatus != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "consentStatus", runtime.ParamLocationQuery, *params.ConsentStatus); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
 						queryValues.Add(k, v2)
 					}
 				}
@@ -651,6 +1232,47 @@ func NewPatchRelationshipRequestWithBody(server string, trustDomainName external
 	return req, nil
 }
 
+// NewGetRelationshipConsentsRequest generates requests for GetRelationshipConsents
+func NewGetRelationshipConsentsRequest(server string, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, trustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trust-domain/%s/relationships/%s/consents", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -694,6 +1316,9 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// GetJWKS request
+	GetJWKSWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetJWKSResponse, error)
+
 	// BundlePut request with any body
 	BundlePutWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BundlePutResponse, error)
 
@@ -704,12 +1329,33 @@ type ClientWithResponsesInterface interface {
 
 	BundleSyncWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body BundleSyncJSONRequestBody, reqEditors ...RequestEditorFn) (*BundleSyncResponse, error)
 
+	// IssueClientCertificate request with any body
+	IssueClientCertificateWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*IssueClientCertificateResponse, error)
+
+	IssueClientCertificateWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body IssueClientCertificateJSONRequestBody, reqEditors ...RequestEditorFn) (*IssueClientCertificateResponse, error)
+
 	// GetNewJWTToken request
 	GetNewJWTTokenWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetNewJWTTokenResponse, error)
 
+	// ReleaseLease request
+	ReleaseLeaseWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *ReleaseLeaseParams, reqEditors ...RequestEditorFn) (*ReleaseLeaseResponse, error)
+
+	// AcquireLease request with any body
+	AcquireLeaseWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AcquireLeaseResponse, error)
+
+	AcquireLeaseWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body AcquireLeaseJSONRequestBody, reqEditors ...RequestEditorFn) (*AcquireLeaseResponse, error)
+
 	// Onboard request
 	OnboardWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *OnboardParams, reqEditors ...RequestEditorFn) (*OnboardResponse, error)
 
+	// OnboardWithProof request with any body
+	OnboardWithProofWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*OnboardWithProofResponse, error)
+
+	OnboardWithProofWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body OnboardWithProofJSONRequestBody, reqEditors ...RequestEditorFn) (*OnboardWithProofResponse, error)
+
+	// CreateOnboardChallenge request
+	CreateOnboardChallengeWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*CreateOnboardChallengeResponse, error)
+
 	// GetRelationships request
 	GetRelationshipsWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*GetRelationshipsResponse, error)
 
@@ -717,9 +1363,35 @@ type ClientWithResponsesInterface interface {
 	PatchRelationshipWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error)
 
 	PatchRelationshipWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, body PatchRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error)
+
+	// GetRelationshipConsents request
+	GetRelationshipConsentsWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipConsentsResponse, error)
 }
 
-type BundlePutResponse struct {
+type GetJWKSResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *JWKS
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetJWKSResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetJWKSResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type BundlePutResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSONDefault  *externalRef0.ApiError
@@ -764,6 +1436,29 @@ func (r BundleSyncResponse) StatusCode() int {
 	return 0
 }
 
+type IssueClientCertificateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ClientCertificateResponse
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r IssueClientCertificateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r IssueClientCertificateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetNewJWTTokenResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -787,6 +1482,52 @@ func (r GetNewJWTTokenResponse) StatusCode() int {
 	return 0
 }
 
+type ReleaseLeaseResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r ReleaseLeaseResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReleaseLeaseResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AcquireLeaseResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Lease
+	JSON409      *Lease
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r AcquireLeaseResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AcquireLeaseResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type OnboardResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -810,6 +1551,52 @@ func (r OnboardResponse) StatusCode() int {
 	return 0
 }
 
+type OnboardWithProofResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OnboardHarvesterResponse
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r OnboardWithProofResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r OnboardWithProofResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateOnboardChallengeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OnboardChallengeResponse
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateOnboardChallengeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateOnboardChallengeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetRelationshipsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -856,6 +1643,38 @@ func (r PatchRelationshipResponse) StatusCode() int {
 	return 0
 }
 
+type GetRelationshipConsentsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetRelationshipConsentResponse
+	JSONDefault  *externalRef0.ApiError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRelationshipConsentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRelationshipConsentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetJWKSWithResponse request returning *GetJWKSResponse
+func (c *ClientWithResponses) GetJWKSWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetJWKSResponse, error) {
+	rsp, err := c.GetJWKS(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetJWKSResponse(rsp)
+}
+
 // BundlePutWithBodyWithResponse request with arbitrary body returning *BundlePutResponse
 func (c *ClientWithResponses) BundlePutWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BundlePutResponse, error) {
 	rsp, err := c.BundlePutWithBody(ctx, trustDomainName, contentType, body, reqEditors...)
@@ -873,81 +1692,357 @@ func (c *ClientWithResponses) BundlePutWithResponse(ctx context.Context, trustDo
 	return ParseBundlePutResponse(rsp)
 }
 
-// BundleSyncWithBodyWithResponse request with arbitrary body returning *BundleSyncResponse
-func (c *ClientWithResponses) BundleSyncWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BundleSyncResponse, error) {
-	rsp, err := c.BundleSyncWithBody(ctx, trustDomainName, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+// BundleSyncWithBodyWithResponse request with arbitrary body returning *BundleSyncResponse
+func (c *ClientWithResponses) BundleSyncWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BundleSyncResponse, error) {
+	rsp, err := c.BundleSyncWithBody(ctx, trustDomainName, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBundleSyncResponse(rsp)
+}
+
+func (c *ClientWithResponses) BundleSyncWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body BundleSyncJSONRequestBody, reqEditors ...RequestEditorFn) (*BundleSyncResponse, error) {
+	rsp, err := c.BundleSync(ctx, trustDomainName, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBundleSyncResponse(rsp)
+}
+
+// IssueClientCertificateWithBodyWithResponse request with arbitrary body returning *IssueClientCertificateResponse
+func (c *ClientWithResponses) IssueClientCertificateWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*IssueClientCertificateResponse, error) {
+	rsp, err := c.IssueClientCertificateWithBody(ctx, trustDomainName, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseIssueClientCertificateResponse(rsp)
+}
+
+func (c *ClientWithResponses) IssueClientCertificateWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body IssueClientCertificateJSONRequestBody, reqEditors ...RequestEditorFn) (*IssueClientCertificateResponse, error) {
+	rsp, err := c.IssueClientCertificate(ctx, trustDomainName, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseIssueClientCertificateResponse(rsp)
+}
+
+// GetNewJWTTokenWithResponse request returning *GetNewJWTTokenResponse
+func (c *ClientWithResponses) GetNewJWTTokenWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetNewJWTTokenResponse, error) {
+	rsp, err := c.GetNewJWTToken(ctx, trustDomainName, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetNewJWTTokenResponse(rsp)
+}
+
+// ReleaseLeaseWithResponse request returning *ReleaseLeaseResponse
+func (c *ClientWithResponses) ReleaseLeaseWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *ReleaseLeaseParams, reqEditors ...RequestEditorFn) (*ReleaseLeaseResponse, error) {
+	rsp, err := c.ReleaseLease(ctx, trustDomainName, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReleaseLeaseResponse(rsp)
+}
+
+// AcquireLeaseWithBodyWithResponse request with arbitrary body returning *AcquireLeaseResponse
+func (c *ClientWithResponses) AcquireLeaseWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AcquireLeaseResponse, error) {
+	rsp, err := c.AcquireLeaseWithBody(ctx, trustDomainName, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAcquireLeaseResponse(rsp)
+}
+
+func (c *ClientWithResponses) AcquireLeaseWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body AcquireLeaseJSONRequestBody, reqEditors ...RequestEditorFn) (*AcquireLeaseResponse, error) {
+	rsp, err := c.AcquireLease(ctx, trustDomainName, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAcquireLeaseResponse(rsp)
+}
+
+// OnboardWithResponse request returning *OnboardResponse
+func (c *ClientWithResponses) OnboardWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *OnboardParams, reqEditors ...RequestEditorFn) (*OnboardResponse, error) {
+	rsp, err := c.Onboard(ctx, trustDomainName, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOnboardResponse(rsp)
+}
+
+// OnboardWithProofWithBodyWithResponse request with arbitrary body returning *OnboardWithProofResponse
+func (c *ClientWithResponses) OnboardWithProofWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*OnboardWithProofResponse, error) {
+	rsp, err := c.OnboardWithProofWithBody(ctx, trustDomainName, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOnboardWithProofResponse(rsp)
+}
+
+func (c *ClientWithResponses) OnboardWithProofWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body OnboardWithProofJSONRequestBody, reqEditors ...RequestEditorFn) (*OnboardWithProofResponse, error) {
+	rsp, err := c.OnboardWithProof(ctx, trustDomainName, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOnboardWithProofResponse(rsp)
+}
+
+// CreateOnboardChallengeWithResponse request returning *CreateOnboardChallengeResponse
+func (c *ClientWithResponses) CreateOnboardChallengeWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*CreateOnboardChallengeResponse, error) {
+	rsp, err := c.CreateOnboardChallenge(ctx, trustDomainName, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateOnboardChallengeResponse(rsp)
+}
+
+// GetRelationshipsWithResponse request returning *GetRelationshipsResponse
+func (c *ClientWithResponses) GetRelationshipsWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*GetRelationshipsResponse, error) {
+	rsp, err := c.GetRelationships(ctx, trustDomainName, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRelationshipsResponse(rsp)
+}
+
+// PatchRelationshipWithBodyWithResponse request with arbitrary body returning *PatchRelationshipResponse
+func (c *ClientWithResponses) PatchRelationshipWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error) {
+	rsp, err := c.PatchRelationshipWithBody(ctx, trustDomainName, relationshipID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchRelationshipResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchRelationshipWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, body PatchRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error) {
+	rsp, err := c.PatchRelationship(ctx, trustDomainName, relationshipID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchRelationshipResponse(rsp)
+}
+
+// GetRelationshipConsentsWithResponse request returning *GetRelationshipConsentsResponse
+func (c *ClientWithResponses) GetRelationshipConsentsWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, reqEditors ...RequestEditorFn) (*GetRelationshipConsentsResponse, error) {
+	rsp, err := c.GetRelationshipConsents(ctx, trustDomainName, relationshipID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRelationshipConsentsResponse(rsp)
+}
+
+// ParseGetJWKSResponse parses an HTTP response from a GetJWKSWithResponse call
+func ParseGetJWKSResponse(rsp *http.Response) (*GetJWKSResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetJWKSResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest JWKS
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseBundlePutResponse parses an HTTP response from a BundlePutWithResponse call
+func ParseBundlePutResponse(rsp *http.Response) (*BundlePutResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BundlePutResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseBundleSyncResponse parses an HTTP response from a BundleSyncWithResponse call
+func ParseBundleSyncResponse(rsp *http.Response) (*BundleSyncResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BundleSyncResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PostBundleSyncResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseIssueClientCertificateResponse parses an HTTP response from a IssueClientCertificateWithResponse call
+func ParseIssueClientCertificateResponse(rsp *http.Response) (*IssueClientCertificateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &IssueClientCertificateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ClientCertificateResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return ParseBundleSyncResponse(rsp)
+
+	return response, nil
 }
 
-func (c *ClientWithResponses) BundleSyncWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, body BundleSyncJSONRequestBody, reqEditors ...RequestEditorFn) (*BundleSyncResponse, error) {
-	rsp, err := c.BundleSync(ctx, trustDomainName, body, reqEditors...)
+// ParseGetNewJWTTokenResponse parses an HTTP response from a GetNewJWTTokenWithResponse call
+func ParseGetNewJWTTokenResponse(rsp *http.Response) (*GetNewJWTTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseBundleSyncResponse(rsp)
-}
 
-// GetNewJWTTokenWithResponse request returning *GetNewJWTTokenResponse
-func (c *ClientWithResponses) GetNewJWTTokenWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, reqEditors ...RequestEditorFn) (*GetNewJWTTokenResponse, error) {
-	rsp, err := c.GetNewJWTToken(ctx, trustDomainName, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetNewJWTTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetNewJWTTokenResponse(rsp)
-}
 
-// OnboardWithResponse request returning *OnboardResponse
-func (c *ClientWithResponses) OnboardWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *OnboardParams, reqEditors ...RequestEditorFn) (*OnboardResponse, error) {
-	rsp, err := c.Onboard(ctx, trustDomainName, params, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetJwtResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return ParseOnboardResponse(rsp)
+
+	return response, nil
 }
 
-// GetRelationshipsWithResponse request returning *GetRelationshipsResponse
-func (c *ClientWithResponses) GetRelationshipsWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, params *GetRelationshipsParams, reqEditors ...RequestEditorFn) (*GetRelationshipsResponse, error) {
-	rsp, err := c.GetRelationships(ctx, trustDomainName, params, reqEditors...)
+// ParseReleaseLeaseResponse parses an HTTP response from a ReleaseLeaseWithResponse call
+func ParseReleaseLeaseResponse(rsp *http.Response) (*ReleaseLeaseResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetRelationshipsResponse(rsp)
-}
 
-// PatchRelationshipWithBodyWithResponse request with arbitrary body returning *PatchRelationshipResponse
-func (c *ClientWithResponses) PatchRelationshipWithBodyWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error) {
-	rsp, err := c.PatchRelationshipWithBody(ctx, trustDomainName, relationshipID, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &ReleaseLeaseResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParsePatchRelationshipResponse(rsp)
-}
 
-func (c *ClientWithResponses) PatchRelationshipWithResponse(ctx context.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID, body PatchRelationshipJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRelationshipResponse, error) {
-	rsp, err := c.PatchRelationship(ctx, trustDomainName, relationshipID, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return ParsePatchRelationshipResponse(rsp)
+
+	return response, nil
 }
 
-// ParseBundlePutResponse parses an HTTP response from a BundlePutWithResponse call
-func ParseBundlePutResponse(rsp *http.Response) (*BundlePutResponse, error) {
+// ParseAcquireLeaseResponse parses an HTTP response from a AcquireLeaseWithResponse call
+func ParseAcquireLeaseResponse(rsp *http.Response) (*AcquireLeaseResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &BundlePutResponse{
+	response := &AcquireLeaseResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Lease
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Lease
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest externalRef0.ApiError
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -960,22 +2055,22 @@ func ParseBundlePutResponse(rsp *http.Response) (*BundlePutResponse, error) {
 	return response, nil
 }
 
-// ParseBundleSyncResponse parses an HTTP response from a BundleSyncWithResponse call
-func ParseBundleSyncResponse(rsp *http.Response) (*BundleSyncResponse, error) {
+// ParseOnboardResponse parses an HTTP response from a OnboardWithResponse call
+func ParseOnboardResponse(rsp *http.Response) (*OnboardResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &BundleSyncResponse{
+	response := &OnboardResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest PostBundleSyncResponse
+		var dest OnboardHarvesterResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -993,22 +2088,22 @@ func ParseBundleSyncResponse(rsp *http.Response) (*BundleSyncResponse, error) {
 	return response, nil
 }
 
-// ParseGetNewJWTTokenResponse parses an HTTP response from a GetNewJWTTokenWithResponse call
-func ParseGetNewJWTTokenResponse(rsp *http.Response) (*GetNewJWTTokenResponse, error) {
+// ParseOnboardWithProofResponse parses an HTTP response from a OnboardWithProofWithResponse call
+func ParseOnboardWithProofResponse(rsp *http.Response) (*OnboardWithProofResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetNewJWTTokenResponse{
+	response := &OnboardWithProofResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest GetJwtResponse
+		var dest OnboardHarvesterResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1026,22 +2121,22 @@ func ParseGetNewJWTTokenResponse(rsp *http.Response) (*GetNewJWTTokenResponse, e
 	return response, nil
 }
 
-// ParseOnboardResponse parses an HTTP response from a OnboardWithResponse call
-func ParseOnboardResponse(rsp *http.Response) (*OnboardResponse, error) {
+// ParseCreateOnboardChallengeResponse parses an HTTP response from a CreateOnboardChallengeWithResponse call
+func ParseCreateOnboardChallengeResponse(rsp *http.Response) (*CreateOnboardChallengeResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &OnboardResponse{
+	response := &CreateOnboardChallengeResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest OnboardHarvesterResponse
+		var dest OnboardChallengeResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1125,26 +2220,80 @@ func ParsePatchRelationshipResponse(rsp *http.Response) (*PatchRelationshipRespo
 	return response, nil
 }
 
+// ParseGetRelationshipConsentsResponse parses an HTTP response from a GetRelationshipConsentsWithResponse call
+func ParseGetRelationshipConsentsResponse(rsp *http.Response) (*GetRelationshipConsentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRelationshipConsentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetRelationshipConsentResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest externalRef0.ApiError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Get the JSON Web Key Set used to validate the JWT access tokens issued by the server
+	// (GET /.well-known/jwks.json)
+	GetJWKS(ctx echo.Context) error
 	// Upload a new trust bundle to the server
 	// (PUT /trust-domain/{trustDomainName}/bundles)
 	BundlePut(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
 	// Synchronizes federated bundles with Galadriel Server
 	// (POST /trust-domain/{trustDomainName}/bundles/sync)
 	BundleSync(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
+	// Get a short-lived client certificate to authenticate the Harvester with mutual TLS
+	// (POST /trust-domain/{trustDomainName}/client-certificate)
+	IssueClientCertificate(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
 	// Get a renewed JWT token with the same claims as the original one
 	// (GET /trust-domain/{trustDomainName}/jwt)
 	GetNewJWTToken(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
+	// Release the lease if it is held by the given Harvester instance
+	// (DELETE /trust-domain/{trustDomainName}/lease)
+	ReleaseLease(ctx echo.Context, trustDomainName externalRef0.TrustDomainName, params ReleaseLeaseParams) error
+	// Acquire or renew the lease granting a Harvester instance the exclusive right to upload the trust domain bundle
+	// (PUT /trust-domain/{trustDomainName}/lease)
+	AcquireLease(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
 	// Onboarding a new Trust Domain in the Galadriel Server
 	// (GET /trust-domain/{trustDomainName}/onboard)
 	Onboard(ctx echo.Context, trustDomainName externalRef0.TrustDomainName, params OnboardParams) error
+	// Onboarding a Harvester by proving the control of its Trust Domain
+	// (POST /trust-domain/{trustDomainName}/onboard)
+	OnboardWithProof(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
+	// Get a challenge to onboard a Harvester by proving the control of its Trust Domain
+	// (POST /trust-domain/{trustDomainName}/onboard/challenge)
+	CreateOnboardChallenge(ctx echo.Context, trustDomainName externalRef0.TrustDomainName) error
 	// List the relationships.
 	// (GET /trust-domain/{trustDomainName}/relationships)
 	GetRelationships(ctx echo.Context, trustDomainName externalRef0.TrustDomainName, params GetRelationshipsParams) error
 	// Accept/Denies relationship requests
 	// (PATCH /trust-domain/{trustDomainName}/relationships/{relationshipID})
 	PatchRelationship(ctx echo.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID) error
+	// Get the signed consents of the trust domains of a relationship
+	// (GET /trust-domain/{trustDomainName}/relationships/{relationshipID}/consents)
+	GetRelationshipConsents(ctx echo.Context, trustDomainName externalRef0.TrustDomainName, relationshipID externalRef0.UUID) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -1152,6 +2301,15 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// GetJWKS converts echo context to params.
+func (w *ServerInterfaceWrapper) GetJWKS(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetJWKS(ctx)
+	return err
+}
+
 // BundlePut converts echo context to params.
 func (w *ServerInterfaceWrapper) BundlePut(ctx echo.Context) error {
 	var err error
@@ -1188,6 +2346,24 @@ func (w *ServerInterfaceWrapper) BundleSync(ctx echo.Context) error {
 	return err
 }
 
+// IssueClientCertificate converts echo context to params.
+func (w *ServerInterfaceWrapper) IssueClientCertificate(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	ctx.Set(Harvester_authScopes, []string{})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.IssueClientCertificate(ctx, trustDomainName)
+	return err
+}
+
 // GetNewJWTToken converts echo context to params.
 func (w *ServerInterfaceWrapper) GetNewJWTToken(ctx echo.Context) error {
 	var err error
@@ -1206,6 +2382,51 @@ func (w *ServerInterfaceWrapper) GetNewJWTToken(ctx echo.Context) error {
 	return err
 }
 
+// ReleaseLease converts echo context to params.
+func (w *ServerInterfaceWrapper) ReleaseLease(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	ctx.Set(Harvester_authScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReleaseLeaseParams
+	// ------------- Required query parameter "instanceID" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "instanceID", ctx.QueryParams(), &params.InstanceID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter instanceID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ReleaseLease(ctx, trustDomainName, params)
+	return err
+}
+
+// AcquireLease converts echo context to params.
+func (w *ServerInterfaceWrapper) AcquireLease(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	ctx.Set(Harvester_authScopes, []string{})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AcquireLease(ctx, trustDomainName)
+	return err
+}
+
 // Onboard converts echo context to params.
 func (w *ServerInterfaceWrapper) Onboard(ctx echo.Context) error {
 	var err error
@@ -1226,11 +2447,64 @@ func (w *ServerInterfaceWrapper) Onboard(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter joinToken: %s", err))
 	}
 
+	// ------------- Optional query parameter "harvesterPublicKey" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "harvesterPublicKey", ctx.QueryParams(), &params.HarvesterPublicKey)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter harvesterPublicKey: %s", err))
+	}
+
+	// ------------- Optional query parameter "signedAt" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "signedAt", ctx.QueryParams(), &params.SignedAt)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter signedAt: %s", err))
+	}
+
+	// ------------- Optional query parameter "signature" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "signature", ctx.QueryParams(), &params.Signature)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter signature: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.Onboard(ctx, trustDomainName, params)
 	return err
 }
 
+// OnboardWithProof converts echo context to params.
+func (w *ServerInterfaceWrapper) OnboardWithProof(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.OnboardWithProof(ctx, trustDomainName)
+	return err
+}
+
+// CreateOnboardChallenge converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateOnboardChallenge(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateOnboardChallenge(ctx, trustDomainName)
+	return err
+}
+
 // GetRelationships converts echo context to params.
 func (w *ServerInterfaceWrapper) GetRelationships(ctx echo.Context) error {
 	var err error
@@ -1298,6 +2572,32 @@ func (w *ServerInterfaceWrapper) PatchRelationship(ctx echo.Context) error {
 	return err
 }
 
+// GetRelationshipConsents converts echo context to params.
+func (w *ServerInterfaceWrapper) GetRelationshipConsents(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "trustDomainName" -------------
+	var trustDomainName externalRef0.TrustDomainName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "trustDomainName", runtime.ParamLocationPath, ctx.Param("trustDomainName"), &trustDomainName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter trustDomainName: %s", err))
+	}
+
+	// ------------- Path parameter "relationshipID" -------------
+	var relationshipID externalRef0.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "relationshipID", runtime.ParamLocationPath, ctx.Param("relationshipID"), &relationshipID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter relationshipID: %s", err))
+	}
+
+	ctx.Set(Harvester_authScopes, []string{})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetRelationshipConsents(ctx, trustDomainName, relationshipID)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -1326,115 +2626,160 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.GET(baseURL+"/.well-known/jwks.json", wrapper.GetJWKS)
 	router.PUT(baseURL+"/trust-domain/:trustDomainName/bundles", wrapper.BundlePut)
 	router.POST(baseURL+"/trust-domain/:trustDomainName/bundles/sync", wrapper.BundleSync)
+	router.POST(baseURL+"/trust-domain/:trustDomainName/client-certificate", wrapper.IssueClientCertificate)
 	router.GET(baseURL+"/trust-domain/:trustDomainName/jwt", wrapper.GetNewJWTToken)
+	router.DELETE(baseURL+"/trust-domain/:trustDomainName/lease", wrapper.ReleaseLease)
+	router.PUT(baseURL+"/trust-domain/:trustDomainName/lease", wrapper.AcquireLease)
 	router.GET(baseURL+"/trust-domain/:trustDomainName/onboard", wrapper.Onboard)
+	router.POST(baseURL+"/trust-domain/:trustDomainName/onboard", wrapper.OnboardWithProof)
+	router.POST(baseURL+"/trust-domain/:trustDomainName/onboard/challenge", wrapper.CreateOnboardChallenge)
 	router.GET(baseURL+"/trust-domain/:trustDomainName/relationships", wrapper.GetRelationships)
 	router.PATCH(baseURL+"/trust-domain/:trustDomainName/relationships/:relationshipID", wrapper.PatchRelationship)
+	router.GET(baseURL+"/trust-domain/:trustDomainName/relationships/:relationshipID/consents", wrapper.GetRelationshipConsents)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+R6aXPi2LLgX1Ew78NMYButIDmi44V2JBACJBbp0lOh5WgBbWhBQEf99wkJ7AKbqq6u",
-	"e3veffH8xeIseTLz5J7nj46TxlmagKQsOq9/dHJQZGlSgPYHBzyrisrm00mTEiTtp5VlUehYZZgmvW2R",
-	"Js1Y4QQgtpqv/8iB13nt/K/eN7i9y2zRo7OQz/M073z9+vWp44LCycOsgdN57bQTED2VoG8oNKuuexvQ",
-	"79sbJFw3bHZa0TRPM5CXYYOyZ0UFeOpkN0MN6i5o/ntpHltl57UTJmUf7zx1YusYxlXceSUo6qkTh8nl",
-	"FwLDT53ylIHLUuCDvPP1qRODorD8FhI4WnEWNfM0ZAOrKkOviiDQUvC27OnbeUWZh4l/OXAMEr8MOq/o",
-	"zSHX+YbaHOyrMAdu5/UfF7y/nfv7+/rU3gKnbHBiqsSNABf6oGiv5p6ltlWAPg6BpIHkQtqQfkaJPuS2",
-	"y6HUg8oAQHYLovN0Q5QH40TfHVjAhUkSDCgE4H0EdjAHtdw+ZnkA7wMUDAYDiiQ913YodAB7CAEcaoAg",
-	"No52PlH2hmlxQbX4/g3+WIDu6P16g/MfnTKvivKLm8ZWmHxBOq8dksQwgkQH8AAmQN8b4AC2bIAiFu44",
-	"RB+QKAX3SQq3EQQBpEPZmOX0bRQjKAx2QB93GypuYaKd145LWgB1bBIAQADLJh0E8TAbwzEKWLiFWjhM",
-	"IQDu9/E+iQ5IFAEAofr2gOiTFo7gzieYWOe1gxC41/cGHoVbfRgdoAPCwYHXtzFgAxgf9AFCULZtuZgL",
-	"ex7cx1AXR3AbOIByHUD07YYP3xGMYpG5Vgn+SXa/QZFKEP8J0//oFKGfWGWVN+hMxkO5ysyEHB2FVI61",
-	"UObYaXdRpZqsRoEyQoaGtHX6xnRAHABCTB1FHpz3iDyer4XzulTgswdrztg2kcQwxMMs9lddUaaPxLSI",
-	"tT0WI7ttfoQ9QeZgntsvzcA6p4aUFiQ+tci92HNWACYKJB+mhkFgtYpiiCnuyt2Q6I+Skzvk0LoG3mnG",
-	"ZvRvnacW9TDxvzgNc7zGwoEvTmCFjSo9N38ML0oTiOXnuiRILK3z7SikSBJXnVmW3iusMOzq+DJYyHHP",
-	"4BrF2Es0QSG1sJ31FBoWWW0vapKNcTOeYesFrUiiCSmzomZnBreczUS+lpeLM68qdC3SyIJn6VpYikvc",
-	"WCtHnqNVxp8sGdpRGDg4uOsJbKP4ERqd6ewykSrSLohc9Bi5w5m/EIWthQonk2UEO5lHTsKcrPUkkvjJ",
-	"wV4zgZ3sjsMt7UCXzYUiLILZXGOGxuoYmEM5M1e1vxjKBytebl2OtxVm12JF17XmoELpiMdovJqcIHM9",
-	"z8w42hrreaQw+JrTpbPCKSdF53Hl7J/VZbrmdKUZO6rc+1jtm7sje6blKwaGTkdLXZnhNUe3/JA4erkw",
-	"10HgnPmZQuPt6UxdDzWRQhxsfrC3fK6wOxFqmeXXoSYuMVtcwi7LzIzVJDfW8k7il5UrLk/OUM4cdOHP",
-	"UKp0RKECOg8U5sJoiK3rpSYwgsS7gS0KOyeOIptlZk5M7c3VBFbmRS1ebonjGPlsrJDaFhelgcmRK0Yx",
-	"ZK0mgSsuat/nw493Tc8WNI1LDFfTzfyITiWGnrFkj9SpBW7ZwegYQAfsGBxZ7SCrVj0Ieko62+4VlArD",
-	"8crMuxyaDtDJnkTMuTKZZnN+ng4ngzM+suxUzgPi0IW6p1lOkRU7MXY7miPJ1X4arbmACLxMYAxHsfh6",
-	"jNox040dobdCaFNNjTQajOaEe1x3BRpy0zSPevmyViwWnS6G+CLe4spU642L84o9DFDRgbdBPlLohYhm",
-	"W+pkrnujUT6u5niB1vkZMo74DEUmwXSg9uVc5gOeMfgFcuxW+Y6tksqhz7CM6HNmfCjPC6I4ZB56hK3R",
-	"qV/3wOnch/hY33Vrcno44lGdMseTlStDhh4zQ8cnaHFZZQtnsK7YCSkRkToDOBez8wGxOwrpVJjhABoc",
-	"9rgpC0PaVxia5mtuZsij1JSCgzOhZ/yYmdGc7/MMzfDZiIvn5ImU5zYaa1qWoPyMhRR3Z4treKXMcpQb",
-	"9YwkX0TwoCvFi7hWFTtj95UqG7BBR3SfPO6I3qw8exLJeSxacDN+DYm1vIO383SJJi68zEcYdabPhz6F",
-	"SvNDfurBQ/eIwHDskcKujsX03HOcWD9qXfFEoHNu3oW6mt3zaDoN4+MKHRZru0pCGpXsemdPlDyHu2ow",
-	"tWWTUTGE37srxKwJNKCO69LRBnQ1FiCXsYt4u1oLMr/CsDGn8DPM25qhNnf2ge8tj4rklbNzyEdIuSTF",
-	"wUxe5+FO6VtpNTYEbQI5mIyXMtwjKId1A3ENp2BeH8bYWFiTMt6vEEHAHY8pEjs1IoX3MGOkMuo6Do/W",
-	"0AL+b1BrGfkJ98lavnvAa9zx2mnjnj9xYK3r+WtRn/seFP2VgOLGff14o/a+8OsPPcePobDfNrDt+q8f",
-	"2fPj/Xqz9kLDp/DxDs7TGz9uSfwR4o+izU/Yfoo41y8ETEE30KAWGhQm0JRXoGtEfBtr/sCxbpLGs4qN",
-	"Z/VVn64lhvYlST8IB4IX2N3BeaTbtbjlDYUxWpu+SeijMrVWBGyu5dJczbPGP41Xk+CbjzJOCifVypY+",
-	"TqLGR0mwwhlHVb+MbZJJlNZDjrcUxr+4vqMyfwRxrEsPLf8m+Y7t38PshNZrD2FLw17gS1LMzqA72dnb",
-	"08rB9UQ5SOO16sXoJkFHAjeUdssgPfTGozHjRbA99CXf7Qb4jozPM2bSHZA4cSryeOcTstzztgPaSZBw",
-	"ttC54X6TRAKF9U9ldeAPnul3pwdlOnLPY9WY+ULVrxj2LHU1pb/obqe9lT+SegY3WO385Vmah/3wYGyS",
-	"RY6Pp9Whq9Sz897Rz+eodNypNjDxcDxengfU0JkmOFwLh+Gsp2rzSEvlrc0cjut6hxjb8SZZJxI14UEt",
-	"V57TtbBp4p3sinKsIY/3CHNqSRS3NomwSJRV0t2q1kRkgUzyYxLnJW4oUZuknpDOqguvYsBvk0DzQyye",
-	"UxizPyTxOg9y4RAGmJFOg6GsHi/2X9waZya4Bg7uJuFqnunVM76RJmZLT5uwYzhXGNojeUanOXo27CkM",
-	"3K7m/NmKYTRynHKgHPq1JSziTRKMJgvJWB2TxDv1t7TXQtAUXuTolc/oUl2PUDDyNE62VQSbgUOmFyac",
-	"rvQzPWH83X6TBLtQpGqYoWeFQNMqS894WtdG+UrzzV0Psyu1nGJRd0gcPISIxFFvR5xrf7odLnTl0Dew",
-	"TaIHlHDUS9REeVkGsBr0E0QzKnWLVIgWTsBBF0hXUqXCH2d2gJjSeJxyo+NkNFKdpF5tN0m4oxRByUd9",
-	"nKzViW2Zam+hp+k2WtBCn2OIIcINFri8ctWuqxxsUyKW0mCsDbHthGQnBbpJKtylYWu4B0K+ttGgirip",
-	"a24dVzrqytzHwx7v5oP52TH1vLtXei5trLZclXD9LlPMz260SVhriaGz6qSsawHuGgxpKOSU1gR6KIbh",
-	"fDdDpLk/nDAyidqorwBGKs+ryBHJnClU47jUNwnqYnBcRvJyMhkI6J7QkAJZqeJyK+WlrcaJbp8IXp7v",
-	"K/q33zbJd33SdepHVojXWZb24ncrxMcpOjc8/IQtHlohfvstXG2t0NCJqYPLIltDp4FQwyflTKPKlr5Y",
-	"H920mrEJR6OKvnsfU5j02MjVJrmEx4rOoVEbydqr5c5aCbCpNRBZltakj3gw10hnSnMs21ihlG3jHgWx",
-	"6z7LKbIpzbTjYhkW5FASuhV7cEpKJRzZsocWhnBjadfzpsYiVRVfRetNYouZzuJeLMsWcS4XvVkvOJZR",
-	"MJXOI2lcwMier5aRZKV5dzLip8YBwdauPMWDUz3dUwMp2yTKcVdh3nGQqPSUTs/94syeeof17hAcqfMg",
-	"n5L8CvamGmds1VpZ8JXo8km23+uc64+nbKhtEmVRhIjgT3Fp7AOFc71sfOrNpla22I1Ubjv1d0YVJKdz",
-	"rqtLAqNoC2ewYs2CXOfOyzHlbRJ9IR8jwMW07OEoZR+2urYyA9Y5lKfKqDxajhgpHJgiQ6WiP04zWJJY",
-	"uCJHSLlEV0H3sEkoEVWRERfOKcXTVMmZUpJWrwaxYQheJCosXfM0bU22isjXnG9wyzk8bewKQ8842ufF",
-	"TaLQZGth+Is1EhS6tUD1cNauVhnG4AVly9G5iWeUpETFYO0y/W6kHhMtMKebRGEuEKR6ZiiMRQusLmQm",
-	"Na/qDB8FSYAYyrpen0fbEDk8yjs3SeOR6Bk7TGkwXpCnMyaVoyM8nEo8P7XFfg8/UfhAmoa7o4/t5NVY",
-	"F0bBaIpEGJpRirSON8lBPuDifFZZK/WkR6YHkuNySGK7PcUBak3upHNB5nNhTsSH6cply1RbBdJpORIn",
-	"ZkEWvrRJRFicjrd6TmqrfdeubL4qpX7p2RyzG2232Tg6LAeatR/YCIbMaCIAcqWOVt1CDHEMo4XZJjGH",
-	"MZVHY3XlnR2UJC1BtcMha6nR1l+ZheFr64icdr3IVmSh52LHJe2hJjrnVV/1iIjyN0nm+uFKyIEzKUKF",
-	"ybk9st1FgObFHlXiy6HI0aVCUpNFWqs22l+YaTnSGVM6p0ptDtJc2ySIq8LxeNib5OODgMHJaesreDgL",
-	"Bt2J+kOrc1sjzED8qGzGpkkBklIrrbJqw1qQVHET3VlZlqcH4DaRHUjC9iMDidvs+/0BIBGUcl3Or6XV",
-	"vxhLl+kO/GksK6/0zzFou/FRLCmCcg6itohcBGF2i1hYgvhPS1O3m9vqrHWULvuIm8qqlefWqZlukLsr",
-	"3IKTHNiiE6qhLC3OEjIJpUJK5oTDSn1pl62XrEy9gJN8dldSqIbSUdkq8EQ3MJXb1VJYh3YslKbWLj5Y",
-	"Iu7PRSpqxhurLG3T40TnUWWrEAonnbzZi+ZFo2M9lzUFjEYCOtNxr84UIHtYf6ru+id5+cVyZ0VRE86t",
-	"WGzr8r5ujMNU/6mTWWUJ8ibi/r//sJ7P9LMJP1ObzfOX37v/udm8PBr73x8H/89//scjiVMTO7Vyd2jl",
-	"B1CUIP/bReaa8XBtEVHi/mzPYiFxHzZNrPjnMqWb5Y8l9SMun495JMtTyweTKrZB/jkj0gMAJe0clHpQ",
-	"K9pQmULFLswgG3hpDqCitPIyTPxm3EmjCDhlW5nPQVFFJVSA8qVz05542JxoUNDCM7ggcO3aoPDTd7Ep",
-	"7tDJQVnlyctdTwS+bYk8PrN0gnsl3lfXnPsvtWdaE/eleLdxP8yY7wzi55bJHayHl5W+ZczaKXF+DeUG",
-	"PPjJ4vlbx+MjqhcYP4PhL+nfL6H41Km+NQx+vjHwHdq+QXtIZXUl8teu4H9eeee+dfTP2rv3ctAd0A/o",
-	"Pbq3O8f7FzU9B1YJ3C9Wee+LURhFnmHkGYN1mHzF4FcYNm+dYCNEz2UYgw8tVOSB+wrdv+RA3npX1per",
-	"6fiL9ucTmF8+P/kVJ/YBiv2vocL+VSrsX6XiYij+Tsn4IP5hEy/fyOMdCo8u9RGLvitD372WRwql3dqk",
-	"Hzbw363XD3r3ltg315hlet1+6fdOc85059qkVDAqOpuryclcz2WTQ2Rjhejvv1lz667lk7ki4KUYleZy",
-	"AhsrpJ7qPDI58ydFX9SqvojNdVBbazlq1+jwUeV8dKI7iMLtEDmRAzueH2wdPilbGlW2i98ehZe3Ju0T",
-	"vdpUEgQeatdciYPCBJI1dfKodvzHpomPv1hVGaR52FiZTef1H39sOuCYhTkovljlpvO66SB9EieQPoZj",
-	"m87TprMDpy+h287Qrm46sDM4F1Tf6fuH2VFm+jOX73MnrZp4h3Z9VtlR6HzZgVO7RxF2NV8bw1FqSuct",
-	"zNIzQ7p+c/TM4WY+zR+RqTmvPR7jzELdowoDq8R05dnFObcyceLFBC/0kLReE4nETeKtHtq9yckbsIA9",
-	"aGOHdzDYyCz7QNv+eEg6BRpwZ4T+7bdN5+vT9+gjkc/0ef7S4hxLpw3rvBPRlUdhq1I8xnN37dHwhPlV",
-	"+nJO24ZOnuy1RcKjJ4DIaeUxnDi2S0nZysJSHIGhWo50otpHTG+kkxMUI9ZFsfb18WyuBOeM5hxFwRc9",
-	"I3IO6Wk3JGK/pe/3p00nB14OiuBLECYXCuEW0aKJFBIHfLmEsO3MoJ25Vbd2uHSRTefrdwXwPm/4JlEt",
-	"nJcLnBcnjf/8FQ9OPjijNY53gDHPIgmvjz8TA2TwjBN99NnGPOcZdag+5vX7lmf1bw+rqta+3ByFfUj7",
-	"4GfKevZ+/4P8+vz+jf/EN4J+fZD3NVEOcKo8LE9aY6YvDjt4SwJbHfuuXb/f2Puwq33rFSZe+vaMzHJa",
-	"K39xFR0xLIPKbgxwHnVeO0FZZsVrr+e3w80d9IagjkBZTi1nZ+Vuz7ciy81DEHU+vSET36YgDeQHkEPv",
-	"WWz7sKzIgHMJx8K0TXai0AHX0PqKDZ1ZTgAg9AW+w+i116vr+sVqZ1/S3O9dtxa9scTyE41/Rl/gl6CM",
-	"W6zKsGxv/U/weYbUDCTNF9aedwB5cSEEeYFfEKQBlWYgsbKwEaEX+AXrtEIQtLfTa6X1+SKtvT8+ZKpf",
-	"excD2i7NqpblTTDWEi+5nddri3ValS3Q3IpBCfKisaAfs8bWHl9AQy2jmgvtvLaodJ7eePcxVb71uGVe",
-	"gaeffCX4OXT9/QIKFCWTuqd/2XPETynIg2eJlwVNjmwD6BojfKKsDS5u3k2iMPzAuVWOA4rCqyLo/R4u",
-	"Ivz+yPIRsu+Ae2+vMW+Vtb2tj2r6j98bjhVVHFv5qfPaWWRRarmQBSWghspb51qmbRxRtNLZWAXLbwTg",
-	"euPXLK/ze3PiT0pbrzglTityafFdmWvy2v+xQvew/vBA8q5ZeRPrWVEEecBtGAnc690VUBlYJXTHJqfK",
-	"c5CU0QnaJWldvPysoP4thF3LFg8oE9IchH4C3UkZ9FYs+P+iEg2KQZ4m4RkUD1hbh2UAfbTe/5x+bOuW",
-	"Hh880AoRlBNQyytdv1Yh/xtoxt8kRR/6FA+k57/OjoqghCwoBwmogQvJKx1qq8YXaWntqBUDyImsMC4g",
-	"q2iH0jz0w8SKoDQBNwLUbL5c9k8JT3opyt8I0D1PpBKqClBAFrRNw+SKVplC19ToDFpc3ulrkqpm4FN8",
-	"YiUu5IMSCsuipY9uWQ29ieW91F47Bf/24vr0ESP5jkeNXy+A2ySY0JXPlzi/RXJfgfz0DcuGvW/c+D5+",
-	"H4Prv1NhvtuveaA687bMX0BWAlmXm33IhUZqQFK2+CT+N7EpoDSBbBBYkfdWgbi93Jdf18J3FVPfL+Aa",
-	"rtxJz3fE9kav3mTyp7QqvyllFj8yzvO7hX8i77dQoUsjAvLyNIase2IykDcJSRke/is04pFwO3dVwZ8F",
-	"/6kj89AAcG8F5kcHZ2/dq589873d9cvHXft1f+XA65a/3f897Iv/WznCcVi8tShvFOPlRg/vNeava2Pv",
-	"j9ufEve1TSms0gk+K+inPuR/P48kcW8GNb+n4wFa94z5ZawuBfy/Lcv5Xm/4oVf6bDB/Kd/+l2B+/7Dk",
-	"30nrmmAsK3scSEJQ3AkKdL3C4kca2B7XuMuLStyX26LUsaIgLcqXorZ8H+QvYdqzsrB3wDoNFleoH+VW",
-	"fePAFR/gtiWF2xIDODqBlfhNcJq4UPGed10Y9y7V9xnVZw25OakJUS5HXGzJfdR2hfcWCPwQ0g3Od+y0",
-	"QVmDJi66OaX4BvuetV9///r/AgAA///JbY7DRTsAAA==",
+	"H4sIAAAAAAAC/+x8ebOqSPLoVyF888dMnEU2txvR8QsQVFBccW3vu1FAsSjbYRG143z3FwXqceGsfW9P",
+	"z5tf/9PnApWVmZWZlat/FFTP8T0XulFY+PZHIYCh77khTP/BQR3EdoT+VD03gm76J/B921JBZHlucRV6",
+	"LnoWqiZ0APrrHwHUC98K/6f4AreYvQ2LjG/xQeAFhefn5/uCBkM1sHwEp/CtkL7AmL6AvaCAvjqsRaBP",
+	"yxESmmahlcDuB54Pg8hCKOvADuF9wT97hFDXIPq/7gUOiArfCpYblenCfcEBW8uJncK3Uq12X3AsN/sX",
+	"geP3hWjnw+xTaMCg8HxfcGAYAiOFBLfA8W30nsEUCOLI0mMbgykFx8/uX/YLo8ByjWzDDnSNyCx8I882",
+	"ObxH1AbwKbYCqBW+/Z7h/bLv99P3nrKCaoRwYmNXsyFnGTBMj+aSpQoIYZnGoIsgadioxTyQpTKmpZ9j",
+	"no5FJsSUFETh/owoHadLZa0CoIZXq7BSIyBdJnCVUkmglSmgQ7oMSVipVGrVqq4pao2s4DpRgmqtQhAK",
+	"TRZuKDtiGmaohq+f4NsCdEHv8xnOfxSiIA6jH5rnAMv9QRS+FapViipVyQpewUuwrFdoiAMFkgSgVbVU",
+	"hlWyhperNVohCAJW1ZpCAbWskFSpRuEqLNNa4f4SJln4VtCqAJKqUoUQliBQqipB6JRC0VQNAhqQgMZr",
+	"BMTLZbpcJStVkoCQqJWVSqlcBTRBqzcwqcK3AlGi9bJe0Ws0KONkhayUVBrqZYWCCsTpShkSpZqiAI3S",
+	"cF3HyxSp0QStQBXWNBWWykrh+cTua8EIx74GIvgn2X2EIkTQeYfpfxRCy3BBFAcInW6nJcb+wq22tw1P",
+	"dEaWyNX7d+PYG4k925TaRGsurNTyvF8pbSBR6quSWNk/EWJnOGvsZ5GE73V8pHaUBeHO583NwDGmd02R",
+	"2Zb6oTN6ohxivQq2uN4QOZznniYLE+y9ueCFVboPqk/NojqFeCkkgpY3n5eopEdSxKK5jtatUrnt7rQW",
+	"RyYJ1HeDus/8VrhPUbdc44eKmKMjCwd/qCawkCo9oP9Yvil0sTo/lIWGUGdkPn2KSYLAxft6nXmS6o3W",
+	"nUxPzLHoFOccUowngSnViKSxGhQlBm/WR0/NkaBQ3IBn68mYkYTmApMGYVIfzLnJYNDkE3Ey3vM9iUma",
+	"DDHm60zSmDQn9HwmbXmO6bFGd8IyqsTi5kabdXGFpLdYe8/42QtPEtamrZFbW2sNjHGzsQJkY7eosw3F",
+	"Hdqqy+7ArGsLfHejzFhTcdfb1opRsWxxKDXG5mA4Ylvz6dZctER/MU2McUvcAGey0jhekdh1ihWTJCOV",
+	"bERqc2t3pt0dtpgN/YVjr+azoS2x9IyThb3ESTtJ5mlpb+x7E2/GyRJ6tu1xp2eJsVhv63tGPGAwlxl7",
+	"IksDOuGYlB8Cx0zGi5lpqnt+IDF0ujubJK1Rs0ao1HCjrPhAqq+bWMosI7FGzQmlNCe4VmcH82k3mM/E",
+	"tcBPYq052akt0VfJsTEga5HabMRQ5qHEZozG6kkyGTXYhsBrptJsrFXHtpU6O1Cd2tNi2sWlYZg0s1Pi",
+	"OFbcz6dEojTH0ZwSba1pOxiYdk2tOU4Mg7euz5oZjBmGFlguYdD7NuMJLDOoV4tVuTamgWK2tya2obbm",
+	"tj7aiD2QVMyi5A1WTxJZs6zOdBHccaRXIbtPVWIxlLp9f8gPvVa3sqfbQPHEwCxt7rC73SCoVeN6d75e",
+	"M1y1On3q2zPOLJm632DnqgT4pEMqDnvnqI3ilGAWPW/u2ZX2sKRtZ3cNBtM8L7CLwSSRQJ3sj1v02FnR",
+	"Un9U7IT7aX1TIZsqvjKDtsSMm6S/qu0Ws2K7HXTiIR2SSbDH5lt6QBJds1/plcVA5E2enfNjYnsXB+t6",
+	"7MYqs8dFQh6ynU20H5fCja+TWxy0d+WkCHf7MsY78vouqfY3W9pOPHa7A4HUYpkO21KNEtOcxP5Yrczi",
+	"ercqlOzeANKcUx9WSuttw+s3BjTEKpsneiE2WowhsQzDJ9xgLra9hWBu1C4z4DvsgOEMg2cZlvfbnDOs",
+	"7qriUCGd0ch3SX5QxyRtrTRn+FQaBCTXLs7dYGzjlTvBGTtJT1L8+lPcE+f4nLGZcnW7LhUH0V4Xqpxe",
+	"J0NuwM+wZiKu8dXQm5Cuhk+CNlXbM/tNuUYKw02wK+ItbUvguKNXG+vEaXr7oqo68nZ019yVyCE3vMPu",
+	"RkpRZxjPcrZTshXOlNi1GFJQkrXSlYIAv+uZfUVcsD2K4J+0KbFISqRZ284idVRh4k4D01gldFbTWUPk",
+	"pxTV4SR+QOmrhTUaqk+moU+2kqBHg73F20Q0qTYrA3EWWGupDLy4M2+MuphKiXQk4sVSTa1rZnOGe3CY",
+	"bDpUpzGrinQ5JhoNWtXZ0FW8uS3xOjVv99jezLG2oAWg8RuWWka+y91Yy9MNePA7vhUKz+9fYOnV8zmv",
+	"Tzs5RZ9xKM6ur7cXjk4fPr95c7wNpf6yoJ5+/3zNnrfXy+jbjIYb9/ECzv2RH+ckvoV4nrd5g+2Nxzl7",
+	"LOE17AwalkLDLBfr8xJ28IjPfc03Ltali27WJrpZjZ7BJALLGIIgbxqbEt+orzdqnm4nzRU/l9h5atOX",
+	"LrOV+mBawhczMVpMhz66nzrTrvlyR813Eick0orZdm10Rwm4xM23PTl7tnS7tpe0OB5IrJFdfVtpmAex",
+	"Iwu5ln/pvmL7n/B6l5ETnahHc2VMT6pNfw/vumtltZuqtOxKG6Ez6+kOuXTJdoNrCeuJ6W2KnXaH1W1c",
+	"aRmCod2Z9Lrq7Ads965SpUu7MHDWRkkUi/qqwqguYQ3GMtd6Wrp2o0aVd1G84Tf6wrjrb6R+W9t3evOB",
+	"0YjLMVvfC3cjqTy+W/WLU6MtFOdcZbo2JnthaJWtzXzpjgO60483d1Iy2D+p8n5vR6rWH1UWtNXpTPaV",
+	"WkvtuzSeNDatQbE3GtojT1wp7GY7S9bEfNVZujNXqHV5mIixrt4Bqu/qOyWuqaDF08XSog+EGjdblKzQ",
+	"labu3aoHus06FKt8p0rzAtcSaks36VbV6R0+dSC/cs2RYVHOsEaxTxvXmQVm0NhYJjX3+mZL7G0z+99c",
+	"zfeseXActKXLJTxbTAY8kiZ2xfSR29EaSiyjV3lWZjhm0CpKLJ5+zRmDKcuOqh2Pg1HLSEBj7Cxds90d",
+	"C/Pp1nX1XXnF6CmEkcQ3OWZqsLKQJG0StvURJyo9ghrAjS+HC9ybynumyxrrp6Vrrq1mLcFZZhA2GKZX",
+	"ZwY8I4/awXRkLNZFSol7UZ+y71qljU6U7Ga7uC7tE6O/ao1laVOeU0tXNmuNrRyRC5IXRYj3zLJLjOZx",
+	"b0XExMjqwo3cqGpCTwiNjq+YxELodDyuve222z3VTaarpWuta1JDCtplupr0ugpY9Ipj2fNW9phplDm2",
+	"1CK4ypgWp1rvTpM2ykIoTYRKZ9SiVt1qvRuSSzemNQYHrSfYCGYKacY219cWK1UTtrI0NGiryGtBZbhX",
+	"F3Jw9yQVNWY+XXGxy5Xv2HC41+ylWwcTihzEO2mWNPC7OVudS9U+M2owraZlDdcDQhgarS4rVkmFNCTI",
+	"CtF+aqvNasCGvfl2Ii9dUqNwJ7LFSbdbaZBPpREREtNec7ISgkjpOa6s7Eq8OHyKmd9+W7qv3klL910r",
+	"xMv1OqM7JyvEOx45nOv0jhrnWiF+9eKuplaopTq1jVYnVnOZgY0E30l7hpRWTGZ95AVAz7ocQ0ry+vRM",
+	"Yr0tkqulm7nHksyRdurJKtPJGkwb+GKEINbrzEi4xoM9eDp9hqvXkRXy6qnfIxFKUq5zkrgQBqPteGKF",
+	"1ZbQuIvrGzWq9UqqCJQWoAiuI6yLen8+9nqS0SOTpas0fblO644ogtI+GhcHRXMb2WZf2LeFTogTT3w8",
+	"sQXgBXfdNt+fbwhqpol92twl/adaRfCXrrRdx5S+rbg9ps94+3K4r++Km9l6Y25r+0rQr/JTXO+PuPmq",
+	"l0hjPm5qvOs/PcmcZnT6dWu0dKVxaBENo08LHQNKnKb7nV1x0Af+eN3ucau+sZ7HprvbB3JvUqJqDKBZ",
+	"KpzVYSBz+0mnpi9deSxubcg5jKjTZE3ZrOTRdGHW1U20i+exzog2K1iVRZOteU2j4/m4INTxuNomogk5",
+	"Ne82S7fWJHtEm7OGNUkf9QS1XxNGybTizOcN3W5KdSbhGQZ0V1KTTzhjzk2GeB/ZFZYZcIzBN5euxFRT",
+	"C8Nn1qghMakFSlqD9Osey875hrTimGBB+zVBssPKTGPLd3Zv647MRX/pSmwGQUgGc4kFTKMuN/xFbRgn",
+	"Pt02XZOYS7Nktm+vLGKTF3cuXXQjMYN6y2NgZ1zd7Skham/xVl/g+b7SLBfpXY2uCH1rvTWotTjtyI22",
+	"2e4TNkX6NUmYOUt3I27o5nAQg2lvJ9sLHbrbSatKrZ9qHKzNqmthH1aDYWNYcjb9qVaPvNHUFHaTdrO7",
+	"CKuhISzdJt7sd1ZyUB1Nn+6UWOHjSChHusKx6/Zq5XfszaQyAk8VhaCIAVMyoRj32tO7sGnRFMU0Bkt3",
+	"0XJqgd3pTfW9SlaroNFTrFYd9OyVMV2Ec2M0s6v9O91WJLFR1KjthNHJBTnke0ZPL9k1Y+n6mmFNGwFU",
+	"u6ElsQH3RKzWNmT4ZrEW0ZNWk2MiqVrrjr2kp5Dl8cKL2jK7EPaelCwqXjBauoTWw51Oq9gNOpsGhbu7",
+	"lSHR1sCs3HV7b1qd8xyhD528tFndtqAbnTleQ/gUH/zaz6RAw+Dd9CDHDy88t4NviAXZjvfpA6hhiRWZ",
+	"aebQD6wN+nANd8dkYgsEGxhGMCi8m9wMg3wf85bgLBv8WYp/hisOt74VwPAHyEmuypYDU5rVFOML1h3W",
+	"YSA6P2IUyTxElgPfZ84N8he45PIN8ciNRhGIoAPdHISvjlsc9bpYePz8eIBqBgb9E2BpBIFl2T0s8jCA",
+	"BdBO8/6hafn3GAgxK8ISEB5FQ9lhVhS+SMHSvfD14U7cLaZbU2vaG8XtemDG6mA6sHqWsF84PN2dzslu",
+	"HSe6e57qyAOiuxKi+arrzHf4br5fkxI5J7tOw5nLc6tTF3HVney1Rm2tkIQJprTVs0R8wfGxQomuYCXW",
+	"nKzFKjmJtUZtrzUbuDaT0Dem2mJ3CrWwF3UhFNyuvyBL9qJRM7W6UBZWyPGXIombRxLXGEuyUJY4pixx",
+	"LBBc/LdcJX3hfZwKH3RRHeH3AvD9wNtAlPbVoGulf/jQ1dC67zmAmjASk+iLEh95a/iulItT+TZQTBfm",
+	"CVUTRsOzEz/QeY6fFUHn3TRyDgwE3QFbIVtOnvYGQQB2OVv/qT0vNyvh+O124rT9SXYD27gsBQ1HZKmc",
+	"Jx5qsLnVxnocbGCqZi7G15ENzVsKX1PjOLBPmgy3GfEHaMMR8xq4taXdAhS4o/Kv4e4ec0Ckmsjup08s",
+	"DTMh0GBw/EacyqnWZxqfu0m0u92kDXcY+hL7J0LPCzC+/q8L2zAcMXnA3I8wwPG02I7D9+mPw6vyXWgZ",
+	"ed9tP7LrFlM9L9AsF1n89w5y9xGIu49DvFJhxPPsePPUWJy2R5+U7jXchR/WNaQ8z9c6dY0hApiPnHx5",
+	"JnAnmkpTRZZaGO8FomshKz0sqcg6r/3ZpC7WHuFO3GtTAV0dW2kl4V15TvW4dYLMvuI0osUo/XgDmrQx",
+	"bNZs9BzFS8LK23ZlnpRWUknihJ0+eBzpdnubDMWRBNvtBjmQaT3xJSjqVLnfW5d34uQH0AZhmJTU89t8",
+	"lUSXFV0ar5XvCz6IIhig8/2/v4OHPfOwwB9qy+XDj+93/7NcPuY9++f1w3/9zz/yZKgDwacvhUsP5iOu",
+	"yH3BcsMIuCr88ba1ON3y2HEBZnq2djQddortuYqbxwUP+LsCfY7Du75PypivOcZfpvWnUJZHTM9VPBBo",
+	"dRPYNnSNr/q/b7mu6OAxoCNyEtNSM2/e9dAJqsDFXA+zPdeAAaZALA6hdkEriZPUA15+wAmZIL/hpW84",
+	"vvigl3tfSDe5RSjbO/Iwy1XtWIMoX4xwOriVXsaSF2/1Ht1ACFXPtXdHLDHv+li05mSv1YlYIUurxeDd",
+	"k3FPAN6UtsMBnUTil7trh5IAl/rhAvfemvFY4K4WdYHzsVLC2ef5XuI1LrfbvMGyfuB5+tf09CACP8I/",
+	"Gd9chzQHuPmxzHVEe3FHvRt9KE4tnpNj9Hw9JBblBS9ttamw6059MB79nMjj316uuhKS22P6M/WmPjBg",
+	"N3YUmJO8kJHJSt+ho03dFHSe4dryMQXqXgDR4QdReh15mOrZNlSj9EgDGMZ2hIUweiyctX3lNn0hFEbW",
+	"/mCzDt1wJH7/KjbhBToBjOLAfbzoNcPPW83y94xU8zL4+VLOJ4u0LlXmzdO9TiGg2OUMSBx+AkIc/h3l",
+	"84qcXKHzjhXV0c5Vv8j6OAi9IDfoC73gIBUvNsYP4Mby4hALd676iE1N6CLpvE9fpseXXnYKcqr0CIOO",
+	"H+0w4GpHaU6vn+wuVD03ApYbpq9UE7gGDDEHaOguTe9YE0TZNpe5GQKnyfucDsk3lSNF7YM9ZMfGv+f7",
+	"QgKsHPPdPSlzCFXP1VL9QZ+iqjEGDsQcdTujGjmb1/w68cMKM5Zk+ayMeIjpUIMBiBDzM8QwzdJ1GGB6",
+	"4DnpF4a1QfAQbfcoUk0QePQiRSb1naAN/BCGVzws42dqTuH42+y7ksyMlx8RyK+lQ9+WSGQ5oasd/S4X",
+	"bjMpya5Ie4cFUIXWBt6KVeZGRmbKbfh4KVOlSo5M3cqR5apBanGAnWPogxi+nMFnpf14nBn9j5h80qgI",
+	"gUyhoKglA5H1SIRHZ+FWVlL1yTZCPoOrYQF0UIYPsy20EK1KHSks84gOK0AAz7zqE9hHrIHOKoe6C8L0",
+	"2LYzpDOBOzBQ8TwbgjRPfUDiwwH7ja93mxD7om7HLw2nH28sfUUZXqDlqkV80IqvmeivtQd9PTSOfdsD",
+	"p+A4k6d77Eg0isNsiAHXi8zrgDq8CKf/fi7gn+hYuuyG/rMRyqnD6QLoFXp5onSRK/6kXQ0g0uRDmH0R",
+	"JRMPOPFA4TJe/Ubhb0XJ51MBRF4+RvtUyHdsxwY/Dt7Op722KzBf3t/9Sth5BUX5OVQoX6VC+SoVme36",
+	"lZJxnVhCeZozebxAIe9Q81j0qgy9eizvKVT95fj+8uDlL1DO84roJ2Tsb2O1D+f5ccz/crm+5vAt5vc5",
+	"4vK6KuQJ7Oj8ON5MK50O7o35KdAsL2YUWOh35cgo7obcQhuOupFE1ez9YtrdLWZDccER4nxKyKd/1xcr",
+	"bYbq4yV80rSjxaSLowmDvsyjevhOksdJTx47i5mZgJlop9/I+LbHGWRXVgmJWxOiK5qKM9woMr6TVqi9",
+	"bJybNTq/g2/oHfWFRoM/eLEZcSgsSNNpOf27fyxRJeQHiCPTCyykvsvCt9//WJ5lUZeFb8sCUa7SJaJM",
+	"0dSycL9E5aAflpa+YTR5oeJqZR/WymrZ2Ay2IlseaHyZ243irr5Jv/djxbbUH2u4S9dIjXXCJ/MWanfb",
+	"r/A6M5gLh785ZqByA4Pht0R/MUx0nuIWYe+JlFi8V+pPdSXcB8BvdnWnxDeKhJfMSq7AdZ2VbCnF7k6v",
+	"1GF9M+qovErhcx8oG0YxOq2qGpImtydQI2Hh+f41+qrELX26MQGcCmRmDvbrJjnVa9Q0am6doTbTGbzL",
+	"fpW+gButLDVwn0Zjlyd3kBC9WGe5ZkeJBGklNibNNmz1orZcip9sttiWq12SKs3CcGbIncFQMvc+w6mS",
+	"RI+Lc1vdeLt1q+QYKX3f75eFAOoBDM0fpuVmFOIpoiHytpEHnKW70jeV9M25VqaPI41YFp5fFcDL1PSL",
+	"RKVwHjM4j6rnvD9JSVdz9kjt1QVgSgfVkl6mH0oVovJAl8rkg0Lp6gOp1sqUXi4DHZTPN4tjS7vciroq",
+	"8OEPNfCgf/+j+vxw+pv+wN8E+fyP3HJECNU4sKLdCBnd7CY81ZZSHXvVSl8uLF6tSudtLVf3jqO8QE3N",
+	"d+bbFJpWZMYKMpOBjcpZUeSH34pFI32MzqDYgokNo6gP1DUItKIBbKAFFrQLN3O8zeMrbASDDQzOgiE0",
+	"3Bv6UM1uIstLE6O2pcJDPuOADeMD1YQY+YhfYPStWEyS5BGkbx+9wCgelobFjlDnuyP+gXzEH83ISbGK",
+	"rMiG7+PzgPV86KK/qHS/DQzCjBDiEX8kCATK86ELfAuJ0CP+SBVSITDT0yk+JtC2H9aul7jFVbIOH48T",
+	"0QZMOYycmpRWQUPIwCgtxt9fzlmTOP7TZqxT+Dnz1aNYVWEYokHlE07Z6Z1mvPPAnvAsHofBUzmNHQcE",
+	"u4yirC8E3Q9TqGCo02MEo6wkF3nYBtiWdki4oO4RDKR4YGk9KcSsMIxfMqFhekRINYARossfLZCz/iS0",
+	"bzE1Dg+ZcSj+cVV7ei4e8jSIGD/O4X926fXjKD3DADgwggHaKCfrdEriYKlcIv0pfEtPvnB/FNUrBArn",
+	"nksUxPD+g4d2G9p+z0DBMGI9bffTpOMma5IjKdkH6OxQZTVznG4oe84X4V8pcwcTl57WtVX8/fvz93Ox",
+	"HKfpFgxgLkwORT/lRFaupGUnzh4E6DPSVkSZ0lTkvLwp/GGa7Q/fyii+kYI+pLfBWfbZCrMPjvnu9CDR",
+	"UxPaGha7kWVj4GYrLC08vLjSR//1QtKPOdR/olTlg+/ZtuUa/zrhoN7WL8Bl9eIMuTS3+l4ZIk2NnuD9",
+	"8ywTnL7+V5axPVhuHdh2iClAXWfdqFliFEE5ZVAPGFrIvniYZ2tZyjTPCqBs+n+tGcgtcuXYAu4lIw5s",
+	"+zX5vZShOAigG9k7DN2K4eNHTccvISzbJo+yhhdAy3CxC73Hjhnnv8RIIRTNwHOtPQxzWJs22l+7L3/O",
+	"YmW96g9n+YPX7RZSvOvds+s6RKbgBURaoEvrmGkQk84DHApJb84TJKYXXk4RIOMB3QMbUHVlDf3ophMj",
+	"swmnf6a1UcRg6EbZTqcJhfPtDxjJnRFmAlcLTbCGaaIdAi1rd792To4FHhcm4Q04bwMzolXPdaGKeHaB",
+	"xGFOworyDJCAuHgz6fDfaoxenXHJ0dr66wKVN4Py1xme1+dWfrkv/hmTg9x1gIWmF0QPtoUKlznjK5F3",
+	"IcpXJa1Urp04ioGNtOnMIGU8wM5F+kNWCbXSvhExdWEiTmX50H/2H6Aiv0jErqZD/oZyldpK1Hs3lTMT",
+	"+mKKQ+CgUSlgOSEGMnPqBZZhucDGPBd+PeSzj13RGrRhdp9dStAQpp90DgXUv7f83H+qqBykpF13XKcI",
+	"P8Uw2L1gfFwicG8ie52R+v73jvIOR/tCPWah5rtTLHTwHLJwKreH+yh0mXR8f77Pzxswasqx/wwZ+kXX",
+	"9EWX/fPz8zXCv/J2zRifY/Hkl5O/PPMDB5BunI77+b5A47V/E1LXvR1/jYYcBDcL/tNcyAk3IwBuyh+Q",
+	"Z1rQd3Cr2nGI2r4CyzAj5BdkbSy3rdSnKti1Qn3Agh+ahs9cgCsTmCYTUdCx8iz3cLEcfBQvsPYZsuYZ",
+	"DViUF7sgb96AUTqiii4ZJvP1j47FpcYfWtb/8y4M8YJHx9kIFKId+JwVUPIuCcTeIzc+fkfcYMDezpWf",
+	"RYbXt9lZE9RBdV9OOcQUL3a1LNvzIqQIjG6hXjo/sNzoFWpOAtFPN2+nY3SfICMd8AbR2YjMCwaHGQH0",
+	"8GY45RV0siVMdIHEx2bD32HwTUn6BqUs9XbyxS44+Qa6p679z/oHP8W8vjpnk2NxjzlW4F5E8NcacBbV",
+	"IMN3kpAQ81B7tQlsPS8t+vgT6iS9k/IdktIXluMVk3VmTw/rMxfl1XzNpYSm/LgVhlQOQKpFWQrm5ve6",
+	"wmOW/Jg6zhmeCSMP6ewrmN9j8NF4PE4VH4ZZZyW89jCaCFwewEes650beCvEXAg11Gcbeoc22jP6PP2M",
+	"OKhdgEozQgaMDpy+tvUpA7w4SqVFcywkM2k1cvnqNTC1IjOdXvpvdf7yJrjyAtBXBvb+0lTM/8eG40X+",
+	"lR2GflviGPshxgWenQ0fhRdI5BqRj7tlRfU4ifp+iQscpkhTbb2OWdPJzvBoMG7N0uFHBVLbg0iD14Td",
+	"Wgz5Ym71Zhg09fgOAyNWdPxVlnN78tqAKYLnehGCFkDfBjuYW0iqpy1m1xO7/9VJqlfHl99QvkORMFvw",
+	"iTHgx5/UvwAudz/u89eq23ltNnwrHTq8+PAdWTuHimVTb1mZGVw6ID4MQh+qkbX5d0QweQ6oetHP/VHw",
+	"V13gOV79y/6veL7+ceTzo3ueZkS/vN1hyPUzGx6W/PKMc+6P8PytUs8dKzzO9Z4pxuOZHl5qzOe1sfjH",
+	"+T8F7jm9CUGkmrcKejO8+5+ccg4u6chB65IxX8Yqay7/Zd0Orw1U595JtwbzS51QPwXzy1+x+jtpHQqo",
+	"/KjIQdeC4WVj0eEIw1+pgcXD9RC+mjW8cC6yj188hzDPnwzzxP7+rIR1TImEp+HrYwk6J4LOYFln/aYX",
+	"nqcPT50LmhW9eMxZkji0tBPWaE60d+ylehffU+yL/JIjk/J81/zfdgv/11x90lz9NVfv9U/v/e2Kv2d+",
+	"+lHqXtUxcH1Sr5qJFBOUT8pE8bI93fZUYJteGD2GCTAMGDxaXhH4VnFDoRGGI9RreekdmXMwW1mb8kWP",
+	"KNweexaRooenNq2MpydpumzAer5/Y6e0Reo8RXWRjD/AO8YLz/cfw/lC7xUYJRC6l9x+gX3J2jd3OP0I",
+	"HbJsIdzAANg5JaLw9kdCX7Y71Ma+P/+/AQCvhaUNYGwAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
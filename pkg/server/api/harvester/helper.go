@@ -1,13 +1,28 @@
 package harvester
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
 	"fmt"
+	"math"
+	"math/big"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
 
+const (
+	jwkKtyRSA   = "RSA"
+	jwkKtyEC    = "EC"
+	jwkUseSig   = "sig"
+	jwkAlgRS256 = "RS256"
+)
+
 func (b PutBundleRequest) ToEntity() (*entity.Bundle, error) {
 	td, err := spiffeid.TrustDomainFromString(b.TrustDomain)
 	if err != nil {
@@ -46,3 +61,177 @@ func (b PutBundleRequest) ToEntity() (*entity.Bundle, error) {
 		SigningCertificateChain: cert,
 	}, nil
 }
+
+// ConsentToEntity returns the signed consent in the request, or nil if the request has no consent statement.
+func (r PatchRelationshipRequest) ConsentToEntity() (*entity.RelationshipConsent, error) {
+	if r.ConsentStatement == nil {
+		return nil, nil
+	}
+
+	statement, err := encoding.DecodeFromBase64(*r.ConsentStatement)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode consent statement: %w", err)
+	}
+
+	var sig []byte
+	if r.Signature != nil {
+		sig, err = encoding.DecodeFromBase64(*r.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode signature: %w", err)
+		}
+	}
+
+	var cert []byte
+	if r.SigningCertificateChain != nil {
+		cert, err = encoding.DecodeFromBase64(*r.SigningCertificateChain)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode signing certificate: %w", err)
+		}
+	}
+
+	return &entity.RelationshipConsent{
+		ConsentStatement:        statement,
+		Signature:               sig,
+		SigningCertificateChain: cert,
+	}, nil
+}
+
+// Decode returns the signed onboard statement, its signature and the DER encoded signing certificate chain in the request.
+func (r OnboardProofRequest) Decode() (statement, signature, certChain []byte, err error) {
+	statement, err = encoding.DecodeFromBase64(r.OnboardStatement)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot decode onboard statement: %w", err)
+	}
+
+	signature, err = encoding.DecodeFromBase64(r.Signature)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot decode signature: %w", err)
+	}
+
+	certChain, err = encoding.DecodeFromBase64(r.SigningCertificateChain)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot decode signing certificate chain: %w", err)
+	}
+
+	return statement, signature, certChain, nil
+}
+
+// ParseCSR decodes the certificate signing request and checks that it is signed with the private key
+// of the public key it holds.
+func (r ClientCertificateRequest) ParseCSR() (*x509.CertificateRequest, error) {
+	der, err := encoding.DecodeFromBase64(r.Csr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode certificate signing request: %w", err)
+	}
+
+	csr, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse certificate signing request: %w", err)
+	}
+
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("invalid certificate signing request signature: %w", err)
+	}
+
+	return csr, nil
+}
+
+// LeaseFromEntity converts a harvester lease entity to its API representation.
+func LeaseFromEntity(lease *entity.HarvesterLease) *Lease {
+	return &Lease{
+		InstanceId: lease.InstanceID,
+		ExpiresAt:  lease.ExpiresAt,
+	}
+}
+
+// JWKFromPublicKey creates the JSON Web Key representation of the given public key.
+// Only RSA and EC keys are supported.
+func JWKFromPublicKey(kid string, publicKey crypto.PublicKey) (*JWK, error) {
+	use := jwkUseSig
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		alg := jwkAlgRS256
+		n := base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+		e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+		return &JWK{
+			Kty: jwkKtyRSA,
+			Kid: kid,
+			Use: &use,
+			Alg: &alg,
+			N:   &n,
+			E:   &e,
+		}, nil
+	case *ecdsa.PublicKey:
+		crv := key.Curve.Params().Name
+		size := (key.Curve.Params().BitSize + 7) / 8
+		x := base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, size)))
+		y := base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, size)))
+		return &JWK{
+			Kty: jwkKtyEC,
+			Kid: kid,
+			Use: &use,
+			Crv: &crv,
+			X:   &x,
+			Y:   &y,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+}
+
+// PublicKey returns the public key represented by the JSON Web Key.
+func (k JWK) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case jwkKtyRSA:
+		if k.N == nil || k.E == nil {
+			return nil, fmt.Errorf("RSA key %q is missing the modulus or the exponent", k.Kid)
+		}
+		n, err := base64.RawURLEncoding.DecodeString(*k.N)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode modulus of key %q: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(*k.E)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode exponent of key %q: %w", k.Kid, err)
+		}
+		exp := new(big.Int).SetBytes(e)
+		if !exp.IsInt64() || exp.Int64() > math.MaxInt32 {
+			return nil, fmt.Errorf("invalid exponent for key %q", k.Kid)
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(exp.Int64()),
+		}, nil
+	case jwkKtyEC:
+		if k.Crv == nil || k.X == nil || k.Y == nil {
+			return nil, fmt.Errorf("EC key %q is missing the curve or the coordinates", k.Kid)
+		}
+		var curve elliptic.Curve
+		switch *k.Crv {
+		case elliptic.P256().Params().Name:
+			curve = elliptic.P256()
+		case elliptic.P384().Params().Name:
+			curve = elliptic.P384()
+		case elliptic.P521().Params().Name:
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q for key %q", *k.Crv, k.Kid)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(*k.X)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode x coordinate of key %q: %w", k.Kid, err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(*k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode y coordinate of key %q: %w", k.Kid, err)
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q for key %q", k.Kty, k.Kid)
+	}
+}
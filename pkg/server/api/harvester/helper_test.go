@@ -1,11 +1,19 @@
 package harvester
 
 import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"testing"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
 	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBundlePutToEntity(t *testing.T) {
@@ -43,3 +51,107 @@ func TestBundlePutToEntity(t *testing.T) {
 		assert.Equal(t, []byte("test-certificate"), bundle.SigningCertificateChain)
 	})
 }
+
+func TestPatchRelationshipRequestConsentToEntity(t *testing.T) {
+	consent, err := PatchRelationshipRequest{ConsentStatus: "approved"}.ConsentToEntity()
+	require.NoError(t, err)
+	assert.Nil(t, consent)
+
+	statement := encoding.EncodeToBase64([]byte("test-statement"))
+	sig := encoding.EncodeToBase64([]byte("test-signature"))
+	cert := encoding.EncodeToBase64([]byte("test-certificate"))
+	req := PatchRelationshipRequest{
+		ConsentStatus:           "approved",
+		ConsentStatement:        &statement,
+		Signature:               &sig,
+		SigningCertificateChain: &cert,
+	}
+
+	consent, err = req.ConsentToEntity()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("test-statement"), consent.ConsentStatement)
+	assert.Equal(t, []byte("test-signature"), consent.Signature)
+	assert.Equal(t, []byte("test-certificate"), consent.SigningCertificateChain)
+
+	invalid := "not base64!"
+	req.Signature = &invalid
+	_, err = req.ConsentToEntity()
+	assert.ErrorContains(t, err, "cannot decode signature")
+}
+
+func TestClientCertificateRequestParseCSR(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "td1.org"}}, key)
+	require.NoError(t, err)
+
+	csr, err := ClientCertificateRequest{Csr: encoding.EncodeToBase64(der)}.ParseCSR()
+	require.NoError(t, err)
+	assert.Equal(t, key.Public(), csr.PublicKey)
+
+	_, err = ClientCertificateRequest{Csr: "not base64!"}.ParseCSR()
+	assert.ErrorContains(t, err, "cannot decode certificate signing request")
+
+	_, err = ClientCertificateRequest{Csr: encoding.EncodeToBase64([]byte("not a csr"))}.ParseCSR()
+	assert.ErrorContains(t, err, "cannot parse certificate signing request")
+
+	// a CSR signed with another key does not prove the possession of the key
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	parsed, err := x509.ParseCertificateRequest(der)
+	require.NoError(t, err)
+	tampered := bytes.Replace(der, parsed.RawSubjectPublicKeyInfo, mustMarshalPKIXPublicKey(t, otherKey.Public()), 1)
+	_, err = ClientCertificateRequest{Csr: encoding.EncodeToBase64(tampered)}.ParseCSR()
+	assert.ErrorContains(t, err, "invalid certificate signing request signature")
+}
+
+func mustMarshalPKIXPublicKey(t *testing.T, publicKey crypto.PublicKey) []byte {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	return der
+}
+
+func TestJWKFromPublicKey(t *testing.T) {
+	t.Run("RSA key round trip", func(t *testing.T) {
+		signer, err := cryptoutil.GenerateSigner(cryptoutil.RSA2048)
+		require.NoError(t, err)
+
+		jwk, err := JWKFromPublicKey("kid-1", signer.Public())
+		require.NoError(t, err)
+		assert.Equal(t, "RSA", jwk.Kty)
+		assert.Equal(t, "kid-1", jwk.Kid)
+		assert.Equal(t, "RS256", *jwk.Alg)
+
+		publicKey, err := jwk.PublicKey()
+		require.NoError(t, err)
+		assert.Equal(t, signer.Public(), publicKey)
+	})
+
+	t.Run("EC key round trip", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		jwk, err := JWKFromPublicKey("kid-2", key.Public())
+		require.NoError(t, err)
+		assert.Equal(t, "EC", jwk.Kty)
+		assert.Equal(t, "P-256", *jwk.Crv)
+
+		publicKey, err := jwk.PublicKey()
+		require.NoError(t, err)
+		assert.True(t, key.PublicKey.Equal(publicKey))
+	})
+
+	t.Run("Unsupported key type", func(t *testing.T) {
+		jwk, err := JWKFromPublicKey("kid-3", "not a key")
+		assert.ErrorContains(t, err, "unsupported public key type string")
+		assert.Nil(t, jwk)
+
+		_, err = JWK{Kty: "oct", Kid: "kid-3"}.PublicKey()
+		assert.EqualError(t, err, `unsupported key type "oct" for key "kid-3"`)
+	})
+
+	t.Run("Missing RSA parameters", func(t *testing.T) {
+		_, err := JWK{Kty: "RSA", Kid: "kid-4"}.PublicKey()
+		assert.EqualError(t, err, `RSA key "kid-4" is missing the modulus or the exponent`)
+	})
+}
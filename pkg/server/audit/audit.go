@@ -0,0 +1,154 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// AdminActor is the actor of the actions performed through the admin API.
+	AdminActor = "admin"
+
+	// harvesterActorPrefix prefixes the trust domain of the Harvester performing an action.
+	harvesterActorPrefix = "harvester:"
+)
+
+// Store is the datastore holding the audit log.
+type Store interface {
+	AppendAuditEvent(ctx context.Context, req *entity.AuditEvent) (*entity.AuditEvent, error)
+}
+
+// Config is the configuration for the audit Recorder.
+type Config struct {
+	// Store is the datastore holding the audit log.
+	Store Store
+
+	// FilePath is optional, when set, the recorded events are also appended to the file as JSON lines,
+	// in the representation of the admin API.
+	FilePath string
+
+	Logger logrus.FieldLogger
+}
+
+// Recorder records the administrative and Harvester actions in the audit log.
+type Recorder struct {
+	store  Store
+	logger logrus.FieldLogger
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewRecorder creates a new audit Recorder.
+func NewRecorder(c *Config) (*Recorder, error) {
+	if c.Store == nil {
+		return nil, errors.New("store is required")
+	}
+	if c.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+
+	r := &Recorder{
+		store:  c.Store,
+		logger: c.Logger,
+	}
+
+	if c.FilePath != "" {
+		file, err := os.OpenFile(c.FilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open audit file: %w", err)
+		}
+		r.file = file
+	}
+
+	return r, nil
+}
+
+// HarvesterActor returns the actor of the actions performed by the Harvester of the given trust domain.
+func HarvesterActor(trustDomain string) string {
+	return harvesterActorPrefix + trustDomain
+}
+
+// Record appends an event to the audit log, and to the file sink if it is configured.
+// The failures are logged, the action is performed regardless. A nil Recorder records nothing.
+func (r *Recorder) Record(ctx context.Context, actor string, action entity.AuditAction, target, details string) {
+	if r == nil {
+		return
+	}
+
+	log := r.logger.WithFields(logrus.Fields{
+		telemetry.Actor:  actor,
+		telemetry.Action: action,
+	})
+
+	event, err := r.store.AppendAuditEvent(ctx, &entity.AuditEvent{
+		Actor:   actor,
+		Action:  action,
+		Target:  target,
+		Details: details,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to record audit event")
+		return
+	}
+
+	if err := r.writeToFile(event); err != nil {
+		log.WithError(err).Error("Failed to write audit event to file")
+	}
+}
+
+// Close closes the file sink.
+func (r *Recorder) Close() error {
+	if r == nil || r.file == nil {
+		return nil
+	}
+
+	return r.file.Close()
+}
+
+// VerifyChain verifies that the events, ordered by ID, form the complete audit log: the first event is chained
+// to the genesis hash, each event is chained to the previous one and its hash matches its content.
+// It returns an error identifying the first event that was altered, or that follows a deleted event.
+func VerifyChain(events []*entity.AuditEvent) error {
+	prevHash := entity.GenesisAuditHash
+	for _, e := range events {
+		if !bytes.Equal(e.PrevHash, prevHash) {
+			return fmt.Errorf("audit event %d is not chained to the previous event", e.ID)
+		}
+
+		if !bytes.Equal(e.ComputeHash(), e.Hash) {
+			return fmt.Errorf("audit event %d does not match its hash", e.ID)
+		}
+
+		prevHash = e.Hash
+	}
+
+	return nil
+}
+
+func (r *Recorder) writeToFile(e *entity.AuditEvent) error {
+	if r.file == nil {
+		return nil
+	}
+
+	line, err := json.Marshal(admin.AuditEventFromEntity(e))
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err = r.file.Write(append(line, '\n'))
+	return err
+}
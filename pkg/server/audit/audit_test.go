@@ -0,0 +1,127 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRecorder(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := fakedatastore.NewFakeDB()
+
+	_, err := NewRecorder(&Config{Logger: logger})
+	require.EqualError(t, err, "store is required")
+
+	_, err = NewRecorder(&Config{Store: store})
+	require.EqualError(t, err, "logger is required")
+
+	_, err = NewRecorder(&Config{Store: store, Logger: logger, FilePath: filepath.Join(t.TempDir(), "missing", "audit.jsonl")})
+	require.ErrorContains(t, err, "failed to open audit file")
+
+	r, err := NewRecorder(&Config{Store: store, Logger: logger})
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+}
+
+func TestRecord(t *testing.T) {
+	ctx := context.Background()
+	logger, hook := test.NewNullLogger()
+	store := fakedatastore.NewFakeDB()
+	filePath := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	r, err := NewRecorder(&Config{Store: store, Logger: logger, FilePath: filePath})
+	require.NoError(t, err)
+
+	r.Record(ctx, AdminActor, entity.AuditActionTrustDomainCreate, "td1.org", "")
+	r.Record(ctx, HarvesterActor("td1.org"), entity.AuditActionHarvesterOnboard, "td1.org", "")
+
+	// a failure to record an event is logged, and the event is not written to the file
+	store.SetNextError(errors.New("datastore unavailable"))
+	r.Record(ctx, AdminActor, entity.AuditActionTrustDomainDelete, "td1.org", "")
+	require.Len(t, hook.AllEntries(), 1)
+	assert.Equal(t, logrus.ErrorLevel, hook.LastEntry().Level)
+	assert.Equal(t, "Failed to record audit event", hook.LastEntry().Message)
+
+	require.NoError(t, r.Close())
+
+	events, err := store.ListAuditEvents(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "harvester:td1.org", events[1].Actor)
+	require.NoError(t, VerifyChain(events))
+
+	file, err := os.Open(filePath)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var lines []admin.AuditEvent
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event admin.AuditEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		lines = append(lines, event)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, lines, 2)
+	assert.Equal(t, admin.AuditEventFromEntity(events[0]), lines[0])
+	assert.Equal(t, admin.AuditEventFromEntity(events[1]), lines[1])
+}
+
+func TestRecordNilRecorder(t *testing.T) {
+	var r *Recorder
+	r.Record(context.Background(), AdminActor, entity.AuditActionTrustDomainCreate, "td1.org", "")
+	require.NoError(t, r.Close())
+}
+
+func TestVerifyChain(t *testing.T) {
+	ctx := context.Background()
+	store := fakedatastore.NewFakeDB()
+
+	for _, target := range []string{"td1.org", "td2.org", "td3.org"} {
+		_, err := store.AppendAuditEvent(ctx, &entity.AuditEvent{Actor: AdminActor, Action: entity.AuditActionTrustDomainCreate, Target: target})
+		require.NoError(t, err)
+	}
+
+	events, err := store.ListAuditEvents(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, VerifyChain(events))
+	require.NoError(t, VerifyChain(nil))
+
+	copyEvents := func() []*entity.AuditEvent {
+		copied := make([]*entity.AuditEvent, len(events))
+		for i, e := range events {
+			event := *e
+			copied[i] = &event
+		}
+		return copied
+	}
+
+	altered := copyEvents()
+	altered[1].Target = "evil.org"
+	require.EqualError(t, VerifyChain(altered), "audit event 2 does not match its hash")
+
+	rehashed := copyEvents()
+	rehashed[1].Target = "evil.org"
+	rehashed[1].Hash = rehashed[1].ComputeHash()
+	require.EqualError(t, VerifyChain(rehashed), "audit event 3 is not chained to the previous event")
+
+	deleted := copyEvents()
+	deleted = append(deleted[:1], deleted[2:]...)
+	require.EqualError(t, VerifyChain(deleted), "audit event 3 is not chained to the previous event")
+
+	require.EqualError(t, VerifyChain(events[1:]), "audit event 2 is not chained to the previous event")
+}
@@ -1,12 +1,16 @@
 package catalog
 
 import (
+	"encoding/base64"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
 	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/common/x509ca"
 	"github.com/HewlettPackard/galadriel/pkg/common/x509ca/disk"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
 	"github.com/HewlettPackard/galadriel/pkg/server/db"
 	"github.com/HewlettPackard/galadriel/pkg/server/db/postgres"
 	"github.com/HewlettPackard/galadriel/pkg/server/db/sqlite"
@@ -21,13 +25,22 @@ type Catalog interface {
 	GetDatastore() db.Datastore
 	GetX509CA() x509ca.X509CA
 	GetKeyManager() keymanager.KeyManager
+	GetBundleVerifiers() []*BundleVerifier
+}
+
+// BundleVerifier is a bundle verifier along with the name of the provider that implements it.
+// Trust domains can reference the provider name to require that their bundles are accepted by it.
+type BundleVerifier struct {
+	Name string
+	integrity.Verifier
 }
 
 // ProvidersRepository is the implementation of the Catalog interface.
 type ProvidersRepository struct {
-	datastore  db.Datastore
-	x509ca     x509ca.X509CA
-	keyManager keymanager.KeyManager
+	datastore       db.Datastore
+	x509ca          x509ca.X509CA
+	keyManager      keymanager.KeyManager
+	bundleVerifiers []*BundleVerifier
 
 	log   logrus.FieldLogger
 	clock clock.Clock
@@ -35,9 +48,10 @@ type ProvidersRepository struct {
 
 // ProvidersConfig holds the HCL configuration for the providers.
 type ProvidersConfig struct {
-	Datastore  *providerConfig `hcl:"Datastore,block"`
-	X509CA     *providerConfig `hcl:"X509CA,block"`
-	KeyManager *providerConfig `hcl:"KeyManager,block"`
+	Datastore       *providerConfig   `hcl:"Datastore,block"`
+	X509CA          *providerConfig   `hcl:"X509CA,block"`
+	KeyManager      *providerConfig   `hcl:"KeyManager,block"`
+	BundleVerifiers []*providerConfig `hcl:"BundleVerifier,block"`
 }
 
 // providerConfig holds the HCL configuration options for a single provider.
@@ -54,6 +68,12 @@ type diskKeyManagerConfig struct {
 	KeysFilePath string `hcl:"keys_file_path"`
 }
 
+type datastoreKeyManagerConfig struct {
+	// KeyEncryptionKeyPath is the path to a file holding the base64 encoded 32 bytes AES key
+	// used to encrypt the keys stored in the datastore.
+	KeyEncryptionKeyPath string `hcl:"key_encryption_key_path"`
+}
+
 // New creates a new ProvidersRepository.
 // It is the responsibility of the caller to load the catalog with providers using LoadFromProvidersConfig.
 func New(log logrus.FieldLogger) *ProvidersRepository {
@@ -92,15 +112,31 @@ func (c *ProvidersRepository) LoadFromProvidersConfig(config *ProvidersConfig) e
 		return fmt.Errorf("error loading X509CA: %w", err)
 	}
 
-	c.keyManager, err = loadKeyManager(config.KeyManager)
-	if err != nil {
-		return fmt.Errorf("error loading KeyManager: %w", err)
-	}
 	c.datastore, err = loadDatastore(config.Datastore, c.log.WithField(telemetry.SubsystemName, telemetry.Datastore))
 	if err != nil {
 		return fmt.Errorf("error loading datastore: %w", err)
 	}
 
+	// the datastore KeyManager stores the keys in the datastore, so it is loaded after it
+	c.keyManager, err = loadKeyManager(config.KeyManager, c.datastore)
+	if err != nil {
+		return fmt.Errorf("error loading KeyManager: %w", err)
+	}
+
+	names := make(map[string]bool)
+	for _, bv := range config.BundleVerifiers {
+		if names[bv.Name] {
+			return fmt.Errorf("duplicate BundleVerifier configuration: %s", bv.Name)
+		}
+		names[bv.Name] = true
+
+		verifier, err := loadBundleVerifier(bv, c.clock)
+		if err != nil {
+			return fmt.Errorf("error loading BundleVerifier: %w", err)
+		}
+		c.bundleVerifiers = append(c.bundleVerifiers, &BundleVerifier{Name: bv.Name, Verifier: verifier})
+	}
+
 	return nil
 }
 
@@ -116,6 +152,10 @@ func (c *ProvidersRepository) GetKeyManager() keymanager.KeyManager {
 	return c.keyManager
 }
 
+func (c *ProvidersRepository) GetBundleVerifiers() []*BundleVerifier {
+	return c.bundleVerifiers
+}
+
 func (r *ProvidersRepository) loadX509CA(c *providerConfig) (x509ca.X509CA, error) {
 	switch c.Name {
 	case "disk":
@@ -129,7 +169,7 @@ func (r *ProvidersRepository) loadX509CA(c *providerConfig) (x509ca.X509CA, erro
 	return nil, fmt.Errorf("unknown X509CA provider: %s", c.Name)
 }
 
-func loadKeyManager(c *providerConfig) (keymanager.KeyManager, error) {
+func loadKeyManager(c *providerConfig, keyStore keymanager.KeyStore) (keymanager.KeyManager, error) {
 	switch c.Name {
 	case "memory":
 		km := keymanager.NewMemoryKeyManager(nil)
@@ -144,6 +184,20 @@ func loadKeyManager(c *providerConfig) (keymanager.KeyManager, error) {
 			return nil, fmt.Errorf("error creating disk KeyManager: %w", err)
 		}
 		return km, nil
+	case "datastore":
+		kmConfig, err := decodeDatastoreKeyManagerConfig(c)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding datastore KeyManager config: %w", err)
+		}
+		keyEncryptionKey, err := loadKeyEncryptionKey(kmConfig.KeyEncryptionKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		km, err := keymanager.NewDatastoreKeyManager(nil, keyStore, keyEncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("error creating datastore KeyManager: %w", err)
+		}
+		return km, nil
 	}
 
 	return nil, fmt.Errorf("unknown KeyManager provider: %s", c.Name)
@@ -190,6 +244,32 @@ func decodeDiskKeyManagerConfig(config *providerConfig) (*diskKeyManagerConfig,
 	return &dsConfig, nil
 }
 
+func decodeDatastoreKeyManagerConfig(config *providerConfig) (*datastoreKeyManagerConfig, error) {
+	var kmConfig datastoreKeyManagerConfig
+	if err := gohcl.DecodeBody(config.Options, nil, &kmConfig); err != nil {
+		return nil, err
+	}
+	return &kmConfig, nil
+}
+
+func loadKeyEncryptionKey(path string) ([]byte, error) {
+	if path == "" {
+		return nil, fmt.Errorf("key_encryption_key_path is required")
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading key encryption key: %w", err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding key encryption key: %w", err)
+	}
+
+	return key, nil
+}
+
 func makeDiskX509CA(config *providerConfig, clk clock.Clock) (*disk.X509CA, error) {
 	var diskX509CAConfig disk.Config
 	if err := gohcl.DecodeBody(config.Options, nil, &diskX509CAConfig); err != nil {
@@ -207,3 +287,77 @@ func makeDiskX509CA(config *providerConfig, clk clock.Clock) (*disk.X509CA, erro
 	}
 	return ca, nil
 }
+
+func loadBundleVerifier(config *providerConfig, clk clock.Clock) (integrity.Verifier, error) {
+	switch config.Name {
+	case "disk":
+		c, err := decodeDiskBundleVerifierConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding disk bundle verifier config: %w", err)
+		}
+		c.Clock = clk
+
+		verifier := integrity.NewDiskVerifier()
+		if err := verifier.Configure(c); err != nil {
+			return nil, fmt.Errorf("error configuring disk bundle verifier: %w", err)
+		}
+
+		return verifier, nil
+	case "sigstore":
+		c, err := decodeSigstoreBundleVerifierConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding sigstore bundle verifier config: %w", err)
+		}
+
+		verifier := integrity.NewSigstoreVerifier()
+		if err := verifier.Configure(c); err != nil {
+			return nil, fmt.Errorf("error configuring sigstore bundle verifier: %w", err)
+		}
+
+		return verifier, nil
+	case "x509svid":
+		c, err := decodeX509SVIDBundleVerifierConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding x509svid bundle verifier config: %w", err)
+		}
+		c.Clock = clk
+
+		verifier := integrity.NewX509SVIDVerifier()
+		if err := verifier.Configure(c); err != nil {
+			return nil, fmt.Errorf("error configuring x509svid bundle verifier: %w", err)
+		}
+
+		return verifier, nil
+	case "noop":
+		return integrity.NewNoOpVerifier(), nil
+	}
+
+	return nil, fmt.Errorf("unknown bundle verifier provider: %s", config.Name)
+}
+
+func decodeDiskBundleVerifierConfig(config *providerConfig) (*integrity.DiskVerifierConfig, error) {
+	var dvConfig integrity.DiskVerifierConfig
+	if err := gohcl.DecodeBody(config.Options, nil, &dvConfig); err != nil {
+		return nil, err
+	}
+
+	return &dvConfig, nil
+}
+
+func decodeSigstoreBundleVerifierConfig(config *providerConfig) (*integrity.SigstoreVerifierConfig, error) {
+	var svConfig integrity.SigstoreVerifierConfig
+	if err := gohcl.DecodeBody(config.Options, nil, &svConfig); err != nil {
+		return nil, err
+	}
+
+	return &svConfig, nil
+}
+
+func decodeX509SVIDBundleVerifierConfig(config *providerConfig) (*integrity.X509SVIDVerifierConfig, error) {
+	var xvConfig integrity.X509SVIDVerifierConfig
+	if err := gohcl.DecodeBody(config.Options, nil, &xvConfig); err != nil {
+		return nil, err
+	}
+
+	return &xvConfig, nil
+}
@@ -1,12 +1,21 @@
 package catalog
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
 	"os"
 	"testing"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
 	"github.com/HewlettPackard/galadriel/pkg/common/x509ca/disk"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
 	"github.com/HewlettPackard/galadriel/test/certtest"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
@@ -26,6 +35,10 @@ providers {
         bundle_file_path = "%s"
 	}
     KeyManager "memory" {}
+    BundleVerifier "noop" {}
+    BundleVerifier "disk" {
+        trust_bundle_path = "%s"
+    }
 }
 `
 var clk = clock.NewFake()
@@ -50,13 +63,14 @@ func TestProvidersConfigsFromHCLBody(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, pc)
 	require.NotNil(t, pc.X509CA)
+	require.Len(t, pc.BundleVerifiers, 2)
 }
 
 func TestLoadFromProvidersConfig(t *testing.T) {
 	tempDir, cleanup := setupTest(t)
 	defer cleanup()
 
-	hclConfig := fmt.Sprintf(hclConfigTemplate, ":memory:", tempDir+"/intermediate-ca.key", tempDir+"/intermediate-ca.crt", tempDir+"/root-ca.crt")
+	hclConfig := fmt.Sprintf(hclConfigTemplate, ":memory:", tempDir+"/intermediate-ca.key", tempDir+"/intermediate-ca.crt", tempDir+"/root-ca.crt", tempDir+"/root-ca.crt")
 
 	hclBody, diagErr := hclsyntax.ParseConfig([]byte(hclConfig), "", hcl.Pos{Line: 1, Column: 1})
 	require.False(t, diagErr.HasErrors())
@@ -79,6 +93,85 @@ func TestLoadFromProvidersConfig(t *testing.T) {
 
 	_, ok := cat.GetX509CA().(*disk.X509CA)
 	require.True(t, ok)
+
+	verifiers := cat.GetBundleVerifiers()
+	require.Len(t, verifiers, 2)
+	require.Equal(t, "noop", verifiers[0].Name)
+	require.IsType(t, &integrity.NoOpVerifier{}, verifiers[0].Verifier)
+	require.Equal(t, "disk", verifiers[1].Name)
+	require.IsType(t, &integrity.DiskVerifier{}, verifiers[1].Verifier)
+}
+
+func TestLoadDatastoreKeyManager(t *testing.T) {
+	tempDir := t.TempDir()
+	keyPath := tempDir + "/kek"
+	key := base64.StdEncoding.EncodeToString(make([]byte, keymanager.KeyEncryptionKeySize))
+	require.NoError(t, os.WriteFile(keyPath, []byte(key+"\n"), 0600))
+
+	config := fmt.Sprintf(`key_encryption_key_path = "%s"`, keyPath)
+	hclBody, diagErr := hclsyntax.ParseConfig([]byte(config), "", hcl.Pos{Line: 1, Column: 1})
+	require.False(t, diagErr.HasErrors())
+
+	km, err := loadKeyManager(&providerConfig{Name: "datastore", Options: hclBody.Body}, fakedatastore.NewFakeDB())
+	require.NoError(t, err)
+	require.IsType(t, &keymanager.Datastore{}, km)
+
+	shortKeyPath := tempDir + "/short-kek"
+	require.NoError(t, os.WriteFile(shortKeyPath, []byte(base64.StdEncoding.EncodeToString([]byte("short"))), 0600))
+	config = fmt.Sprintf(`key_encryption_key_path = "%s"`, shortKeyPath)
+	hclBody, diagErr = hclsyntax.ParseConfig([]byte(config), "", hcl.Pos{Line: 1, Column: 1})
+	require.False(t, diagErr.HasErrors())
+
+	_, err = loadKeyManager(&providerConfig{Name: "datastore", Options: hclBody.Body}, fakedatastore.NewFakeDB())
+	require.EqualError(t, err, "error creating datastore KeyManager: key encryption key must be 32 bytes long, got 5")
+}
+
+func TestLoadBundleVerifier(t *testing.T) {
+	_, err := loadBundleVerifier(&providerConfig{Name: "unknown"}, clk)
+	require.EqualError(t, err, "unknown bundle verifier provider: unknown")
+}
+
+func TestLoadSigstoreBundleVerifier(t *testing.T) {
+	publicKeyPath := writeTestPublicKey(t)
+
+	config := fmt.Sprintf(`public_key_path = "%s"`, publicKeyPath)
+	hclBody, diagErr := hclsyntax.ParseConfig([]byte(config), "", hcl.Pos{Line: 1, Column: 1})
+	require.False(t, diagErr.HasErrors())
+
+	verifier, err := loadBundleVerifier(&providerConfig{Name: "sigstore", Options: hclBody.Body}, clk)
+	require.NoError(t, err)
+	require.IsType(t, &integrity.SigstoreVerifier{}, verifier)
+
+	config = fmt.Sprintf(`
+public_key_path = "%s"
+rekor_public_key_path = "%s"
+`, publicKeyPath, t.TempDir()+"/missing.pub")
+	hclBody, diagErr = hclsyntax.ParseConfig([]byte(config), "", hcl.Pos{Line: 1, Column: 1})
+	require.False(t, diagErr.HasErrors())
+
+	_, err = loadBundleVerifier(&providerConfig{Name: "sigstore", Options: hclBody.Body}, clk)
+	require.ErrorContains(t, err, "error configuring sigstore bundle verifier: failed to load transparency log public key")
+}
+
+func TestLoadX509SVIDBundleVerifier(t *testing.T) {
+	hclBody, diagErr := hclsyntax.ParseConfig([]byte(``), "", hcl.Pos{Line: 1, Column: 1})
+	require.False(t, diagErr.HasErrors())
+
+	verifier, err := loadBundleVerifier(&providerConfig{Name: "x509svid", Options: hclBody.Body}, clk)
+	require.NoError(t, err)
+	require.IsType(t, &integrity.X509SVIDVerifier{}, verifier)
+}
+
+func writeTestPublicKey(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+
+	path := t.TempDir() + "/cosign.pub"
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+
+	return path
 }
 
 func setupTest(t *testing.T) (string, func()) {
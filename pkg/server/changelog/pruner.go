@@ -0,0 +1,98 @@
+package changelog
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/server/leader"
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultRetention is how long the changes are kept in the change log. A Harvester whose cursor
+	// is older falls back to a full bundle sync.
+	DefaultRetention = 24 * time.Hour
+
+	// pruneInterval is how often the changes older than the retention are deleted.
+	pruneInterval = time.Hour
+)
+
+// Store is the datastore holding the change log.
+type Store interface {
+	PruneChangeEvents(ctx context.Context, before time.Time) error
+}
+
+// Config is the configuration for the change log Pruner.
+type Config struct {
+	// Store is the datastore holding the change log.
+	Store Store
+
+	// Retention is how long the changes are kept in the change log.
+	Retention time.Duration
+
+	// Leader reports whether this replica prunes the change log when several Galadriel Server replicas
+	// share the datastore. It is nil when the server runs as a single replica.
+	Leader leader.Leader
+
+	Clock  clock.Clock
+	Logger logrus.FieldLogger
+}
+
+// Pruner periodically deletes the changes older than the retention from the change log,
+// so that it does not grow without bounds.
+type Pruner struct {
+	c *Config
+}
+
+// NewPruner creates a new change log Pruner.
+func NewPruner(c *Config) (*Pruner, error) {
+	if c.Store == nil {
+		return nil, errors.New("store is required")
+	}
+	if c.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+	if c.Retention == 0 {
+		c.Retention = DefaultRetention
+	}
+	if c.Retention < 0 {
+		return nil, errors.New("retention cannot be negative")
+	}
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+
+	return &Pruner{c: c}, nil
+}
+
+// Run periodically prunes the change log until the context is done.
+func (p *Pruner) Run(ctx context.Context) error {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.prune(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// prune deletes the changes older than the retention, unless another replica is the leader.
+func (p *Pruner) prune(ctx context.Context) {
+	if p.c.Leader != nil && !p.c.Leader.IsLeader() {
+		return
+	}
+
+	before := p.c.Clock.Now().Add(-p.c.Retention)
+	if err := p.c.Store.PruneChangeEvents(ctx, before); err != nil {
+		p.c.Logger.WithError(err).Error("Failed to prune change log")
+		return
+	}
+
+	p.c.Logger.Debug("Pruned change log")
+}
@@ -0,0 +1,86 @@
+package changelog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	before []time.Time
+	err    error
+}
+
+func (s *fakeStore) PruneChangeEvents(ctx context.Context, before time.Time) error {
+	s.before = append(s.before, before)
+	return s.err
+}
+
+type fakeLeader bool
+
+func (l fakeLeader) IsLeader() bool {
+	return bool(l)
+}
+
+func TestNewPruner(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := &fakeStore{}
+
+	p, err := NewPruner(&Config{Store: store, Logger: logger})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRetention, p.c.Retention)
+
+	_, err = NewPruner(&Config{Logger: logger})
+	require.EqualError(t, err, "store is required")
+
+	_, err = NewPruner(&Config{Store: store})
+	require.EqualError(t, err, "logger is required")
+
+	_, err = NewPruner(&Config{Store: store, Logger: logger, Retention: -time.Hour})
+	require.EqualError(t, err, "retention cannot be negative")
+}
+
+func TestPrune(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	clk := clock.NewFake()
+	clk.Set(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	t.Run("Prunes the changes older than the retention", func(t *testing.T) {
+		store := &fakeStore{}
+		p, err := NewPruner(&Config{Store: store, Retention: time.Hour, Clock: clk, Logger: logger})
+		require.NoError(t, err)
+
+		p.prune(context.Background())
+		assert.Equal(t, []time.Time{clk.Now().Add(-time.Hour)}, store.before)
+	})
+
+	t.Run("Only the leader prunes", func(t *testing.T) {
+		store := &fakeStore{}
+		p, err := NewPruner(&Config{Store: store, Leader: fakeLeader(false), Clock: clk, Logger: logger})
+		require.NoError(t, err)
+
+		p.prune(context.Background())
+		assert.Empty(t, store.before)
+
+		p.c.Leader = fakeLeader(true)
+		p.prune(context.Background())
+		assert.Len(t, store.before, 1)
+	})
+
+	t.Run("Logs the failures", func(t *testing.T) {
+		hook.Reset()
+		store := &fakeStore{err: errors.New("datastore error")}
+		p, err := NewPruner(&Config{Store: store, Clock: clk, Logger: logger})
+		require.NoError(t, err)
+
+		p.prune(context.Background())
+		require.NotNil(t, hook.LastEntry())
+		assert.Equal(t, "Failed to prune change log", hook.LastEntry().Message)
+	})
+}
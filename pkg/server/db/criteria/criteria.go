@@ -2,6 +2,8 @@
 package criteria
 
 import (
+	"time"
+
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	"github.com/HewlettPackard/galadriel/pkg/server/db/dbtypes"
 	"github.com/Masterminds/squirrel"
@@ -129,3 +131,68 @@ func (c *ListTrustDomainsCriteria) GetOrderDirection() OrderDirection {
 func (c *ListTrustDomainsCriteria) GetFilters() []Filter {
 	return []Filter{}
 }
+
+// ActorFilter represents a filter based on the actor of the audit events.
+type ActorFilter struct {
+	Actor string
+}
+
+// GetCondition returns the SQL condition based on the actor filter.
+func (f *ActorFilter) GetCondition(dbtypes.Engine) squirrel.Sqlizer {
+	return squirrel.Eq{"actor": f.Actor}
+}
+
+// CreatedAtRangeFilter represents a filter based on the creation time. The zero bounds are ignored.
+type CreatedAtRangeFilter struct {
+	Since time.Time // Inclusive lower bound
+	Until time.Time // Exclusive upper bound
+}
+
+// GetCondition returns the SQL condition based on the creation time filter.
+func (f *CreatedAtRangeFilter) GetCondition(dbtypes.Engine) squirrel.Sqlizer {
+	conditions := squirrel.And{}
+	if !f.Since.IsZero() {
+		conditions = append(conditions, squirrel.GtOrEq{"created_at": f.Since.UTC()})
+	}
+	if !f.Until.IsZero() {
+		conditions = append(conditions, squirrel.Lt{"created_at": f.Until.UTC()})
+	}
+	return conditions
+}
+
+// ListAuditEventsCriteria defines the criteria for filtering audit events. The events are always ordered by ID,
+// which is the order of the hash chain.
+type ListAuditEventsCriteria struct {
+	PageNumber    uint      // Page number for pagination (0 for no pagination)
+	PageSize      uint      // Number of items per page (0 for no pagination)
+	FilterByActor string    // Filter audit events by actor (optional)
+	FilterBySince time.Time // Filter audit events created at or after this time (optional)
+	FilterByUntil time.Time // Filter audit events created before this time (optional)
+}
+
+func (c *ListAuditEventsCriteria) GetPageNumber() uint {
+	return c.PageNumber
+}
+
+func (c *ListAuditEventsCriteria) GetPageSize() uint {
+	return c.PageSize
+}
+
+func (c *ListAuditEventsCriteria) GetOrderDirection() OrderDirection {
+	return NoOrder
+}
+
+// GetFilters returns the filters for audit events.
+func (c *ListAuditEventsCriteria) GetFilters() []Filter {
+	var filters []Filter
+
+	if c.FilterByActor != "" {
+		filters = append(filters, &ActorFilter{Actor: c.FilterByActor})
+	}
+
+	if !c.FilterBySince.IsZero() || !c.FilterByUntil.IsZero() {
+		filters = append(filters, &CreatedAtRangeFilter{Since: c.FilterBySince, Until: c.FilterByUntil})
+	}
+
+	return filters
+}
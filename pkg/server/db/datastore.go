@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	"github.com/HewlettPackard/galadriel/pkg/server/db/criteria"
@@ -22,17 +23,58 @@ type Datastore interface {
 	FindBundleByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) (*entity.Bundle, error)
 	ListBundles(ctx context.Context) ([]*entity.Bundle, error)
 
+	CreateBundleVersion(ctx context.Context, req *entity.BundleVersion) (*entity.BundleVersion, error)
+	FindBundleVersion(ctx context.Context, trustDomainID uuid.UUID, version int64) (*entity.BundleVersion, error)
+	FindBundleVersionByDigest(ctx context.Context, trustDomainID uuid.UUID, digest []byte) (*entity.BundleVersion, error)
+	ListBundleVersions(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.BundleVersion, error)
+
+	AcquireHarvesterLease(ctx context.Context, req *entity.HarvesterLease, now time.Time) (*entity.HarvesterLease, error)
+	FindHarvesterLease(ctx context.Context, trustDomainID uuid.UUID) (*entity.HarvesterLease, error)
+	DeleteHarvesterLease(ctx context.Context, trustDomainID uuid.UUID, instanceID string) error
+
+	CreateOrUpdateBundleResetApproval(ctx context.Context, req *entity.BundleResetApproval) (*entity.BundleResetApproval, error)
+	FindBundleResetApproval(ctx context.Context, trustDomainID uuid.UUID) (*entity.BundleResetApproval, error)
+	DeleteBundleResetApproval(ctx context.Context, trustDomainID uuid.UUID) error
+
+	CreateOrUpdateSigningKey(ctx context.Context, req *entity.SigningKey) (*entity.SigningKey, error)
+	FindSigningKeyByID(ctx context.Context, id string) (*entity.SigningKey, error)
+	ListSigningKeys(ctx context.Context) ([]*entity.SigningKey, error)
+	DeleteSigningKey(ctx context.Context, id string) error
+
+	AcquireServerLease(ctx context.Context, req *entity.ServerLease, now time.Time) (*entity.ServerLease, error)
+	DeleteServerLease(ctx context.Context, name, holderID string) error
+
 	CreateJoinToken(ctx context.Context, req *entity.JoinToken) (*entity.JoinToken, error)
 	DeleteJoinToken(ctx context.Context, joinTokenID uuid.UUID) error
 	FindJoinToken(ctx context.Context, token string) (*entity.JoinToken, error)
 	FindJoinTokensByID(ctx context.Context, joinTokenID uuid.UUID) (*entity.JoinToken, error)
 	UpdateJoinToken(ctx context.Context, joinTokenID uuid.UUID, used bool) (*entity.JoinToken, error)
+	UseJoinToken(ctx context.Context, joinTokenID uuid.UUID) (*entity.JoinToken, error)
 	FindJoinTokensByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.JoinToken, error)
 	ListJoinTokens(ctx context.Context) ([]*entity.JoinToken, error)
+	PruneJoinTokens(ctx context.Context, expiredBefore time.Time) error
+
+	CreateOnboardChallenge(ctx context.Context, req *entity.OnboardChallenge) (*entity.OnboardChallenge, error)
+	UseOnboardChallenge(ctx context.Context, nonce string, trustDomainID uuid.UUID, now time.Time) (*entity.OnboardChallenge, error)
+	PruneOnboardChallenges(ctx context.Context, expiredBefore time.Time) error
 
 	CreateOrUpdateRelationship(ctx context.Context, req *entity.Relationship) (*entity.Relationship, error)
 	DeleteRelationship(ctx context.Context, relationshipID uuid.UUID) error
 	FindRelationshipByID(ctx context.Context, relationshipID uuid.UUID) (*entity.Relationship, error)
 	FindRelationshipsByTrustDomainID(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.Relationship, error)
 	ListRelationships(ctx context.Context, criteria *criteria.ListRelationshipsCriteria) ([]*entity.Relationship, error)
+
+	CreateOrUpdateRelationshipConsent(ctx context.Context, req *entity.RelationshipConsent) (*entity.RelationshipConsent, error)
+	FindRelationshipConsentsByRelationshipID(ctx context.Context, relationshipID uuid.UUID) ([]*entity.RelationshipConsent, error)
+	DeleteRelationshipConsent(ctx context.Context, relationshipID, trustDomainID uuid.UUID) error
+	UpdateRelationshipConsent(ctx context.Context, req *entity.Relationship, trustDomainID uuid.UUID, consent *entity.RelationshipConsent) (*entity.Relationship, error)
+
+	ListChangeEvents(ctx context.Context, afterID, untilID int64) ([]*entity.ChangeEvent, error)
+	FindChangeEventRange(ctx context.Context) (oldestID, latestID int64, err error)
+	PruneChangeEvents(ctx context.Context, before time.Time) error
+
+	AppendAuditEvent(ctx context.Context, req *entity.AuditEvent) (*entity.AuditEvent, error)
+	ListAuditEvents(ctx context.Context, criteria *criteria.ListAuditEventsCriteria) ([]*entity.AuditEvent, error)
+
+	Ping(ctx context.Context) error
 }
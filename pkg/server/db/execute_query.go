@@ -19,7 +19,7 @@ func ExecuteListRelationshipsQuery(ctx context.Context, db *sql.DB, listCriteria
 		query = applyPaginationAndOrder(query, listCriteria)
 	}
 
-	return buildAndExecute(ctx, db, query)
+	return buildAndExecute(ctx, db, "ListRelationshipsByCriteria", query)
 }
 
 // ExecuteListTrustDomainQuery executes a query to retrieve trust domains from the database based on the provided criteria.
@@ -31,7 +31,23 @@ func ExecuteListTrustDomainQuery(ctx context.Context, db *sql.DB, listCriteria *
 		query = applyPaginationAndOrder(query, listCriteria)
 	}
 
-	return buildAndExecute(ctx, db, query)
+	return buildAndExecute(ctx, db, "ListTrustDomainsByCriteria", query)
+}
+
+// ExecuteListAuditEventsQuery executes a query to retrieve audit events from the database based on the provided criteria,
+// ordered by ID.
+func ExecuteListAuditEventsQuery(ctx context.Context, db *sql.DB, listCriteria *criteria.ListAuditEventsCriteria, dbType dbtypes.Engine) (*sql.Rows, error) {
+	query := squirrel.Select("*").From("audit_events").OrderBy("id")
+	if dbType == dbtypes.PostgreSQL {
+		query = query.PlaceholderFormat(squirrel.Dollar)
+	}
+
+	if listCriteria != nil {
+		query = applyWhereClause(query, listCriteria, dbType)
+		query = applyPaginationAndOrder(query, listCriteria)
+	}
+
+	return buildAndExecute(ctx, db, "ListAuditEventsByCriteria", query)
 }
 
 func applyPaginationAndOrder(query squirrel.SelectBuilder, listCriteria criteria.QueryCriteria) squirrel.SelectBuilder {
@@ -69,13 +85,17 @@ func applyWhereClause(query squirrel.SelectBuilder, listCriteria criteria.QueryC
 	return query.Where(conditions)
 }
 
-func buildAndExecute(ctx context.Context, db *sql.DB, query squirrel.SelectBuilder) (*sql.Rows, error) {
+func buildAndExecute(ctx context.Context, db *sql.DB, name string, query squirrel.SelectBuilder) (*sql.Rows, error) {
 	toSql, args, err := query.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build SQL query: %w", err)
 	}
 
-	rows, err := db.QueryContext(ctx, toSql, args...)
+	var rows *sql.Rows
+	err = instrument(ctx, name, func(ctx context.Context) (err error) {
+		rows, err = db.QueryContext(ctx, toSql, args...)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute SQL query: %w", err)
 	}
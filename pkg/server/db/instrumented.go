@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// sqlcNamePrefix starts the queries generated by sqlc, followed by the query name.
+const sqlcNamePrefix = "-- name: "
+
+// InstrumentedDB wraps a database to record the latency of the queries generated by sqlc, labeled with their name,
+// and to trace them as spans of the request they serve.
+// It implements the DBTX interface of the sqlc generated code.
+type InstrumentedDB struct {
+	db queryer
+}
+
+// queryer is implemented by both a database and a transaction.
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// NewInstrumentedDB returns the database wrapped to record the latency of the queries.
+func NewInstrumentedDB(db *sql.DB) *InstrumentedDB {
+	return &InstrumentedDB{db: db}
+}
+
+// NewInstrumentedTx returns the transaction wrapped to record the latency of the queries.
+func NewInstrumentedTx(tx *sql.Tx) *InstrumentedDB {
+	return &InstrumentedDB{db: tx}
+}
+
+func (d *InstrumentedDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	var result sql.Result
+	err := instrument(ctx, queryName(query), func(ctx context.Context) (err error) {
+		result, err = d.db.ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+func (d *InstrumentedDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return d.db.PrepareContext(ctx, query)
+}
+
+func (d *InstrumentedDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := instrument(ctx, queryName(query), func(ctx context.Context) (err error) {
+		rows, err = d.db.QueryContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+func (d *InstrumentedDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	var row *sql.Row
+	_ = instrument(ctx, queryName(query), func(ctx context.Context) error {
+		row = d.db.QueryRowContext(ctx, query, args...)
+		return row.Err()
+	})
+	return row
+}
+
+// instrument runs the query in a span named after it, and records its latency.
+func instrument(ctx context.Context, name string, query func(context.Context) error) error {
+	ctx, span := telemetry.Tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String(telemetry.QueryAttribute, name)))
+	defer span.End()
+
+	start := time.Now()
+	err := query(ctx)
+	telemetry.ObserveDatastoreQuery(name, err, time.Since(start))
+	telemetry.RecordError(span, err)
+
+	return err
+}
+
+// queryName returns the name of a query generated by sqlc, or "other" for the queries built at runtime.
+func queryName(query string) string {
+	if !strings.HasPrefix(query, sqlcNamePrefix) {
+		return "other"
+	}
+
+	name, _, _ := strings.Cut(strings.TrimPrefix(query, sqlcNamePrefix), " ")
+	return name
+}
@@ -0,0 +1,12 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryName(t *testing.T) {
+	assert.Equal(t, "FindTrustDomainByName", queryName("-- name: FindTrustDomainByName :one\nSELECT * FROM trust_domains WHERE name = $1"))
+	assert.Equal(t, "other", queryName("SELECT * FROM trust_domains"))
+}
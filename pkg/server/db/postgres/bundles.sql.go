@@ -7,15 +7,16 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/jackc/pgtype"
 )
 
 const createBundle = `-- name: CreateBundle :one
-INSERT INTO bundles(data, digest, signature, signing_certificate_chain, trust_domain_id, created_at)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at
+INSERT INTO bundles(data, digest, signature, signing_certificate_chain, trust_domain_id, pinned_version, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at, pinned_version
 `
 
 type CreateBundleParams struct {
@@ -24,6 +25,7 @@ type CreateBundleParams struct {
 	Signature               []byte
 	SigningCertificateChain []byte
 	TrustDomainID           pgtype.UUID
+	PinnedVersion           sql.NullInt64
 	CreatedAt               time.Time
 }
 
@@ -34,6 +36,7 @@ func (q *Queries) CreateBundle(ctx context.Context, arg CreateBundleParams) (Bun
 		arg.Signature,
 		arg.SigningCertificateChain,
 		arg.TrustDomainID,
+		arg.PinnedVersion,
 		arg.CreatedAt,
 	)
 	var i Bundle
@@ -46,6 +49,7 @@ func (q *Queries) CreateBundle(ctx context.Context, arg CreateBundleParams) (Bun
 		&i.SigningCertificateChain,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PinnedVersion,
 	)
 	return i, err
 }
@@ -62,7 +66,7 @@ func (q *Queries) DeleteBundle(ctx context.Context, id pgtype.UUID) error {
 }
 
 const findBundleByID = `-- name: FindBundleByID :one
-SELECT id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at
+SELECT id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at, pinned_version
 FROM bundles
 WHERE id = $1
 `
@@ -79,12 +83,13 @@ func (q *Queries) FindBundleByID(ctx context.Context, id pgtype.UUID) (Bundle, e
 		&i.SigningCertificateChain,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PinnedVersion,
 	)
 	return i, err
 }
 
 const findBundleByTrustDomainID = `-- name: FindBundleByTrustDomainID :one
-SELECT id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at
+SELECT id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at, pinned_version
 FROM bundles
 WHERE trust_domain_id = $1
 `
@@ -101,12 +106,13 @@ func (q *Queries) FindBundleByTrustDomainID(ctx context.Context, trustDomainID p
 		&i.SigningCertificateChain,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PinnedVersion,
 	)
 	return i, err
 }
 
 const listBundles = `-- name: ListBundles :many
-SELECT id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at
+SELECT id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at, pinned_version
 FROM bundles
 ORDER BY created_at DESC
 `
@@ -129,6 +135,7 @@ func (q *Queries) ListBundles(ctx context.Context) ([]Bundle, error) {
 			&i.SigningCertificateChain,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PinnedVersion,
 		); err != nil {
 			return nil, err
 		}
@@ -149,9 +156,10 @@ SET data                = $2,
     digest              = $3,
     signature           = $4,
     signing_certificate_chain = $5,
+    pinned_version      = $6,
     updated_at          = now()
 WHERE id = $1
-RETURNING id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at
+RETURNING id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at, pinned_version
 `
 
 type UpdateBundleParams struct {
@@ -160,6 +168,7 @@ type UpdateBundleParams struct {
 	Digest                  []byte
 	Signature               []byte
 	SigningCertificateChain []byte
+	PinnedVersion           sql.NullInt64
 }
 
 func (q *Queries) UpdateBundle(ctx context.Context, arg UpdateBundleParams) (Bundle, error) {
@@ -169,6 +178,7 @@ func (q *Queries) UpdateBundle(ctx context.Context, arg UpdateBundleParams) (Bun
 		arg.Digest,
 		arg.Signature,
 		arg.SigningCertificateChain,
+		arg.PinnedVersion,
 	)
 	var i Bundle
 	err := row.Scan(
@@ -180,6 +190,7 @@ func (q *Queries) UpdateBundle(ctx context.Context, arg UpdateBundleParams) (Bun
 		&i.SigningCertificateChain,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PinnedVersion,
 	)
 	return i, err
 }
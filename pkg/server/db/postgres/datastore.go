@@ -11,7 +11,9 @@ import (
 	"github.com/HewlettPackard/galadriel/pkg/server/db/criteria"
 	"github.com/HewlettPackard/galadriel/pkg/server/db/dbtypes"
 	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -20,6 +22,16 @@ import (
 
 const driverName = "postgresql"
 
+// uniqueViolationCode is the PostgreSQL error code of a unique constraint violation.
+const uniqueViolationCode = "23505"
+
+// maxAppendAuditEventAttempts bounds the attempts to append an audit event while other events are appended concurrently.
+const maxAppendAuditEventAttempts = 5
+
+// maxCreateBundleVersionAttempts bounds the attempts to create a bundle version while other versions of the same
+// trust domain are created concurrently.
+const maxCreateBundleVersionAttempts = 5
+
 // Datastore is a SQL database accessor that provides convenient methods
 // to perform CRUD operations for Galadriel entities.
 // It implements the Datastore interface.
@@ -46,7 +58,7 @@ func NewDatastore(connString string, log logrus.FieldLogger) (*Datastore, error)
 
 	return &Datastore{
 		db:      openDB,
-		querier: New(openDB),
+		querier: New(db.NewInstrumentedDB(openDB)),
 	}, nil
 }
 
@@ -86,11 +98,17 @@ func (d *Datastore) DeleteTrustDomain(ctx context.Context, trustDomainID uuid.UU
 		return err
 	}
 
-	if err = d.querier.DeleteTrustDomain(ctx, pgID); err != nil {
-		return fmt.Errorf("failed deleting trust domain with ID=%q: %w", trustDomainID, err)
-	}
+	return d.withTx(ctx, func(q Querier) error {
+		if err := recordTrustDomainChange(ctx, q, entity.ChangeEventKindTrustDomain, pgID); err != nil {
+			return fmt.Errorf("failed recording deletion of trust domain with ID=%q: %w", trustDomainID, err)
+		}
 
-	return nil
+		if err := q.DeleteTrustDomain(ctx, pgID); err != nil {
+			return fmt.Errorf("failed deleting trust domain with ID=%q: %w", trustDomainID, err)
+		}
+
+		return nil
+	})
 }
 
 func (d *Datastore) ListTrustDomains(ctx context.Context, criteria *criteria.ListTrustDomainsCriteria) ([]*entity.TrustDomain, error) {
@@ -103,7 +121,7 @@ func (d *Datastore) ListTrustDomains(ctx context.Context, criteria *criteria.Lis
 	var domains []TrustDomain
 	for rows.Next() {
 		var d TrustDomain
-		if err := rows.Scan(&d.ID, &d.Name, &d.Description, &d.CreatedAt, &d.UpdatedAt); err != nil {
+		if err := rows.Scan(&d.ID, &d.Name, &d.Description, &d.CreatedAt, &d.UpdatedAt, &d.BundleVerifier); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 		domains = append(domains, d)
@@ -153,12 +171,23 @@ func (d *Datastore) FindTrustDomainByName(ctx context.Context, name spiffeid.Tru
 
 func (d *Datastore) CreateOrUpdateBundle(ctx context.Context, req *entity.Bundle) (*entity.Bundle, error) {
 	var bundle *Bundle
-	var err error
-	if req.ID.Valid {
-		bundle, err = d.updateBundle(ctx, req)
-	} else {
-		bundle, err = d.createBundle(ctx, req)
-	}
+	err := d.withTx(ctx, func(q Querier) error {
+		var err error
+		if req.ID.Valid {
+			bundle, err = d.updateBundle(ctx, q, req)
+		} else {
+			bundle, err = d.createBundle(ctx, q, req)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err = recordTrustDomainChange(ctx, q, entity.ChangeEventKindBundle, bundle.TrustDomainID); err != nil {
+			return fmt.Errorf("failed recording change of bundle for trust domain ID=%q: %w", req.TrustDomainID, err)
+		}
+
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -239,8 +268,317 @@ func (d *Datastore) DeleteBundle(ctx context.Context, bundleID uuid.UUID) error
 		return err
 	}
 
-	if err = d.querier.DeleteBundle(ctx, pgID); err != nil {
-		return fmt.Errorf("failed deleting bundle with ID=%q: %w", bundleID, err)
+	return d.withTx(ctx, func(q Querier) error {
+		if err := q.CreateBundleChangeEvent(ctx, CreateBundleChangeEventParams{CreatedAt: time.Now().UTC(), ID: pgID}); err != nil {
+			return fmt.Errorf("failed recording deletion of bundle with ID=%q: %w", bundleID, err)
+		}
+
+		if err := q.DeleteBundle(ctx, pgID); err != nil {
+			return fmt.Errorf("failed deleting bundle with ID=%q: %w", bundleID, err)
+		}
+
+		return nil
+	})
+}
+
+// CreateBundleVersion appends the given bundle to the history of bundles of its trust domain.
+// The version number is assigned by the datastore, incrementing the latest version of the trust domain.
+func (d *Datastore) CreateBundleVersion(ctx context.Context, req *entity.BundleVersion) (*entity.BundleVersion, error) {
+	pgTrustDomainID, err := uuidToPgType(req.TrustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := CreateBundleVersionParams{
+		TrustDomainID:           pgTrustDomainID,
+		Data:                    req.Data,
+		Digest:                  req.Digest,
+		Signature:               req.Signature,
+		SigningCertificateChain: req.SigningCertificateChain,
+		UploadedBy:              req.UploadedBy,
+	}
+
+	for attempt := 0; attempt < maxCreateBundleVersionAttempts; attempt++ {
+		bundleVersion, err := d.querier.CreateBundleVersion(ctx, params)
+		switch {
+		case isUniqueViolation(err):
+			// another version of the trust domain took the version number in the meantime
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("failed creating bundle version: %w", err)
+		}
+
+		return bundleVersion.ToEntity(), nil
+	}
+
+	return nil, fmt.Errorf("failed creating bundle version after %d attempts", maxCreateBundleVersionAttempts)
+}
+
+func (d *Datastore) FindBundleVersion(ctx context.Context, trustDomainID uuid.UUID, version int64) (*entity.BundleVersion, error) {
+	pgID, err := uuidToPgType(trustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := FindBundleVersionParams{
+		TrustDomainID: pgID,
+		Version:       version,
+	}
+
+	bundleVersion, err := d.querier.FindBundleVersion(ctx, params)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed looking up bundle version %d for trust domain ID=%q: %w", version, trustDomainID, err)
+	}
+
+	return bundleVersion.ToEntity(), nil
+}
+
+// FindBundleVersionByDigest returns the latest version of the trust domain bundle history with the given digest.
+func (d *Datastore) FindBundleVersionByDigest(ctx context.Context, trustDomainID uuid.UUID, digest []byte) (*entity.BundleVersion, error) {
+	pgID, err := uuidToPgType(trustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := FindBundleVersionByDigestParams{
+		TrustDomainID: pgID,
+		Digest:        digest,
+	}
+
+	bundleVersion, err := d.querier.FindBundleVersionByDigest(ctx, params)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed looking up bundle version by digest for trust domain ID=%q: %w", trustDomainID, err)
+	}
+
+	return bundleVersion.ToEntity(), nil
+}
+
+// ListBundleVersions returns the bundle history of the given trust domain, latest version first.
+func (d *Datastore) ListBundleVersions(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.BundleVersion, error) {
+	pgID, err := uuidToPgType(trustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	bundleVersions, err := d.querier.ListBundleVersions(ctx, pgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting bundle versions list: %w", err)
+	}
+
+	result := make([]*entity.BundleVersion, len(bundleVersions))
+	for i, m := range bundleVersions {
+		result[i] = m.ToEntity()
+	}
+
+	return result, nil
+}
+
+// AcquireHarvesterLease acquires or renews the lease of the trust domain for the Harvester instance of the request.
+// The lease is only granted if it is free, held by the same instance, or expired at the given time.
+// It returns nil if the lease is held by another instance.
+func (d *Datastore) AcquireHarvesterLease(ctx context.Context, req *entity.HarvesterLease, now time.Time) (*entity.HarvesterLease, error) {
+	pgTrustDomainID, err := uuidToPgType(req.TrustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := AcquireHarvesterLeaseParams{
+		TrustDomainID: pgTrustDomainID,
+		InstanceID:    req.InstanceID,
+		ExpiresAt:     req.ExpiresAt,
+		UpdatedAt:     now,
+	}
+
+	lease, err := d.querier.AcquireHarvesterLease(ctx, params)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed acquiring harvester lease for trust domain ID=%q: %w", req.TrustDomainID, err)
+	}
+
+	return lease.ToEntity(), nil
+}
+
+func (d *Datastore) FindHarvesterLease(ctx context.Context, trustDomainID uuid.UUID) (*entity.HarvesterLease, error) {
+	pgID, err := uuidToPgType(trustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	lease, err := d.querier.FindHarvesterLease(ctx, pgID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed looking up harvester lease for trust domain ID=%q: %w", trustDomainID, err)
+	}
+
+	return lease.ToEntity(), nil
+}
+
+// DeleteHarvesterLease releases the lease of the trust domain if it is held by the given Harvester instance.
+func (d *Datastore) DeleteHarvesterLease(ctx context.Context, trustDomainID uuid.UUID, instanceID string) error {
+	pgID, err := uuidToPgType(trustDomainID)
+	if err != nil {
+		return err
+	}
+
+	params := DeleteHarvesterLeaseParams{
+		TrustDomainID: pgID,
+		InstanceID:    instanceID,
+	}
+
+	if err := d.querier.DeleteHarvesterLease(ctx, params); err != nil {
+		return fmt.Errorf("failed deleting harvester lease for trust domain ID=%q: %w", trustDomainID, err)
+	}
+
+	return nil
+}
+
+// CreateOrUpdateBundleResetApproval stores the approval to reset the bundle of the trust domain,
+// renewing the approval if it exists.
+func (d *Datastore) CreateOrUpdateBundleResetApproval(ctx context.Context, req *entity.BundleResetApproval) (*entity.BundleResetApproval, error) {
+	pgTrustDomainID, err := uuidToPgType(req.TrustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := CreateOrUpdateBundleResetApprovalParams{
+		TrustDomainID: pgTrustDomainID,
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	approval, err := d.querier.CreateOrUpdateBundleResetApproval(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed storing bundle reset approval for trust domain ID=%q: %w", req.TrustDomainID, err)
+	}
+
+	return approval.ToEntity(), nil
+}
+
+func (d *Datastore) FindBundleResetApproval(ctx context.Context, trustDomainID uuid.UUID) (*entity.BundleResetApproval, error) {
+	pgID, err := uuidToPgType(trustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	approval, err := d.querier.FindBundleResetApproval(ctx, pgID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed looking up bundle reset approval for trust domain ID=%q: %w", trustDomainID, err)
+	}
+
+	return approval.ToEntity(), nil
+}
+
+func (d *Datastore) DeleteBundleResetApproval(ctx context.Context, trustDomainID uuid.UUID) error {
+	pgID, err := uuidToPgType(trustDomainID)
+	if err != nil {
+		return err
+	}
+
+	if err := d.querier.DeleteBundleResetApproval(ctx, pgID); err != nil {
+		return fmt.Errorf("failed deleting bundle reset approval for trust domain ID=%q: %w", trustDomainID, err)
+	}
+
+	return nil
+}
+
+// CreateOrUpdateSigningKey stores the signing key, overwriting the key with the same ID if it exists.
+func (d *Datastore) CreateOrUpdateSigningKey(ctx context.Context, req *entity.SigningKey) (*entity.SigningKey, error) {
+	now := time.Now().UTC()
+	if !req.CreatedAt.IsZero() {
+		now = req.CreatedAt
+	}
+
+	params := CreateOrUpdateSigningKeyParams{
+		ID:           req.ID,
+		EncryptedKey: req.EncryptedKey,
+		CreatedAt:    now,
+	}
+
+	signingKey, err := d.querier.CreateOrUpdateSigningKey(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed storing signing key ID=%q: %w", req.ID, err)
+	}
+
+	return signingKey.ToEntity(), nil
+}
+
+func (d *Datastore) FindSigningKeyByID(ctx context.Context, id string) (*entity.SigningKey, error) {
+	signingKey, err := d.querier.FindSigningKeyByID(ctx, id)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed looking up signing key ID=%q: %w", id, err)
+	}
+
+	return signingKey.ToEntity(), nil
+}
+
+func (d *Datastore) DeleteSigningKey(ctx context.Context, id string) error {
+	if err := d.querier.DeleteSigningKey(ctx, id); err != nil {
+		return fmt.Errorf("failed deleting signing key ID=%q: %w", id, err)
+	}
+
+	return nil
+}
+
+func (d *Datastore) ListSigningKeys(ctx context.Context) ([]*entity.SigningKey, error) {
+	signingKeys, err := d.querier.ListSigningKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting signing keys list: %w", err)
+	}
+
+	result := make([]*entity.SigningKey, len(signingKeys))
+	for i, m := range signingKeys {
+		result[i] = m.ToEntity()
+	}
+
+	return result, nil
+}
+
+// AcquireServerLease acquires or renews the named lease for the Galadriel Server replica of the request.
+// The lease is only granted if it is free, held by the same replica, or expired at the given time.
+// It returns nil if the lease is held by another replica.
+func (d *Datastore) AcquireServerLease(ctx context.Context, req *entity.ServerLease, now time.Time) (*entity.ServerLease, error) {
+	params := AcquireServerLeaseParams{
+		Name:      req.Name,
+		HolderID:  req.HolderID,
+		ExpiresAt: req.ExpiresAt,
+		UpdatedAt: now,
+	}
+
+	lease, err := d.querier.AcquireServerLease(ctx, params)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed acquiring server lease %q: %w", req.Name, err)
+	}
+
+	return lease.ToEntity(), nil
+}
+
+// DeleteServerLease releases the named lease if it is held by the given Galadriel Server replica.
+func (d *Datastore) DeleteServerLease(ctx context.Context, name, holderID string) error {
+	params := DeleteServerLeaseParams{
+		Name:     name,
+		HolderID: holderID,
+	}
+
+	if err := d.querier.DeleteServerLease(ctx, params); err != nil {
+		return fmt.Errorf("failed deleting server lease %q: %w", name, err)
 	}
 
 	return nil
@@ -252,10 +590,35 @@ func (d *Datastore) CreateJoinToken(ctx context.Context, req *entity.JoinToken)
 		return nil, err
 	}
 
+	// only the salted hash of the token is stored
+	hashed := *req
+	if err := hashed.HashToken(); err != nil {
+		return nil, err
+	}
+
 	params := CreateJoinTokenParams{
-		Token:         req.Token,
+		TokenHash:     hashed.TokenHash,
+		TokenSalt:     hashed.TokenSalt,
+		TokenHint:     hashed.TokenHint,
 		ExpiresAt:     req.ExpiresAt,
 		TrustDomainID: pgID,
+		CreatedAt:     req.CreatedAt,
+		MaxUses:       req.MaxUses,
+	}
+	if params.MaxUses == 0 {
+		params.MaxUses = 1
+	}
+	if req.HarvesterKeyFingerprint != "" {
+		params.HarvesterKeyFingerprint = sql.NullString{
+			String: req.HarvesterKeyFingerprint,
+			Valid:  true,
+		}
+	}
+	if req.SourceCIDR != "" {
+		params.SourceCidr = sql.NullString{
+			String: req.SourceCIDR,
+			Valid:  true,
+		}
 	}
 	joinToken, err := d.querier.CreateJoinToken(ctx, params)
 	if err != nil {
@@ -337,6 +700,24 @@ func (d *Datastore) UpdateJoinToken(ctx context.Context, joinTokenID uuid.UUID,
 	return jt.ToEntity(), nil
 }
 
+// UseJoinToken counts a use of the join token, and returns it, or nil if it was already used as many times as allowed.
+func (d *Datastore) UseJoinToken(ctx context.Context, joinTokenID uuid.UUID) (*entity.JoinToken, error) {
+	pgID, err := uuidToPgType(joinTokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	jt, err := d.querier.UseJoinToken(ctx, pgID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed using join token with ID=%q: %w", joinTokenID, err)
+	}
+
+	return jt.ToEntity(), nil
+}
+
 func (d *Datastore) DeleteJoinToken(ctx context.Context, joinTokenID uuid.UUID) error {
 	pgID, err := uuidToPgType(joinTokenID)
 	if err != nil {
@@ -350,26 +731,98 @@ func (d *Datastore) DeleteJoinToken(ctx context.Context, joinTokenID uuid.UUID)
 	return nil
 }
 
+// PruneJoinTokens deletes the join tokens that expired before the given time.
+func (d *Datastore) PruneJoinTokens(ctx context.Context, expiredBefore time.Time) error {
+	if err := d.querier.DeleteJoinTokensExpiredBefore(ctx, expiredBefore); err != nil {
+		return fmt.Errorf("failed pruning join tokens: %w", err)
+	}
+
+	return nil
+}
+
+// FindJoinToken looks up the join token by its hint, and compares the hash of the token
+// with the hashes of the join tokens sharing the hint.
 func (d *Datastore) FindJoinToken(ctx context.Context, token string) (*entity.JoinToken, error) {
-	joinToken, err := d.querier.FindJoinToken(ctx, token)
+	candidates, err := d.querier.FindJoinTokensByHint(ctx, entity.JoinTokenHint(token))
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up join token: %w", err)
+	}
+
+	for _, candidate := range candidates {
+		joinToken := candidate.ToEntity()
+		if joinToken.MatchesToken(token) {
+			return joinToken, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// CreateOnboardChallenge stores the challenge issued to the Harvester of the trust domain.
+func (d *Datastore) CreateOnboardChallenge(ctx context.Context, req *entity.OnboardChallenge) (*entity.OnboardChallenge, error) {
+	pgTrustDomainID, err := uuidToPgType(req.TrustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := CreateOnboardChallengeParams{
+		Nonce:         req.Nonce,
+		TrustDomainID: pgTrustDomainID,
+		ExpiresAt:     req.ExpiresAt,
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	if err := d.querier.CreateOnboardChallenge(ctx, params); err != nil {
+		return nil, fmt.Errorf("failed creating onboard challenge for trust domain ID=%q: %w", req.TrustDomainID, err)
+	}
+
+	challenge := *req
+	challenge.CreatedAt = params.CreatedAt
+
+	return &challenge, nil
+}
+
+// UseOnboardChallenge deletes the challenge issued to the Harvester of the trust domain, if it did not expire at now.
+// It returns nil if the challenge is unknown, expired or was already used, so that a challenge can only be used once.
+func (d *Datastore) UseOnboardChallenge(ctx context.Context, nonce string, trustDomainID uuid.UUID, now time.Time) (*entity.OnboardChallenge, error) {
+	pgTrustDomainID, err := uuidToPgType(trustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := UseOnboardChallengeParams{
+		Nonce:         nonce,
+		TrustDomainID: pgTrustDomainID,
+		ExpiresAt:     now,
+	}
+
+	challenge, err := d.querier.UseOnboardChallenge(ctx, params)
 	switch {
 	case errors.Is(err, sql.ErrNoRows):
 		return nil, nil
 	case err != nil:
-		return nil, fmt.Errorf("failed looking up join token: %w", err)
+		return nil, fmt.Errorf("failed using onboard challenge of trust domain ID=%q: %w", trustDomainID, err)
 	}
 
-	return joinToken.ToEntity(), nil
+	return challenge.ToEntity(), nil
+}
+
+// PruneOnboardChallenges deletes the onboard challenges that expired before the given time.
+func (d *Datastore) PruneOnboardChallenges(ctx context.Context, expiredBefore time.Time) error {
+	if err := d.querier.DeleteOnboardChallengesExpiredBefore(ctx, expiredBefore); err != nil {
+		return fmt.Errorf("failed pruning onboard challenges: %w", err)
+	}
+
+	return nil
 }
 
 func (d *Datastore) CreateOrUpdateRelationship(ctx context.Context, req *entity.Relationship) (*entity.Relationship, error) {
 	var relationship *Relationship
-	var err error
-	if req.ID.Valid {
-		relationship, err = d.updateRelationship(ctx, req)
-	} else {
-		relationship, err = d.createRelationship(ctx, req)
-	}
+	err := d.withTx(ctx, func(q Querier) error {
+		var err error
+		relationship, err = d.storeRelationship(ctx, q, req)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -467,13 +920,292 @@ func (d *Datastore) DeleteRelationship(ctx context.Context, relationshipID uuid.
 		return err
 	}
 
-	if err = d.querier.DeleteRelationship(ctx, pgID); err != nil {
-		return fmt.Errorf("failed deleting relationship ID=%q: %w", relationshipID, err)
+	return d.withTx(ctx, func(q Querier) error {
+		if err := q.CreateRelationshipChangeEvent(ctx, CreateRelationshipChangeEventParams{CreatedAt: time.Now().UTC(), ID: pgID}); err != nil {
+			return fmt.Errorf("failed recording deletion of relationship ID=%q: %w", relationshipID, err)
+		}
+
+		if err := q.DeleteRelationship(ctx, pgID); err != nil {
+			return fmt.Errorf("failed deleting relationship ID=%q: %w", relationshipID, err)
+		}
+
+		return nil
+	})
+}
+
+// CreateOrUpdateRelationshipConsent stores the signed consent of the trust domain to the relationship,
+// replacing the consent it signed before.
+func (d *Datastore) CreateOrUpdateRelationshipConsent(ctx context.Context, req *entity.RelationshipConsent) (*entity.RelationshipConsent, error) {
+	return storeRelationshipConsent(ctx, d.querier, req)
+}
+
+func (d *Datastore) FindRelationshipConsentsByRelationshipID(ctx context.Context, relationshipID uuid.UUID) ([]*entity.RelationshipConsent, error) {
+	pgID, err := uuidToPgType(relationshipID)
+	if err != nil {
+		return nil, err
+	}
+
+	consents, err := d.querier.FindRelationshipConsentsByRelationshipID(ctx, pgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up consents to relationship ID=%q: %w", relationshipID, err)
+	}
+
+	result := make([]*entity.RelationshipConsent, len(consents))
+	for i, m := range consents {
+		result[i] = m.ToEntity()
+	}
+
+	return result, nil
+}
+
+func (d *Datastore) DeleteRelationshipConsent(ctx context.Context, relationshipID, trustDomainID uuid.UUID) error {
+	return removeRelationshipConsent(ctx, d.querier, relationshipID, trustDomainID)
+}
+
+// UpdateRelationshipConsent stores the relationship with the updated consent status of the trust domain, along with
+// the consent it signed, in a single transaction. When the consent is nil, the consent the trust domain signed before
+// is deleted, so that the stored consent always matches the consent status.
+func (d *Datastore) UpdateRelationshipConsent(ctx context.Context, req *entity.Relationship, trustDomainID uuid.UUID, consent *entity.RelationshipConsent) (*entity.Relationship, error) {
+	var relationship *Relationship
+	err := d.withTx(ctx, func(q Querier) error {
+		var err error
+		if relationship, err = d.storeRelationship(ctx, q, req); err != nil {
+			return err
+		}
+
+		if consent != nil {
+			_, err = storeRelationshipConsent(ctx, q, consent)
+			return err
+		}
+
+		return removeRelationshipConsent(ctx, q, req.ID.UUID, trustDomainID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := relationship.ToEntity()
+	if err != nil {
+		return nil, fmt.Errorf("failed converting relationship model to entity: %w", err)
+	}
+
+	return response, nil
+}
+
+// ListChangeEvents returns the changes recorded after afterID, up to untilID included, ordered by ID.
+func (d *Datastore) ListChangeEvents(ctx context.Context, afterID, untilID int64) ([]*entity.ChangeEvent, error) {
+	events, err := d.querier.ListChangeEvents(ctx, ListChangeEventsParams{ID: afterID, ID_2: untilID})
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up change events: %w", err)
+	}
+
+	result := make([]*entity.ChangeEvent, len(events))
+	for i, m := range events {
+		ent, err := m.ToEntity()
+		if err != nil {
+			return nil, fmt.Errorf("failed converting change event model to entity: %w", err)
+		}
+		result[i] = ent
+	}
+
+	return result, nil
+}
+
+// FindChangeEventRange returns the IDs of the oldest and the latest changes kept in the change log, 0 if it is empty.
+func (d *Datastore) FindChangeEventRange(ctx context.Context) (int64, int64, error) {
+	oldestID, err := d.querier.FindOldestChangeEventID(ctx)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return 0, 0, nil
+	case err != nil:
+		return 0, 0, fmt.Errorf("failed looking up oldest change event: %w", err)
+	}
+
+	latestID, err := d.querier.FindLatestChangeEventID(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed looking up latest change event: %w", err)
+	}
+
+	return oldestID, latestID, nil
+}
+
+// PruneChangeEvents deletes the changes recorded before the given time, except the latest one.
+func (d *Datastore) PruneChangeEvents(ctx context.Context, before time.Time) error {
+	if err := d.querier.DeleteChangeEventsBefore(ctx, before); err != nil {
+		return fmt.Errorf("failed pruning change events: %w", err)
+	}
+
+	return nil
+}
+
+// AppendAuditEvent appends the event to the hash chain of the audit log, setting its creation time,
+// its previous hash and its hash. When another event is appended concurrently to the same previous event,
+// the event is chained to the new latest event.
+func (d *Datastore) AppendAuditEvent(ctx context.Context, req *entity.AuditEvent) (*entity.AuditEvent, error) {
+	event := *req
+	// the creation time is hashed, it is truncated to the precision of the database
+	event.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+
+	for attempt := 0; attempt < maxAppendAuditEventAttempts; attempt++ {
+		latest, err := d.querier.FindLatestAuditEvent(ctx)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			event.PrevHash = entity.GenesisAuditHash
+		case err != nil:
+			return nil, fmt.Errorf("failed looking up latest audit event: %w", err)
+		default:
+			event.PrevHash = latest.Hash
+		}
+		event.Hash = event.ComputeHash()
+
+		params := CreateAuditEventParams{
+			Actor:     event.Actor,
+			Action:    string(event.Action),
+			Target:    event.Target,
+			Details:   event.Details,
+			PrevHash:  event.PrevHash,
+			Hash:      event.Hash,
+			CreatedAt: event.CreatedAt,
+		}
+		m, err := d.querier.CreateAuditEvent(ctx, params)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			// another event was chained to the latest event in the meantime
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("failed creating audit event: %w", err)
+		}
+
+		return m.ToEntity(), nil
+	}
+
+	return nil, fmt.Errorf("failed appending audit event after %d attempts", maxAppendAuditEventAttempts)
+}
+
+// ListAuditEvents returns the audit events matching the criteria, ordered by ID.
+func (d *Datastore) ListAuditEvents(ctx context.Context, criteria *criteria.ListAuditEventsCriteria) ([]*entity.AuditEvent, error) {
+	rows, err := db.ExecuteListAuditEventsQuery(ctx, d.db, criteria, dbtypes.PostgreSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking up audit events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*entity.AuditEvent
+	for rows.Next() {
+		var m AuditEvent
+		if err := rows.Scan(&m.ID, &m.Actor, &m.Action, &m.Target, &m.Details, &m.PrevHash, &m.Hash, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		events = append(events, m.ToEntity())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed during row iteration: %w", err)
+	}
+
+	return events, nil
+}
+
+// Ping checks that the database is reachable.
+func (d *Datastore) Ping(ctx context.Context) error {
+	if err := d.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return nil
+}
+
+// recordTrustDomainChange appends a change of the given trust domain to the change log.
+func recordTrustDomainChange(ctx context.Context, q Querier, kind entity.ChangeEventKind, trustDomainID pgtype.UUID) error {
+	params := CreateTrustDomainChangeEventParams{
+		Kind:      string(kind),
+		CreatedAt: time.Now().UTC(),
+		ID:        trustDomainID,
+	}
+
+	return q.CreateTrustDomainChangeEvent(ctx, params)
+}
+
+// storeRelationshipConsent stores the signed consent of the trust domain to the relationship.
+func storeRelationshipConsent(ctx context.Context, q Querier, req *entity.RelationshipConsent) (*entity.RelationshipConsent, error) {
+	pgRelationshipID, err := uuidToPgType(req.RelationshipID)
+	if err != nil {
+		return nil, err
+	}
+
+	pgTrustDomainID, err := uuidToPgType(req.TrustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	params := CreateOrUpdateRelationshipConsentParams{
+		RelationshipID:          pgRelationshipID,
+		TrustDomainID:           pgTrustDomainID,
+		ConsentStatement:        req.ConsentStatement,
+		Signature:               req.Signature,
+		SigningCertificateChain: req.SigningCertificateChain,
+		CreatedAt:               now,
+		UpdatedAt:               now,
+	}
+
+	consent, err := q.CreateOrUpdateRelationshipConsent(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed storing consent of trust domain ID=%q to relationship ID=%q: %w", req.TrustDomainID, req.RelationshipID, err)
+	}
+
+	return consent.ToEntity(), nil
+}
+
+// removeRelationshipConsent deletes the consent of the trust domain to the relationship.
+func removeRelationshipConsent(ctx context.Context, q Querier, relationshipID, trustDomainID uuid.UUID) error {
+	pgRelationshipID, err := uuidToPgType(relationshipID)
+	if err != nil {
+		return err
+	}
+
+	pgTrustDomainID, err := uuidToPgType(trustDomainID)
+	if err != nil {
+		return err
+	}
+
+	params := DeleteRelationshipConsentParams{
+		RelationshipID: pgRelationshipID,
+		TrustDomainID:  pgTrustDomainID,
+	}
+
+	if err := q.DeleteRelationshipConsent(ctx, params); err != nil {
+		return fmt.Errorf("failed deleting consent of trust domain ID=%q to relationship ID=%q: %w", trustDomainID, relationshipID, err)
+	}
+
+	return nil
+}
+
+// withTx runs fn with a querier bound to a transaction, that is committed if fn succeeds and rolled back otherwise.
+func (d *Datastore) withTx(ctx context.Context, fn func(q Querier) error) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(New(db.NewInstrumentedTx(tx))); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
 }
 
+// isUniqueViolation returns true if the error is caused by a unique constraint violation.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
 func (d *Datastore) createTrustDomain(ctx context.Context, req *entity.TrustDomain) (*TrustDomain, error) {
 	params := CreateTrustDomainParams{
 		Name:      req.Name.String(),
@@ -486,6 +1218,13 @@ func (d *Datastore) createTrustDomain(ctx context.Context, req *entity.TrustDoma
 		}
 	}
 
+	if req.BundleVerifier != "" {
+		params.BundleVerifier = sql.NullString{
+			String: req.BundleVerifier,
+			Valid:  true,
+		}
+	}
+
 	td, err := d.querier.CreateTrustDomain(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed creating new trust domain: %w", err)
@@ -510,6 +1249,13 @@ func (d *Datastore) updateTrustDomain(ctx context.Context, req *entity.TrustDoma
 		}
 	}
 
+	if req.BundleVerifier != "" {
+		params.BundleVerifier = sql.NullString{
+			String: req.BundleVerifier,
+			Valid:  true,
+		}
+	}
+
 	td, err := d.querier.UpdateTrustDomain(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed updating trust domain: %w", err)
@@ -517,7 +1263,7 @@ func (d *Datastore) updateTrustDomain(ctx context.Context, req *entity.TrustDoma
 	return &td, nil
 }
 
-func (d *Datastore) createBundle(ctx context.Context, req *entity.Bundle) (*Bundle, error) {
+func (d *Datastore) createBundle(ctx context.Context, q Querier, req *entity.Bundle) (*Bundle, error) {
 	pgTrustDomainID, err := uuidToPgType(req.TrustDomainID)
 	if err != nil {
 		return nil, err
@@ -531,7 +1277,14 @@ func (d *Datastore) createBundle(ctx context.Context, req *entity.Bundle) (*Bund
 		CreatedAt:               req.CreatedAt,
 	}
 
-	bundle, err := d.querier.CreateBundle(ctx, params)
+	if req.PinnedVersion != 0 {
+		params.PinnedVersion = sql.NullInt64{
+			Int64: req.PinnedVersion,
+			Valid: true,
+		}
+	}
+
+	bundle, err := q.CreateBundle(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed creating new bundle: %w", err)
 	}
@@ -539,7 +1292,7 @@ func (d *Datastore) createBundle(ctx context.Context, req *entity.Bundle) (*Bund
 	return &bundle, nil
 }
 
-func (d *Datastore) updateBundle(ctx context.Context, req *entity.Bundle) (*Bundle, error) {
+func (d *Datastore) updateBundle(ctx context.Context, q Querier, req *entity.Bundle) (*Bundle, error) {
 	pgID, err := uuidToPgType(req.ID.UUID)
 	if err != nil {
 		return nil, err
@@ -552,7 +1305,14 @@ func (d *Datastore) updateBundle(ctx context.Context, req *entity.Bundle) (*Bund
 		SigningCertificateChain: req.SigningCertificateChain,
 	}
 
-	bundle, err := d.querier.UpdateBundle(ctx, params)
+	if req.PinnedVersion != 0 {
+		params.PinnedVersion = sql.NullInt64{
+			Int64: req.PinnedVersion,
+			Valid: true,
+		}
+	}
+
+	bundle, err := q.UpdateBundle(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed updating bundle: %w", err)
 	}
@@ -560,7 +1320,29 @@ func (d *Datastore) updateBundle(ctx context.Context, req *entity.Bundle) (*Bund
 	return &bundle, nil
 }
 
-func (d *Datastore) createRelationship(ctx context.Context, req *entity.Relationship) (*Relationship, error) {
+// storeRelationship creates or updates the relationship, and records its change.
+func (d *Datastore) storeRelationship(ctx context.Context, q Querier, req *entity.Relationship) (*Relationship, error) {
+	var (
+		relationship *Relationship
+		err          error
+	)
+	if req.ID.Valid {
+		relationship, err = d.updateRelationship(ctx, q, req)
+	} else {
+		relationship, err = d.createRelationship(ctx, q, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = q.CreateRelationshipChangeEvent(ctx, CreateRelationshipChangeEventParams{CreatedAt: time.Now().UTC(), ID: relationship.ID}); err != nil {
+		return nil, fmt.Errorf("failed recording change of relationship: %w", err)
+	}
+
+	return relationship, nil
+}
+
+func (d *Datastore) createRelationship(ctx context.Context, q Querier, req *entity.Relationship) (*Relationship, error) {
 	pgTrustDomainAID, err := uuidToPgType(req.TrustDomainAID)
 	if err != nil {
 		return nil, err
@@ -592,7 +1374,7 @@ func (d *Datastore) createRelationship(ctx context.Context, req *entity.Relation
 		CreatedAt:           req.CreatedAt,
 	}
 
-	relationship, err := d.querier.CreateRelationship(ctx, params)
+	relationship, err := q.CreateRelationship(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed creating new relationship: %w", err)
 	}
@@ -600,7 +1382,7 @@ func (d *Datastore) createRelationship(ctx context.Context, req *entity.Relation
 	return &relationship, nil
 }
 
-func (d *Datastore) updateRelationship(ctx context.Context, req *entity.Relationship) (*Relationship, error) {
+func (d *Datastore) updateRelationship(ctx context.Context, q Querier, req *entity.Relationship) (*Relationship, error) {
 	pgID, err := uuidToPgType(req.ID.UUID)
 	if err != nil {
 		return nil, err
@@ -612,7 +1394,7 @@ func (d *Datastore) updateRelationship(ctx context.Context, req *entity.Relation
 		TrustDomainBConsent: ConsentStatus(req.TrustDomainBConsent),
 	}
 
-	relationship, err := d.querier.UpdateRelationship(ctx, params)
+	relationship, err := q.UpdateRelationship(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed updating relationship: %w", err)
 	}
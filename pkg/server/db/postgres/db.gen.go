@@ -24,27 +24,84 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.acquireHarvesterLeaseStmt, err = db.PrepareContext(ctx, acquireHarvesterLease); err != nil {
+		return nil, fmt.Errorf("error preparing query AcquireHarvesterLease: %w", err)
+	}
+	if q.acquireServerLeaseStmt, err = db.PrepareContext(ctx, acquireServerLease); err != nil {
+		return nil, fmt.Errorf("error preparing query AcquireServerLease: %w", err)
+	}
+	if q.createAuditEventStmt, err = db.PrepareContext(ctx, createAuditEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateAuditEvent: %w", err)
+	}
 	if q.createBundleStmt, err = db.PrepareContext(ctx, createBundle); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateBundle: %w", err)
 	}
+	if q.createBundleChangeEventStmt, err = db.PrepareContext(ctx, createBundleChangeEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateBundleChangeEvent: %w", err)
+	}
+	if q.createBundleVersionStmt, err = db.PrepareContext(ctx, createBundleVersion); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateBundleVersion: %w", err)
+	}
 	if q.createJoinTokenStmt, err = db.PrepareContext(ctx, createJoinToken); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateJoinToken: %w", err)
 	}
+	if q.createOnboardChallengeStmt, err = db.PrepareContext(ctx, createOnboardChallenge); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateOnboardChallenge: %w", err)
+	}
+	if q.createOrUpdateBundleResetApprovalStmt, err = db.PrepareContext(ctx, createOrUpdateBundleResetApproval); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateOrUpdateBundleResetApproval: %w", err)
+	}
+	if q.createOrUpdateRelationshipConsentStmt, err = db.PrepareContext(ctx, createOrUpdateRelationshipConsent); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateOrUpdateRelationshipConsent: %w", err)
+	}
+	if q.createOrUpdateSigningKeyStmt, err = db.PrepareContext(ctx, createOrUpdateSigningKey); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateOrUpdateSigningKey: %w", err)
+	}
 	if q.createRelationshipStmt, err = db.PrepareContext(ctx, createRelationship); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateRelationship: %w", err)
 	}
+	if q.createRelationshipChangeEventStmt, err = db.PrepareContext(ctx, createRelationshipChangeEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateRelationshipChangeEvent: %w", err)
+	}
 	if q.createTrustDomainStmt, err = db.PrepareContext(ctx, createTrustDomain); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateTrustDomain: %w", err)
 	}
+	if q.createTrustDomainChangeEventStmt, err = db.PrepareContext(ctx, createTrustDomainChangeEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTrustDomainChangeEvent: %w", err)
+	}
 	if q.deleteBundleStmt, err = db.PrepareContext(ctx, deleteBundle); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteBundle: %w", err)
 	}
+	if q.deleteBundleResetApprovalStmt, err = db.PrepareContext(ctx, deleteBundleResetApproval); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteBundleResetApproval: %w", err)
+	}
+	if q.deleteChangeEventsBeforeStmt, err = db.PrepareContext(ctx, deleteChangeEventsBefore); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteChangeEventsBefore: %w", err)
+	}
+	if q.deleteHarvesterLeaseStmt, err = db.PrepareContext(ctx, deleteHarvesterLease); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteHarvesterLease: %w", err)
+	}
 	if q.deleteJoinTokenStmt, err = db.PrepareContext(ctx, deleteJoinToken); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteJoinToken: %w", err)
 	}
+	if q.deleteJoinTokensExpiredBeforeStmt, err = db.PrepareContext(ctx, deleteJoinTokensExpiredBefore); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteJoinTokensExpiredBefore: %w", err)
+	}
+	if q.deleteOnboardChallengesExpiredBeforeStmt, err = db.PrepareContext(ctx, deleteOnboardChallengesExpiredBefore); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteOnboardChallengesExpiredBefore: %w", err)
+	}
 	if q.deleteRelationshipStmt, err = db.PrepareContext(ctx, deleteRelationship); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteRelationship: %w", err)
 	}
+	if q.deleteRelationshipConsentStmt, err = db.PrepareContext(ctx, deleteRelationshipConsent); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteRelationshipConsent: %w", err)
+	}
+	if q.deleteServerLeaseStmt, err = db.PrepareContext(ctx, deleteServerLease); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteServerLease: %w", err)
+	}
+	if q.deleteSigningKeyStmt, err = db.PrepareContext(ctx, deleteSigningKey); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSigningKey: %w", err)
+	}
 	if q.deleteTrustDomainStmt, err = db.PrepareContext(ctx, deleteTrustDomain); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteTrustDomain: %w", err)
 	}
@@ -54,33 +111,69 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.findBundleByTrustDomainIDStmt, err = db.PrepareContext(ctx, findBundleByTrustDomainID); err != nil {
 		return nil, fmt.Errorf("error preparing query FindBundleByTrustDomainID: %w", err)
 	}
-	if q.findJoinTokenStmt, err = db.PrepareContext(ctx, findJoinToken); err != nil {
-		return nil, fmt.Errorf("error preparing query FindJoinToken: %w", err)
+	if q.findBundleResetApprovalStmt, err = db.PrepareContext(ctx, findBundleResetApproval); err != nil {
+		return nil, fmt.Errorf("error preparing query FindBundleResetApproval: %w", err)
+	}
+	if q.findBundleVersionStmt, err = db.PrepareContext(ctx, findBundleVersion); err != nil {
+		return nil, fmt.Errorf("error preparing query FindBundleVersion: %w", err)
+	}
+	if q.findBundleVersionByDigestStmt, err = db.PrepareContext(ctx, findBundleVersionByDigest); err != nil {
+		return nil, fmt.Errorf("error preparing query FindBundleVersionByDigest: %w", err)
+	}
+	if q.findHarvesterLeaseStmt, err = db.PrepareContext(ctx, findHarvesterLease); err != nil {
+		return nil, fmt.Errorf("error preparing query FindHarvesterLease: %w", err)
 	}
 	if q.findJoinTokenByIDStmt, err = db.PrepareContext(ctx, findJoinTokenByID); err != nil {
 		return nil, fmt.Errorf("error preparing query FindJoinTokenByID: %w", err)
 	}
+	if q.findJoinTokensByHintStmt, err = db.PrepareContext(ctx, findJoinTokensByHint); err != nil {
+		return nil, fmt.Errorf("error preparing query FindJoinTokensByHint: %w", err)
+	}
 	if q.findJoinTokensByTrustDomainIDStmt, err = db.PrepareContext(ctx, findJoinTokensByTrustDomainID); err != nil {
 		return nil, fmt.Errorf("error preparing query FindJoinTokensByTrustDomainID: %w", err)
 	}
+	if q.findLatestAuditEventStmt, err = db.PrepareContext(ctx, findLatestAuditEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query FindLatestAuditEvent: %w", err)
+	}
+	if q.findLatestChangeEventIDStmt, err = db.PrepareContext(ctx, findLatestChangeEventID); err != nil {
+		return nil, fmt.Errorf("error preparing query FindLatestChangeEventID: %w", err)
+	}
+	if q.findOldestChangeEventIDStmt, err = db.PrepareContext(ctx, findOldestChangeEventID); err != nil {
+		return nil, fmt.Errorf("error preparing query FindOldestChangeEventID: %w", err)
+	}
 	if q.findRelationshipByIDStmt, err = db.PrepareContext(ctx, findRelationshipByID); err != nil {
 		return nil, fmt.Errorf("error preparing query FindRelationshipByID: %w", err)
 	}
+	if q.findRelationshipConsentsByRelationshipIDStmt, err = db.PrepareContext(ctx, findRelationshipConsentsByRelationshipID); err != nil {
+		return nil, fmt.Errorf("error preparing query FindRelationshipConsentsByRelationshipID: %w", err)
+	}
 	if q.findRelationshipsByTrustDomainIDStmt, err = db.PrepareContext(ctx, findRelationshipsByTrustDomainID); err != nil {
 		return nil, fmt.Errorf("error preparing query FindRelationshipsByTrustDomainID: %w", err)
 	}
+	if q.findSigningKeyByIDStmt, err = db.PrepareContext(ctx, findSigningKeyByID); err != nil {
+		return nil, fmt.Errorf("error preparing query FindSigningKeyByID: %w", err)
+	}
 	if q.findTrustDomainByIDStmt, err = db.PrepareContext(ctx, findTrustDomainByID); err != nil {
 		return nil, fmt.Errorf("error preparing query FindTrustDomainByID: %w", err)
 	}
 	if q.findTrustDomainByNameStmt, err = db.PrepareContext(ctx, findTrustDomainByName); err != nil {
 		return nil, fmt.Errorf("error preparing query FindTrustDomainByName: %w", err)
 	}
+	if q.listBundleVersionsStmt, err = db.PrepareContext(ctx, listBundleVersions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBundleVersions: %w", err)
+	}
 	if q.listBundlesStmt, err = db.PrepareContext(ctx, listBundles); err != nil {
 		return nil, fmt.Errorf("error preparing query ListBundles: %w", err)
 	}
+	if q.listChangeEventsStmt, err = db.PrepareContext(ctx, listChangeEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query ListChangeEvents: %w", err)
+	}
 	if q.listJoinTokensStmt, err = db.PrepareContext(ctx, listJoinTokens); err != nil {
 		return nil, fmt.Errorf("error preparing query ListJoinTokens: %w", err)
 	}
+	if q.listSigningKeysStmt, err = db.PrepareContext(ctx, listSigningKeys); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSigningKeys: %w", err)
+	}
 	if q.updateBundleStmt, err = db.PrepareContext(ctx, updateBundle); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateBundle: %w", err)
 	}
@@ -93,46 +186,147 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateTrustDomainStmt, err = db.PrepareContext(ctx, updateTrustDomain); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateTrustDomain: %w", err)
 	}
+	if q.useJoinTokenStmt, err = db.PrepareContext(ctx, useJoinToken); err != nil {
+		return nil, fmt.Errorf("error preparing query UseJoinToken: %w", err)
+	}
+	if q.useOnboardChallengeStmt, err = db.PrepareContext(ctx, useOnboardChallenge); err != nil {
+		return nil, fmt.Errorf("error preparing query UseOnboardChallenge: %w", err)
+	}
 	return &q, nil
 }
 
 func (q *Queries) Close() error {
 	var err error
+	if q.acquireHarvesterLeaseStmt != nil {
+		if cerr := q.acquireHarvesterLeaseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing acquireHarvesterLeaseStmt: %w", cerr)
+		}
+	}
+	if q.acquireServerLeaseStmt != nil {
+		if cerr := q.acquireServerLeaseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing acquireServerLeaseStmt: %w", cerr)
+		}
+	}
+	if q.createAuditEventStmt != nil {
+		if cerr := q.createAuditEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createAuditEventStmt: %w", cerr)
+		}
+	}
 	if q.createBundleStmt != nil {
 		if cerr := q.createBundleStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createBundleStmt: %w", cerr)
 		}
 	}
+	if q.createBundleChangeEventStmt != nil {
+		if cerr := q.createBundleChangeEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createBundleChangeEventStmt: %w", cerr)
+		}
+	}
+	if q.createBundleVersionStmt != nil {
+		if cerr := q.createBundleVersionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createBundleVersionStmt: %w", cerr)
+		}
+	}
 	if q.createJoinTokenStmt != nil {
 		if cerr := q.createJoinTokenStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createJoinTokenStmt: %w", cerr)
 		}
 	}
+	if q.createOnboardChallengeStmt != nil {
+		if cerr := q.createOnboardChallengeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createOnboardChallengeStmt: %w", cerr)
+		}
+	}
+	if q.createOrUpdateBundleResetApprovalStmt != nil {
+		if cerr := q.createOrUpdateBundleResetApprovalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createOrUpdateBundleResetApprovalStmt: %w", cerr)
+		}
+	}
+	if q.createOrUpdateRelationshipConsentStmt != nil {
+		if cerr := q.createOrUpdateRelationshipConsentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createOrUpdateRelationshipConsentStmt: %w", cerr)
+		}
+	}
+	if q.createOrUpdateSigningKeyStmt != nil {
+		if cerr := q.createOrUpdateSigningKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createOrUpdateSigningKeyStmt: %w", cerr)
+		}
+	}
 	if q.createRelationshipStmt != nil {
 		if cerr := q.createRelationshipStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createRelationshipStmt: %w", cerr)
 		}
 	}
+	if q.createRelationshipChangeEventStmt != nil {
+		if cerr := q.createRelationshipChangeEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createRelationshipChangeEventStmt: %w", cerr)
+		}
+	}
 	if q.createTrustDomainStmt != nil {
 		if cerr := q.createTrustDomainStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createTrustDomainStmt: %w", cerr)
 		}
 	}
+	if q.createTrustDomainChangeEventStmt != nil {
+		if cerr := q.createTrustDomainChangeEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTrustDomainChangeEventStmt: %w", cerr)
+		}
+	}
 	if q.deleteBundleStmt != nil {
 		if cerr := q.deleteBundleStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteBundleStmt: %w", cerr)
 		}
 	}
+	if q.deleteBundleResetApprovalStmt != nil {
+		if cerr := q.deleteBundleResetApprovalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteBundleResetApprovalStmt: %w", cerr)
+		}
+	}
+	if q.deleteChangeEventsBeforeStmt != nil {
+		if cerr := q.deleteChangeEventsBeforeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteChangeEventsBeforeStmt: %w", cerr)
+		}
+	}
+	if q.deleteHarvesterLeaseStmt != nil {
+		if cerr := q.deleteHarvesterLeaseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteHarvesterLeaseStmt: %w", cerr)
+		}
+	}
 	if q.deleteJoinTokenStmt != nil {
 		if cerr := q.deleteJoinTokenStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteJoinTokenStmt: %w", cerr)
 		}
 	}
+	if q.deleteJoinTokensExpiredBeforeStmt != nil {
+		if cerr := q.deleteJoinTokensExpiredBeforeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteJoinTokensExpiredBeforeStmt: %w", cerr)
+		}
+	}
+	if q.deleteOnboardChallengesExpiredBeforeStmt != nil {
+		if cerr := q.deleteOnboardChallengesExpiredBeforeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteOnboardChallengesExpiredBeforeStmt: %w", cerr)
+		}
+	}
 	if q.deleteRelationshipStmt != nil {
 		if cerr := q.deleteRelationshipStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteRelationshipStmt: %w", cerr)
 		}
 	}
+	if q.deleteRelationshipConsentStmt != nil {
+		if cerr := q.deleteRelationshipConsentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteRelationshipConsentStmt: %w", cerr)
+		}
+	}
+	if q.deleteServerLeaseStmt != nil {
+		if cerr := q.deleteServerLeaseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteServerLeaseStmt: %w", cerr)
+		}
+	}
+	if q.deleteSigningKeyStmt != nil {
+		if cerr := q.deleteSigningKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSigningKeyStmt: %w", cerr)
+		}
+	}
 	if q.deleteTrustDomainStmt != nil {
 		if cerr := q.deleteTrustDomainStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteTrustDomainStmt: %w", cerr)
@@ -148,9 +342,24 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing findBundleByTrustDomainIDStmt: %w", cerr)
 		}
 	}
-	if q.findJoinTokenStmt != nil {
-		if cerr := q.findJoinTokenStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing findJoinTokenStmt: %w", cerr)
+	if q.findBundleResetApprovalStmt != nil {
+		if cerr := q.findBundleResetApprovalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findBundleResetApprovalStmt: %w", cerr)
+		}
+	}
+	if q.findBundleVersionStmt != nil {
+		if cerr := q.findBundleVersionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findBundleVersionStmt: %w", cerr)
+		}
+	}
+	if q.findBundleVersionByDigestStmt != nil {
+		if cerr := q.findBundleVersionByDigestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findBundleVersionByDigestStmt: %w", cerr)
+		}
+	}
+	if q.findHarvesterLeaseStmt != nil {
+		if cerr := q.findHarvesterLeaseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findHarvesterLeaseStmt: %w", cerr)
 		}
 	}
 	if q.findJoinTokenByIDStmt != nil {
@@ -158,21 +367,51 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing findJoinTokenByIDStmt: %w", cerr)
 		}
 	}
+	if q.findJoinTokensByHintStmt != nil {
+		if cerr := q.findJoinTokensByHintStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findJoinTokensByHintStmt: %w", cerr)
+		}
+	}
 	if q.findJoinTokensByTrustDomainIDStmt != nil {
 		if cerr := q.findJoinTokensByTrustDomainIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing findJoinTokensByTrustDomainIDStmt: %w", cerr)
 		}
 	}
+	if q.findLatestAuditEventStmt != nil {
+		if cerr := q.findLatestAuditEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findLatestAuditEventStmt: %w", cerr)
+		}
+	}
+	if q.findLatestChangeEventIDStmt != nil {
+		if cerr := q.findLatestChangeEventIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findLatestChangeEventIDStmt: %w", cerr)
+		}
+	}
+	if q.findOldestChangeEventIDStmt != nil {
+		if cerr := q.findOldestChangeEventIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findOldestChangeEventIDStmt: %w", cerr)
+		}
+	}
 	if q.findRelationshipByIDStmt != nil {
 		if cerr := q.findRelationshipByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing findRelationshipByIDStmt: %w", cerr)
 		}
 	}
+	if q.findRelationshipConsentsByRelationshipIDStmt != nil {
+		if cerr := q.findRelationshipConsentsByRelationshipIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findRelationshipConsentsByRelationshipIDStmt: %w", cerr)
+		}
+	}
 	if q.findRelationshipsByTrustDomainIDStmt != nil {
 		if cerr := q.findRelationshipsByTrustDomainIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing findRelationshipsByTrustDomainIDStmt: %w", cerr)
 		}
 	}
+	if q.findSigningKeyByIDStmt != nil {
+		if cerr := q.findSigningKeyByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findSigningKeyByIDStmt: %w", cerr)
+		}
+	}
 	if q.findTrustDomainByIDStmt != nil {
 		if cerr := q.findTrustDomainByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing findTrustDomainByIDStmt: %w", cerr)
@@ -183,16 +422,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing findTrustDomainByNameStmt: %w", cerr)
 		}
 	}
+	if q.listBundleVersionsStmt != nil {
+		if cerr := q.listBundleVersionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBundleVersionsStmt: %w", cerr)
+		}
+	}
 	if q.listBundlesStmt != nil {
 		if cerr := q.listBundlesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listBundlesStmt: %w", cerr)
 		}
 	}
+	if q.listChangeEventsStmt != nil {
+		if cerr := q.listChangeEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listChangeEventsStmt: %w", cerr)
+		}
+	}
 	if q.listJoinTokensStmt != nil {
 		if cerr := q.listJoinTokensStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listJoinTokensStmt: %w", cerr)
 		}
 	}
+	if q.listSigningKeysStmt != nil {
+		if cerr := q.listSigningKeysStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSigningKeysStmt: %w", cerr)
+		}
+	}
 	if q.updateBundleStmt != nil {
 		if cerr := q.updateBundleStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateBundleStmt: %w", cerr)
@@ -213,6 +467,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateTrustDomainStmt: %w", cerr)
 		}
 	}
+	if q.useJoinTokenStmt != nil {
+		if cerr := q.useJoinTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing useJoinTokenStmt: %w", cerr)
+		}
+	}
+	if q.useOnboardChallengeStmt != nil {
+		if cerr := q.useOnboardChallengeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing useOnboardChallengeStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -250,59 +514,125 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                                   DBTX
-	tx                                   *sql.Tx
-	createBundleStmt                     *sql.Stmt
-	createJoinTokenStmt                  *sql.Stmt
-	createRelationshipStmt               *sql.Stmt
-	createTrustDomainStmt                *sql.Stmt
-	deleteBundleStmt                     *sql.Stmt
-	deleteJoinTokenStmt                  *sql.Stmt
-	deleteRelationshipStmt               *sql.Stmt
-	deleteTrustDomainStmt                *sql.Stmt
-	findBundleByIDStmt                   *sql.Stmt
-	findBundleByTrustDomainIDStmt        *sql.Stmt
-	findJoinTokenStmt                    *sql.Stmt
-	findJoinTokenByIDStmt                *sql.Stmt
-	findJoinTokensByTrustDomainIDStmt    *sql.Stmt
-	findRelationshipByIDStmt             *sql.Stmt
-	findRelationshipsByTrustDomainIDStmt *sql.Stmt
-	findTrustDomainByIDStmt              *sql.Stmt
-	findTrustDomainByNameStmt            *sql.Stmt
-	listBundlesStmt                      *sql.Stmt
-	listJoinTokensStmt                   *sql.Stmt
-	updateBundleStmt                     *sql.Stmt
-	updateJoinTokenStmt                  *sql.Stmt
-	updateRelationshipStmt               *sql.Stmt
-	updateTrustDomainStmt                *sql.Stmt
+	db                                           DBTX
+	tx                                           *sql.Tx
+	acquireHarvesterLeaseStmt                    *sql.Stmt
+	acquireServerLeaseStmt                       *sql.Stmt
+	createAuditEventStmt                         *sql.Stmt
+	createBundleStmt                             *sql.Stmt
+	createBundleChangeEventStmt                  *sql.Stmt
+	createBundleVersionStmt                      *sql.Stmt
+	createJoinTokenStmt                          *sql.Stmt
+	createOnboardChallengeStmt                   *sql.Stmt
+	createOrUpdateBundleResetApprovalStmt        *sql.Stmt
+	createOrUpdateRelationshipConsentStmt        *sql.Stmt
+	createOrUpdateSigningKeyStmt                 *sql.Stmt
+	createRelationshipStmt                       *sql.Stmt
+	createRelationshipChangeEventStmt            *sql.Stmt
+	createTrustDomainStmt                        *sql.Stmt
+	createTrustDomainChangeEventStmt             *sql.Stmt
+	deleteBundleStmt                             *sql.Stmt
+	deleteBundleResetApprovalStmt                *sql.Stmt
+	deleteChangeEventsBeforeStmt                 *sql.Stmt
+	deleteHarvesterLeaseStmt                     *sql.Stmt
+	deleteJoinTokenStmt                          *sql.Stmt
+	deleteJoinTokensExpiredBeforeStmt            *sql.Stmt
+	deleteOnboardChallengesExpiredBeforeStmt     *sql.Stmt
+	deleteRelationshipStmt                       *sql.Stmt
+	deleteRelationshipConsentStmt                *sql.Stmt
+	deleteServerLeaseStmt                        *sql.Stmt
+	deleteSigningKeyStmt                         *sql.Stmt
+	deleteTrustDomainStmt                        *sql.Stmt
+	findBundleByIDStmt                           *sql.Stmt
+	findBundleByTrustDomainIDStmt                *sql.Stmt
+	findBundleResetApprovalStmt                  *sql.Stmt
+	findBundleVersionStmt                        *sql.Stmt
+	findBundleVersionByDigestStmt                *sql.Stmt
+	findHarvesterLeaseStmt                       *sql.Stmt
+	findJoinTokenByIDStmt                        *sql.Stmt
+	findJoinTokensByHintStmt                     *sql.Stmt
+	findJoinTokensByTrustDomainIDStmt            *sql.Stmt
+	findLatestAuditEventStmt                     *sql.Stmt
+	findLatestChangeEventIDStmt                  *sql.Stmt
+	findOldestChangeEventIDStmt                  *sql.Stmt
+	findRelationshipByIDStmt                     *sql.Stmt
+	findRelationshipConsentsByRelationshipIDStmt *sql.Stmt
+	findRelationshipsByTrustDomainIDStmt         *sql.Stmt
+	findSigningKeyByIDStmt                       *sql.Stmt
+	findTrustDomainByIDStmt                      *sql.Stmt
+	findTrustDomainByNameStmt                    *sql.Stmt
+	listBundleVersionsStmt                       *sql.Stmt
+	listBundlesStmt                              *sql.Stmt
+	listChangeEventsStmt                         *sql.Stmt
+	listJoinTokensStmt                           *sql.Stmt
+	listSigningKeysStmt                          *sql.Stmt
+	updateBundleStmt                             *sql.Stmt
+	updateJoinTokenStmt                          *sql.Stmt
+	updateRelationshipStmt                       *sql.Stmt
+	updateTrustDomainStmt                        *sql.Stmt
+	useJoinTokenStmt                             *sql.Stmt
+	useOnboardChallengeStmt                      *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                                   tx,
-		tx:                                   tx,
-		createBundleStmt:                     q.createBundleStmt,
-		createJoinTokenStmt:                  q.createJoinTokenStmt,
-		createRelationshipStmt:               q.createRelationshipStmt,
-		createTrustDomainStmt:                q.createTrustDomainStmt,
-		deleteBundleStmt:                     q.deleteBundleStmt,
-		deleteJoinTokenStmt:                  q.deleteJoinTokenStmt,
-		deleteRelationshipStmt:               q.deleteRelationshipStmt,
-		deleteTrustDomainStmt:                q.deleteTrustDomainStmt,
-		findBundleByIDStmt:                   q.findBundleByIDStmt,
-		findBundleByTrustDomainIDStmt:        q.findBundleByTrustDomainIDStmt,
-		findJoinTokenStmt:                    q.findJoinTokenStmt,
-		findJoinTokenByIDStmt:                q.findJoinTokenByIDStmt,
-		findJoinTokensByTrustDomainIDStmt:    q.findJoinTokensByTrustDomainIDStmt,
-		findRelationshipByIDStmt:             q.findRelationshipByIDStmt,
-		findRelationshipsByTrustDomainIDStmt: q.findRelationshipsByTrustDomainIDStmt,
-		findTrustDomainByIDStmt:              q.findTrustDomainByIDStmt,
-		findTrustDomainByNameStmt:            q.findTrustDomainByNameStmt,
-		listBundlesStmt:                      q.listBundlesStmt,
-		listJoinTokensStmt:                   q.listJoinTokensStmt,
-		updateBundleStmt:                     q.updateBundleStmt,
-		updateJoinTokenStmt:                  q.updateJoinTokenStmt,
-		updateRelationshipStmt:               q.updateRelationshipStmt,
-		updateTrustDomainStmt:                q.updateTrustDomainStmt,
+		db:                                           tx,
+		tx:                                           tx,
+		acquireHarvesterLeaseStmt:                    q.acquireHarvesterLeaseStmt,
+		acquireServerLeaseStmt:                       q.acquireServerLeaseStmt,
+		createAuditEventStmt:                         q.createAuditEventStmt,
+		createBundleStmt:                             q.createBundleStmt,
+		createBundleChangeEventStmt:                  q.createBundleChangeEventStmt,
+		createBundleVersionStmt:                      q.createBundleVersionStmt,
+		createJoinTokenStmt:                          q.createJoinTokenStmt,
+		createOnboardChallengeStmt:                   q.createOnboardChallengeStmt,
+		createOrUpdateBundleResetApprovalStmt:        q.createOrUpdateBundleResetApprovalStmt,
+		createOrUpdateRelationshipConsentStmt:        q.createOrUpdateRelationshipConsentStmt,
+		createOrUpdateSigningKeyStmt:                 q.createOrUpdateSigningKeyStmt,
+		createRelationshipStmt:                       q.createRelationshipStmt,
+		createRelationshipChangeEventStmt:            q.createRelationshipChangeEventStmt,
+		createTrustDomainStmt:                        q.createTrustDomainStmt,
+		createTrustDomainChangeEventStmt:             q.createTrustDomainChangeEventStmt,
+		deleteBundleStmt:                             q.deleteBundleStmt,
+		deleteBundleResetApprovalStmt:                q.deleteBundleResetApprovalStmt,
+		deleteChangeEventsBeforeStmt:                 q.deleteChangeEventsBeforeStmt,
+		deleteHarvesterLeaseStmt:                     q.deleteHarvesterLeaseStmt,
+		deleteJoinTokenStmt:                          q.deleteJoinTokenStmt,
+		deleteJoinTokensExpiredBeforeStmt:            q.deleteJoinTokensExpiredBeforeStmt,
+		deleteOnboardChallengesExpiredBeforeStmt:     q.deleteOnboardChallengesExpiredBeforeStmt,
+		deleteRelationshipStmt:                       q.deleteRelationshipStmt,
+		deleteRelationshipConsentStmt:                q.deleteRelationshipConsentStmt,
+		deleteServerLeaseStmt:                        q.deleteServerLeaseStmt,
+		deleteSigningKeyStmt:                         q.deleteSigningKeyStmt,
+		deleteTrustDomainStmt:                        q.deleteTrustDomainStmt,
+		findBundleByIDStmt:                           q.findBundleByIDStmt,
+		findBundleByTrustDomainIDStmt:                q.findBundleByTrustDomainIDStmt,
+		findBundleResetApprovalStmt:                  q.findBundleResetApprovalStmt,
+		findBundleVersionStmt:                        q.findBundleVersionStmt,
+		findBundleVersionByDigestStmt:                q.findBundleVersionByDigestStmt,
+		findHarvesterLeaseStmt:                       q.findHarvesterLeaseStmt,
+		findJoinTokenByIDStmt:                        q.findJoinTokenByIDStmt,
+		findJoinTokensByHintStmt:                     q.findJoinTokensByHintStmt,
+		findJoinTokensByTrustDomainIDStmt:            q.findJoinTokensByTrustDomainIDStmt,
+		findLatestAuditEventStmt:                     q.findLatestAuditEventStmt,
+		findLatestChangeEventIDStmt:                  q.findLatestChangeEventIDStmt,
+		findOldestChangeEventIDStmt:                  q.findOldestChangeEventIDStmt,
+		findRelationshipByIDStmt:                     q.findRelationshipByIDStmt,
+		findRelationshipConsentsByRelationshipIDStmt: q.findRelationshipConsentsByRelationshipIDStmt,
+		findRelationshipsByTrustDomainIDStmt:         q.findRelationshipsByTrustDomainIDStmt,
+		findSigningKeyByIDStmt:                       q.findSigningKeyByIDStmt,
+		findTrustDomainByIDStmt:                      q.findTrustDomainByIDStmt,
+		findTrustDomainByNameStmt:                    q.findTrustDomainByNameStmt,
+		listBundleVersionsStmt:                       q.listBundleVersionsStmt,
+		listBundlesStmt:                              q.listBundlesStmt,
+		listChangeEventsStmt:                         q.listChangeEventsStmt,
+		listJoinTokensStmt:                           q.listJoinTokensStmt,
+		listSigningKeysStmt:                          q.listSigningKeysStmt,
+		updateBundleStmt:                             q.updateBundleStmt,
+		updateJoinTokenStmt:                          q.updateJoinTokenStmt,
+		updateRelationshipStmt:                       q.updateRelationshipStmt,
+		updateTrustDomainStmt:                        q.updateTrustDomainStmt,
+		useJoinTokenStmt:                             q.useJoinTokenStmt,
+		useOnboardChallengeStmt:                      q.useOnboardChallengeStmt,
 	}
 }
@@ -30,6 +30,10 @@ func (td TrustDomain) ToEntity() (*entity.TrustDomain, error) {
 		result.Description = td.Description.String
 	}
 
+	if td.BundleVerifier.Valid {
+		result.BundleVerifier = td.BundleVerifier.String
+	}
+
 	return result, nil
 }
 
@@ -56,7 +60,7 @@ func (b Bundle) ToEntity() (*entity.Bundle, error) {
 		Valid: true,
 	}
 
-	return &entity.Bundle{
+	result := &entity.Bundle{
 		ID:                      id,
 		Data:                    b.Data,
 		Digest:                  b.Digest,
@@ -65,7 +69,97 @@ func (b Bundle) ToEntity() (*entity.Bundle, error) {
 		TrustDomainID:           b.TrustDomainID.Bytes,
 		CreatedAt:               b.CreatedAt,
 		UpdatedAt:               b.UpdatedAt,
-	}, nil
+	}
+
+	if b.PinnedVersion.Valid {
+		result.PinnedVersion = b.PinnedVersion.Int64
+	}
+
+	return result, nil
+}
+
+func (bv BundleVersion) ToEntity() *entity.BundleVersion {
+	return &entity.BundleVersion{
+		ID:                      uuid.NullUUID{UUID: bv.ID.Bytes, Valid: true},
+		TrustDomainID:           bv.TrustDomainID.Bytes,
+		Version:                 bv.Version,
+		Data:                    bv.Data,
+		Digest:                  bv.Digest,
+		Signature:               bv.Signature,
+		SigningCertificateChain: bv.SigningCertificateChain,
+		UploadedBy:              bv.UploadedBy,
+		CreatedAt:               bv.CreatedAt,
+	}
+}
+
+func (rc RelationshipConsent) ToEntity() *entity.RelationshipConsent {
+	return &entity.RelationshipConsent{
+		RelationshipID:          rc.RelationshipID.Bytes,
+		TrustDomainID:           rc.TrustDomainID.Bytes,
+		ConsentStatement:        rc.ConsentStatement,
+		Signature:               rc.Signature,
+		SigningCertificateChain: rc.SigningCertificateChain,
+		CreatedAt:               rc.CreatedAt,
+		UpdatedAt:               rc.UpdatedAt,
+	}
+}
+
+func (hl HarvesterLease) ToEntity() *entity.HarvesterLease {
+	return &entity.HarvesterLease{
+		TrustDomainID: hl.TrustDomainID.Bytes,
+		InstanceID:    hl.InstanceID,
+		ExpiresAt:     hl.ExpiresAt,
+		CreatedAt:     hl.CreatedAt,
+		UpdatedAt:     hl.UpdatedAt,
+	}
+}
+
+func (a BundleResetApproval) ToEntity() *entity.BundleResetApproval {
+	return &entity.BundleResetApproval{
+		TrustDomainID: a.TrustDomainID.Bytes,
+		CreatedAt:     a.CreatedAt,
+	}
+}
+
+func (sk SigningKey) ToEntity() *entity.SigningKey {
+	return &entity.SigningKey{
+		ID:           sk.ID,
+		EncryptedKey: sk.EncryptedKey,
+		CreatedAt:    sk.CreatedAt,
+		UpdatedAt:    sk.UpdatedAt,
+	}
+}
+
+func (oc OnboardChallenge) ToEntity() *entity.OnboardChallenge {
+	return &entity.OnboardChallenge{
+		Nonce:         oc.Nonce,
+		TrustDomainID: oc.TrustDomainID.Bytes,
+		ExpiresAt:     oc.ExpiresAt,
+		CreatedAt:     oc.CreatedAt,
+	}
+}
+
+func (sl ServerLease) ToEntity() *entity.ServerLease {
+	return &entity.ServerLease{
+		Name:      sl.Name,
+		HolderID:  sl.HolderID,
+		ExpiresAt: sl.ExpiresAt,
+		CreatedAt: sl.CreatedAt,
+		UpdatedAt: sl.UpdatedAt,
+	}
+}
+
+func (ae AuditEvent) ToEntity() *entity.AuditEvent {
+	return &entity.AuditEvent{
+		ID:        ae.ID,
+		Actor:     ae.Actor,
+		Action:    entity.AuditAction(ae.Action),
+		Target:    ae.Target,
+		Details:   ae.Details,
+		PrevHash:  ae.PrevHash,
+		Hash:      ae.Hash,
+		CreatedAt: ae.CreatedAt,
+	}
 }
 
 func (jt JoinToken) ToEntity() *entity.JoinToken {
@@ -76,15 +170,48 @@ func (jt JoinToken) ToEntity() *entity.JoinToken {
 
 	return &entity.JoinToken{
 		ID:            id,
-		Token:         jt.Token,
+		TokenHash:     jt.TokenHash,
+		TokenSalt:     jt.TokenSalt,
+		TokenHint:     jt.TokenHint,
 		ExpiresAt:     jt.ExpiresAt,
 		Used:          jt.Used,
+		MaxUses:       jt.MaxUses,
+		UseCount:      jt.UseCount,
 		TrustDomainID: jt.TrustDomainID.Bytes,
 		CreatedAt:     jt.CreatedAt,
 		UpdatedAt:     jt.UpdatedAt,
+
+		HarvesterKeyFingerprint: jt.HarvesterKeyFingerprint.String,
+		SourceCIDR:              jt.SourceCidr.String,
 	}
 }
 
+func (ce ChangeEvent) ToEntity() (*entity.ChangeEvent, error) {
+	trustDomain, err := spiffeid.TrustDomainFromString(ce.TrustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.ChangeEvent{
+		ID:              ce.ID,
+		Kind:            entity.ChangeEventKind(ce.Kind),
+		TrustDomainID:   ce.TrustDomainID.Bytes,
+		TrustDomainName: trustDomain,
+		CreatedAt:       ce.CreatedAt,
+	}
+
+	if ce.PeerTrustDomainID.Valid {
+		peerTrustDomain, err := spiffeid.TrustDomainFromString(ce.PeerTrustDomainName.String)
+		if err != nil {
+			return nil, err
+		}
+		result.PeerTrustDomainID = ce.PeerTrustDomainID
+		result.PeerTrustDomainName = peerTrustDomain
+	}
+
+	return result, nil
+}
+
 func uuidToPgType(id uuid.UUID) (pgtype.UUID, error) {
 	pgID := pgtype.UUID{}
 	err := pgID.Set(id)
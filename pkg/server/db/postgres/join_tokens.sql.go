@@ -7,40 +7,58 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/jackc/pgtype"
 )
 
 const createJoinToken = `-- name: CreateJoinToken :one
-INSERT INTO join_tokens(token, expires_at, trust_domain_id, created_at)
-VALUES ($1, $2, $3, $4)
-RETURNING id, trust_domain_id, token, used, expires_at, created_at, updated_at
+INSERT INTO join_tokens(token_hash, token_salt, token_hint, expires_at, trust_domain_id, created_at, max_uses,
+                        harvester_key_fingerprint, source_cidr)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, trust_domain_id, token_hash, token_salt, token_hint, used, expires_at, created_at, updated_at, max_uses, use_count, harvester_key_fingerprint, source_cidr
 `
 
 type CreateJoinTokenParams struct {
-	Token         string
-	ExpiresAt     time.Time
-	TrustDomainID pgtype.UUID
-	CreatedAt     time.Time
+	TokenHash               []byte
+	TokenSalt               []byte
+	TokenHint               string
+	ExpiresAt               time.Time
+	TrustDomainID           pgtype.UUID
+	CreatedAt               time.Time
+	MaxUses                 int32
+	HarvesterKeyFingerprint sql.NullString
+	SourceCidr              sql.NullString
 }
 
 func (q *Queries) CreateJoinToken(ctx context.Context, arg CreateJoinTokenParams) (JoinToken, error) {
 	row := q.queryRow(ctx, q.createJoinTokenStmt, createJoinToken,
-		arg.Token,
+		arg.TokenHash,
+		arg.TokenSalt,
+		arg.TokenHint,
 		arg.ExpiresAt,
 		arg.TrustDomainID,
 		arg.CreatedAt,
+		arg.MaxUses,
+		arg.HarvesterKeyFingerprint,
+		arg.SourceCidr,
 	)
 	var i JoinToken
 	err := row.Scan(
 		&i.ID,
 		&i.TrustDomainID,
-		&i.Token,
+		&i.TokenHash,
+		&i.TokenSalt,
+		&i.TokenHint,
 		&i.Used,
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxUses,
+		&i.UseCount,
+		&i.HarvesterKeyFingerprint,
+		&i.SourceCidr,
 	)
 	return i, err
 }
@@ -56,29 +74,19 @@ func (q *Queries) DeleteJoinToken(ctx context.Context, id pgtype.UUID) error {
 	return err
 }
 
-const findJoinToken = `-- name: FindJoinToken :one
-SELECT id, trust_domain_id, token, used, expires_at, created_at, updated_at
+const deleteJoinTokensExpiredBefore = `-- name: DeleteJoinTokensExpiredBefore :exec
+DELETE
 FROM join_tokens
-WHERE token = $1
+WHERE expires_at < $1
 `
 
-func (q *Queries) FindJoinToken(ctx context.Context, token string) (JoinToken, error) {
-	row := q.queryRow(ctx, q.findJoinTokenStmt, findJoinToken, token)
-	var i JoinToken
-	err := row.Scan(
-		&i.ID,
-		&i.TrustDomainID,
-		&i.Token,
-		&i.Used,
-		&i.ExpiresAt,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+func (q *Queries) DeleteJoinTokensExpiredBefore(ctx context.Context, expiresAt time.Time) error {
+	_, err := q.exec(ctx, q.deleteJoinTokensExpiredBeforeStmt, deleteJoinTokensExpiredBefore, expiresAt)
+	return err
 }
 
 const findJoinTokenByID = `-- name: FindJoinTokenByID :one
-SELECT id, trust_domain_id, token, used, expires_at, created_at, updated_at
+SELECT id, trust_domain_id, token_hash, token_salt, token_hint, used, expires_at, created_at, updated_at, max_uses, use_count, harvester_key_fingerprint, source_cidr
 FROM join_tokens
 WHERE id = $1
 `
@@ -89,17 +97,66 @@ func (q *Queries) FindJoinTokenByID(ctx context.Context, id pgtype.UUID) (JoinTo
 	err := row.Scan(
 		&i.ID,
 		&i.TrustDomainID,
-		&i.Token,
+		&i.TokenHash,
+		&i.TokenSalt,
+		&i.TokenHint,
 		&i.Used,
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxUses,
+		&i.UseCount,
+		&i.HarvesterKeyFingerprint,
+		&i.SourceCidr,
 	)
 	return i, err
 }
 
+const findJoinTokensByHint = `-- name: FindJoinTokensByHint :many
+SELECT id, trust_domain_id, token_hash, token_salt, token_hint, used, expires_at, created_at, updated_at, max_uses, use_count, harvester_key_fingerprint, source_cidr
+FROM join_tokens
+WHERE token_hint = $1
+`
+
+func (q *Queries) FindJoinTokensByHint(ctx context.Context, tokenHint string) ([]JoinToken, error) {
+	rows, err := q.query(ctx, q.findJoinTokensByHintStmt, findJoinTokensByHint, tokenHint)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JoinToken
+	for rows.Next() {
+		var i JoinToken
+		if err := rows.Scan(
+			&i.ID,
+			&i.TrustDomainID,
+			&i.TokenHash,
+			&i.TokenSalt,
+			&i.TokenHint,
+			&i.Used,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.MaxUses,
+			&i.UseCount,
+			&i.HarvesterKeyFingerprint,
+			&i.SourceCidr,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const findJoinTokensByTrustDomainID = `-- name: FindJoinTokensByTrustDomainID :many
-SELECT id, trust_domain_id, token, used, expires_at, created_at, updated_at
+SELECT id, trust_domain_id, token_hash, token_salt, token_hint, used, expires_at, created_at, updated_at, max_uses, use_count, harvester_key_fingerprint, source_cidr
 FROM join_tokens
 WHERE trust_domain_id = $1
 `
@@ -116,11 +173,17 @@ func (q *Queries) FindJoinTokensByTrustDomainID(ctx context.Context, trustDomain
 		if err := rows.Scan(
 			&i.ID,
 			&i.TrustDomainID,
-			&i.Token,
+			&i.TokenHash,
+			&i.TokenSalt,
+			&i.TokenHint,
 			&i.Used,
 			&i.ExpiresAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.MaxUses,
+			&i.UseCount,
+			&i.HarvesterKeyFingerprint,
+			&i.SourceCidr,
 		); err != nil {
 			return nil, err
 		}
@@ -136,7 +199,7 @@ func (q *Queries) FindJoinTokensByTrustDomainID(ctx context.Context, trustDomain
 }
 
 const listJoinTokens = `-- name: ListJoinTokens :many
-SELECT id, trust_domain_id, token, used, expires_at, created_at, updated_at
+SELECT id, trust_domain_id, token_hash, token_salt, token_hint, used, expires_at, created_at, updated_at, max_uses, use_count, harvester_key_fingerprint, source_cidr
 FROM join_tokens
 ORDER BY created_at DESC
 `
@@ -153,11 +216,17 @@ func (q *Queries) ListJoinTokens(ctx context.Context) ([]JoinToken, error) {
 		if err := rows.Scan(
 			&i.ID,
 			&i.TrustDomainID,
-			&i.Token,
+			&i.TokenHash,
+			&i.TokenSalt,
+			&i.TokenHint,
 			&i.Used,
 			&i.ExpiresAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.MaxUses,
+			&i.UseCount,
+			&i.HarvesterKeyFingerprint,
+			&i.SourceCidr,
 		); err != nil {
 			return nil, err
 		}
@@ -177,7 +246,7 @@ UPDATE join_tokens
 SET used       = $2,
     updated_at = now()
 WHERE id = $1
-RETURNING id, trust_domain_id, token, used, expires_at, created_at, updated_at
+RETURNING id, trust_domain_id, token_hash, token_salt, token_hint, used, expires_at, created_at, updated_at, max_uses, use_count, harvester_key_fingerprint, source_cidr
 `
 
 type UpdateJoinTokenParams struct {
@@ -191,11 +260,48 @@ func (q *Queries) UpdateJoinToken(ctx context.Context, arg UpdateJoinTokenParams
 	err := row.Scan(
 		&i.ID,
 		&i.TrustDomainID,
-		&i.Token,
+		&i.TokenHash,
+		&i.TokenSalt,
+		&i.TokenHint,
+		&i.Used,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.MaxUses,
+		&i.UseCount,
+		&i.HarvesterKeyFingerprint,
+		&i.SourceCidr,
+	)
+	return i, err
+}
+
+const useJoinToken = `-- name: UseJoinToken :one
+UPDATE join_tokens
+SET use_count  = use_count + 1,
+    used       = use_count + 1 >= max_uses,
+    updated_at = now()
+WHERE id = $1
+  AND use_count < max_uses
+RETURNING id, trust_domain_id, token_hash, token_salt, token_hint, used, expires_at, created_at, updated_at, max_uses, use_count, harvester_key_fingerprint, source_cidr
+`
+
+func (q *Queries) UseJoinToken(ctx context.Context, id pgtype.UUID) (JoinToken, error) {
+	row := q.queryRow(ctx, q.useJoinTokenStmt, useJoinToken, id)
+	var i JoinToken
+	err := row.Scan(
+		&i.ID,
+		&i.TrustDomainID,
+		&i.TokenHash,
+		&i.TokenSalt,
+		&i.TokenHint,
 		&i.Used,
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxUses,
+		&i.UseCount,
+		&i.HarvesterKeyFingerprint,
+		&i.SourceCidr,
 	)
 	return i, err
 }
@@ -0,0 +1,9 @@
+-- the approval of an admin to accept the next bundle of a trust domain even if it does not continue its previous bundle
+CREATE TABLE IF NOT EXISTS bundle_reset_approvals
+(
+    trust_domain_id UUID PRIMARY KEY,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+ALTER TABLE "bundle_reset_approvals"
+    ADD FOREIGN KEY ("trust_domain_id") REFERENCES "trust_domains" ("id") ON DELETE CASCADE;
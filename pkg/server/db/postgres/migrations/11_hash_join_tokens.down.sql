@@ -0,0 +1,15 @@
+-- the hashed join tokens cannot be turned back into plaintext tokens so they are dropped
+DROP TABLE IF EXISTS join_tokens;
+CREATE TABLE IF NOT EXISTS join_tokens
+(
+    id              UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    trust_domain_id UUID                     NOT NULL,
+    token           TEXT                     NOT NULL UNIQUE,
+    used            BOOL                     NOT NULL DEFAULT FALSE,
+    expires_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+ALTER TABLE "join_tokens"
+    ADD FOREIGN KEY ("trust_domain_id") REFERENCES "trust_domains" ("id");
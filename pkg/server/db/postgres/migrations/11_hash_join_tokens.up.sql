@@ -0,0 +1,19 @@
+-- the join tokens are stored as a salted hash, the plaintext tokens cannot be hashed by SQL so they are dropped
+DROP TABLE IF EXISTS join_tokens;
+CREATE TABLE IF NOT EXISTS join_tokens
+(
+    id              UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    trust_domain_id UUID                     NOT NULL,
+    token_hash      BYTEA                    NOT NULL,
+    token_salt      BYTEA                    NOT NULL,
+    token_hint      TEXT                     NOT NULL,
+    used            BOOL                     NOT NULL DEFAULT FALSE,
+    expires_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+ALTER TABLE "join_tokens"
+    ADD FOREIGN KEY ("trust_domain_id") REFERENCES "trust_domains" ("id");
+
+CREATE INDEX IF NOT EXISTS idx_join_tokens_token_hint ON join_tokens (token_hint);
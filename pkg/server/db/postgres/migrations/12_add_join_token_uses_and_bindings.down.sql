@@ -0,0 +1,5 @@
+ALTER TABLE join_tokens
+    DROP COLUMN source_cidr,
+    DROP COLUMN harvester_key_fingerprint,
+    DROP COLUMN use_count,
+    DROP COLUMN max_uses;
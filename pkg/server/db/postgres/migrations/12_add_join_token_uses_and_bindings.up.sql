@@ -0,0 +1,10 @@
+-- the join tokens can be used several times, and can be bound to the key of a Harvester or to a source CIDR
+ALTER TABLE join_tokens
+    ADD COLUMN max_uses                  INTEGER NOT NULL DEFAULT 1,
+    ADD COLUMN use_count                 INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN harvester_key_fingerprint TEXT,
+    ADD COLUMN source_cidr               TEXT;
+
+UPDATE join_tokens
+SET use_count = 1
+WHERE used = TRUE;
@@ -0,0 +1,12 @@
+-- the nonces issued to the Harvesters to onboard by proving the control of their trust domain. A nonce is deleted
+-- when it is used, so that a signed onboard statement cannot be replayed.
+CREATE TABLE IF NOT EXISTS onboard_challenges
+(
+    nonce           TEXT                     NOT NULL PRIMARY KEY,
+    trust_domain_id UUID                     NOT NULL,
+    expires_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+ALTER TABLE "onboard_challenges"
+    ADD FOREIGN KEY ("trust_domain_id") REFERENCES "trust_domains" ("id") ON DELETE CASCADE;
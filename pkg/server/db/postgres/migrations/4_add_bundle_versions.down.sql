@@ -0,0 +1,4 @@
+ALTER TABLE bundles
+    DROP COLUMN pinned_version;
+
+DROP TABLE IF EXISTS bundle_versions;
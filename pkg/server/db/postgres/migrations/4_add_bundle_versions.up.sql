@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS bundle_versions
+(
+    id                        UUID PRIMARY KEY                  DEFAULT gen_random_uuid(),
+    trust_domain_id           UUID                     NOT NULL,
+    version                   BIGINT                   NOT NULL,
+    data                      BYTEA                    NOT NULL,
+    digest                    BYTEA                    NOT NULL,
+    signature                 BYTEA,
+    signing_certificate_chain BYTEA,
+    created_at                TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    UNIQUE (trust_domain_id, version)
+);
+
+ALTER TABLE "bundle_versions"
+    ADD FOREIGN KEY ("trust_domain_id") REFERENCES "trust_domains" ("id") ON DELETE CASCADE;
+
+ALTER TABLE bundles
+    ADD COLUMN pinned_version BIGINT;
+
+-- the bundles stored before the history was introduced become the first version
+INSERT INTO bundle_versions(trust_domain_id, version, data, digest, signature, signing_certificate_chain, created_at)
+SELECT trust_domain_id, 1, data, digest, signature, signing_certificate_chain, updated_at
+FROM bundles;
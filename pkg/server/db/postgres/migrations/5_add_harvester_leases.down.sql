@@ -0,0 +1,4 @@
+ALTER TABLE bundle_versions
+    DROP COLUMN uploaded_by;
+
+DROP TABLE IF EXISTS harvester_leases;
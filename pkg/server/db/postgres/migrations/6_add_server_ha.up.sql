@@ -0,0 +1,18 @@
+-- the signing keys shared by the Galadriel Server replicas, encrypted with the key encryption key of the replicas
+CREATE TABLE IF NOT EXISTS signing_keys
+(
+    id            TEXT PRIMARY KEY,
+    encrypted_key BYTEA                    NOT NULL,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- the leases electing the Galadriel Server replica that runs a background task
+CREATE TABLE IF NOT EXISTS server_leases
+(
+    name       TEXT PRIMARY KEY,
+    holder_id  TEXT                     NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
@@ -0,0 +1,18 @@
+-- the consent of a trust domain to a relationship, signed by its Harvester. The signed statement is kept as it was
+-- signed, so that the peers can verify the signature.
+CREATE TABLE IF NOT EXISTS relationship_consents
+(
+    relationship_id           UUID                     NOT NULL,
+    trust_domain_id           UUID                     NOT NULL,
+    consent_statement         BYTEA                    NOT NULL,
+    signature                 BYTEA,
+    signing_certificate_chain BYTEA,
+    created_at                TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at                TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (relationship_id, trust_domain_id)
+);
+
+ALTER TABLE "relationship_consents"
+    ADD FOREIGN KEY ("relationship_id") REFERENCES "relationships" ("id") ON DELETE CASCADE;
+ALTER TABLE "relationship_consents"
+    ADD FOREIGN KEY ("trust_domain_id") REFERENCES "trust_domains" ("id") ON DELETE CASCADE;
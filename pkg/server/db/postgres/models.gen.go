@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgtype"
 )
 
@@ -56,6 +57,17 @@ func (ns NullConsentStatus) Value() (driver.Value, error) {
 	return string(ns.ConsentStatus), nil
 }
 
+type AuditEvent struct {
+	ID        int64
+	Actor     string
+	Action    string
+	Target    string
+	Details   string
+	PrevHash  []byte
+	Hash      []byte
+	CreatedAt time.Time
+}
+
 type Bundle struct {
 	ID                      pgtype.UUID
 	TrustDomainID           pgtype.UUID
@@ -65,18 +77,67 @@ type Bundle struct {
 	SigningCertificateChain []byte
 	CreatedAt               time.Time
 	UpdatedAt               time.Time
+	PinnedVersion           sql.NullInt64
 }
 
-type JoinToken struct {
-	ID            pgtype.UUID
+type BundleResetApproval struct {
 	TrustDomainID pgtype.UUID
-	Token         string
-	Used          bool
+	CreatedAt     time.Time
+}
+
+type BundleVersion struct {
+	ID                      pgtype.UUID
+	TrustDomainID           pgtype.UUID
+	Version                 int64
+	Data                    []byte
+	Digest                  []byte
+	Signature               []byte
+	SigningCertificateChain []byte
+	CreatedAt               time.Time
+	UploadedBy              string
+}
+
+type ChangeEvent struct {
+	ID                  int64
+	Kind                string
+	TrustDomainID       pgtype.UUID
+	TrustDomainName     string
+	PeerTrustDomainID   uuid.NullUUID
+	PeerTrustDomainName sql.NullString
+	CreatedAt           time.Time
+}
+
+type HarvesterLease struct {
+	TrustDomainID pgtype.UUID
+	InstanceID    string
 	ExpiresAt     time.Time
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 }
 
+type JoinToken struct {
+	ID                      pgtype.UUID
+	TrustDomainID           pgtype.UUID
+	TokenHash               []byte
+	TokenSalt               []byte
+	TokenHint               string
+	Used                    bool
+	ExpiresAt               time.Time
+	CreatedAt               time.Time
+	UpdatedAt               time.Time
+	MaxUses                 int32
+	UseCount                int32
+	HarvesterKeyFingerprint sql.NullString
+	SourceCidr              sql.NullString
+}
+
+type OnboardChallenge struct {
+	Nonce         string
+	TrustDomainID pgtype.UUID
+	ExpiresAt     time.Time
+	CreatedAt     time.Time
+}
+
 type Relationship struct {
 	ID                  pgtype.UUID
 	TrustDomainAID      pgtype.UUID
@@ -87,10 +148,36 @@ type Relationship struct {
 	UpdatedAt           time.Time
 }
 
+type RelationshipConsent struct {
+	RelationshipID          pgtype.UUID
+	TrustDomainID           pgtype.UUID
+	ConsentStatement        []byte
+	Signature               []byte
+	SigningCertificateChain []byte
+	CreatedAt               time.Time
+	UpdatedAt               time.Time
+}
+
+type ServerLease struct {
+	Name      string
+	HolderID  string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type SigningKey struct {
+	ID           string
+	EncryptedKey []byte
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
 type TrustDomain struct {
-	ID          pgtype.UUID
-	Name        string
-	Description sql.NullString
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID             pgtype.UUID
+	Name           string
+	Description    sql.NullString
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	BundleVerifier sql.NullString
 }
@@ -0,0 +1,16 @@
+-- name: CreateOrUpdateBundleResetApproval :one
+INSERT INTO bundle_reset_approvals(trust_domain_id, created_at)
+VALUES ($1, $2)
+ON CONFLICT (trust_domain_id) DO UPDATE
+    SET created_at = excluded.created_at
+RETURNING *;
+
+-- name: FindBundleResetApproval :one
+SELECT *
+FROM bundle_reset_approvals
+WHERE trust_domain_id = $1;
+
+-- name: DeleteBundleResetApproval :exec
+DELETE
+FROM bundle_reset_approvals
+WHERE trust_domain_id = $1;
@@ -0,0 +1,24 @@
+-- name: CreateBundleVersion :one
+INSERT INTO bundle_versions(trust_domain_id, version, data, digest, signature, signing_certificate_chain, uploaded_by)
+VALUES ($1, (SELECT COALESCE(MAX(version), 0) + 1 FROM bundle_versions WHERE trust_domain_id = $1), $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: FindBundleVersion :one
+SELECT *
+FROM bundle_versions
+WHERE trust_domain_id = $1
+  AND version = $2;
+
+-- name: FindBundleVersionByDigest :one
+SELECT *
+FROM bundle_versions
+WHERE trust_domain_id = $1
+  AND digest = $2
+ORDER BY version DESC
+LIMIT 1;
+
+-- name: ListBundleVersions :many
+SELECT *
+FROM bundle_versions
+WHERE trust_domain_id = $1
+ORDER BY version DESC;
@@ -1,6 +1,6 @@
 -- name: CreateBundle :one
-INSERT INTO bundles(data, digest, signature, signing_certificate_chain, trust_domain_id, created_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO bundles(data, digest, signature, signing_certificate_chain, trust_domain_id, pinned_version, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING *;
 
 -- name: UpdateBundle :one
@@ -9,6 +9,7 @@ SET data                = $2,
     digest              = $3,
     signature           = $4,
     signing_certificate_chain = $5,
+    pinned_version      = $6,
     updated_at          = now()
 WHERE id = $1
 RETURNING *;
@@ -0,0 +1,47 @@
+-- name: CreateTrustDomainChangeEvent :exec
+INSERT INTO change_events(kind, trust_domain_id, trust_domain_name, created_at)
+SELECT $1, td.id, td.name, $2
+FROM trust_domains td
+WHERE td.id = $3;
+
+-- name: CreateBundleChangeEvent :exec
+INSERT INTO change_events(kind, trust_domain_id, trust_domain_name, created_at)
+SELECT 'bundle', td.id, td.name, $1
+FROM bundles b
+         JOIN trust_domains td ON td.id = b.trust_domain_id
+WHERE b.id = $2;
+
+-- name: CreateRelationshipChangeEvent :exec
+INSERT INTO change_events(kind, trust_domain_id, trust_domain_name, peer_trust_domain_id, peer_trust_domain_name,
+                          created_at)
+SELECT 'relationship', tda.id, tda.name, tdb.id, tdb.name, $1
+FROM relationships r
+         JOIN trust_domains tda ON tda.id = r.trust_domain_a_id
+         JOIN trust_domains tdb ON tdb.id = r.trust_domain_b_id
+WHERE r.id = $2;
+
+-- name: ListChangeEvents :many
+SELECT *
+FROM change_events
+WHERE id > $1
+  AND id <= $2
+ORDER BY id;
+
+-- name: FindOldestChangeEventID :one
+SELECT id
+FROM change_events
+ORDER BY id
+LIMIT 1;
+
+-- name: FindLatestChangeEventID :one
+SELECT id
+FROM change_events
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: DeleteChangeEventsBefore :exec
+-- the latest change is kept, so that the cursors that are up to date remain valid
+DELETE
+FROM change_events
+WHERE change_events.created_at < $1
+  AND change_events.id < (SELECT MAX(latest.id) FROM change_events latest);
@@ -1,6 +1,7 @@
 -- name: CreateJoinToken :one
-INSERT INTO join_tokens(token, expires_at, trust_domain_id, created_at)
-VALUES ($1, $2, $3, $4)
+INSERT INTO join_tokens(token_hash, token_salt, token_hint, expires_at, trust_domain_id, created_at, max_uses,
+                        harvester_key_fingerprint, source_cidr)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
 
 -- name: UpdateJoinToken :one
@@ -10,6 +11,15 @@ SET used       = $2,
 WHERE id = $1
 RETURNING *;
 
+-- name: UseJoinToken :one
+UPDATE join_tokens
+SET use_count  = use_count + 1,
+    used       = use_count + 1 >= max_uses,
+    updated_at = now()
+WHERE id = $1
+  AND use_count < max_uses
+RETURNING *;
+
 -- name: DeleteJoinToken :exec
 DELETE
 FROM join_tokens
@@ -20,10 +30,10 @@ SELECT *
 FROM join_tokens
 WHERE id = $1;
 
--- name: FindJoinToken :one
+-- name: FindJoinTokensByHint :many
 SELECT *
 FROM join_tokens
-WHERE token = $1;
+WHERE token_hint = $1;
 
 -- name: FindJoinTokensByTrustDomainID :many
 SELECT *
@@ -34,3 +44,8 @@ WHERE trust_domain_id = $1;
 SELECT *
 FROM join_tokens
 ORDER BY created_at DESC;
+
+-- name: DeleteJoinTokensExpiredBefore :exec
+DELETE
+FROM join_tokens
+WHERE expires_at < $1;
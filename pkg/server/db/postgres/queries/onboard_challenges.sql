@@ -0,0 +1,17 @@
+-- name: CreateOnboardChallenge :exec
+INSERT INTO onboard_challenges(nonce, trust_domain_id, expires_at, created_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: UseOnboardChallenge :one
+-- the challenge is deleted when it is used, so that it can only be used once, and only before it expires
+DELETE
+FROM onboard_challenges
+WHERE nonce = $1
+  AND trust_domain_id = $2
+  AND expires_at > $3
+RETURNING *;
+
+-- name: DeleteOnboardChallengesExpiredBefore :exec
+DELETE
+FROM onboard_challenges
+WHERE expires_at < $1;
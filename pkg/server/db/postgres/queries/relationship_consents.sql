@@ -0,0 +1,22 @@
+-- name: CreateOrUpdateRelationshipConsent :one
+INSERT INTO relationship_consents(relationship_id, trust_domain_id, consent_statement, signature,
+                                  signing_certificate_chain, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (relationship_id, trust_domain_id) DO UPDATE
+    SET consent_statement         = excluded.consent_statement,
+        signature                 = excluded.signature,
+        signing_certificate_chain = excluded.signing_certificate_chain,
+        updated_at                = excluded.updated_at
+RETURNING *;
+
+-- name: FindRelationshipConsentsByRelationshipID :many
+SELECT *
+FROM relationship_consents
+WHERE relationship_id = $1
+ORDER BY created_at;
+
+-- name: DeleteRelationshipConsent :exec
+DELETE
+FROM relationship_consents
+WHERE relationship_id = $1
+  AND trust_domain_id = $2;
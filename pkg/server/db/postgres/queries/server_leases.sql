@@ -0,0 +1,17 @@
+-- name: AcquireServerLease :one
+-- the lease is only acquired if it is held by the same replica or it expired before updated_at
+INSERT INTO server_leases(name, holder_id, expires_at, updated_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (name) DO UPDATE
+    SET holder_id  = excluded.holder_id,
+        expires_at = excluded.expires_at,
+        updated_at = excluded.updated_at
+WHERE server_leases.holder_id = excluded.holder_id
+   OR server_leases.expires_at < excluded.updated_at
+RETURNING *;
+
+-- name: DeleteServerLease :exec
+DELETE
+FROM server_leases
+WHERE name = $1
+  AND holder_id = $2;
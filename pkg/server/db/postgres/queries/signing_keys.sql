@@ -0,0 +1,22 @@
+-- name: CreateOrUpdateSigningKey :one
+INSERT INTO signing_keys(id, encrypted_key, created_at, updated_at)
+VALUES ($1, $2, $3, $3)
+ON CONFLICT (id) DO UPDATE
+    SET encrypted_key = excluded.encrypted_key,
+        updated_at    = excluded.updated_at
+RETURNING *;
+
+-- name: DeleteSigningKey :exec
+DELETE
+FROM signing_keys
+WHERE id = $1;
+
+-- name: FindSigningKeyByID :one
+SELECT *
+FROM signing_keys
+WHERE id = $1;
+
+-- name: ListSigningKeys :many
+SELECT *
+FROM signing_keys
+ORDER BY created_at;
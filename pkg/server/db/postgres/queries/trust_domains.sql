@@ -1,12 +1,13 @@
 -- name: CreateTrustDomain :one
-INSERT INTO trust_domains(name, description, created_at)
-VALUES ($1, $2, $3)
+INSERT INTO trust_domains(name, description, bundle_verifier, created_at)
+VALUES ($1, $2, $3, $4)
 RETURNING *;
 
 -- name: UpdateTrustDomain :one
 UPDATE trust_domains
-SET description = $2,
-    updated_at  = now()
+SET description     = $2,
+    bundle_verifier = $3,
+    updated_at      = now()
 WHERE id = $1
 RETURNING *;
 
@@ -14,19 +14,25 @@ import (
 )
 
 const createTrustDomain = `-- name: CreateTrustDomain :one
-INSERT INTO trust_domains(name, description, created_at)
-VALUES ($1, $2, $3)
-RETURNING id, name, description, created_at, updated_at
+INSERT INTO trust_domains(name, description, bundle_verifier, created_at)
+VALUES ($1, $2, $3, $4)
+RETURNING id, name, description, created_at, updated_at, bundle_verifier
 `
 
 type CreateTrustDomainParams struct {
-	Name        string
-	Description sql.NullString
-	CreatedAt   time.Time
+	Name           string
+	Description    sql.NullString
+	BundleVerifier sql.NullString
+	CreatedAt      time.Time
 }
 
 func (q *Queries) CreateTrustDomain(ctx context.Context, arg CreateTrustDomainParams) (TrustDomain, error) {
-	row := q.queryRow(ctx, q.createTrustDomainStmt, createTrustDomain, arg.Name, arg.Description, arg.CreatedAt)
+	row := q.queryRow(ctx, q.createTrustDomainStmt, createTrustDomain,
+		arg.Name,
+		arg.Description,
+		arg.BundleVerifier,
+		arg.CreatedAt,
+	)
 	var i TrustDomain
 	err := row.Scan(
 		&i.ID,
@@ -34,6 +40,7 @@ func (q *Queries) CreateTrustDomain(ctx context.Context, arg CreateTrustDomainPa
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BundleVerifier,
 	)
 	return i, err
 }
@@ -50,7 +57,7 @@ func (q *Queries) DeleteTrustDomain(ctx context.Context, id pgtype.UUID) error {
 }
 
 const findTrustDomainByID = `-- name: FindTrustDomainByID :one
-SELECT id, name, description, created_at, updated_at
+SELECT id, name, description, created_at, updated_at, bundle_verifier
 FROM trust_domains
 WHERE id = $1
 `
@@ -64,12 +71,13 @@ func (q *Queries) FindTrustDomainByID(ctx context.Context, id pgtype.UUID) (Trus
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BundleVerifier,
 	)
 	return i, err
 }
 
 const findTrustDomainByName = `-- name: FindTrustDomainByName :one
-SELECT id, name, description, created_at, updated_at
+SELECT id, name, description, created_at, updated_at, bundle_verifier
 FROM trust_domains
 WHERE name = $1
 `
@@ -83,25 +91,28 @@ func (q *Queries) FindTrustDomainByName(ctx context.Context, name string) (Trust
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BundleVerifier,
 	)
 	return i, err
 }
 
 const updateTrustDomain = `-- name: UpdateTrustDomain :one
 UPDATE trust_domains
-SET description = $2,
-    updated_at  = now()
+SET description     = $2,
+    bundle_verifier = $3,
+    updated_at      = now()
 WHERE id = $1
-RETURNING id, name, description, created_at, updated_at
+RETURNING id, name, description, created_at, updated_at, bundle_verifier
 `
 
 type UpdateTrustDomainParams struct {
-	ID          pgtype.UUID
-	Description sql.NullString
+	ID             pgtype.UUID
+	Description    sql.NullString
+	BundleVerifier sql.NullString
 }
 
 func (q *Queries) UpdateTrustDomain(ctx context.Context, arg UpdateTrustDomainParams) (TrustDomain, error) {
-	row := q.queryRow(ctx, q.updateTrustDomainStmt, updateTrustDomain, arg.ID, arg.Description)
+	row := q.queryRow(ctx, q.updateTrustDomainStmt, updateTrustDomain, arg.ID, arg.Description, arg.BundleVerifier)
 	var i TrustDomain
 	err := row.Scan(
 		&i.ID,
@@ -109,6 +120,7 @@ func (q *Queries) UpdateTrustDomain(ctx context.Context, arg UpdateTrustDomainPa
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BundleVerifier,
 	)
 	return i, err
 }
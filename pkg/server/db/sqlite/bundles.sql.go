@@ -7,13 +7,14 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
 const createBundle = `-- name: CreateBundle :one
-INSERT INTO bundles(id, data, digest, signature, signing_certificate_chain, trust_domain_id, created_at)
-VALUES (?, ?, ?, ?, ?, ?, ?)
-RETURNING id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at
+INSERT INTO bundles(id, data, digest, signature, signing_certificate_chain, trust_domain_id, pinned_version, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at, pinned_version
 `
 
 type CreateBundleParams struct {
@@ -23,6 +24,7 @@ type CreateBundleParams struct {
 	Signature               []byte
 	SigningCertificateChain []byte
 	TrustDomainID           string
+	PinnedVersion           sql.NullInt64
 	CreatedAt               time.Time
 }
 
@@ -34,6 +36,7 @@ func (q *Queries) CreateBundle(ctx context.Context, arg CreateBundleParams) (Bun
 		arg.Signature,
 		arg.SigningCertificateChain,
 		arg.TrustDomainID,
+		arg.PinnedVersion,
 		arg.CreatedAt,
 	)
 	var i Bundle
@@ -46,6 +49,7 @@ func (q *Queries) CreateBundle(ctx context.Context, arg CreateBundleParams) (Bun
 		&i.SigningCertificateChain,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PinnedVersion,
 	)
 	return i, err
 }
@@ -62,7 +66,7 @@ func (q *Queries) DeleteBundle(ctx context.Context, id string) error {
 }
 
 const findBundleByID = `-- name: FindBundleByID :one
-SELECT id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at
+SELECT id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at, pinned_version
 FROM bundles
 WHERE id = ?
 `
@@ -79,12 +83,13 @@ func (q *Queries) FindBundleByID(ctx context.Context, id string) (Bundle, error)
 		&i.SigningCertificateChain,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PinnedVersion,
 	)
 	return i, err
 }
 
 const findBundleByTrustDomainID = `-- name: FindBundleByTrustDomainID :one
-SELECT id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at
+SELECT id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at, pinned_version
 FROM bundles
 WHERE trust_domain_id = ?
 LIMIT 1
@@ -102,12 +107,13 @@ func (q *Queries) FindBundleByTrustDomainID(ctx context.Context, trustDomainID s
 		&i.SigningCertificateChain,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PinnedVersion,
 	)
 	return i, err
 }
 
 const listBundles = `-- name: ListBundles :many
-SELECT id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at
+SELECT id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at, pinned_version
 FROM bundles
 ORDER BY created_at DESC
 `
@@ -130,6 +136,7 @@ func (q *Queries) ListBundles(ctx context.Context) ([]Bundle, error) {
 			&i.SigningCertificateChain,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PinnedVersion,
 		); err != nil {
 			return nil, err
 		}
@@ -150,9 +157,10 @@ SET data                = ?,
     digest              = ?,
     signature           = ?,
     signing_certificate_chain = ?,
+    pinned_version      = ?,
     updated_at          = datetime('now')
 WHERE id = ?
-RETURNING id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at
+RETURNING id, trust_domain_id, data, digest, signature, signing_certificate_chain, created_at, updated_at, pinned_version
 `
 
 type UpdateBundleParams struct {
@@ -160,6 +168,7 @@ type UpdateBundleParams struct {
 	Digest                  []byte
 	Signature               []byte
 	SigningCertificateChain []byte
+	PinnedVersion           sql.NullInt64
 	ID                      string
 }
 
@@ -169,6 +178,7 @@ func (q *Queries) UpdateBundle(ctx context.Context, arg UpdateBundleParams) (Bun
 		arg.Digest,
 		arg.Signature,
 		arg.SigningCertificateChain,
+		arg.PinnedVersion,
 		arg.ID,
 	)
 	var i Bundle
@@ -181,6 +191,7 @@ func (q *Queries) UpdateBundle(ctx context.Context, arg UpdateBundleParams) (Bun
 		&i.SigningCertificateChain,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PinnedVersion,
 	)
 	return i, err
 }
@@ -34,6 +34,10 @@ func (td TrustDomain) ToEntity() (*entity.TrustDomain, error) {
 		result.Description = td.Description.String
 	}
 
+	if td.BundleVerifier.Valid {
+		result.BundleVerifier = td.BundleVerifier.String
+	}
+
 	return result, nil
 }
 
@@ -82,7 +86,7 @@ func (b Bundle) ToEntity() (*entity.Bundle, error) {
 		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
 	}
 
-	return &entity.Bundle{
+	result := &entity.Bundle{
 		ID:                      nullID,
 		Data:                    b.Data,
 		Digest:                  b.Digest,
@@ -91,9 +95,134 @@ func (b Bundle) ToEntity() (*entity.Bundle, error) {
 		TrustDomainID:           tdID,
 		CreatedAt:               b.CreatedAt,
 		UpdatedAt:               b.UpdatedAt,
+	}
+
+	if b.PinnedVersion.Valid {
+		result.PinnedVersion = b.PinnedVersion.Int64
+	}
+
+	return result, nil
+}
+
+func (bv BundleVersion) ToEntity() (*entity.BundleVersion, error) {
+	id, err := uuid.Parse(bv.ID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	tdID, err := uuid.Parse(bv.TrustDomainID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	return &entity.BundleVersion{
+		ID:                      uuid.NullUUID{UUID: id, Valid: true},
+		TrustDomainID:           tdID,
+		Version:                 bv.Version,
+		Data:                    bv.Data,
+		Digest:                  bv.Digest,
+		Signature:               bv.Signature,
+		SigningCertificateChain: bv.SigningCertificateChain,
+		UploadedBy:              bv.UploadedBy,
+		CreatedAt:               bv.CreatedAt,
+	}, nil
+}
+
+func (hl HarvesterLease) ToEntity() (*entity.HarvesterLease, error) {
+	tdID, err := uuid.Parse(hl.TrustDomainID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	return &entity.HarvesterLease{
+		TrustDomainID: tdID,
+		InstanceID:    hl.InstanceID,
+		ExpiresAt:     hl.ExpiresAt,
+		CreatedAt:     hl.CreatedAt,
+		UpdatedAt:     hl.UpdatedAt,
+	}, nil
+}
+
+func (a BundleResetApproval) ToEntity() (*entity.BundleResetApproval, error) {
+	tdID, err := uuid.Parse(a.TrustDomainID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	return &entity.BundleResetApproval{
+		TrustDomainID: tdID,
+		CreatedAt:     a.CreatedAt,
+	}, nil
+}
+
+func (sk SigningKey) ToEntity() *entity.SigningKey {
+	return &entity.SigningKey{
+		ID:           sk.ID,
+		EncryptedKey: sk.EncryptedKey,
+		CreatedAt:    sk.CreatedAt,
+		UpdatedAt:    sk.UpdatedAt,
+	}
+}
+
+func (oc OnboardChallenge) ToEntity() (*entity.OnboardChallenge, error) {
+	trustDomainID, err := uuid.Parse(oc.TrustDomainID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	return &entity.OnboardChallenge{
+		Nonce:         oc.Nonce,
+		TrustDomainID: trustDomainID,
+		ExpiresAt:     oc.ExpiresAt,
+		CreatedAt:     oc.CreatedAt,
+	}, nil
+}
+
+func (sl ServerLease) ToEntity() *entity.ServerLease {
+	return &entity.ServerLease{
+		Name:      sl.Name,
+		HolderID:  sl.HolderID,
+		ExpiresAt: sl.ExpiresAt,
+		CreatedAt: sl.CreatedAt,
+		UpdatedAt: sl.UpdatedAt,
+	}
+}
+
+func (rc RelationshipConsent) ToEntity() (*entity.RelationshipConsent, error) {
+	relationshipID, err := uuid.Parse(rc.RelationshipID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	trustDomainID, err := uuid.Parse(rc.TrustDomainID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	return &entity.RelationshipConsent{
+		RelationshipID:          relationshipID,
+		TrustDomainID:           trustDomainID,
+		ConsentStatement:        rc.ConsentStatement,
+		Signature:               rc.Signature,
+		SigningCertificateChain: rc.SigningCertificateChain,
+		CreatedAt:               rc.CreatedAt,
+		UpdatedAt:               rc.UpdatedAt,
 	}, nil
 }
 
+func (ae AuditEvent) ToEntity() *entity.AuditEvent {
+	return &entity.AuditEvent{
+		ID:        ae.ID,
+		Actor:     ae.Actor,
+		Action:    entity.AuditAction(ae.Action),
+		Target:    ae.Target,
+		Details:   ae.Details,
+		PrevHash:  ae.PrevHash,
+		Hash:      ae.Hash,
+		CreatedAt: ae.CreatedAt,
+	}
+}
+
 func (jt JoinToken) ToEntity() (*entity.JoinToken, error) {
 	id, err := uuid.Parse(jt.ID)
 	if err != nil {
@@ -111,11 +240,54 @@ func (jt JoinToken) ToEntity() (*entity.JoinToken, error) {
 
 	return &entity.JoinToken{
 		ID:            nullID,
-		Token:         jt.Token,
+		TokenHash:     jt.TokenHash,
+		TokenSalt:     jt.TokenSalt,
+		TokenHint:     jt.TokenHint,
 		ExpiresAt:     jt.ExpiresAt,
 		Used:          jt.Used,
+		MaxUses:       int32(jt.MaxUses),
+		UseCount:      int32(jt.UseCount),
 		TrustDomainID: tdID,
 		CreatedAt:     jt.CreatedAt,
 		UpdatedAt:     jt.UpdatedAt,
+
+		HarvesterKeyFingerprint: jt.HarvesterKeyFingerprint.String,
+		SourceCIDR:              jt.SourceCidr.String,
 	}, nil
 }
+
+func (ce ChangeEvent) ToEntity() (*entity.ChangeEvent, error) {
+	tdID, err := uuid.Parse(ce.TrustDomainID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+	}
+
+	trustDomain, err := spiffeid.TrustDomainFromString(ce.TrustDomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.ChangeEvent{
+		ID:              ce.ID,
+		Kind:            entity.ChangeEventKind(ce.Kind),
+		TrustDomainID:   tdID,
+		TrustDomainName: trustDomain,
+		CreatedAt:       ce.CreatedAt,
+	}
+
+	if ce.PeerTrustDomainID.Valid {
+		peerID, err := uuid.Parse(ce.PeerTrustDomainID.String)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert model to entity: %v", err)
+		}
+
+		peerTrustDomain, err := spiffeid.TrustDomainFromString(ce.PeerTrustDomainName.String)
+		if err != nil {
+			return nil, err
+		}
+		result.PeerTrustDomainID = uuid.NullUUID{UUID: peerID, Valid: true}
+		result.PeerTrustDomainName = peerTrustDomain
+	}
+
+	return result, nil
+}
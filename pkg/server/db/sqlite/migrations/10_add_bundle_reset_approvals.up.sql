@@ -0,0 +1,9 @@
+-- the approval of an admin to accept the next bundle of a trust domain even if it does not continue its previous bundle
+CREATE TABLE IF NOT EXISTS bundle_reset_approvals
+(
+    trust_domain_id TEXT PRIMARY KEY,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (trust_domain_id)
+        REFERENCES trust_domains (id)
+        ON DELETE CASCADE
+);
@@ -0,0 +1,14 @@
+-- the hashed join tokens cannot be turned back into plaintext tokens so they are dropped
+DROP TABLE IF EXISTS join_tokens;
+CREATE TABLE IF NOT EXISTS join_tokens
+(
+    id              TEXT PRIMARY KEY,
+    trust_domain_id TEXT      NOT NULL,
+    token           TEXT      NOT NULL UNIQUE,
+    used            BOOL      NOT NULL DEFAULT 0,
+    expires_at      TIMESTAMP NOT NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (trust_domain_id)
+        REFERENCES trust_domains (id)
+);
@@ -0,0 +1,18 @@
+-- the join tokens are stored as a salted hash, the plaintext tokens cannot be hashed by SQL so they are dropped
+DROP TABLE IF EXISTS join_tokens;
+CREATE TABLE IF NOT EXISTS join_tokens
+(
+    id              TEXT PRIMARY KEY,
+    trust_domain_id TEXT      NOT NULL,
+    token_hash      BLOB      NOT NULL,
+    token_salt      BLOB      NOT NULL,
+    token_hint      TEXT      NOT NULL,
+    used            BOOL      NOT NULL DEFAULT 0,
+    expires_at      TIMESTAMP NOT NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (trust_domain_id)
+        REFERENCES trust_domains (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_join_tokens_token_hint ON join_tokens (token_hint);
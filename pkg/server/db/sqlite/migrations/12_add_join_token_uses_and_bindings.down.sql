@@ -0,0 +1,8 @@
+ALTER TABLE join_tokens
+    DROP COLUMN source_cidr;
+ALTER TABLE join_tokens
+    DROP COLUMN harvester_key_fingerprint;
+ALTER TABLE join_tokens
+    DROP COLUMN use_count;
+ALTER TABLE join_tokens
+    DROP COLUMN max_uses;
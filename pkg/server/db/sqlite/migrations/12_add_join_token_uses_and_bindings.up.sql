@@ -0,0 +1,13 @@
+-- the join tokens can be used several times, and can be bound to the key of a Harvester or to a source CIDR
+ALTER TABLE join_tokens
+    ADD COLUMN max_uses INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE join_tokens
+    ADD COLUMN use_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE join_tokens
+    ADD COLUMN harvester_key_fingerprint TEXT;
+ALTER TABLE join_tokens
+    ADD COLUMN source_cidr TEXT;
+
+UPDATE join_tokens
+SET use_count = 1
+WHERE used = 1;
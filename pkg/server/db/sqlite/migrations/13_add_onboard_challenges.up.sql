@@ -0,0 +1,12 @@
+-- the nonces issued to the Harvesters to onboard by proving the control of their trust domain. A nonce is deleted
+-- when it is used, so that a signed onboard statement cannot be replayed.
+CREATE TABLE IF NOT EXISTS onboard_challenges
+(
+    nonce           TEXT      NOT NULL PRIMARY KEY,
+    trust_domain_id TEXT      NOT NULL,
+    expires_at      TIMESTAMP NOT NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (trust_domain_id)
+        REFERENCES trust_domains (id)
+        ON DELETE CASCADE
+);
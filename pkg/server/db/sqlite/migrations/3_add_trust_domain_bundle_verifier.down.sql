@@ -0,0 +1,2 @@
+ALTER TABLE trust_domains
+    DROP COLUMN bundle_verifier;
@@ -0,0 +1,2 @@
+ALTER TABLE trust_domains
+    ADD COLUMN bundle_verifier TEXT;
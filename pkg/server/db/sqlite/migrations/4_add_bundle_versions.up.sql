@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS bundle_versions
+(
+    id                        TEXT PRIMARY KEY,
+    trust_domain_id           TEXT      NOT NULL,
+    version                   INTEGER   NOT NULL,
+    data                      BLOB      NOT NULL,
+    digest                    BLOB      NOT NULL,
+    signature                 BLOB,
+    signing_certificate_chain BLOB,
+    created_at                TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (trust_domain_id, version),
+    FOREIGN KEY (trust_domain_id)
+        REFERENCES trust_domains (id)
+        ON DELETE CASCADE
+);
+
+ALTER TABLE bundles
+    ADD COLUMN pinned_version INTEGER;
+
+-- the bundles stored before the history was introduced become the first version
+INSERT INTO bundle_versions(id, trust_domain_id, version, data, digest, signature, signing_certificate_chain, created_at)
+SELECT id, trust_domain_id, 1, data, digest, signature, signing_certificate_chain, updated_at
+FROM bundles;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS harvester_leases
+(
+    trust_domain_id TEXT PRIMARY KEY,
+    instance_id     TEXT      NOT NULL,
+    expires_at      TIMESTAMP NOT NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (trust_domain_id)
+        REFERENCES trust_domains (id)
+        ON DELETE CASCADE
+);
+
+-- the Harvester instance that uploaded each bundle version, empty for uploads made without an instance ID
+ALTER TABLE bundle_versions
+    ADD COLUMN uploaded_by TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS server_leases;
+DROP TABLE IF EXISTS signing_keys;
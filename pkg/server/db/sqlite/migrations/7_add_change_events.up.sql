@@ -0,0 +1,14 @@
+-- the change log of the bundles and relationships, read by the incremental bundle sync of the Harvesters.
+-- The rows are not bound to the trust domains, so that the changes made by deleting a trust domain are kept.
+CREATE TABLE IF NOT EXISTS change_events
+(
+    id                     INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind                   TEXT      NOT NULL,
+    trust_domain_id        TEXT      NOT NULL,
+    trust_domain_name      TEXT      NOT NULL,
+    peer_trust_domain_id   TEXT,
+    peer_trust_domain_name TEXT,
+    created_at             TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS change_events_created_at_idx ON change_events (created_at);
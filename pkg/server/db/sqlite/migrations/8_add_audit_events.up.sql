@@ -0,0 +1,16 @@
+-- the tamper-evident audit log of the administrative and Harvester actions. Each event holds the hash of the
+-- previous one, the unique prev_hash prevents the chain from forking when replicas append events concurrently.
+CREATE TABLE IF NOT EXISTS audit_events
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    actor      TEXT      NOT NULL,
+    action     TEXT      NOT NULL,
+    target     TEXT      NOT NULL,
+    details    TEXT      NOT NULL,
+    prev_hash  BLOB      NOT NULL UNIQUE,
+    hash       BLOB      NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS audit_events_created_at_idx ON audit_events (created_at);
+CREATE INDEX IF NOT EXISTS audit_events_actor_idx ON audit_events (actor);
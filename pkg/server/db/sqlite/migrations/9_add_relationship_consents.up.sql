@@ -0,0 +1,19 @@
+-- the consent of a trust domain to a relationship, signed by its Harvester. The signed statement is kept as it was
+-- signed, so that the peers can verify the signature.
+CREATE TABLE IF NOT EXISTS relationship_consents
+(
+    relationship_id           TEXT      NOT NULL,
+    trust_domain_id           TEXT      NOT NULL,
+    consent_statement         BLOB      NOT NULL,
+    signature                 BLOB,
+    signing_certificate_chain BLOB,
+    created_at                TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at                TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (relationship_id, trust_domain_id),
+    FOREIGN KEY (relationship_id)
+        REFERENCES relationships (id)
+        ON DELETE CASCADE,
+    FOREIGN KEY (trust_domain_id)
+        REFERENCES trust_domains (id)
+        ON DELETE CASCADE
+);
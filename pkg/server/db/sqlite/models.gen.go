@@ -9,6 +9,17 @@ import (
 	"time"
 )
 
+type AuditEvent struct {
+	ID        int64
+	Actor     string
+	Action    string
+	Target    string
+	Details   string
+	PrevHash  []byte
+	Hash      []byte
+	CreatedAt time.Time
+}
+
 type Bundle struct {
 	ID                      string
 	TrustDomainID           string
@@ -18,18 +29,67 @@ type Bundle struct {
 	SigningCertificateChain []byte
 	CreatedAt               time.Time
 	UpdatedAt               time.Time
+	PinnedVersion           sql.NullInt64
 }
 
-type JoinToken struct {
-	ID            string
+type BundleResetApproval struct {
 	TrustDomainID string
-	Token         string
-	Used          bool
+	CreatedAt     time.Time
+}
+
+type BundleVersion struct {
+	ID                      string
+	TrustDomainID           string
+	Version                 int64
+	Data                    []byte
+	Digest                  []byte
+	Signature               []byte
+	SigningCertificateChain []byte
+	CreatedAt               time.Time
+	UploadedBy              string
+}
+
+type ChangeEvent struct {
+	ID                  int64
+	Kind                string
+	TrustDomainID       string
+	TrustDomainName     string
+	PeerTrustDomainID   sql.NullString
+	PeerTrustDomainName sql.NullString
+	CreatedAt           time.Time
+}
+
+type HarvesterLease struct {
+	TrustDomainID string
+	InstanceID    string
 	ExpiresAt     time.Time
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 }
 
+type JoinToken struct {
+	ID                      string
+	TrustDomainID           string
+	TokenHash               []byte
+	TokenSalt               []byte
+	TokenHint               string
+	Used                    bool
+	ExpiresAt               time.Time
+	CreatedAt               time.Time
+	UpdatedAt               time.Time
+	MaxUses                 int64
+	UseCount                int64
+	HarvesterKeyFingerprint sql.NullString
+	SourceCidr              sql.NullString
+}
+
+type OnboardChallenge struct {
+	Nonce         string
+	TrustDomainID string
+	ExpiresAt     time.Time
+	CreatedAt     time.Time
+}
+
 type Relationship struct {
 	ID                  string
 	TrustDomainAID      string
@@ -40,10 +100,36 @@ type Relationship struct {
 	UpdatedAt           time.Time
 }
 
+type RelationshipConsent struct {
+	RelationshipID          string
+	TrustDomainID           string
+	ConsentStatement        []byte
+	Signature               []byte
+	SigningCertificateChain []byte
+	CreatedAt               time.Time
+	UpdatedAt               time.Time
+}
+
+type ServerLease struct {
+	Name      string
+	HolderID  string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type SigningKey struct {
+	ID           string
+	EncryptedKey []byte
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
 type TrustDomain struct {
-	ID          string
-	Name        string
-	Description sql.NullString
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID             string
+	Name           string
+	Description    sql.NullString
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	BundleVerifier sql.NullString
 }
@@ -6,32 +6,71 @@ package sqlite
 
 import (
 	"context"
+	"time"
 )
 
 type Querier interface {
+	// the lease is only acquired if it is held by the same instance or it expired before updated_at
+	AcquireHarvesterLease(ctx context.Context, arg AcquireHarvesterLeaseParams) (HarvesterLease, error)
+	// the lease is only acquired if it is held by the same replica or it expired before updated_at
+	AcquireServerLease(ctx context.Context, arg AcquireServerLeaseParams) (ServerLease, error)
+	// no event is created if another event is already chained to the same previous event
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error)
 	CreateBundle(ctx context.Context, arg CreateBundleParams) (Bundle, error)
+	CreateBundleChangeEvent(ctx context.Context, arg CreateBundleChangeEventParams) error
+	CreateBundleVersion(ctx context.Context, arg CreateBundleVersionParams) (BundleVersion, error)
 	CreateJoinToken(ctx context.Context, arg CreateJoinTokenParams) (JoinToken, error)
+	CreateOnboardChallenge(ctx context.Context, arg CreateOnboardChallengeParams) error
+	CreateOrUpdateBundleResetApproval(ctx context.Context, arg CreateOrUpdateBundleResetApprovalParams) (BundleResetApproval, error)
+	CreateOrUpdateRelationshipConsent(ctx context.Context, arg CreateOrUpdateRelationshipConsentParams) (RelationshipConsent, error)
+	CreateOrUpdateSigningKey(ctx context.Context, arg CreateOrUpdateSigningKeyParams) (SigningKey, error)
 	CreateRelationship(ctx context.Context, arg CreateRelationshipParams) (Relationship, error)
+	CreateRelationshipChangeEvent(ctx context.Context, arg CreateRelationshipChangeEventParams) error
 	CreateTrustDomain(ctx context.Context, arg CreateTrustDomainParams) (TrustDomain, error)
+	CreateTrustDomainChangeEvent(ctx context.Context, arg CreateTrustDomainChangeEventParams) error
 	DeleteBundle(ctx context.Context, id string) error
+	DeleteBundleResetApproval(ctx context.Context, trustDomainID string) error
+	// the latest change is kept, so that the cursors that are up to date remain valid
+	DeleteChangeEventsBefore(ctx context.Context, createdAt time.Time) error
+	DeleteHarvesterLease(ctx context.Context, arg DeleteHarvesterLeaseParams) error
 	DeleteJoinToken(ctx context.Context, id string) error
+	DeleteJoinTokensExpiredBefore(ctx context.Context, expiresAt time.Time) error
+	DeleteOnboardChallengesExpiredBefore(ctx context.Context, expiresAt time.Time) error
 	DeleteRelationship(ctx context.Context, id string) error
+	DeleteRelationshipConsent(ctx context.Context, arg DeleteRelationshipConsentParams) error
+	DeleteServerLease(ctx context.Context, arg DeleteServerLeaseParams) error
+	DeleteSigningKey(ctx context.Context, id string) error
 	DeleteTrustDomain(ctx context.Context, id string) error
 	FindBundleByID(ctx context.Context, id string) (Bundle, error)
 	FindBundleByTrustDomainID(ctx context.Context, trustDomainID string) (Bundle, error)
-	FindJoinToken(ctx context.Context, token string) (JoinToken, error)
+	FindBundleResetApproval(ctx context.Context, trustDomainID string) (BundleResetApproval, error)
+	FindBundleVersion(ctx context.Context, arg FindBundleVersionParams) (BundleVersion, error)
+	FindBundleVersionByDigest(ctx context.Context, arg FindBundleVersionByDigestParams) (BundleVersion, error)
+	FindHarvesterLease(ctx context.Context, trustDomainID string) (HarvesterLease, error)
 	FindJoinTokenByID(ctx context.Context, id string) (JoinToken, error)
+	FindJoinTokensByHint(ctx context.Context, tokenHint string) ([]JoinToken, error)
 	FindJoinTokensByTrustDomainID(ctx context.Context, trustDomainID string) ([]JoinToken, error)
+	FindLatestAuditEvent(ctx context.Context) (AuditEvent, error)
+	FindLatestChangeEventID(ctx context.Context) (int64, error)
+	FindOldestChangeEventID(ctx context.Context) (int64, error)
 	FindRelationshipByID(ctx context.Context, id string) (Relationship, error)
+	FindRelationshipConsentsByRelationshipID(ctx context.Context, relationshipID string) ([]RelationshipConsent, error)
 	FindRelationshipsByTrustDomainID(ctx context.Context, arg FindRelationshipsByTrustDomainIDParams) ([]Relationship, error)
+	FindSigningKeyByID(ctx context.Context, id string) (SigningKey, error)
 	FindTrustDomainByID(ctx context.Context, id string) (TrustDomain, error)
 	FindTrustDomainByName(ctx context.Context, name string) (TrustDomain, error)
+	ListBundleVersions(ctx context.Context, trustDomainID string) ([]BundleVersion, error)
 	ListBundles(ctx context.Context) ([]Bundle, error)
+	ListChangeEvents(ctx context.Context, arg ListChangeEventsParams) ([]ChangeEvent, error)
 	ListJoinTokens(ctx context.Context) ([]JoinToken, error)
+	ListSigningKeys(ctx context.Context) ([]SigningKey, error)
 	UpdateBundle(ctx context.Context, arg UpdateBundleParams) (Bundle, error)
 	UpdateJoinToken(ctx context.Context, arg UpdateJoinTokenParams) (JoinToken, error)
 	UpdateRelationship(ctx context.Context, arg UpdateRelationshipParams) (Relationship, error)
 	UpdateTrustDomain(ctx context.Context, arg UpdateTrustDomainParams) (TrustDomain, error)
+	UseJoinToken(ctx context.Context, id string) (JoinToken, error)
+	// the challenge is deleted when it is used, so that it can only be used once, and only before it expires
+	UseOnboardChallenge(ctx context.Context, arg UseOnboardChallengeParams) (OnboardChallenge, error)
 }
 
 var _ Querier = (*Queries)(nil)
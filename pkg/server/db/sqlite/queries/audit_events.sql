@@ -0,0 +1,12 @@
+-- name: CreateAuditEvent :one
+-- no event is created if another event is already chained to the same previous event
+INSERT INTO audit_events(actor, action, target, details, prev_hash, hash, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (prev_hash) DO NOTHING
+RETURNING *;
+
+-- name: FindLatestAuditEvent :one
+SELECT *
+FROM audit_events
+ORDER BY id DESC
+LIMIT 1;
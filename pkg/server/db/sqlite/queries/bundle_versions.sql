@@ -0,0 +1,33 @@
+-- name: CreateBundleVersion :one
+INSERT INTO bundle_versions(id, trust_domain_id, version, data, digest, signature, signing_certificate_chain, uploaded_by)
+SELECT ?,
+       ?,
+       COALESCE(MAX(bv.version), 0) + 1,
+       ?,
+       ?,
+       ?,
+       ?,
+       ?
+FROM bundle_versions bv
+WHERE bv.trust_domain_id = ?
+RETURNING *;
+
+-- name: FindBundleVersion :one
+SELECT *
+FROM bundle_versions
+WHERE trust_domain_id = ?
+  AND version = ?;
+
+-- name: FindBundleVersionByDigest :one
+SELECT *
+FROM bundle_versions
+WHERE trust_domain_id = ?
+  AND digest = ?
+ORDER BY version DESC
+LIMIT 1;
+
+-- name: ListBundleVersions :many
+SELECT *
+FROM bundle_versions
+WHERE trust_domain_id = ?
+ORDER BY version DESC;
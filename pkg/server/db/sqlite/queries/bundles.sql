@@ -1,6 +1,6 @@
 -- name: CreateBundle :one
-INSERT INTO bundles(id, data, digest, signature, signing_certificate_chain, trust_domain_id, created_at)
-VALUES (?, ?, ?, ?, ?, ?, ?)
+INSERT INTO bundles(id, data, digest, signature, signing_certificate_chain, trust_domain_id, pinned_version, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: UpdateBundle :one
@@ -9,6 +9,7 @@ SET data                = ?,
     digest              = ?,
     signature           = ?,
     signing_certificate_chain = ?,
+    pinned_version      = ?,
     updated_at          = datetime('now')
 WHERE id = ?
 RETURNING *;
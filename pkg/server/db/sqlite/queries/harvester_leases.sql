@@ -0,0 +1,22 @@
+-- name: AcquireHarvesterLease :one
+-- the lease is only acquired if it is held by the same instance or it expired before updated_at
+INSERT INTO harvester_leases(trust_domain_id, instance_id, expires_at, updated_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (trust_domain_id) DO UPDATE
+    SET instance_id = excluded.instance_id,
+        expires_at  = excluded.expires_at,
+        updated_at  = excluded.updated_at
+WHERE harvester_leases.instance_id = excluded.instance_id
+   OR harvester_leases.expires_at < excluded.updated_at
+RETURNING *;
+
+-- name: FindHarvesterLease :one
+SELECT *
+FROM harvester_leases
+WHERE trust_domain_id = ?;
+
+-- name: DeleteHarvesterLease :exec
+DELETE
+FROM harvester_leases
+WHERE trust_domain_id = ?
+  AND instance_id = ?;
@@ -1,6 +1,7 @@
 -- name: CreateJoinToken :one
-INSERT INTO join_tokens(id, token, expires_at, trust_domain_id, created_at)
-VALUES (?, ?, ?, ?, ?)
+INSERT INTO join_tokens(id, token_hash, token_salt, token_hint, expires_at, trust_domain_id, created_at, max_uses,
+                        harvester_key_fingerprint, source_cidr)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: UpdateJoinToken :one
@@ -10,6 +11,15 @@ SET used       = ?,
 WHERE id = ?
 RETURNING *;
 
+-- name: UseJoinToken :one
+UPDATE join_tokens
+SET use_count  = use_count + 1,
+    used       = use_count + 1 >= max_uses,
+    updated_at = datetime('now')
+WHERE id = ?
+  AND use_count < max_uses
+RETURNING *;
+
 -- name: DeleteJoinToken :exec
 DELETE
 FROM join_tokens
@@ -20,10 +30,10 @@ SELECT *
 FROM join_tokens
 WHERE id = ?;
 
--- name: FindJoinToken :one
+-- name: FindJoinTokensByHint :many
 SELECT *
 FROM join_tokens
-WHERE token = ?;
+WHERE token_hint = ?;
 
 -- name: FindJoinTokensByTrustDomainID :many
 SELECT *
@@ -35,3 +45,8 @@ ORDER BY created_at DESC;
 SELECT *
 FROM join_tokens
 ORDER BY created_at DESC;
+
+-- name: DeleteJoinTokensExpiredBefore :exec
+DELETE
+FROM join_tokens
+WHERE expires_at < ?;
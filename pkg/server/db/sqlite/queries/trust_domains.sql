@@ -1,12 +1,13 @@
 -- name: CreateTrustDomain :one
-INSERT INTO trust_domains(id, name, description, created_at)
-VALUES (?, ?, ?, ?)
+INSERT INTO trust_domains(id, name, description, bundle_verifier, created_at)
+VALUES (?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: UpdateTrustDomain :one
 UPDATE trust_domains
-SET description = ?,
-    updated_at  = datetime('now')
+SET description     = ?,
+    bundle_verifier = ?,
+    updated_at      = datetime('now')
 WHERE id = ?
 RETURNING *;
 
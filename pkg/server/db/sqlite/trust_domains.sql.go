@@ -12,16 +12,17 @@ import (
 )
 
 const createTrustDomain = `-- name: CreateTrustDomain :one
-INSERT INTO trust_domains(id, name, description, created_at)
-VALUES (?, ?, ?, ?)
-RETURNING id, name, description, created_at, updated_at
+INSERT INTO trust_domains(id, name, description, bundle_verifier, created_at)
+VALUES (?, ?, ?, ?, ?)
+RETURNING id, name, description, created_at, updated_at, bundle_verifier
 `
 
 type CreateTrustDomainParams struct {
-	ID          string
-	Name        string
-	Description sql.NullString
-	CreatedAt   time.Time
+	ID             string
+	Name           string
+	Description    sql.NullString
+	BundleVerifier sql.NullString
+	CreatedAt      time.Time
 }
 
 func (q *Queries) CreateTrustDomain(ctx context.Context, arg CreateTrustDomainParams) (TrustDomain, error) {
@@ -29,6 +30,7 @@ func (q *Queries) CreateTrustDomain(ctx context.Context, arg CreateTrustDomainPa
 		arg.ID,
 		arg.Name,
 		arg.Description,
+		arg.BundleVerifier,
 		arg.CreatedAt,
 	)
 	var i TrustDomain
@@ -38,6 +40,7 @@ func (q *Queries) CreateTrustDomain(ctx context.Context, arg CreateTrustDomainPa
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BundleVerifier,
 	)
 	return i, err
 }
@@ -54,7 +57,7 @@ func (q *Queries) DeleteTrustDomain(ctx context.Context, id string) error {
 }
 
 const findTrustDomainByID = `-- name: FindTrustDomainByID :one
-SELECT id, name, description, created_at, updated_at
+SELECT id, name, description, created_at, updated_at, bundle_verifier
 FROM trust_domains
 WHERE id = ?
 `
@@ -68,12 +71,13 @@ func (q *Queries) FindTrustDomainByID(ctx context.Context, id string) (TrustDoma
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BundleVerifier,
 	)
 	return i, err
 }
 
 const findTrustDomainByName = `-- name: FindTrustDomainByName :one
-SELECT id, name, description, created_at, updated_at
+SELECT id, name, description, created_at, updated_at, bundle_verifier
 FROM trust_domains
 WHERE name = ?
 `
@@ -87,25 +91,28 @@ func (q *Queries) FindTrustDomainByName(ctx context.Context, name string) (Trust
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BundleVerifier,
 	)
 	return i, err
 }
 
 const updateTrustDomain = `-- name: UpdateTrustDomain :one
 UPDATE trust_domains
-SET description = ?,
-    updated_at  = datetime('now')
+SET description     = ?,
+    bundle_verifier = ?,
+    updated_at      = datetime('now')
 WHERE id = ?
-RETURNING id, name, description, created_at, updated_at
+RETURNING id, name, description, created_at, updated_at, bundle_verifier
 `
 
 type UpdateTrustDomainParams struct {
-	Description sql.NullString
-	ID          string
+	Description    sql.NullString
+	BundleVerifier sql.NullString
+	ID             string
 }
 
 func (q *Queries) UpdateTrustDomain(ctx context.Context, arg UpdateTrustDomainParams) (TrustDomain, error) {
-	row := q.queryRow(ctx, q.updateTrustDomainStmt, updateTrustDomain, arg.Description, arg.ID)
+	row := q.queryRow(ctx, q.updateTrustDomainStmt, updateTrustDomain, arg.Description, arg.BundleVerifier, arg.ID)
 	var i TrustDomain
 	err := row.Scan(
 		&i.ID,
@@ -113,6 +120,7 @@ func (q *Queries) UpdateTrustDomain(ctx context.Context, arg UpdateTrustDomainPa
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BundleVerifier,
 	)
 	return i, err
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	"github.com/HewlettPackard/galadriel/pkg/server/db"
+	"github.com/HewlettPackard/galadriel/pkg/server/db/criteria"
 	"github.com/google/uuid"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/stretchr/testify/assert"
@@ -38,6 +39,13 @@ func TestDatastoreOperations(t *testing.T) {
 }
 
 func runDatastoreTests(t *testing.T, ctx context.Context, newDS func(*testing.T) db.Datastore) {
+	t.Run("Test Ping", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS(t)
+
+		require.NoError(t, ds.Ping(ctx))
+	})
+
 	t.Run("Test CRUD TrustDomains", func(t *testing.T) {
 		t.Parallel()
 		ds := newDS(t)
@@ -56,12 +64,14 @@ func runDatastoreTests(t *testing.T, ctx context.Context, newDS func(*testing.T)
 
 		// Create second trust domain
 		req2 := &entity.TrustDomain{
-			Name: spiffeTD2,
+			Name:           spiffeTD2,
+			BundleVerifier: "disk",
 		}
 		td2, err := ds.CreateOrUpdateTrustDomain(ctx, req2)
 		assert.NoError(t, err)
 		assert.NotNil(t, td2.ID)
 		assert.Equal(t, req2.Name, td2.Name)
+		assert.Equal(t, req2.BundleVerifier, td2.BundleVerifier)
 		assert.NotNil(t, td2.CreatedAt)
 		assert.NotNil(t, td2.UpdatedAt)
 
@@ -75,6 +85,7 @@ func runDatastoreTests(t *testing.T, ctx context.Context, newDS func(*testing.T)
 
 		// Update trust domain
 		td1.Description = "updated_description"
+		td1.BundleVerifier = "noop"
 
 		updated1, err := ds.CreateOrUpdateTrustDomain(ctx, td1)
 		assert.NoError(t, err)
@@ -85,6 +96,7 @@ func runDatastoreTests(t *testing.T, ctx context.Context, newDS func(*testing.T)
 		assert.NoError(t, err)
 		assert.Equal(t, td1.ID, stored.ID)
 		assert.Equal(t, td1.Description, stored.Description)
+		assert.Equal(t, td1.BundleVerifier, stored.BundleVerifier)
 
 		// Find trust domain by name
 		td1 = updated1
@@ -341,6 +353,7 @@ func runDatastoreTests(t *testing.T, ctx context.Context, newDS func(*testing.T)
 		b1.Digest = []byte("test-digest-3")
 		b1.Signature = []byte{'f', 'g', 'h'}
 		b1.SigningCertificateChain = []byte{'f', 'g', 'h'}
+		b1.PinnedVersion = 2
 
 		updated, err := ds.CreateOrUpdateBundle(ctx, b1)
 		assert.NoError(t, err)
@@ -350,6 +363,7 @@ func runDatastoreTests(t *testing.T, ctx context.Context, newDS func(*testing.T)
 		assert.Equal(t, b1.Signature, updated.Signature)
 		assert.Equal(t, b1.SigningCertificateChain, updated.SigningCertificateChain)
 		assert.Equal(t, b1.TrustDomainID, updated.TrustDomainID)
+		assert.Equal(t, b1.PinnedVersion, updated.PinnedVersion)
 
 		// Look up bundle stored in DB and compare
 		stored, err = ds.FindBundleByID(ctx, b1.ID.UUID)
@@ -417,6 +431,524 @@ func runDatastoreTests(t *testing.T, ctx context.Context, newDS func(*testing.T)
 
 		assertErrorString(t, err, sqliteExpectedUniqueErr, postgresExpectedUniqueErr)
 	})
+	t.Run("Test CRUD Bundle Versions", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS(t)
+
+		td1 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD1})
+		td2 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD2})
+
+		// Versions are numbered per trust domain
+		req1 := &entity.BundleVersion{
+			TrustDomainID:           td1.ID.UUID,
+			Data:                    []byte{1, 2, 3},
+			Digest:                  []byte("test-digest-1"),
+			Signature:               []byte{4, 2},
+			SigningCertificateChain: []byte{50, 60},
+			UploadedBy:              "harvester-1",
+		}
+		v1, err := ds.CreateBundleVersion(ctx, req1)
+		require.NoError(t, err)
+		assert.True(t, v1.ID.Valid)
+		assert.Equal(t, int64(1), v1.Version)
+		assert.Equal(t, req1.Data, v1.Data)
+		assert.Equal(t, req1.Digest, v1.Digest)
+		assert.Equal(t, req1.Signature, v1.Signature)
+		assert.Equal(t, req1.SigningCertificateChain, v1.SigningCertificateChain)
+		assert.Equal(t, td1.ID.UUID, v1.TrustDomainID)
+		assert.Equal(t, "harvester-1", v1.UploadedBy)
+
+		v2, err := ds.CreateBundleVersion(ctx, &entity.BundleVersion{
+			TrustDomainID: td1.ID.UUID,
+			Data:          []byte{10, 20, 30},
+			Digest:        []byte("test-digest-2"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), v2.Version)
+
+		other, err := ds.CreateBundleVersion(ctx, &entity.BundleVersion{
+			TrustDomainID: td2.ID.UUID,
+			Data:          []byte{1, 2, 3},
+			Digest:        []byte("test-digest-1"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), other.Version)
+
+		// Find versions
+		stored, err := ds.FindBundleVersion(ctx, td1.ID.UUID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, v1, stored)
+
+		stored, err = ds.FindBundleVersion(ctx, td1.ID.UUID, 3)
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+
+		stored, err = ds.FindBundleVersionByDigest(ctx, td1.ID.UUID, []byte("test-digest-2"))
+		require.NoError(t, err)
+		assert.Equal(t, v2, stored)
+
+		stored, err = ds.FindBundleVersionByDigest(ctx, td2.ID.UUID, []byte("test-digest-2"))
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+
+		// List versions, latest first
+		versions, err := ds.ListBundleVersions(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.BundleVersion{v2, v1}, versions)
+
+		// Versions are deleted with their trust domain
+		err = ds.DeleteTrustDomain(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+
+		versions, err = ds.ListBundleVersions(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		assert.Empty(t, versions)
+	})
+	t.Run("Test Harvester Leases", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS(t)
+
+		td1 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD1})
+		now := time.Now().UTC().Truncate(time.Second)
+
+		lease, err := ds.FindHarvesterLease(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		assert.Nil(t, lease)
+
+		// The free lease is acquired
+		lease, err = ds.AcquireHarvesterLease(ctx, &entity.HarvesterLease{
+			TrustDomainID: td1.ID.UUID,
+			InstanceID:    "harvester-1",
+			ExpiresAt:     now.Add(time.Minute),
+		}, now)
+		require.NoError(t, err)
+		require.NotNil(t, lease)
+		assert.Equal(t, "harvester-1", lease.InstanceID)
+		assert.True(t, now.Add(time.Minute).Equal(lease.ExpiresAt))
+
+		// Another instance cannot acquire the lease before it expires
+		lease, err = ds.AcquireHarvesterLease(ctx, &entity.HarvesterLease{
+			TrustDomainID: td1.ID.UUID,
+			InstanceID:    "harvester-2",
+			ExpiresAt:     now.Add(2 * time.Minute),
+		}, now.Add(30*time.Second))
+		require.NoError(t, err)
+		assert.Nil(t, lease)
+
+		// The holder renews the lease
+		lease, err = ds.AcquireHarvesterLease(ctx, &entity.HarvesterLease{
+			TrustDomainID: td1.ID.UUID,
+			InstanceID:    "harvester-1",
+			ExpiresAt:     now.Add(2 * time.Minute),
+		}, now.Add(30*time.Second))
+		require.NoError(t, err)
+		require.NotNil(t, lease)
+		assert.True(t, now.Add(2*time.Minute).Equal(lease.ExpiresAt))
+
+		// Another instance takes over the expired lease
+		lease, err = ds.AcquireHarvesterLease(ctx, &entity.HarvesterLease{
+			TrustDomainID: td1.ID.UUID,
+			InstanceID:    "harvester-2",
+			ExpiresAt:     now.Add(4 * time.Minute),
+		}, now.Add(3*time.Minute))
+		require.NoError(t, err)
+		require.NotNil(t, lease)
+		assert.Equal(t, "harvester-2", lease.InstanceID)
+
+		stored, err := ds.FindHarvesterLease(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, "harvester-2", stored.InstanceID)
+
+		// Only the holder releases the lease
+		err = ds.DeleteHarvesterLease(ctx, td1.ID.UUID, "harvester-1")
+		require.NoError(t, err)
+		stored, err = ds.FindHarvesterLease(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		assert.NotNil(t, stored)
+
+		err = ds.DeleteHarvesterLease(ctx, td1.ID.UUID, "harvester-2")
+		require.NoError(t, err)
+		stored, err = ds.FindHarvesterLease(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+	})
+
+	t.Run("Test Bundle Reset Approvals", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS(t)
+
+		td1 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD1})
+		td2 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD2})
+
+		approval, err := ds.FindBundleResetApproval(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		assert.Nil(t, approval)
+
+		approval, err = ds.CreateOrUpdateBundleResetApproval(ctx, &entity.BundleResetApproval{TrustDomainID: td1.ID.UUID})
+		require.NoError(t, err)
+		assert.Equal(t, td1.ID.UUID, approval.TrustDomainID)
+		assert.False(t, approval.CreatedAt.IsZero())
+
+		// Approving again renews the approval
+		_, err = ds.CreateOrUpdateBundleResetApproval(ctx, &entity.BundleResetApproval{TrustDomainID: td1.ID.UUID})
+		require.NoError(t, err)
+		_, err = ds.CreateOrUpdateBundleResetApproval(ctx, &entity.BundleResetApproval{TrustDomainID: td2.ID.UUID})
+		require.NoError(t, err)
+
+		stored, err := ds.FindBundleResetApproval(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, td1.ID.UUID, stored.TrustDomainID)
+
+		err = ds.DeleteBundleResetApproval(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		stored, err = ds.FindBundleResetApproval(ctx, td1.ID.UUID)
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+
+		// Approvals are deleted with their trust domain
+		err = ds.DeleteTrustDomain(ctx, td2.ID.UUID)
+		require.NoError(t, err)
+		stored, err = ds.FindBundleResetApproval(ctx, td2.ID.UUID)
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+	})
+
+	t.Run("Test Signing Keys", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS(t)
+
+		signingKey, err := ds.FindSigningKeyByID(ctx, "key-1")
+		require.NoError(t, err)
+		assert.Nil(t, signingKey)
+
+		createdAt := time.Now().UTC().Truncate(time.Second)
+		stored, err := ds.CreateOrUpdateSigningKey(ctx, &entity.SigningKey{ID: "key-1", EncryptedKey: []byte("encrypted-1"), CreatedAt: createdAt})
+		require.NoError(t, err)
+		assert.Equal(t, "key-1", stored.ID)
+		assert.Equal(t, []byte("encrypted-1"), stored.EncryptedKey)
+
+		_, err = ds.CreateOrUpdateSigningKey(ctx, &entity.SigningKey{ID: "key-2", EncryptedKey: []byte("encrypted-2"), CreatedAt: createdAt.Add(time.Minute)})
+		require.NoError(t, err)
+
+		// The key with the same ID is overwritten
+		_, err = ds.CreateOrUpdateSigningKey(ctx, &entity.SigningKey{ID: "key-1", EncryptedKey: []byte("encrypted-3"), CreatedAt: createdAt})
+		require.NoError(t, err)
+
+		signingKey, err = ds.FindSigningKeyByID(ctx, "key-1")
+		require.NoError(t, err)
+		require.NotNil(t, signingKey)
+		assert.Equal(t, []byte("encrypted-3"), signingKey.EncryptedKey)
+
+		signingKeys, err := ds.ListSigningKeys(ctx)
+		require.NoError(t, err)
+		require.Len(t, signingKeys, 2)
+		assert.Equal(t, "key-1", signingKeys[0].ID)
+		assert.Equal(t, "key-2", signingKeys[1].ID)
+	})
+	t.Run("Test Server Leases", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS(t)
+
+		now := time.Now().UTC().Truncate(time.Second)
+
+		// The free lease is acquired
+		lease, err := ds.AcquireServerLease(ctx, &entity.ServerLease{Name: "leader", HolderID: "server-1", ExpiresAt: now.Add(time.Minute)}, now)
+		require.NoError(t, err)
+		require.NotNil(t, lease)
+		assert.Equal(t, "server-1", lease.HolderID)
+
+		// Another replica cannot acquire the lease before it expires
+		lease, err = ds.AcquireServerLease(ctx, &entity.ServerLease{Name: "leader", HolderID: "server-2", ExpiresAt: now.Add(2 * time.Minute)}, now.Add(30*time.Second))
+		require.NoError(t, err)
+		assert.Nil(t, lease)
+
+		// Leases with different names are independent
+		lease, err = ds.AcquireServerLease(ctx, &entity.ServerLease{Name: "other", HolderID: "server-2", ExpiresAt: now.Add(time.Minute)}, now)
+		require.NoError(t, err)
+		require.NotNil(t, lease)
+
+		// The holder renews the lease
+		lease, err = ds.AcquireServerLease(ctx, &entity.ServerLease{Name: "leader", HolderID: "server-1", ExpiresAt: now.Add(2 * time.Minute)}, now.Add(30*time.Second))
+		require.NoError(t, err)
+		require.NotNil(t, lease)
+		assert.True(t, now.Add(2*time.Minute).Equal(lease.ExpiresAt))
+
+		// Another replica takes over the expired lease
+		lease, err = ds.AcquireServerLease(ctx, &entity.ServerLease{Name: "leader", HolderID: "server-2", ExpiresAt: now.Add(4 * time.Minute)}, now.Add(3*time.Minute))
+		require.NoError(t, err)
+		require.NotNil(t, lease)
+		assert.Equal(t, "server-2", lease.HolderID)
+
+		// Only the holder releases the lease
+		require.NoError(t, ds.DeleteServerLease(ctx, "leader", "server-1"))
+		lease, err = ds.AcquireServerLease(ctx, &entity.ServerLease{Name: "leader", HolderID: "server-1", ExpiresAt: now.Add(4 * time.Minute)}, now.Add(3*time.Minute))
+		require.NoError(t, err)
+		assert.Nil(t, lease)
+
+		require.NoError(t, ds.DeleteServerLease(ctx, "leader", "server-2"))
+		lease, err = ds.AcquireServerLease(ctx, &entity.ServerLease{Name: "leader", HolderID: "server-1", ExpiresAt: now.Add(4 * time.Minute)}, now.Add(3*time.Minute))
+		require.NoError(t, err)
+		require.NotNil(t, lease)
+	})
+	t.Run("Test Change Events", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS(t)
+
+		oldestID, latestID, err := ds.FindChangeEventRange(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, oldestID)
+		assert.Zero(t, latestID)
+
+		td1 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD1})
+		td2 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD2})
+
+		bundle, err := ds.CreateOrUpdateBundle(ctx, &entity.Bundle{TrustDomainID: td1.ID.UUID, Data: []byte("bundle"), Digest: []byte("digest"), Signature: []byte("signature")})
+		require.NoError(t, err)
+
+		relationship, err := ds.CreateOrUpdateRelationship(ctx, &entity.Relationship{
+			TrustDomainAID:      td1.ID.UUID,
+			TrustDomainBID:      td2.ID.UUID,
+			TrustDomainAConsent: entity.ConsentStatusPending,
+			TrustDomainBConsent: entity.ConsentStatusPending,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, ds.DeleteBundle(ctx, bundle.ID.UUID))
+		require.NoError(t, ds.DeleteRelationship(ctx, relationship.ID.UUID))
+		require.NoError(t, ds.DeleteTrustDomain(ctx, td2.ID.UUID))
+
+		oldestID, latestID, err = ds.FindChangeEventRange(ctx)
+		require.NoError(t, err)
+
+		events, err := ds.ListChangeEvents(ctx, 0, latestID)
+		require.NoError(t, err)
+		require.Len(t, events, 5)
+		assert.Equal(t, oldestID, events[0].ID)
+
+		assert.Equal(t, entity.ChangeEventKindBundle, events[0].Kind)
+		assert.Equal(t, td1.ID.UUID, events[0].TrustDomainID)
+		assert.Equal(t, spiffeTD1, events[0].TrustDomainName)
+		assert.False(t, events[0].PeerTrustDomainID.Valid)
+
+		assert.Equal(t, entity.ChangeEventKindRelationship, events[1].Kind)
+		assert.Equal(t, td1.ID.UUID, events[1].TrustDomainID)
+		assert.Equal(t, td2.ID.UUID, events[1].PeerTrustDomainID.UUID)
+		assert.Equal(t, spiffeTD2, events[1].PeerTrustDomainName)
+
+		assert.Equal(t, entity.ChangeEventKindBundle, events[2].Kind)
+		assert.Equal(t, entity.ChangeEventKindRelationship, events[3].Kind)
+
+		// the trust domain is deleted, its name is kept in the change log
+		assert.Equal(t, entity.ChangeEventKindTrustDomain, events[4].Kind)
+		assert.Equal(t, spiffeTD2, events[4].TrustDomainName)
+
+		for i := 1; i < len(events); i++ {
+			assert.Greater(t, events[i].ID, events[i-1].ID)
+		}
+
+		events, err = ds.ListChangeEvents(ctx, events[2].ID, events[3].ID)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, entity.ChangeEventKindRelationship, events[0].Kind)
+
+		// all the changes but the latest one are pruned
+		require.NoError(t, ds.PruneChangeEvents(ctx, time.Now().UTC().Add(time.Minute)))
+		oldestID, prunedLatestID, err := ds.FindChangeEventRange(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, latestID, oldestID)
+		assert.Equal(t, latestID, prunedLatestID)
+	})
+	t.Run("Test Change Events of Failed Deletion", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS(t)
+
+		td1 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD1})
+		td2 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD2})
+		_, err := ds.CreateOrUpdateRelationship(ctx, &entity.Relationship{TrustDomainAID: td1.ID.UUID, TrustDomainBID: td2.ID.UUID})
+		require.NoError(t, err)
+
+		_, latestID, err := ds.FindChangeEventRange(ctx)
+		require.NoError(t, err)
+
+		// the trust domain has a relationship, so it is not deleted, and its deletion is not recorded
+		require.Error(t, ds.DeleteTrustDomain(ctx, td1.ID.UUID))
+
+		_, latestAfterID, err := ds.FindChangeEventRange(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, latestID, latestAfterID)
+	})
+	t.Run("Test Relationship Consents", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS(t)
+
+		td1 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD1})
+		td2 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD2})
+		relationship, err := ds.CreateOrUpdateRelationship(ctx, &entity.Relationship{TrustDomainAID: td1.ID.UUID, TrustDomainBID: td2.ID.UUID})
+		require.NoError(t, err)
+
+		consents, err := ds.FindRelationshipConsentsByRelationshipID(ctx, relationship.ID.UUID)
+		require.NoError(t, err)
+		assert.Empty(t, consents)
+
+		req := &entity.RelationshipConsent{
+			RelationshipID:          relationship.ID.UUID,
+			TrustDomainID:           td1.ID.UUID,
+			ConsentStatement:        []byte("statement-1"),
+			Signature:               []byte("signature-1"),
+			SigningCertificateChain: []byte("chain-1"),
+		}
+		consent1, err := ds.CreateOrUpdateRelationshipConsent(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, req.ConsentStatement, consent1.ConsentStatement)
+		assert.Equal(t, req.Signature, consent1.Signature)
+		assert.Equal(t, req.SigningCertificateChain, consent1.SigningCertificateChain)
+
+		// a new consent of the same trust domain replaces the previous one
+		req.ConsentStatement = []byte("statement-2")
+		req.Signature = []byte("signature-2")
+		consent1, err = ds.CreateOrUpdateRelationshipConsent(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("statement-2"), consent1.ConsentStatement)
+
+		_, err = ds.CreateOrUpdateRelationshipConsent(ctx, &entity.RelationshipConsent{
+			RelationshipID:   relationship.ID.UUID,
+			TrustDomainID:    td2.ID.UUID,
+			ConsentStatement: []byte("statement-3"),
+		})
+		require.NoError(t, err)
+
+		consents, err = ds.FindRelationshipConsentsByRelationshipID(ctx, relationship.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, consents, 2)
+
+		require.NoError(t, ds.DeleteRelationshipConsent(ctx, relationship.ID.UUID, td1.ID.UUID))
+		consents, err = ds.FindRelationshipConsentsByRelationshipID(ctx, relationship.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, consents, 1)
+		assert.Equal(t, td2.ID.UUID, consents[0].TrustDomainID)
+		assert.Nil(t, consents[0].Signature)
+
+		// the consents are deleted along with the relationship
+		require.NoError(t, ds.DeleteRelationship(ctx, relationship.ID.UUID))
+		consents, err = ds.FindRelationshipConsentsByRelationshipID(ctx, relationship.ID.UUID)
+		require.NoError(t, err)
+		assert.Empty(t, consents)
+	})
+	t.Run("Test Relationship Consent Update", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS(t)
+
+		td1 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD1})
+		td2 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD2})
+		relationship, err := ds.CreateOrUpdateRelationship(ctx, &entity.Relationship{TrustDomainAID: td1.ID.UUID, TrustDomainBID: td2.ID.UUID})
+		require.NoError(t, err)
+
+		// the consent status is stored along with the signed consent
+		relationship.TrustDomainAConsent = entity.ConsentStatusApproved
+		updated, err := ds.UpdateRelationshipConsent(ctx, relationship, td1.ID.UUID, &entity.RelationshipConsent{
+			RelationshipID:   relationship.ID.UUID,
+			TrustDomainID:    td1.ID.UUID,
+			ConsentStatement: []byte("statement-1"),
+			Signature:        []byte("signature-1"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entity.ConsentStatusApproved, updated.TrustDomainAConsent)
+
+		consents, err := ds.FindRelationshipConsentsByRelationshipID(ctx, relationship.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, consents, 1)
+		assert.Equal(t, []byte("statement-1"), consents[0].ConsentStatement)
+
+		_, latestID, err := ds.FindChangeEventRange(ctx)
+		require.NoError(t, err)
+
+		// when storing the consent fails, the consent status change is rolled back
+		relationship.TrustDomainAConsent = entity.ConsentStatusDenied
+		_, err = ds.UpdateRelationshipConsent(ctx, relationship, td1.ID.UUID, &entity.RelationshipConsent{
+			RelationshipID:   relationship.ID.UUID,
+			TrustDomainID:    uuid.New(),
+			ConsentStatement: []byte("statement-2"),
+		})
+		require.Error(t, err)
+
+		stored, err := ds.FindRelationshipByID(ctx, relationship.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.ConsentStatusApproved, stored.TrustDomainAConsent)
+
+		_, latestIDAfter, err := ds.FindChangeEventRange(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, latestID, latestIDAfter)
+
+		// without a consent, the consent signed before is deleted
+		relationship.TrustDomainAConsent = entity.ConsentStatusPending
+		updated, err = ds.UpdateRelationshipConsent(ctx, relationship, td1.ID.UUID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, entity.ConsentStatusPending, updated.TrustDomainAConsent)
+
+		consents, err = ds.FindRelationshipConsentsByRelationshipID(ctx, relationship.ID.UUID)
+		require.NoError(t, err)
+		assert.Empty(t, consents)
+	})
+	t.Run("Test Audit Events", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS(t)
+
+		events, err := ds.ListAuditEvents(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+
+		requests := []*entity.AuditEvent{
+			{Actor: "admin", Action: entity.AuditActionTrustDomainCreate, Target: spiffeTD1.String()},
+			{Actor: "harvester:" + spiffeTD1.String(), Action: entity.AuditActionHarvesterOnboard, Target: spiffeTD1.String()},
+			{Actor: "admin", Action: entity.AuditActionJoinTokenCreate, Target: spiffeTD2.String(), Details: "ttl: 600s"},
+		}
+		for _, req := range requests {
+			event, err := ds.AppendAuditEvent(ctx, req)
+			require.NoError(t, err)
+			assert.NotZero(t, event.ID)
+			assert.Equal(t, req.Actor, event.Actor)
+			assert.Equal(t, req.Details, event.Details)
+		}
+
+		events, err = ds.ListAuditEvents(ctx, nil)
+		require.NoError(t, err)
+		require.Len(t, events, 3)
+
+		// the events are chained in the order they were appended
+		assert.Equal(t, entity.GenesisAuditHash, events[0].PrevHash)
+		for i, event := range events {
+			assert.Equal(t, requests[i].Action, event.Action)
+			assert.Equal(t, event.ComputeHash(), event.Hash)
+			if i > 0 {
+				assert.Greater(t, event.ID, events[i-1].ID)
+				assert.Equal(t, events[i-1].Hash, event.PrevHash)
+			}
+		}
+
+		events, err = ds.ListAuditEvents(ctx, &criteria.ListAuditEventsCriteria{FilterByActor: "admin"})
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, entity.AuditActionTrustDomainCreate, events[0].Action)
+		assert.Equal(t, entity.AuditActionJoinTokenCreate, events[1].Action)
+
+		all, err := ds.ListAuditEvents(ctx, nil)
+		require.NoError(t, err)
+
+		events, err = ds.ListAuditEvents(ctx, &criteria.ListAuditEventsCriteria{FilterBySince: all[1].CreatedAt})
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, all[1].ID, events[0].ID)
+
+		events, err = ds.ListAuditEvents(ctx, &criteria.ListAuditEventsCriteria{FilterByActor: "admin", FilterByUntil: all[1].CreatedAt})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, all[0].ID, events[0].ID)
+
+		events, err = ds.ListAuditEvents(ctx, &criteria.ListAuditEventsCriteria{FilterBySince: time.Now().Add(time.Hour)})
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
 	t.Run("Test CRUD Join Tokens", func(t *testing.T) {
 		t.Parallel()
 		ds := newDS(t)
@@ -445,7 +977,11 @@ func runDatastoreTests(t *testing.T, ctx context.Context, newDS func(*testing.T)
 		token1, err := ds.CreateJoinToken(ctx, req1)
 		assert.NoError(t, err)
 		assert.NotNil(t, token1)
-		assert.Equal(t, req1.Token, token1.Token)
+		// only the salted hash of the token is stored
+		assert.Empty(t, token1.Token)
+		assert.Equal(t, entity.JoinTokenHint(req1.Token), token1.TokenHint)
+		assert.NotEmpty(t, token1.TokenSalt)
+		assert.True(t, token1.MatchesToken(req1.Token))
 		assertEqualDate(t, req1.ExpiresAt, token1.ExpiresAt.In(location))
 		require.False(t, token1.Used)
 		assert.Equal(t, req1.TrustDomainID, token1.TrustDomainID)
@@ -465,7 +1001,7 @@ func runDatastoreTests(t *testing.T, ctx context.Context, newDS func(*testing.T)
 		token2, err := ds.CreateJoinToken(ctx, req2)
 		assert.NoError(t, err)
 		assert.NotNil(t, token1)
-		assert.Equal(t, req2.Token, token2.Token)
+		assert.True(t, token2.MatchesToken(req2.Token))
 		assert.Equal(t, req1.TrustDomainID, token1.TrustDomainID)
 		require.False(t, token2.Used)
 
@@ -501,18 +1037,23 @@ func runDatastoreTests(t *testing.T, ctx context.Context, newDS func(*testing.T)
 		require.Contains(t, tokens, token3)
 
 		// Look up join token by token string
-		stored, err = ds.FindJoinToken(ctx, token1.Token)
+		stored, err = ds.FindJoinToken(ctx, req1.Token)
 		assert.NoError(t, err)
 		assert.Equal(t, token1, stored)
 
-		stored, err = ds.FindJoinToken(ctx, token2.Token)
+		stored, err = ds.FindJoinToken(ctx, req2.Token)
 		assert.NoError(t, err)
 		assert.Equal(t, token2, stored)
 
-		stored, err = ds.FindJoinToken(ctx, token3.Token)
+		stored, err = ds.FindJoinToken(ctx, req3.Token)
 		assert.NoError(t, err)
 		assert.Equal(t, token3, stored)
 
+		// a token sharing the hint of a stored token but not its hash is not found
+		stored, err = ds.FindJoinToken(ctx, uuid.NewString()[:32]+token1.TokenHint)
+		assert.NoError(t, err)
+		assert.Nil(t, stored)
+
 		// List tokens
 		tokens, err = ds.ListJoinTokens(ctx)
 		assert.NoError(t, err)
@@ -555,6 +1096,175 @@ func runDatastoreTests(t *testing.T, ctx context.Context, newDS func(*testing.T)
 		assert.NoError(t, err)
 		assert.Equal(t, 0, len(tokens))
 	})
+
+	t.Run("Test Use Join Tokens", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS(t)
+
+		td := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD1})
+
+		token, err := ds.CreateJoinToken(ctx, &entity.JoinToken{
+			Token:                   uuid.NewString(),
+			MaxUses:                 2,
+			HarvesterKeyFingerprint: strings.Repeat("ab", 32),
+			SourceCIDR:              "10.0.1.0/24",
+			ExpiresAt:               time.Now().Add(time.Hour),
+			TrustDomainID:           td.ID.UUID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), token.MaxUses)
+		assert.Equal(t, int32(0), token.UseCount)
+		assert.Equal(t, strings.Repeat("ab", 32), token.HarvesterKeyFingerprint)
+		assert.Equal(t, "10.0.1.0/24", token.SourceCIDR)
+
+		used, err := ds.UseJoinToken(ctx, token.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), used.UseCount)
+		assert.False(t, used.Used)
+
+		used, err = ds.UseJoinToken(ctx, token.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), used.UseCount)
+		assert.True(t, used.Used)
+
+		// the token cannot be used more than its maximum number of uses
+		used, err = ds.UseJoinToken(ctx, token.ID.UUID)
+		require.NoError(t, err)
+		assert.Nil(t, used)
+
+		stored, err := ds.FindJoinTokensByID(ctx, token.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), stored.UseCount)
+		assert.True(t, stored.Used)
+
+		// a token without a maximum number of uses can be used once
+		single, err := ds.CreateJoinToken(ctx, &entity.JoinToken{
+			Token:         uuid.NewString(),
+			ExpiresAt:     time.Now().Add(time.Hour),
+			TrustDomainID: td.ID.UUID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), single.MaxUses)
+		assert.Empty(t, single.HarvesterKeyFingerprint)
+		assert.Empty(t, single.SourceCIDR)
+
+		used, err = ds.UseJoinToken(ctx, single.ID.UUID)
+		require.NoError(t, err)
+		assert.True(t, used.Used)
+
+		used, err = ds.UseJoinToken(ctx, uuid.New())
+		require.NoError(t, err)
+		assert.Nil(t, used)
+	})
+
+	t.Run("Test Prune Join Tokens", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS(t)
+
+		td := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD1})
+
+		expired, err := ds.CreateJoinToken(ctx, &entity.JoinToken{
+			Token:         uuid.NewString(),
+			ExpiresAt:     time.Now().Add(-2 * time.Hour),
+			TrustDomainID: td.ID.UUID,
+		})
+		require.NoError(t, err)
+
+		valid, err := ds.CreateJoinToken(ctx, &entity.JoinToken{
+			Token:         uuid.NewString(),
+			ExpiresAt:     time.Now().Add(time.Hour),
+			TrustDomainID: td.ID.UUID,
+		})
+		require.NoError(t, err)
+
+		err = ds.PruneJoinTokens(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+
+		stored, err := ds.FindJoinTokensByID(ctx, expired.ID.UUID)
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+
+		stored, err = ds.FindJoinTokensByID(ctx, valid.ID.UUID)
+		require.NoError(t, err)
+		assert.NotNil(t, stored)
+	})
+
+	t.Run("Test Use Onboard Challenges", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS(t)
+
+		td1 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD1})
+		td2 := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD2})
+
+		challenge, err := ds.CreateOnboardChallenge(ctx, &entity.OnboardChallenge{
+			Nonce:         uuid.NewString(),
+			TrustDomainID: td1.ID.UUID,
+			ExpiresAt:     time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+
+		// a challenge cannot be used by another trust domain
+		used, err := ds.UseOnboardChallenge(ctx, challenge.Nonce, td2.ID.UUID, time.Now())
+		require.NoError(t, err)
+		assert.Nil(t, used)
+
+		used, err = ds.UseOnboardChallenge(ctx, challenge.Nonce, td1.ID.UUID, time.Now())
+		require.NoError(t, err)
+		require.NotNil(t, used)
+		assert.Equal(t, challenge.Nonce, used.Nonce)
+		assert.Equal(t, td1.ID.UUID, used.TrustDomainID)
+		assertEqualDate(t, challenge.ExpiresAt, used.ExpiresAt)
+
+		// a challenge can be used only once
+		used, err = ds.UseOnboardChallenge(ctx, challenge.Nonce, td1.ID.UUID, time.Now())
+		require.NoError(t, err)
+		assert.Nil(t, used)
+
+		// an expired challenge cannot be used
+		expired, err := ds.CreateOnboardChallenge(ctx, &entity.OnboardChallenge{
+			Nonce:         uuid.NewString(),
+			TrustDomainID: td1.ID.UUID,
+			ExpiresAt:     time.Now().Add(-time.Minute),
+		})
+		require.NoError(t, err)
+
+		used, err = ds.UseOnboardChallenge(ctx, expired.Nonce, td1.ID.UUID, time.Now())
+		require.NoError(t, err)
+		assert.Nil(t, used)
+	})
+
+	t.Run("Test Prune Onboard Challenges", func(t *testing.T) {
+		t.Parallel()
+		ds := newDS(t)
+
+		td := createTrustDomain(ctx, t, ds, &entity.TrustDomain{Name: spiffeTD1})
+
+		expired, err := ds.CreateOnboardChallenge(ctx, &entity.OnboardChallenge{
+			Nonce:         uuid.NewString(),
+			TrustDomainID: td.ID.UUID,
+			ExpiresAt:     time.Now().Add(-2 * time.Hour),
+		})
+		require.NoError(t, err)
+
+		valid, err := ds.CreateOnboardChallenge(ctx, &entity.OnboardChallenge{
+			Nonce:         uuid.NewString(),
+			TrustDomainID: td.ID.UUID,
+			ExpiresAt:     time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+
+		err = ds.PruneOnboardChallenges(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+
+		// the pruned challenge is gone even when checked against an earlier time
+		used, err := ds.UseOnboardChallenge(ctx, expired.Nonce, td.ID.UUID, time.Now().Add(-3*time.Hour))
+		require.NoError(t, err)
+		assert.Nil(t, used)
+
+		used, err = ds.UseOnboardChallenge(ctx, valid.Nonce, td.ID.UUID, time.Now())
+		require.NoError(t, err)
+		assert.NotNil(t, used)
+	})
 }
 
 func createTrustDomain(ctx context.Context, t *testing.T, ds db.Datastore, req *entity.TrustDomain) *entity.TrustDomain {
@@ -2,9 +2,13 @@ package endpoints
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/api"
@@ -12,7 +16,10 @@ import (
 	chttp "github.com/HewlettPackard/galadriel/pkg/common/http"
 	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/HewlettPackard/galadriel/pkg/server/audit"
+	"github.com/HewlettPackard/galadriel/pkg/server/catalog"
 	"github.com/HewlettPackard/galadriel/pkg/server/db"
+	"github.com/HewlettPackard/galadriel/pkg/server/db/criteria"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
@@ -24,16 +31,28 @@ const (
 	DefaultTokenTTL = 600
 )
 
+// maxJoinTokenUses is the largest number of times a join token can be used.
+const maxJoinTokenUses = 1000
+
 type AdminAPIHandlers struct {
-	Logger    logrus.FieldLogger
-	Datastore db.Datastore
+	Logger          logrus.FieldLogger
+	Datastore       db.Datastore
+	notifier        *Notifier
+	auditor         *audit.Recorder
+	bundleVerifiers []*catalog.BundleVerifier
 }
 
 // NewAdminAPIHandlers creates a new NewAdminAPIHandlers
-func NewAdminAPIHandlers(l logrus.FieldLogger, ds db.Datastore) *AdminAPIHandlers {
+// The notifier is optional, when set, the Harvesters waiting for changes are notified of the changes in bundles and relationships.
+// The auditor is optional, when set, the changes are recorded in the audit log.
+// The bundleVerifiers are the configured verifiers, a trust domain can only require one of them.
+func NewAdminAPIHandlers(l logrus.FieldLogger, ds db.Datastore, notifier *Notifier, auditor *audit.Recorder, bundleVerifiers []*catalog.BundleVerifier) *AdminAPIHandlers {
 	return &AdminAPIHandlers{
-		Logger:    l,
-		Datastore: ds,
+		Logger:          l,
+		Datastore:       ds,
+		notifier:        notifier,
+		auditor:         auditor,
+		bundleVerifiers: bundleVerifiers,
 	}
 }
 
@@ -114,6 +133,9 @@ func (h *AdminAPIHandlers) PutRelationship(echoCtx echo.Context) error {
 		err = fmt.Errorf("%s: %v", msg, err)
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
 	}
+	h.notifier.Notify(rel.TrustDomainAID, rel.TrustDomainBID)
+	h.auditor.Record(ctx, audit.AdminActor, entity.AuditActionRelationshipCreate, rel.ID.UUID.String(),
+		fmt.Sprintf("trust_domain_a: %s, trust_domain_b: %s", dbTd1.Name.String(), dbTd2.Name.String()))
 
 	response := api.RelationshipFromEntity(rel)
 	err = chttp.WriteResponse(echoCtx, http.StatusCreated, response)
@@ -152,6 +174,29 @@ func (h *AdminAPIHandlers) GetRelationshipByID(echoCtx echo.Context, relationshi
 	return nil
 }
 
+// GetRelationshipConsents retrieves the signed consents of the trust domains of a relationship - (GET /relationships/{relationshipID}/consents)
+func (h *AdminAPIHandlers) GetRelationshipConsents(echoCtx echo.Context, relationshipID api.UUID) error {
+	ctx := echoCtx.Request().Context()
+
+	r, err := h.findRelationshipByID(ctx, relationshipID)
+	if err != nil {
+		return err
+	}
+
+	if r == nil {
+		err = errors.New("relationship not found")
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusNotFound)
+	}
+
+	consents, err := h.Datastore.FindRelationshipConsentsByRelationshipID(ctx, relationshipID)
+	if err != nil {
+		err = fmt.Errorf("failed getting relationship consents: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return chttp.WriteResponse(echoCtx, http.StatusOK, api.MapRelationshipConsents(consents...))
+}
+
 // PutTrustDomain creates a new trust domain - (PUT /trust-domain)
 func (h *AdminAPIHandlers) PutTrustDomain(echoCtx echo.Context) error {
 	ctx := echoCtx.Request().Context()
@@ -178,6 +223,10 @@ func (h *AdminAPIHandlers) PutTrustDomain(echoCtx echo.Context) error {
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
 	}
 
+	if err := h.validateBundleVerifier(dbTD.BundleVerifier); err != nil {
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
 	m, err := h.Datastore.CreateOrUpdateTrustDomain(ctx, dbTD)
 	if err != nil {
 		err = fmt.Errorf("failed creating trustDomain: %v", err)
@@ -185,6 +234,7 @@ func (h *AdminAPIHandlers) PutTrustDomain(echoCtx echo.Context) error {
 	}
 
 	h.Logger.Printf("Created trustDomain: %s", dbTD.Name.String())
+	h.auditor.Record(ctx, audit.AdminActor, entity.AuditActionTrustDomainCreate, m.Name.String(), bundleVerifierDetails(m))
 
 	response := api.TrustDomainFromEntity(m)
 	err = chttp.WriteResponse(echoCtx, http.StatusCreated, response)
@@ -235,10 +285,12 @@ func (h *AdminAPIHandlers) DeleteTrustDomainByName(echoCtx echo.Context, trustDo
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusNotFound)
 	}
 
+	// a trust domain cannot be deleted while it has relationships, so no other trust domain is affected
 	err = h.Datastore.DeleteTrustDomain(ctx, trustDomain.ID.UUID)
 	if err != nil {
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
 	}
+	h.auditor.Record(ctx, audit.AdminActor, entity.AuditActionTrustDomainDelete, trustDomain.Name.String(), "")
 
 	message := fmt.Sprintf("Trust Domain %q deleted", trustDomain.Name.String())
 	response := api.DeleteResponse{Code: http.StatusOK, Message: message}
@@ -295,8 +347,7 @@ func (h *AdminAPIHandlers) PutTrustDomainByName(echoCtx echo.Context, trustDomai
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
 	}
 
-	etd, err := reqBody.ToEntity()
-	if err != nil {
+	if _, err := reqBody.ToEntity(); err != nil {
 		err := fmt.Errorf("failed to read trust domain put body: %v", err)
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
 	}
@@ -306,14 +357,24 @@ func (h *AdminAPIHandlers) PutTrustDomainByName(echoCtx echo.Context, trustDomai
 		return err
 	}
 
-	// If the trust domain exist, set the ID to perform an update instead of a Creation of a new Trust Domain.
-	etd.ID = dbTD.ID
+	// The fields omitted from the body keep their current value, so that an update of the description does not
+	// remove the bundle verifier required by the trust domain.
+	if reqBody.Description != nil {
+		dbTD.Description = *reqBody.Description
+	}
+	if reqBody.BundleVerifier != nil {
+		if err := h.validateBundleVerifier(*reqBody.BundleVerifier); err != nil {
+			return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+		}
+		dbTD.BundleVerifier = *reqBody.BundleVerifier
+	}
 
-	td, err := h.Datastore.CreateOrUpdateTrustDomain(ctx, etd)
+	td, err := h.Datastore.CreateOrUpdateTrustDomain(ctx, dbTD)
 	if err != nil {
 		err = fmt.Errorf("failed creating/updating trust domain: %v", err)
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
 	}
+	h.auditor.Record(ctx, audit.AdminActor, entity.AuditActionTrustDomainUpdate, td.Name.String(), bundleVerifierDetails(td))
 
 	response := api.TrustDomainFromEntity(td)
 	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
@@ -371,6 +432,25 @@ func (h *AdminAPIHandlers) PatchRelationshipByID(echoCtx echo.Context, relations
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
 	}
 
+	// a consent status set by the administrator is not signed by the Harvester, so the signed
+	// consent of that trust domain no longer applies
+	if relationship.TrustDomainAConsent != relDB.TrustDomainAConsent {
+		if err := h.Datastore.DeleteRelationshipConsent(ctx, relDB.ID.UUID, relDB.TrustDomainAID); err != nil {
+			err = fmt.Errorf("failed deleting relationship consent: %v", err)
+			return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+		}
+	}
+	if relationship.TrustDomainBConsent != relDB.TrustDomainBConsent {
+		if err := h.Datastore.DeleteRelationshipConsent(ctx, relDB.ID.UUID, relDB.TrustDomainBID); err != nil {
+			err = fmt.Errorf("failed deleting relationship consent: %v", err)
+			return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	h.notifier.Notify(relationship.TrustDomainAID, relationship.TrustDomainBID)
+	h.auditor.Record(ctx, audit.AdminActor, entity.AuditActionRelationshipUpdate, relationship.ID.UUID.String(),
+		fmt.Sprintf("consent_status_a: %s, consent_status_b: %s", relationship.TrustDomainAConsent, relationship.TrustDomainBConsent))
+
 	response := api.RelationshipFromEntity(relationship)
 	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
 	if err != nil {
@@ -403,6 +483,8 @@ func (h *AdminAPIHandlers) DeleteRelationshipByID(echoCtx echo.Context, relation
 		err = fmt.Errorf("failed getting relationships: %v", err)
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
 	}
+	h.notifier.Notify(relationship.TrustDomainAID, relationship.TrustDomainBID)
+	h.auditor.Record(ctx, audit.AdminActor, entity.AuditActionRelationshipDelete, relationship.ID.UUID.String(), "")
 
 	response := api.DeleteResponse{Code: http.StatusOK, Message: "Relationship deleted"}
 	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
@@ -442,14 +524,28 @@ func (h *AdminAPIHandlers) GetJoinToken(echoCtx echo.Context, trustDomainName ap
 	joinToken := &entity.JoinToken{
 		Token:         token.String(),
 		TrustDomainID: td.ID.UUID,
+		MaxUses:       1,
 		ExpiresAt:     time.Now().Add(ttl),
 	}
 
+	if err := setJoinTokenConstraints(joinToken, params); err != nil {
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
 	_, err = h.Datastore.CreateJoinToken(ctx, joinToken)
 	if err != nil {
 		err = fmt.Errorf("failed creating join token: %v", err)
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
 	}
+	// the token is a secret, it is not recorded in the audit log
+	details := fmt.Sprintf("expires_at: %s, max_uses: %d", joinToken.ExpiresAt.UTC().Format(time.RFC3339), joinToken.MaxUses)
+	if joinToken.HarvesterKeyFingerprint != "" {
+		details += fmt.Sprintf(", harvester_key_fingerprint: %s", joinToken.HarvesterKeyFingerprint)
+	}
+	if joinToken.SourceCIDR != "" {
+		details += fmt.Sprintf(", source_cidr: %s", joinToken.SourceCIDR)
+	}
+	h.auditor.Record(ctx, audit.AdminActor, entity.AuditActionJoinTokenCreate, td.Name.String(), details)
 
 	response := admin.JoinTokenResponse{
 		Token: token,
@@ -465,6 +561,366 @@ func (h *AdminAPIHandlers) GetJoinToken(echoCtx echo.Context, trustDomainName ap
 	return nil
 }
 
+// setJoinTokenConstraints sets the maximum number of uses and the bindings requested for the join token.
+func setJoinTokenConstraints(joinToken *entity.JoinToken, params admin.GetJoinTokenParams) error {
+	if params.MaxUses != nil {
+		if *params.MaxUses < 1 || *params.MaxUses > maxJoinTokenUses {
+			return fmt.Errorf("join token max uses must be between 1 and %d", maxJoinTokenUses)
+		}
+		joinToken.MaxUses = *params.MaxUses
+	}
+
+	if params.HarvesterKeyFingerprint != nil {
+		fingerprint := strings.ToLower(*params.HarvesterKeyFingerprint)
+		if decoded, err := hex.DecodeString(fingerprint); err != nil || len(decoded) != sha256.Size {
+			return fmt.Errorf("invalid harvester key fingerprint %q: it must be a hex encoded SHA-256 digest", *params.HarvesterKeyFingerprint)
+		}
+		joinToken.HarvesterKeyFingerprint = fingerprint
+	}
+
+	if params.SourceCIDR != nil {
+		_, network, err := net.ParseCIDR(*params.SourceCIDR)
+		if err != nil {
+			return fmt.Errorf("invalid source CIDR %q: %v", *params.SourceCIDR, err)
+		}
+		joinToken.SourceCIDR = network.String()
+	}
+
+	return nil
+}
+
+// ListJoinTokens lists the join tokens, with their values masked - (GET /join-tokens)
+func (h *AdminAPIHandlers) ListJoinTokens(echoCtx echo.Context, params admin.ListJoinTokensParams) error {
+	ctx := echoCtx.Request().Context()
+
+	var (
+		joinTokens []*entity.JoinToken
+		td         *entity.TrustDomain
+		err        error
+	)
+	if params.TrustDomainName != nil {
+		td, err = h.lookupTrustDomain(ctx, *params.TrustDomainName)
+		if err != nil {
+			return err
+		}
+		joinTokens, err = h.Datastore.FindJoinTokensByTrustDomainID(ctx, td.ID.UUID)
+	} else {
+		joinTokens, err = h.Datastore.ListJoinTokens(ctx)
+	}
+	if err != nil {
+		msg := "failed listing join tokens"
+		err := fmt.Errorf("%s: %v", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	trustDomainNames, err := h.trustDomainNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	response := make([]admin.JoinTokenDetails, 0, len(joinTokens))
+	for _, jt := range joinTokens {
+		if params.Used != nil && jt.Used != *params.Used {
+			continue
+		}
+		if params.Expired != nil && jt.IsExpired(now) != *params.Expired {
+			continue
+		}
+
+		jt.TrustDomainName = trustDomainNames[jt.TrustDomainID]
+		response = append(response, admin.JoinTokenDetailsFromEntity(jt, now))
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("join tokens entities - %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetJoinTokenByID gets a join token, with its value masked - (GET /join-tokens/{joinTokenID})
+func (h *AdminAPIHandlers) GetJoinTokenByID(echoCtx echo.Context, joinTokenID api.UUID) error {
+	ctx := echoCtx.Request().Context()
+
+	jt, err := h.lookupJoinToken(ctx, joinTokenID)
+	if err != nil {
+		return err
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, admin.JoinTokenDetailsFromEntity(jt, time.Now()))
+	if err != nil {
+		err = fmt.Errorf("join token entity - %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// RevokeJoinToken deletes a join token, so that it can no longer be used to onboard a Harvester - (DELETE /join-tokens/{joinTokenID})
+func (h *AdminAPIHandlers) RevokeJoinToken(echoCtx echo.Context, joinTokenID api.UUID) error {
+	ctx := echoCtx.Request().Context()
+
+	jt, err := h.lookupJoinToken(ctx, joinTokenID)
+	if err != nil {
+		return err
+	}
+
+	err = h.Datastore.DeleteJoinToken(ctx, joinTokenID)
+	if err != nil {
+		msg := "failed revoking join token"
+		err := fmt.Errorf("%s: %v", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+	h.auditor.Record(ctx, audit.AdminActor, entity.AuditActionJoinTokenRevoke, jt.TrustDomainName.String(),
+		fmt.Sprintf("join_token_id: %s", joinTokenID))
+
+	response := api.DeleteResponse{Code: http.StatusOK, Message: "Join token revoked"}
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("join token entity - %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	h.Logger.WithField(telemetry.TrustDomain, jt.TrustDomainName.String()).Debug("Revoked join token")
+
+	return nil
+}
+
+// ListBundleVersions lists the bundle history of the trust domain - (GET /trust-domain/{trustDomainName}/bundles)
+func (h *AdminAPIHandlers) ListBundleVersions(echoCtx echo.Context, trustDomainName api.TrustDomainName) error {
+	ctx := echoCtx.Request().Context()
+
+	td, err := h.lookupTrustDomain(ctx, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	versions, err := h.Datastore.ListBundleVersions(ctx, td.ID.UUID)
+	if err != nil {
+		msg := "failed listing bundle versions"
+		err := fmt.Errorf("%s: %v", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	pinnedVersion, err := h.findPinnedVersion(ctx, td.ID.UUID)
+	if err != nil {
+		return err
+	}
+
+	response := make([]admin.BundleVersion, 0, len(versions))
+	for _, bv := range versions {
+		response = append(response, admin.BundleVersionFromEntity(bv, bv.Version == pinnedVersion))
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("bundle versions entities - %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetBundleVersion gets a version of the bundle of the trust domain - (GET /trust-domain/{trustDomainName}/bundles/{version})
+func (h *AdminAPIHandlers) GetBundleVersion(echoCtx echo.Context, trustDomainName api.TrustDomainName, version admin.BundleVersionNumber) error {
+	ctx := echoCtx.Request().Context()
+
+	td, err := h.lookupTrustDomain(ctx, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	bv, err := h.findBundleVersion(ctx, td, version)
+	if err != nil {
+		return err
+	}
+
+	pinnedVersion, err := h.findPinnedVersion(ctx, td.ID.UUID)
+	if err != nil {
+		return err
+	}
+
+	response := admin.BundleVersionFromEntity(bv, bv.Version == pinnedVersion)
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("bundle version entity - %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetBundleVersionDiff compares a version of the bundle of the trust domain with a base version - (GET /trust-domain/{trustDomainName}/bundles/{version}/diff)
+func (h *AdminAPIHandlers) GetBundleVersionDiff(echoCtx echo.Context, trustDomainName api.TrustDomainName, version admin.BundleVersionNumber, params admin.GetBundleVersionDiffParams) error {
+	ctx := echoCtx.Request().Context()
+
+	baseVersion := version - 1
+	if params.Base != nil {
+		baseVersion = *params.Base
+	}
+	if baseVersion < 1 {
+		err := fmt.Errorf("there is no base version to compare version %d with", version)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	td, err := h.lookupTrustDomain(ctx, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	bv, err := h.findBundleVersion(ctx, td, version)
+	if err != nil {
+		return err
+	}
+
+	base, err := h.findBundleVersion(ctx, td, baseVersion)
+	if err != nil {
+		return err
+	}
+
+	response, err := diffBundleVersions(td.Name, base, bv)
+	if err != nil {
+		err = fmt.Errorf("failed comparing bundle versions: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("bundle diff entity - %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// RollbackBundle serves a previous version of the bundle of the trust domain and pins it - (POST /trust-domain/{trustDomainName}/bundles/{version}/rollback)
+func (h *AdminAPIHandlers) RollbackBundle(echoCtx echo.Context, trustDomainName api.TrustDomainName, version admin.BundleVersionNumber) error {
+	ctx := echoCtx.Request().Context()
+
+	td, err := h.lookupTrustDomain(ctx, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	bv, err := h.findBundleVersion(ctx, td, version)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := h.Datastore.FindBundleByTrustDomainID(ctx, td.ID.UUID)
+	if err != nil {
+		msg := "failed looking up bundle"
+		err := fmt.Errorf("%s: %v", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if bundle == nil {
+		bundle = &entity.Bundle{TrustDomainID: td.ID.UUID}
+	}
+	bundle.Data = bv.Data
+	bundle.Digest = bv.Digest
+	bundle.Signature = bv.Signature
+	bundle.SigningCertificateChain = bv.SigningCertificateChain
+	bundle.PinnedVersion = bv.Version
+
+	if _, err := h.Datastore.CreateOrUpdateBundle(ctx, bundle); err != nil {
+		msg := "failed rolling back bundle"
+		err := fmt.Errorf("%s: %v", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+	notifyRelatedTrustDomains(ctx, h.Datastore, h.notifier, h.Logger, td.ID.UUID)
+	h.auditor.Record(ctx, audit.AdminActor, entity.AuditActionBundleRollback, td.Name.String(), fmt.Sprintf("version: %d", bv.Version))
+
+	response := admin.BundleVersionFromEntity(bv, true)
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("bundle version entity - %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	h.Logger.WithField(telemetry.TrustDomain, td.Name.String()).Infof("Rolled back bundle to version %d", bv.Version)
+
+	return nil
+}
+
+// ApproveBundleReset approves the next bundle uploaded for the trust domain even if it does not continue the
+// current bundle of the trust domain - (POST /trust-domain/{trustDomainName}/bundle-reset)
+// The approval only matters when the bundle continuity policy is enabled, and is consumed by the first bundle accepted thanks to it.
+func (h *AdminAPIHandlers) ApproveBundleReset(echoCtx echo.Context, trustDomainName api.TrustDomainName) error {
+	ctx := echoCtx.Request().Context()
+
+	td, err := h.lookupTrustDomain(ctx, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	approval, err := h.Datastore.CreateOrUpdateBundleResetApproval(ctx, &entity.BundleResetApproval{TrustDomainID: td.ID.UUID})
+	if err != nil {
+		msg := "failed storing bundle reset approval"
+		err := fmt.Errorf("%s: %v", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+	h.auditor.Record(ctx, audit.AdminActor, entity.AuditActionBundleResetApprove, td.Name.String(), "")
+
+	response := admin.BundleResetApproval{
+		TrustDomainName: td.Name.String(),
+		CreatedAt:       approval.CreatedAt,
+	}
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("bundle reset approval entity - %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	h.Logger.WithField(telemetry.TrustDomain, td.Name.String()).Info("Approved bundle reset")
+
+	return nil
+}
+
+// ListAuditEvents lists the events of the audit log, in the order of the hash chain - (GET /audit-events)
+func (h *AdminAPIHandlers) ListAuditEvents(echoCtx echo.Context, params admin.ListAuditEventsParams) error {
+	ctx := echoCtx.Request().Context()
+
+	listCriteria := &criteria.ListAuditEventsCriteria{}
+	if params.Since != nil {
+		listCriteria.FilterBySince = *params.Since
+	}
+	if params.Until != nil {
+		listCriteria.FilterByUntil = *params.Until
+	}
+	if params.Actor != nil {
+		listCriteria.FilterByActor = *params.Actor
+	}
+
+	if params.Since != nil && params.Until != nil && !params.Until.After(*params.Since) {
+		err := errors.New("until must be after since")
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	events, err := h.Datastore.ListAuditEvents(ctx, listCriteria)
+	if err != nil {
+		msg := "failed listing audit events"
+		err := fmt.Errorf("%s: %v", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	response := make([]admin.AuditEvent, 0, len(events))
+	for _, e := range events {
+		response = append(response, admin.AuditEventFromEntity(e))
+	}
+
+	err = chttp.WriteResponse(echoCtx, http.StatusOK, response)
+	if err != nil {
+		err = fmt.Errorf("audit events entities - %v", err.Error())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
 func (h *AdminAPIHandlers) findTrustDomainByName(ctx context.Context, trustDomain string) (*entity.TrustDomain, error) {
 	tdName, err := spiffeid.TrustDomainFromString(trustDomain)
 	if err != nil {
@@ -502,6 +958,82 @@ func (h *AdminAPIHandlers) lookupTrustDomain(ctx context.Context, trustDomainNam
 	return td, nil
 }
 
+// lookupJoinToken returns the join token with the name of its trust domain, or a 404 error if it does not exist.
+func (h *AdminAPIHandlers) lookupJoinToken(ctx context.Context, joinTokenID api.UUID) (*entity.JoinToken, error) {
+	jt, err := h.Datastore.FindJoinTokensByID(ctx, joinTokenID)
+	if err != nil {
+		msg := "failed looking up join token"
+		err := fmt.Errorf("%s: %v", msg, err)
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if jt == nil {
+		err := fmt.Errorf("join token does not exist: %q", joinTokenID.String())
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusNotFound)
+	}
+
+	td, err := h.Datastore.FindTrustDomainByID(ctx, jt.TrustDomainID)
+	if err != nil {
+		msg := "error looking up trust domain"
+		err := fmt.Errorf("%s: %v", msg, err)
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+	if td != nil {
+		jt.TrustDomainName = td.Name
+	}
+
+	return jt, nil
+}
+
+// trustDomainNames returns the names of the trust domains by ID.
+func (h *AdminAPIHandlers) trustDomainNames(ctx context.Context) (map[uuid.UUID]spiffeid.TrustDomain, error) {
+	tds, err := h.Datastore.ListTrustDomains(ctx, nil)
+	if err != nil {
+		msg := "failed listing trust domains"
+		err := fmt.Errorf("%s: %v", msg, err)
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	names := make(map[uuid.UUID]spiffeid.TrustDomain, len(tds))
+	for _, td := range tds {
+		names[td.ID.UUID] = td.Name
+	}
+
+	return names, nil
+}
+
+func (h *AdminAPIHandlers) findBundleVersion(ctx context.Context, td *entity.TrustDomain, version int64) (*entity.BundleVersion, error) {
+	bv, err := h.Datastore.FindBundleVersion(ctx, td.ID.UUID, version)
+	if err != nil {
+		msg := "failed looking up bundle version"
+		err := fmt.Errorf("%s: %v", msg, err)
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if bv == nil {
+		err := fmt.Errorf("bundle version %d does not exist for trust domain %q", version, td.Name.String())
+		return nil, chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusNotFound)
+	}
+
+	return bv, nil
+}
+
+// findPinnedVersion returns the bundle version pinned for the trust domain, or 0 if no version is pinned.
+func (h *AdminAPIHandlers) findPinnedVersion(ctx context.Context, trustDomainID uuid.UUID) (int64, error) {
+	bundle, err := h.Datastore.FindBundleByTrustDomainID(ctx, trustDomainID)
+	if err != nil {
+		msg := "failed looking up bundle"
+		err := fmt.Errorf("%s: %v", msg, err)
+		return 0, chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if bundle == nil {
+		return 0, nil
+	}
+
+	return bundle.PinnedVersion, nil
+}
+
 func (h *AdminAPIHandlers) findRelationshipByID(ctx context.Context, relationshipID api.UUID) (*entity.Relationship, error) {
 	relationship, err := h.Datastore.FindRelationshipByID(ctx, relationshipID)
 	if err != nil {
@@ -511,3 +1043,28 @@ func (h *AdminAPIHandlers) findRelationshipByID(ctx context.Context, relationshi
 
 	return relationship, nil
 }
+
+// validateBundleVerifier returns an error if the bundle verifier required by a trust domain is not one of the
+// configured verifiers, as none of the bundles of the trust domain could be accepted.
+func (h *AdminAPIHandlers) validateBundleVerifier(name string) error {
+	if name == "" {
+		return nil
+	}
+
+	for _, verifier := range h.bundleVerifiers {
+		if verifier.Name == name {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("bundle verifier %q is not configured", name)
+}
+
+// bundleVerifierDetails returns the details of the audit event of a change of the trust domain.
+func bundleVerifierDetails(td *entity.TrustDomain) string {
+	if td.BundleVerifier == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("bundle_verifier: %s", td.BundleVerifier)
+}
@@ -2,21 +2,32 @@ package endpoints
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/api"
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
 	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
+	"github.com/HewlettPackard/galadriel/pkg/server/audit"
+	"github.com/HewlettPackard/galadriel/pkg/server/catalog"
+	"github.com/HewlettPackard/galadriel/test/certtest"
 	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
 	"github.com/google/uuid"
+	"github.com/jmhodges/clock"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -89,7 +100,7 @@ func NewManagementTestSetup(t *testing.T, method, url string, body interface{})
 	return &ManagementTestSetup{
 		EchoCtx:      e.NewContext(req, rec),
 		Recorder:     rec,
-		Handler:      NewAdminAPIHandlers(logger, fakeDB),
+		Handler:      NewAdminAPIHandlers(logger, fakeDB, nil, nil, nil),
 		FakeDatabase: fakeDB,
 		// Helpers
 		url:        url,
@@ -414,6 +425,74 @@ func TestUDSPatchRelationshipByID(t *testing.T) {
 	})
 }
 
+func TestUDSPatchRelationshipByIDDeletesOverriddenConsents(t *testing.T) {
+	ctx := context.Background()
+	fakeRelationship := &entity.Relationship{
+		ID:                  NewNullableID(),
+		TrustDomainAID:      tdUUID1.UUID,
+		TrustDomainBID:      tdUUID2.UUID,
+		TrustDomainAConsent: entity.ConsentStatusApproved,
+		TrustDomainBConsent: entity.ConsentStatusApproved,
+	}
+
+	reqBody := &admin.PatchRelationshipByIDJSONRequestBody{
+		ConsentStatusA: api.Denied,
+	}
+
+	setup := NewManagementTestSetup(t, http.MethodPatch, fmt.Sprintf("/relationships/%v", fakeRelationship.ID.UUID), reqBody)
+	setup.FakeDatabase.WithRelationships(fakeRelationship)
+	for _, tdID := range []uuid.UUID{tdUUID1.UUID, tdUUID2.UUID} {
+		_, err := setup.FakeDatabase.CreateOrUpdateRelationshipConsent(ctx, &entity.RelationshipConsent{
+			RelationshipID:   fakeRelationship.ID.UUID,
+			TrustDomainID:    tdID,
+			ConsentStatement: []byte("statement"),
+		})
+		require.NoError(t, err)
+	}
+
+	err := setup.Handler.PatchRelationshipByID(setup.EchoCtx, fakeRelationship.ID.UUID)
+	require.NoError(t, err)
+
+	// only the consent of the trust domain whose status was set by the administrator is deleted
+	consents, err := setup.FakeDatabase.FindRelationshipConsentsByRelationshipID(ctx, fakeRelationship.ID.UUID)
+	require.NoError(t, err)
+	require.Len(t, consents, 1)
+	assert.Equal(t, tdUUID2.UUID, consents[0].TrustDomainID)
+}
+
+func TestUDSGetRelationshipConsents(t *testing.T) {
+	ctx := context.Background()
+	fakeRelationship := &entity.Relationship{
+		ID:             NewNullableID(),
+		TrustDomainAID: tdUUID1.UUID,
+		TrustDomainBID: tdUUID2.UUID,
+	}
+
+	setup := NewManagementTestSetup(t, http.MethodGet, fmt.Sprintf("/relationships/%v/consents", fakeRelationship.ID.UUID), nil)
+	setup.FakeDatabase.WithRelationships(fakeRelationship)
+	_, err := setup.FakeDatabase.CreateOrUpdateRelationshipConsent(ctx, &entity.RelationshipConsent{
+		RelationshipID:   fakeRelationship.ID.UUID,
+		TrustDomainID:    tdUUID1.UUID,
+		ConsentStatement: []byte("statement"),
+		Signature:        []byte("signature"),
+	})
+	require.NoError(t, err)
+
+	err = setup.Handler.GetRelationshipConsents(setup.EchoCtx, fakeRelationship.ID.UUID)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+	var consents []*api.RelationshipConsent
+	require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &consents))
+	require.Len(t, consents, 1)
+	assert.Equal(t, tdUUID1.UUID, consents[0].TrustDomainId)
+
+	setup = NewManagementTestSetup(t, http.MethodGet, "/relationships/unknown/consents", nil)
+	err = setup.Handler.GetRelationshipConsents(setup.EchoCtx, uuid.New())
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+}
+
 func TestUDSPutTrustDomain(t *testing.T) {
 	trustDomainPath := "/trust-domain"
 	t.Run("Successfully create a new trust domain", func(t *testing.T) {
@@ -461,6 +540,25 @@ func TestUDSPutTrustDomain(t *testing.T) {
 		expectedErrorMsg := fmt.Sprintf("trust domain already exists: %q", td1)
 		assert.Equal(t, expectedErrorMsg, echoHttpErr.Message)
 	})
+
+	t.Run("Should not allow creating trust domain requiring a bundle verifier that is not configured", func(t *testing.T) {
+		bundleVerifier := "typo"
+		reqBody := &admin.PutTrustDomainRequest{
+			Name:           td1,
+			BundleVerifier: &bundleVerifier,
+		}
+
+		// Setup
+		setup := NewManagementTestSetup(t, http.MethodPut, trustDomainPath, reqBody)
+		setup.Handler.bundleVerifiers = []*catalog.BundleVerifier{{Name: "disk"}}
+
+		err := setup.Handler.PutTrustDomain(setup.EchoCtx)
+		assert.Error(t, err)
+
+		echoHttpErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusBadRequest, echoHttpErr.Code)
+		assert.Equal(t, `bundle verifier "typo" is not configured`, echoHttpErr.Message)
+	})
 }
 
 func TestUDSListTrustDomains(t *testing.T) {
@@ -614,6 +712,76 @@ func TestUDSPutTrustDomainByName(t *testing.T) {
 		assert.Equal(t, description, *apiTrustDomain.Description)
 	})
 
+	t.Run("Keep the bundle verifier of the trust domain when it is omitted", func(t *testing.T) {
+		fakeTrustDomains := entity.TrustDomain{ID: tdUUID1, Name: NewTrustDomain(t, td1), Description: "old", BundleVerifier: "disk"}
+
+		description := "I am being updated"
+		reqBody := &admin.PutTrustDomainByNameJSONRequestBody{
+			Name:        td1,
+			Description: &description,
+		}
+
+		// Setup
+		setup := NewManagementTestSetup(t, http.MethodPut, fmt.Sprintf(trustDomainPath, td1), reqBody)
+		setup.FakeDatabase.WithTrustDomains(&fakeTrustDomains)
+
+		err := setup.Handler.PutTrustDomainByName(setup.EchoCtx, td1)
+		assert.NoError(t, err)
+
+		stored, err := setup.FakeDatabase.FindTrustDomainByName(context.Background(), NewTrustDomain(t, td1))
+		assert.NoError(t, err)
+		assert.Equal(t, description, stored.Description)
+		assert.Equal(t, "disk", stored.BundleVerifier)
+	})
+
+	t.Run("Successfully remove the bundle verifier of the trust domain", func(t *testing.T) {
+		fakeTrustDomains := entity.TrustDomain{ID: tdUUID1, Name: NewTrustDomain(t, td1), Description: "old", BundleVerifier: "disk"}
+
+		bundleVerifier := ""
+		reqBody := &admin.PutTrustDomainByNameJSONRequestBody{
+			Name:           td1,
+			BundleVerifier: &bundleVerifier,
+		}
+
+		// Setup
+		setup := NewManagementTestSetup(t, http.MethodPut, fmt.Sprintf(trustDomainPath, td1), reqBody)
+		setup.FakeDatabase.WithTrustDomains(&fakeTrustDomains)
+
+		err := setup.Handler.PutTrustDomainByName(setup.EchoCtx, td1)
+		assert.NoError(t, err)
+
+		stored, err := setup.FakeDatabase.FindTrustDomainByName(context.Background(), NewTrustDomain(t, td1))
+		assert.NoError(t, err)
+		assert.Equal(t, "old", stored.Description)
+		assert.Empty(t, stored.BundleVerifier)
+	})
+
+	t.Run("Raise a bad request when the bundle verifier is not configured", func(t *testing.T) {
+		fakeTrustDomains := entity.TrustDomain{ID: tdUUID1, Name: NewTrustDomain(t, td1), BundleVerifier: "disk"}
+
+		bundleVerifier := "typo"
+		reqBody := &admin.PutTrustDomainByNameJSONRequestBody{
+			Name:           td1,
+			BundleVerifier: &bundleVerifier,
+		}
+
+		// Setup
+		setup := NewManagementTestSetup(t, http.MethodPut, fmt.Sprintf(trustDomainPath, td1), reqBody)
+		setup.FakeDatabase.WithTrustDomains(&fakeTrustDomains)
+		setup.Handler.bundleVerifiers = []*catalog.BundleVerifier{{Name: "disk"}}
+
+		err := setup.Handler.PutTrustDomainByName(setup.EchoCtx, td1)
+		assert.Error(t, err)
+
+		echoHTTPErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusBadRequest, echoHTTPErr.Code)
+		assert.Equal(t, `bundle verifier "typo" is not configured`, echoHTTPErr.Message)
+
+		stored, err := setup.FakeDatabase.FindTrustDomainByName(context.Background(), NewTrustDomain(t, td1))
+		assert.NoError(t, err)
+		assert.Equal(t, "disk", stored.BundleVerifier)
+	})
+
 	t.Run("Raise a not found when trying to updated a trust domain that does not exists", func(t *testing.T) {
 		completePath := fmt.Sprintf(trustDomainPath, tdUUID1.UUID)
 
@@ -664,6 +832,13 @@ func TestUDSGetJoinToken(t *testing.T) {
 		assert.NoError(t, err)
 
 		assert.NotEmpty(t, jtResp)
+
+		// only the salted hash of the token is stored
+		stored, err := setup.FakeDatabase.ListJoinTokens(context.Background())
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		assert.Empty(t, stored[0].Token)
+		assert.True(t, stored[0].MatchesToken(jtResp.Token.String()))
 	})
 
 	t.Run("Raise a bad request when trying to generates a join token for the trust domain that does not exists", func(t *testing.T) {
@@ -684,6 +859,484 @@ func TestUDSGetJoinToken(t *testing.T) {
 		expectedMsg := fmt.Sprintf("trust domain does not exist: %q", td1)
 		assert.Equal(t, expectedMsg, echoHttpErr.Message)
 	})
+
+	t.Run("Successfully generates a multi-use join token bound to a harvester key and a network", func(t *testing.T) {
+		fakeTrustDomains := entity.TrustDomain{ID: NewNullableID(), Name: NewTrustDomain(t, td1)}
+		setup := NewManagementTestSetup(t, http.MethodGet, fmt.Sprintf(trustDomainPath, td1), nil)
+		setup.FakeDatabase.WithTrustDomains(&fakeTrustDomains)
+
+		maxUses := int32(5)
+		fingerprint := strings.Repeat("AB", 32)
+		sourceCIDR := "10.0.1.7/24"
+		params := admin.GetJoinTokenParams{
+			Ttl:                     600,
+			MaxUses:                 &maxUses,
+			HarvesterKeyFingerprint: &fingerprint,
+			SourceCIDR:              &sourceCIDR,
+		}
+		err := setup.Handler.GetJoinToken(setup.EchoCtx, td1, params)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		stored, err := setup.FakeDatabase.ListJoinTokens(context.Background())
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		assert.Equal(t, maxUses, stored[0].MaxUses)
+		assert.Equal(t, strings.Repeat("ab", 32), stored[0].HarvesterKeyFingerprint)
+		assert.Equal(t, "10.0.1.0/24", stored[0].SourceCIDR)
+	})
+
+	t.Run("Raise a bad request when the join token constraints are invalid", func(t *testing.T) {
+		zero := int32(0)
+		tooMany := int32(maxJoinTokenUses + 1)
+		shortFingerprint := "abcd"
+		invalidCIDR := "10.0.1.0"
+
+		testCases := []struct {
+			name        string
+			params      admin.GetJoinTokenParams
+			expectedMsg string
+		}{
+			{name: "zero max uses", params: admin.GetJoinTokenParams{Ttl: 600, MaxUses: &zero}, expectedMsg: "join token max uses must be between 1 and 1000"},
+			{name: "too many max uses", params: admin.GetJoinTokenParams{Ttl: 600, MaxUses: &tooMany}, expectedMsg: "join token max uses must be between 1 and 1000"},
+			{name: "invalid fingerprint", params: admin.GetJoinTokenParams{Ttl: 600, HarvesterKeyFingerprint: &shortFingerprint}, expectedMsg: "invalid harvester key fingerprint"},
+			{name: "invalid source CIDR", params: admin.GetJoinTokenParams{Ttl: 600, SourceCIDR: &invalidCIDR}, expectedMsg: "invalid source CIDR"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				fakeTrustDomains := entity.TrustDomain{ID: NewNullableID(), Name: NewTrustDomain(t, td1)}
+				setup := NewManagementTestSetup(t, http.MethodGet, fmt.Sprintf(trustDomainPath, td1), nil)
+				setup.FakeDatabase.WithTrustDomains(&fakeTrustDomains)
+
+				err := setup.Handler.GetJoinToken(setup.EchoCtx, td1, tc.params)
+				require.Error(t, err)
+
+				echoHTTPErr := err.(*echo.HTTPError)
+				assert.Equal(t, http.StatusBadRequest, echoHTTPErr.Code)
+				assert.Contains(t, echoHTTPErr.Message, tc.expectedMsg)
+
+				stored, err := setup.FakeDatabase.ListJoinTokens(context.Background())
+				require.NoError(t, err)
+				assert.Empty(t, stored)
+			})
+		}
+	})
+}
+
+func TestUDSListJoinTokens(t *testing.T) {
+	path := "/join-tokens"
+
+	setupJoinTokens := func(setup *ManagementTestSetup) (used, expired, valid *entity.JoinToken) {
+		td1Entity := &entity.TrustDomain{ID: NewNullableID(), Name: NewTrustDomain(t, td1)}
+		td2Entity := &entity.TrustDomain{ID: NewNullableID(), Name: NewTrustDomain(t, td2)}
+		setup.FakeDatabase.WithTrustDomains(td1Entity, td2Entity)
+
+		now := time.Now()
+		used = &entity.JoinToken{ID: NewNullableID(), Token: uuid.NewString(), Used: true, TrustDomainID: td1Entity.ID.UUID, ExpiresAt: now.Add(time.Hour)}
+		expired = &entity.JoinToken{ID: NewNullableID(), Token: uuid.NewString(), TrustDomainID: td1Entity.ID.UUID, ExpiresAt: now.Add(-time.Hour)}
+		valid = &entity.JoinToken{ID: NewNullableID(), Token: uuid.NewString(), TrustDomainID: td2Entity.ID.UUID, ExpiresAt: now.Add(time.Hour)}
+		setup.FakeDatabase.WithTokens(used, expired, valid)
+
+		return used, expired, valid
+	}
+
+	listJoinTokens := func(t *testing.T, params admin.ListJoinTokensParams) []admin.JoinTokenDetails {
+		setup := NewManagementTestSetup(t, http.MethodGet, path, nil)
+		used, expired, valid := setupJoinTokens(setup)
+
+		err := setup.Handler.ListJoinTokens(setup.EchoCtx, params)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var response []admin.JoinTokenDetails
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &response))
+
+		// the token values are never listed
+		for _, jt := range []*entity.JoinToken{used, expired, valid} {
+			assert.NotContains(t, setup.Recorder.Body.String(), jt.Token)
+		}
+
+		return response
+	}
+
+	t.Run("Successfully list all the join tokens", func(t *testing.T) {
+		response := listJoinTokens(t, admin.ListJoinTokensParams{})
+		assert.Len(t, response, 3)
+	})
+
+	t.Run("Successfully filter the join tokens by trust domain", func(t *testing.T) {
+		tdName := td2
+		response := listJoinTokens(t, admin.ListJoinTokensParams{TrustDomainName: &tdName})
+		require.Len(t, response, 1)
+		assert.Equal(t, td2, response[0].TrustDomainName)
+		assert.False(t, response[0].Used)
+		assert.False(t, response[0].Expired)
+	})
+
+	t.Run("Successfully filter the join tokens by used and expired state", func(t *testing.T) {
+		used := true
+		response := listJoinTokens(t, admin.ListJoinTokensParams{Used: &used})
+		require.Len(t, response, 1)
+		assert.True(t, response[0].Used)
+		assert.Equal(t, td1, response[0].TrustDomainName)
+
+		expired := true
+		response = listJoinTokens(t, admin.ListJoinTokensParams{Expired: &expired})
+		require.Len(t, response, 1)
+		assert.True(t, response[0].Expired)
+
+		notUsed, notExpired := false, false
+		response = listJoinTokens(t, admin.ListJoinTokensParams{Used: &notUsed, Expired: &notExpired})
+		require.Len(t, response, 1)
+		assert.Equal(t, td2, response[0].TrustDomainName)
+	})
+
+	t.Run("Raise a not found when the trust domain does not exist", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, path, nil)
+
+		tdName := td3
+		err := setup.Handler.ListJoinTokens(setup.EchoCtx, admin.ListJoinTokensParams{TrustDomainName: &tdName})
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+	})
+
+	t.Run("Raise an internal error when listing the join tokens of the trust domain fails", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, path, nil)
+		setupJoinTokens(setup)
+
+		// the trust domain lookup succeeds, listing its join tokens fails
+		setup.FakeDatabase.AppendNextError(nil)
+		setup.FakeDatabase.AppendNextError(errors.New("datastore unavailable"))
+
+		tdName := td1
+		err := setup.Handler.ListJoinTokens(setup.EchoCtx, admin.ListJoinTokensParams{TrustDomainName: &tdName})
+		require.Error(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.(*echo.HTTPError).Code)
+	})
+}
+
+func TestUDSGetJoinTokenByID(t *testing.T) {
+	td := &entity.TrustDomain{ID: NewNullableID(), Name: NewTrustDomain(t, td1)}
+	jt := &entity.JoinToken{ID: NewNullableID(), Token: uuid.NewString(), TrustDomainID: td.ID.UUID, ExpiresAt: time.Now().Add(time.Hour)}
+	path := fmt.Sprintf("/join-tokens/%v", jt.ID.UUID)
+
+	t.Run("Successfully get a join token with its value masked", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, path, nil)
+		setup.FakeDatabase.WithTrustDomains(td)
+		setup.FakeDatabase.WithTokens(jt)
+
+		err := setup.Handler.GetJoinTokenByID(setup.EchoCtx, jt.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var response admin.JoinTokenDetails
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &response))
+		assert.Equal(t, jt.ID.UUID, response.Id)
+		assert.Equal(t, "****"+entity.JoinTokenHint(jt.Token), response.MaskedToken)
+		assert.Equal(t, td1, response.TrustDomainName)
+		assert.NotContains(t, setup.Recorder.Body.String(), jt.Token)
+	})
+
+	t.Run("Raise a not found when the join token does not exist", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, path, nil)
+
+		err := setup.Handler.GetJoinTokenByID(setup.EchoCtx, jt.ID.UUID)
+		require.Error(t, err)
+		echoHTTPErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusNotFound, echoHTTPErr.Code)
+		assert.Equal(t, fmt.Sprintf("join token does not exist: %q", jt.ID.UUID.String()), echoHTTPErr.Message)
+	})
+}
+
+func TestUDSRevokeJoinToken(t *testing.T) {
+	td := &entity.TrustDomain{ID: NewNullableID(), Name: NewTrustDomain(t, td1)}
+	jt := &entity.JoinToken{ID: NewNullableID(), Token: uuid.NewString(), TrustDomainID: td.ID.UUID, ExpiresAt: time.Now().Add(time.Hour)}
+	path := fmt.Sprintf("/join-tokens/%v", jt.ID.UUID)
+
+	t.Run("Successfully revoke a join token", func(t *testing.T) {
+		ctx := context.Background()
+		setup := NewManagementTestSetup(t, http.MethodDelete, path, nil)
+		setup.FakeDatabase.WithTrustDomains(td)
+		setup.FakeDatabase.WithTokens(jt)
+		logger, _ := logrustest.NewNullLogger()
+		auditor, err := audit.NewRecorder(&audit.Config{Store: setup.FakeDatabase, Logger: logger})
+		require.NoError(t, err)
+		setup.Handler = NewAdminAPIHandlers(logger, setup.FakeDatabase, nil, auditor, nil)
+
+		err = setup.Handler.RevokeJoinToken(setup.EchoCtx, jt.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var response api.DeleteResponse
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &response))
+		assert.Equal(t, "Join token revoked", response.Message)
+
+		stored, err := setup.FakeDatabase.FindJoinTokensByID(ctx, jt.ID.UUID)
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+
+		events, err := setup.FakeDatabase.ListAuditEvents(ctx, nil)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, entity.AuditActionJoinTokenRevoke, events[0].Action)
+		assert.Equal(t, td1, events[0].Target)
+		assert.NotContains(t, events[0].Details, jt.Token)
+	})
+
+	t.Run("Raise a not found when the join token does not exist", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodDelete, path, nil)
+
+		err := setup.Handler.RevokeJoinToken(setup.EchoCtx, jt.ID.UUID)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+	})
+}
+
+func TestUDSListBundleVersions(t *testing.T) {
+	path := fmt.Sprintf("/trust-domain/%v/bundles", td1)
+
+	t.Run("Successfully list the bundle history of the trust domain", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, path, nil)
+		td := setupBundleHistory(t, setup.FakeDatabase, 1)
+
+		err := setup.Handler.ListBundleVersions(setup.EchoCtx, td.Name.String())
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var versions []admin.BundleVersion
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &versions)
+		require.NoError(t, err)
+
+		require.Len(t, versions, 2)
+		assert.Equal(t, int64(2), versions[0].Version)
+		assert.False(t, versions[0].Pinned)
+		assert.Equal(t, int64(1), versions[1].Version)
+		assert.True(t, versions[1].Pinned)
+	})
+
+	t.Run("Raise a not found when the trust domain does not exist", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, path, nil)
+
+		err := setup.Handler.ListBundleVersions(setup.EchoCtx, td1)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+	})
+}
+
+func TestUDSGetBundleVersion(t *testing.T) {
+	path := fmt.Sprintf("/trust-domain/%v/bundles/1", td1)
+
+	t.Run("Successfully get a version of the bundle", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, path, nil)
+		td := setupBundleHistory(t, setup.FakeDatabase, 0)
+
+		err := setup.Handler.GetBundleVersion(setup.EchoCtx, td.Name.String(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		version := admin.BundleVersion{}
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &version)
+		require.NoError(t, err)
+
+		stored, err := setup.FakeDatabase.FindBundleVersion(context.Background(), td.ID.UUID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), version.Version)
+		assert.Equal(t, string(stored.Data), version.TrustBundle)
+		assert.Equal(t, encoding.EncodeToBase64(stored.Digest), version.Digest)
+		assert.False(t, version.Pinned)
+	})
+
+	t.Run("Raise a not found when the version does not exist", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, path, nil)
+		td := setupBundleHistory(t, setup.FakeDatabase, 0)
+
+		err := setup.Handler.GetBundleVersion(setup.EchoCtx, td.Name.String(), 3)
+		require.Error(t, err)
+
+		echoHttpErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusNotFound, echoHttpErr.Code)
+		assert.Equal(t, fmt.Sprintf("bundle version 3 does not exist for trust domain %q", td1), echoHttpErr.Message)
+	})
+}
+
+func TestUDSGetBundleVersionDiff(t *testing.T) {
+	path := fmt.Sprintf("/trust-domain/%v/bundles/2/diff", td1)
+
+	t.Run("Successfully compare a version with the previous one", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, path, nil)
+		td := setupBundleHistory(t, setup.FakeDatabase, 0)
+
+		err := setup.Handler.GetBundleVersionDiff(setup.EchoCtx, td.Name.String(), 2, admin.GetBundleVersionDiffParams{})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		diff := admin.BundleDiff{}
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &diff)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(2), diff.Version)
+		assert.Equal(t, int64(1), diff.BaseVersion)
+		require.Len(t, diff.AddedX509Authorities, 1)
+		assert.Equal(t, "CN=authority-2", diff.AddedX509Authorities[0].Subject)
+		assert.Empty(t, diff.RemovedX509Authorities)
+		assert.Equal(t, []string{"key-2"}, diff.AddedJwtAuthorities)
+		assert.Equal(t, []string{"key-1"}, diff.RemovedJwtAuthorities)
+	})
+
+	t.Run("Successfully compare a version with an explicit base version", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, path, nil)
+		td := setupBundleHistory(t, setup.FakeDatabase, 0)
+
+		base := int64(2)
+		err := setup.Handler.GetBundleVersionDiff(setup.EchoCtx, td.Name.String(), 1, admin.GetBundleVersionDiffParams{Base: &base})
+		require.NoError(t, err)
+
+		diff := admin.BundleDiff{}
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &diff)
+		require.NoError(t, err)
+
+		assert.Empty(t, diff.AddedX509Authorities)
+		require.Len(t, diff.RemovedX509Authorities, 1)
+		assert.Equal(t, "CN=authority-2", diff.RemovedX509Authorities[0].Subject)
+		assert.Equal(t, []string{"key-1"}, diff.AddedJwtAuthorities)
+		assert.Equal(t, []string{"key-2"}, diff.RemovedJwtAuthorities)
+	})
+
+	t.Run("Raise a bad request when there is no base version", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, path, nil)
+		td := setupBundleHistory(t, setup.FakeDatabase, 0)
+
+		err := setup.Handler.GetBundleVersionDiff(setup.EchoCtx, td.Name.String(), 1, admin.GetBundleVersionDiffParams{})
+		require.Error(t, err)
+
+		echoHttpErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusBadRequest, echoHttpErr.Code)
+		assert.Equal(t, "there is no base version to compare version 1 with", echoHttpErr.Message)
+	})
+}
+
+func TestUDSRollbackBundle(t *testing.T) {
+	path := fmt.Sprintf("/trust-domain/%v/bundles/1/rollback", td1)
+
+	t.Run("Successfully roll back and pin a previous version of the bundle", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodPost, path, nil)
+		td := setupBundleHistory(t, setup.FakeDatabase, 0)
+
+		err := setup.Handler.RollbackBundle(setup.EchoCtx, td.Name.String(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		version := admin.BundleVersion{}
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &version)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), version.Version)
+		assert.True(t, version.Pinned)
+
+		ctx := context.Background()
+		stored, err := setup.FakeDatabase.FindBundleVersion(ctx, td.ID.UUID, 1)
+		require.NoError(t, err)
+		bundle, err := setup.FakeDatabase.FindBundleByTrustDomainID(ctx, td.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, stored.Data, bundle.Data)
+		assert.Equal(t, stored.Digest, bundle.Digest)
+		assert.Equal(t, int64(1), bundle.PinnedVersion)
+	})
+
+	t.Run("Raise a not found when the version does not exist", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodPost, path, nil)
+		td := setupBundleHistory(t, setup.FakeDatabase, 0)
+
+		err := setup.Handler.RollbackBundle(setup.EchoCtx, td.Name.String(), 5)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+	})
+}
+
+func TestUDSApproveBundleReset(t *testing.T) {
+	path := fmt.Sprintf("/trust-domain/%v/bundle-reset", td1)
+
+	t.Run("Successfully approve a reset of the bundle", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodPost, path, nil)
+		td := &entity.TrustDomain{ID: NewNullableID(), Name: NewTrustDomain(t, td1)}
+		setup.FakeDatabase.WithTrustDomains(td)
+
+		err := setup.Handler.ApproveBundleReset(setup.EchoCtx, td1)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		approval := admin.BundleResetApproval{}
+		err = json.Unmarshal(setup.Recorder.Body.Bytes(), &approval)
+		require.NoError(t, err)
+		assert.Equal(t, td1, approval.TrustDomainName)
+
+		stored, err := setup.FakeDatabase.FindBundleResetApproval(context.Background(), td.ID.UUID)
+		require.NoError(t, err)
+		assert.NotNil(t, stored)
+	})
+
+	t.Run("Raise a not found when the trust domain does not exist", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodPost, path, nil)
+
+		err := setup.Handler.ApproveBundleReset(setup.EchoCtx, td1)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+	})
+}
+
+// setupBundleHistory stores two versions of the bundle of the td1 trust domain and serves the
+// version pinnedVersion, or the latest one if pinnedVersion is 0. The second version adds an
+// X.509 authority and replaces the JWT authority of the first one.
+func setupBundleHistory(t *testing.T, ds *fakedatastore.FakeDatabase, pinnedVersion int64) *entity.TrustDomain {
+	ctx := context.Background()
+	td := &entity.TrustDomain{ID: NewNullableID(), Name: NewTrustDomain(t, td1)}
+	ds.WithTrustDomains(td)
+
+	clk := clock.NewFake()
+	authority1, rootKey := certtest.CreateTestSelfSignedCACertificate(t, clk)
+	authority2, _ := certtest.CreateTestIntermediateCACertificate(t, clk, authority1, rootKey, "authority-2")
+	jwtKey1, err := cryptoutil.GenerateSigner(cryptoutil.DefaultKeyType)
+	require.NoError(t, err)
+	jwtKey2, err := cryptoutil.GenerateSigner(cryptoutil.DefaultKeyType)
+	require.NoError(t, err)
+
+	bundle1 := spiffebundle.New(td.Name)
+	bundle1.AddX509Authority(authority1)
+	require.NoError(t, bundle1.AddJWTAuthority("key-1", jwtKey1.Public()))
+
+	bundle2 := spiffebundle.New(td.Name)
+	bundle2.AddX509Authority(authority1)
+	bundle2.AddX509Authority(authority2)
+	require.NoError(t, bundle2.AddJWTAuthority("key-2", jwtKey2.Public()))
+
+	var versions []*entity.BundleVersion
+	for _, b := range []*spiffebundle.Bundle{bundle1, bundle2} {
+		data, err := b.Marshal()
+		require.NoError(t, err)
+
+		bv, err := ds.CreateBundleVersion(ctx, &entity.BundleVersion{
+			TrustDomainID: td.ID.UUID,
+			Data:          data,
+			Digest:        cryptoutil.CalculateDigest(data),
+			Signature:     []byte("signature"),
+		})
+		require.NoError(t, err)
+		versions = append(versions, bv)
+	}
+
+	served := versions[len(versions)-1]
+	if pinnedVersion != 0 {
+		served = versions[pinnedVersion-1]
+	}
+	_, err = ds.CreateOrUpdateBundle(ctx, &entity.Bundle{
+		TrustDomainID: td.ID.UUID,
+		Data:          served.Data,
+		Digest:        served.Digest,
+		Signature:     served.Signature,
+		PinnedVersion: pinnedVersion,
+	})
+	require.NoError(t, err)
+
+	return td
 }
 
 func NewNullableID() uuid.NullUUID {
@@ -717,3 +1370,97 @@ func equalRelationships(r1 *api.Relationship, r2 *api.Relationship) bool {
 	return r1.Id == r2.Id && r1.TrustDomainAId == r2.TrustDomainAId && r1.TrustDomainBId == r2.TrustDomainBId &&
 		r1.TrustDomainAConsent == r2.TrustDomainAConsent && r1.TrustDomainBConsent == r2.TrustDomainBConsent
 }
+
+func TestUDSListAuditEvents(t *testing.T) {
+	path := "/audit-events"
+
+	setupAuditLog := func(t *testing.T, setup *ManagementTestSetup) []*entity.AuditEvent {
+		ctx := context.Background()
+		var events []*entity.AuditEvent
+		for _, actor := range []string{audit.AdminActor, audit.HarvesterActor(td1), audit.AdminActor} {
+			e, err := setup.FakeDatabase.AppendAuditEvent(ctx, &entity.AuditEvent{Actor: actor, Action: entity.AuditActionTrustDomainUpdate, Target: td1})
+			require.NoError(t, err)
+			events = append(events, e)
+		}
+		return events
+	}
+
+	t.Run("Successfully list the audit log", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, path, nil)
+		events := setupAuditLog(t, setup)
+
+		err := setup.Handler.ListAuditEvents(setup.EchoCtx, admin.ListAuditEventsParams{})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var response []admin.AuditEvent
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &response))
+		require.Len(t, response, 3)
+
+		var listed []*entity.AuditEvent
+		for _, e := range response {
+			ent, err := e.ToEntity()
+			require.NoError(t, err)
+			listed = append(listed, ent)
+		}
+		assert.Equal(t, events[0].Hash, listed[0].Hash)
+		require.NoError(t, audit.VerifyChain(listed))
+	})
+
+	t.Run("Successfully filter the audit log by actor", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, path, nil)
+		setupAuditLog(t, setup)
+
+		actor := audit.HarvesterActor(td1)
+		err := setup.Handler.ListAuditEvents(setup.EchoCtx, admin.ListAuditEventsParams{Actor: &actor})
+		require.NoError(t, err)
+
+		var response []admin.AuditEvent
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &response))
+		require.Len(t, response, 1)
+		assert.Equal(t, actor, response[0].Actor)
+	})
+
+	t.Run("Raise a bad request when the time range is empty", func(t *testing.T) {
+		setup := NewManagementTestSetup(t, http.MethodGet, path, nil)
+
+		now := time.Now()
+		err := setup.Handler.ListAuditEvents(setup.EchoCtx, admin.ListAuditEventsParams{Since: &now, Until: &now})
+		require.Error(t, err)
+		echoHTTPErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusBadRequest, echoHTTPErr.Code)
+		assert.Equal(t, "until must be after since", echoHTTPErr.Message)
+	})
+}
+
+func TestUDSAdminActionsAreAudited(t *testing.T) {
+	ctx := context.Background()
+	setup := NewManagementTestSetup(t, http.MethodPut, "/trust-domain", &admin.PutTrustDomainRequest{Name: td1})
+	logger, _ := logrustest.NewNullLogger()
+	auditor, err := audit.NewRecorder(&audit.Config{Store: setup.FakeDatabase, Logger: logger})
+	require.NoError(t, err)
+	setup.Handler = NewAdminAPIHandlers(logger, setup.FakeDatabase, nil, auditor, nil)
+
+	require.NoError(t, setup.Handler.PutTrustDomain(setup.EchoCtx))
+
+	setup.method = http.MethodGet
+	setup.url = fmt.Sprintf("/trust-domain/%s/join-token", td1)
+	setup.Refresh()
+	require.NoError(t, setup.Handler.GetJoinToken(setup.EchoCtx, td1, admin.GetJoinTokenParams{Ttl: 600}))
+
+	var token admin.JoinTokenResponse
+	require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &token))
+
+	events, err := setup.FakeDatabase.ListAuditEvents(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.NoError(t, audit.VerifyChain(events))
+
+	assert.Equal(t, audit.AdminActor, events[0].Actor)
+	assert.Equal(t, entity.AuditActionTrustDomainCreate, events[0].Action)
+	assert.Equal(t, td1, events[0].Target)
+
+	assert.Equal(t, entity.AuditActionJoinTokenCreate, events[1].Action)
+	assert.Equal(t, td1, events[1].Target)
+	assert.NotContains(t, events[1].Details, token.Token.String())
+}
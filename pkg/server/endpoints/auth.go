@@ -1,8 +1,10 @@
 package endpoints
 
 import (
+	"crypto/x509/pkix"
 	"fmt"
 	"net/http"
+	"time"
 
 	chttp "github.com/HewlettPackard/galadriel/pkg/common/http"
 	"github.com/HewlettPackard/galadriel/pkg/common/jwt"
@@ -12,6 +14,10 @@ import (
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
 
+// harvesterClientCertificateOU is the organizational unit in the subject of the client certificates
+// issued to the Harvesters, whose common name is the name of their trust domain.
+const harvesterClientCertificateOU = "Galadriel Harvester"
+
 type AuthenticationMiddleware struct {
 	datastore    db.Datastore
 	jwtValidator jwt.Validator
@@ -64,3 +70,65 @@ func (m *AuthenticationMiddleware) Authenticate(bearerToken string, echoCtx echo
 
 	return true, nil
 }
+
+// AuthenticateClientCertificate is the middleware method that is responsible for authenticating the calling Harvester
+// using the client certificate it presented in the TLS handshake, verified by the TCP listener against the X509CA.
+// It returns false without an error if no client certificate was presented, so that the Harvester can authenticate
+// with a JWT token instead.
+func (m *AuthenticationMiddleware) AuthenticateClientCertificate(echoCtx echo.Context) (bool, error) {
+	req := echoCtx.Request()
+	if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 {
+		return false, nil
+	}
+
+	leaf := req.TLS.VerifiedChains[0][0]
+	if !isHarvesterClientCertificate(leaf.Subject) {
+		msg := "invalid client certificate: not issued to a Harvester"
+		return false, chttp.LogAndRespondWithError(m.logger, nil, msg, http.StatusUnauthorized)
+	}
+
+	// the connection can outlive the certificate it was authenticated with
+	if time.Now().After(leaf.NotAfter) {
+		msg := "invalid client certificate: expired"
+		return false, chttp.LogAndRespondWithError(m.logger, nil, msg, http.StatusUnauthorized)
+	}
+
+	tdName, err := spiffeid.TrustDomainFromString(leaf.Subject.CommonName)
+	if err != nil {
+		return false, chttp.LogAndRespondWithError(m.logger, err, "invalid client certificate: invalid trust domain name", http.StatusUnauthorized)
+	}
+
+	td, err := m.datastore.FindTrustDomainByName(req.Context(), tdName)
+	if err != nil {
+		return false, chttp.LogAndRespondWithError(m.logger, err, "invalid client certificate: trust domain not found", http.StatusUnauthorized)
+	}
+
+	if td == nil {
+		msg := fmt.Sprintf("trust domain not found: %q", tdName)
+		return false, chttp.LogAndRespondWithError(m.logger, nil, msg, http.StatusUnauthorized)
+	}
+
+	// set the authenticated trust domain ID in the echo context
+	echoCtx.Set(authTrustDomainKey, td)
+
+	return true, nil
+}
+
+// harvesterClientCertificateSubject returns the subject of the client certificates issued to the Harvesters of the trust domain.
+func harvesterClientCertificateSubject(td spiffeid.TrustDomain) pkix.Name {
+	return pkix.Name{
+		CommonName:         td.String(),
+		OrganizationalUnit: []string{harvesterClientCertificateOU},
+	}
+}
+
+// isHarvesterClientCertificate reports whether the subject is the one of a client certificate issued to a Harvester,
+// unlike e.g. the subject of the TLS certificate of the server, issued by the same X509CA.
+func isHarvesterClientCertificate(subject pkix.Name) bool {
+	for _, ou := range subject.OrganizationalUnit {
+		if ou == harvesterClientCertificateOU {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,9 @@ package endpoints
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -11,7 +14,9 @@ import (
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	"github.com/HewlettPackard/galadriel/pkg/common/jwt"
 	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
+	"github.com/HewlettPackard/galadriel/test/certtest"
 	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
+	"github.com/jmhodges/clock"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/sirupsen/logrus"
@@ -105,3 +110,86 @@ func TestAuthenticate(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, echoHTTPErr.Code)
 	})
 }
+
+func TestAuthenticateClientCertificate(t *testing.T) {
+	clk := clock.NewFake()
+	clk.Set(time.Now())
+	caCert, caKey := certtest.CreateTestSelfSignedCACertificate(t, clk)
+
+	td := entity.TrustDomain{Name: spiffeid.RequireTrustDomainFromString("test.com")}
+	issueClientCertificate := func(subject pkix.Name, ttl time.Duration) *x509.Certificate {
+		key, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+		require.NoError(t, err)
+		template, err := cryptoutil.CreateX509Template(clk, key.Public(), subject, nil, nil, ttl)
+		require.NoError(t, err)
+		cert, err := cryptoutil.SignX509(template, caCert, caKey)
+		require.NoError(t, err)
+		return cert
+	}
+	withClientCertificate := func(authnSetup *AuthNTestSetup, cert *x509.Certificate) {
+		authnSetup.EchoCtx.Request().TLS = &tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{cert, caCert}},
+		}
+	}
+
+	t.Run("Harvesters presenting no client certificate must authenticate with a JWT token", func(t *testing.T) {
+		authnSetup := SetupMiddleware(t)
+
+		authenticated, err := authnSetup.Middleware.AuthenticateClientCertificate(authnSetup.EchoCtx)
+		assert.NoError(t, err)
+		assert.False(t, authenticated)
+		assert.Nil(t, authnSetup.EchoCtx.Get(authTrustDomainKey))
+	})
+
+	t.Run("Client certificates issued to a Harvester must be able to pass authn verification", func(t *testing.T) {
+		authnSetup := SetupMiddleware(t)
+		authnSetup.FakeDatabase.WithTrustDomains(&td)
+		withClientCertificate(authnSetup, issueClientCertificate(harvesterClientCertificateSubject(td.Name), time.Hour))
+
+		authenticated, err := authnSetup.Middleware.AuthenticateClientCertificate(authnSetup.EchoCtx)
+		require.NoError(t, err)
+		assert.True(t, authenticated)
+
+		authTD, ok := authnSetup.EchoCtx.Get(authTrustDomainKey).(*entity.TrustDomain)
+		require.True(t, ok)
+		assert.Equal(t, td.Name, authTD.Name)
+	})
+
+	testCases := []struct {
+		name    string
+		cert    *x509.Certificate
+		message string
+	}{
+		{
+			name:    "Certificates not issued to a Harvester must raise unauthorized responses",
+			cert:    issueClientCertificate(pkix.Name{CommonName: td.Name.String()}, time.Hour),
+			message: "invalid client certificate: not issued to a Harvester",
+		},
+		{
+			name:    "Expired client certificates must raise unauthorized responses",
+			cert:    issueClientCertificate(harvesterClientCertificateSubject(td.Name), -time.Minute),
+			message: "invalid client certificate: expired",
+		},
+		{
+			name:    "Client certificates of unknown trust domains must raise unauthorized responses",
+			cert:    issueClientCertificate(harvesterClientCertificateSubject(spiffeid.RequireTrustDomainFromString("other.com")), time.Hour),
+			message: `trust domain not found: "other.com"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			authnSetup := SetupMiddleware(t)
+			authnSetup.FakeDatabase.WithTrustDomains(&td)
+			withClientCertificate(authnSetup, tc.cert)
+
+			authenticated, err := authnSetup.Middleware.AuthenticateClientCertificate(authnSetup.EchoCtx)
+			require.Error(t, err)
+			assert.False(t, authenticated)
+
+			echoHTTPErr := err.(*echo.HTTPError)
+			assert.Equal(t, http.StatusUnauthorized, echoHTTPErr.Code)
+			assert.Equal(t, tc.message, echoHTTPErr.Message)
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package endpoints
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	chttp "github.com/HewlettPackard/galadriel/pkg/common/http"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca"
+	"github.com/HewlettPackard/galadriel/pkg/server/db"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+)
+
+// BundleEndpointProfile is the SPIFFE bundle endpoint profile used to authenticate the bundle endpoint.
+type BundleEndpointProfile string
+
+const (
+	// BundleEndpointProfileHTTPSWeb authenticates the bundle endpoint using a certificate for its DNS names.
+	BundleEndpointProfileHTTPSWeb BundleEndpointProfile = "https_web"
+	// BundleEndpointProfileHTTPSSPIFFE authenticates the bundle endpoint using an X509-SVID.
+	BundleEndpointProfileHTTPSSPIFFE BundleEndpointProfile = "https_spiffe"
+
+	// DefaultBundleRefreshHint is the refresh hint set in the served bundles when none is configured.
+	DefaultBundleRefreshHint = 5 * time.Minute
+
+	// bundleEndpointPath is the path the bundle of each trust domain is served on.
+	bundleEndpointPath = "/bundle/:trustDomainName"
+)
+
+// BundleEndpointConfig represents the configuration of the SPIFFE Federation bundle endpoint.
+type BundleEndpointConfig struct {
+	Address *net.TCPAddr
+	Profile BundleEndpointProfile
+
+	// SPIFFEID is the SPIFFE ID of the bundle endpoint, required by the https_spiffe profile.
+	SPIFFEID spiffeid.ID
+
+	// DNSNames are the DNS names set in the bundle endpoint certificate by the https_web profile.
+	DNSNames []string
+
+	// RefreshHint is the refresh hint set in the served bundles.
+	RefreshHint time.Duration
+}
+
+func (c *BundleEndpointConfig) validate() error {
+	if c.Address == nil {
+		return errors.New("bundle endpoint address is required")
+	}
+
+	switch c.Profile {
+	case BundleEndpointProfileHTTPSWeb:
+		if len(c.DNSNames) == 0 {
+			c.DNSNames = []string{constants.GaladrielServerName}
+		}
+	case BundleEndpointProfileHTTPSSPIFFE:
+		if c.SPIFFEID.IsZero() {
+			return errors.New("bundle endpoint SPIFFE ID is required by the https_spiffe profile")
+		}
+	default:
+		return fmt.Errorf("unknown bundle endpoint profile: %q", c.Profile)
+	}
+
+	if c.RefreshHint < 0 {
+		return errors.New("bundle endpoint refresh hint cannot be negative")
+	}
+	if c.RefreshHint == 0 {
+		c.RefreshHint = DefaultBundleRefreshHint
+	}
+
+	return nil
+}
+
+// BundleEndpointHandler serves the bundles stored in the datastore following
+// the SPIFFE Federation bundle endpoint protocol.
+type BundleEndpointHandler struct {
+	Logger      logrus.FieldLogger
+	Datastore   db.Datastore
+	RefreshHint time.Duration
+}
+
+// NewBundleEndpointHandler creates a new BundleEndpointHandler
+func NewBundleEndpointHandler(l logrus.FieldLogger, ds db.Datastore, refreshHint time.Duration) *BundleEndpointHandler {
+	return &BundleEndpointHandler{
+		Logger:      l,
+		Datastore:   ds,
+		RefreshHint: refreshHint,
+	}
+}
+
+// GetBundle serves the bundle of a trust domain in the SPIFFE bundle format - (GET /bundle/{trustDomainName})
+// Callers presenting an X509-SVID are only served the bundles of the trust domains they
+// have an approved relationship with.
+func (h *BundleEndpointHandler) GetBundle(echoCtx echo.Context) error {
+	ctx := echoCtx.Request().Context()
+
+	tdName, err := spiffeid.TrustDomainFromString(echoCtx.Param("trustDomainName"))
+	if err != nil {
+		err = fmt.Errorf("failed parsing trust domain name: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	td, err := h.Datastore.FindTrustDomainByName(ctx, tdName)
+	if err != nil {
+		msg := "failed looking up trust domain"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+	if td == nil {
+		err := fmt.Errorf("trust domain does not exist: %q", tdName.String())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusNotFound)
+	}
+
+	if err := h.authorizeCaller(ctx, echoCtx.Request().TLS, td); err != nil {
+		return err
+	}
+
+	bundle, err := h.Datastore.FindBundleByTrustDomainID(ctx, td.ID.UUID)
+	if err != nil {
+		msg := "failed looking up bundle"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+	if bundle == nil {
+		err := fmt.Errorf("no bundle for trust domain %q", tdName.String())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusNotFound)
+	}
+
+	data, err := h.marshalBundle(ctx, td, bundle)
+	if err != nil {
+		msg := "failed to build SPIFFE bundle"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	return echoCtx.JSONBlob(http.StatusOK, data)
+}
+
+// authorizeCaller checks that a caller identified by its X509-SVID has an approved relationship
+// with the trust domain. Callers that do not present a certificate cannot be identified and are authorized.
+func (h *BundleEndpointHandler) authorizeCaller(ctx context.Context, state *tls.ConnectionState, td *entity.TrustDomain) error {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return nil
+	}
+
+	callerID, err := x509svid.IDFromCert(state.PeerCertificates[0])
+	if err != nil {
+		err = fmt.Errorf("client certificate is not an X509-SVID: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusUnauthorized)
+	}
+
+	callerTD, err := h.Datastore.FindTrustDomainByName(ctx, callerID.TrustDomain())
+	if err != nil {
+		msg := "failed looking up trust domain"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+	if callerTD == nil {
+		err := fmt.Errorf("unknown caller trust domain %q", callerID.TrustDomain().String())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusUnauthorized)
+	}
+
+	callerBundle, err := h.Datastore.FindBundleByTrustDomainID(ctx, callerTD.ID.UUID)
+	if err != nil {
+		msg := "failed looking up bundle"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+	if callerBundle == nil {
+		err := fmt.Errorf("no bundle to authenticate caller trust domain %q", callerID.TrustDomain().String())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusUnauthorized)
+	}
+
+	bundleSource, err := spiffebundle.Parse(callerTD.Name, callerBundle.Data)
+	if err != nil {
+		msg := "failed to parse caller trust domain bundle"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if _, _, err := x509svid.Verify(state.PeerCertificates, bundleSource); err != nil {
+		err = fmt.Errorf("failed to verify client X509-SVID: %v", err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusUnauthorized)
+	}
+
+	if callerTD.ID.UUID == td.ID.UUID {
+		return nil
+	}
+
+	relationships, err := h.Datastore.FindRelationshipsByTrustDomainID(ctx, callerTD.ID.UUID)
+	if err != nil {
+		msg := "failed looking up relationships"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	for _, r := range relationships {
+		if r.TrustDomainAID != td.ID.UUID && r.TrustDomainBID != td.ID.UUID {
+			continue
+		}
+		if r.TrustDomainAConsent == entity.ConsentStatusApproved && r.TrustDomainBConsent == entity.ConsentStatusApproved {
+			return nil
+		}
+	}
+
+	err = fmt.Errorf("trust domain %q has no approved relationship with %q", callerTD.Name.String(), td.Name.String())
+	return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusForbidden)
+}
+
+// marshalBundle returns the bundle in the SPIFFE bundle format, using its version
+// in the bundle history as sequence number.
+func (h *BundleEndpointHandler) marshalBundle(ctx context.Context, td *entity.TrustDomain, bundle *entity.Bundle) ([]byte, error) {
+	spiffeBundle, err := spiffebundle.Parse(td.Name, bundle.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := h.Datastore.FindBundleVersionByDigest(ctx, td.ID.UUID, bundle.Digest)
+	if err != nil {
+		return nil, err
+	}
+	if version != nil {
+		spiffeBundle.SetSequenceNumber(uint64(version.Version))
+	}
+	spiffeBundle.SetRefreshHint(h.RefreshHint)
+
+	return spiffeBundle.Marshal()
+}
+
+func (e *Endpoints) startBundleEndpointListener(ctx context.Context) error {
+	e.logger.Debug("Starting bundle endpoint listener")
+
+	server := echo.New()
+	server.HideBanner = true
+	server.HidePort = true
+
+	handler := NewBundleEndpointHandler(e.logger.WithField(telemetry.SubsystemName, telemetry.BundleEndpoint), e.datastore, e.bundleEndpoint.RefreshHint)
+	server.GET(bundleEndpointPath, handler.GetBundle)
+	server.Use(chttp.TracingMiddleware(bundleEndpointAPIName), chttp.MetricsMiddleware(bundleEndpointAPIName), middleware.Recover())
+
+	issueCert := func(ctx context.Context) (*tls.Certificate, error) {
+		return e.newTLSCertificate(ctx, e.bundleEndpointCertificateParams())
+	}
+
+	cert, err := issueCert(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start bundle endpoint listener: %w", err)
+	}
+	certsStore := &certificateSource{cert: cert}
+
+	tlsConfig := &tls.Config{
+		GetCertificate: func(info *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return certsStore.getTLSCertificate(), nil
+		},
+		// client certificates are optional, they are verified against the bundle
+		// of the caller trust domain by the handler
+		ClientAuth: tls.RequestClientCert,
+	}
+
+	httpServer := http.Server{
+		Addr:      e.bundleEndpoint.Address.String(),
+		Handler:   server,
+		TLSConfig: tlsConfig,
+	}
+
+	log := e.logger.WithFields(logrus.Fields{
+		telemetry.Network: e.bundleEndpoint.Address.Network(),
+		telemetry.Address: e.bundleEndpoint.Address.String()})
+
+	errChan := make(chan error)
+	go func() {
+		log.WithField(telemetry.Profile, e.bundleEndpoint.Profile).Info("Started bundle endpoint listener")
+		errChan <- httpServer.ListenAndServeTLS("", "")
+	}()
+
+	go e.startTLSCertificateRotation(ctx, certsStore, issueCert, errChan)
+
+	select {
+	case err := <-errChan:
+		log.WithError(err).Error("Bundle endpoint listener stopped prematurely")
+		return err
+	case <-ctx.Done():
+		log.Info("Stopping bundle endpoint listener")
+		if err := httpServer.Close(); err != nil {
+			log.WithError(err).Error("Error closing bundle endpoint listener")
+		}
+		<-errChan
+		log.Info("Bundle endpoint listener stopped")
+		return nil
+	}
+}
+
+func (e *Endpoints) bundleEndpointCertificateParams() *x509ca.X509CertificateParams {
+	params := &x509ca.X509CertificateParams{
+		Subject: pkix.Name{
+			CommonName: constants.GaladrielServerName,
+		},
+		TTL: serverCertificateTTL,
+	}
+
+	switch e.bundleEndpoint.Profile {
+	case BundleEndpointProfileHTTPSSPIFFE:
+		params.URIs = []*url.URL{e.bundleEndpoint.SPIFFEID.URL()}
+	default:
+		params.DNSNames = e.bundleEndpoint.DNSNames
+	}
+
+	return params
+}
@@ -0,0 +1,326 @@
+package endpoints
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/test/certtest"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
+	"github.com/jmhodges/clock"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bundleEndpointTestSetup struct {
+	ds *fakedatastore.FakeDatabase
+
+	// served is the trust domain whose bundle is requested
+	served *entity.TrustDomain
+	bundle *spiffebundle.Bundle
+
+	// callerSVID belongs to a trust domain with an approved relationship with the served one
+	callerSVID []*x509.Certificate
+	// otherSVID belongs to a trust domain with no approved relationship with the served one
+	otherSVID []*x509.Certificate
+}
+
+func TestBundleEndpointGetBundle(t *testing.T) {
+	testCases := []struct {
+		name               string
+		trustDomain        string
+		peerCertificates   func(s *bundleEndpointTestSetup) []*x509.Certificate
+		expectedStatusCode int
+		expectedError      string
+	}{
+		{
+			name:               "Anonymous caller",
+			trustDomain:        "served.org",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:        "Caller with an approved relationship",
+			trustDomain: "served.org",
+			peerCertificates: func(s *bundleEndpointTestSetup) []*x509.Certificate {
+				return s.callerSVID
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:        "Caller without an approved relationship",
+			trustDomain: "served.org",
+			peerCertificates: func(s *bundleEndpointTestSetup) []*x509.Certificate {
+				return s.otherSVID
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedError:      `trust domain "other.org" has no approved relationship with "served.org"`,
+		},
+		{
+			name:        "Caller presenting an X509-SVID not signed by its trust domain",
+			trustDomain: "served.org",
+			peerCertificates: func(s *bundleEndpointTestSetup) []*x509.Certificate {
+				// the X509-SVID of the other trust domain is not signed by the authorities in the caller bundle
+				svid := *s.otherSVID[0]
+				svid.URIs = s.callerSVID[0].URIs
+				return []*x509.Certificate{&svid}
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedError:      "failed to verify client X509-SVID",
+		},
+		{
+			name:        "Caller presenting a certificate that is not an X509-SVID",
+			trustDomain: "served.org",
+			peerCertificates: func(s *bundleEndpointTestSetup) []*x509.Certificate {
+				cert := *s.callerSVID[0]
+				cert.URIs = nil
+				return []*x509.Certificate{&cert}
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedError:      "client certificate is not an X509-SVID",
+		},
+		{
+			name:               "Unknown trust domain",
+			trustDomain:        "unknown.org",
+			expectedStatusCode: http.StatusNotFound,
+			expectedError:      `trust domain does not exist: "unknown.org"`,
+		},
+		{
+			name:               "Trust domain without a bundle",
+			trustDomain:        "empty.org",
+			expectedStatusCode: http.StatusNotFound,
+			expectedError:      `no bundle for trust domain "empty.org"`,
+		},
+		{
+			name:               "Malformed trust domain",
+			trustDomain:        "INVALID.org",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "failed parsing trust domain name",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := setupBundleEndpointTest(t)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/bundle/"+tc.trustDomain, nil)
+			if tc.peerCertificates != nil {
+				req.TLS = &tls.ConnectionState{PeerCertificates: tc.peerCertificates(s)}
+			}
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.SetParamNames("trustDomainName")
+			echoCtx.SetParamValues(tc.trustDomain)
+
+			handler := NewBundleEndpointHandler(logrus.New(), s.ds, time.Minute)
+			err := handler.GetBundle(echoCtx)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				echoErr := err.(*echo.HTTPError)
+				assert.Equal(t, tc.expectedStatusCode, echoErr.Code)
+				assert.Contains(t, echoErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+
+			served, err := spiffebundle.Parse(s.served.Name, rec.Body.Bytes())
+			require.NoError(t, err)
+			assert.True(t, served.X509Bundle().Equal(s.bundle.X509Bundle()))
+
+			sequenceNumber, ok := served.SequenceNumber()
+			require.True(t, ok)
+			assert.Equal(t, uint64(1), sequenceNumber)
+
+			refreshHint, ok := served.RefreshHint()
+			require.True(t, ok)
+			assert.Equal(t, time.Minute, refreshHint)
+		})
+	}
+}
+
+func TestBundleEndpointConfigValidate(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8443}
+
+	c := &BundleEndpointConfig{Address: addr, Profile: BundleEndpointProfileHTTPSWeb}
+	require.NoError(t, c.validate())
+	assert.Equal(t, []string{constants.GaladrielServerName}, c.DNSNames)
+	assert.Equal(t, DefaultBundleRefreshHint, c.RefreshHint)
+
+	c = &BundleEndpointConfig{Address: addr, Profile: BundleEndpointProfileHTTPSSPIFFE}
+	require.EqualError(t, c.validate(), "bundle endpoint SPIFFE ID is required by the https_spiffe profile")
+
+	c = &BundleEndpointConfig{Address: addr, Profile: "https_other"}
+	require.EqualError(t, c.validate(), `unknown bundle endpoint profile: "https_other"`)
+
+	c = &BundleEndpointConfig{Profile: BundleEndpointProfileHTTPSWeb}
+	require.EqualError(t, c.validate(), "bundle endpoint address is required")
+
+	c = &BundleEndpointConfig{Address: addr, Profile: BundleEndpointProfileHTTPSWeb, RefreshHint: -time.Minute}
+	require.EqualError(t, c.validate(), "bundle endpoint refresh hint cannot be negative")
+}
+
+func TestBundleEndpointListenAndServe(t *testing.T) {
+	endpointID := spiffeid.RequireFromString("spiffe://galadriel.org/bundle-endpoint")
+
+	testCases := []struct {
+		name    string
+		profile BundleEndpointProfile
+		assert  func(t *testing.T, cert *x509.Certificate)
+	}{
+		{
+			name:    "https_web",
+			profile: BundleEndpointProfileHTTPSWeb,
+			assert: func(t *testing.T, cert *x509.Certificate) {
+				assert.Equal(t, []string{constants.GaladrielServerName}, cert.DNSNames)
+				assert.Empty(t, cert.URIs)
+			},
+		},
+		{
+			name:    "https_spiffe",
+			profile: BundleEndpointProfileHTTPSSPIFFE,
+			assert: func(t *testing.T, cert *x509.Certificate) {
+				require.Len(t, cert.URIs, 1)
+				assert.Equal(t, endpointID.String(), cert.URIs[0].String())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			config := newEndpointTestConfig(t)
+			config.Catalog = fakeCatalog{
+				ds:         fakedatastore.NewFakeDB(),
+				x509ca:     config.Catalog.GetX509CA(),
+				keyManager: config.Catalog.GetKeyManager(),
+			}
+			config.BundleEndpoint = &BundleEndpointConfig{
+				Address:  newTestTCPAddr(t),
+				Profile:  tc.profile,
+				SPIFFEID: endpointID,
+			}
+
+			endpoints, err := New(config)
+			require.NoError(t, err)
+			endpoints.hooks.tcpListening = make(chan struct{})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			errCh := make(chan error)
+			go func() {
+				errCh <- endpoints.ListenAndServe(ctx)
+			}()
+			defer func() {
+				cancel()
+				assert.NoError(t, <-errCh)
+			}()
+			waitForListening(t, endpoints, errCh)
+
+			client := &http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: &tls.Config{
+						// the test only checks the certificate presented by the bundle endpoint
+						InsecureSkipVerify: true, // nolint: gosec
+					},
+				},
+			}
+			bundleURL := fmt.Sprintf("https://%s/bundle/unknown.org", config.BundleEndpoint.Address.String())
+
+			var resp *http.Response
+			require.Eventually(t, func() bool {
+				resp, err = client.Get(bundleURL)
+				return err == nil
+			}, 5*time.Second, 50*time.Millisecond)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+			require.NotEmpty(t, resp.TLS.PeerCertificates)
+			tc.assert(t, resp.TLS.PeerCertificates[0])
+		})
+	}
+}
+
+func setupBundleEndpointTest(t *testing.T) *bundleEndpointTestSetup {
+	ctx := context.Background()
+	clk := clock.New()
+
+	served := &entity.TrustDomain{ID: NewNullableID(), Name: spiffeid.RequireTrustDomainFromString("served.org")}
+	caller := &entity.TrustDomain{ID: NewNullableID(), Name: spiffeid.RequireTrustDomainFromString("caller.org")}
+	other := &entity.TrustDomain{ID: NewNullableID(), Name: spiffeid.RequireTrustDomainFromString("other.org")}
+	empty := &entity.TrustDomain{ID: NewNullableID(), Name: spiffeid.RequireTrustDomainFromString("empty.org")}
+
+	servedCA, _ := certtest.CreateTestSelfSignedCACertificate(t, clk)
+	servedBundle := spiffebundle.FromX509Authorities(served.Name, []*x509.Certificate{servedCA})
+	servedData, err := servedBundle.Marshal()
+	require.NoError(t, err)
+
+	callerCA, callerKey := certtest.CreateTestSelfSignedCACertificate(t, clk)
+	callerData, err := spiffebundle.FromX509Authorities(caller.Name, []*x509.Certificate{callerCA}).Marshal()
+	require.NoError(t, err)
+
+	otherCA, otherKey := certtest.CreateTestSelfSignedCACertificate(t, clk)
+	otherData, err := spiffebundle.FromX509Authorities(other.Name, []*x509.Certificate{otherCA}).Marshal()
+	require.NoError(t, err)
+
+	ds := fakedatastore.NewFakeDB()
+	ds.WithTrustDomains(served, caller, other, empty)
+	ds.WithBundles(
+		&entity.Bundle{ID: NewNullableID(), TrustDomainID: served.ID.UUID, Data: servedData, Digest: cryptoutil.CalculateDigest(servedData)},
+		&entity.Bundle{ID: NewNullableID(), TrustDomainID: caller.ID.UUID, Data: callerData, Digest: cryptoutil.CalculateDigest(callerData)},
+		&entity.Bundle{ID: NewNullableID(), TrustDomainID: other.ID.UUID, Data: otherData, Digest: cryptoutil.CalculateDigest(otherData)},
+	)
+	ds.WithRelationships(
+		&entity.Relationship{ID: NewNullableID(), TrustDomainAID: served.ID.UUID, TrustDomainBID: caller.ID.UUID, TrustDomainAConsent: entity.ConsentStatusApproved, TrustDomainBConsent: entity.ConsentStatusApproved},
+		&entity.Relationship{ID: NewNullableID(), TrustDomainAID: served.ID.UUID, TrustDomainBID: other.ID.UUID, TrustDomainAConsent: entity.ConsentStatusApproved, TrustDomainBConsent: entity.ConsentStatusPending},
+	)
+	_, err = ds.CreateBundleVersion(ctx, &entity.BundleVersion{TrustDomainID: served.ID.UUID, Data: servedData, Digest: cryptoutil.CalculateDigest(servedData)})
+	require.NoError(t, err)
+
+	return &bundleEndpointTestSetup{
+		ds:         ds,
+		served:     served,
+		callerSVID: createTestX509SVID(t, clk, spiffeid.RequireFromPath(caller.Name, "/workload"), callerCA, callerKey),
+		otherSVID:  createTestX509SVID(t, clk, spiffeid.RequireFromPath(other.Name, "/workload"), otherCA, otherKey),
+		bundle:     servedBundle,
+	}
+}
+
+func createTestX509SVID(t *testing.T, clk clock.Clock, id spiffeid.ID, ca *x509.Certificate, caKey crypto.PrivateKey) []*x509.Certificate {
+	signer, err := cryptoutil.GenerateSigner(cryptoutil.DefaultKeyType)
+	require.NoError(t, err)
+
+	template, err := cryptoutil.CreateX509Template(clk, signer.Public(), pkix.Name{CommonName: "workload"}, []*url.URL{id.URL()}, nil, time.Hour)
+	require.NoError(t, err)
+
+	svid, err := cryptoutil.SignX509(template, ca, caKey)
+	require.NoError(t, err)
+
+	return []*x509.Certificate{svid}
+}
+
+func newTestTCPAddr(t *testing.T) *net.TCPAddr {
+	listener, err := net.ListenTCP("tcp", &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	require.NoError(t, listener.Close())
+
+	return listener.Addr().(*net.TCPAddr)
+}
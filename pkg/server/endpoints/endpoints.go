@@ -2,7 +2,6 @@ package endpoints
 
 import (
 	"context"
-	"crypto"
 	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
@@ -20,20 +19,33 @@ import (
 
 	"github.com/HewlettPackard/galadriel/pkg/common/constants"
 	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/health"
+	chttp "github.com/HewlettPackard/galadriel/pkg/common/http"
 	"github.com/HewlettPackard/galadriel/pkg/common/jwt"
+	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
 	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/common/util"
 	"github.com/HewlettPackard/galadriel/pkg/common/x509ca"
 	adminapi "github.com/HewlettPackard/galadriel/pkg/server/api/admin"
 	harvesterapi "github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+	"github.com/HewlettPackard/galadriel/pkg/server/audit"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
 
 const (
 	serverCertificateTTL = 1 * time.Hour
+
+	// jwksPath is the path of the JWKS document, it does not require authentication.
+	jwksPath = "/.well-known/jwks.json"
+
+	// names of the APIs in the metrics
+	adminAPIName          = "admin"
+	harvesterAPIName      = "harvester"
+	bundleEndpointAPIName = "bundle_endpoint"
 )
 
 // Server manages the UDS and TCP endpoints lifecycle
@@ -41,6 +53,9 @@ type Server interface {
 	// ListenAndServe starts all endpoint servers and blocks until the context
 	// is canceled or any of the endpoints fails to run.
 	ListenAndServe(ctx context.Context) error
+
+	// CheckTLSCertificate reports an error unless the TLS certificate of the TCP listener is loaded and valid.
+	CheckTLSCertificate(ctx context.Context) error
 }
 
 type Endpoints struct {
@@ -50,10 +65,21 @@ type Endpoints struct {
 	logger     logrus.FieldLogger
 
 	x509CA       x509ca.X509CA
+	keyManager   keymanager.KeyManager
 	jwtIssuer    jwt.Issuer
 	jwtValidator jwt.Validator
+	jwtKeyStatus jwt.KeyStatus
 	certsStore   *certificateSource
 
+	bundleVerifiers []*catalog.BundleVerifier
+	bundleEndpoint  *BundleEndpointConfig
+	notifier        *Notifier
+	auditor         *audit.Recorder
+	healthChecker   *health.Checker
+
+	bundleContinuity bool
+	onboardSigners   []spiffeid.ID
+
 	hooks struct {
 		// test hook used to signal that TCP listener is ready
 		tcpListening chan struct{}
@@ -66,8 +92,22 @@ type Config struct {
 	LocalAddress net.Addr
 	JWTIssuer    jwt.Issuer
 	JWTValidator jwt.Validator
+	// JWTKeyStatus is optional, when set, the retired JWT keys are not published in the JWKS.
+	JWTKeyStatus jwt.KeyStatus
 	Catalog      catalog.Catalog
 	Logger       logrus.FieldLogger
+	// BundleEndpoint is optional, when set, the bundles are also served using the SPIFFE Federation bundle endpoint protocol.
+	BundleEndpoint *BundleEndpointConfig
+	// HealthChecker is optional, when set, the liveness and readiness endpoints are served on the UDS.
+	HealthChecker *health.Checker
+	// Auditor is optional, when set, the administrative and Harvester actions are recorded in the audit log.
+	Auditor *audit.Recorder
+	// BundleContinuity requires each bundle uploaded by a Harvester to keep an X.509 authority of the previous
+	// bundle of its trust domain, or to be signed by a key chaining to it, unless an admin approved a reset.
+	BundleContinuity bool
+	// OnboardSigners are optional, the SPIFFE IDs, besides the SPIRE Server of each trust domain, whose
+	// X509-SVIDs can prove the control of their trust domain to onboard a Harvester without a join token.
+	OnboardSigners []spiffeid.ID
 }
 
 type certificateSource struct {
@@ -80,23 +120,46 @@ func New(c *Config) (*Endpoints, error) {
 		return nil, err
 	}
 
+	if c.BundleEndpoint != nil {
+		if err := c.BundleEndpoint.validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	return &Endpoints{
 		tcpAddress:   c.TCPAddress,
 		localAddr:    c.LocalAddress,
 		datastore:    c.Catalog.GetDatastore(),
 		logger:       c.Logger,
 		x509CA:       c.Catalog.GetX509CA(),
+		keyManager:   c.Catalog.GetKeyManager(),
 		jwtIssuer:    c.JWTIssuer,
 		jwtValidator: c.JWTValidator,
+		jwtKeyStatus: c.JWTKeyStatus,
+
+		bundleVerifiers: c.Catalog.GetBundleVerifiers(),
+		bundleEndpoint:  c.BundleEndpoint,
+		notifier:        NewNotifier(),
+		auditor:         c.Auditor,
+		healthChecker:   c.HealthChecker,
+		certsStore:      &certificateSource{},
+
+		bundleContinuity: c.BundleContinuity,
+		onboardSigners:   c.OnboardSigners,
 	}, nil
 }
 
 func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 	e.logger.Debug("Initializing API endpoints")
-	err := util.RunTasks(ctx,
+	tasks := []func(context.Context) error{
 		e.startTCPListener,
 		e.startUDSListener,
-	)
+	}
+	if e.bundleEndpoint != nil {
+		tasks = append(tasks, e.startBundleEndpointListener)
+	}
+
+	err := util.RunTasks(ctx, tasks...)
 	if errors.Is(err, context.Canceled) {
 		err = nil
 	}
@@ -118,11 +181,24 @@ func (e *Endpoints) startTCPListener(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to start TCP listener: %w", err)
 	}
-	e.certsStore = &certificateSource{cert: cert}
+	e.certsStore.setTLSCertificate(cert)
 
+	getCertificate := func(info *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		return e.certsStore.getTLSCertificate(), nil
+	}
+
+	// the client certificates issued to the Harvesters are verified against the current authorities of the X509CA,
+	// so that a change of the X509CA does not lock out the Harvesters authenticating with mutual TLS.
+	// The Harvesters presenting no certificate authenticate with a JWT token
+	clientCAs := &clientCAsSource{}
 	tlsConfig := &tls.Config{
-		GetCertificate: func(info *tls.ClientHelloInfo) (*tls.Certificate, error) {
-			return e.certsStore.getTLSCertificate(), nil
+		GetCertificate: getCertificate,
+		GetConfigForClient: func(info *tls.ClientHelloInfo) (*tls.Config, error) {
+			return &tls.Config{
+				GetCertificate: getCertificate,
+				ClientAuth:     tls.VerifyClientCertIfGiven,
+				ClientCAs:      clientCAs.getPool(e.x509CA.X509Authorities()),
+			}, nil
 		},
 	}
 
@@ -144,7 +220,7 @@ func (e *Endpoints) startTCPListener(ctx context.Context) error {
 		errChan <- httpServer.ListenAndServeTLS("", "")
 	}()
 
-	go e.startTLSCertificateRotation(ctx, errChan)
+	go e.startTLSCertificateRotation(ctx, e.certsStore, e.getTLSCertificate, errChan)
 
 	select {
 	case err := <-errChan:
@@ -176,6 +252,7 @@ func (e *Endpoints) startUDSListener(ctx context.Context) error {
 	}
 	defer l.Close()
 
+	server.Use(chttp.TracingMiddleware(adminAPIName), chttp.MetricsMiddleware(adminAPIName))
 	e.addUDSHandlers(server)
 
 	log := e.logger.WithFields(logrus.Fields{
@@ -205,31 +282,116 @@ func (e *Endpoints) startUDSListener(ctx context.Context) error {
 }
 
 func (e *Endpoints) addUDSHandlers(server *echo.Echo) {
-	adminapi.RegisterHandlers(server, NewAdminAPIHandlers(e.logger, e.datastore))
+	adminapi.RegisterHandlers(server, NewAdminAPIHandlers(e.logger, e.datastore, e.notifier, e.auditor, e.bundleVerifiers))
+	if e.healthChecker != nil {
+		e.healthChecker.RegisterHandlers(server)
+	}
+}
+
+// CheckTLSCertificate reports an error unless the TLS certificate of the TCP listener is loaded and has not expired.
+func (e *Endpoints) CheckTLSCertificate(context.Context) error {
+	cert := e.certsStore.getTLSCertificate()
+	if cert == nil || len(cert.Certificate) == 0 {
+		return errors.New("TLS certificate is not loaded")
+	}
+
+	leaf := cert.Leaf
+	if leaf == nil {
+		var err error
+		if leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			return fmt.Errorf("failed to parse TLS certificate: %w", err)
+		}
+	}
+
+	if time.Now().After(leaf.NotAfter) {
+		return fmt.Errorf("TLS certificate expired at %s", leaf.NotAfter.Format(time.RFC3339))
+	}
+
+	return nil
 }
 
 func (e *Endpoints) addTCPHandlers(server *echo.Echo) {
-	harvesterapi.RegisterHandlers(server, NewHarvesterAPIHandlers(e.logger, e.datastore, e.jwtIssuer, e.jwtValidator))
+	harvesterapi.RegisterHandlers(server, NewHarvesterAPIHandlers(&HarvesterAPIConfig{
+		Logger:           e.logger,
+		Datastore:        e.datastore,
+		JWTIssuer:        e.jwtIssuer,
+		JWTValidator:     e.jwtValidator,
+		KeyManager:       e.keyManager,
+		X509CA:           e.x509CA,
+		JWTKeyStatus:     e.jwtKeyStatus,
+		BundleVerifiers:  e.bundleVerifiers,
+		Notifier:         e.notifier,
+		Auditor:          e.auditor,
+		BundleContinuity: e.bundleContinuity,
+		OnboardSigners:   e.onboardSigners,
+	}))
 }
 
 func (e *Endpoints) addTCPMiddlewares(server *echo.Echo) {
 	logger := e.logger.WithField(telemetry.SubsystemName, telemetry.Endpoints)
 	authNMiddleware := NewAuthenticationMiddleware(logger, e.datastore, e.jwtValidator)
 
-	skipOnboard := func(c echo.Context) bool {
-		return strings.Contains(c.Request().URL.Path, "/onboard")
+	skipAuthentication := func(c echo.Context) bool {
+		path := c.Request().URL.Path
+		return strings.Contains(path, "/onboard") || path == jwksPath
 	}
 
 	myMiddleware := func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			if skipOnboard(c) {
+			if skipAuthentication(c) {
+				return next(c)
+			}
+			// the Harvesters authenticate either with a client certificate or with a JWT token
+			authenticated, err := authNMiddleware.AuthenticateClientCertificate(c)
+			if err != nil {
+				return err
+			}
+			if authenticated {
 				return next(c)
 			}
 			return middleware.KeyAuth(authNMiddleware.Authenticate)(next)(c)
 		}
 	}
 
-	server.Use(myMiddleware, middleware.Recover(), middleware.CORS())
+	server.Use(chttp.TracingMiddleware(harvesterAPIName), chttp.MetricsMiddleware(harvesterAPIName), myMiddleware, middleware.Recover(), middleware.CORS())
+}
+
+// clientCAsSource keeps the pool of the authorities the client certificates are verified against,
+// and rebuilds it when the authorities change.
+type clientCAsSource struct {
+	mu          sync.Mutex
+	authorities []*x509.Certificate
+	pool        *x509.CertPool
+}
+
+func (c *clientCAsSource) getPool(authorities []*x509.Certificate) *x509.CertPool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pool != nil && sameCertificates(c.authorities, authorities) {
+		return c.pool
+	}
+
+	pool := x509.NewCertPool()
+	for _, authority := range authorities {
+		pool.AddCert(authority)
+	}
+	c.authorities = authorities
+	c.pool = pool
+
+	return pool
+}
+
+func sameCertificates(a, b []*x509.Certificate) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
 }
 
 func (t *certificateSource) setTLSCertificate(cert *tls.Certificate) {
@@ -244,7 +406,7 @@ func (t *certificateSource) getTLSCertificate() *tls.Certificate {
 	return t.cert
 }
 
-func (e *Endpoints) startTLSCertificateRotation(ctx context.Context, errChan chan error) {
+func (e *Endpoints) startTLSCertificateRotation(ctx context.Context, certsStore *certificateSource, issueCert func(context.Context) (*tls.Certificate, error), errChan chan error) {
 	e.logger.Info("Started TLS certificate rotator")
 
 	// Start a ticker that rotates the certificate every default interval
@@ -255,11 +417,11 @@ func (e *Endpoints) startTLSCertificateRotation(ctx context.Context, errChan cha
 		select {
 		case <-ticker.C:
 			e.logger.Debug("Rotating Server TLS certificate")
-			cert, err := e.getTLSCertificate(ctx)
+			cert, err := issueCert(ctx)
 			if err != nil {
 				errChan <- fmt.Errorf("failed to rotate Server TLS certificate: %w", err)
 			}
-			e.certsStore.setTLSCertificate(cert)
+			certsStore.setTLSCertificate(cert)
 		case <-ctx.Done():
 			e.logger.Info("Stopped Server TLS certificate rotator")
 			return
@@ -268,13 +430,24 @@ func (e *Endpoints) startTLSCertificateRotation(ctx context.Context, errChan cha
 }
 
 func (e *Endpoints) getTLSCertificate(ctx context.Context) (*tls.Certificate, error) {
+	return e.newTLSCertificate(ctx, &x509ca.X509CertificateParams{
+		Subject: pkix.Name{
+			CommonName: constants.GaladrielServerName,
+		},
+		TTL:      serverCertificateTTL,
+		DNSNames: []string{constants.GaladrielServerName},
+	})
+}
+
+// newTLSCertificate generates a new key and issues a certificate for it using the X509CA.
+func (e *Endpoints) newTLSCertificate(ctx context.Context, params *x509ca.X509CertificateParams) (*tls.Certificate, error) {
 	privateKey, err := cryptoutil.GenerateSigner(cryptoutil.DefaultKeyType)
 	if err != nil {
 		return nil, err
 	}
 
-	publicKey := privateKey.Public()
-	certChain, err := e.issueCertificate(ctx, publicKey)
+	params.PublicKey = privateKey.Public()
+	certChain, err := e.x509CA.IssueX509Certificate(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -297,18 +470,6 @@ func buildTLSCertificate(certPEM []byte, keyPEM []byte) (*tls.Certificate, error
 	return &certificate, nil
 }
 
-func (e *Endpoints) issueCertificate(ctx context.Context, publicKey crypto.PublicKey) ([]*x509.Certificate, error) {
-	params := &x509ca.X509CertificateParams{
-		Subject: pkix.Name{
-			CommonName: constants.GaladrielServerName,
-		},
-		TTL:       serverCertificateTTL,
-		PublicKey: publicKey,
-		DNSNames:  []string{constants.GaladrielServerName},
-	}
-	return e.x509CA.IssueX509Certificate(ctx, params)
-}
-
 func (e *Endpoints) triggerListeningHook() {
 	if e.hooks.tcpListening != nil {
 		e.hooks.tcpListening <- struct{}{}
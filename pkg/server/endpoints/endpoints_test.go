@@ -2,26 +2,39 @@ package endpoints
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"net"
+	"net/http"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/HewlettPackard/galadriel/pkg/common/constants"
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/health"
 	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
 	"github.com/HewlettPackard/galadriel/pkg/common/x509ca"
 	"github.com/HewlettPackard/galadriel/pkg/common/x509ca/disk"
+	"github.com/HewlettPackard/galadriel/pkg/server/catalog"
 	"github.com/HewlettPackard/galadriel/pkg/server/db"
 	"github.com/HewlettPackard/galadriel/test/certtest"
+	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
 	"github.com/jmhodges/clock"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 type fakeCatalog struct {
-	ds         db.Datastore
-	x509ca     x509ca.X509CA
-	keyManager keymanager.KeyManager
+	ds              db.Datastore
+	x509ca          x509ca.X509CA
+	keyManager      keymanager.KeyManager
+	bundleVerifiers []*catalog.BundleVerifier
 }
 
 func (c fakeCatalog) GetX509CA() x509ca.X509CA {
@@ -36,6 +49,10 @@ func (c fakeCatalog) GetDatastore() db.Datastore {
 	return c.ds
 }
 
+func (c fakeCatalog) GetBundleVerifiers() []*catalog.BundleVerifier {
+	return c.bundleVerifiers
+}
+
 func TestListenAndServe(t *testing.T) {
 	config := newEndpointTestConfig(t)
 
@@ -58,6 +75,163 @@ func TestListenAndServe(t *testing.T) {
 	waitForListening(t, endpoints, errCh)
 }
 
+func TestHealthChecks(t *testing.T) {
+	config := newEndpointTestConfig(t)
+	config.HealthChecker = health.NewChecker()
+
+	endpoints, err := New(config)
+	require.NoError(t, err)
+	config.HealthChecker.AddCheck("tls_certificate", endpoints.CheckTLSCertificate)
+
+	// the certificate is loaded when the TCP listener starts
+	require.EqualError(t, endpoints.CheckTLSCertificate(context.Background()), "TLS certificate is not loaded")
+
+	endpoints.hooks.tcpListening = make(chan struct{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- endpoints.ListenAndServe(ctx)
+	}()
+	defer func() {
+		cancel()
+		assert.NoError(t, <-errCh)
+	}()
+
+	waitForListening(t, endpoints, errCh)
+	require.NoError(t, endpoints.CheckTLSCertificate(context.Background()))
+
+	// the health checks are served on the UDS
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, config.LocalAddress.Network(), config.LocalAddress.String())
+		},
+	}}
+
+	var report *health.Report
+	require.Eventually(t, func() bool {
+		report, err = health.Probe(ctx, client, "http://localhost", health.ReadyPath)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, &health.Report{Status: health.StatusOK, Checks: map[string]string{"tls_certificate": health.StatusOK}}, report)
+}
+
+func TestClientCertificateAuthentication(t *testing.T) {
+	config := newEndpointTestConfig(t)
+	fakeDB := fakedatastore.NewFakeDB()
+	td := &entity.TrustDomain{Name: spiffeid.RequireTrustDomainFromString("td1.org")}
+	fakeDB.WithTrustDomains(td)
+	cat := config.Catalog.(fakeCatalog)
+	cat.ds = fakeDB
+	x509CA := &rotatingX509CA{ca: cat.x509ca}
+	cat.x509ca = x509CA
+	config.Catalog = cat
+
+	endpoints, err := New(config)
+	require.NoError(t, err)
+	endpoints.hooks.tcpListening = make(chan struct{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- endpoints.ListenAndServe(ctx)
+	}()
+	defer func() {
+		cancel()
+		assert.NoError(t, <-errCh)
+	}()
+
+	waitForListening(t, endpoints, errCh)
+
+	key, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+	require.NoError(t, err)
+	chain, err := cat.x509ca.IssueX509Certificate(ctx, &x509ca.X509CertificateParams{
+		PublicKey: key.Public(),
+		Subject:   harvesterClientCertificateSubject(td.Name),
+		TTL:       HarvesterClientCertificateTTL,
+	})
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	for _, authority := range cat.x509ca.X509Authorities() {
+		roots.AddCert(authority)
+	}
+
+	get := func(t *testing.T, clientCerts []tls.Certificate) int {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			ServerName:   constants.GaladrielServerName,
+			Certificates: clientCerts,
+		}}}
+		url := fmt.Sprintf("https://%s/trust-domain/%s/relationships", config.TCPAddress, td.Name)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	t.Run("Harvesters authenticate with a client certificate issued by the X509CA", func(t *testing.T) {
+		cert := tls.Certificate{Certificate: [][]byte{chain[0].Raw}, PrivateKey: key}
+		assert.Equal(t, http.StatusOK, get(t, []tls.Certificate{cert}))
+	})
+
+	t.Run("Harvesters presenting no client certificate need a JWT token", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get(t, nil))
+	})
+
+	t.Run("Harvesters authenticate with a client certificate issued by the X509CA after it changed", func(t *testing.T) {
+		newCA, err := disk.New()
+		require.NoError(t, err)
+		certsFolder := certtest.CreateTestCACertificates(t, clock.New())
+		require.NoError(t, newCA.Configure(&disk.Config{
+			CertFilePath: certsFolder + "/root-ca.crt",
+			KeyFilePath:  certsFolder + "/root-ca.key",
+		}))
+		x509CA.set(newCA)
+
+		newChain, err := newCA.IssueX509Certificate(ctx, &x509ca.X509CertificateParams{
+			PublicKey: key.Public(),
+			Subject:   harvesterClientCertificateSubject(td.Name),
+			TTL:       HarvesterClientCertificateTTL,
+		})
+		require.NoError(t, err)
+
+		cert := tls.Certificate{Certificate: [][]byte{newChain[0].Raw}, PrivateKey: key}
+		assert.Equal(t, http.StatusOK, get(t, []tls.Certificate{cert}))
+	})
+}
+
+// rotatingX509CA is an X509CA whose underlying CA can be replaced.
+type rotatingX509CA struct {
+	mu sync.Mutex
+	ca x509ca.X509CA
+}
+
+func (r *rotatingX509CA) set(ca x509ca.X509CA) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ca = ca
+}
+
+func (r *rotatingX509CA) get() x509ca.X509CA {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ca
+}
+
+func (r *rotatingX509CA) IssueX509Certificate(ctx context.Context, params *x509ca.X509CertificateParams) ([]*x509.Certificate, error) {
+	return r.get().IssueX509Certificate(ctx, params)
+}
+
+func (r *rotatingX509CA) X509Authorities() []*x509.Certificate {
+	return r.get().X509Authorities()
+}
+
 func newEndpointTestConfig(t *testing.T) *Config {
 	// used to generate a TCP address with a random port
 	listener, err := net.ListenTCP("tcp", &net.TCPAddr{})
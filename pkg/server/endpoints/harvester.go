@@ -3,9 +3,12 @@ package endpoints
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/api"
@@ -14,36 +17,128 @@ import (
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	chttp "github.com/HewlettPackard/galadriel/pkg/common/http"
 	"github.com/HewlettPackard/galadriel/pkg/common/jwt"
+	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
+	"github.com/HewlettPackard/galadriel/pkg/common/random"
 	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca"
+	"github.com/HewlettPackard/galadriel/pkg/harvester/integrity"
 	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+	"github.com/HewlettPackard/galadriel/pkg/server/audit"
+	"github.com/HewlettPackard/galadriel/pkg/server/catalog"
 	"github.com/HewlettPackard/galadriel/pkg/server/db"
 	gojwt "github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 )
 
 const (
 	authTrustDomainKey = "trust_domain"
 	authClaimsKey      = "auth_claims"
+
+	// MaxJWTTTL is the TTL of the access tokens issued to harvesters when they onboard.
+	// It is the longest TTL of any token issued by the server.
+	MaxJWTTTL = 24 * 5 * time.Hour
+
+	// HarvesterLeaseTTL is the time a Harvester instance holds the upload lease of its trust domain
+	// unless it renews it.
+	HarvesterLeaseTTL = 30 * time.Second
+
+	// MaxBundleSyncWait is the longest time a BundleSync request waits for a change in the federated bundles.
+	MaxBundleSyncWait = 5 * time.Minute
+
+	// bundleSyncRecheckInterval is how often a waiting BundleSync request checks the datastore for changes
+	// that were not notified, e.g. changes made through another Galadriel Server replica.
+	bundleSyncRecheckInterval = 30 * time.Second
+
+	// MaxConsentClockSkew is how far the signing time of a consent statement can be from the time
+	// the server receives it.
+	MaxConsentClockSkew = 5 * time.Minute
+
+	// HarvesterClientCertificateTTL is the TTL of the client certificates issued to the Harvesters.
+	HarvesterClientCertificateTTL = 1 * time.Hour
+
+	// MaxOnboardClockSkew is how far the signing time of an onboard statement can be from the time
+	// the server receives it.
+	MaxOnboardClockSkew = 5 * time.Minute
+
+	// OnboardChallengeTTL is how long the nonce issued to onboard a Harvester by proving the control of its
+	// trust domain can be used.
+	OnboardChallengeTTL = 5 * time.Minute
+
+	// onboardNonceSize is the number of random bytes of the nonces of the onboard challenges.
+	onboardNonceSize = 32
+
+	// spireServerPath is the path of the SPIFFE ID of the SPIRE Server of a trust domain.
+	spireServerPath = "/spire/server"
 )
 
 type HarvesterAPIHandlers struct {
+	Logger          logrus.FieldLogger
+	Datastore       db.Datastore
+	jwtIssuer       jwt.Issuer
+	jwtValidator    jwt.Validator
+	keyManager      keymanager.KeyManager
+	x509CA          x509ca.X509CA
+	jwtKeyStatus    jwt.KeyStatus
+	bundleVerifiers []*catalog.BundleVerifier
+	notifier        *Notifier
+	auditor         *audit.Recorder
+
+	// bundleContinuity requires the bundles uploaded to continue the bundle stored for the trust domain
+	bundleContinuity bool
+
+	// onboardSigners are the SPIFFE IDs, besides the SPIRE Server of the trust domain, that can sign the proof
+	// of the control of their trust domain
+	onboardSigners []spiffeid.ID
+}
+
+// HarvesterAPIConfig is the configuration of the handlers of the Harvester API.
+type HarvesterAPIConfig struct {
 	Logger       logrus.FieldLogger
 	Datastore    db.Datastore
-	jwtIssuer    jwt.Issuer
-	jwtValidator jwt.Validator
+	JWTIssuer    jwt.Issuer
+	JWTValidator jwt.Validator
+	KeyManager   keymanager.KeyManager
+	// X509CA is optional, when set, the Harvesters can get client certificates to authenticate with mutual TLS.
+	X509CA x509ca.X509CA
+	// JWTKeyStatus is optional, when set, the retired keys are not published in the JWKS.
+	JWTKeyStatus jwt.KeyStatus
+	// BundleVerifiers are optional, when set, the bundles uploaded and the consents given by the harvesters must be
+	// signed and accepted by them.
+	BundleVerifiers []*catalog.BundleVerifier
+	// Notifier is optional, when set, the Harvesters waiting for changes are notified of the changes in bundles and relationships.
+	Notifier *Notifier
+	// Auditor is optional, when set, the onboardings, consents and bundle replacements are recorded in the audit log.
+	Auditor *audit.Recorder
+	// BundleContinuity rejects a bundle that does not continue the stored bundle of the trust domain,
+	// unless an admin approved a reset of the bundle.
+	BundleContinuity bool
+	// OnboardSigners are optional, the SPIFFE IDs that can sign the proof of the control of their trust domain
+	// to onboard a Harvester without a join token. The SPIRE Server of the trust domain can always sign it.
+	OnboardSigners []spiffeid.ID
 }
 
 // NewHarvesterAPIHandlers creates a new HarvesterAPIHandlers
-func NewHarvesterAPIHandlers(l logrus.FieldLogger, ds db.Datastore, jwtIssuer jwt.Issuer, jwtValidator jwt.Validator) *HarvesterAPIHandlers {
+func NewHarvesterAPIHandlers(c *HarvesterAPIConfig) *HarvesterAPIHandlers {
 	return &HarvesterAPIHandlers{
-		Logger:       l,
-		Datastore:    ds,
-		jwtIssuer:    jwtIssuer,
-		jwtValidator: jwtValidator,
+		Logger:          c.Logger,
+		Datastore:       c.Datastore,
+		jwtIssuer:       c.JWTIssuer,
+		jwtValidator:    c.JWTValidator,
+		keyManager:      c.KeyManager,
+		x509CA:          c.X509CA,
+		jwtKeyStatus:    c.JWTKeyStatus,
+		bundleVerifiers: c.BundleVerifiers,
+		notifier:        c.Notifier,
+		auditor:         c.Auditor,
+
+		bundleContinuity: c.BundleContinuity,
+		onboardSigners:   c.OnboardSigners,
 	}
 }
 
@@ -136,6 +231,36 @@ func (h *HarvesterAPIHandlers) PatchRelationship(echoCtx echo.Context, trustDoma
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
 	}
 
+	consent, err := patchRequest.ConsentToEntity()
+	if err != nil {
+		msg := "failed to parse request consent"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
+	}
+
+	// when bundle verifiers are configured, the consent must be signed by the Harvester
+	if consent == nil && len(h.bundleVerifiers) > 0 {
+		err := errors.New("a signed consent statement is required")
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	var statement *entity.ConsentStatement
+	if consent != nil {
+		statement, err = checkConsentStatement(consent.ConsentStatement, relationshipID, authTD, entity.ConsentStatus(consentStatus), time.Now())
+		if err != nil {
+			return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+		}
+
+		if err := h.verifyConsent(ctx, authTD, consent); err != nil {
+			msg := "consent signature verification failed"
+			err := fmt.Errorf("%s: %w", msg, err)
+			return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+		}
+
+		consent.RelationshipID = relationshipID
+		consent.TrustDomainID = authTD.ID.UUID
+	}
+
 	// update the relationship consent status for the authenticated trust domain
 	if relationship.TrustDomainAID == authTD.ID.UUID {
 		relationship.TrustDomainAConsent = entity.ConsentStatus(consentStatus)
@@ -143,13 +268,23 @@ func (h *HarvesterAPIHandlers) PatchRelationship(echoCtx echo.Context, trustDoma
 		relationship.TrustDomainBConsent = entity.ConsentStatus(consentStatus)
 	}
 
-	updatedRel, err := h.Datastore.CreateOrUpdateRelationship(ctx, relationship)
+	// the consent status and the consent are stored together, so that the stored consent always matches the
+	// consent status of the trust domain, an unsigned consent replaces the signed one
+	updatedRel, err := h.Datastore.UpdateRelationshipConsent(ctx, relationship, authTD.ID.UUID, consent)
 	if err != nil {
 		msg := "error updating relationship"
 		err := fmt.Errorf("%s: %w", msg, err)
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
 	}
 
+	details := fmt.Sprintf("consent_status: %s", consentStatus)
+	if consent != nil {
+		details = fmt.Sprintf("%s, signed_at: %s", details, statement.SignedAt.UTC().Format(time.RFC3339))
+	}
+
+	h.notifier.Notify(updatedRel.TrustDomainAID, updatedRel.TrustDomainBID)
+	h.auditor.Record(ctx, audit.HarvesterActor(authTD.Name.String()), entity.AuditActionRelationshipUpdate, updatedRel.ID.UUID.String(), details)
+
 	r, err := db.PopulateTrustDomainNames(ctx, h.Datastore, updatedRel)
 	if err != nil {
 		msg := "failed populating relationships entities"
@@ -166,6 +301,43 @@ func (h *HarvesterAPIHandlers) PatchRelationship(echoCtx echo.Context, trustDoma
 	return nil
 }
 
+// GetRelationshipConsents returns the signed consents of the trust domains of a relationship - (GET /trust-domain/{trustDomainName}/relationships/{relationshipID}/consents)
+// Only the trust domains of the relationship can get its consents.
+func (h *HarvesterAPIHandlers) GetRelationshipConsents(echoCtx echo.Context, trustDomainName api.TrustDomainName, relationshipID api.UUID) error {
+	ctx := echoCtx.Request().Context()
+
+	authTD, err := h.getAuthenticateTrustDomain(echoCtx, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	relationship, err := h.Datastore.FindRelationshipByID(ctx, relationshipID)
+	if err != nil {
+		msg := "error looking up relationships"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if relationship == nil {
+		err := fmt.Errorf("relationship not found")
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusNotFound)
+	}
+
+	if relationship.TrustDomainAID != authTD.ID.UUID && relationship.TrustDomainBID != authTD.ID.UUID {
+		err := fmt.Errorf("relationship doesn't belong to the authenticated trust domain")
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusUnauthorized)
+	}
+
+	consents, err := h.Datastore.FindRelationshipConsentsByRelationshipID(ctx, relationshipID)
+	if err != nil {
+		msg := "error looking up relationship consents"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	return chttp.WriteResponse(echoCtx, http.StatusOK, api.MapRelationshipConsents(consents...))
+}
+
 // Onboard introduces a harvester to Galadriel Server providing its join token, and gets back a JWT token - (GET /trust-domain/onboard)
 func (h *HarvesterAPIHandlers) Onboard(echoCtx echo.Context, trustDomainName api.TrustDomainName, params harvester.OnboardParams) error {
 	ctx := echoCtx.Request().Context()
@@ -228,18 +400,184 @@ func (h *HarvesterAPIHandlers) Onboard(echoCtx echo.Context, trustDomainName api
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
 	}
 
-	// mark token as used
-	if _, err := h.Datastore.UpdateJoinToken(ctx, token.ID.UUID, true); err != nil {
+	if err := checkJoinTokenSource(token, echoCtx.Request()); err != nil {
+		msg := "token cannot be used from this source"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusForbidden)
+	}
+
+	if err := checkJoinTokenHarvesterKey(token, tdName, params, time.Now()); err != nil {
+		msg := "token cannot be used by this harvester"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusForbidden)
+	}
+
+	// count the use of the token, the token is used up when it reaches its maximum number of uses
+	usedToken, err := h.Datastore.UseJoinToken(ctx, token.ID.UUID)
+	if err != nil {
 		msg := "failed to update token"
 		err := fmt.Errorf("%s: %w", msg, err)
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
 	}
 
+	if usedToken == nil {
+		msg := "token already used"
+		err := fmt.Errorf("%s: trust domain name: %s", msg, trustDomainName)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
+	}
+
+	return h.respondOnboarded(echoCtx, trustDomain, "")
+}
+
+// OnboardWithProof onboards a Harvester that proves the control of its trust domain, without a join token - (POST /trust-domain/{trustDomainName}/onboard)
+func (h *HarvesterAPIHandlers) OnboardWithProof(echoCtx echo.Context, trustDomainName api.TrustDomainName) error {
+	ctx := echoCtx.Request().Context()
+
+	tdName, err := spiffeid.TrustDomainFromString(trustDomainName)
+	if err != nil {
+		err := fmt.Errorf("invalid trust domain name: %q", trustDomainName)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	var proofRequest harvester.OnboardProofRequest
+	if err := chttp.ParseRequestBodyToStruct(echoCtx, &proofRequest); err != nil {
+		msg := "error reading body"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
+	}
+
+	statement, signature, certChain, err := proofRequest.Decode()
+	if err != nil {
+		msg := "failed to parse request proof"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
+	}
+
+	onboardStatement, err := checkOnboardStatement(statement, tdName, time.Now())
+	if err != nil {
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	trustDomain, err := h.Datastore.FindTrustDomainByName(ctx, tdName)
+	if err != nil {
+		msg := "error looking up trust domain"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if trustDomain == nil {
+		msg := "trust domain not found"
+		err := fmt.Errorf("%s: trust domain name: %s", msg, tdName)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
+	}
+
+	storedBundle, err := h.Datastore.FindBundleByTrustDomainID(ctx, trustDomain.ID.UUID)
+	if err != nil {
+		msg := "error looking up trust domain bundle"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	// the control of the trust domain is proven against the bundle uploaded by its Harvester, so
+	// a trust domain that never uploaded a bundle can only onboard with a join token
+	if storedBundle == nil {
+		msg := "trust domain has no bundle to prove its control, a join token is required"
+		err := fmt.Errorf("%s: trust domain name: %s", msg, tdName)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusForbidden)
+	}
+
+	signer, err := verifyTrustDomainControl(tdName, storedBundle.Data, statement, signature, certChain, h.onboardSigners, time.Now())
+	if err != nil {
+		msg := "proof of trust domain control verification failed"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusForbidden)
+	}
+
+	// the challenge is used up, so that the signed onboard statement cannot be replayed
+	challenge, err := h.Datastore.UseOnboardChallenge(ctx, onboardStatement.Nonce, trustDomain.ID.UUID, time.Now())
+	if err != nil {
+		msg := "failed to use onboard challenge"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if challenge == nil {
+		msg := "onboard challenge is unknown, expired or already used"
+		err := fmt.Errorf("%s: trust domain name: %s", msg, tdName)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusForbidden)
+	}
+
+	return h.respondOnboarded(echoCtx, trustDomain, fmt.Sprintf("signer: %s", signer))
+}
+
+// CreateOnboardChallenge issues the nonce that a Harvester includes in the onboard statement it signs to prove
+// the control of its trust domain - (POST /trust-domain/{trustDomainName}/onboard/challenge)
+func (h *HarvesterAPIHandlers) CreateOnboardChallenge(echoCtx echo.Context, trustDomainName api.TrustDomainName) error {
+	ctx := echoCtx.Request().Context()
+
+	tdName, err := spiffeid.TrustDomainFromString(trustDomainName)
+	if err != nil {
+		err := fmt.Errorf("invalid trust domain name: %q", trustDomainName)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	trustDomain, err := h.Datastore.FindTrustDomainByName(ctx, tdName)
+	if err != nil {
+		msg := "error looking up trust domain"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if trustDomain == nil {
+		msg := "trust domain not found"
+		err := fmt.Errorf("%s: trust domain name: %s", msg, tdName)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
+	}
+
+	// the expired challenges are pruned as new ones are issued, so that the unused challenges do not pile up
+	now := time.Now()
+	if err := h.Datastore.PruneOnboardChallenges(ctx, now); err != nil {
+		msg := "failed to prune onboard challenges"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	nonce, err := random.GenerateRandomString(onboardNonceSize)
+	if err != nil {
+		msg := "failed to generate onboard challenge nonce"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	challenge, err := h.Datastore.CreateOnboardChallenge(ctx, &entity.OnboardChallenge{
+		Nonce:         nonce,
+		TrustDomainID: trustDomain.ID.UUID,
+		ExpiresAt:     now.Add(OnboardChallengeTTL),
+	})
+	if err != nil {
+		msg := "failed to store onboard challenge"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	resp := &harvester.OnboardChallengeResponse{
+		Nonce:     challenge.Nonce,
+		ExpiresAt: challenge.ExpiresAt,
+	}
+
+	return chttp.WriteResponse(echoCtx, http.StatusOK, resp)
+}
+
+// respondOnboarded issues the JWT access token of the onboarded Harvester of the trust domain,
+// and records the onboarding with the given details in the audit log.
+func (h *HarvesterAPIHandlers) respondOnboarded(echoCtx echo.Context, trustDomain *entity.TrustDomain, details string) error {
+	ctx := echoCtx.Request().Context()
+
 	jwtParams := &jwt.JWTParams{
 		Issuer:   constants.GaladrielServerName,
 		Subject:  trustDomain.Name,
 		Audience: []string{constants.GaladrielServerName},
-		TTL:      24 * 5 * time.Hour,
+		TTL:      MaxJWTTTL,
 	}
 
 	jwtToken, err := h.jwtIssuer.IssueJWT(ctx, jwtParams)
@@ -249,7 +587,8 @@ func (h *HarvesterAPIHandlers) Onboard(echoCtx echo.Context, trustDomainName api
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
 	}
 
-	h.Logger.WithField(telemetry.TrustDomain, tdName.String()).Debug("Harvester onboarded successfully")
+	h.Logger.WithField(telemetry.TrustDomain, trustDomain.Name.String()).Debug("Harvester onboarded successfully")
+	h.auditor.Record(ctx, audit.HarvesterActor(trustDomain.Name.String()), entity.AuditActionHarvesterOnboard, trustDomain.Name.String(), details)
 
 	resp := &harvester.OnboardHarvesterResponse{
 		Token:           jwtToken,
@@ -304,6 +643,7 @@ func (h *HarvesterAPIHandlers) GetNewJWTToken(echoCtx echo.Context, trustDomainN
 	}
 
 	newToken, err := h.jwtIssuer.IssueJWT(ctx, &params)
+	telemetry.IncJWTRenewals(err)
 	if err != nil {
 		msg := "failed to generate new JWT token"
 		err := fmt.Errorf("%s: %w", msg, err)
@@ -317,6 +657,99 @@ func (h *HarvesterAPIHandlers) GetNewJWTToken(echoCtx echo.Context, trustDomainN
 	return chttp.WriteResponse(echoCtx, http.StatusOK, jwtResp)
 }
 
+// IssueClientCertificate issues a client certificate for the public key of the certificate signing request of the
+// Harvester - (POST /trust-domain/{trustDomainName}/client-certificate)
+// The Harvester authenticates with the certificate in the TLS handshake instead of a JWT access token, and renews it
+// before it expires over the connection authenticated with it. The trust domain name should match the authenticated one.
+func (h *HarvesterAPIHandlers) IssueClientCertificate(echoCtx echo.Context, trustDomainName api.TrustDomainName) error {
+	ctx := echoCtx.Request().Context()
+
+	authTD, err := h.getAuthenticateTrustDomain(echoCtx, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	if h.x509CA == nil {
+		err := errors.New("client certificates are not supported by the server")
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusNotImplemented)
+	}
+
+	var certRequest harvester.ClientCertificateRequest
+	if err := chttp.ParseRequestBodyToStruct(echoCtx, &certRequest); err != nil {
+		msg := "error reading body"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
+	}
+
+	csr, err := certRequest.ParseCSR()
+	if err != nil {
+		msg := "invalid certificate signing request"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
+	}
+
+	// the identity of the certificate is set by the server, only the public key is taken from the request
+	certChain, err := h.x509CA.IssueX509Certificate(ctx, &x509ca.X509CertificateParams{
+		PublicKey: csr.PublicKey,
+		Subject:   harvesterClientCertificateSubject(authTD.Name),
+		TTL:       HarvesterClientCertificateTTL,
+	})
+	if err != nil {
+		msg := "failed to issue client certificate"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	chainPEM, err := cryptoutil.EncodeCertificates(certChain)
+	if err != nil {
+		msg := "failed to encode client certificate"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	resp := harvester.ClientCertificateResponse{
+		CertificateChain: string(chainPEM),
+		ExpiresAt:        certChain[0].NotAfter,
+	}
+
+	h.Logger.WithField(telemetry.TrustDomain, authTD.Name).Debug("Issued new client certificate")
+
+	return chttp.WriteResponse(echoCtx, http.StatusOK, resp)
+}
+
+// GetJWKS returns the JSON Web Key Set with the public keys that validate the JWT access tokens - (GET /.well-known/jwks.json)
+func (h *HarvesterAPIHandlers) GetJWKS(echoCtx echo.Context) error {
+	ctx := echoCtx.Request().Context()
+
+	keys, err := h.keyManager.GetKeys(ctx)
+	if err != nil {
+		msg := "failed to get keys"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	jwks := harvester.JWKS{Keys: make([]harvester.JWK, 0, len(keys))}
+	for _, key := range keys {
+		if h.jwtKeyStatus != nil && h.jwtKeyStatus.IsRetired(key.ID()) {
+			continue
+		}
+
+		jwk, err := harvester.JWKFromPublicKey(key.ID(), key.Signer().Public())
+		if err != nil {
+			msg := "failed to build JWKS"
+			err := fmt.Errorf("%s: %w", msg, err)
+			return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+		}
+		jwks.Keys = append(jwks.Keys, *jwk)
+	}
+
+	sort.Slice(jwks.Keys, func(i, j int) bool {
+		return jwks.Keys[i].Kid < jwks.Keys[j].Kid
+	})
+
+	return chttp.WriteResponse(echoCtx, http.StatusOK, jwks)
+}
+
 // BundleSync synchronizes the status of trust bundles between server and harvester - (POST /trust-domain/{trustDomainName}/bundles/sync)
 func (h *HarvesterAPIHandlers) BundleSync(echoCtx echo.Context, trustDomainName api.TrustDomainName) error {
 	ctx := echoCtx.Request().Context()
@@ -334,24 +767,35 @@ func (h *HarvesterAPIHandlers) BundleSync(echoCtx echo.Context, trustDomainName
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
 	}
 
-	// Look up relationships the authenticated trust domain has with other trust domains
-	relationships, err := h.Datastore.FindRelationshipsByTrustDomainID(ctx, authTD.ID.UUID)
-	if err != nil {
-		msg := "failed to look up relationships"
-		err := fmt.Errorf("%s: %w", msg, err)
-		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	var wait time.Duration
+	if req.Wait != nil {
+		wait = time.Duration(*req.Wait) * time.Second
+		if wait < 0 || wait > MaxBundleSyncWait {
+			err := fmt.Errorf("wait must be between 0 and %d seconds", int(MaxBundleSyncWait.Seconds()))
+			return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+		}
 	}
 
-	// filer out the relationships whose consent status is not "approved" by the authenticated trust domain
-	relationships = entity.FilterRelationships(relationships, entity.ConsentStatusApproved, &authTD.ID.UUID)
+	// subscribe before looking up the current state, so that no change is missed
+	changes, unsubscribe := h.notifier.Subscribe(authTD.ID.UUID)
+	defer unsubscribe()
 
-	resp, err := h.getBundleSyncResult(ctx, authTD, relationships, req)
+	resp, err := h.syncBundles(ctx, authTD, req)
 	if err != nil {
 		msg := "failed to generate bundle sync result"
 		err := fmt.Errorf("%s: %w", msg, err)
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
 	}
 
+	if wait > 0 && !hasBundleSyncChanges(resp, req) {
+		resp, err = h.waitForBundleSyncChanges(ctx, authTD, req, resp, changes, wait)
+		if err != nil {
+			msg := "failed to generate bundle sync result"
+			err := fmt.Errorf("%s: %w", msg, err)
+			return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+		}
+	}
+
 	h.Logger.WithField(telemetry.TrustDomain, trustDomainName).Debug("Bundle sync request complete")
 
 	return chttp.WriteResponse(echoCtx, http.StatusOK, resp)
@@ -383,6 +827,23 @@ func (h *HarvesterAPIHandlers) BundlePut(echoCtx echo.Context, trustDomainName a
 		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusUnauthorized)
 	}
 
+	var instanceID string
+	if req.InstanceId != nil {
+		instanceID = *req.InstanceId
+	}
+
+	// while a Harvester instance holds the lease of the trust domain, only that instance can upload bundles
+	lease, err := h.Datastore.FindHarvesterLease(ctx, authTD.ID.UUID)
+	if err != nil {
+		msg := "failed looking up harvester lease in DB"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+	if lease != nil && lease.InstanceID != instanceID && lease.ExpiresAt.After(time.Now().UTC()) {
+		err := fmt.Errorf("harvester instance %q holds the lease of trust domain %q", lease.InstanceID, authTD.Name.String())
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusConflict)
+	}
+
 	bundle, err := req.ToEntity()
 	if err != nil {
 		msg := "failed to parse request bundle"
@@ -399,27 +860,392 @@ func (h *HarvesterAPIHandlers) BundlePut(echoCtx echo.Context, trustDomainName a
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
 	}
 
+	if err := h.verifyBundle(authTD, bundle, storedBundle); err != nil {
+		telemetry.IncBundleVerificationFailures(authTD.Name.String())
+		msg := "bundle signature verification failed"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
 	// the bundle already exists in the datastore, so we need to update it
 	if storedBundle != nil {
 		bundle.ID = storedBundle.ID
 	}
 
-	if _, err := h.Datastore.CreateOrUpdateBundle(ctx, bundle); err != nil {
-		msg := "failed to store bundle in DB"
+	knownVersion, err := h.Datastore.FindBundleVersionByDigest(ctx, authTD.ID.UUID, bundle.Digest)
+	if err != nil {
+		msg := "failed looking up bundle version in DB"
 		err := fmt.Errorf("%s: %w", msg, err)
 		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
 	}
 
-	h.Logger.WithField(telemetry.TrustDomain, authTD.Name.String()).Info("Stored new bundle")
+	// a pinned bundle is served until the harvester uploads a bundle that is not in the bundle history yet
+	if storedBundle != nil && storedBundle.PinnedVersion != 0 && knownVersion != nil {
+		h.Logger.WithField(telemetry.TrustDomain, authTD.Name.String()).Infof("Bundle version %d is pinned, ignoring upload of bundle version %d", storedBundle.PinnedVersion, knownVersion.Version)
 
-	if err = chttp.RespondWithoutBody(echoCtx, http.StatusOK); err != nil {
-		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+		if err = chttp.RespondWithoutBody(echoCtx, http.StatusOK); err != nil {
+			return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+		}
+
+		return nil
 	}
 
-	return nil
-}
+	// the bundle did not change, so there is nothing to store and the related trust domains are not notified
+	if storedBundle != nil && bytes.Equal(storedBundle.Digest, bundle.Digest) {
+		if err = chttp.RespondWithoutBody(echoCtx, http.StatusOK); err != nil {
+			return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+		}
 
-func (h *HarvesterAPIHandlers) getBundleSyncResult(ctx context.Context, authTD *entity.TrustDomain, relationships []*entity.Relationship, req harvester.PostBundleSyncRequest) (*harvester.PostBundleSyncResponse, error) {
+		return nil
+	}
+
+	reset := false
+	if storedBundle != nil {
+		reset, err = h.checkBundleContinuity(ctx, authTD, bundle, storedBundle)
+		if err != nil {
+			return err
+		}
+	}
+
+	bundleVersion := &entity.BundleVersion{
+		TrustDomainID:           authTD.ID.UUID,
+		Data:                    bundle.Data,
+		Digest:                  bundle.Digest,
+		Signature:               bundle.Signature,
+		SigningCertificateChain: bundle.SigningCertificateChain,
+		UploadedBy:              instanceID,
+	}
+	newVersion, err := h.Datastore.CreateBundleVersion(ctx, bundleVersion)
+	if err != nil {
+		msg := "failed to store bundle version in DB"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if _, err := h.Datastore.CreateOrUpdateBundle(ctx, bundle); err != nil {
+		msg := "failed to store bundle in DB"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	h.Logger.WithField(telemetry.TrustDomain, authTD.Name.String()).Info("Stored new bundle")
+	if reset {
+		// the approval is consumed, the next bundles must continue this one
+		if err := h.Datastore.DeleteBundleResetApproval(ctx, authTD.ID.UUID); err != nil {
+			h.Logger.WithField(telemetry.TrustDomain, authTD.Name.String()).Errorf("Failed to delete bundle reset approval: %v", err)
+		}
+		h.auditor.Record(ctx, audit.HarvesterActor(authTD.Name.String()), entity.AuditActionBundleReset, authTD.Name.String(), "")
+	}
+	details := fmt.Sprintf("version: %d", newVersion.Version)
+	if instanceID != "" {
+		details += fmt.Sprintf(", instance_id: %s", instanceID)
+	}
+	h.auditor.Record(ctx, audit.HarvesterActor(authTD.Name.String()), entity.AuditActionBundleReplace, authTD.Name.String(), details)
+	notifyRelatedTrustDomains(ctx, h.Datastore, h.notifier, h.Logger, authTD.ID.UUID)
+
+	if err = chttp.RespondWithoutBody(echoCtx, http.StatusOK); err != nil {
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// AcquireLease acquires or renews the upload lease of the trust domain for a Harvester instance - (PUT /trust-domain/{trustDomainName}/lease)
+// Harvesters of a SPIRE Server running in HA mode use the lease to elect the instance that uploads the bundle.
+// If another instance holds an unexpired lease, it responds with 409 Conflict and the current lease.
+func (h *HarvesterAPIHandlers) AcquireLease(echoCtx echo.Context, trustDomainName api.TrustDomainName) error {
+	ctx := echoCtx.Request().Context()
+
+	authTD, err := h.getAuthenticateTrustDomain(echoCtx, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	req := &harvester.AcquireLeaseJSONRequestBody{}
+	if err := chttp.ParseRequestBodyToStruct(echoCtx, req); err != nil {
+		msg := "failed to read lease request from request body"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
+	}
+
+	if req.InstanceId == "" {
+		err := errors.New("instance ID is required")
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	now := time.Now().UTC()
+	lease, err := h.Datastore.AcquireHarvesterLease(ctx, &entity.HarvesterLease{
+		TrustDomainID: authTD.ID.UUID,
+		InstanceID:    req.InstanceId,
+		ExpiresAt:     now.Add(HarvesterLeaseTTL),
+	}, now)
+	if err != nil {
+		msg := "failed to acquire harvester lease"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if lease != nil {
+		return chttp.WriteResponse(echoCtx, http.StatusOK, harvester.LeaseFromEntity(lease))
+	}
+
+	holder, err := h.Datastore.FindHarvesterLease(ctx, authTD.ID.UUID)
+	if err != nil {
+		msg := "failed looking up harvester lease in DB"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+	if holder == nil {
+		err := errors.New("harvester lease was released concurrently")
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusConflict)
+	}
+
+	return chttp.WriteResponse(echoCtx, http.StatusConflict, harvester.LeaseFromEntity(holder))
+}
+
+// ReleaseLease releases the upload lease of the trust domain held by a Harvester instance - (DELETE /trust-domain/{trustDomainName}/lease)
+// Releasing a lease held by another instance has no effect.
+func (h *HarvesterAPIHandlers) ReleaseLease(echoCtx echo.Context, trustDomainName api.TrustDomainName, params harvester.ReleaseLeaseParams) error {
+	ctx := echoCtx.Request().Context()
+
+	authTD, err := h.getAuthenticateTrustDomain(echoCtx, trustDomainName)
+	if err != nil {
+		return err
+	}
+
+	if params.InstanceID == "" {
+		err := errors.New("instance ID is required")
+		return chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	if err := h.Datastore.DeleteHarvesterLease(ctx, authTD.ID.UUID, params.InstanceID); err != nil {
+		msg := "failed to release harvester lease"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	h.Logger.WithField(telemetry.TrustDomain, authTD.Name.String()).Debugf("Harvester instance %q released the lease", params.InstanceID)
+
+	return chttp.RespondWithoutBody(echoCtx, http.StatusOK)
+}
+
+// verifyBundle verifies the signature of the bundle using the configured bundle verifiers.
+// If the trust domain requires a specific verifier, only that verifier is used. Otherwise,
+// the bundle is accepted if any of the verifiers can verify it.
+// When no bundle verifiers are configured, the bundle is accepted without verification.
+// The stored bundle is the last bundle of the trust domain that was accepted, or nil if there is none.
+func (h *HarvesterAPIHandlers) verifyBundle(td *entity.TrustDomain, bundle, storedBundle *entity.Bundle) error {
+	return h.verifySignature(td, storedBundle, "bundle", bundle.Data, bundle.Signature, bundle.SigningCertificateChain)
+}
+
+// verifyConsent verifies the signature of the consent statement of the trust domain, the same
+// way the signatures of its bundles are verified.
+func (h *HarvesterAPIHandlers) verifyConsent(ctx context.Context, td *entity.TrustDomain, consent *entity.RelationshipConsent) error {
+	if len(h.bundleVerifiers) == 0 {
+		return nil
+	}
+
+	storedBundle, err := h.Datastore.FindBundleByTrustDomainID(ctx, td.ID.UUID)
+	if err != nil {
+		return fmt.Errorf("failed looking up bundle in DB: %w", err)
+	}
+
+	return h.verifySignature(td, storedBundle, "consent", consent.ConsentStatement, consent.Signature, consent.SigningCertificateChain)
+}
+
+// verifySignature verifies the signature of a payload signed by the Harvester of the trust domain,
+// using the bundle verifiers selected for the trust domain. The verifiers that check the signer against
+// the trust domain are given its stored bundle as the trusted bundle.
+func (h *HarvesterAPIHandlers) verifySignature(td *entity.TrustDomain, storedBundle *entity.Bundle, subject string, payload, signature, signingCertificateChain []byte) error {
+	if len(h.bundleVerifiers) == 0 {
+		return nil
+	}
+
+	verifiers := h.bundleVerifiers
+	if td.BundleVerifier != "" {
+		verifiers = nil
+		for _, verifier := range h.bundleVerifiers {
+			if verifier.Name == td.BundleVerifier {
+				verifiers = append(verifiers, verifier)
+			}
+		}
+		if len(verifiers) == 0 {
+			return fmt.Errorf("bundle verifier %q required by trust domain %q is not configured", td.BundleVerifier, td.Name)
+		}
+	}
+
+	var certChain []*x509.Certificate
+	if len(signingCertificateChain) > 0 {
+		var err error
+		certChain, err = x509.ParseCertificates(signingCertificateChain)
+		if err != nil {
+			return fmt.Errorf("failed to parse signing certificate chain: %w", err)
+		}
+	}
+
+	var trustedBundle *spiffebundle.Bundle
+	if storedBundle != nil && hasTrustDomainVerifier(verifiers) {
+		var err error
+		trustedBundle, err = spiffebundle.Parse(td.Name, storedBundle.Data)
+		if err != nil {
+			return fmt.Errorf("failed to parse stored bundle: %w", err)
+		}
+	}
+
+	for _, verifier := range verifiers {
+		err := integrity.VerifyForTrustDomain(verifier.Verifier, td.Name, trustedBundle, payload, signature, certChain)
+		if err == nil {
+			return nil
+		}
+		h.Logger.WithField(telemetry.TrustDomain, td.Name.String()).Warnf("Signature of the %s failed verification using %q verifier: %v", subject, verifier.Name, err)
+	}
+
+	return fmt.Errorf("no verifier could verify the %s", subject)
+}
+
+// checkBundleContinuity checks, when the bundle continuity policy is enabled, that the bundle continues the stored
+// bundle of the trust domain. A bundle that does not is only accepted if an admin approved a reset of the bundle of
+// the trust domain, in which case it returns true. It responds with an error if the bundle is rejected.
+func (h *HarvesterAPIHandlers) checkBundleContinuity(ctx context.Context, td *entity.TrustDomain, bundle, storedBundle *entity.Bundle) (bool, error) {
+	if !h.bundleContinuity {
+		return false, nil
+	}
+
+	previous, err := spiffebundle.Parse(td.Name, storedBundle.Data)
+	if err != nil {
+		msg := "failed to parse stored bundle"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return false, chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	var certChain []*x509.Certificate
+	if len(bundle.SigningCertificateChain) > 0 {
+		certChain, err = x509.ParseCertificates(bundle.SigningCertificateChain)
+		if err != nil {
+			msg := "failed to parse signing certificate chain"
+			err := fmt.Errorf("%s: %w", msg, err)
+			return false, chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusBadRequest)
+		}
+	}
+
+	continuityErr := integrity.CheckContinuity(previous, bundle.Data, bundle.Signature, certChain, time.Now())
+	if continuityErr == nil {
+		return false, nil
+	}
+
+	approval, err := h.Datastore.FindBundleResetApproval(ctx, td.ID.UUID)
+	if err != nil {
+		msg := "failed looking up bundle reset approval in DB"
+		err := fmt.Errorf("%s: %w", msg, err)
+		return false, chttp.LogAndRespondWithError(h.Logger, err, msg, http.StatusInternalServerError)
+	}
+
+	if approval == nil {
+		telemetry.IncBundleVerificationFailures(td.Name.String())
+		err := fmt.Errorf("bundle continuity check failed: %w", continuityErr)
+		return false, chttp.LogAndRespondWithError(h.Logger, err, err.Error(), http.StatusBadRequest)
+	}
+
+	h.Logger.WithField(telemetry.TrustDomain, td.Name.String()).Warnf("Accepting bundle that does not continue the stored bundle, as approved by an admin at %s", approval.CreatedAt.UTC().Format(time.RFC3339))
+
+	return true, nil
+}
+
+func hasTrustDomainVerifier(verifiers []*catalog.BundleVerifier) bool {
+	for _, verifier := range verifiers {
+		if _, ok := verifier.Verifier.(integrity.TrustDomainVerifier); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// syncBundles returns the federated bundles of the trust domain that differ from the state in the request,
+// or only the changes made since the cursor in the request, if it is still in the change log.
+func (h *HarvesterAPIHandlers) syncBundles(ctx context.Context, authTD *entity.TrustDomain, req harvester.PostBundleSyncRequest) (*harvester.PostBundleSyncResponse, error) {
+	// the latest change is looked up first, so that the changes made while the result is computed
+	// are returned by the next sync
+	oldestChangeID, latestChangeID, err := h.Datastore.FindChangeEventRange(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up change log: %w", err)
+	}
+
+	// Look up relationships the authenticated trust domain has with other trust domains
+	relationships, err := h.Datastore.FindRelationshipsByTrustDomainID(ctx, authTD.ID.UUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up relationships: %w", err)
+	}
+
+	// filer out the relationships whose consent status is not "approved" by the authenticated trust domain
+	relationships = entity.FilterRelationships(relationships, entity.ConsentStatusApproved, &authTD.ID.UUID)
+
+	var resp *harvester.PostBundleSyncResponse
+	if req.Cursor != nil && isValidCursor(*req.Cursor, oldestChangeID, latestChangeID) {
+		resp, err = h.getIncrementalBundleSyncResult(ctx, authTD, relationships, *req.Cursor, latestChangeID)
+	} else {
+		resp, err = h.getBundleSyncResult(ctx, authTD, relationships, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// without changes in the change log, there is no cursor to resume from
+	if latestChangeID > 0 {
+		resp.Cursor = &latestChangeID
+	}
+
+	return resp, nil
+}
+
+// isValidCursor returns true if no change made after the cursor has been pruned from the change log.
+func isValidCursor(cursor, oldestChangeID, latestChangeID int64) bool {
+	return cursor > 0 && cursor >= oldestChangeID-1 && cursor <= latestChangeID
+}
+
+// waitForBundleSyncChanges waits until the federated bundles of the trust domain differ from the state in the request,
+// or until the wait time elapses, and returns the last sync result.
+func (h *HarvesterAPIHandlers) waitForBundleSyncChanges(ctx context.Context, authTD *entity.TrustDomain, req harvester.PostBundleSyncRequest, resp *harvester.PostBundleSyncResponse, changes <-chan struct{}, wait time.Duration) (*harvester.PostBundleSyncResponse, error) {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	recheck := time.NewTicker(bundleSyncRecheckInterval)
+	defer recheck.Stop()
+
+	for {
+		select {
+		case <-changes:
+		case <-recheck.C:
+		case <-timer.C:
+			return resp, nil
+		case <-ctx.Done():
+			// the Harvester is gone
+			return resp, nil
+		}
+
+		var err error
+		resp, err = h.syncBundles(ctx, authTD, req)
+		if err != nil {
+			return nil, err
+		}
+		if hasBundleSyncChanges(resp, req) {
+			return resp, nil
+		}
+	}
+}
+
+// hasBundleSyncChanges returns true if the federated bundles in the sync result differ from the state in the request.
+func hasBundleSyncChanges(resp *harvester.PostBundleSyncResponse, req harvester.PostBundleSyncRequest) bool {
+	if resp.Incremental != nil && *resp.Incremental {
+		return len(resp.Updates) > 0 || (resp.Removed != nil && len(*resp.Removed) > 0)
+	}
+
+	// every bundle in the result whose digest is not in the request state is an update,
+	// so without updates the result state is a subset of the request state
+	return len(resp.Updates) > 0 || len(resp.State) != len(req.State)
+}
+
+func (h *HarvesterAPIHandlers) getBundleSyncResult(ctx context.Context, authTD *entity.TrustDomain, relationships []*entity.Relationship, req harvester.PostBundleSyncRequest) (*harvester.PostBundleSyncResponse, error) {
 	resp := &harvester.PostBundleSyncResponse{
 		State:   make(map[string]api.BundleDigest, len(relationships)),
 		Updates: make(harvester.BundlesUpdates),
@@ -455,12 +1281,7 @@ func (h *HarvesterAPIHandlers) getBundleSyncResult(ctx context.Context, authTD *
 
 		// The bundle digest in the request is different from the stored one, so the bundle needs to be updated
 		if !ok || !bytes.Equal(bundle.Digest[:], decodedReqDigest) {
-			updateItem := harvester.BundlesUpdatesItem{}
-			updateItem.TrustBundle = string(bundle.Data)
-			updateItem.Digest = encoding.EncodeToBase64(bundle.Digest[:])
-			updateItem.Signature = encoding.EncodeToBase64(bundle.Signature)
-			updateItem.SigningCertificateChain = encoding.EncodeToBase64(bundle.SigningCertificateChain)
-			resp.Updates[bundle.TrustDomainName.String()] = updateItem
+			resp.Updates[bundle.TrustDomainName.String()] = bundleUpdateItem(bundle)
 		}
 
 		// Add the bundle to the current state
@@ -470,6 +1291,78 @@ func (h *HarvesterAPIHandlers) getBundleSyncResult(ctx context.Context, authTD *
 	return resp, nil
 }
 
+// getIncrementalBundleSyncResult returns the federated bundles of the trust domain that changed after the cursor,
+// and the trust domains that are no longer federated.
+func (h *HarvesterAPIHandlers) getIncrementalBundleSyncResult(ctx context.Context, authTD *entity.TrustDomain, relationships []*entity.Relationship, cursor, latestChangeID int64) (*harvester.PostBundleSyncResponse, error) {
+	events, err := h.Datastore.ListChangeEvents(ctx, cursor, latestChangeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up changes: %w", err)
+	}
+
+	federated := make(map[uuid.UUID]bool, len(relationships))
+	for _, relationship := range relationships {
+		if relationship.TrustDomainAID == authTD.ID.UUID {
+			federated[relationship.TrustDomainBID] = true
+		} else {
+			federated[relationship.TrustDomainAID] = true
+		}
+	}
+
+	// the trust domains whose bundle may have changed for the authenticated trust domain
+	changed := make(map[uuid.UUID]spiffeid.TrustDomain)
+	for _, e := range events {
+		switch {
+		case e.Kind == entity.ChangeEventKindRelationship && e.TrustDomainID == authTD.ID.UUID:
+			changed[e.PeerTrustDomainID.UUID] = e.PeerTrustDomainName
+		case e.Kind == entity.ChangeEventKindRelationship && e.PeerTrustDomainID.UUID == authTD.ID.UUID:
+			changed[e.TrustDomainID] = e.TrustDomainName
+		case e.Kind == entity.ChangeEventKindBundle && federated[e.TrustDomainID]:
+			changed[e.TrustDomainID] = e.TrustDomainName
+		}
+	}
+
+	incremental := true
+	resp := &harvester.PostBundleSyncResponse{
+		State:       make(map[string]api.BundleDigest),
+		Updates:     make(harvester.BundlesUpdates),
+		Incremental: &incremental,
+	}
+
+	removed := make([]api.TrustDomainName, 0)
+	for trustDomainID, trustDomainName := range changed {
+		var bundle *entity.Bundle
+		if federated[trustDomainID] {
+			bundle, err = h.Datastore.FindBundleByTrustDomainID(ctx, trustDomainID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if bundle == nil {
+			removed = append(removed, trustDomainName.String())
+			continue
+		}
+
+		resp.Updates[trustDomainName.String()] = bundleUpdateItem(bundle)
+		resp.State[trustDomainName.String()] = encoding.EncodeToBase64(bundle.Digest[:])
+	}
+
+	sort.Strings(removed)
+	resp.Removed = &removed
+
+	return resp, nil
+}
+
+// bundleUpdateItem converts the bundle to an item of the bundle sync updates.
+func bundleUpdateItem(bundle *entity.Bundle) harvester.BundlesUpdatesItem {
+	return harvester.BundlesUpdatesItem{
+		TrustBundle:             string(bundle.Data),
+		Digest:                  encoding.EncodeToBase64(bundle.Digest[:]),
+		Signature:               encoding.EncodeToBase64(bundle.Signature),
+		SigningCertificateChain: encoding.EncodeToBase64(bundle.SigningCertificateChain),
+	}
+}
+
 func (h *HarvesterAPIHandlers) getAuthenticateTrustDomain(echoCtx echo.Context, trustDomainName string) (*entity.TrustDomain, error) {
 	authTD, ok := echoCtx.Get(authTrustDomainKey).(*entity.TrustDomain)
 	if !ok {
@@ -485,6 +1378,212 @@ func (h *HarvesterAPIHandlers) getAuthenticateTrustDomain(echoCtx echo.Context,
 	return authTD, nil
 }
 
+// checkConsentStatement parses the signed consent statement and checks that it states the consent
+// of the trust domain to the relationship, and that it was signed recently.
+func checkConsentStatement(data []byte, relationshipID uuid.UUID, td *entity.TrustDomain, consentStatus entity.ConsentStatus, now time.Time) (*entity.ConsentStatement, error) {
+	statement, err := entity.ParseConsentStatement(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if statement.RelationshipID != relationshipID {
+		return nil, fmt.Errorf("consent statement is for relationship %q", statement.RelationshipID)
+	}
+
+	if statement.TrustDomain != td.Name.String() {
+		return nil, fmt.Errorf("consent statement is for trust domain %q", statement.TrustDomain)
+	}
+
+	if statement.ConsentStatus != consentStatus {
+		return nil, fmt.Errorf("consent statement status %q does not match the requested status %q", statement.ConsentStatus, consentStatus)
+	}
+
+	if skew := now.Sub(statement.SignedAt); skew > MaxConsentClockSkew || skew < -MaxConsentClockSkew {
+		return nil, fmt.Errorf("consent statement was signed at %s, too far from the current time", statement.SignedAt.UTC().Format(time.RFC3339))
+	}
+
+	return statement, nil
+}
+
+// checkJoinTokenSource checks that the request comes from the network the join token is bound to, if any.
+func checkJoinTokenSource(token *entity.JoinToken, req *http.Request) error {
+	if token.SourceCIDR == "" {
+		return nil
+	}
+
+	_, network, err := net.ParseCIDR(token.SourceCIDR)
+	if err != nil {
+		return fmt.Errorf("invalid token source CIDR %q: %w", token.SourceCIDR, err)
+	}
+
+	// the address of the peer is used, as the forwarding headers can be set by the client
+	remoteIP := net.ParseIP(echo.ExtractIPDirect()(req))
+	if remoteIP == nil || !network.Contains(remoteIP) {
+		return fmt.Errorf("remote address %q is not in %s", req.RemoteAddr, token.SourceCIDR)
+	}
+
+	return nil
+}
+
+// checkJoinTokenHarvesterKey checks that the Harvester holds the key the join token is bound to, if any,
+// by verifying the signature of the onboard statement made with the key.
+func checkJoinTokenHarvesterKey(token *entity.JoinToken, tdName spiffeid.TrustDomain, params harvester.OnboardParams, now time.Time) error {
+	if token.HarvesterKeyFingerprint == "" {
+		return nil
+	}
+
+	if params.HarvesterPublicKey == nil || params.Signature == nil || params.SignedAt == nil {
+		return errors.New("harvester public key, signature and signing time are required")
+	}
+
+	der, err := encoding.DecodeFromBase64(*params.HarvesterPublicKey)
+	if err != nil {
+		return fmt.Errorf("failed decoding harvester public key: %w", err)
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return fmt.Errorf("failed parsing harvester public key: %w", err)
+	}
+
+	fingerprint, err := cryptoutil.PublicKeyFingerprint(publicKey)
+	if err != nil {
+		return err
+	}
+	if fingerprint != token.HarvesterKeyFingerprint {
+		return fmt.Errorf("harvester public key fingerprint %q does not match the token", fingerprint)
+	}
+
+	signedAt := params.SignedAt.UTC()
+	if skew := now.Sub(signedAt); skew > MaxOnboardClockSkew || skew < -MaxOnboardClockSkew {
+		return fmt.Errorf("onboard statement was signed at %s, too far from the current time", signedAt.UTC().Format(time.RFC3339))
+	}
+
+	signature, err := encoding.DecodeFromBase64(*params.Signature)
+	if err != nil {
+		return fmt.Errorf("failed decoding signature: %w", err)
+	}
+
+	statement := &entity.OnboardStatement{
+		TrustDomain: tdName.String(),
+		JoinToken:   params.JoinToken,
+		SignedAt:    signedAt,
+	}
+	payload, err := statement.Marshal()
+	if err != nil {
+		return err
+	}
+
+	if err := cryptoutil.VerifySignature(publicKey, payload, signature); err != nil {
+		return fmt.Errorf("failed verifying onboard statement: %w", err)
+	}
+
+	return nil
+}
+
+// checkOnboardStatement parses the onboard statement signed to prove the control of the trust domain, and checks
+// that it states the trust domain to onboard, without a join token, with the nonce of an onboard challenge,
+// and that it was signed recently.
+func checkOnboardStatement(data []byte, tdName spiffeid.TrustDomain, now time.Time) (*entity.OnboardStatement, error) {
+	statement, err := entity.ParseOnboardStatement(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if statement.TrustDomain != tdName.String() {
+		return nil, fmt.Errorf("onboard statement is for trust domain %q", statement.TrustDomain)
+	}
+
+	if statement.JoinToken != "" {
+		return nil, errors.New("onboard statement proving the control of the trust domain cannot have a join token")
+	}
+
+	if statement.Nonce == "" {
+		return nil, errors.New("onboard statement proving the control of the trust domain has no onboard challenge nonce")
+	}
+
+	if skew := now.Sub(statement.SignedAt); skew > MaxOnboardClockSkew || skew < -MaxOnboardClockSkew {
+		return nil, fmt.Errorf("onboard statement was signed at %s, too far from the current time", statement.SignedAt.UTC().Format(time.RFC3339))
+	}
+
+	return statement, nil
+}
+
+// verifyTrustDomainControl verifies that the payload was signed with a key whose X509-SVID chains to the
+// X.509 authorities of the stored bundle of the trust domain. The signer must be the SPIRE Server of the trust
+// domain or one of the allowed signers, so that the workloads of the trust domain cannot onboard a Harvester.
+// It returns the SPIFFE ID of the signer.
+func verifyTrustDomainControl(tdName spiffeid.TrustDomain, bundleData, payload, signature, signingCertificateChain []byte, allowedSigners []spiffeid.ID, now time.Time) (string, error) {
+	bundle, err := spiffebundle.Parse(tdName, bundleData)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse stored bundle: %w", err)
+	}
+
+	certChain, err := x509.ParseCertificates(signingCertificateChain)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse signing certificate chain: %w", err)
+	}
+	if len(certChain) == 0 {
+		return "", errors.New("signing certificate chain is missing")
+	}
+
+	leaf := certChain[0]
+	if leaf.IsCA {
+		return "", errors.New("signing certificate cannot be a CA certificate")
+	}
+
+	id, err := x509svid.IDFromCert(leaf)
+	if err != nil {
+		return "", fmt.Errorf("signing certificate is not an X509-SVID: %w", err)
+	}
+	if id.TrustDomain() != tdName {
+		return "", fmt.Errorf("signer %q is not a member of trust domain %q", id, tdName)
+	}
+	if !isOnboardSigner(id, allowedSigners) {
+		return "", fmt.Errorf("signer %q is not allowed to prove the control of trust domain %q", id, tdName)
+	}
+	signer := id.String()
+
+	roots := x509.NewCertPool()
+	for _, authority := range bundle.X509Authorities() {
+		roots.AddCert(authority)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certChain[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return "", fmt.Errorf("signing certificate does not chain to the bundle of trust domain %q: %w", tdName, err)
+	}
+
+	if err := cryptoutil.VerifySignature(leaf.PublicKey, payload, signature); err != nil {
+		return "", fmt.Errorf("failed verifying onboard statement: %w", err)
+	}
+
+	return signer, nil
+}
+
+// isOnboardSigner returns true if the SPIFFE ID is the one of the SPIRE Server of its trust domain, or one of the allowed signers.
+func isOnboardSigner(id spiffeid.ID, allowedSigners []spiffeid.ID) bool {
+	if id.Path() == spireServerPath {
+		return true
+	}
+
+	for _, allowed := range allowedSigners {
+		if allowed == id {
+			return true
+		}
+	}
+
+	return false
+}
+
 func validateBundleRequest(req *harvester.BundlePutJSONRequestBody) error {
 	if req.TrustDomain == "" {
 		return errors.New("bundle trust domain is required")
@@ -2,37 +2,54 @@ package endpoints
 
 import (
 	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/api"
+	"github.com/HewlettPackard/galadriel/pkg/common/constants"
 	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
 	"github.com/HewlettPackard/galadriel/pkg/common/util/encoding"
+	"github.com/HewlettPackard/galadriel/pkg/common/x509ca/disk"
 	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
+	"github.com/HewlettPackard/galadriel/pkg/server/audit"
+	"github.com/HewlettPackard/galadriel/pkg/server/catalog"
 	"github.com/HewlettPackard/galadriel/pkg/server/db"
+	"github.com/HewlettPackard/galadriel/test/certtest"
 	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
 	"github.com/HewlettPackard/galadriel/test/fakes/fakejwtissuer"
 	"github.com/HewlettPackard/galadriel/test/jwttest"
 	gojwt "github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
+	"github.com/jmhodges/clock"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 const (
-	jwtPath           = "/jwt"
-	onboardPath       = "/onboard"
-	relationshipsPath = "/relationships"
+	jwtPath               = "/jwt"
+	clientCertificatePath = "/client-certificate"
+	onboardPath           = "/onboard"
+	relationshipsPath     = "/relationships"
 )
 
 var (
@@ -52,11 +69,12 @@ var (
 )
 
 type HarvesterTestSetup struct {
-	EchoCtx   echo.Context
-	Handler   *HarvesterAPIHandlers
-	Datastore *fakedatastore.FakeDatabase
-	JWTIssuer *fakejwtissuer.JWTIssuer
-	Recorder  *httptest.ResponseRecorder
+	EchoCtx    echo.Context
+	Handler    *HarvesterAPIHandlers
+	Datastore  *fakedatastore.FakeDatabase
+	JWTIssuer  *fakejwtissuer.JWTIssuer
+	KeyManager keymanager.KeyManager
+	Recorder   *httptest.ResponseRecorder
 }
 
 func NewHarvesterTestSetup(t *testing.T, method, url string, body interface{}) *HarvesterTestSetup {
@@ -77,13 +95,21 @@ func NewHarvesterTestSetup(t *testing.T, method, url string, body interface{}) *
 	jwtAudience := []string{"test"}
 	jwtIssuer := fakejwtissuer.New(t, "test", td1, jwtAudience)
 	jwtValidator := jwttest.NewJWTValidator(jwtIssuer.Signer, jwtAudience)
+	keyManager := keymanager.NewMemoryKeyManager(nil)
 
 	return &HarvesterTestSetup{
-		EchoCtx:   e.NewContext(req, rec),
-		Recorder:  rec,
-		Handler:   NewHarvesterAPIHandlers(logger, fakeDB, jwtIssuer, jwtValidator),
-		JWTIssuer: jwtIssuer,
-		Datastore: fakeDB,
+		EchoCtx:  e.NewContext(req, rec),
+		Recorder: rec,
+		Handler: NewHarvesterAPIHandlers(&HarvesterAPIConfig{
+			Logger:       logger,
+			Datastore:    fakeDB,
+			JWTIssuer:    jwtIssuer,
+			JWTValidator: jwtValidator,
+			KeyManager:   keyManager,
+		}),
+		JWTIssuer:  jwtIssuer,
+		Datastore:  fakeDB,
+		KeyManager: keyManager,
 	}
 }
 
@@ -114,16 +140,17 @@ func SetupBundle(t *testing.T, ds db.Datastore, td uuid.UUID) *entity.Bundle {
 	return bundle
 }
 
-func SetupJoinToken(t *testing.T, ds db.Datastore, td uuid.UUID) *entity.JoinToken {
+// SetupJoinToken creates a join token for the trust domain and returns its plaintext value, which is not stored.
+func SetupJoinToken(t *testing.T, ds db.Datastore, td uuid.UUID) string {
 	jt := &entity.JoinToken{
 		Token:         "test-join-token",
 		TrustDomainID: td,
 	}
 
-	joinToken, err := ds.CreateJoinToken(context.Background(), jt)
+	_, err := ds.CreateJoinToken(context.Background(), jt)
 	require.NoError(t, err)
 
-	return joinToken
+	return jt.Token
 }
 
 func TestTCPGetRelationships(t *testing.T) {
@@ -310,6 +337,235 @@ func testPatchRelationship(t *testing.T, f func(setup *HarvesterTestSetup, trust
 	assert.Equal(t, expected.TrustDomainAConsent, resp.TrustDomainAConsent)
 }
 
+func TestPatchRelationshipSignedConsent(t *testing.T) {
+	acceptVerifier := &catalog.BundleVerifier{Name: "accept", Verifier: fakeBundleVerifier{}}
+	rejectVerifier := &catalog.BundleVerifier{Name: "reject", Verifier: fakeBundleVerifier{err: errors.New("invalid signature")}}
+	relationshipID := uuid.New()
+
+	newStatement := func() *entity.ConsentStatement {
+		return &entity.ConsentStatement{
+			RelationshipID: relationshipID,
+			TrustDomain:    tdA.Name.String(),
+			ConsentStatus:  entity.ConsentStatusApproved,
+			SignedAt:       time.Now().UTC(),
+		}
+	}
+
+	testCases := []struct {
+		name                 string
+		verifiers            []*catalog.BundleVerifier
+		unsigned             bool
+		modifyStatement      func(*entity.ConsentStatement)
+		expectedErrorMessage string
+	}{
+		{
+			name:      "Unsigned consent without verifiers configured",
+			unsigned:  true,
+			verifiers: nil,
+		},
+		{
+			name:      "Signed consent accepted by a verifier",
+			verifiers: []*catalog.BundleVerifier{rejectVerifier, acceptVerifier},
+		},
+		{
+			name:                 "Unsigned consent with verifiers configured",
+			unsigned:             true,
+			verifiers:            []*catalog.BundleVerifier{acceptVerifier},
+			expectedErrorMessage: "a signed consent statement is required",
+		},
+		{
+			name:                 "No verifier accepts the consent",
+			verifiers:            []*catalog.BundleVerifier{rejectVerifier},
+			expectedErrorMessage: "consent signature verification failed: no verifier could verify the consent",
+		},
+		{
+			name:                 "Statement for another relationship",
+			verifiers:            []*catalog.BundleVerifier{acceptVerifier},
+			modifyStatement:      func(s *entity.ConsentStatement) { s.RelationshipID = pendingRelAB.ID.UUID },
+			expectedErrorMessage: fmt.Sprintf("consent statement is for relationship %q", pendingRelAB.ID.UUID),
+		},
+		{
+			name:                 "Statement for another trust domain",
+			verifiers:            []*catalog.BundleVerifier{acceptVerifier},
+			modifyStatement:      func(s *entity.ConsentStatement) { s.TrustDomain = tdC.Name.String() },
+			expectedErrorMessage: `consent statement is for trust domain "td-c.org"`,
+		},
+		{
+			name:                 "Statement with another consent status",
+			verifiers:            []*catalog.BundleVerifier{acceptVerifier},
+			modifyStatement:      func(s *entity.ConsentStatement) { s.ConsentStatus = entity.ConsentStatusDenied },
+			expectedErrorMessage: `consent statement status "denied" does not match the requested status "approved"`,
+		},
+		{
+			name:                 "Statement signed too long ago",
+			verifiers:            []*catalog.BundleVerifier{acceptVerifier},
+			modifyStatement:      func(s *entity.ConsentStatement) { s.SignedAt = s.SignedAt.Add(-time.Hour) },
+			expectedErrorMessage: "too far from the current time",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody := &harvester.PatchRelationshipRequest{ConsentStatus: api.Approved}
+
+			statement := newStatement()
+			if tc.modifyStatement != nil {
+				tc.modifyStatement(statement)
+			}
+			if !tc.unsigned {
+				data, err := statement.Marshal()
+				require.NoError(t, err)
+				encodedStatement := encoding.EncodeToBase64(data)
+				sig := encoding.EncodeToBase64([]byte("test-signature"))
+				requestBody.ConsentStatement = &encodedStatement
+				requestBody.Signature = &sig
+			}
+
+			setup := NewHarvesterTestSetup(t, http.MethodPatch, relationshipsPath+"/"+relationshipID.String(), requestBody)
+			setup.Handler.bundleVerifiers = tc.verifiers
+			setup.Datastore.WithTrustDomains(tdA, tdB, tdC)
+			setup.Datastore.WithRelationships(&entity.Relationship{
+				ID:                  uuid.NullUUID{UUID: relationshipID, Valid: true},
+				TrustDomainAID:      tdA.ID.UUID,
+				TrustDomainBID:      tdC.ID.UUID,
+				TrustDomainAConsent: entity.ConsentStatusPending,
+				TrustDomainBConsent: entity.ConsentStatusPending,
+			})
+			setup.EchoCtx.Set(authTrustDomainKey, tdA)
+
+			// a consent signed before is replaced or removed by the new consent
+			_, err := setup.Datastore.CreateOrUpdateRelationshipConsent(context.Background(), &entity.RelationshipConsent{
+				RelationshipID:   relationshipID,
+				TrustDomainID:    tdA.ID.UUID,
+				ConsentStatement: []byte("previous-statement"),
+			})
+			require.NoError(t, err)
+
+			err = setup.Handler.PatchRelationship(setup.EchoCtx, tdA.Name.String(), relationshipID)
+
+			consents, dsErr := setup.Datastore.FindRelationshipConsentsByRelationshipID(context.Background(), relationshipID)
+			require.NoError(t, dsErr)
+
+			if tc.expectedErrorMessage != "" {
+				require.Error(t, err)
+				assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+				assert.Contains(t, err.(*echo.HTTPError).Message, tc.expectedErrorMessage)
+				require.Len(t, consents, 1)
+				assert.Equal(t, []byte("previous-statement"), consents[0].ConsentStatement)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+			if tc.unsigned {
+				assert.Empty(t, consents)
+				return
+			}
+
+			require.Len(t, consents, 1)
+			assert.Equal(t, tdA.ID.UUID, consents[0].TrustDomainID)
+			assert.Equal(t, *requestBody.ConsentStatement, encoding.EncodeToBase64(consents[0].ConsentStatement))
+			assert.Equal(t, []byte("test-signature"), consents[0].Signature)
+		})
+	}
+}
+
+func TestPatchRelationshipFailsToStoreConsent(t *testing.T) {
+	relationshipID := uuid.New()
+	requestBody := &harvester.PatchRelationshipRequest{ConsentStatus: api.Approved}
+
+	setup := NewHarvesterTestSetup(t, http.MethodPatch, relationshipsPath+"/"+relationshipID.String(), requestBody)
+	setup.Datastore.WithTrustDomains(tdA, tdC)
+	setup.Datastore.WithRelationships(&entity.Relationship{
+		ID:                  uuid.NullUUID{UUID: relationshipID, Valid: true},
+		TrustDomainAID:      tdA.ID.UUID,
+		TrustDomainBID:      tdC.ID.UUID,
+		TrustDomainAConsent: entity.ConsentStatusPending,
+		TrustDomainBConsent: entity.ConsentStatusPending,
+	})
+	setup.EchoCtx.Set(authTrustDomainKey, tdA)
+
+	_, err := setup.Datastore.CreateOrUpdateRelationshipConsent(context.Background(), &entity.RelationshipConsent{
+		RelationshipID:   relationshipID,
+		TrustDomainID:    tdA.ID.UUID,
+		ConsentStatement: []byte("previous-statement"),
+	})
+	require.NoError(t, err)
+	_, latestID, err := setup.Datastore.FindChangeEventRange(context.Background())
+	require.NoError(t, err)
+
+	// the relationship is found, storing the consent status along with the consent fails
+	setup.Datastore.AppendNextError(nil)
+	setup.Datastore.AppendNextError(errors.New("datastore unavailable"))
+
+	err = setup.Handler.PatchRelationship(setup.EchoCtx, tdA.Name.String(), relationshipID)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.(*echo.HTTPError).Code)
+
+	// neither the consent status change nor the consent deletion were stored
+	_, latestIDAfter, err := setup.Datastore.FindChangeEventRange(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, latestID, latestIDAfter)
+
+	consents, err := setup.Datastore.FindRelationshipConsentsByRelationshipID(context.Background(), relationshipID)
+	require.NoError(t, err)
+	require.Len(t, consents, 1)
+	assert.Equal(t, []byte("previous-statement"), consents[0].ConsentStatement)
+}
+
+func TestGetRelationshipConsents(t *testing.T) {
+	consent := &entity.RelationshipConsent{
+		RelationshipID:   pendingRelAC.ID.UUID,
+		TrustDomainID:    tdC.ID.UUID,
+		ConsentStatement: []byte("statement"),
+		Signature:        []byte("signature"),
+	}
+
+	newSetup := func(authTD *entity.TrustDomain) *HarvesterTestSetup {
+		setup := NewHarvesterTestSetup(t, http.MethodGet, relationshipsPath+"/"+pendingRelAC.ID.UUID.String()+"/consents", nil)
+		setup.Datastore.WithTrustDomains(tdA, tdB, tdC)
+		setup.Datastore.WithRelationships(pendingRelAC)
+		_, err := setup.Datastore.CreateOrUpdateRelationshipConsent(context.Background(), consent)
+		require.NoError(t, err)
+		setup.EchoCtx.Set(authTrustDomainKey, authTD)
+		return setup
+	}
+
+	t.Run("Peer of the relationship gets the consents", func(t *testing.T) {
+		setup := newSetup(tdA)
+
+		err := setup.Handler.GetRelationshipConsents(setup.EchoCtx, tdA.Name.String(), pendingRelAC.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var consents []*api.RelationshipConsent
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &consents))
+		require.Len(t, consents, 1)
+		got, err := consents[0].ToEntity()
+		require.NoError(t, err)
+		assert.Equal(t, tdC.ID.UUID, got.TrustDomainID)
+		assert.Equal(t, consent.ConsentStatement, got.ConsentStatement)
+		assert.Equal(t, consent.Signature, got.Signature)
+	})
+
+	t.Run("Fails for a trust domain outside of the relationship", func(t *testing.T) {
+		setup := newSetup(tdB)
+
+		err := setup.Handler.GetRelationshipConsents(setup.EchoCtx, tdB.Name.String(), pendingRelAC.ID.UUID)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.(*echo.HTTPError).Code)
+	})
+
+	t.Run("Fails for an unknown relationship", func(t *testing.T) {
+		setup := newSetup(tdA)
+
+		err := setup.Handler.GetRelationshipConsents(setup.EchoCtx, tdA.Name.String(), uuid.New())
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+	})
+}
+
 func TestTCPOnboard(t *testing.T) {
 	t.Run("Successfully onboard a new agent", func(t *testing.T) {
 		// Arrange
@@ -320,7 +576,7 @@ func TestTCPOnboard(t *testing.T) {
 		token := SetupJoinToken(t, harvesterTestSetup.Handler.Datastore, td.ID.UUID)
 
 		params := harvester.OnboardParams{
-			JoinToken: token.Token,
+			JoinToken: token,
 		}
 
 		// Act
@@ -384,7 +640,7 @@ func TestTCPOnboard(t *testing.T) {
 		token := SetupJoinToken(t, harvesterTestSetup.Handler.Datastore, td.ID.UUID)
 
 		params := harvester.OnboardParams{
-			JoinToken: token.Token,
+			JoinToken: token,
 		}
 		err := harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), params)
 		require.NoError(t, err)
@@ -397,6 +653,420 @@ func TestTCPOnboard(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
 		assert.Contains(t, httpErr.Message, "token already used")
 	})
+	t.Run("onboard with multi-use join token until it is used up", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, onboardPath, nil)
+		echoCtx := harvesterTestSetup.EchoCtx
+
+		td := SetupTrustDomain(t, harvesterTestSetup.Handler.Datastore)
+		jt := &entity.JoinToken{Token: "multi-use-join-token", TrustDomainID: td.ID.UUID, MaxUses: 3}
+		_, err := harvesterTestSetup.Handler.Datastore.CreateJoinToken(context.Background(), jt)
+		require.NoError(t, err)
+
+		params := harvester.OnboardParams{
+			JoinToken: "multi-use-join-token",
+		}
+		for i := 0; i < 3; i++ {
+			err = harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), params)
+			require.NoError(t, err)
+		}
+
+		err = harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), params)
+		require.Error(t, err)
+
+		httpErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+		assert.Contains(t, httpErr.Message, "token already used")
+	})
+	t.Run("onboard with join token bound to a source CIDR", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, onboardPath, nil)
+		echoCtx := harvesterTestSetup.EchoCtx
+		echoCtx.Request().RemoteAddr = "10.0.1.5:4321"
+
+		td := SetupTrustDomain(t, harvesterTestSetup.Handler.Datastore)
+		for _, jt := range []*entity.JoinToken{
+			{Token: "join-token-in-network", TrustDomainID: td.ID.UUID, SourceCIDR: "10.0.1.0/24"},
+			{Token: "join-token-other-network", TrustDomainID: td.ID.UUID, SourceCIDR: "10.0.2.0/24"},
+		} {
+			_, err := harvesterTestSetup.Handler.Datastore.CreateJoinToken(context.Background(), jt)
+			require.NoError(t, err)
+		}
+
+		err := harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), harvester.OnboardParams{JoinToken: "join-token-in-network"})
+		require.NoError(t, err)
+
+		// forwarding headers are not trusted
+		echoCtx.Request().Header.Set(echo.HeaderXForwardedFor, "10.0.2.5")
+		err = harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), harvester.OnboardParams{JoinToken: "join-token-other-network"})
+		require.Error(t, err)
+
+		httpErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusForbidden, httpErr.Code)
+		assert.Contains(t, httpErr.Message, "token cannot be used from this source")
+	})
+	t.Run("onboard with join token bound to a harvester key", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, onboardPath, nil)
+		echoCtx := harvesterTestSetup.EchoCtx
+
+		key, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+		require.NoError(t, err)
+		otherKey, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+		require.NoError(t, err)
+		fingerprint, err := cryptoutil.PublicKeyFingerprint(key.Public())
+		require.NoError(t, err)
+
+		td := SetupTrustDomain(t, harvesterTestSetup.Handler.Datastore)
+		jt := &entity.JoinToken{Token: "bound-join-token", TrustDomainID: td.ID.UUID, MaxUses: 10, HarvesterKeyFingerprint: fingerprint}
+		_, err = harvesterTestSetup.Handler.Datastore.CreateJoinToken(context.Background(), jt)
+		require.NoError(t, err)
+
+		signedParams := func(signer crypto.Signer, publicKey crypto.PublicKey, signedAt time.Time) harvester.OnboardParams {
+			statement := &entity.OnboardStatement{TrustDomain: td.Name.String(), JoinToken: "bound-join-token", SignedAt: signedAt}
+			payload, err := statement.Marshal()
+			require.NoError(t, err)
+			signature, err := signer.Sign(rand.Reader, cryptoutil.CalculateDigest(payload), crypto.SHA256)
+			require.NoError(t, err)
+			der, err := x509.MarshalPKIXPublicKey(publicKey)
+			require.NoError(t, err)
+
+			encodedKey := encoding.EncodeToBase64(der)
+			encodedSignature := encoding.EncodeToBase64(signature)
+			return harvester.OnboardParams{
+				JoinToken:          "bound-join-token",
+				HarvesterPublicKey: &encodedKey,
+				SignedAt:           &signedAt,
+				Signature:          &encodedSignature,
+			}
+		}
+
+		now := time.Now().UTC().Truncate(time.Second)
+		err = harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), signedParams(key, key.Public(), now))
+		require.NoError(t, err)
+
+		testCases := []struct {
+			name   string
+			params harvester.OnboardParams
+		}{
+			{name: "without signature", params: harvester.OnboardParams{JoinToken: "bound-join-token"}},
+			{name: "with another key", params: signedParams(otherKey, otherKey.Public(), now)},
+			{name: "with a signature of another key", params: signedParams(otherKey, key.Public(), now)},
+			{name: "with an old signature", params: signedParams(key, key.Public(), now.Add(-MaxOnboardClockSkew-time.Minute))},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := harvesterTestSetup.Handler.Onboard(echoCtx, td.Name.String(), tc.params)
+				require.Error(t, err)
+
+				httpErr := err.(*echo.HTTPError)
+				assert.Equal(t, http.StatusForbidden, httpErr.Code)
+				assert.Contains(t, httpErr.Message, "token cannot be used by this harvester")
+			})
+		}
+	})
+}
+
+func TestTCPOnboardWithProof(t *testing.T) {
+	clk := clock.New()
+	authority, authorityKey := certtest.CreateTestSelfSignedCACertificate(t, clk)
+	otherAuthority, otherAuthorityKey := certtest.CreateTestSelfSignedCACertificate(t, clk)
+
+	createSVID := func(t *testing.T, ca *x509.Certificate, caKey crypto.PrivateKey, id string) (*x509.Certificate, crypto.Signer) {
+		key, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+		require.NoError(t, err)
+		uri, err := url.Parse(id)
+		require.NoError(t, err)
+		template, err := cryptoutil.CreateX509Template(clk, key.Public(), pkix.Name{}, []*url.URL{uri}, nil, time.Hour)
+		require.NoError(t, err)
+		cert, err := cryptoutil.SignX509(template, ca, caKey)
+		require.NoError(t, err)
+		return cert, key
+	}
+
+	signStatement := func(t *testing.T, statement *entity.OnboardStatement, key crypto.Signer, chain ...*x509.Certificate) *harvester.OnboardProofRequest {
+		data, err := statement.Marshal()
+		require.NoError(t, err)
+		signature, err := key.Sign(rand.Reader, cryptoutil.CalculateDigest(data), crypto.SHA256)
+		require.NoError(t, err)
+
+		var chainBytes []byte
+		for _, cert := range chain {
+			chainBytes = append(chainBytes, cert.Raw...)
+		}
+
+		return &harvester.OnboardProofRequest{
+			OnboardStatement:        encoding.EncodeToBase64(data),
+			Signature:               encoding.EncodeToBase64(signature),
+			SigningCertificateChain: encoding.EncodeToBase64(chainBytes),
+		}
+	}
+
+	now := time.Now().UTC()
+	nonce := "test-nonce"
+	statement := &entity.OnboardStatement{TrustDomain: td1, Nonce: nonce, SignedAt: now}
+	svid, svidKey := createSVID(t, authority, authorityKey, "spiffe://"+td1+"/spire/server")
+	harvesterSVID, harvesterSVIDKey := createSVID(t, authority, authorityKey, "spiffe://"+td1+"/harvester")
+	workloadSVID, workloadSVIDKey := createSVID(t, authority, authorityKey, "spiffe://"+td1+"/workload")
+	otherTDSVID, otherTDSVIDKey := createSVID(t, authority, authorityKey, "spiffe://"+td2+"/spire/server")
+	untrustedSVID, untrustedSVIDKey := createSVID(t, otherAuthority, otherAuthorityKey, "spiffe://"+td1+"/spire/server")
+	intermediate, intermediateKey := certtest.CreateTestIntermediateCACertificate(t, clk, authority, authorityKey, "intermediate-ca")
+	intermediateSigner, ok := intermediateKey.(crypto.Signer)
+	require.True(t, ok)
+
+	leafKey, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+	require.NoError(t, err)
+	leafTemplate, err := cryptoutil.CreateX509Template(clk, leafKey.Public(), pkix.Name{CommonName: "leaf"}, nil, nil, time.Hour)
+	require.NoError(t, err)
+	leaf, err := cryptoutil.SignX509(leafTemplate, authority, authorityKey)
+	require.NoError(t, err)
+
+	harvesterID := spiffeid.RequireFromString("spiffe://" + td1 + "/harvester")
+
+	testCases := []struct {
+		name             string
+		request          *harvester.OnboardProofRequest
+		onboardSigners   []spiffeid.ID
+		noBundle         bool
+		expiredChallenge bool
+		expectedCode     int
+		expectedError    string
+	}{
+		{
+			name:    "Successfully onboard with the X509-SVID of the SPIRE Server of the trust domain",
+			request: signStatement(t, statement, svidKey, svid),
+		},
+		{
+			name:           "Successfully onboard with an X509-SVID of an allowed signer",
+			request:        signStatement(t, statement, harvesterSVIDKey, harvesterSVID),
+			onboardSigners: []spiffeid.ID{harvesterID},
+		},
+		{
+			name:           "Fails with the X509-SVID of a workload of the trust domain",
+			request:        signStatement(t, statement, workloadSVIDKey, workloadSVID),
+			onboardSigners: []spiffeid.ID{harvesterID},
+			expectedCode:   http.StatusForbidden,
+			expectedError:  "proof of trust domain control verification failed",
+		},
+		{
+			name:          "Fails with a certificate that is not an X509-SVID",
+			request:       signStatement(t, statement, leafKey, leaf),
+			expectedCode:  http.StatusForbidden,
+			expectedError: "proof of trust domain control verification failed",
+		},
+		{
+			name:          "Fails with a CA certificate chaining to the bundle of the trust domain",
+			request:       signStatement(t, statement, intermediateSigner, intermediate),
+			expectedCode:  http.StatusForbidden,
+			expectedError: "proof of trust domain control verification failed",
+		},
+		{
+			name:          "Fails if the trust domain has no bundle",
+			request:       signStatement(t, statement, svidKey, svid),
+			noBundle:      true,
+			expectedCode:  http.StatusForbidden,
+			expectedError: "trust domain has no bundle to prove its control, a join token is required",
+		},
+		{
+			name:          "Fails with an X509-SVID that does not chain to the bundle",
+			request:       signStatement(t, statement, untrustedSVIDKey, untrustedSVID),
+			expectedCode:  http.StatusForbidden,
+			expectedError: "proof of trust domain control verification failed",
+		},
+		{
+			name:          "Fails with an X509-SVID of another trust domain",
+			request:       signStatement(t, statement, otherTDSVIDKey, otherTDSVID),
+			expectedCode:  http.StatusForbidden,
+			expectedError: "proof of trust domain control verification failed",
+		},
+		{
+			name:          "Fails with a signature made with another key",
+			request:       signStatement(t, statement, untrustedSVIDKey, svid),
+			expectedCode:  http.StatusForbidden,
+			expectedError: "proof of trust domain control verification failed",
+		},
+		{
+			name:          "Fails with a statement for another trust domain",
+			request:       signStatement(t, &entity.OnboardStatement{TrustDomain: td2, Nonce: nonce, SignedAt: now}, svidKey, svid),
+			expectedCode:  http.StatusBadRequest,
+			expectedError: fmt.Sprintf("onboard statement is for trust domain %q", td2),
+		},
+		{
+			name:          "Fails with a statement with a join token",
+			request:       signStatement(t, &entity.OnboardStatement{TrustDomain: td1, JoinToken: "join-token", Nonce: nonce, SignedAt: now}, svidKey, svid),
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "onboard statement proving the control of the trust domain cannot have a join token",
+		},
+		{
+			name:          "Fails with an old statement",
+			request:       signStatement(t, &entity.OnboardStatement{TrustDomain: td1, Nonce: nonce, SignedAt: now.Add(-MaxOnboardClockSkew - time.Minute)}, svidKey, svid),
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "too far from the current time",
+		},
+		{
+			name:          "Fails with a statement without a nonce",
+			request:       signStatement(t, &entity.OnboardStatement{TrustDomain: td1, SignedAt: now}, svidKey, svid),
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "onboard statement proving the control of the trust domain has no onboard challenge nonce",
+		},
+		{
+			name:          "Fails with a nonce that was not issued by the server",
+			request:       signStatement(t, &entity.OnboardStatement{TrustDomain: td1, Nonce: "unknown-nonce", SignedAt: now}, svidKey, svid),
+			expectedCode:  http.StatusForbidden,
+			expectedError: "onboard challenge is unknown, expired or already used",
+		},
+		{
+			name:             "Fails with the nonce of an expired challenge",
+			request:          signStatement(t, statement, svidKey, svid),
+			expiredChallenge: true,
+			expectedCode:     http.StatusForbidden,
+			expectedError:    "onboard challenge is unknown, expired or already used",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			setup := NewHarvesterTestSetup(t, http.MethodPost, onboardPath, tc.request)
+			setup.Handler.onboardSigners = tc.onboardSigners
+			td := SetupTrustDomain(t, setup.Handler.Datastore)
+
+			challengeExpiresAt := time.Now().Add(OnboardChallengeTTL)
+			if tc.expiredChallenge {
+				challengeExpiresAt = time.Now().Add(-time.Second)
+			}
+			_, err := setup.Datastore.CreateOnboardChallenge(context.Background(), &entity.OnboardChallenge{
+				Nonce:         nonce,
+				TrustDomainID: td.ID.UUID,
+				ExpiresAt:     challengeExpiresAt,
+			})
+			require.NoError(t, err)
+
+			if !tc.noBundle {
+				bundleData, err := spiffebundle.FromX509Authorities(td.Name, []*x509.Certificate{authority}).Marshal()
+				require.NoError(t, err)
+				_, err = setup.Handler.Datastore.CreateOrUpdateBundle(context.Background(), &entity.Bundle{
+					TrustDomainID: td.ID.UUID,
+					Data:          bundleData,
+					Digest:        cryptoutil.CalculateDigest(bundleData),
+				})
+				require.NoError(t, err)
+			}
+
+			err = setup.Handler.OnboardWithProof(setup.EchoCtx, td1)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				httpErr := err.(*echo.HTTPError)
+				assert.Equal(t, tc.expectedCode, httpErr.Code)
+				assert.Contains(t, httpErr.Message, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+			var result harvester.OnboardHarvesterResponse
+			require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &result))
+			assert.Equal(t, td.ID.UUID, result.TrustDomainID)
+			assert.Equal(t, setup.JWTIssuer.Token, result.Token)
+		})
+	}
+}
+
+func TestTCPOnboardWithProofReplay(t *testing.T) {
+	clk := clock.New()
+	authority, authorityKey := certtest.CreateTestSelfSignedCACertificate(t, clk)
+
+	key, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+	require.NoError(t, err)
+	uri, err := url.Parse("spiffe://" + td1 + "/spire/server")
+	require.NoError(t, err)
+	template, err := cryptoutil.CreateX509Template(clk, key.Public(), pkix.Name{}, []*url.URL{uri}, nil, time.Hour)
+	require.NoError(t, err)
+	svid, err := cryptoutil.SignX509(template, authority, authorityKey)
+	require.NoError(t, err)
+
+	challengeSetup := NewHarvesterTestSetup(t, http.MethodPost, onboardPath+"/challenge", nil)
+	datastore := challengeSetup.Datastore
+	td := SetupTrustDomain(t, datastore)
+	bundleData, err := spiffebundle.FromX509Authorities(td.Name, []*x509.Certificate{authority}).Marshal()
+	require.NoError(t, err)
+	_, err = datastore.CreateOrUpdateBundle(context.Background(), &entity.Bundle{
+		TrustDomainID: td.ID.UUID,
+		Data:          bundleData,
+		Digest:        cryptoutil.CalculateDigest(bundleData),
+	})
+	require.NoError(t, err)
+
+	// the Harvester gets a challenge, and signs an onboard statement with its nonce
+	require.NoError(t, challengeSetup.Handler.CreateOnboardChallenge(challengeSetup.EchoCtx, td1))
+	var challenge harvester.OnboardChallengeResponse
+	require.NoError(t, json.Unmarshal(challengeSetup.Recorder.Body.Bytes(), &challenge))
+	require.NotEmpty(t, challenge.Nonce)
+
+	data, err := (&entity.OnboardStatement{TrustDomain: td1, Nonce: challenge.Nonce, SignedAt: time.Now().UTC()}).Marshal()
+	require.NoError(t, err)
+	signature, err := key.Sign(rand.Reader, cryptoutil.CalculateDigest(data), crypto.SHA256)
+	require.NoError(t, err)
+	request := &harvester.OnboardProofRequest{
+		OnboardStatement:        encoding.EncodeToBase64(data),
+		Signature:               encoding.EncodeToBase64(signature),
+		SigningCertificateChain: encoding.EncodeToBase64(svid.Raw),
+	}
+
+	onboard := func() error {
+		setup := NewHarvesterTestSetup(t, http.MethodPost, onboardPath, request)
+		setup.Handler.Datastore = datastore
+		return setup.Handler.OnboardWithProof(setup.EchoCtx, td1)
+	}
+
+	require.NoError(t, onboard())
+
+	// the same signed statement cannot be used again, within the allowed clock skew
+	err = onboard()
+	require.Error(t, err)
+	httpErr := err.(*echo.HTTPError)
+	assert.Equal(t, http.StatusForbidden, httpErr.Code)
+	assert.Equal(t, "onboard challenge is unknown, expired or already used", httpErr.Message)
+}
+
+func TestTCPCreateOnboardChallenge(t *testing.T) {
+	t.Run("Successfully issue a challenge to the trust domain", func(t *testing.T) {
+		setup := NewHarvesterTestSetup(t, http.MethodPost, onboardPath+"/challenge", nil)
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+
+		// an expired challenge is pruned when a new one is issued
+		_, err := setup.Datastore.CreateOnboardChallenge(context.Background(), &entity.OnboardChallenge{
+			Nonce:         "expired-nonce",
+			TrustDomainID: td.ID.UUID,
+			ExpiresAt:     time.Now().Add(-time.Minute),
+		})
+		require.NoError(t, err)
+
+		before := time.Now()
+		require.NoError(t, setup.Handler.CreateOnboardChallenge(setup.EchoCtx, td1))
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var challenge harvester.OnboardChallengeResponse
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &challenge))
+		assert.NotEmpty(t, challenge.Nonce)
+		assert.WithinDuration(t, before.Add(OnboardChallengeTTL), challenge.ExpiresAt, time.Minute)
+
+		used, err := setup.Datastore.UseOnboardChallenge(context.Background(), "expired-nonce", td.ID.UUID, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Nil(t, used)
+
+		used, err = setup.Datastore.UseOnboardChallenge(context.Background(), challenge.Nonce, td.ID.UUID, time.Now())
+		require.NoError(t, err)
+		require.NotNil(t, used)
+		assert.Equal(t, td.ID.UUID, used.TrustDomainID)
+	})
+
+	t.Run("Fails if the trust domain does not exist", func(t *testing.T) {
+		setup := NewHarvesterTestSetup(t, http.MethodPost, onboardPath+"/challenge", nil)
+
+		err := setup.Handler.CreateOnboardChallenge(setup.EchoCtx, td1)
+		require.Error(t, err)
+		httpErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+		assert.Equal(t, "trust domain not found", httpErr.Message)
+	})
 }
 
 func TestTCPGetNewJWTToken(t *testing.T) {
@@ -437,29 +1107,174 @@ func TestTCPGetNewJWTToken(t *testing.T) {
 	})
 }
 
-func TestTCPBundleSync(t *testing.T) {
-	testCases := []struct {
-		name          string
-		trustDomain   string
-		relationships []*entity.Relationship
-		bundleState   harvester.PostBundleSyncRequest
-		expected      harvester.PostBundleSyncResponse
-	}{
-		{
-			name:          "Successfully sync no new bundles",
-			trustDomain:   tdA.Name.String(),
-			relationships: []*entity.Relationship{acceptedPendingRelAB, acceptedDeniedRelAC, acceptedAcceptedRelBC},
-			bundleState: harvester.PostBundleSyncRequest{
-				State: map[string]api.BundleDigest{
-					tdB.Name.String(): encoding.EncodeToBase64(bundleB.Digest),
-					tdC.Name.String(): encoding.EncodeToBase64(bundleC.Digest),
-				},
-			},
-			expected: harvester.PostBundleSyncResponse{
-				State: harvester.BundlesDigests{
-					tdB.Name.String(): encoding.EncodeToBase64(bundleB.Digest),
-					tdC.Name.String(): encoding.EncodeToBase64(bundleC.Digest),
-				},
+func TestTCPIssueClientCertificate(t *testing.T) {
+	clk := clock.NewFake()
+	clk.Set(time.Now())
+	certsFolder := certtest.CreateTestCACertificates(t, clk)
+	ca, err := disk.New()
+	require.NoError(t, err)
+	require.NoError(t, ca.Configure(&disk.Config{
+		CertFilePath: certsFolder + "/root-ca.crt",
+		KeyFilePath:  certsFolder + "/root-ca.key",
+		Clock:        clk,
+	}))
+
+	key, err := cryptoutil.GenerateSigner(cryptoutil.ECP256)
+	require.NoError(t, err)
+	// the subject requested by the Harvester is ignored
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: constants.GaladrielServerName}}, key)
+	require.NoError(t, err)
+	certRequest := harvester.ClientCertificateRequest{Csr: encoding.EncodeToBase64(csr)}
+
+	t.Run("Successfully issue a client certificate", func(t *testing.T) {
+		setup := NewHarvesterTestSetup(t, http.MethodPost, clientCertificatePath, certRequest)
+		setup.Handler.x509CA = ca
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+
+		err := setup.Handler.IssueClientCertificate(setup.EchoCtx, td.Name.String())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var resp harvester.ClientCertificateResponse
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &resp))
+
+		chain, err := cryptoutil.ParseCertificates([]byte(resp.CertificateChain))
+		require.NoError(t, err)
+		leaf := chain[0]
+		assert.Equal(t, key.Public(), leaf.PublicKey)
+		assert.Equal(t, td.Name.String(), leaf.Subject.CommonName)
+		assert.True(t, isHarvesterClientCertificate(leaf.Subject))
+		assert.WithinDuration(t, clk.Now().Add(HarvesterClientCertificateTTL), leaf.NotAfter, time.Second)
+		assert.True(t, resp.ExpiresAt.Equal(leaf.NotAfter))
+
+		roots := x509.NewCertPool()
+		for _, authority := range ca.X509Authorities() {
+			roots.AddCert(authority)
+		}
+		_, err = leaf.Verify(x509.VerifyOptions{
+			Roots:       roots,
+			CurrentTime: clk.Now(),
+			KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fails if the trust domain does not match the authenticated one", func(t *testing.T) {
+		setup := NewHarvesterTestSetup(t, http.MethodPost, clientCertificatePath, certRequest)
+		setup.Handler.x509CA = ca
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+
+		err := setup.Handler.IssueClientCertificate(setup.EchoCtx, td2)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.(*echo.HTTPError).Code)
+	})
+
+	t.Run("Fails if the certificate signing request is invalid", func(t *testing.T) {
+		setup := NewHarvesterTestSetup(t, http.MethodPost, clientCertificatePath, harvester.ClientCertificateRequest{Csr: encoding.EncodeToBase64([]byte("not a csr"))})
+		setup.Handler.x509CA = ca
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+
+		err := setup.Handler.IssueClientCertificate(setup.EchoCtx, td.Name.String())
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+		assert.Equal(t, "invalid certificate signing request", err.(*echo.HTTPError).Message)
+	})
+
+	t.Run("Fails if the server has no X509CA", func(t *testing.T) {
+		setup := NewHarvesterTestSetup(t, http.MethodPost, clientCertificatePath, certRequest)
+		td := SetupTrustDomain(t, setup.Handler.Datastore)
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+
+		err := setup.Handler.IssueClientCertificate(setup.EchoCtx, td.Name.String())
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotImplemented, err.(*echo.HTTPError).Code)
+	})
+}
+
+func TestTCPGetJWKS(t *testing.T) {
+	t.Run("Successfully get the JWKS", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, jwksPath, nil)
+		echoCtx := harvesterTestSetup.EchoCtx
+		ctx := context.Background()
+
+		key1, err := harvesterTestSetup.KeyManager.GenerateKey(ctx, "key-1", cryptoutil.RSA2048)
+		require.NoError(t, err)
+		key2, err := harvesterTestSetup.KeyManager.GenerateKey(ctx, "key-2", cryptoutil.RSA2048)
+		require.NoError(t, err)
+
+		err = harvesterTestSetup.Handler.GetJWKS(echoCtx)
+		assert.NoError(t, err)
+
+		recorder := harvesterTestSetup.Recorder
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		var jwks harvester.JWKS
+		err = json.Unmarshal(recorder.Body.Bytes(), &jwks)
+		require.NoError(t, err)
+		require.Len(t, jwks.Keys, 2)
+
+		for i, key := range []keymanager.Key{key1, key2} {
+			assert.Equal(t, key.ID(), jwks.Keys[i].Kid)
+			publicKey, err := jwks.Keys[i].PublicKey()
+			require.NoError(t, err)
+			assert.Equal(t, key.Signer().Public(), publicKey)
+		}
+	})
+
+	t.Run("Retired keys are not published", func(t *testing.T) {
+		harvesterTestSetup := NewHarvesterTestSetup(t, http.MethodGet, jwksPath, nil)
+		echoCtx := harvesterTestSetup.EchoCtx
+		ctx := context.Background()
+
+		_, err := harvesterTestSetup.KeyManager.GenerateKey(ctx, "retired-key", cryptoutil.RSA2048)
+		require.NoError(t, err)
+		_, err = harvesterTestSetup.KeyManager.GenerateKey(ctx, "active-key", cryptoutil.RSA2048)
+		require.NoError(t, err)
+		harvesterTestSetup.Handler.jwtKeyStatus = retiredKeys{"retired-key": true}
+
+		err = harvesterTestSetup.Handler.GetJWKS(echoCtx)
+		assert.NoError(t, err)
+
+		var jwks harvester.JWKS
+		err = json.Unmarshal(harvesterTestSetup.Recorder.Body.Bytes(), &jwks)
+		require.NoError(t, err)
+		require.Len(t, jwks.Keys, 1)
+		assert.Equal(t, "active-key", jwks.Keys[0].Kid)
+	})
+}
+
+type retiredKeys map[string]bool
+
+func (r retiredKeys) IsRetired(kid string) bool {
+	return r[kid]
+}
+
+func TestTCPBundleSync(t *testing.T) {
+	testCases := []struct {
+		name          string
+		trustDomain   string
+		relationships []*entity.Relationship
+		bundleState   harvester.PostBundleSyncRequest
+		expected      harvester.PostBundleSyncResponse
+	}{
+		{
+			name:          "Successfully sync no new bundles",
+			trustDomain:   tdA.Name.String(),
+			relationships: []*entity.Relationship{acceptedPendingRelAB, acceptedDeniedRelAC, acceptedAcceptedRelBC},
+			bundleState: harvester.PostBundleSyncRequest{
+				State: map[string]api.BundleDigest{
+					tdB.Name.String(): encoding.EncodeToBase64(bundleB.Digest),
+					tdC.Name.String(): encoding.EncodeToBase64(bundleC.Digest),
+				},
+			},
+			expected: harvester.PostBundleSyncResponse{
+				State: harvester.BundlesDigests{
+					tdB.Name.String(): encoding.EncodeToBase64(bundleB.Digest),
+					tdC.Name.String(): encoding.EncodeToBase64(bundleC.Digest),
+				},
 				Updates: harvester.BundlesUpdates{},
 			},
 		},
@@ -581,6 +1396,334 @@ func TestTCPBundleSync(t *testing.T) {
 	}
 }
 
+func TestTCPBundleSyncWait(t *testing.T) {
+	upToDateState := map[string]api.BundleDigest{
+		tdB.Name.String(): encoding.EncodeToBase64(bundleB.Digest),
+	}
+
+	setupWait := func(t *testing.T, state map[string]api.BundleDigest, wait int) *HarvesterTestSetup {
+		req := &harvester.PostBundleSyncRequest{State: state, Wait: &wait}
+		setup := NewHarvesterTestSetup(t, http.MethodPost, "/trust-domain/:trustDomainName/bundles/sync", req)
+		setup.EchoCtx.Set(authTrustDomainKey, tdA)
+		setup.Handler.notifier = NewNotifier()
+
+		setup.Datastore.WithTrustDomains(tdA, tdB)
+		setup.Datastore.WithRelationships(acceptedPendingRelAB)
+		setup.Datastore.WithBundles(&entity.Bundle{ID: bundleB.ID, Data: bundleB.Data, Digest: bundleB.Digest, Signature: bundleB.Signature, TrustDomainID: tdB.ID.UUID})
+
+		return setup
+	}
+
+	t.Run("Responds immediately when the state is outdated", func(t *testing.T) {
+		setup := setupWait(t, map[string]api.BundleDigest{}, 60)
+
+		err := setup.Handler.BundleSync(setup.EchoCtx, tdA.Name.String())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var resp harvester.PostBundleSyncResponse
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &resp))
+		assert.Contains(t, resp.Updates, tdB.Name.String())
+	})
+
+	t.Run("Responds when the wait time elapses", func(t *testing.T) {
+		setup := setupWait(t, upToDateState, 1)
+
+		start := time.Now()
+		err := setup.Handler.BundleSync(setup.EchoCtx, tdA.Name.String())
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var resp harvester.PostBundleSyncResponse
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &resp))
+		assert.Empty(t, resp.Updates)
+		assert.Equal(t, harvester.BundlesDigests(upToDateState), resp.State)
+	})
+
+	t.Run("Responds when a federated bundle changes", func(t *testing.T) {
+		setup := setupWait(t, upToDateState, 60)
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- setup.Handler.BundleSync(setup.EchoCtx, tdA.Name.String())
+		}()
+
+		// wait for the request to subscribe to the changes
+		require.Eventually(t, func() bool {
+			setup.Handler.notifier.mu.Lock()
+			defer setup.Handler.notifier.mu.Unlock()
+			return len(setup.Handler.notifier.subscribers[tdA.ID.UUID]) == 1
+		}, time.Second, 10*time.Millisecond)
+
+		// a notification that does not change the federated bundles keeps the request waiting
+		setup.Handler.notifier.Notify(tdA.ID.UUID)
+
+		newData := []byte("bundle-B-rotated")
+		_, err := setup.Datastore.CreateOrUpdateBundle(context.Background(), &entity.Bundle{ID: bundleB.ID, Data: newData, Digest: cryptoutil.CalculateDigest(newData), Signature: bundleB.Signature, TrustDomainID: tdB.ID.UUID})
+		require.NoError(t, err)
+		setup.Handler.notifier.Notify(tdA.ID.UUID)
+
+		select {
+		case err := <-errCh:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "bundle sync request was not notified")
+		}
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var resp harvester.PostBundleSyncResponse
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &resp))
+		require.Contains(t, resp.Updates, tdB.Name.String())
+		assert.Equal(t, string(newData), resp.Updates[tdB.Name.String()].TrustBundle)
+
+		// the request unsubscribed
+		assert.Empty(t, setup.Handler.notifier.subscribers)
+	})
+
+	t.Run("Rejects a wait time that is too long", func(t *testing.T) {
+		setup := setupWait(t, upToDateState, 301)
+
+		err := setup.Handler.BundleSync(setup.EchoCtx, tdA.Name.String())
+		require.Error(t, err)
+		echoHTTPErr := err.(*echo.HTTPError)
+		assert.Equal(t, http.StatusBadRequest, echoHTTPErr.Code)
+		assert.Equal(t, "wait must be between 0 and 300 seconds", echoHTTPErr.Message)
+	})
+}
+
+func TestTCPBundleSyncIncremental(t *testing.T) {
+	setupSync := func(t *testing.T, cursor int64) *HarvesterTestSetup {
+		req := &harvester.PostBundleSyncRequest{State: map[string]api.BundleDigest{}, Cursor: &cursor}
+		setup := NewHarvesterTestSetup(t, http.MethodPost, "/trust-domain/:trustDomainName/bundles/sync", req)
+		setup.EchoCtx.Set(authTrustDomainKey, tdA)
+		return setup
+	}
+
+	sync := func(t *testing.T, setup *HarvesterTestSetup) harvester.PostBundleSyncResponse {
+		err := setup.Handler.BundleSync(setup.EchoCtx, tdA.Name.String())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		var resp harvester.PostBundleSyncResponse
+		require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &resp))
+		return resp
+	}
+
+	newBundle := func(bundle *entity.Bundle, data string) *entity.Bundle {
+		return &entity.Bundle{ID: bundle.ID, Data: []byte(data), Digest: cryptoutil.CalculateDigest([]byte(data)), Signature: bundle.Signature, TrustDomainID: bundle.TrustDomainID}
+	}
+
+	// the first sync is a full one, that returns the cursor to resume from
+	setup := setupSync(t, 0)
+	setup.Datastore.WithTrustDomains(tdA, tdB, tdC)
+	setup.Datastore.WithRelationships(acceptedPendingRelAB)
+	setup.Datastore.WithBundles(newBundle(bundleC, "bundle-C"))
+	_, err := setup.Datastore.CreateOrUpdateBundle(context.Background(), newBundle(bundleB, "bundle-B"))
+	require.NoError(t, err)
+
+	resp := sync(t, setup)
+	assert.Nil(t, resp.Incremental)
+	assert.Contains(t, resp.Updates, tdB.Name.String())
+	require.NotNil(t, resp.Cursor)
+	cursor := *resp.Cursor
+
+	datastore := setup.Datastore
+
+	t.Run("Returns only the bundles changed since the cursor", func(t *testing.T) {
+		_, err := datastore.CreateOrUpdateBundle(context.Background(), newBundle(bundleC, "bundle-C-rotated"))
+		require.NoError(t, err)
+		_, err = datastore.CreateOrUpdateBundle(context.Background(), newBundle(bundleB, "bundle-B-rotated"))
+		require.NoError(t, err)
+
+		setup := setupSync(t, cursor)
+		setup.Handler.Datastore = datastore
+
+		resp := sync(t, setup)
+		require.NotNil(t, resp.Incremental)
+		assert.True(t, *resp.Incremental)
+		require.Len(t, resp.Updates, 1)
+		assert.Equal(t, "bundle-B-rotated", resp.Updates[tdB.Name.String()].TrustBundle)
+		assert.Empty(t, *resp.Removed)
+		require.NotNil(t, resp.Cursor)
+		assert.Greater(t, *resp.Cursor, cursor)
+		cursor = *resp.Cursor
+	})
+
+	t.Run("Returns no changes for an up to date cursor", func(t *testing.T) {
+		setup := setupSync(t, cursor)
+		setup.Handler.Datastore = datastore
+
+		resp := sync(t, setup)
+		require.NotNil(t, resp.Incremental)
+		assert.Empty(t, resp.Updates)
+		assert.Empty(t, *resp.Removed)
+		assert.Equal(t, cursor, *resp.Cursor)
+	})
+
+	t.Run("Returns the trust domains no longer federated", func(t *testing.T) {
+		require.NoError(t, datastore.DeleteRelationship(context.Background(), acceptedPendingRelAB.ID.UUID))
+
+		setup := setupSync(t, cursor)
+		setup.Handler.Datastore = datastore
+
+		resp := sync(t, setup)
+		require.NotNil(t, resp.Incremental)
+		assert.Empty(t, resp.Updates)
+		assert.Equal(t, []api.TrustDomainName{tdB.Name.String()}, *resp.Removed)
+		cursor = *resp.Cursor
+	})
+
+	t.Run("Falls back to a full sync when the cursor was pruned", func(t *testing.T) {
+		_, err := datastore.CreateOrUpdateRelationship(context.Background(), acceptedPendingRelAB)
+		require.NoError(t, err)
+		_, err = datastore.CreateOrUpdateBundle(context.Background(), newBundle(bundleC, "bundle-C"))
+		require.NoError(t, err)
+		// the latest change is kept, the relationship change made after the cursor is pruned
+		require.NoError(t, datastore.PruneChangeEvents(context.Background(), time.Now().Add(time.Hour)))
+
+		setup := setupSync(t, cursor)
+		setup.Handler.Datastore = datastore
+
+		resp := sync(t, setup)
+		assert.Nil(t, resp.Incremental)
+		assert.Contains(t, resp.Updates, tdB.Name.String())
+		assert.Greater(t, *resp.Cursor, cursor)
+	})
+
+	t.Run("Falls back to a full sync when the cursor is ahead of the change log", func(t *testing.T) {
+		setup := setupSync(t, cursor+100)
+		setup.Handler.Datastore = datastore
+
+		resp := sync(t, setup)
+		assert.Nil(t, resp.Incremental)
+		assert.Contains(t, resp.Updates, tdB.Name.String())
+	})
+}
+
+func TestTCPBundleSyncIncrementalIgnoresDeletedTrustDomain(t *testing.T) {
+	ctx := context.Background()
+
+	datastore := fakedatastore.NewFakeDB()
+	datastore.WithTrustDomains(tdA, tdB, tdC)
+	datastore.WithRelationships(acceptedPendingRelAB)
+	_, err := datastore.CreateOrUpdateBundle(ctx, &entity.Bundle{ID: bundleB.ID, Data: bundleB.Data, Digest: bundleB.Digest, Signature: bundleB.Signature, TrustDomainID: tdB.ID.UUID})
+	require.NoError(t, err)
+
+	_, cursor, err := datastore.FindChangeEventRange(ctx)
+	require.NoError(t, err)
+
+	// the deletion of a trust domain does not change the bundles federated with other trust domains
+	require.NoError(t, datastore.DeleteTrustDomain(ctx, tdC.ID.UUID))
+
+	req := &harvester.PostBundleSyncRequest{State: map[string]api.BundleDigest{}, Cursor: &cursor}
+	setup := NewHarvesterTestSetup(t, http.MethodPost, "/trust-domain/:trustDomainName/bundles/sync", req)
+	setup.EchoCtx.Set(authTrustDomainKey, tdA)
+	setup.Handler.Datastore = datastore
+
+	err = setup.Handler.BundleSync(setup.EchoCtx, tdA.Name.String())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+	var resp harvester.PostBundleSyncResponse
+	require.NoError(t, json.Unmarshal(setup.Recorder.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Incremental)
+	assert.Empty(t, resp.Updates)
+	assert.Empty(t, *resp.Removed)
+	assert.Greater(t, *resp.Cursor, cursor)
+}
+
+func TestBundlePutNotifiesRelatedTrustDomains(t *testing.T) {
+	setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", &harvester.PutBundleRequest{
+		TrustBundle: string(bundleA.Data),
+		Digest:      encoding.EncodeToBase64(bundleA.Digest),
+		TrustDomain: tdA.Name.String(),
+	})
+	setup.EchoCtx.Set(authTrustDomainKey, tdA)
+	setup.Handler.notifier = NewNotifier()
+	setup.Datastore.WithTrustDomains(tdA, tdB, tdC)
+	setup.Datastore.WithRelationships(acceptedPendingRelAB)
+
+	changesB, unsubscribeB := setup.Handler.notifier.Subscribe(tdB.ID.UUID)
+	defer unsubscribeB()
+	changesC, unsubscribeC := setup.Handler.notifier.Subscribe(tdC.ID.UUID)
+	defer unsubscribeC()
+
+	err := setup.Handler.BundlePut(setup.EchoCtx, tdA.Name.String())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+	assert.Len(t, changesB, 1)
+	assert.Len(t, changesC, 0)
+}
+
+func TestBundlePutUnchangedBundle(t *testing.T) {
+	ctx := context.Background()
+	setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", &harvester.PutBundleRequest{
+		TrustBundle: string(bundleA.Data),
+		Digest:      encoding.EncodeToBase64(bundleA.Digest),
+		TrustDomain: tdA.Name.String(),
+	})
+	setup.EchoCtx.Set(authTrustDomainKey, tdA)
+	setup.Handler.notifier = NewNotifier()
+	setup.Datastore.WithTrustDomains(tdA, tdB)
+	setup.Datastore.WithRelationships(acceptedPendingRelAB)
+	setup.Datastore.WithBundles(&entity.Bundle{ID: bundleA.ID, Data: bundleA.Data, Digest: bundleA.Digest, TrustDomainID: tdA.ID.UUID})
+
+	_, latestID, err := setup.Datastore.FindChangeEventRange(ctx)
+	require.NoError(t, err)
+
+	changesB, unsubscribeB := setup.Handler.notifier.Subscribe(tdB.ID.UUID)
+	defer unsubscribeB()
+
+	err = setup.Handler.BundlePut(setup.EchoCtx, tdA.Name.String())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+	// the bundle is not stored again, so the related trust domains are not notified
+	assert.Len(t, changesB, 0)
+	_, latestAfterID, err := setup.Datastore.FindChangeEventRange(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, latestID, latestAfterID)
+
+	versions, err := setup.Datastore.ListBundleVersions(ctx, tdA.ID.UUID)
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+}
+
+func TestBundlePutIsAudited(t *testing.T) {
+	ctx := context.Background()
+	ds := fakedatastore.NewFakeDB()
+	td := SetupTrustDomain(t, ds)
+	logger, _ := logrustest.NewNullLogger()
+	auditor, err := audit.NewRecorder(&audit.Config{Store: ds, Logger: logger})
+	require.NoError(t, err)
+
+	for _, bundle := range []string{"bundle-1", "bundle-1", "bundle-2"} {
+		setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", &harvester.PutBundleRequest{
+			TrustBundle: bundle,
+			Digest:      encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle))),
+			TrustDomain: td.Name.String(),
+		})
+		setup.Handler.Datastore = ds
+		setup.Handler.auditor = auditor
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+
+		require.NoError(t, setup.Handler.BundlePut(setup.EchoCtx, td.Name.String()))
+	}
+
+	// uploading the same bundle again does not replace it
+	events, err := ds.ListAuditEvents(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	for i, e := range events {
+		assert.Equal(t, audit.HarvesterActor(td1), e.Actor)
+		assert.Equal(t, entity.AuditActionBundleReplace, e.Action)
+		assert.Equal(t, td1, e.Target)
+		assert.Equal(t, fmt.Sprintf("version: %d", i+1), e.Details)
+	}
+}
+
 func TestBundlePut(t *testing.T) {
 	t.Run("Successfully post new bundle for a trust domain", func(t *testing.T) {
 		setupFunc := func(setup *HarvesterTestSetup) *entity.TrustDomain {
@@ -633,6 +1776,471 @@ func TestBundlePut(t *testing.T) {
 	})
 }
 
+func TestBundlePutHistory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Store a new bundle version only when the bundle changes", func(t *testing.T) {
+		ds := fakedatastore.NewFakeDB()
+		td := SetupTrustDomain(t, ds)
+
+		putBundle(t, ds, td, "bundle-1")
+		putBundle(t, ds, td, "bundle-1")
+		putBundle(t, ds, td, "bundle-2")
+
+		versions, err := ds.ListBundleVersions(ctx, td.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		assert.Equal(t, "bundle-2", string(versions[0].Data))
+		assert.Equal(t, int64(2), versions[0].Version)
+		assert.Equal(t, "bundle-1", string(versions[1].Data))
+		assert.Equal(t, int64(1), versions[1].Version)
+	})
+
+	t.Run("Keep serving a pinned bundle until a new bundle is uploaded", func(t *testing.T) {
+		ds := fakedatastore.NewFakeDB()
+		td := SetupTrustDomain(t, ds)
+
+		putBundle(t, ds, td, "bundle-1")
+		putBundle(t, ds, td, "bundle-2")
+
+		bundle, err := ds.FindBundleByTrustDomainID(ctx, td.ID.UUID)
+		require.NoError(t, err)
+		bundle.Data = []byte("bundle-1")
+		bundle.Digest = cryptoutil.CalculateDigest(bundle.Data)
+		bundle.PinnedVersion = 1
+		_, err = ds.CreateOrUpdateBundle(ctx, bundle)
+		require.NoError(t, err)
+
+		// the harvester uploads a bundle that is already in the history
+		putBundle(t, ds, td, "bundle-2")
+
+		bundle, err = ds.FindBundleByTrustDomainID(ctx, td.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, "bundle-1", string(bundle.Data))
+		assert.Equal(t, int64(1), bundle.PinnedVersion)
+
+		// a bundle that is not in the history replaces the pinned bundle
+		putBundle(t, ds, td, "bundle-3")
+
+		bundle, err = ds.FindBundleByTrustDomainID(ctx, td.ID.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, "bundle-3", string(bundle.Data))
+		assert.Equal(t, int64(0), bundle.PinnedVersion)
+
+		versions, err := ds.ListBundleVersions(ctx, td.ID.UUID)
+		require.NoError(t, err)
+		require.Len(t, versions, 3)
+		assert.Equal(t, int64(3), versions[0].Version)
+	})
+}
+
+func putBundle(t *testing.T, ds *fakedatastore.FakeDatabase, td *entity.TrustDomain, bundle string) {
+	sig := encoding.EncodeToBase64([]byte("test-signature"))
+	bundlePut := harvester.PutBundleRequest{
+		Signature:   &sig,
+		TrustBundle: bundle,
+		Digest:      encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle))),
+		TrustDomain: td.Name.String(),
+	}
+
+	setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", &bundlePut)
+	setup.Handler.Datastore = ds
+	setup.EchoCtx.Set(authTrustDomainKey, td)
+
+	err := setup.Handler.BundlePut(setup.EchoCtx, td.Name.String())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+}
+
+func TestLease(t *testing.T) {
+	ctx := context.Background()
+	ds := fakedatastore.NewFakeDB()
+	td := SetupTrustDomain(t, ds)
+
+	acquireLease := func(instanceID string) *httptest.ResponseRecorder {
+		setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/lease", &harvester.LeaseRequest{InstanceId: instanceID})
+		setup.Handler.Datastore = ds
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+
+		err := setup.Handler.AcquireLease(setup.EchoCtx, td.Name.String())
+		require.NoError(t, err)
+		return setup.Recorder
+	}
+
+	t.Run("Acquire and renew the lease", func(t *testing.T) {
+		recorder := acquireLease("harvester-1")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		var lease harvester.Lease
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &lease))
+		assert.Equal(t, "harvester-1", lease.InstanceId)
+		assert.WithinDuration(t, time.Now().Add(HarvesterLeaseTTL), lease.ExpiresAt, 5*time.Second)
+
+		recorder = acquireLease("harvester-1")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("Fail to acquire the lease held by another instance", func(t *testing.T) {
+		recorder := acquireLease("harvester-2")
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+
+		var lease harvester.Lease
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &lease))
+		assert.Equal(t, "harvester-1", lease.InstanceId)
+	})
+
+	t.Run("Release the lease", func(t *testing.T) {
+		// releasing the lease of another instance has no effect
+		setup := NewHarvesterTestSetup(t, http.MethodDelete, "/trust-domain/:trustDomainName/lease", nil)
+		setup.Handler.Datastore = ds
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+		err := setup.Handler.ReleaseLease(setup.EchoCtx, td.Name.String(), harvester.ReleaseLeaseParams{InstanceID: "harvester-2"})
+		require.NoError(t, err)
+
+		lease, err := ds.FindHarvesterLease(ctx, td.ID.UUID)
+		require.NoError(t, err)
+		require.NotNil(t, lease)
+
+		setup = NewHarvesterTestSetup(t, http.MethodDelete, "/trust-domain/:trustDomainName/lease", nil)
+		setup.Handler.Datastore = ds
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+		err = setup.Handler.ReleaseLease(setup.EchoCtx, td.Name.String(), harvester.ReleaseLeaseParams{InstanceID: "harvester-1"})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+
+		lease, err = ds.FindHarvesterLease(ctx, td.ID.UUID)
+		require.NoError(t, err)
+		assert.Nil(t, lease)
+
+		recorder := acquireLease("harvester-2")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("Acquire an expired lease held by another instance", func(t *testing.T) {
+		_, err := ds.AcquireHarvesterLease(ctx, &entity.HarvesterLease{
+			TrustDomainID: td.ID.UUID,
+			InstanceID:    "harvester-2",
+			ExpiresAt:     time.Now().Add(-time.Second),
+		}, time.Now())
+		require.NoError(t, err)
+
+		recorder := acquireLease("harvester-1")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("Fail to acquire the lease without instance ID", func(t *testing.T) {
+		setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/lease", &harvester.LeaseRequest{})
+		setup.Handler.Datastore = ds
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+
+		err := setup.Handler.AcquireLease(setup.EchoCtx, td.Name.String())
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+		assert.Contains(t, err.(*echo.HTTPError).Message, "instance ID is required")
+	})
+}
+
+func TestBundlePutLease(t *testing.T) {
+	ctx := context.Background()
+	ds := fakedatastore.NewFakeDB()
+	td := SetupTrustDomain(t, ds)
+
+	uploadBundle := func(instanceID *string, bundle string) error {
+		sig := encoding.EncodeToBase64([]byte("test-signature"))
+		bundlePut := harvester.PutBundleRequest{
+			Signature:   &sig,
+			TrustBundle: bundle,
+			Digest:      encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle))),
+			TrustDomain: td.Name.String(),
+			InstanceId:  instanceID,
+		}
+
+		setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", &bundlePut)
+		setup.Handler.Datastore = ds
+		setup.EchoCtx.Set(authTrustDomainKey, td)
+
+		return setup.Handler.BundlePut(setup.EchoCtx, td.Name.String())
+	}
+
+	_, err := ds.AcquireHarvesterLease(ctx, &entity.HarvesterLease{
+		TrustDomainID: td.ID.UUID,
+		InstanceID:    "harvester-1",
+		ExpiresAt:     time.Now().Add(time.Minute),
+	}, time.Now())
+	require.NoError(t, err)
+
+	leader := "harvester-1"
+	follower := "harvester-2"
+
+	err = uploadBundle(&follower, "bundle-1")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusConflict, err.(*echo.HTTPError).Code)
+	assert.Contains(t, err.(*echo.HTTPError).Message, `harvester instance "harvester-1" holds the lease of trust domain "test1.com"`)
+
+	err = uploadBundle(nil, "bundle-1")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusConflict, err.(*echo.HTTPError).Code)
+
+	err = uploadBundle(&leader, "bundle-1")
+	require.NoError(t, err)
+
+	versions, err := ds.ListBundleVersions(ctx, td.ID.UUID)
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	assert.Equal(t, leader, versions[0].UploadedBy)
+
+	// once the lease is released, any instance can upload the bundle
+	require.NoError(t, ds.DeleteHarvesterLease(ctx, td.ID.UUID, leader))
+	err = uploadBundle(&follower, "bundle-2")
+	require.NoError(t, err)
+
+	versions, err = ds.ListBundleVersions(ctx, td.ID.UUID)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, follower, versions[0].UploadedBy)
+}
+
+type fakeBundleVerifier struct {
+	err error
+}
+
+func (v fakeBundleVerifier) Verify(payload, signature []byte, certChain []*x509.Certificate) error {
+	return v.err
+}
+
+type fakeTrustDomainVerifier struct {
+	fakeBundleVerifier
+
+	td            spiffeid.TrustDomain
+	trustedBundle *spiffebundle.Bundle
+}
+
+func (v *fakeTrustDomainVerifier) VerifyTrustDomainSignature(td spiffeid.TrustDomain, trustedBundle *spiffebundle.Bundle, payload, signature []byte, certChain []*x509.Certificate) error {
+	v.td = td
+	v.trustedBundle = trustedBundle
+	return v.err
+}
+
+func TestBundlePutPassesStoredBundleToTrustDomainVerifiers(t *testing.T) {
+	bundle := "a new bundle"
+	sig := encoding.EncodeToBase64([]byte("test-signature"))
+	bundlePut := harvester.PutBundleRequest{
+		Signature:   &sig,
+		TrustBundle: bundle,
+		Digest:      encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle))),
+		TrustDomain: td1,
+	}
+
+	setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", &bundlePut)
+	verifier := &fakeTrustDomainVerifier{}
+	setup.Handler.bundleVerifiers = []*catalog.BundleVerifier{{Name: "x509svid", Verifier: verifier}}
+
+	td := SetupTrustDomain(t, setup.Handler.Datastore)
+	setup.EchoCtx.Set(authTrustDomainKey, td)
+
+	authority, _ := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+	trusted := spiffebundle.FromX509Authorities(td.Name, []*x509.Certificate{authority})
+	trustedData, err := trusted.Marshal()
+	require.NoError(t, err)
+	_, err = setup.Handler.Datastore.CreateOrUpdateBundle(context.Background(), &entity.Bundle{
+		TrustDomainID: td.ID.UUID,
+		Data:          trustedData,
+		Digest:        cryptoutil.CalculateDigest(trustedData),
+	})
+	require.NoError(t, err)
+
+	err = setup.Handler.BundlePut(setup.EchoCtx, td1)
+	require.NoError(t, err)
+	assert.Equal(t, td.Name, verifier.td)
+	require.NotNil(t, verifier.trustedBundle)
+	assert.Equal(t, td.Name, verifier.trustedBundle.TrustDomain())
+}
+
+func TestBundlePutContinuity(t *testing.T) {
+	oldAuthority, _ := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+	newAuthority, _ := certtest.CreateTestSelfSignedCACertificate(t, clock.New())
+
+	marshal := func(authorities ...*x509.Certificate) string {
+		data, err := spiffebundle.FromX509Authorities(spiffeid.RequireTrustDomainFromString(td1), authorities).Marshal()
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	testCases := []struct {
+		name                 string
+		bundle               string
+		continuity           bool
+		resetApproved        bool
+		expectedErrorMessage string
+	}{
+		{
+			name:       "Policy disabled",
+			bundle:     marshal(newAuthority),
+			continuity: false,
+		},
+		{
+			name:       "Bundle keeps an authority of the stored bundle",
+			bundle:     marshal(oldAuthority, newAuthority),
+			continuity: true,
+		},
+		{
+			name:                 "Bundle unrelated to the stored bundle",
+			bundle:               marshal(newAuthority),
+			continuity:           true,
+			expectedErrorMessage: "bundle continuity check failed",
+		},
+		{
+			name:          "Bundle unrelated to the stored bundle with a reset approved",
+			bundle:        marshal(newAuthority),
+			continuity:    true,
+			resetApproved: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sig := encoding.EncodeToBase64([]byte("test-signature"))
+			bundlePut := harvester.PutBundleRequest{
+				Signature:   &sig,
+				TrustBundle: tc.bundle,
+				Digest:      encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(tc.bundle))),
+				TrustDomain: td1,
+			}
+
+			setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", &bundlePut)
+			setup.Handler.bundleContinuity = tc.continuity
+			ctx := context.Background()
+
+			td := SetupTrustDomain(t, setup.Handler.Datastore)
+			setup.EchoCtx.Set(authTrustDomainKey, td)
+
+			stored := []byte(marshal(oldAuthority))
+			_, err := setup.Handler.Datastore.CreateOrUpdateBundle(ctx, &entity.Bundle{
+				TrustDomainID: td.ID.UUID,
+				Data:          stored,
+				Digest:        cryptoutil.CalculateDigest(stored),
+			})
+			require.NoError(t, err)
+
+			if tc.resetApproved {
+				_, err = setup.Handler.Datastore.CreateOrUpdateBundleResetApproval(ctx, &entity.BundleResetApproval{TrustDomainID: td.ID.UUID})
+				require.NoError(t, err)
+			}
+
+			err = setup.Handler.BundlePut(setup.EchoCtx, td1)
+			storedBundle, dsErr := setup.Handler.Datastore.FindBundleByTrustDomainID(ctx, td.ID.UUID)
+			require.NoError(t, dsErr)
+
+			if tc.expectedErrorMessage != "" {
+				require.Error(t, err)
+				assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+				assert.Contains(t, err.(*echo.HTTPError).Message, tc.expectedErrorMessage)
+				assert.Equal(t, stored, storedBundle.Data)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+			assert.Equal(t, tc.bundle, string(storedBundle.Data))
+
+			// the approval is consumed by the bundle accepted thanks to it
+			approval, err := setup.Handler.Datastore.FindBundleResetApproval(ctx, td.ID.UUID)
+			require.NoError(t, err)
+			assert.Nil(t, approval)
+		})
+	}
+}
+
+func TestBundlePutVerification(t *testing.T) {
+	acceptVerifier := &catalog.BundleVerifier{Name: "accept", Verifier: fakeBundleVerifier{}}
+	rejectVerifier := &catalog.BundleVerifier{Name: "reject", Verifier: fakeBundleVerifier{err: errors.New("invalid signature")}}
+
+	testCases := []struct {
+		name                 string
+		verifiers            []*catalog.BundleVerifier
+		trustDomainVerifier  string
+		signingCertificate   string
+		expectedErrorMessage string
+	}{
+		{
+			name:      "No verifiers configured",
+			verifiers: nil,
+		},
+		{
+			name:      "Any verifier accepts the bundle",
+			verifiers: []*catalog.BundleVerifier{rejectVerifier, acceptVerifier},
+		},
+		{
+			name:                "Verifier required by the trust domain accepts the bundle",
+			verifiers:           []*catalog.BundleVerifier{rejectVerifier, acceptVerifier},
+			trustDomainVerifier: "accept",
+		},
+		{
+			name:                 "No verifier accepts the bundle",
+			verifiers:            []*catalog.BundleVerifier{rejectVerifier},
+			expectedErrorMessage: "bundle signature verification failed: no verifier could verify the bundle",
+		},
+		{
+			name:                 "Verifier required by the trust domain rejects the bundle",
+			verifiers:            []*catalog.BundleVerifier{acceptVerifier, rejectVerifier},
+			trustDomainVerifier:  "reject",
+			expectedErrorMessage: "bundle signature verification failed: no verifier could verify the bundle",
+		},
+		{
+			name:                 "Verifier required by the trust domain is not configured",
+			verifiers:            []*catalog.BundleVerifier{acceptVerifier},
+			trustDomainVerifier:  "disk",
+			expectedErrorMessage: `bundle signature verification failed: bundle verifier "disk" required by trust domain "test1.com" is not configured`,
+		},
+		{
+			name:                 "Malformed signing certificate chain",
+			verifiers:            []*catalog.BundleVerifier{acceptVerifier},
+			signingCertificate:   encoding.EncodeToBase64([]byte("not-a-certificate")),
+			expectedErrorMessage: "bundle signature verification failed: failed to parse signing certificate chain",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bundle := "a new bundle"
+			sig := encoding.EncodeToBase64([]byte("test-signature"))
+			bundlePut := harvester.PutBundleRequest{
+				Signature:   &sig,
+				TrustBundle: bundle,
+				Digest:      encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle))),
+				TrustDomain: td1,
+			}
+			if tc.signingCertificate != "" {
+				bundlePut.SigningCertificateChain = &tc.signingCertificate
+			}
+
+			setup := NewHarvesterTestSetup(t, http.MethodPut, "/trust-domain/:trustDomainName/bundles", &bundlePut)
+			setup.Handler.bundleVerifiers = tc.verifiers
+
+			td := SetupTrustDomain(t, setup.Handler.Datastore)
+			td.BundleVerifier = tc.trustDomainVerifier
+			setup.EchoCtx.Set(authTrustDomainKey, td)
+
+			err := setup.Handler.BundlePut(setup.EchoCtx, td1)
+			storedBundle, dsErr := setup.Handler.Datastore.FindBundleByTrustDomainID(context.Background(), td.ID.UUID)
+			require.NoError(t, dsErr)
+
+			if tc.expectedErrorMessage != "" {
+				require.Error(t, err)
+				assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
+				assert.Contains(t, err.(*echo.HTTPError).Message, tc.expectedErrorMessage)
+				assert.Nil(t, storedBundle)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, setup.Recorder.Code)
+			require.NotNil(t, storedBundle)
+			assert.Equal(t, bundle, string(storedBundle.Data))
+		})
+	}
+}
+
 func testBundlePut(t *testing.T, setupFunc func(*HarvesterTestSetup) *entity.TrustDomain, expectedStatusCode int, expectedResponseBody string) {
 	bundle := "a new bundle"
 	digest := encoding.EncodeToBase64(cryptoutil.CalculateDigest([]byte(bundle)))
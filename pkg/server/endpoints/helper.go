@@ -1,13 +1,20 @@
 package endpoints
 
 import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
 	"fmt"
+	"sort"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/api"
 	"github.com/HewlettPackard/galadriel/pkg/common/entity"
 	"github.com/HewlettPackard/galadriel/pkg/server/api/admin"
 	"github.com/HewlettPackard/galadriel/pkg/server/api/harvester"
 	"github.com/HewlettPackard/galadriel/pkg/server/db/criteria"
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 )
 
 type relationshipsParamGetter interface {
@@ -98,3 +105,79 @@ func convertValidConsentStatusParam(status *api.ConsentStatus) (*entity.ConsentS
 		return nil, fmt.Errorf("invalid consent status filter %q, it must be one of [approved, denied, pending]", *status)
 	}
 }
+
+// diffBundleVersions returns the X.509 and JWT authorities added and removed in the version
+// of a bundle with respect to the base version. JWT authorities are identified by their key ID,
+// a key ID whose public key changed is reported both as removed and as added.
+func diffBundleVersions(td spiffeid.TrustDomain, base, bv *entity.BundleVersion) (*admin.BundleDiff, error) {
+	baseBundle, err := spiffebundle.Parse(td, base.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bundle version %d: %w", base.Version, err)
+	}
+
+	bundle, err := spiffebundle.Parse(td, bv.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bundle version %d: %w", bv.Version, err)
+	}
+
+	baseX509Authorities := x509AuthoritiesByFingerprint(baseBundle.X509Authorities())
+	x509Authorities := x509AuthoritiesByFingerprint(bundle.X509Authorities())
+
+	diff := &admin.BundleDiff{
+		Version:                bv.Version,
+		BaseVersion:            base.Version,
+		AddedX509Authorities:   missingX509Authorities(x509Authorities, baseX509Authorities),
+		RemovedX509Authorities: missingX509Authorities(baseX509Authorities, x509Authorities),
+		AddedJwtAuthorities:    missingJWTAuthorities(bundle.JWTAuthorities(), baseBundle.JWTAuthorities()),
+		RemovedJwtAuthorities:  missingJWTAuthorities(baseBundle.JWTAuthorities(), bundle.JWTAuthorities()),
+	}
+
+	return diff, nil
+}
+
+func x509AuthoritiesByFingerprint(certs []*x509.Certificate) map[string]*x509.Certificate {
+	out := make(map[string]*x509.Certificate, len(certs))
+	for _, cert := range certs {
+		fingerprint := sha256.Sum256(cert.Raw)
+		out[hex.EncodeToString(fingerprint[:])] = cert
+	}
+
+	return out
+}
+
+// missingX509Authorities returns the authorities in a that are not in b, sorted by fingerprint.
+func missingX509Authorities(a, b map[string]*x509.Certificate) []admin.X509Authority {
+	out := []admin.X509Authority{}
+	for fingerprint, cert := range a {
+		if _, ok := b[fingerprint]; ok {
+			continue
+		}
+		out = append(out, admin.X509Authority{
+			Subject:  cert.Subject.String(),
+			Sha256:   fingerprint,
+			NotAfter: cert.NotAfter,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Sha256 < out[j].Sha256 })
+
+	return out
+}
+
+// missingJWTAuthorities returns the key IDs of the authorities in a that are not in b, sorted.
+func missingJWTAuthorities(a, b map[string]crypto.PublicKey) []string {
+	out := []string{}
+	for keyID, key := range a {
+		if other, ok := b[keyID]; ok && publicKeysEqual(key, other) {
+			continue
+		}
+		out = append(out, keyID)
+	}
+	sort.Strings(out)
+
+	return out
+}
+
+func publicKeysEqual(a, b crypto.PublicKey) bool {
+	key, ok := a.(interface{ Equal(crypto.PublicKey) bool })
+	return ok && key.Equal(b)
+}
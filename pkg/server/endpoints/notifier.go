@@ -0,0 +1,113 @@
+package endpoints
+
+import (
+	"context"
+	"sync"
+
+	"github.com/HewlettPackard/galadriel/pkg/server/db"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Notifier notifies the Harvesters waiting for changes in the federated bundles of their trust domains.
+// It is shared by the handlers that change bundles and relationships and the BundleSync handler.
+// Notifications only reach the requests served by the same Galadriel Server replica, the waiting requests
+// also check the datastore periodically to pick up changes made through other replicas.
+type Notifier struct {
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan struct{}]struct{}
+}
+
+// NewNotifier creates a new Notifier.
+func NewNotifier() *Notifier {
+	return &Notifier{
+		subscribers: make(map[uuid.UUID]map[chan struct{}]struct{}),
+	}
+}
+
+// Subscribe returns a channel that receives a value when the federated bundles of the given trust domain
+// may have changed, and a function to stop receiving notifications.
+// A nil Notifier returns a channel that never receives.
+func (n *Notifier) Subscribe(trustDomainID uuid.UUID) (<-chan struct{}, func()) {
+	if n == nil {
+		return nil, func() {}
+	}
+
+	ch := make(chan struct{}, 1)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.subscribers[trustDomainID] == nil {
+		n.subscribers[trustDomainID] = make(map[chan struct{}]struct{})
+	}
+	n.subscribers[trustDomainID][ch] = struct{}{}
+
+	unsubscribe := func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+
+		delete(n.subscribers[trustDomainID], ch)
+		if len(n.subscribers[trustDomainID]) == 0 {
+			delete(n.subscribers, trustDomainID)
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+// Notify notifies the subscribers of the given trust domains. It never blocks.
+func (n *Notifier) Notify(trustDomainIDs ...uuid.UUID) {
+	if n == nil {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, id := range trustDomainIDs {
+		for ch := range n.subscribers[id] {
+			select {
+			case ch <- struct{}{}:
+			default:
+				// a notification is already pending
+			}
+		}
+	}
+}
+
+// notifyRelatedTrustDomains notifies the trust domains that have a relationship with the given trust domain,
+// whose federated bundles include the bundle of the given trust domain.
+func notifyRelatedTrustDomains(ctx context.Context, ds db.Datastore, notifier *Notifier, logger logrus.FieldLogger, trustDomainID uuid.UUID) {
+	if notifier == nil {
+		return
+	}
+
+	relatedTrustDomainIDs, err := findRelatedTrustDomainIDs(ctx, ds, trustDomainID)
+	if err != nil {
+		// the waiting Harvesters pick up the change when they check the datastore again
+		logger.WithError(err).Warn("Failed to notify related trust domains")
+		return
+	}
+
+	notifier.Notify(relatedTrustDomainIDs...)
+}
+
+// findRelatedTrustDomainIDs returns the IDs of the trust domains that have a relationship with the given trust domain.
+func findRelatedTrustDomainIDs(ctx context.Context, ds db.Datastore, trustDomainID uuid.UUID) ([]uuid.UUID, error) {
+	relationships, err := ds.FindRelationshipsByTrustDomainID(ctx, trustDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(relationships))
+	for _, r := range relationships {
+		if r.TrustDomainAID == trustDomainID {
+			ids = append(ids, r.TrustDomainBID)
+		} else {
+			ids = append(ids, r.TrustDomainAID)
+		}
+	}
+
+	return ids, nil
+}
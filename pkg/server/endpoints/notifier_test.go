@@ -0,0 +1,38 @@
+package endpoints
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifier(t *testing.T) {
+	n := NewNotifier()
+	td1, td2 := uuid.New(), uuid.New()
+
+	changes1, unsubscribe1 := n.Subscribe(td1)
+	changes2, unsubscribe2 := n.Subscribe(td2)
+	defer unsubscribe2()
+
+	// notifications do not block when a notification is already pending
+	n.Notify(td1)
+	n.Notify(td1)
+	assert.Len(t, changes1, 1)
+	assert.Len(t, changes2, 0)
+
+	unsubscribe1()
+	<-changes1
+	n.Notify(td1)
+	assert.Len(t, changes1, 0)
+	assert.NotContains(t, n.subscribers, td1)
+}
+
+func TestNilNotifier(t *testing.T) {
+	var n *Notifier
+
+	changes, unsubscribe := n.Subscribe(uuid.New())
+	assert.Nil(t, changes)
+	unsubscribe()
+	n.Notify(uuid.New())
+}
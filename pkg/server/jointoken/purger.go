@@ -0,0 +1,98 @@
+package jointoken
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/server/leader"
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultRetention is how long the expired join tokens are kept, so that admins can still list them.
+	DefaultRetention = 24 * time.Hour
+
+	// purgeInterval is how often the join tokens expired for longer than the retention are deleted.
+	purgeInterval = time.Hour
+)
+
+// Store is the datastore holding the join tokens.
+type Store interface {
+	PruneJoinTokens(ctx context.Context, expiredBefore time.Time) error
+}
+
+// Config is the configuration for the join token Purger.
+type Config struct {
+	// Store is the datastore holding the join tokens.
+	Store Store
+
+	// Retention is how long the expired join tokens are kept.
+	Retention time.Duration
+
+	// Leader reports whether this replica purges the join tokens when several Galadriel Server replicas
+	// share the datastore. It is nil when the server runs as a single replica.
+	Leader leader.Leader
+
+	Clock  clock.Clock
+	Logger logrus.FieldLogger
+}
+
+// Purger periodically deletes the join tokens expired for longer than the retention,
+// so that the unused tokens do not pile up in the datastore.
+type Purger struct {
+	c *Config
+}
+
+// NewPurger creates a new join token Purger.
+func NewPurger(c *Config) (*Purger, error) {
+	if c.Store == nil {
+		return nil, errors.New("store is required")
+	}
+	if c.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+	if c.Retention == 0 {
+		c.Retention = DefaultRetention
+	}
+	if c.Retention < 0 {
+		return nil, errors.New("retention cannot be negative")
+	}
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+
+	return &Purger{c: c}, nil
+}
+
+// Run periodically purges the expired join tokens until the context is done.
+func (p *Purger) Run(ctx context.Context) error {
+	timer := p.c.Clock.NewTimer(purgeInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			p.purge(ctx)
+			timer.Reset(purgeInterval)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// purge deletes the join tokens expired for longer than the retention, unless another replica is the leader.
+func (p *Purger) purge(ctx context.Context) {
+	if p.c.Leader != nil && !p.c.Leader.IsLeader() {
+		return
+	}
+
+	expiredBefore := p.c.Clock.Now().Add(-p.c.Retention)
+	if err := p.c.Store.PruneJoinTokens(ctx, expiredBefore); err != nil {
+		p.c.Logger.WithError(err).Error("Failed to purge expired join tokens")
+		return
+	}
+
+	p.c.Logger.Debug("Purged expired join tokens")
+}
@@ -0,0 +1,130 @@
+package jointoken
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	mu            sync.Mutex
+	expiredBefore []time.Time
+	err           error
+}
+
+func (s *fakeStore) PruneJoinTokens(ctx context.Context, expiredBefore time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expiredBefore = append(s.expiredBefore, expiredBefore)
+	return s.err
+}
+
+func (s *fakeStore) purges() []time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]time.Time(nil), s.expiredBefore...)
+}
+
+type fakeLeader bool
+
+func (l fakeLeader) IsLeader() bool {
+	return bool(l)
+}
+
+func TestNewPurger(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := &fakeStore{}
+
+	p, err := NewPurger(&Config{Store: store, Logger: logger})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRetention, p.c.Retention)
+
+	_, err = NewPurger(&Config{Logger: logger})
+	require.EqualError(t, err, "store is required")
+
+	_, err = NewPurger(&Config{Store: store})
+	require.EqualError(t, err, "logger is required")
+
+	_, err = NewPurger(&Config{Store: store, Logger: logger, Retention: -time.Hour})
+	require.EqualError(t, err, "retention cannot be negative")
+}
+
+func TestPurge(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	clk := clock.NewFake()
+	clk.Set(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	t.Run("Purges the join tokens expired for longer than the retention", func(t *testing.T) {
+		store := &fakeStore{}
+		p, err := NewPurger(&Config{Store: store, Retention: time.Hour, Clock: clk, Logger: logger})
+		require.NoError(t, err)
+
+		p.purge(context.Background())
+		assert.Equal(t, []time.Time{clk.Now().Add(-time.Hour)}, store.expiredBefore)
+	})
+
+	t.Run("Only the leader purges", func(t *testing.T) {
+		store := &fakeStore{}
+		p, err := NewPurger(&Config{Store: store, Leader: fakeLeader(false), Clock: clk, Logger: logger})
+		require.NoError(t, err)
+
+		p.purge(context.Background())
+		assert.Empty(t, store.expiredBefore)
+
+		p.c.Leader = fakeLeader(true)
+		p.purge(context.Background())
+		assert.Len(t, store.expiredBefore, 1)
+	})
+
+	t.Run("Logs the failures", func(t *testing.T) {
+		hook.Reset()
+		store := &fakeStore{err: errors.New("datastore error")}
+		p, err := NewPurger(&Config{Store: store, Clock: clk, Logger: logger})
+		require.NoError(t, err)
+
+		p.purge(context.Background())
+		require.NotNil(t, hook.LastEntry())
+		assert.Equal(t, "Failed to purge expired join tokens", hook.LastEntry().Message)
+	})
+}
+
+func TestRunPurgesOnClockTicks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger, _ := test.NewNullLogger()
+	clk := clock.NewFake()
+	clk.Set(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	store := &fakeStore{}
+	p, err := NewPurger(&Config{Store: store, Retention: time.Hour, Clock: clk, Logger: logger})
+	require.NoError(t, err)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- p.Run(ctx)
+	}()
+
+	// the join tokens are purged on the ticks of the clock, without waiting for the actual purge interval
+	require.Eventually(t, func() bool {
+		clk.Add(purgeInterval)
+		return len(store.purges()) >= 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-errCh)
+
+	// the expiry cutoff is computed from the same clock
+	purges := store.purges()
+	assert.True(t, purges[1].After(purges[0]))
+	assert.False(t, purges[len(purges)-1].After(clk.Now().Add(-time.Hour)))
+}
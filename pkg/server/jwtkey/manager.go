@@ -0,0 +1,391 @@
+package jwtkey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/jwt"
+	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
+	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
+	"github.com/HewlettPackard/galadriel/pkg/server/leader"
+	"github.com/google/uuid"
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultRotationInterval is the default interval after which a new JWT signing key is generated.
+	DefaultRotationInterval = 30 * 24 * time.Hour
+
+	// keyIDPrefix identifies the keys in the KeyManager that are used for signing JWTs.
+	keyIDPrefix = "jwt-"
+
+	// rotationCheckInterval is how often the manager checks whether the active key needs to be rotated.
+	rotationCheckInterval = time.Minute
+)
+
+// Config is the configuration for the JWT key Manager.
+type Config struct {
+	// KeyManager is used to generate and persist the JWT signing keys.
+	KeyManager keymanager.KeyManager
+
+	// RotationInterval is the lifetime of the active signing key before a new one is generated.
+	RotationInterval time.Duration
+
+	// TokenTTL is the longest TTL of the tokens issued by the server. A key that has been
+	// rotated out is still accepted for verification until this time has elapsed.
+	TokenTTL time.Duration
+
+	// Leader reports whether this replica rotates the keys when several Galadriel Server replicas
+	// share the KeyManager. The other replicas adopt the keys generated by the leader.
+	// It is nil when the server runs as a single replica.
+	Leader leader.Leader
+
+	Clock  clock.Clock
+	Logger logrus.FieldLogger
+}
+
+// Manager manages the lifecycle of the keys used for signing JWTs. It reuses the active key
+// across restarts, rotates it on a schedule, and keeps track of the previous keys so that the
+// tokens they signed can be validated until they expire.
+// It implements the jwt.Issuer and jwt.KeyStatus interfaces.
+type Manager struct {
+	c *Config
+
+	mu     sync.RWMutex
+	keys   []*signingKey // sorted by creation time, the last one is the active key
+	issuer *jwt.JWTCA
+}
+
+type signingKey struct {
+	id        string
+	createdAt time.Time
+	key       keymanager.Key
+}
+
+// New creates a new JWT key Manager.
+func New(c *Config) (*Manager, error) {
+	if c.KeyManager == nil {
+		return nil, errors.New("key manager is required")
+	}
+	if c.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+	if c.RotationInterval == 0 {
+		c.RotationInterval = DefaultRotationInterval
+	}
+	if c.RotationInterval < 0 {
+		return nil, errors.New("rotation interval cannot be negative")
+	}
+	if c.TokenTTL < 0 {
+		return nil, errors.New("token TTL cannot be negative")
+	}
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+
+	return &Manager{c: c}, nil
+}
+
+// Initialize loads the JWT signing keys from the KeyManager. The newest key becomes the active key,
+// unless there is none or it is due for rotation, in which case a new key is generated.
+// When the KeyManager is shared by several replicas, a key due for rotation is kept until the leader rotates it.
+func (m *Manager) Initialize(ctx context.Context) error {
+	signingKeys, err := m.loadKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.keys = signingKeys
+	m.mu.Unlock()
+
+	if len(signingKeys) == 0 || (m.c.Leader == nil && m.shouldRotate()) {
+		return m.rotate(ctx)
+	}
+
+	active := signingKeys[len(signingKeys)-1]
+	if err := m.setActiveKey(active); err != nil {
+		return err
+	}
+
+	m.c.Logger.WithField(telemetry.KeyID, active.id).Info("Loaded JWT signing key")
+	return nil
+}
+
+// Run periodically checks if the active key is due for rotation, and rotates it if it is.
+// It also deletes the retired keys from the KeyManager.
+func (m *Manager) Run(ctx context.Context) error {
+	timer := m.c.Clock.NewTimer(rotationCheckInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			m.maintainKeys(ctx)
+			timer.Reset(rotationCheckInterval)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// IssueJWT issues a JWT signed with the active key.
+func (m *Manager) IssueJWT(ctx context.Context, params *jwt.JWTParams) (string, error) {
+	m.mu.RLock()
+	issuer := m.issuer
+	m.mu.RUnlock()
+
+	if issuer == nil {
+		return "", errors.New("JWT key manager is not initialized")
+	}
+
+	return issuer.IssueJWT(ctx, params)
+}
+
+// IsRetired returns true if the key with the given ID has been rotated out and the
+// tokens it signed have all expired. Keys not managed by this Manager are never reported as retired.
+func (m *Manager) IsRetired(kid string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := m.c.Clock.Now()
+	for i, k := range m.keys {
+		if k.id == kid {
+			return m.isRetiredAt(i, now)
+		}
+	}
+
+	return false
+}
+
+// ActiveKeyID returns the ID of the key currently used for signing JWTs.
+func (m *Manager) ActiveKeyID() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.keys) == 0 {
+		return ""
+	}
+	return m.keys[len(m.keys)-1].id
+}
+
+// CheckReady reports an error unless a JWT signing key is available to issue the JWTs.
+func (m *Manager) CheckReady(context.Context) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.issuer == nil {
+		return errors.New("JWT signing key is not available")
+	}
+
+	return nil
+}
+
+// maintainKeys adopts the keys generated by the leader when the KeyManager is shared by several replicas.
+// If this replica is the leader, it rotates the active key when it is due for rotation, and deletes the retired keys.
+func (m *Manager) maintainKeys(ctx context.Context) {
+	if m.c.Leader != nil {
+		if err := m.refresh(ctx); err != nil {
+			m.c.Logger.WithError(err).Error("Failed to refresh JWT signing keys")
+		}
+		if !m.c.Leader.IsLeader() {
+			return
+		}
+	}
+
+	if m.shouldRotate() {
+		if err := m.rotate(ctx); err != nil {
+			m.c.Logger.WithError(err).Error("Failed to rotate JWT signing key")
+		}
+	}
+
+	m.deleteRetiredKeys(ctx)
+}
+
+// deleteRetiredKeys deletes from the KeyManager the keys that are retired, since the tokens they signed have all expired.
+func (m *Manager) deleteRetiredKeys(ctx context.Context) {
+	now := m.c.Clock.Now()
+
+	m.mu.RLock()
+	var retired []string
+	for i, k := range m.keys {
+		if m.isRetiredAt(i, now) {
+			retired = append(retired, k.id)
+		}
+	}
+	m.mu.RUnlock()
+
+	for _, id := range retired {
+		if err := m.c.KeyManager.DeleteKey(ctx, id); err != nil {
+			m.c.Logger.WithError(err).WithField(telemetry.KeyID, id).Error("Failed to delete retired JWT signing key")
+			continue
+		}
+
+		m.mu.Lock()
+		for i, k := range m.keys {
+			if k.id == id {
+				m.keys = append(m.keys[:i], m.keys[i+1:]...)
+				break
+			}
+		}
+		m.mu.Unlock()
+
+		m.c.Logger.WithField(telemetry.KeyID, id).Info("Deleted retired JWT signing key")
+	}
+}
+
+// isRetiredAt returns true if the key at the given index has been rotated out and the tokens it signed
+// have all expired at the given time. The caller must hold the lock.
+func (m *Manager) isRetiredAt(i int, now time.Time) bool {
+	if i == len(m.keys)-1 {
+		// active key
+		return false
+	}
+
+	successor := m.keys[i+1]
+	return !now.Before(successor.createdAt.Add(m.c.TokenTTL))
+}
+
+// refresh reloads the keys from the KeyManager, and makes the newest key the active key.
+func (m *Manager) refresh(ctx context.Context) error {
+	signingKeys, err := m.loadKeys(ctx)
+	if err != nil {
+		return err
+	}
+	if len(signingKeys) == 0 {
+		return nil
+	}
+
+	active := signingKeys[len(signingKeys)-1]
+	adopted := active.id != m.ActiveKeyID()
+	if adopted {
+		if err := m.setActiveKey(active); err != nil {
+			return err
+		}
+	}
+
+	m.mu.Lock()
+	m.keys = signingKeys
+	m.mu.Unlock()
+
+	if adopted {
+		m.c.Logger.WithField(telemetry.KeyID, active.id).Info("Adopted JWT signing key generated by another replica")
+	}
+	return nil
+}
+
+// loadKeys returns the JWT signing keys in the KeyManager, sorted by creation time.
+func (m *Manager) loadKeys(ctx context.Context) ([]*signingKey, error) {
+	keys, err := m.c.KeyManager.GetKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load keys: %w", err)
+	}
+
+	var signingKeys []*signingKey
+	for _, key := range keys {
+		createdAt, ok := parseKeyID(key.ID())
+		if !ok {
+			continue
+		}
+		signingKeys = append(signingKeys, &signingKey{
+			id:        key.ID(),
+			createdAt: createdAt,
+			key:       key,
+		})
+	}
+	sort.Slice(signingKeys, func(i, j int) bool {
+		return signingKeys[i].createdAt.Before(signingKeys[j].createdAt)
+	})
+
+	return signingKeys, nil
+}
+
+func (m *Manager) shouldRotate() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.keys) == 0 {
+		return true
+	}
+
+	active := m.keys[len(m.keys)-1]
+	return !m.c.Clock.Now().Before(active.createdAt.Add(m.c.RotationInterval))
+}
+
+func (m *Manager) rotate(ctx context.Context) error {
+	now := m.c.Clock.Now()
+	keyID := newKeyID(now)
+
+	key, err := m.c.KeyManager.GenerateKey(ctx, keyID, cryptoutil.DefaultKeyType)
+	if err != nil {
+		return fmt.Errorf("failed to generate JWT signing key: %w", err)
+	}
+
+	newKey := &signingKey{
+		id:        keyID,
+		createdAt: now,
+		key:       key,
+	}
+
+	m.mu.Lock()
+	m.keys = append(m.keys, newKey)
+	m.mu.Unlock()
+
+	if err := m.setActiveKey(newKey); err != nil {
+		return err
+	}
+
+	m.c.Logger.WithField(telemetry.KeyID, keyID).Info("Generated new JWT signing key")
+	return nil
+}
+
+func (m *Manager) setActiveKey(k *signingKey) error {
+	issuer, err := jwt.NewJWTCA(&jwt.Config{
+		Signer: k.key.Signer(),
+		Kid:    k.id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create JWT issuer: %w", err)
+	}
+
+	m.mu.Lock()
+	m.issuer = issuer
+	m.mu.Unlock()
+
+	return nil
+}
+
+// newKeyID returns a new key ID that encodes the creation time of the key,
+// so that the rotation schedule survives restarts without keeping extra state.
+func newKeyID(createdAt time.Time) string {
+	return fmt.Sprintf("%s%d-%s", keyIDPrefix, createdAt.Unix(), uuid.NewString())
+}
+
+// parseKeyID returns the creation time encoded in a JWT key ID.
+// It returns false if the ID does not belong to a JWT signing key.
+func parseKeyID(id string) (time.Time, bool) {
+	rest, ok := strings.CutPrefix(id, keyIDPrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	ts, _, ok := strings.Cut(rest, "-")
+	if !ok {
+		return time.Time{}, false
+	}
+
+	secs, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(secs, 0), true
+}
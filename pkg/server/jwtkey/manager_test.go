@@ -0,0 +1,370 @@
+package jwtkey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/jwt"
+	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
+	"github.com/HewlettPackard/galadriel/pkg/server/leader"
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	rotationInterval = 24 * time.Hour
+	tokenTTL         = time.Hour
+)
+
+func TestNew(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	km := keymanager.NewMemoryKeyManager(nil)
+
+	m, err := New(&Config{KeyManager: km, Logger: logger})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRotationInterval, m.c.RotationInterval)
+
+	_, err = New(&Config{Logger: logger})
+	require.EqualError(t, err, "key manager is required")
+
+	_, err = New(&Config{KeyManager: km})
+	require.EqualError(t, err, "logger is required")
+
+	_, err = New(&Config{KeyManager: km, Logger: logger, RotationInterval: -time.Hour})
+	require.EqualError(t, err, "rotation interval cannot be negative")
+}
+
+func TestInitializeGeneratesKey(t *testing.T) {
+	ctx := context.Background()
+	km := keymanager.NewMemoryKeyManager(nil)
+	m, _ := setup(t, km, clock.NewFake())
+
+	require.NoError(t, m.Initialize(ctx))
+
+	keys, err := km.GetKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, keys[0].ID(), m.ActiveKeyID())
+
+	token, err := m.IssueJWT(ctx, testParams())
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+}
+
+func TestInitializeReusesActiveKey(t *testing.T) {
+	ctx := context.Background()
+	km := keymanager.NewMemoryKeyManager(nil)
+	clk := clock.NewFake()
+	clk.Set(time.Now())
+
+	m, _ := setup(t, km, clk)
+	require.NoError(t, m.Initialize(ctx))
+	activeKeyID := m.ActiveKeyID()
+
+	// a new manager, as after a restart, reuses the key stored in the key manager
+	clk.Add(rotationInterval / 2)
+	restarted, _ := setup(t, km, clk)
+	require.NoError(t, restarted.Initialize(ctx))
+	assert.Equal(t, activeKeyID, restarted.ActiveKeyID())
+
+	keys, err := km.GetKeys(ctx)
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+}
+
+func TestInitializeRotatesExpiredKey(t *testing.T) {
+	ctx := context.Background()
+	km := keymanager.NewMemoryKeyManager(nil)
+	clk := clock.NewFake()
+	clk.Set(time.Now())
+
+	m, _ := setup(t, km, clk)
+	require.NoError(t, m.Initialize(ctx))
+	oldKeyID := m.ActiveKeyID()
+
+	clk.Add(rotationInterval)
+	restarted, _ := setup(t, km, clk)
+	require.NoError(t, restarted.Initialize(ctx))
+	assert.NotEqual(t, oldKeyID, restarted.ActiveKeyID())
+	assert.False(t, restarted.IsRetired(oldKeyID))
+}
+
+func TestRotationAndRetirement(t *testing.T) {
+	ctx := context.Background()
+	km := keymanager.NewMemoryKeyManager(nil)
+	clk := clock.NewFake()
+	clk.Set(time.Now())
+
+	m, _ := setup(t, km, clk)
+	require.NoError(t, m.Initialize(ctx))
+	oldKeyID := m.ActiveKeyID()
+
+	validator := jwt.NewDefaultJWTValidator(&jwt.ValidatorConfig{
+		KeyManager:       km,
+		KeyStatus:        m,
+		ExpectedAudience: []string{"test-audience"},
+	})
+
+	oldToken, err := m.IssueJWT(ctx, testParams())
+	require.NoError(t, err)
+
+	assert.False(t, m.shouldRotate())
+	clk.Add(rotationInterval)
+	require.True(t, m.shouldRotate())
+	require.NoError(t, m.rotate(ctx))
+
+	newKeyID := m.ActiveKeyID()
+	assert.NotEqual(t, oldKeyID, newKeyID)
+
+	// the previous key is still accepted until the tokens it signed have expired
+	assert.False(t, m.IsRetired(oldKeyID))
+	assert.False(t, m.IsRetired(newKeyID))
+
+	newToken, err := m.IssueJWT(ctx, testParams())
+	require.NoError(t, err)
+	_, err = validator.ValidateToken(ctx, newToken)
+	require.NoError(t, err)
+
+	clk.Add(tokenTTL)
+	assert.True(t, m.IsRetired(oldKeyID))
+	assert.False(t, m.IsRetired(newKeyID))
+
+	_, err = validator.ValidateToken(ctx, oldToken)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is retired")
+}
+
+func TestMaintainKeysDeletesRetiredKeys(t *testing.T) {
+	ctx := context.Background()
+	km := keymanager.NewMemoryKeyManager(nil)
+	clk := clock.NewFake()
+	clk.Set(time.Now())
+
+	m, _ := setup(t, km, clk)
+	require.NoError(t, m.Initialize(ctx))
+	oldKeyID := m.ActiveKeyID()
+
+	clk.Add(rotationInterval)
+	m.maintainKeys(ctx)
+	newKeyID := m.ActiveKeyID()
+	require.NotEqual(t, oldKeyID, newKeyID)
+
+	// the previous key is kept until the tokens it signed have expired
+	_, err := km.GetKey(ctx, oldKeyID)
+	require.NoError(t, err)
+
+	clk.Add(tokenTTL)
+	m.maintainKeys(ctx)
+
+	_, err = km.GetKey(ctx, oldKeyID)
+	require.Error(t, err)
+	keys, err := km.GetKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, newKeyID, keys[0].ID())
+	assert.Equal(t, newKeyID, m.ActiveKeyID())
+	assert.False(t, m.IsRetired(oldKeyID))
+}
+
+func TestMaintainKeysKeepsRetiredKeysOnFollower(t *testing.T) {
+	ctx := context.Background()
+	km := keymanager.NewMemoryKeyManager(nil)
+	clk := clock.NewFake()
+	clk.Set(time.Now())
+
+	leaderReplica, _ := setupReplica(t, km, clk, &fakeLeader{leader: true})
+	require.NoError(t, leaderReplica.Initialize(ctx))
+	clk.Add(rotationInterval)
+	leaderReplica.maintainKeys(ctx)
+
+	follower, _ := setupReplica(t, km, clk, &fakeLeader{leader: false})
+	require.NoError(t, follower.Initialize(ctx))
+
+	clk.Add(tokenTTL)
+	follower.maintainKeys(ctx)
+
+	keys, err := km.GetKeys(ctx)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+}
+
+func TestRunRotatesOnClockTicks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clk := clock.NewFake()
+	clk.Set(time.Now())
+
+	m, _ := setup(t, keymanager.NewMemoryKeyManager(nil), clk)
+	require.NoError(t, m.Initialize(ctx))
+	oldKeyID := m.ActiveKeyID()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- m.Run(ctx)
+	}()
+
+	// the key is rotated on the ticks of the clock, without waiting for the actual check interval
+	clk.Add(rotationInterval)
+	require.Eventually(t, func() bool {
+		clk.Add(rotationCheckInterval)
+		return m.ActiveKeyID() != oldKeyID
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
+
+func TestIsRetiredUnknownKey(t *testing.T) {
+	m, _ := setup(t, keymanager.NewMemoryKeyManager(nil), clock.NewFake())
+	assert.False(t, m.IsRetired("unknown"))
+}
+
+func TestInitializeIgnoresOtherKeys(t *testing.T) {
+	ctx := context.Background()
+	km := keymanager.NewMemoryKeyManager(nil)
+	_, err := km.GenerateKey(ctx, "other-key", cryptoutil.RSA2048)
+	require.NoError(t, err)
+
+	m, _ := setup(t, km, clock.NewFake())
+	require.NoError(t, m.Initialize(ctx))
+	assert.NotEqual(t, "other-key", m.ActiveKeyID())
+}
+
+func TestIssueJWTNotInitialized(t *testing.T) {
+	m, _ := setup(t, keymanager.NewMemoryKeyManager(nil), clock.NewFake())
+	_, err := m.IssueJWT(context.Background(), testParams())
+	require.EqualError(t, err, "JWT key manager is not initialized")
+}
+
+func TestCheckReady(t *testing.T) {
+	m, _ := setup(t, keymanager.NewMemoryKeyManager(nil), clock.NewFake())
+	require.EqualError(t, m.CheckReady(context.Background()), "JWT signing key is not available")
+
+	require.NoError(t, m.Initialize(context.Background()))
+	require.NoError(t, m.CheckReady(context.Background()))
+}
+
+func TestParseKeyID(t *testing.T) {
+	now := time.Unix(time.Now().Unix(), 0)
+
+	createdAt, ok := parseKeyID(newKeyID(now))
+	require.True(t, ok)
+	assert.Equal(t, now, createdAt)
+
+	for _, id := range []string{"", "other", "jwt-", "jwt-abc-123", "jwt-123"} {
+		_, ok := parseKeyID(id)
+		assert.False(t, ok, id)
+	}
+}
+
+func setup(t *testing.T, km keymanager.KeyManager, clk clock.Clock) (*Manager, *test.Hook) {
+	logger, hook := test.NewNullLogger()
+	m, err := New(&Config{
+		KeyManager:       km,
+		RotationInterval: rotationInterval,
+		TokenTTL:         tokenTTL,
+		Clock:            clk,
+		Logger:           logger,
+	})
+	require.NoError(t, err)
+
+	return m, hook
+}
+
+func testParams() *jwt.JWTParams {
+	return &jwt.JWTParams{
+		Issuer:   "test-issuer",
+		Subject:  spiffeid.RequireTrustDomainFromString("test.org"),
+		Audience: []string{"test-audience"},
+		TTL:      time.Minute,
+	}
+}
+
+type fakeLeader struct {
+	leader bool
+}
+
+func (l *fakeLeader) IsLeader() bool {
+	return l.leader
+}
+
+func TestReplicasShareKeys(t *testing.T) {
+	ctx := context.Background()
+	km := keymanager.NewMemoryKeyManager(nil)
+	clk := clock.NewFake()
+	clk.Set(time.Now())
+
+	leader := &fakeLeader{leader: true}
+	m1, _ := setupReplica(t, km, clk, leader)
+	require.NoError(t, m1.Initialize(ctx))
+
+	follower := &fakeLeader{}
+	m2, _ := setupReplica(t, km, clk, follower)
+	require.NoError(t, m2.Initialize(ctx))
+	assert.Equal(t, m1.ActiveKeyID(), m2.ActiveKeyID())
+
+	// a token issued by a replica is accepted by another one
+	validator := jwt.NewDefaultJWTValidator(&jwt.ValidatorConfig{
+		KeyManager:       km,
+		KeyStatus:        m2,
+		ExpectedAudience: []string{"test-audience"},
+	})
+	token, err := m1.IssueJWT(ctx, testParams())
+	require.NoError(t, err)
+	_, err = validator.ValidateToken(ctx, token)
+	require.NoError(t, err)
+
+	// only the leader rotates the key
+	clk.Add(rotationInterval)
+	oldKeyID := m1.ActiveKeyID()
+	m2.maintainKeys(ctx)
+	assert.Equal(t, oldKeyID, m2.ActiveKeyID())
+
+	m1.maintainKeys(ctx)
+	newKeyID := m1.ActiveKeyID()
+	assert.NotEqual(t, oldKeyID, newKeyID)
+
+	// the follower adopts the key generated by the leader
+	m2.maintainKeys(ctx)
+	assert.Equal(t, newKeyID, m2.ActiveKeyID())
+	assert.False(t, m2.IsRetired(oldKeyID))
+
+	keys, err := km.GetKeys(ctx)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+
+	clk.Add(tokenTTL)
+	assert.True(t, m2.IsRetired(oldKeyID))
+}
+
+func TestInitializeFollowerKeepsKeyDueForRotation(t *testing.T) {
+	ctx := context.Background()
+	km := keymanager.NewMemoryKeyManager(nil)
+	clk := clock.NewFake()
+	clk.Set(time.Now())
+
+	m1, _ := setupReplica(t, km, clk, &fakeLeader{leader: true})
+	require.NoError(t, m1.Initialize(ctx))
+
+	clk.Add(rotationInterval)
+	m2, _ := setupReplica(t, km, clk, &fakeLeader{})
+	require.NoError(t, m2.Initialize(ctx))
+	assert.Equal(t, m1.ActiveKeyID(), m2.ActiveKeyID())
+
+	keys, err := km.GetKeys(ctx)
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+}
+
+func setupReplica(t *testing.T, km keymanager.KeyManager, clk clock.Clock, replicaLeader leader.Leader) (*Manager, *test.Hook) {
+	m, hook := setup(t, km, clk)
+	m.c.Leader = replicaLeader
+
+	return m, hook
+}
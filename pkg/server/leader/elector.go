@@ -0,0 +1,177 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/pkg/common/entity"
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultLeaseName is the name of the lease held by the leader of the Galadriel Server replicas.
+	DefaultLeaseName = "leader"
+
+	// DefaultLeaseTTL is how long the leader holds the lease without renewing it.
+	// Another replica takes over when the lease expires.
+	DefaultLeaseTTL = 30 * time.Second
+
+	// DefaultRenewInterval is well below the lease TTL, so that the lease is renewed several times before it expires.
+	DefaultRenewInterval = 10 * time.Second
+
+	leaseReleaseTimeout = 5 * time.Second
+)
+
+// LeaseStore persists the leases of the Galadriel Server replicas.
+// It is implemented by the Galadriel Server datastore.
+type LeaseStore interface {
+	AcquireServerLease(ctx context.Context, req *entity.ServerLease, now time.Time) (*entity.ServerLease, error)
+	DeleteServerLease(ctx context.Context, name, holderID string) error
+}
+
+// Leader reports whether the Galadriel Server replica is the leader of the replicas.
+// The background tasks that only run on the leader take it as a dependency, it is implemented by the Elector.
+type Leader interface {
+	IsLeader() bool
+}
+
+// Config is the configuration for the Elector.
+type Config struct {
+	// LeaseStore is the datastore shared by the replicas.
+	LeaseStore LeaseStore
+
+	// HolderID identifies the replica, it must be unique across the replicas.
+	HolderID string
+
+	LeaseName     string
+	LeaseTTL      time.Duration
+	RenewInterval time.Duration
+
+	Clock  clock.Clock
+	Logger logrus.FieldLogger
+}
+
+// Elector elects, among the Galadriel Server replicas sharing a datastore, the one that runs the
+// background work, such as the rotation of the JWT signing keys. The leader holds a lease in the
+// datastore that it keeps renewing, and releases it when it stops so that another replica can take over
+// without waiting for the lease to expire.
+type Elector struct {
+	c *Config
+
+	mu        sync.RWMutex
+	expiresAt time.Time // expiration of the lease held by the replica, zero if it is not the leader
+}
+
+// New creates a new Elector.
+func New(c *Config) (*Elector, error) {
+	if c.LeaseStore == nil {
+		return nil, errors.New("lease store is required")
+	}
+	if c.HolderID == "" {
+		return nil, errors.New("holder ID is required")
+	}
+	if c.Logger == nil {
+		return nil, errors.New("logger is required")
+	}
+	if c.LeaseName == "" {
+		c.LeaseName = DefaultLeaseName
+	}
+	if c.LeaseTTL == 0 {
+		c.LeaseTTL = DefaultLeaseTTL
+	}
+	if c.RenewInterval == 0 {
+		c.RenewInterval = DefaultRenewInterval
+	}
+	if c.RenewInterval >= c.LeaseTTL {
+		return nil, fmt.Errorf("renew interval %s must be shorter than the lease TTL %s", c.RenewInterval, c.LeaseTTL)
+	}
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+
+	return &Elector{c: c}, nil
+}
+
+// Run tries to acquire or renew the lease until the context is done, then releases it.
+func (e *Elector) Run(ctx context.Context) error {
+	e.c.Logger.Info("Leader Elector started")
+
+	ticker := time.NewTicker(e.c.RenewInterval)
+	defer ticker.Stop()
+
+	e.acquireLease(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			e.acquireLease(ctx)
+		case <-ctx.Done():
+			e.releaseLease()
+			e.c.Logger.Info("Leader Elector stopped")
+			return nil
+		}
+	}
+}
+
+// IsLeader returns true if the replica holds an unexpired lease.
+func (e *Elector) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.c.Clock.Now().Before(e.expiresAt)
+}
+
+func (e *Elector) acquireLease(ctx context.Context) {
+	now := e.c.Clock.Now().UTC()
+	lease, err := e.c.LeaseStore.AcquireServerLease(ctx, &entity.ServerLease{
+		Name:      e.c.LeaseName,
+		HolderID:  e.c.HolderID,
+		ExpiresAt: now.Add(e.c.LeaseTTL),
+	}, now)
+	if err != nil {
+		// the lease held so far remains valid until it expires
+		e.c.Logger.WithError(err).Error("Failed to acquire leader lease")
+		return
+	}
+
+	wasLeader := e.IsLeader()
+
+	e.mu.Lock()
+	if lease != nil {
+		e.expiresAt = lease.ExpiresAt
+	} else {
+		e.expiresAt = time.Time{}
+	}
+	e.mu.Unlock()
+
+	switch {
+	case lease != nil && !wasLeader:
+		e.c.Logger.Info("Acquired leader lease, this replica runs the background work")
+	case lease == nil && wasLeader:
+		e.c.Logger.Warn("Lost leader lease")
+	}
+}
+
+func (e *Elector) releaseLease() {
+	if !e.IsLeader() {
+		return
+	}
+
+	e.mu.Lock()
+	e.expiresAt = time.Time{}
+	e.mu.Unlock()
+
+	// the context of the elector is done, use a new one to release the lease
+	ctx, cancel := context.WithTimeout(context.Background(), leaseReleaseTimeout)
+	defer cancel()
+
+	if err := e.c.LeaseStore.DeleteServerLease(ctx, e.c.LeaseName, e.c.HolderID); err != nil {
+		e.c.Logger.WithError(err).Error("Failed to release leader lease")
+		return
+	}
+
+	e.c.Logger.Info("Released leader lease")
+}
@@ -0,0 +1,108 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/galadriel/test/fakes/fakedatastore"
+	"github.com/jmhodges/clock"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestElector(t *testing.T, store LeaseStore, holderID string, clk clock.Clock) *Elector {
+	logger, _ := test.NewNullLogger()
+	elector, err := New(&Config{
+		LeaseStore: store,
+		HolderID:   holderID,
+		Clock:      clk,
+		Logger:     logger,
+	})
+	require.NoError(t, err)
+
+	return elector
+}
+
+func TestNew(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store := fakedatastore.NewFakeDB()
+
+	_, err := New(&Config{HolderID: "server-1", Logger: logger})
+	assert.EqualError(t, err, "lease store is required")
+
+	_, err = New(&Config{LeaseStore: store, Logger: logger})
+	assert.EqualError(t, err, "holder ID is required")
+
+	_, err = New(&Config{LeaseStore: store, HolderID: "server-1"})
+	assert.EqualError(t, err, "logger is required")
+
+	_, err = New(&Config{LeaseStore: store, HolderID: "server-1", Logger: logger, RenewInterval: time.Minute})
+	assert.EqualError(t, err, "renew interval 1m0s must be shorter than the lease TTL 30s")
+
+	elector, err := New(&Config{LeaseStore: store, HolderID: "server-1", Logger: logger})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultLeaseName, elector.c.LeaseName)
+	assert.Equal(t, DefaultLeaseTTL, elector.c.LeaseTTL)
+	assert.Equal(t, DefaultRenewInterval, elector.c.RenewInterval)
+}
+
+func TestElector(t *testing.T) {
+	ctx := context.Background()
+	store := fakedatastore.NewFakeDB()
+	clk := clock.NewFake()
+	clk.Set(time.Now())
+
+	elector1 := newTestElector(t, store, "server-1", clk)
+	elector2 := newTestElector(t, store, "server-2", clk)
+	assert.False(t, elector1.IsLeader())
+
+	elector1.acquireLease(ctx)
+	elector2.acquireLease(ctx)
+	assert.True(t, elector1.IsLeader())
+	assert.False(t, elector2.IsLeader())
+
+	// the leader remains the leader until its lease expires if it cannot renew it
+	store.AppendNextError(errors.New("connection refused"))
+	elector1.acquireLease(ctx)
+	assert.True(t, elector1.IsLeader())
+
+	// another replica takes over once the lease has expired
+	clk.Add(DefaultLeaseTTL + time.Second)
+	assert.False(t, elector1.IsLeader())
+	elector2.acquireLease(ctx)
+	elector1.acquireLease(ctx)
+	assert.True(t, elector2.IsLeader())
+	assert.False(t, elector1.IsLeader())
+
+	// a replica takes over as soon as the leader releases the lease
+	elector2.releaseLease()
+	assert.False(t, elector2.IsLeader())
+	elector1.acquireLease(ctx)
+	assert.True(t, elector1.IsLeader())
+}
+
+func TestElectorRun(t *testing.T) {
+	store := fakedatastore.NewFakeDB()
+	elector := newTestElector(t, store, "server-1", clock.New())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- elector.Run(ctx)
+	}()
+
+	// the lease is acquired as soon as the elector starts
+	require.Eventually(t, elector.IsLeader, time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-errCh)
+	assert.False(t, elector.IsLeader())
+
+	// the lease has been released, so another replica acquires it right away
+	other := newTestElector(t, store, "server-2", clock.New())
+	other.acquireLease(context.Background())
+	assert.True(t, other.IsLeader())
+}
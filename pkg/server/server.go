@@ -5,17 +5,33 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/HewlettPackard/galadriel/pkg/common/constants"
-	"github.com/HewlettPackard/galadriel/pkg/common/cryptoutil"
+	"github.com/HewlettPackard/galadriel/pkg/common/health"
 	"github.com/HewlettPackard/galadriel/pkg/common/jwt"
 	"github.com/HewlettPackard/galadriel/pkg/common/keymanager"
 	"github.com/HewlettPackard/galadriel/pkg/common/telemetry"
 	"github.com/HewlettPackard/galadriel/pkg/common/util"
+	"github.com/HewlettPackard/galadriel/pkg/server/audit"
 	"github.com/HewlettPackard/galadriel/pkg/server/catalog"
+	"github.com/HewlettPackard/galadriel/pkg/server/changelog"
 	"github.com/HewlettPackard/galadriel/pkg/server/endpoints"
-	"github.com/google/uuid"
+	"github.com/HewlettPackard/galadriel/pkg/server/jointoken"
+	"github.com/HewlettPackard/galadriel/pkg/server/jwtkey"
+	"github.com/HewlettPackard/galadriel/pkg/server/leader"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+)
+
+const (
+	// serviceName names the service in the exported spans.
+	serviceName = "galadriel-server"
+
+	// names of the readiness checks
+	datastoreCheck      = "datastore"
+	jwtKeyCheck         = "jwt_key"
+	tlsCertificateCheck = "tls_certificate"
 )
 
 // Server represents a Galadriel Server.
@@ -29,6 +45,50 @@ type Config struct {
 	LocalAddress    net.Addr
 	Logger          logrus.FieldLogger
 	ProvidersConfig *catalog.ProvidersConfig
+
+	// JWTKeyRotationInterval is the interval after which the JWT signing key is rotated.
+	JWTKeyRotationInterval time.Duration
+
+	// BundleEndpoint is the configuration of the SPIFFE Federation bundle endpoint, nil if it is disabled.
+	BundleEndpoint *endpoints.BundleEndpointConfig
+
+	// HighAvailability is the configuration of the replica when several Galadriel Server replicas
+	// share the datastore, nil if the server runs as a single replica.
+	HighAvailability *HighAvailabilityConfig
+
+	// Metrics is the configuration of the metrics listener, nil if it is disabled.
+	Metrics *telemetry.MetricsConfig
+
+	// Tracing is the configuration of the export of the spans, nil if the tracing is disabled.
+	Tracing *telemetry.TracingConfig
+
+	// HealthChecks is the configuration of the health checks listener, nil if it is disabled.
+	// The health checks are served on the UDS regardless.
+	HealthChecks *health.Config
+
+	// Audit is the configuration of the export of the audit events, nil if it is disabled.
+	// The audit events are recorded in the datastore regardless.
+	Audit *AuditConfig
+
+	// BundleContinuity rejects the bundles uploaded by the Harvesters that keep no X.509 authority of the previous
+	// bundle of their trust domain and are not signed by a key chaining to it, unless an admin approved a reset.
+	BundleContinuity bool
+
+	// OnboardSigners are the SPIFFE IDs, besides the SPIRE Server of each trust domain, whose X509-SVIDs can
+	// prove the control of their trust domain to onboard a Harvester without a join token.
+	OnboardSigners []spiffeid.ID
+}
+
+// HighAvailabilityConfig conveys the configuration of a Galadriel Server replica.
+type HighAvailabilityConfig struct {
+	// InstanceID identifies the replica, it must be unique across the replicas.
+	InstanceID string
+}
+
+// AuditConfig conveys the configuration of the export of the audit events.
+type AuditConfig struct {
+	// FilePath is the path of the JSON-lines file the audit events are appended to.
+	FilePath string
 }
 
 // New creates a new instance of the Galadriel Server.
@@ -39,72 +99,195 @@ func New(config *Config) *Server {
 // Run starts the Galadriel Server, initializing the components and listening for incoming requests.
 // It performs the following steps:
 // 1. Loads catalogs from the providers configuration.
-// 2. Creates a JWT issuer based on the key manager from the catalogs.
+// 2. Loads or generates the JWT signing key using the key manager from the catalogs.
 // 3. Sets up a JWT validator.
 // 4. Creates the endpoints server, which handles incoming requests.
-// 5. Starts the endpoints server and listens for requests until the context is canceled.
+// 5. Starts the endpoints server, the JWT key rotation, the change log pruning, the metrics and health checks
+// listeners if they are enabled and, in high availability mode, the leader election until the context is canceled.
+// The readiness checks cover the datastore connectivity, the JWT signing key and the TLS certificate.
+// The administrative and Harvester actions are recorded in the audit log of the datastore.
 func (s *Server) Run(ctx context.Context) error {
 	s.config.Logger.Info("Starting Galadriel Server")
 
+	if s.config.Tracing != nil {
+		shutdownTracing, err := telemetry.InitTracing(ctx, s.config.Tracing, serviceName)
+		if err != nil {
+			return fmt.Errorf("failed to initialize tracing: %w", err)
+		}
+		defer telemetry.ShutdownTracing(shutdownTracing, s.config.Logger)
+	}
 	cat := catalog.New(s.config.Logger)
 	err := cat.LoadFromProvidersConfig(s.config.ProvidersConfig)
 	if err != nil {
 		return fmt.Errorf("failed to load catalogs from providers config: %w", err)
 	}
 
-	jwtIssuer, err := s.createJWTIssuer(ctx, cat.GetKeyManager())
+	tasks := []func(context.Context) error{}
+
+	healthChecker := health.NewChecker()
+	healthChecker.AddCheck(datastoreCheck, cat.GetDatastore().Ping)
+
+	// the background tasks run on every replica, unless the replicas elect a leader
+	var replicaLeader leader.Leader
+	if s.config.HighAvailability != nil {
+		elector, err := s.createLeaderElector(cat)
+		if err != nil {
+			return fmt.Errorf("failed to create leader elector: %w", err)
+		}
+		tasks = append(tasks, elector.Run)
+		replicaLeader = elector
+	}
+
+	jwtKeyManager, err := s.createJWTKeyManager(ctx, cat.GetKeyManager(), replicaLeader)
 	if err != nil {
-		return fmt.Errorf("failed to create JWT issuer: %w", err)
+		return fmt.Errorf("failed to create JWT key manager: %w", err)
 	}
 
+	healthChecker.AddCheck(jwtKeyCheck, jwtKeyManager.CheckReady)
+
+	auditor, err := s.createAuditRecorder(cat)
+	if err != nil {
+		return fmt.Errorf("failed to create audit recorder: %w", err)
+	}
+	defer func() {
+		if err := auditor.Close(); err != nil {
+			s.config.Logger.WithError(err).Error("Failed to close audit file")
+		}
+	}()
+
 	c := &jwt.ValidatorConfig{
 		KeyManager:       cat.GetKeyManager(),
+		KeyStatus:        jwtKeyManager,
 		ExpectedAudience: []string{constants.GaladrielServerName},
 	}
 	jwtValidator := jwt.NewDefaultJWTValidator(c)
 
-	endpointsServer, err := s.newEndpointsServer(cat, jwtIssuer, jwtValidator)
+	endpointsServer, err := s.newEndpointsServer(cat, jwtKeyManager, jwtValidator, healthChecker, auditor)
 	if err != nil {
 		return fmt.Errorf("failed to create endpoints server: %w", err)
 	}
+	healthChecker.AddCheck(tlsCertificateCheck, endpointsServer.CheckTLSCertificate)
+
+	changeLogPruner, err := s.createChangeLogPruner(cat, replicaLeader)
+	if err != nil {
+		return fmt.Errorf("failed to create change log pruner: %w", err)
+	}
+
+	joinTokenPurger, err := s.createJoinTokenPurger(cat, replicaLeader)
+	if err != nil {
+		return fmt.Errorf("failed to create join token purger: %w", err)
+	}
 
-	err = util.RunTasks(ctx, endpointsServer.ListenAndServe)
+	tasks = append(tasks, endpointsServer.ListenAndServe, jwtKeyManager.Run, changeLogPruner.Run, joinTokenPurger.Run)
+	if s.config.Metrics != nil {
+		tasks = append(tasks, s.serveMetrics)
+	}
+	if s.config.HealthChecks != nil {
+		tasks = append(tasks, func(ctx context.Context) error {
+			return health.Serve(ctx, s.config.HealthChecks, healthChecker, s.config.Logger.WithField(telemetry.SubsystemName, telemetry.HealthChecks))
+		})
+	}
+
+	err = util.RunTasks(ctx, tasks...)
 	if errors.Is(err, context.Canceled) {
 		err = nil
 	}
 	return err
 }
 
-func (s *Server) newEndpointsServer(catalog catalog.Catalog, jwtIssuer jwt.Issuer, jwtValidator jwt.Validator) (endpoints.Server, error) {
+func (s *Server) newEndpointsServer(catalog catalog.Catalog, jwtKeyManager *jwtkey.Manager, jwtValidator jwt.Validator, healthChecker *health.Checker, auditor *audit.Recorder) (endpoints.Server, error) {
 	config := &endpoints.Config{
 		TCPAddress:   s.config.TCPAddress,
 		LocalAddress: s.config.LocalAddress,
 		Logger:       s.config.Logger.WithField(telemetry.SubsystemName, telemetry.Endpoints),
 		Catalog:      catalog,
-		JWTIssuer:    jwtIssuer,
+		JWTIssuer:    jwtKeyManager,
 		JWTValidator: jwtValidator,
+		JWTKeyStatus: jwtKeyManager,
+
+		BundleEndpoint:   s.config.BundleEndpoint,
+		HealthChecker:    healthChecker,
+		Auditor:          auditor,
+		BundleContinuity: s.config.BundleContinuity,
+		OnboardSigners:   s.config.OnboardSigners,
 	}
 
 	return endpoints.New(config)
 }
 
-func (s *Server) createJWTIssuer(ctx context.Context, keyManager keymanager.KeyManager) (jwt.Issuer, error) {
-	keyID, err := uuid.NewUUID()
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate Key ID: %v", err)
+// createLeaderElector creates the elector of the replica that runs the background work.
+// The replicas must share the JWT signing keys through the datastore KeyManager,
+// so that a token issued by a replica is accepted by the others.
+func (s *Server) createLeaderElector(cat catalog.Catalog) (*leader.Elector, error) {
+	if _, ok := cat.GetKeyManager().(*keymanager.Datastore); !ok {
+		return nil, errors.New(`high availability requires the "datastore" KeyManager`)
 	}
-	key, err := keyManager.GenerateKey(ctx, keyID.String(), cryptoutil.DefaultKeyType)
-	if err != nil {
-		return nil, err
+	if s.config.ProvidersConfig.Datastore.Name == "sqlite3" {
+		s.config.Logger.Warn("The sqlite3 datastore cannot be shared by replicas on different hosts, use postgres for high availability")
 	}
 
-	jwtIssuer, err := jwt.NewJWTCA(&jwt.Config{
-		Signer: key.Signer(),
-		Kid:    key.ID(),
+	s.config.Logger.WithField(telemetry.InstanceID, s.config.HighAvailability.InstanceID).Info("Running in high availability mode")
+
+	return leader.New(&leader.Config{
+		LeaseStore: cat.GetDatastore(),
+		HolderID:   s.config.HighAvailability.InstanceID,
+		Logger:     s.config.Logger.WithField(telemetry.SubsystemName, telemetry.LeaderElector),
 	})
+}
+
+func (s *Server) createJWTKeyManager(ctx context.Context, keyManager keymanager.KeyManager, replicaLeader leader.Leader) (*jwtkey.Manager, error) {
+	config := &jwtkey.Config{
+		KeyManager:       keyManager,
+		RotationInterval: s.config.JWTKeyRotationInterval,
+		TokenTTL:         endpoints.MaxJWTTTL,
+		Leader:           replicaLeader,
+		Logger:           s.config.Logger.WithField(telemetry.SubsystemName, telemetry.JWTKeyManager),
+	}
+
+	jwtKeyManager, err := jwtkey.New(config)
 	if err != nil {
 		return nil, err
 	}
 
-	return jwtIssuer, nil
+	if err := jwtKeyManager.Initialize(ctx); err != nil {
+		return nil, err
+	}
+
+	return jwtKeyManager, nil
+}
+
+func (s *Server) createAuditRecorder(cat catalog.Catalog) (*audit.Recorder, error) {
+	config := &audit.Config{
+		Store:  cat.GetDatastore(),
+		Logger: s.config.Logger.WithField(telemetry.SubsystemName, telemetry.AuditRecorder),
+	}
+	if s.config.Audit != nil {
+		config.FilePath = s.config.Audit.FilePath
+	}
+
+	return audit.NewRecorder(config)
+}
+
+func (s *Server) createChangeLogPruner(cat catalog.Catalog, replicaLeader leader.Leader) (*changelog.Pruner, error) {
+	config := &changelog.Config{
+		Store:  cat.GetDatastore(),
+		Leader: replicaLeader,
+		Logger: s.config.Logger.WithField(telemetry.SubsystemName, telemetry.ChangeLogPruner),
+	}
+
+	return changelog.NewPruner(config)
+}
+
+func (s *Server) createJoinTokenPurger(cat catalog.Catalog, replicaLeader leader.Leader) (*jointoken.Purger, error) {
+	config := &jointoken.Config{
+		Store:  cat.GetDatastore(),
+		Leader: replicaLeader,
+		Logger: s.config.Logger.WithField(telemetry.SubsystemName, telemetry.JoinTokenPurger),
+	}
+
+	return jointoken.NewPurger(config)
+}
+
+func (s *Server) serveMetrics(ctx context.Context) error {
+	return telemetry.ServeMetrics(ctx, s.config.Metrics, s.config.Logger.WithField(telemetry.SubsystemName, telemetry.Metrics))
 }
@@ -1,7 +1,9 @@
 package fakedatastore
 
 import (
+	"bytes"
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -16,10 +18,22 @@ type FakeDatabase struct {
 	errors []error
 
 	// Entities
-	bundles       map[uuid.UUID]*entity.Bundle
-	tokens        map[uuid.UUID]*entity.JoinToken
-	trustDomains  map[uuid.UUID]*entity.TrustDomain
-	relationships map[uuid.UUID]*entity.Relationship
+	bundles        map[uuid.UUID]*entity.Bundle
+	bundleVersions map[uuid.UUID][]*entity.BundleVersion
+	leases         map[uuid.UUID]*entity.HarvesterLease
+	resets         map[uuid.UUID]*entity.BundleResetApproval
+	signingKeys    map[string]*entity.SigningKey
+	serverLeases   map[string]*entity.ServerLease
+	tokens         map[uuid.UUID]*entity.JoinToken
+	trustDomains   map[uuid.UUID]*entity.TrustDomain
+	relationships  map[uuid.UUID]*entity.Relationship
+	consents       map[uuid.UUID][]*entity.RelationshipConsent
+	challenges     map[string]*entity.OnboardChallenge
+
+	changeEvents      []*entity.ChangeEvent
+	lastChangeEventID int64
+
+	auditEvents []*entity.AuditEvent
 }
 
 func NewFakeDB() *FakeDatabase {
@@ -27,10 +41,17 @@ func NewFakeDB() *FakeDatabase {
 		errors: []error{},
 		mutex:  sync.Mutex{},
 
-		bundles:       make(map[uuid.UUID]*entity.Bundle),
-		tokens:        make(map[uuid.UUID]*entity.JoinToken),
-		trustDomains:  make(map[uuid.UUID]*entity.TrustDomain),
-		relationships: make(map[uuid.UUID]*entity.Relationship),
+		bundles:        make(map[uuid.UUID]*entity.Bundle),
+		bundleVersions: make(map[uuid.UUID][]*entity.BundleVersion),
+		leases:         make(map[uuid.UUID]*entity.HarvesterLease),
+		resets:         make(map[uuid.UUID]*entity.BundleResetApproval),
+		signingKeys:    make(map[string]*entity.SigningKey),
+		serverLeases:   make(map[string]*entity.ServerLease),
+		tokens:         make(map[uuid.UUID]*entity.JoinToken),
+		trustDomains:   make(map[uuid.UUID]*entity.TrustDomain),
+		relationships:  make(map[uuid.UUID]*entity.Relationship),
+		consents:       make(map[uuid.UUID][]*entity.RelationshipConsent),
+		challenges:     make(map[string]*entity.OnboardChallenge),
 	}
 }
 
@@ -67,14 +88,24 @@ func (db *FakeDatabase) WithBundles(bundles ...*entity.Bundle) {
 	}
 }
 
-// WithTokens overrides all tokens
+// WithTokens overrides all tokens. The plaintext tokens are stored hashed, and the tokens can be used once
+// unless MaxUses is set, as the datastores store them.
 func (db *FakeDatabase) WithTokens(bundles ...*entity.JoinToken) {
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
 
 	db.tokens = make(map[uuid.UUID]*entity.JoinToken)
 	for _, jt := range bundles {
-		db.tokens[jt.ID.UUID] = jt
+		stored := *jt
+		if stored.Token != "" {
+			if err := stored.HashToken(); err != nil {
+				panic(err)
+			}
+		}
+		if stored.MaxUses == 0 {
+			stored.MaxUses = 1
+		}
+		db.tokens[stored.ID.UUID] = &stored
 	}
 }
 
@@ -140,6 +171,7 @@ func (db *FakeDatabase) DeleteTrustDomain(ctx context.Context, trustDomainID uui
 		}
 	}
 
+	db.recordTrustDomainChange(entity.ChangeEventKindTrustDomain, uuid)
 	delete(db.trustDomains, uuid)
 
 	return nil
@@ -213,6 +245,7 @@ func (db *FakeDatabase) CreateOrUpdateBundle(ctx context.Context, req *entity.Bu
 
 	req.UpdatedAt = time.Now()
 	db.bundles[req.ID.UUID] = req
+	db.recordTrustDomainChange(entity.ChangeEventKindBundle, req.TrustDomainID)
 
 	return req, nil
 }
@@ -279,6 +312,7 @@ func (db *FakeDatabase) DeleteBundle(ctx context.Context, bundleID uuid.UUID) er
 	for idx, b := range db.bundles {
 		if bundleID.String() == b.ID.UUID.String() {
 			uuid = idx
+			db.recordTrustDomainChange(entity.ChangeEventKindBundle, b.TrustDomainID)
 		}
 	}
 
@@ -287,7 +321,7 @@ func (db *FakeDatabase) DeleteBundle(ctx context.Context, bundleID uuid.UUID) er
 	return nil
 }
 
-func (db *FakeDatabase) CreateJoinToken(ctx context.Context, req *entity.JoinToken) (*entity.JoinToken, error) {
+func (db *FakeDatabase) CreateBundleVersion(ctx context.Context, req *entity.BundleVersion) (*entity.BundleVersion, error) {
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
 
@@ -295,21 +329,324 @@ func (db *FakeDatabase) CreateJoinToken(ctx context.Context, req *entity.JoinTok
 		return nil, err
 	}
 
+	versions := db.bundleVersions[req.TrustDomainID]
 	req.ID = uuid.NullUUID{
 		UUID:  uuid.New(),
 		Valid: true,
 	}
-
-	req.Used = false
+	req.Version = int64(len(versions) + 1)
 	req.CreatedAt = time.Now()
-	req.UpdatedAt = time.Now()
-	req.ExpiresAt = time.Now().Add(1 * time.Hour)
-
-	db.tokens[req.ID.UUID] = req
+	db.bundleVersions[req.TrustDomainID] = append(versions, req)
 
 	return req, nil
 }
 
+func (db *FakeDatabase) FindBundleVersion(ctx context.Context, trustDomainID uuid.UUID, version int64) (*entity.BundleVersion, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	for _, bv := range db.bundleVersions[trustDomainID] {
+		if bv.Version == version {
+			return bv, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (db *FakeDatabase) FindBundleVersionByDigest(ctx context.Context, trustDomainID uuid.UUID, digest []byte) (*entity.BundleVersion, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	versions := db.bundleVersions[trustDomainID]
+	for i := len(versions) - 1; i >= 0; i-- {
+		if bytes.Equal(versions[i].Digest, digest) {
+			return versions[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (db *FakeDatabase) ListBundleVersions(ctx context.Context, trustDomainID uuid.UUID) ([]*entity.BundleVersion, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	versions := db.bundleVersions[trustDomainID]
+	result := make([]*entity.BundleVersion, 0, len(versions))
+	for i := len(versions) - 1; i >= 0; i-- {
+		result = append(result, versions[i])
+	}
+
+	return result, nil
+}
+
+func (db *FakeDatabase) AcquireHarvesterLease(ctx context.Context, req *entity.HarvesterLease, now time.Time) (*entity.HarvesterLease, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	lease, ok := db.leases[req.TrustDomainID]
+	if !ok {
+		lease = &entity.HarvesterLease{
+			TrustDomainID: req.TrustDomainID,
+			CreatedAt:     now,
+		}
+		db.leases[req.TrustDomainID] = lease
+	} else if lease.InstanceID != req.InstanceID && !lease.ExpiresAt.Before(now) {
+		return nil, nil
+	}
+
+	lease.InstanceID = req.InstanceID
+	lease.ExpiresAt = req.ExpiresAt
+	lease.UpdatedAt = now
+
+	response := *lease
+	return &response, nil
+}
+
+func (db *FakeDatabase) FindHarvesterLease(ctx context.Context, trustDomainID uuid.UUID) (*entity.HarvesterLease, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	lease, ok := db.leases[trustDomainID]
+	if !ok {
+		return nil, nil
+	}
+
+	response := *lease
+	return &response, nil
+}
+
+func (db *FakeDatabase) DeleteHarvesterLease(ctx context.Context, trustDomainID uuid.UUID, instanceID string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return err
+	}
+
+	if lease, ok := db.leases[trustDomainID]; ok && lease.InstanceID == instanceID {
+		delete(db.leases, trustDomainID)
+	}
+
+	return nil
+}
+
+func (db *FakeDatabase) CreateOrUpdateBundleResetApproval(ctx context.Context, req *entity.BundleResetApproval) (*entity.BundleResetApproval, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	approval := &entity.BundleResetApproval{
+		TrustDomainID: req.TrustDomainID,
+		CreatedAt:     time.Now(),
+	}
+	db.resets[req.TrustDomainID] = approval
+
+	response := *approval
+	return &response, nil
+}
+
+func (db *FakeDatabase) FindBundleResetApproval(ctx context.Context, trustDomainID uuid.UUID) (*entity.BundleResetApproval, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	approval, ok := db.resets[trustDomainID]
+	if !ok {
+		return nil, nil
+	}
+
+	response := *approval
+	return &response, nil
+}
+
+func (db *FakeDatabase) DeleteBundleResetApproval(ctx context.Context, trustDomainID uuid.UUID) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return err
+	}
+
+	delete(db.resets, trustDomainID)
+
+	return nil
+}
+
+func (db *FakeDatabase) CreateOrUpdateSigningKey(ctx context.Context, req *entity.SigningKey) (*entity.SigningKey, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	signingKey := *req
+	if signingKey.CreatedAt.IsZero() {
+		signingKey.CreatedAt = now
+	}
+	if existing, ok := db.signingKeys[req.ID]; ok {
+		signingKey.CreatedAt = existing.CreatedAt
+	}
+	signingKey.UpdatedAt = now
+	db.signingKeys[req.ID] = &signingKey
+
+	response := signingKey
+	return &response, nil
+}
+
+func (db *FakeDatabase) FindSigningKeyByID(ctx context.Context, id string) (*entity.SigningKey, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	signingKey, ok := db.signingKeys[id]
+	if !ok {
+		return nil, nil
+	}
+
+	response := *signingKey
+	return &response, nil
+}
+
+func (db *FakeDatabase) DeleteSigningKey(ctx context.Context, id string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return err
+	}
+
+	delete(db.signingKeys, id)
+
+	return nil
+}
+
+func (db *FakeDatabase) ListSigningKeys(ctx context.Context) ([]*entity.SigningKey, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	var signingKeys []*entity.SigningKey
+	for _, signingKey := range db.signingKeys {
+		response := *signingKey
+		signingKeys = append(signingKeys, &response)
+	}
+	sort.Slice(signingKeys, func(i, j int) bool {
+		return signingKeys[i].CreatedAt.Before(signingKeys[j].CreatedAt)
+	})
+
+	return signingKeys, nil
+}
+
+func (db *FakeDatabase) AcquireServerLease(ctx context.Context, req *entity.ServerLease, now time.Time) (*entity.ServerLease, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	lease, ok := db.serverLeases[req.Name]
+	if !ok {
+		lease = &entity.ServerLease{
+			Name:      req.Name,
+			CreatedAt: now,
+		}
+		db.serverLeases[req.Name] = lease
+	} else if lease.HolderID != req.HolderID && !lease.ExpiresAt.Before(now) {
+		return nil, nil
+	}
+
+	lease.HolderID = req.HolderID
+	lease.ExpiresAt = req.ExpiresAt
+	lease.UpdatedAt = now
+
+	response := *lease
+	return &response, nil
+}
+
+func (db *FakeDatabase) DeleteServerLease(ctx context.Context, name, holderID string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return err
+	}
+
+	if lease, ok := db.serverLeases[name]; ok && lease.HolderID == holderID {
+		delete(db.serverLeases, name)
+	}
+
+	return nil
+}
+
+func (db *FakeDatabase) CreateJoinToken(ctx context.Context, req *entity.JoinToken) (*entity.JoinToken, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	stored := *req
+	if err := stored.HashToken(); err != nil {
+		return nil, err
+	}
+
+	stored.ID = uuid.NullUUID{
+		UUID:  uuid.New(),
+		Valid: true,
+	}
+
+	stored.Used = false
+	stored.UseCount = 0
+	if stored.MaxUses == 0 {
+		stored.MaxUses = 1
+	}
+	stored.CreatedAt = time.Now()
+	stored.UpdatedAt = time.Now()
+	stored.ExpiresAt = time.Now().Add(1 * time.Hour)
+
+	db.tokens[stored.ID.UUID] = &stored
+
+	return &stored, nil
+}
+
 func (db *FakeDatabase) FindJoinTokensByID(ctx context.Context, joinTokenID uuid.UUID) (*entity.JoinToken, error) {
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
@@ -380,6 +717,26 @@ func (db *FakeDatabase) UpdateJoinToken(ctx context.Context, joinTokenID uuid.UU
 	return nil, nil
 }
 
+func (db *FakeDatabase) UseJoinToken(ctx context.Context, joinTokenID uuid.UUID) (*entity.JoinToken, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	jt, ok := db.tokens[joinTokenID]
+	if !ok || jt.UseCount >= jt.MaxUses {
+		return nil, nil
+	}
+
+	jt.UseCount++
+	jt.Used = jt.UseCount >= jt.MaxUses
+	jt.UpdatedAt = time.Now()
+
+	return jt, nil
+}
+
 func (db *FakeDatabase) DeleteJoinToken(ctx context.Context, joinTokenID uuid.UUID) error {
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
@@ -400,6 +757,72 @@ func (db *FakeDatabase) DeleteJoinToken(ctx context.Context, joinTokenID uuid.UU
 	return nil
 }
 
+func (db *FakeDatabase) PruneJoinTokens(ctx context.Context, expiredBefore time.Time) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return err
+	}
+
+	for id, jt := range db.tokens {
+		if jt.ExpiresAt.Before(expiredBefore) {
+			delete(db.tokens, id)
+		}
+	}
+
+	return nil
+}
+
+func (db *FakeDatabase) CreateOnboardChallenge(ctx context.Context, req *entity.OnboardChallenge) (*entity.OnboardChallenge, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	challenge := *req
+	challenge.CreatedAt = time.Now().UTC()
+	db.challenges[challenge.Nonce] = &challenge
+
+	return &challenge, nil
+}
+
+func (db *FakeDatabase) UseOnboardChallenge(ctx context.Context, nonce string, trustDomainID uuid.UUID, now time.Time) (*entity.OnboardChallenge, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	challenge, ok := db.challenges[nonce]
+	if !ok || challenge.TrustDomainID != trustDomainID || !challenge.ExpiresAt.After(now) {
+		return nil, nil
+	}
+	delete(db.challenges, nonce)
+
+	return challenge, nil
+}
+
+func (db *FakeDatabase) PruneOnboardChallenges(ctx context.Context, expiredBefore time.Time) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return err
+	}
+
+	for nonce, challenge := range db.challenges {
+		if challenge.ExpiresAt.Before(expiredBefore) {
+			delete(db.challenges, nonce)
+		}
+	}
+
+	return nil
+}
+
 func (db *FakeDatabase) FindJoinToken(ctx context.Context, token string) (*entity.JoinToken, error) {
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
@@ -409,7 +832,7 @@ func (db *FakeDatabase) FindJoinToken(ctx context.Context, token string) (*entit
 	}
 
 	for _, jt := range db.tokens {
-		if token == jt.Token {
+		if jt.MatchesToken(token) {
 			return jt, nil
 		}
 	}
@@ -435,6 +858,7 @@ func (db *FakeDatabase) CreateOrUpdateRelationship(ctx context.Context, req *ent
 
 	req.UpdatedAt = time.Now()
 	db.relationships[req.ID.UUID] = req
+	db.recordRelationshipChange(req)
 
 	return req, nil
 }
@@ -534,10 +958,250 @@ func (db *FakeDatabase) DeleteRelationship(ctx context.Context, relationshipID u
 	for idx, r := range db.relationships {
 		if r.ID.UUID.String() == relationshipID.String() {
 			uuid = idx
+			db.recordRelationshipChange(r)
 		}
 	}
 
 	delete(db.relationships, uuid)
+	delete(db.consents, uuid)
+
+	return nil
+}
+
+func (db *FakeDatabase) CreateOrUpdateRelationshipConsent(ctx context.Context, req *entity.RelationshipConsent) (*entity.RelationshipConsent, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	return db.storeConsent(req), nil
+}
+
+func (db *FakeDatabase) FindRelationshipConsentsByRelationshipID(ctx context.Context, relationshipID uuid.UUID) ([]*entity.RelationshipConsent, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	consents := make([]*entity.RelationshipConsent, len(db.consents[relationshipID]))
+	copy(consents, db.consents[relationshipID])
+
+	return consents, nil
+}
+
+func (db *FakeDatabase) DeleteRelationshipConsent(ctx context.Context, relationshipID, trustDomainID uuid.UUID) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return err
+	}
+
+	db.removeConsent(relationshipID, trustDomainID)
+
+	return nil
+}
+
+// UpdateRelationshipConsent stores the relationship and the consent of the trust domain at once, when it fails
+// nothing is stored, like in the transaction of the real datastores.
+func (db *FakeDatabase) UpdateRelationshipConsent(ctx context.Context, req *entity.Relationship, trustDomainID uuid.UUID, consent *entity.RelationshipConsent) (*entity.Relationship, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	req.UpdatedAt = time.Now()
+	db.relationships[req.ID.UUID] = req
+	db.recordRelationshipChange(req)
+
+	if consent != nil {
+		db.storeConsent(consent)
+	} else {
+		db.removeConsent(req.ID.UUID, trustDomainID)
+	}
+
+	return req, nil
+}
+
+func (db *FakeDatabase) storeConsent(req *entity.RelationshipConsent) *entity.RelationshipConsent {
+	now := time.Now().UTC()
+	consent := *req
+	consent.CreatedAt = now
+	consent.UpdatedAt = now
+
+	consents := db.consents[req.RelationshipID]
+	for i, c := range consents {
+		if c.TrustDomainID == req.TrustDomainID {
+			consent.CreatedAt = c.CreatedAt
+			consents[i] = &consent
+			return &consent
+		}
+	}
+	db.consents[req.RelationshipID] = append(consents, &consent)
+
+	return &consent
+}
+
+func (db *FakeDatabase) removeConsent(relationshipID, trustDomainID uuid.UUID) {
+	var consents []*entity.RelationshipConsent
+	for _, c := range db.consents[relationshipID] {
+		if c.TrustDomainID != trustDomainID {
+			consents = append(consents, c)
+		}
+	}
+	db.consents[relationshipID] = consents
+}
+
+func (db *FakeDatabase) ListChangeEvents(ctx context.Context, afterID, untilID int64) ([]*entity.ChangeEvent, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	var events []*entity.ChangeEvent
+	for _, e := range db.changeEvents {
+		if e.ID > afterID && e.ID <= untilID {
+			events = append(events, e)
+		}
+	}
+
+	return events, nil
+}
+
+func (db *FakeDatabase) FindChangeEventRange(ctx context.Context) (int64, int64, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return 0, 0, err
+	}
+
+	if len(db.changeEvents) == 0 {
+		return 0, 0, nil
+	}
+
+	return db.changeEvents[0].ID, db.changeEvents[len(db.changeEvents)-1].ID, nil
+}
+
+func (db *FakeDatabase) PruneChangeEvents(ctx context.Context, before time.Time) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return err
+	}
+
+	var events []*entity.ChangeEvent
+	for i, e := range db.changeEvents {
+		// the latest change is kept
+		if e.CreatedAt.Before(before) && i < len(db.changeEvents)-1 {
+			continue
+		}
+		events = append(events, e)
+	}
+	db.changeEvents = events
 
 	return nil
 }
+
+func (db *FakeDatabase) AppendAuditEvent(ctx context.Context, req *entity.AuditEvent) (*entity.AuditEvent, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	event := *req
+	event.ID = int64(len(db.auditEvents) + 1)
+	event.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	event.PrevHash = entity.GenesisAuditHash
+	if len(db.auditEvents) > 0 {
+		event.PrevHash = db.auditEvents[len(db.auditEvents)-1].Hash
+	}
+	event.Hash = event.ComputeHash()
+	db.auditEvents = append(db.auditEvents, &event)
+
+	return &event, nil
+}
+
+func (db *FakeDatabase) ListAuditEvents(ctx context.Context, criteria *criteria.ListAuditEventsCriteria) ([]*entity.AuditEvent, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.getNextError(); err != nil {
+		return nil, err
+	}
+
+	if criteria == nil {
+		return db.auditEvents, nil
+	}
+
+	var events []*entity.AuditEvent
+	for _, e := range db.auditEvents {
+		if criteria.FilterByActor != "" && e.Actor != criteria.FilterByActor {
+			continue
+		}
+		if !criteria.FilterBySince.IsZero() && e.CreatedAt.Before(criteria.FilterBySince) {
+			continue
+		}
+		if !criteria.FilterByUntil.IsZero() && !e.CreatedAt.Before(criteria.FilterByUntil) {
+			continue
+		}
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+func (db *FakeDatabase) Ping(ctx context.Context) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	return db.getNextError()
+}
+
+// recordTrustDomainChange appends a change of the given trust domain to the change log, if the trust domain exists.
+func (db *FakeDatabase) recordTrustDomainChange(kind entity.ChangeEventKind, trustDomainID uuid.UUID) {
+	td, ok := db.trustDomains[trustDomainID]
+	if !ok {
+		return
+	}
+
+	db.lastChangeEventID++
+	db.changeEvents = append(db.changeEvents, &entity.ChangeEvent{
+		ID:              db.lastChangeEventID,
+		Kind:            kind,
+		TrustDomainID:   td.ID.UUID,
+		TrustDomainName: td.Name,
+		CreatedAt:       time.Now(),
+	})
+}
+
+// recordRelationshipChange appends a change of the given relationship to the change log, if both trust domains exist.
+func (db *FakeDatabase) recordRelationshipChange(r *entity.Relationship) {
+	tdA, okA := db.trustDomains[r.TrustDomainAID]
+	tdB, okB := db.trustDomains[r.TrustDomainBID]
+	if !okA || !okB {
+		return
+	}
+
+	db.lastChangeEventID++
+	db.changeEvents = append(db.changeEvents, &entity.ChangeEvent{
+		ID:                  db.lastChangeEventID,
+		Kind:                entity.ChangeEventKindRelationship,
+		TrustDomainID:       tdA.ID.UUID,
+		TrustDomainName:     tdA.Name,
+		PeerTrustDomainID:   tdB.ID,
+		PeerTrustDomainName: tdB.Name,
+		CreatedAt:           time.Now(),
+	})
+}
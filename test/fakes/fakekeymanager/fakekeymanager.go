@@ -33,3 +33,7 @@ func (f KeyManager) GetKeys(ctx context.Context) ([]keymanager.Key, error) {
 		PublicKey:  f.Key.Public(),
 	}}, nil
 }
+
+func (f KeyManager) DeleteKey(ctx context.Context, id string) error {
+	return nil
+}
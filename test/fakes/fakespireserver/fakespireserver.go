@@ -0,0 +1,415 @@
+package fakespireserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	bundlev1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/bundle/v1"
+	entryv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/entry/v1"
+	trustdomainv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/trustdomain/v1"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Server is an in-process fake SPIRE Server serving the bundle, trustdomain and entry APIs on a Unix Domain Socket.
+type Server struct {
+	bundlev1.UnimplementedBundleServer
+	trustdomainv1.UnimplementedTrustDomainServer
+	entryv1.UnimplementedEntryServer
+
+	// Addr is the address of the socket the server listens on.
+	Addr net.Addr
+
+	mu               sync.Mutex
+	bundle           *types.Bundle
+	federatedBundles map[string]*types.Bundle
+	relationships    map[string]*types.FederationRelationship
+	entries          map[string]*types.Entry
+}
+
+// New starts a fake SPIRE Server that is stopped when the test finishes.
+func New(t *testing.T) *Server {
+	// a short path is used since the length of socket paths is limited
+	dir, err := os.MkdirTemp("", "spire")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	listener, err := net.Listen("unix", filepath.Join(dir, "api.sock"))
+	require.NoError(t, err)
+
+	s := &Server{
+		Addr:             listener.Addr(),
+		federatedBundles: make(map[string]*types.Bundle),
+		relationships:    make(map[string]*types.FederationRelationship),
+		entries:          make(map[string]*types.Entry),
+	}
+
+	grpcServer := grpc.NewServer()
+	bundlev1.RegisterBundleServer(grpcServer, s)
+	trustdomainv1.RegisterTrustDomainServer(grpcServer, s)
+	entryv1.RegisterEntryServer(grpcServer, s)
+
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	return s
+}
+
+// SetBundle sets the bundle of the SPIRE Server trust domain.
+func (s *Server) SetBundle(bundle *types.Bundle) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bundle = bundle
+}
+
+// WithFederatedBundles overrides all federated bundles.
+func (s *Server) WithFederatedBundles(bundles ...*types.Bundle) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.federatedBundles = make(map[string]*types.Bundle)
+	for _, b := range bundles {
+		s.federatedBundles[b.TrustDomain] = b
+	}
+}
+
+// FederatedBundles returns the federated bundles sorted by trust domain.
+func (s *Server) FederatedBundles() []*types.Bundle {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]*types.Bundle, 0, len(s.federatedBundles))
+	for _, td := range sortedKeys(s.federatedBundles) {
+		out = append(out, s.federatedBundles[td])
+	}
+
+	return out
+}
+
+// WithFederationRelationships overrides all federation relationships.
+func (s *Server) WithFederationRelationships(relationships ...*types.FederationRelationship) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.relationships = make(map[string]*types.FederationRelationship)
+	for _, r := range relationships {
+		s.relationships[r.TrustDomain] = r
+	}
+}
+
+// FederationRelationships returns the federation relationships sorted by trust domain.
+func (s *Server) FederationRelationships() []*types.FederationRelationship {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]*types.FederationRelationship, 0, len(s.relationships))
+	for _, td := range sortedKeys(s.relationships) {
+		out = append(out, s.relationships[td])
+	}
+
+	return out
+}
+
+// WithEntries overrides all registration entries.
+func (s *Server) WithEntries(entries ...*types.Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = make(map[string]*types.Entry)
+	for _, e := range entries {
+		s.entries[e.Id] = e
+	}
+}
+
+// Entries returns the registration entries sorted by ID.
+func (s *Server) Entries() []*types.Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]*types.Entry, 0, len(s.entries))
+	for _, id := range sortedKeys(s.entries) {
+		out = append(out, s.entries[id])
+	}
+
+	return out
+}
+
+func (s *Server) GetBundle(ctx context.Context, req *bundlev1.GetBundleRequest) (*types.Bundle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.bundle == nil {
+		return nil, status.Error(codes.NotFound, "bundle not found")
+	}
+
+	return s.bundle, nil
+}
+
+func (s *Server) ListFederatedBundles(ctx context.Context, req *bundlev1.ListFederatedBundlesRequest) (*bundlev1.ListFederatedBundlesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	trustDomains, nextPageToken, err := paginate(sortedKeys(s.federatedBundles), req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &bundlev1.ListFederatedBundlesResponse{NextPageToken: nextPageToken}
+	for _, td := range trustDomains {
+		resp.Bundles = append(resp.Bundles, s.federatedBundles[td])
+	}
+
+	return resp, nil
+}
+
+func (s *Server) BatchSetFederatedBundle(ctx context.Context, req *bundlev1.BatchSetFederatedBundleRequest) (*bundlev1.BatchSetFederatedBundleResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &bundlev1.BatchSetFederatedBundleResponse{}
+	for _, b := range req.Bundle {
+		s.federatedBundles[b.TrustDomain] = b
+		resp.Results = append(resp.Results, &bundlev1.BatchSetFederatedBundleResponse_Result{
+			Status: okStatus(),
+			Bundle: b,
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *Server) BatchDeleteFederatedBundle(ctx context.Context, req *bundlev1.BatchDeleteFederatedBundleRequest) (*bundlev1.BatchDeleteFederatedBundleResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &bundlev1.BatchDeleteFederatedBundleResponse{}
+	for _, td := range req.TrustDomains {
+		result := &bundlev1.BatchDeleteFederatedBundleResponse_Result{TrustDomain: td, Status: okStatus()}
+		if _, ok := s.federatedBundles[td]; !ok {
+			result.Status = &types.Status{Code: int32(codes.NotFound), Message: "no such bundle"}
+		}
+		delete(s.federatedBundles, td)
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+func (s *Server) ListFederationRelationships(ctx context.Context, req *trustdomainv1.ListFederationRelationshipsRequest) (*trustdomainv1.ListFederationRelationshipsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	trustDomains, nextPageToken, err := paginate(sortedKeys(s.relationships), req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &trustdomainv1.ListFederationRelationshipsResponse{NextPageToken: nextPageToken}
+	for _, td := range trustDomains {
+		resp.FederationRelationships = append(resp.FederationRelationships, s.relationships[td])
+	}
+
+	return resp, nil
+}
+
+func (s *Server) GetFederationRelationship(ctx context.Context, req *trustdomainv1.GetFederationRelationshipRequest) (*types.FederationRelationship, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.relationships[req.TrustDomain]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "federation relationship does not exist")
+	}
+
+	return r, nil
+}
+
+func (s *Server) BatchCreateFederationRelationship(ctx context.Context, req *trustdomainv1.BatchCreateFederationRelationshipRequest) (*trustdomainv1.BatchCreateFederationRelationshipResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &trustdomainv1.BatchCreateFederationRelationshipResponse{}
+	for _, r := range req.FederationRelationships {
+		result := &trustdomainv1.BatchCreateFederationRelationshipResponse_Result{}
+		if _, ok := s.relationships[r.TrustDomain]; ok {
+			result.Status = &types.Status{Code: int32(codes.AlreadyExists), Message: "federation relationship already exists"}
+		} else {
+			s.relationships[r.TrustDomain] = r
+			if r.TrustDomainBundle != nil {
+				s.federatedBundles[r.TrustDomain] = r.TrustDomainBundle
+			}
+			result.Status = okStatus()
+			result.FederationRelationship = r
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+func (s *Server) BatchUpdateFederationRelationship(ctx context.Context, req *trustdomainv1.BatchUpdateFederationRelationshipRequest) (*trustdomainv1.BatchUpdateFederationRelationshipResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mask := req.InputMask
+	if mask == nil {
+		mask = &types.FederationRelationshipMask{BundleEndpointUrl: true, BundleEndpointProfile: true, TrustDomainBundle: true}
+	}
+
+	resp := &trustdomainv1.BatchUpdateFederationRelationshipResponse{}
+	for _, r := range req.FederationRelationships {
+		result := &trustdomainv1.BatchUpdateFederationRelationshipResponse_Result{}
+		stored, ok := s.relationships[r.TrustDomain]
+		if !ok {
+			result.Status = &types.Status{Code: int32(codes.NotFound), Message: "federation relationship does not exist"}
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+
+		updated := proto.Clone(stored).(*types.FederationRelationship)
+		if mask.BundleEndpointUrl {
+			updated.BundleEndpointUrl = r.BundleEndpointUrl
+		}
+		if mask.BundleEndpointProfile {
+			updated.BundleEndpointProfile = r.BundleEndpointProfile
+		}
+		if mask.TrustDomainBundle {
+			updated.TrustDomainBundle = r.TrustDomainBundle
+		}
+		s.relationships[r.TrustDomain] = updated
+
+		result.Status = okStatus()
+		result.FederationRelationship = updated
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+func (s *Server) BatchDeleteFederationRelationship(ctx context.Context, req *trustdomainv1.BatchDeleteFederationRelationshipRequest) (*trustdomainv1.BatchDeleteFederationRelationshipResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &trustdomainv1.BatchDeleteFederationRelationshipResponse{}
+	for _, td := range req.TrustDomains {
+		result := &trustdomainv1.BatchDeleteFederationRelationshipResponse_Result{TrustDomain: td, Status: okStatus()}
+		if _, ok := s.relationships[td]; !ok {
+			result.Status = &types.Status{Code: int32(codes.NotFound), Message: "federation relationship does not exist"}
+		}
+		delete(s.relationships, td)
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+func (s *Server) ListEntries(ctx context.Context, req *entryv1.ListEntriesRequest) (*entryv1.ListEntriesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids, nextPageToken, err := paginate(sortedKeys(s.entries), req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &entryv1.ListEntriesResponse{NextPageToken: nextPageToken}
+	for _, id := range ids {
+		resp.Entries = append(resp.Entries, s.entries[id])
+	}
+
+	return resp, nil
+}
+
+func (s *Server) BatchUpdateEntry(ctx context.Context, req *entryv1.BatchUpdateEntryRequest) (*entryv1.BatchUpdateEntryResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &entryv1.BatchUpdateEntryResponse{}
+	for _, e := range req.Entries {
+		result := &entryv1.BatchUpdateEntryResponse_Result{}
+		stored, ok := s.entries[e.Id]
+		if !ok {
+			result.Status = &types.Status{Code: int32(codes.NotFound), Message: "entry not found"}
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+
+		// only the federated trust domains are supported by the fake
+		if req.InputMask != nil && req.InputMask.FederatesWith {
+			if td, ok := s.missingFederatedBundle(e.FederatesWith); ok {
+				result.Status = &types.Status{Code: int32(codes.InvalidArgument), Message: fmt.Sprintf("unable to find federated bundle %q", td)}
+				resp.Results = append(resp.Results, result)
+				continue
+			}
+			updated := proto.Clone(stored).(*types.Entry)
+			updated.FederatesWith = e.FederatesWith
+			updated.RevisionNumber++
+			s.entries[e.Id] = updated
+			stored = updated
+		}
+
+		result.Status = okStatus()
+		result.Entry = stored
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+// missingFederatedBundle returns the first trust domain that has no federated bundle, as SPIRE rejects
+// entries federating with trust domains it has no bundle for.
+func (s *Server) missingFederatedBundle(trustDomains []string) (string, bool) {
+	for _, td := range trustDomains {
+		if _, ok := s.federatedBundles[td]; !ok {
+			return td, true
+		}
+	}
+
+	return "", false
+}
+
+func okStatus() *types.Status {
+	return &types.Status{Code: int32(codes.OK), Message: "OK"}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// paginate returns the page of keys starting at the index in the page token, and the token of the next page.
+func paginate(keys []string, pageSize int32, pageToken string) ([]string, string, error) {
+	start := 0
+	if pageToken != "" {
+		var err error
+		start, err = strconv.Atoi(pageToken)
+		if err != nil || start < 0 || start > len(keys) {
+			return nil, "", status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+
+	if pageSize <= 0 || start+int(pageSize) >= len(keys) {
+		return keys[start:], "", nil
+	}
+
+	end := start + int(pageSize)
+	return keys[start:end], strconv.Itoa(end), nil
+}
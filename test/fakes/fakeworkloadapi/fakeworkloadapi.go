@@ -0,0 +1,106 @@
+package fakeworkloadapi
+
+import (
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Server is an in-process fake SPIFFE Workload API serving X509-SVIDs on a Unix Domain Socket.
+type Server struct {
+	workload.UnimplementedSpiffeWorkloadAPIServer
+
+	// SocketPath is the path of the socket the server listens on.
+	SocketPath string
+
+	mu    sync.Mutex
+	svids []*workload.X509SVID
+}
+
+// New starts a fake Workload API that is stopped when the test finishes.
+func New(t *testing.T) *Server {
+	// a short path is used since the length of socket paths is limited
+	dir, err := os.MkdirTemp("", "workload")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	socketPath := filepath.Join(dir, "api.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	s := &Server{SocketPath: socketPath}
+
+	grpcServer := grpc.NewServer()
+	workload.RegisterSpiffeWorkloadAPIServer(grpcServer, s)
+
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	return s
+}
+
+// SetX509SVIDs sets the X509-SVIDs served to the workloads, along with the X.509 bundle of their trust domain.
+func (s *Server) SetX509SVIDs(t *testing.T, bundle []*x509.Certificate, svids ...*x509svid.SVID) {
+	var bundleDER []byte
+	for _, cert := range bundle {
+		bundleDER = append(bundleDER, cert.Raw...)
+	}
+
+	out := make([]*workload.X509SVID, 0, len(svids))
+	for _, svid := range svids {
+		var chainDER []byte
+		for _, cert := range svid.Certificates {
+			chainDER = append(chainDER, cert.Raw...)
+		}
+
+		keyDER, err := x509.MarshalPKCS8PrivateKey(svid.PrivateKey)
+		require.NoError(t, err)
+
+		out = append(out, &workload.X509SVID{
+			SpiffeId:    svid.ID.String(),
+			X509Svid:    chainDER,
+			X509SvidKey: keyDER,
+			Bundle:      bundleDER,
+		})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.svids = out
+}
+
+// FetchX509SVID sends the X509-SVIDs and keeps the stream open until the client closes it.
+func (s *Server) FetchX509SVID(_ *workload.X509SVIDRequest, stream workload.SpiffeWorkloadAPI_FetchX509SVIDServer) error {
+	md, ok := metadata.FromIncomingContext(stream.Context())
+	if !ok || len(md.Get("workload.spiffe.io")) != 1 || md.Get("workload.spiffe.io")[0] != "true" {
+		return status.Error(codes.InvalidArgument, "security header missing from request")
+	}
+
+	s.mu.Lock()
+	svids := s.svids
+	s.mu.Unlock()
+
+	if len(svids) == 0 {
+		return status.Error(codes.PermissionDenied, "no identity issued")
+	}
+
+	if err := stream.Send(&workload.X509SVIDResponse{Svids: svids}); err != nil {
+		return err
+	}
+
+	<-stream.Context().Done()
+	return nil
+}